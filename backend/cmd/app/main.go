@@ -8,9 +8,12 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/service"
 	"github.com/Snowitty-Re/CNtunyuan/internal/config"
 	"github.com/Snowitty-Re/CNtunyuan/internal/di"
 	"github.com/Snowitty-Re/CNtunyuan/internal/infrastructure/database"
+	"github.com/Snowitty-Re/CNtunyuan/internal/infrastructure/repository"
+	"github.com/Snowitty-Re/CNtunyuan/internal/infrastructure/search"
 	"github.com/Snowitty-Re/CNtunyuan/pkg/logger"
 )
 
@@ -58,6 +61,28 @@ func main() {
 				os.Exit(1)
 			}
 			return
+		case "-backfill-geohash":
+			// 为历史案件和线索补齐 geohash 列（无 PostGIS 时空间查询依赖该列）
+			if err := runBackfillGeohash(cfg); err != nil {
+				logger.Error("Geohash backfill failed", logger.Err(err))
+				os.Exit(1)
+			}
+			return
+		case "-migrate-dialect-groups":
+			// 将历史方言按自由文本地区归入方言分类（分类为空时先导入默认分类），追加 -dry-run 只预览
+			dryRun := len(os.Args) > 2 && os.Args[2] == "-dry-run"
+			if err := runMigrateDialectGroups(cfg, dryRun); err != nil {
+				logger.Error("Dialect group migration failed", logger.Err(err))
+				os.Exit(1)
+			}
+			return
+		case "-reindex":
+			// 全量重建案件全文检索索引快照（服务运行中请使用 POST /api/v1/search-index/rebuild）
+			if err := runReindex(cfg); err != nil {
+				logger.Error("Search reindex failed", logger.Err(err))
+				os.Exit(1)
+			}
+			return
 		}
 	}
 
@@ -146,6 +171,76 @@ func runSeed(cfg *config.Config) error {
 	return nil
 }
 
+// runBackfillGeohash 回填 geohash 列
+func runBackfillGeohash(cfg *config.Config) error {
+	db, err := database.NewDatabase(&cfg.Database)
+	if err != nil {
+		return fmt.Errorf("failed to connect database: %w", err)
+	}
+
+	logger.Info("Starting geohash backfill...")
+	n, err := repository.NewGeoRepository(db).BackfillGeohash(context.Background())
+	if err != nil {
+		return fmt.Errorf("backfill failed: %w", err)
+	}
+	logger.Info("Geohash backfill completed", logger.Int64("rows", n))
+	return nil
+}
+
+// runMigrateDialectGroups 历史方言归类
+func runMigrateDialectGroups(cfg *config.Config, dryRun bool) error {
+	db, err := database.NewDatabase(&cfg.Database)
+	if err != nil {
+		return fmt.Errorf("failed to connect database: %w", err)
+	}
+
+	logger.Info("Starting dialect group migration...", logger.Bool("dry_run", dryRun))
+	groupService := service.NewDialectGroupAppService(repository.NewDialectGroupRepository(db), repository.NewDialectRepository(db), nil)
+	result, err := groupService.MigrateLegacy(context.Background(), dryRun, "", "")
+	if err != nil {
+		return fmt.Errorf("migration failed: %w", err)
+	}
+	for _, m := range result.Unmatched {
+		logger.Warn("Dialect not grouped",
+			logger.String("dialect_id", m.DialectID),
+			logger.String("region", m.Region),
+			logger.String("province", m.Province),
+			logger.String("city", m.City),
+		)
+	}
+	logger.Info("Dialect group migration completed",
+		logger.Int("seeded", result.Seeded),
+		logger.Int("total", result.Total),
+		logger.Int("matched", result.Matched),
+		logger.Int("unmatched", len(result.Unmatched)),
+	)
+	return nil
+}
+
+// runReindex 从数据库全量重建案件全文检索索引并写入快照
+func runReindex(cfg *config.Config) error {
+	if cfg.Search.Engine == "database" {
+		return fmt.Errorf("search engine is %q, nothing to reindex", cfg.Search.Engine)
+	}
+	if cfg.Search.IndexPath == "" {
+		return fmt.Errorf("search.index_path is empty, index is rebuilt on every startup")
+	}
+
+	db, err := database.NewDatabase(&cfg.Database)
+	if err != nil {
+		return fmt.Errorf("failed to connect database: %w", err)
+	}
+
+	logger.Info("Starting search reindex...", logger.String("path", cfg.Search.IndexPath))
+	searchService := service.NewCaseSearchAppService(search.NewMemoryCaseIndex(cfg.Search.IndexPath), repository.NewMissingPersonRepository(db))
+	n, err := searchService.Reindex(context.Background())
+	if err != nil {
+		return fmt.Errorf("reindex failed: %w", err)
+	}
+	logger.Info("Search reindex completed", logger.Int("documents", n))
+	return nil
+}
+
 // startServer 启动服务器
 func startServer(cfg *config.Config, container *di.Container) {
 	engine := container.Router.GetEngine()
@@ -177,6 +272,9 @@ func startServer(cfg *config.Config, container *di.Container) {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
+	// 停止后台定时任务
+	container.Close()
+
 	// TODO: 实现优雅关闭逻辑
 	_ = ctx
 
@@ -0,0 +1,38 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// CaseExportRequest 案件移交导出请求
+type CaseExportRequest struct {
+	Recipient    string `json:"recipient" binding:"required,max=100"` // 接收人
+	RecipientOrg string `json:"recipient_org" binding:"max=200"`      // 接收单位，如派出所、合作机构
+	Purpose      string `json:"purpose" binding:"max=500"`            // 移交事由
+	NoPhotos     bool   `json:"no_photos"`                            // 不包含照片文件
+}
+
+// CaseExportFile 案件移交导出包
+type CaseExportFile struct {
+	Data     []byte
+	FileName string
+	SHA256   string
+}
+
+// CaseExportPublicKeyResponse 导出包签名公钥
+type CaseExportPublicKeyResponse struct {
+	Algorithm string `json:"algorithm"`
+	KeyID     string `json:"key_id"`
+	PublicKey string `json:"public_key"` // Base64
+}
+
+// CaseExportVerifyResponse 导出包校验结果
+type CaseExportVerifyResponse struct {
+	Valid     bool            `json:"valid"`
+	Error     string          `json:"error,omitempty"`
+	KeyID     string          `json:"key_id,omitempty"`
+	CreatedAt *time.Time      `json:"created_at,omitempty"`
+	Meta      json.RawMessage `json:"meta,omitempty"`
+	FileCount int             `json:"file_count"`
+}
@@ -0,0 +1,15 @@
+package dto
+
+import "time"
+
+// CaseSearchIndexStatusResponse 案件检索索引状态
+type CaseSearchIndexStatusResponse struct {
+	Engine        string     `json:"engine"`
+	Documents     int        `json:"documents"`
+	Terms         int        `json:"terms"`
+	SyncedAt      *time.Time `json:"synced_at,omitempty"`
+	Ready         bool       `json:"ready"`      // 未就绪时检索退化为数据库模糊查询
+	Rebuilding    bool       `json:"rebuilding"` // 正在全量重建
+	LastRebuildAt *time.Time `json:"last_rebuild_at,omitempty"`
+	LastError     string     `json:"last_error,omitempty"`
+}
@@ -0,0 +1,49 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+)
+
+// DialectPlayRequest 播放上报请求
+type DialectPlayRequest struct {
+	Duration int `json:"duration" binding:"min=0"` // 本次播放时长（秒），播放结束或离开页面时上报
+}
+
+// DialectPlayResponse 播放上报响应
+type DialectPlayResponse struct {
+	Counted bool `json:"counted"` // 是否计入播放次数，去重窗口内的重复播放不计入
+}
+
+// DialectAnalyticsRequest 方言播放统计请求
+type DialectAnalyticsRequest struct {
+	From      *time.Time `form:"from" time_format:"2006-01-02"` // 为空默认最近 30 天
+	To        *time.Time `form:"to" time_format:"2006-01-02"`   // 含当天，为空默认今天
+	Interval  string     `form:"interval" binding:"omitempty,oneof=day week month"`
+	DialectID string     `form:"dialect_id"`
+	OrgID     string     `form:"org_id"` // 仅超级管理员可指定，其他用户固定为本组织
+	Limit     int        `form:"limit" binding:"omitempty,min=1,max=50"`
+}
+
+// DialectPlayPointResponse 播放趋势数据点
+type DialectPlayPointResponse struct {
+	Date           string  `json:"date"` // 区间起始日期
+	Plays          int64   `json:"plays"`
+	Listeners      int64   `json:"listeners"`
+	CompletionRate float64 `json:"completion_rate"`
+}
+
+// DialectAnalyticsResponse 方言播放统计响应
+type DialectAnalyticsResponse struct {
+	OrgID       string                      `json:"org_id,omitempty"`
+	DialectID   string                      `json:"dialect_id,omitempty"`
+	From        string                      `json:"from"`
+	To          string                      `json:"to"`
+	Interval    string                      `json:"interval"`
+	Summary     entity.DialectPlaySummary   `json:"summary"`
+	Series      []DialectPlayPointResponse  `json:"series"`
+	TopRegions  []entity.DialectRegionPlays `json:"top_regions"`
+	TopClips    []entity.DialectClipPlays   `json:"top_clips"`
+	GeneratedAt time.Time                   `json:"generated_at"` // 统计生成时间，结果会缓存数分钟
+}
@@ -0,0 +1,133 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+)
+
+// CreateDialectCommentRequest 创建评论请求，parent_id 为被回复的评论（主评论或回复）
+type CreateDialectCommentRequest struct {
+	Content  string `json:"content" binding:"required"`
+	ParentID string `json:"parent_id"`
+}
+
+// DialectCommentListRequest 评论分页请求
+type DialectCommentListRequest struct {
+	Page     int `form:"page,default=1" binding:"min=1"`
+	PageSize int `form:"page_size,default=10" binding:"min=1,max=50"`
+}
+
+// ReportDialectCommentRequest 举报评论请求
+type ReportDialectCommentRequest struct {
+	Reason string `json:"reason" binding:"required"`
+	Detail string `json:"detail" binding:"max=500"`
+}
+
+// HideDialectCommentRequest 隐藏评论请求
+type HideDialectCommentRequest struct {
+	Reason string `json:"reason" binding:"max=200"`
+}
+
+// DialectCommentModerationRequest 评论管理列表请求
+type DialectCommentModerationRequest struct {
+	Page      int    `form:"page,default=1" binding:"min=1"`
+	PageSize  int    `form:"page_size,default=20" binding:"min=1,max=100"`
+	Filter    string `form:"filter" binding:"omitempty,oneof=flagged reported hidden"` // 为空时列出待复核和被举报的评论
+	DialectID string `form:"dialect_id"`
+}
+
+// DialectCommentResponse 评论响应。已隐藏评论对普通用户不返回内容
+type DialectCommentResponse struct {
+	ID            string                   `json:"id"`
+	DialectID     string                   `json:"dialect_id"`
+	DialectTitle  string                   `json:"dialect_title,omitempty"`
+	UserID        string                   `json:"user_id"`
+	Content       string                   `json:"content"`
+	ParentID      *string                  `json:"parent_id,omitempty"`
+	ReplyToUserID *string                  `json:"reply_to_user_id,omitempty"`
+	ReplyToName   string                   `json:"reply_to_name,omitempty"`
+	ReplyCount    int                      `json:"reply_count"`
+	LikeCount     int                      `json:"like_count"`
+	Liked         bool                     `json:"liked"`
+	Hidden        bool                     `json:"hidden"`
+	HiddenReason  string                   `json:"hidden_reason,omitempty"`
+	Flagged       bool                     `json:"flagged,omitempty"`
+	ReportCount   int                      `json:"report_count,omitempty"`
+	User          *UserResponse            `json:"user,omitempty"`
+	Replies       []DialectCommentResponse `json:"replies,omitempty"` // 主评论下最早的几条回复，其余通过回复列表分页获取
+	CreatedAt     time.Time                `json:"created_at"`
+}
+
+// DialectCommentReportResponse 评论举报记录
+type DialectCommentReportResponse struct {
+	ID           string     `json:"id"`
+	ReporterID   string     `json:"reporter_id"`
+	ReporterName string     `json:"reporter_name,omitempty"`
+	Reason       string     `json:"reason"`
+	ReasonLabel  string     `json:"reason_label"`
+	Detail       string     `json:"detail,omitempty"`
+	Status       string     `json:"status"`
+	HandledAt    *time.Time `json:"handled_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// DialectCommentReportReasonResponse 举报原因
+type DialectCommentReportReasonResponse struct {
+	Code  string `json:"code"`
+	Label string `json:"label"`
+}
+
+// ToDialectCommentResponse 转换为评论响应，moderator 为 false 时隐藏评论不返回内容和管理字段
+func ToDialectCommentResponse(c *entity.DialectComment, moderator bool) DialectCommentResponse {
+	resp := DialectCommentResponse{
+		ID:            c.ID,
+		DialectID:     c.DialectID,
+		UserID:        c.UserID,
+		Content:       c.Content,
+		ParentID:      c.ParentID,
+		ReplyToUserID: c.ReplyToUserID,
+		ReplyCount:    c.ReplyCount,
+		LikeCount:     c.LikeCount,
+		Hidden:        c.IsHidden(),
+		CreatedAt:     c.CreatedAt,
+	}
+	if moderator {
+		resp.HiddenReason = c.HiddenReason
+		resp.Flagged = c.Flagged
+		resp.ReportCount = c.ReportCount
+	} else if c.IsHidden() {
+		resp.Content = ""
+	}
+
+	if c.Dialect != nil {
+		resp.DialectTitle = c.Dialect.Title
+	}
+	if c.User != nil {
+		user := ToUserResponse(c.User)
+		resp.User = &user
+	}
+	if c.ReplyToUser != nil {
+		resp.ReplyToName = c.ReplyToUser.Nickname
+	}
+
+	return resp
+}
+
+// ToDialectCommentReportResponse 转换为举报记录响应
+func ToDialectCommentReportResponse(r *entity.DialectCommentReport) DialectCommentReportResponse {
+	resp := DialectCommentReportResponse{
+		ID:          r.ID,
+		ReporterID:  r.ReporterID,
+		Reason:      string(r.Reason),
+		ReasonLabel: r.Reason.Label(),
+		Detail:      r.Detail,
+		Status:      string(r.Status),
+		HandledAt:   r.HandledAt,
+		CreatedAt:   r.CreatedAt,
+	}
+	if r.Reporter != nil {
+		resp.ReporterName = r.Reporter.Nickname
+	}
+	return resp
+}
@@ -6,18 +6,17 @@ import (
 	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
 )
 
-// CreateDialectRequest 创建方言请求
+// CreateDialectRequest 创建方言请求，音频需先通过 /dialects/audio 上传，时长、格式等以服务端探测为准。
+// 指定方言分类时地区名称取分类名称
 type CreateDialectRequest struct {
 	Title       string `json:"title" binding:"required"`
 	Content     string `json:"content"`
-	Region      string `json:"region" binding:"required"`
+	GroupID     string `json:"group_id"`
+	Region      string `json:"region" binding:"required_without=GroupID"`
 	Province    string `json:"province"`
 	City        string `json:"city"`
 	DialectType string `json:"dialect_type"`
-	AudioUrl    string `json:"audio_url" binding:"required"`
-	Duration    int    `json:"duration"`
-	FileSize    int    `json:"file_size"`
-	Format      string `json:"format"`
+	AudioFileID string `json:"audio_file_id" binding:"required"`
 	Tags        string `json:"tags"`
 	Description string `json:"description"`
 }
@@ -26,6 +25,7 @@ type CreateDialectRequest struct {
 type UpdateDialectRequest struct {
 	Title       string `json:"title"`
 	Content     string `json:"content"`
+	GroupID     string `json:"group_id"`
 	Region      string `json:"region"`
 	Province    string `json:"province"`
 	City        string `json:"city"`
@@ -36,28 +36,32 @@ type UpdateDialectRequest struct {
 
 // DialectResponse 方言响应
 type DialectResponse struct {
-	ID           string        `json:"id"`
-	Title        string        `json:"title"`
-	Content      string        `json:"content"`
-	Region       string        `json:"region"`
-	Province     string        `json:"province"`
-	City         string        `json:"city"`
-	DialectType  string        `json:"dialect_type"`
-	AudioUrl     string        `json:"audio_url"`
-	Duration     int           `json:"duration"`
-	FileSize     int           `json:"file_size"`
-	Format       string        `json:"format"`
-	Status       string        `json:"status"`
-	IsFeatured   bool          `json:"is_featured"`
-	PlayCount    int           `json:"play_count"`
-	LikeCount    int           `json:"like_count"`
-	CommentCount int           `json:"comment_count"`
-	Tags         string        `json:"tags"`
-	Description  string        `json:"description"`
-	UploaderID   string        `json:"uploader_id"`
-	OrgID        string        `json:"org_id"`
-	Uploader     *UserResponse `json:"uploader,omitempty"`
-	CreatedAt    time.Time     `json:"created_at"`
+	ID           string             `json:"id"`
+	Title        string             `json:"title"`
+	Content      string             `json:"content"`
+	Region       string             `json:"region"`
+	GroupID      *string            `json:"group_id,omitempty"`
+	Group        *DialectGroupBrief `json:"group,omitempty"`
+	Province     string             `json:"province"`
+	City         string             `json:"city"`
+	DialectType  string             `json:"dialect_type"`
+	AudioUrl     string             `json:"audio_url"`
+	Duration     int                `json:"duration"`
+	FileSize     int                `json:"file_size"`
+	Format       string             `json:"format"`
+	AudioFileID  *string            `json:"audio_file_id,omitempty"`
+	WaveformUrl  string             `json:"waveform_url,omitempty"`
+	Status       string             `json:"status"`
+	IsFeatured   bool               `json:"is_featured"`
+	PlayCount    int                `json:"play_count"`
+	LikeCount    int                `json:"like_count"`
+	CommentCount int                `json:"comment_count"`
+	Tags         string             `json:"tags"`
+	Description  string             `json:"description"`
+	UploaderID   string             `json:"uploader_id"`
+	OrgID        string             `json:"org_id"`
+	Uploader     *UserResponse      `json:"uploader,omitempty"`
+	CreatedAt    time.Time          `json:"created_at"`
 }
 
 // DialectListRequest 方言列表请求
@@ -66,6 +70,7 @@ type DialectListRequest struct {
 	PageSize  int    `form:"page_size,default=10" binding:"min=1,max=100"`
 	Keyword   string `form:"keyword"`
 	Region    string `form:"region"`
+	GroupID   string `form:"group_id"` // 方言分类，任意层级，包含其全部下级
 	Province  string `form:"province"`
 	City      string `form:"city"`
 	Type      string `form:"type"`
@@ -74,6 +79,17 @@ type DialectListRequest struct {
 	SortOrder string `form:"sort_order"`
 }
 
+// SimilarDialectRequest 相似方言查询请求
+type SimilarDialectRequest struct {
+	Limit int `form:"limit,default=10" binding:"min=1,max=50"`
+}
+
+// SimilarDialectResponse 声纹相似的方言
+type SimilarDialectResponse struct {
+	Dialect    DialectResponse `json:"dialect"`
+	Similarity float64         `json:"similarity"` // 声纹指纹相似度（0~1）
+}
+
 // DialectListResponse 方言列表响应
 type DialectListResponse = PageResult[DialectResponse]
 
@@ -82,25 +98,6 @@ type UpdateDialectStatusRequest struct {
 	Status string `json:"status" binding:"required"`
 }
 
-// CreateDialectCommentRequest 创建评论请求
-type CreateDialectCommentRequest struct {
-	Content  string `json:"content" binding:"required"`
-	ParentID string `json:"parent_id"`
-}
-
-// DialectCommentResponse 评论响应
-type DialectCommentResponse struct {
-	ID         string        `json:"id"`
-	DialectID  string        `json:"dialect_id"`
-	UserID     string        `json:"user_id"`
-	Content    string        `json:"content"`
-	ParentID   *string       `json:"parent_id,omitempty"`
-	ReplyCount int           `json:"reply_count"`
-	LikeCount  int           `json:"like_count"`
-	User       *UserResponse `json:"user,omitempty"`
-	CreatedAt  time.Time     `json:"created_at"`
-}
-
 // DialectStatsResponse 方言统计响应
 type DialectStatsResponse struct {
 	Total      int64 `json:"total"`
@@ -118,6 +115,7 @@ func ToDialectResponse(d *entity.Dialect) DialectResponse {
 		Title:        d.Title,
 		Content:      d.Content,
 		Region:       d.Region,
+		GroupID:      d.GroupID,
 		Province:     d.Province,
 		City:         d.City,
 		DialectType:  string(d.DialectType),
@@ -125,6 +123,8 @@ func ToDialectResponse(d *entity.Dialect) DialectResponse {
 		Duration:     d.Duration,
 		FileSize:     d.FileSize,
 		Format:       d.Format,
+		AudioFileID:  d.AudioFileID,
+		WaveformUrl:  d.WaveformUrl,
 		Status:       string(d.Status),
 		IsFeatured:   d.IsFeatured,
 		PlayCount:    d.PlayCount,
@@ -141,26 +141,9 @@ func ToDialectResponse(d *entity.Dialect) DialectResponse {
 		uploader := ToUserResponse(d.Uploader)
 		resp.Uploader = &uploader
 	}
-
-	return resp
-}
-
-// ToDialectCommentResponse 转换为评论响应
-func ToDialectCommentResponse(c *entity.DialectComment) DialectCommentResponse {
-	resp := DialectCommentResponse{
-		ID:         c.ID,
-		DialectID:  c.DialectID,
-		UserID:     c.UserID,
-		Content:    c.Content,
-		ParentID:   c.ParentID,
-		ReplyCount: c.ReplyCount,
-		LikeCount:  c.LikeCount,
-		CreatedAt:  c.CreatedAt,
-	}
-
-	if c.User != nil {
-		user := ToUserResponse(c.User)
-		resp.User = &user
+	if d.Group != nil {
+		group := ToDialectGroupBrief(d.Group)
+		resp.Group = &group
 	}
 
 	return resp
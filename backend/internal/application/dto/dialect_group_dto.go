@@ -0,0 +1,106 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+)
+
+// DialectGroupRegionRequest 方言分类对应的行政区，city 为空表示整个省
+type DialectGroupRegionRequest struct {
+	Province string `json:"province" binding:"required,max=50"`
+	City     string `json:"city" binding:"max=50"`
+}
+
+// DialectGroupRequest 创建、修改方言分类请求（别称、对应地区整体替换）。
+// parent_id 为空表示方言大区，修改时变更 parent_id 即移动到新的上级下
+type DialectGroupRequest struct {
+	ParentID    string                      `json:"parent_id"`
+	Name        string                      `json:"name" binding:"required,max=50"`
+	Aliases     []string                    `json:"aliases" binding:"max=20,dive,max=50"`
+	Regions     []DialectGroupRegionRequest `json:"regions" binding:"max=100,dive"`
+	Sort        int                         `json:"sort"`
+	Description string                      `json:"description" binding:"max=2000"`
+}
+
+// MigrateDialectGroupsRequest 历史方言归类请求
+type MigrateDialectGroupsRequest struct {
+	DryRun bool `json:"dry_run"` // 只返回匹配结果，不写入
+}
+
+// DialectGroupRegionResponse 方言分类对应地区
+type DialectGroupRegionResponse struct {
+	Province string `json:"province"`
+	City     string `json:"city,omitempty"`
+}
+
+// DialectGroupResponse 方言分类响应
+type DialectGroupResponse struct {
+	ID           string                       `json:"id"`
+	ParentID     *string                      `json:"parent_id,omitempty"`
+	Level        string                       `json:"level"`
+	Name         string                       `json:"name"`
+	Aliases      []string                     `json:"aliases,omitempty"`
+	FullName     string                       `json:"full_name"`
+	Sort         int                          `json:"sort"`
+	Description  string                       `json:"description,omitempty"`
+	Regions      []DialectGroupRegionResponse `json:"regions,omitempty"`
+	DialectCount *int64                       `json:"dialect_count,omitempty"`
+	Ancestors    []DialectGroupBrief          `json:"ancestors,omitempty"`
+	Children     []DialectGroupResponse       `json:"children,omitempty"`
+	CreatedAt    time.Time                    `json:"created_at"`
+}
+
+// DialectGroupBrief 方言分类摘要
+type DialectGroupBrief struct {
+	ID       string `json:"id"`
+	Level    string `json:"level"`
+	Name     string `json:"name"`
+	FullName string `json:"full_name"`
+}
+
+// DialectGroupMatchResponse 单条历史方言的归类结果，未匹配时 Group 为空
+type DialectGroupMatchResponse struct {
+	DialectID string             `json:"dialect_id"`
+	Title     string             `json:"title"`
+	Region    string             `json:"region"`
+	Province  string             `json:"province,omitempty"`
+	City      string             `json:"city,omitempty"`
+	Method    string             `json:"method,omitempty"` // name, keyword, region
+	Group     *DialectGroupBrief `json:"group,omitempty"`
+}
+
+// MigrateDialectGroupsResponse 历史方言归类结果
+type MigrateDialectGroupsResponse struct {
+	DryRun    bool                        `json:"dry_run"`
+	Seeded    int                         `json:"seeded"` // 分类为空时导入的默认分类数
+	Total     int                         `json:"total"`
+	Matched   int                         `json:"matched"`
+	ByMethod  map[string]int              `json:"by_method"`
+	Matches   []DialectGroupMatchResponse `json:"matches,omitempty"` // 仅试运行时返回
+	Unmatched []DialectGroupMatchResponse `json:"unmatched"`
+}
+
+// ToDialectGroupResponse 转换为方言分类响应（不含下级）
+func ToDialectGroupResponse(g *entity.DialectGroup) DialectGroupResponse {
+	resp := DialectGroupResponse{
+		ID:          g.ID,
+		ParentID:    g.ParentID,
+		Level:       string(g.Level),
+		Name:        g.Name,
+		Aliases:     g.GetAliases(),
+		FullName:    g.FullName,
+		Sort:        g.Sort,
+		Description: g.Description,
+		CreatedAt:   g.CreatedAt,
+	}
+	for _, r := range g.Regions {
+		resp.Regions = append(resp.Regions, DialectGroupRegionResponse{Province: r.Province, City: r.City})
+	}
+	return resp
+}
+
+// ToDialectGroupBrief 转换为方言分类摘要
+func ToDialectGroupBrief(g *entity.DialectGroup) DialectGroupBrief {
+	return DialectGroupBrief{ID: g.ID, Level: string(g.Level), Name: g.Name, FullName: g.FullName}
+}
@@ -0,0 +1,161 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+)
+
+// CreateDialectPlaylistRequest 创建歌单请求
+type CreateDialectPlaylistRequest struct {
+	Title       string `json:"title" binding:"required,max=100"`
+	Description string `json:"description" binding:"max=500"`
+	Visibility  string `json:"visibility" binding:"omitempty,oneof=private org"` // 默认 private
+}
+
+// CreateDialectCollectionRequest 创建省份精选合集请求
+type CreateDialectCollectionRequest struct {
+	Province string `json:"province" binding:"required,max=50"`
+	Title    string `json:"title" binding:"max=100"` // 为空时使用“某省方言精选”
+	Limit    int    `json:"limit" binding:"min=0,max=200"`
+}
+
+// UpdateDialectPlaylistRequest 修改歌单请求
+type UpdateDialectPlaylistRequest struct {
+	Title       *string `json:"title" binding:"omitempty,max=100"`
+	Description *string `json:"description" binding:"omitempty,max=500"`
+	Visibility  *string `json:"visibility" binding:"omitempty,oneof=private org"`
+}
+
+// DialectPlaylistListRequest 歌单列表请求
+type DialectPlaylistListRequest struct {
+	Page     int    `form:"page,default=1" binding:"min=1"`
+	PageSize int    `form:"page_size,default=20" binding:"min=1,max=100"`
+	Scope    string `form:"scope" binding:"omitempty,oneof=mine shared org auto"` // 为空时列出全部可见歌单
+	Province string `form:"province"`
+	Keyword  string `form:"keyword"`
+}
+
+// AddDialectPlaylistItemRequest 添加片段请求
+type AddDialectPlaylistItemRequest struct {
+	DialectID string `json:"dialect_id" binding:"required"`
+	Note      string `json:"note" binding:"max=200"`
+	Position  int    `json:"position" binding:"min=0"` // 插入位置（从 1 开始），0 为追加到末尾
+}
+
+// ReorderDialectPlaylistRequest 片段排序请求，item_ids 须包含歌单当前的全部片段
+type ReorderDialectPlaylistRequest struct {
+	ItemIDs []string `json:"item_ids" binding:"required"`
+}
+
+// AddDialectPlaylistCollaboratorRequest 添加协作者请求
+type AddDialectPlaylistCollaboratorRequest struct {
+	UserID string `json:"user_id" binding:"required"`
+}
+
+// DialectPlaylistResponse 歌单响应
+type DialectPlaylistResponse struct {
+	ID            string                                `json:"id"`
+	Title         string                                `json:"title"`
+	Description   string                                `json:"description,omitempty"`
+	Visibility    string                                `json:"visibility"`
+	Kind          string                                `json:"kind"`
+	Province      string                                `json:"province,omitempty"`
+	ItemCount     int                                   `json:"item_count"`
+	TotalDuration int                                   `json:"total_duration"`
+	RefreshedAt   *time.Time                            `json:"refreshed_at,omitempty"`
+	OwnerID       string                                `json:"owner_id"`
+	Owner         *UserResponse                         `json:"owner,omitempty"`
+	OrgID         string                                `json:"org_id"`
+	CanEdit       bool                                  `json:"can_edit"`   // 当前用户能否编排片段
+	CanManage     bool                                  `json:"can_manage"` // 当前用户能否修改信息、协作者或删除
+	Items         []DialectPlaylistItemResponse         `json:"items,omitempty"`
+	Collaborators []DialectPlaylistCollaboratorResponse `json:"collaborators,omitempty"`
+	CreatedAt     time.Time                             `json:"created_at"`
+	UpdatedAt     time.Time                             `json:"updated_at"`
+}
+
+// DialectPlaylistItemResponse 歌单片段响应
+type DialectPlaylistItemResponse struct {
+	ID        string           `json:"id"`
+	DialectID string           `json:"dialect_id"`
+	Position  int              `json:"position"`
+	Note      string           `json:"note,omitempty"`
+	Score     float64          `json:"score,omitempty"`
+	Available bool             `json:"available"` // 方言已删除或下架时为 false
+	Dialect   *DialectResponse `json:"dialect,omitempty"`
+	AddedBy   string           `json:"added_by"`
+	CreatedAt time.Time        `json:"created_at"`
+}
+
+// DialectPlaylistCollaboratorResponse 歌单协作者响应
+type DialectPlaylistCollaboratorResponse struct {
+	UserID    string        `json:"user_id"`
+	User      *UserResponse `json:"user,omitempty"`
+	AddedBy   string        `json:"added_by"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+// DialectPlaylistBundle 歌单离线包
+type DialectPlaylistBundle struct {
+	Data     []byte
+	FileName string
+	SHA256   string
+}
+
+// ToDialectPlaylistResponse 转换为歌单响应，不含片段和协作者
+func ToDialectPlaylistResponse(p *entity.DialectPlaylist) DialectPlaylistResponse {
+	resp := DialectPlaylistResponse{
+		ID:            p.ID,
+		Title:         p.Title,
+		Description:   p.Description,
+		Visibility:    string(p.Visibility),
+		Kind:          string(p.Kind),
+		Province:      p.Province,
+		ItemCount:     p.ItemCount,
+		TotalDuration: p.TotalDuration,
+		RefreshedAt:   p.RefreshedAt,
+		OwnerID:       p.OwnerID,
+		OrgID:         p.OrgID,
+		CreatedAt:     p.CreatedAt,
+		UpdatedAt:     p.UpdatedAt,
+	}
+	if p.Owner != nil {
+		owner := ToUserResponse(p.Owner)
+		resp.Owner = &owner
+	}
+	return resp
+}
+
+// ToDialectPlaylistItemResponse 转换为歌单片段响应
+func ToDialectPlaylistItemResponse(item *entity.DialectPlaylistItem) DialectPlaylistItemResponse {
+	resp := DialectPlaylistItemResponse{
+		ID:        item.ID,
+		DialectID: item.DialectID,
+		Position:  item.Position,
+		Note:      item.Note,
+		Score:     item.Score,
+		AddedBy:   item.AddedBy,
+		CreatedAt: item.CreatedAt,
+	}
+	if item.Dialect != nil {
+		d := ToDialectResponse(item.Dialect)
+		resp.Dialect = &d
+		resp.Available = item.Dialect.CanPlay()
+	}
+	return resp
+}
+
+// ToDialectPlaylistCollaboratorResponse 转换为协作者响应
+func ToDialectPlaylistCollaboratorResponse(c *entity.DialectPlaylistCollaborator) DialectPlaylistCollaboratorResponse {
+	resp := DialectPlaylistCollaboratorResponse{
+		UserID:    c.UserID,
+		AddedBy:   c.AddedBy,
+		CreatedAt: c.CreatedAt,
+	}
+	if c.User != nil {
+		user := ToUserResponse(c.User)
+		resp.User = &user
+	}
+	return resp
+}
@@ -0,0 +1,166 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+)
+
+// DialectReviewListRequest 方言审核队列查询请求
+type DialectReviewListRequest struct {
+	Page      int    `form:"page,default=1" binding:"min=1"`
+	PageSize  int    `form:"page_size,default=20" binding:"min=1,max=100"`
+	Status    string `form:"status" binding:"omitempty,oneof=pending claimed approved rejected"`
+	Mine      bool   `form:"mine"`      // 只看自己认领中的
+	Duplicate bool   `form:"duplicate"` // 只看疑似重复上传
+}
+
+// ApproveDialectReviewRequest 审核通过请求
+type ApproveDialectReviewRequest struct {
+	Comment string `json:"comment" binding:"max=500"`
+}
+
+// RejectDialectReviewRequest 审核驳回请求
+type RejectDialectReviewRequest struct {
+	ReasonCode string `json:"reason_code" binding:"required"`
+	Comment    string `json:"comment" binding:"max=500"`
+}
+
+// DialectReviewStatsRequest 审核统计请求
+type DialectReviewStatsRequest struct {
+	Days int `form:"days" binding:"omitempty,min=1,max=365"` // 统计最近天数，为空统计全部
+}
+
+// DialectReviewReasonResponse 驳回原因
+type DialectReviewReasonResponse struct {
+	Code  string `json:"code"`
+	Label string `json:"label"`
+}
+
+// DialectReviewDecisionResponse 审核员结论
+type DialectReviewDecisionResponse struct {
+	ReviewerID    string    `json:"reviewer_id"`
+	ReviewerName  string    `json:"reviewer_name,omitempty"`
+	Action        string    `json:"action"`
+	ReasonCode    string    `json:"reason_code,omitempty"`
+	ReasonLabel   string    `json:"reason_label,omitempty"`
+	Comment       string    `json:"comment,omitempty"`
+	Stage         int       `json:"stage"`
+	HandleSeconds int       `json:"handle_seconds"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// DialectReviewResponse 方言审核响应
+type DialectReviewResponse struct {
+	ID                 string                          `json:"id"`
+	DialectID          string                          `json:"dialect_id"`
+	Dialect            *DialectResponse                `json:"dialect,omitempty"`
+	OrgID              string                          `json:"org_id"`
+	UploaderID         string                          `json:"uploader_id"`
+	Status             string                          `json:"status"`
+	RequiredApprovals  int                             `json:"required_approvals"`
+	Approvals          int                             `json:"approvals"`
+	ReviewerID         *string                         `json:"reviewer_id,omitempty"`
+	ReviewerName       string                          `json:"reviewer_name,omitempty"`
+	ClaimedAt          *time.Time                      `json:"claimed_at,omitempty"`
+	ClaimExpiresAt     *time.Time                      `json:"claim_expires_at,omitempty"`
+	WorkflowInstanceID *string                         `json:"workflow_instance_id,omitempty"`
+	ReasonCode         string                          `json:"reason_code,omitempty"`
+	ReasonLabel        string                          `json:"reason_label,omitempty"`
+	Comment            string                          `json:"comment,omitempty"`
+	DecidedAt          *time.Time                      `json:"decided_at,omitempty"`
+	Decisions          []DialectReviewDecisionResponse `json:"decisions,omitempty"`
+	// 疑似重复上传：声纹与已有方言高度相似
+	DuplicateOfID       *string          `json:"duplicate_of_id,omitempty"`
+	DuplicateOf         *DialectResponse `json:"duplicate_of,omitempty"`
+	DuplicateSimilarity float64          `json:"duplicate_similarity,omitempty"`
+	CreatedAt           time.Time        `json:"created_at"`
+}
+
+// DialectReviewListResponse 方言审核列表响应
+type DialectReviewListResponse struct {
+	List       []DialectReviewResponse `json:"list"`
+	Total      int64                   `json:"total"`
+	Page       int                     `json:"page"`
+	PageSize   int                     `json:"page_size"`
+	TotalPages int                     `json:"total_pages"`
+}
+
+// DialectReviewerStatsResponse 审核员统计
+type DialectReviewerStatsResponse struct {
+	ReviewerID    string     `json:"reviewer_id"`
+	ReviewerName  string     `json:"reviewer_name,omitempty"`
+	Total         int64      `json:"total"`
+	Approved      int64      `json:"approved"`
+	Rejected      int64      `json:"rejected"`
+	AvgSeconds    int        `json:"avg_seconds"`
+	LastDecidedAt *time.Time `json:"last_decided_at,omitempty"`
+}
+
+// DialectReviewStatsResponse 组织审核统计
+type DialectReviewStatsResponse struct {
+	Pending   int64                          `json:"pending"`
+	Claimed   int64                          `json:"claimed"`
+	Approved  int64                          `json:"approved"`
+	Rejected  int64                          `json:"rejected"`
+	Reviewers []DialectReviewerStatsResponse `json:"reviewers"`
+}
+
+// ToDialectReviewResponse 转换为方言审核响应
+func ToDialectReviewResponse(r *entity.DialectReview) DialectReviewResponse {
+	resp := DialectReviewResponse{
+		ID:                 r.ID,
+		DialectID:          r.DialectID,
+		OrgID:              r.OrgID,
+		UploaderID:         r.UploaderID,
+		Status:             string(r.Status),
+		RequiredApprovals:  r.RequiredApprovals,
+		Approvals:          r.Approvals,
+		WorkflowInstanceID: r.WorkflowInstanceID,
+		ReasonCode:         string(r.ReasonCode),
+		ReasonLabel:        r.ReasonCode.Label(),
+		Comment:            r.Comment,
+		DecidedAt:          r.DecidedAt,
+		CreatedAt:          r.CreatedAt,
+	}
+	if r.Status == entity.DialectReviewClaimed {
+		resp.ReviewerID = r.ReviewerID
+		resp.ClaimedAt = r.ClaimedAt
+		if r.ClaimedAt != nil {
+			expiresAt := r.ClaimedAt.Add(entity.DialectReviewClaimTTL)
+			resp.ClaimExpiresAt = &expiresAt
+		}
+		if r.Reviewer != nil {
+			resp.ReviewerName = r.Reviewer.Nickname
+		}
+	}
+	if r.Dialect != nil {
+		dialect := ToDialectResponse(r.Dialect)
+		resp.Dialect = &dialect
+	}
+	if r.IsDuplicate() {
+		resp.DuplicateOfID = r.DuplicateOfID
+		resp.DuplicateSimilarity = r.DuplicateSimilarity
+		if r.DuplicateOf != nil {
+			original := ToDialectResponse(r.DuplicateOf)
+			resp.DuplicateOf = &original
+		}
+	}
+	for _, d := range r.Decisions {
+		decision := DialectReviewDecisionResponse{
+			ReviewerID:    d.ReviewerID,
+			Action:        string(d.Action),
+			ReasonCode:    string(d.ReasonCode),
+			ReasonLabel:   d.ReasonCode.Label(),
+			Comment:       d.Comment,
+			Stage:         d.Stage,
+			HandleSeconds: d.HandleSeconds,
+			CreatedAt:     d.CreatedAt,
+		}
+		if d.Reviewer != nil {
+			decision.ReviewerName = d.Reviewer.Nickname
+		}
+		resp.Decisions = append(resp.Decisions, decision)
+	}
+	return resp
+}
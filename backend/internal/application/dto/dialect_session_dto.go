@@ -0,0 +1,143 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+)
+
+// StartDialectSessionRequest 开始方言辨识请求，已知大致省份（市）时可直接从下一级开始
+type StartDialectSessionRequest struct {
+	MissingPersonID string `json:"missing_person_id"`
+	SubjectName     string `json:"subject_name" binding:"max=50"`
+	Province        string `json:"province" binding:"max=50"`
+	City            string `json:"city" binding:"max=50"`
+	Note            string `json:"note" binding:"max=2000"`
+}
+
+// DialectAnswerRequest 记录受测者反应请求
+type DialectAnswerRequest struct {
+	DialectID   string `json:"dialect_id" binding:"required"`
+	Recognition string `json:"recognition" binding:"required,oneof=native familiar partial unfamiliar"`
+	Note        string `json:"note" binding:"max=500"`
+}
+
+// NarrowDialectSessionRequest 手动调整辨识范围请求，省、市均为空时回到按省辨识
+type NarrowDialectSessionRequest struct {
+	Province string `json:"province" binding:"max=50"`
+	City     string `json:"city" binding:"max=50"`
+}
+
+// CompleteDialectSessionRequest 完成辨识请求
+type CompleteDialectSessionRequest struct {
+	Note string `json:"note" binding:"max=2000"`
+}
+
+// AttachDialectSessionRequest 关联案件请求
+type AttachDialectSessionRequest struct {
+	MissingPersonID string `json:"missing_person_id" binding:"required"`
+	Mode            string `json:"mode" binding:"required,oneof=track note"`
+}
+
+// DialectSessionListRequest 方言辨识会话列表请求
+type DialectSessionListRequest struct {
+	Page     int    `form:"page,default=1" binding:"min=1"`
+	PageSize int    `form:"page_size,default=20" binding:"min=1,max=100"`
+	Status   string `form:"status"`
+	Mine     bool   `form:"mine"`
+}
+
+// DialectRegionCandidate 推断的可能籍贯
+type DialectRegionCandidate struct {
+	Province   string  `json:"province"`
+	City       string  `json:"city,omitempty"`
+	Region     string  `json:"region,omitempty"`
+	Score      int     `json:"score"`
+	Confidence float64 `json:"confidence"`
+	Evidence   int     `json:"evidence"`
+}
+
+// DialectSessionAnswerResponse 受测者反应响应
+type DialectSessionAnswerResponse struct {
+	ID           string    `json:"id"`
+	DialectID    string    `json:"dialect_id"`
+	DialectTitle string    `json:"dialect_title,omitempty"`
+	Stage        string    `json:"stage"`
+	Province     string    `json:"province,omitempty"`
+	City         string    `json:"city,omitempty"`
+	Region       string    `json:"region,omitempty"`
+	Recognition  string    `json:"recognition"`
+	Note         string    `json:"note,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// DialectSessionResponse 方言辨识会话响应
+type DialectSessionResponse struct {
+	ID              string                         `json:"id"`
+	MissingPersonID *string                        `json:"missing_person_id,omitempty"`
+	SubjectName     string                         `json:"subject_name,omitempty"`
+	Status          string                         `json:"status"`
+	Stage           string                         `json:"stage"`
+	Province        string                         `json:"province,omitempty"`
+	City            string                         `json:"city,omitempty"`
+	Note            string                         `json:"note,omitempty"`
+	CompletedAt     *time.Time                     `json:"completed_at,omitempty"`
+	AttachMode      string                         `json:"attach_mode,omitempty"`
+	TrackID         *string                        `json:"track_id,omitempty"`
+	OperatorID      string                         `json:"operator_id"`
+	OrgID           string                         `json:"org_id"`
+	Answers         []DialectSessionAnswerResponse `json:"answers,omitempty"`
+	Ranking         []DialectRegionCandidate       `json:"ranking"`
+	NextClips       []DialectResponse              `json:"next_clips,omitempty"`
+	CreatedAt       time.Time                      `json:"created_at"`
+	UpdatedAt       time.Time                      `json:"updated_at"`
+}
+
+// DialectSessionListResponse 方言辨识会话列表响应
+type DialectSessionListResponse struct {
+	List       []DialectSessionResponse `json:"list"`
+	Total      int64                    `json:"total"`
+	Page       int                      `json:"page"`
+	PageSize   int                      `json:"page_size"`
+	TotalPages int                      `json:"total_pages"`
+}
+
+// ToDialectSessionResponse 转换为方言辨识会话响应（不含排名及推荐片段）
+func ToDialectSessionResponse(s *entity.DialectSession) DialectSessionResponse {
+	resp := DialectSessionResponse{
+		ID:              s.ID,
+		MissingPersonID: s.MissingPersonID,
+		SubjectName:     s.SubjectName,
+		Status:          string(s.Status),
+		Stage:           string(s.Stage),
+		Province:        s.Province,
+		City:            s.City,
+		Note:            s.Note,
+		CompletedAt:     s.CompletedAt,
+		AttachMode:      s.AttachMode,
+		TrackID:         s.TrackID,
+		OperatorID:      s.OperatorID,
+		OrgID:           s.OrgID,
+		Ranking:         []DialectRegionCandidate{},
+		CreatedAt:       s.CreatedAt,
+		UpdatedAt:       s.UpdatedAt,
+	}
+	for _, a := range s.Answers {
+		answer := DialectSessionAnswerResponse{
+			ID:          a.ID,
+			DialectID:   a.DialectID,
+			Stage:       string(a.Stage),
+			Province:    a.Province,
+			City:        a.City,
+			Region:      a.Region,
+			Recognition: string(a.Recognition),
+			Note:        a.Note,
+			CreatedAt:   a.CreatedAt,
+		}
+		if a.Dialect != nil {
+			answer.DialectTitle = a.Dialect.Title
+		}
+		resp.Answers = append(resp.Answers, answer)
+	}
+	return resp
+}
@@ -0,0 +1,161 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+)
+
+// CreateDNASampleRequest 登记 DNA 样本请求
+type CreateDNASampleRequest struct {
+	SampleNo       string    `json:"sample_no" binding:"required,max=50"`
+	SubjectType    string    `json:"subject_type" binding:"required,oneof=missing_person family_member"`
+	SampleType     string    `json:"sample_type" binding:"required,oneof=blood saliva hair other"`
+	DonorName      string    `json:"donor_name" binding:"max=50"`
+	DonorRelation  string    `json:"donor_relation" binding:"max=20"`
+	DonorPhone     string    `json:"donor_phone" binding:"max=20"`
+	CollectedAt    time.Time `json:"collected_at" binding:"required"`
+	CollectionSite string    `json:"collection_site" binding:"required,max=200"`
+	Holder         string    `json:"holder" binding:"max=100"`
+	Note           string    `json:"note" binding:"max=2000"`
+}
+
+// UpdateDNASampleRequest 修改样本采集信息请求
+type UpdateDNASampleRequest struct {
+	SampleType     string     `json:"sample_type" binding:"omitempty,oneof=blood saliva hair other"`
+	DonorName      *string    `json:"donor_name" binding:"omitempty,max=50"`
+	DonorRelation  *string    `json:"donor_relation" binding:"omitempty,max=20"`
+	DonorPhone     *string    `json:"donor_phone" binding:"omitempty,max=20"`
+	CollectedAt    *time.Time `json:"collected_at"`
+	CollectionSite string     `json:"collection_site" binding:"max=200"`
+	Note           *string    `json:"note" binding:"omitempty,max=2000"`
+}
+
+// DNASampleCustodyRequest 登记保管链事件请求
+type DNASampleCustodyRequest struct {
+	Action     string     `json:"action" binding:"required,oneof=transfer submit result reject destroy"`
+	Holder     string     `json:"holder" binding:"max=100"`
+	Location   string     `json:"location" binding:"max=200"`
+	Note       string     `json:"note" binding:"max=2000"`
+	OccurredAt *time.Time `json:"occurred_at"`
+	LabName    string     `json:"lab_name" binding:"max=100"`
+	LabCaseNo  string     `json:"lab_case_no" binding:"max=100"`
+	Result     string     `json:"result" binding:"omitempty,oneof=match no_match inconclusive"`
+	ResultRef  string     `json:"result_ref" binding:"max=100"`
+}
+
+// DNASampleListRequest 样本列表请求
+type DNASampleListRequest struct {
+	Page     int    `form:"page,default=1" binding:"min=1"`
+	PageSize int    `form:"page_size,default=20" binding:"min=1,max=100"`
+	Status   string `form:"status"`
+	OrgID    string `form:"org_id"`
+}
+
+// DNASampleResponse DNA 样本响应，无权查看的字段以 *** 替代并列于 masked_fields
+type DNASampleResponse struct {
+	ID              string        `json:"id"`
+	MissingPersonID string        `json:"missing_person_id"`
+	OrgID           string        `json:"org_id"`
+	SampleNo        string        `json:"sample_no"`
+	SubjectType     string        `json:"subject_type"`
+	SampleType      string        `json:"sample_type"`
+	DonorName       string        `json:"donor_name,omitempty"`
+	DonorRelation   string        `json:"donor_relation,omitempty"`
+	DonorPhone      string        `json:"donor_phone,omitempty"`
+	CollectedAt     time.Time     `json:"collected_at"`
+	CollectionSite  string        `json:"collection_site"`
+	CollectorID     string        `json:"collector_id"`
+	Collector       *UserResponse `json:"collector,omitempty"`
+	Holder          string        `json:"holder,omitempty"`
+	Status          string        `json:"status"`
+	LabName         string        `json:"lab_name,omitempty"`
+	LabCaseNo       string        `json:"lab_case_no,omitempty"`
+	SubmittedAt     *time.Time    `json:"submitted_at,omitempty"`
+	Result          string        `json:"result,omitempty"`
+	ResultRef       string        `json:"result_ref,omitempty"`
+	ResultAt        *time.Time    `json:"result_at,omitempty"`
+	Note            string        `json:"note,omitempty"`
+	MaskedFields    []string      `json:"masked_fields,omitempty"`
+	CreatedAt       time.Time     `json:"created_at"`
+	UpdatedAt       time.Time     `json:"updated_at"`
+}
+
+// DNASampleListResponse 样本列表响应
+type DNASampleListResponse struct {
+	List       []DNASampleResponse `json:"list"`
+	Total      int64               `json:"total"`
+	Page       int                 `json:"page"`
+	PageSize   int                 `json:"page_size"`
+	TotalPages int                 `json:"total_pages"`
+}
+
+// DNASampleCustodyLogResponse 保管链记录响应
+type DNASampleCustodyLogResponse struct {
+	ID         string        `json:"id"`
+	Action     string        `json:"action"`
+	FromStatus string        `json:"from_status,omitempty"`
+	ToStatus   string        `json:"to_status"`
+	OperatorID string        `json:"operator_id"`
+	Operator   *UserResponse `json:"operator,omitempty"`
+	Holder     string        `json:"holder,omitempty"`
+	Location   string        `json:"location,omitempty"`
+	Note       string        `json:"note,omitempty"`
+	OccurredAt time.Time     `json:"occurred_at"`
+	CreatedAt  time.Time     `json:"created_at"`
+}
+
+// ToDNASampleResponse 转换为 DNA 样本响应（未脱敏）
+func ToDNASampleResponse(s *entity.DNASample) DNASampleResponse {
+	resp := DNASampleResponse{
+		ID:              s.ID,
+		MissingPersonID: s.MissingPersonID,
+		OrgID:           s.OrgID,
+		SampleNo:        s.SampleNo,
+		SubjectType:     string(s.SubjectType),
+		SampleType:      string(s.SampleType),
+		DonorName:       s.DonorName,
+		DonorRelation:   s.DonorRelation,
+		DonorPhone:      s.DonorPhone,
+		CollectedAt:     s.CollectedAt,
+		CollectionSite:  s.CollectionSite,
+		CollectorID:     s.CollectorID,
+		Holder:          s.Holder,
+		Status:          string(s.Status),
+		LabName:         s.LabName,
+		LabCaseNo:       s.LabCaseNo,
+		SubmittedAt:     s.SubmittedAt,
+		Result:          string(s.Result),
+		ResultRef:       s.ResultRef,
+		ResultAt:        s.ResultAt,
+		Note:            s.Note,
+		CreatedAt:       s.CreatedAt,
+		UpdatedAt:       s.UpdatedAt,
+	}
+	if s.Collector != nil {
+		user := ToUserResponse(s.Collector)
+		resp.Collector = &user
+	}
+	return resp
+}
+
+// ToDNASampleCustodyLogResponse 转换为保管链记录响应（未脱敏）
+func ToDNASampleCustodyLogResponse(log *entity.DNASampleCustodyLog) DNASampleCustodyLogResponse {
+	resp := DNASampleCustodyLogResponse{
+		ID:         log.ID,
+		Action:     log.Action,
+		FromStatus: string(log.FromStatus),
+		ToStatus:   string(log.ToStatus),
+		OperatorID: log.OperatorID,
+		Holder:     log.Holder,
+		Location:   log.Location,
+		Note:       log.Note,
+		OccurredAt: log.OccurredAt,
+		CreatedAt:  log.CreatedAt,
+	}
+	if log.Operator != nil {
+		user := ToUserResponse(log.Operator)
+		resp.Operator = &user
+	}
+	return resp
+}
@@ -0,0 +1,304 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+)
+
+// FamilySearcherContactRequest 寻亲者联系人
+type FamilySearcherContactRequest struct {
+	Name      string `json:"name" binding:"required,max=50"`
+	Relation  string `json:"relation" binding:"max=20"`
+	Phone     string `json:"phone" binding:"required,max=20"`
+	WeChat    string `json:"wechat" binding:"max=50"`
+	IsPrimary bool   `json:"is_primary"`
+	Note      string `json:"note" binding:"max=255"`
+}
+
+// FamilySearcherRelativeRequest 寻亲者亲属
+type FamilySearcherRelativeRequest struct {
+	Name             string  `json:"name" binding:"max=50"`
+	Relation         string  `json:"relation" binding:"required,max=20"`
+	Gender           string  `json:"gender" binding:"omitempty,oneof=male female"`
+	BirthYear        int     `json:"birth_year" binding:"omitempty,min=1900,max=2100"`
+	Hometown         string  `json:"hometown" binding:"max=255"`
+	Deceased         bool    `json:"deceased"`
+	LinkedSearcherID *string `json:"linked_searcher_id"`
+	Note             string  `json:"note" binding:"max=255"`
+}
+
+// FamilySearcherRequest 登记、修改寻亲者请求（联系人、亲属整体替换）
+type FamilySearcherRequest struct {
+	Kind      string `json:"kind" binding:"required,oneof=seeking_relative seeking_family"`
+	Status    string `json:"status" binding:"omitempty,oneof=active found closed"`
+	Name      string `json:"name" binding:"required,max=50"`
+	Gender    string `json:"gender" binding:"omitempty,oneof=male female"`
+	BirthYear int    `json:"birth_year" binding:"omitempty,min=1900,max=2100"`
+	Province  string `json:"province" binding:"max=50"`
+	City      string `json:"city" binding:"max=50"`
+
+	LostName      string     `json:"lost_name" binding:"max=50"`
+	LostGender    string     `json:"lost_gender" binding:"omitempty,oneof=male female"`
+	LostBirthYear int        `json:"lost_birth_year" binding:"omitempty,min=1900,max=2100"`
+	LostAge       int        `json:"lost_age" binding:"min=0,max=150"`
+	LostTime      *time.Time `json:"lost_time"`
+	LostProvince  string     `json:"lost_province" binding:"max=50"`
+	LostCity      string     `json:"lost_city" binding:"max=50"`
+	LostDistrict  string     `json:"lost_district" binding:"max=50"`
+	LostAddress   string     `json:"lost_address" binding:"max=255"`
+	Dialect       string     `json:"dialect" binding:"max=50"`
+	Features      string     `json:"features" binding:"max=2000"`
+	Memories      string     `json:"memories" binding:"max=5000"`
+	Description   string     `json:"description" binding:"max=5000"`
+
+	Contacts  []FamilySearcherContactRequest  `json:"contacts" binding:"required,min=1,max=10,dive"`
+	Relatives []FamilySearcherRelativeRequest `json:"relatives" binding:"max=50,dive"`
+}
+
+// FamilySearcherListRequest 寻亲者列表请求
+type FamilySearcherListRequest struct {
+	Page     int    `form:"page,default=1" binding:"min=1"`
+	PageSize int    `form:"page_size,default=20" binding:"min=1,max=100"`
+	Kind     string `form:"kind"`
+	Status   string `form:"status"`
+	OrgID    string `form:"org_id"`
+	Keyword  string `form:"keyword"`
+}
+
+// LinkSearcherCaseRequest 关联案件请求
+type LinkSearcherCaseRequest struct {
+	MissingPersonID string `json:"missing_person_id" binding:"required"`
+	Relation        string `json:"relation" binding:"max=20"`
+	Note            string `json:"note" binding:"max=255"`
+}
+
+// FamilySearcherContactResponse 寻亲者联系人响应
+type FamilySearcherContactResponse struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Relation  string `json:"relation,omitempty"`
+	Phone     string `json:"phone"`
+	WeChat    string `json:"wechat,omitempty"`
+	IsPrimary bool   `json:"is_primary"`
+	Note      string `json:"note,omitempty"`
+}
+
+// FamilySearcherRelativeResponse 寻亲者亲属响应
+type FamilySearcherRelativeResponse struct {
+	ID               string  `json:"id"`
+	Name             string  `json:"name,omitempty"`
+	Relation         string  `json:"relation"`
+	Gender           string  `json:"gender,omitempty"`
+	BirthYear        int     `json:"birth_year,omitempty"`
+	Hometown         string  `json:"hometown,omitempty"`
+	Deceased         bool    `json:"deceased"`
+	LinkedSearcherID *string `json:"linked_searcher_id,omitempty"`
+	Note             string  `json:"note,omitempty"`
+}
+
+// FamilySearcherCaseResponse 寻亲者关联案件响应
+type FamilySearcherCaseResponse struct {
+	MissingPersonID string    `json:"missing_person_id"`
+	CaseNo          string    `json:"case_no,omitempty"`
+	Name            string    `json:"name,omitempty"`
+	Status          string    `json:"status,omitempty"`
+	Relation        string    `json:"relation,omitempty"`
+	MatchScore      float64   `json:"match_score,omitempty"`
+	LinkedByID      string    `json:"linked_by_id"`
+	Note            string    `json:"note,omitempty"`
+	LinkedAt        time.Time `json:"linked_at"`
+}
+
+// FamilySearcherResponse 寻亲者响应
+type FamilySearcherResponse struct {
+	ID        string `json:"id"`
+	Kind      string `json:"kind"`
+	Status    string `json:"status"`
+	Name      string `json:"name"`
+	Gender    string `json:"gender,omitempty"`
+	BirthYear int    `json:"birth_year,omitempty"`
+	Province  string `json:"province,omitempty"`
+	City      string `json:"city,omitempty"`
+
+	LostName      string     `json:"lost_name,omitempty"`
+	LostGender    string     `json:"lost_gender,omitempty"`
+	LostBirthYear int        `json:"lost_birth_year,omitempty"`
+	LostAge       int        `json:"lost_age,omitempty"`
+	LostTime      *time.Time `json:"lost_time,omitempty"`
+	LostProvince  string     `json:"lost_province,omitempty"`
+	LostCity      string     `json:"lost_city,omitempty"`
+	LostDistrict  string     `json:"lost_district,omitempty"`
+	LostAddress   string     `json:"lost_address,omitempty"`
+	Dialect       string     `json:"dialect,omitempty"`
+	Features      string     `json:"features,omitempty"`
+	Memories      string     `json:"memories,omitempty"`
+	Description   string     `json:"description,omitempty"`
+
+	OrgID          string                           `json:"org_id"`
+	RegisteredByID string                           `json:"registered_by_id"`
+	Contacts       []FamilySearcherContactResponse  `json:"contacts"`
+	Relatives      []FamilySearcherRelativeResponse `json:"relatives,omitempty"`
+	Cases          []FamilySearcherCaseResponse     `json:"cases,omitempty"`
+	CreatedAt      time.Time                        `json:"created_at"`
+	UpdatedAt      time.Time                        `json:"updated_at"`
+}
+
+// FamilySearcherListResponse 寻亲者列表响应
+type FamilySearcherListResponse struct {
+	List       []FamilySearcherResponse `json:"list"`
+	Total      int64                    `json:"total"`
+	Page       int                      `json:"page"`
+	PageSize   int                      `json:"page_size"`
+	TotalPages int                      `json:"total_pages"`
+}
+
+// FamilyGraphNode 亲属关系图节点
+type FamilyGraphNode struct {
+	ID     string `json:"id"`
+	Type   string `json:"type"` // searcher, relative, case
+	Label  string `json:"label"`
+	Kind   string `json:"kind,omitempty"`   // 寻亲者类型
+	Status string `json:"status,omitempty"` // 寻亲者或案件状态
+}
+
+// FamilyGraphEdge 亲属关系图边
+type FamilyGraphEdge struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Relation string `json:"relation"`
+}
+
+// FamilySearcherGraphResponse 亲属关系图响应
+type FamilySearcherGraphResponse struct {
+	RootID string            `json:"root_id"`
+	Nodes  []FamilyGraphNode `json:"nodes"`
+	Edges  []FamilyGraphEdge `json:"edges"`
+}
+
+// FamilyCaseMatchResponse 寻亲者与案件比对结果
+type FamilyCaseMatchResponse struct {
+	DuplicateCandidateResponse
+	Linked bool `json:"linked"`
+}
+
+// FamilySearcherMatchResponse 寻亲者比对结果
+type FamilySearcherMatchResponse struct {
+	ID            string     `json:"id"`
+	Kind          string     `json:"kind"`
+	Name          string     `json:"name"`
+	LostName      string     `json:"lost_name,omitempty"`
+	LostGender    string     `json:"lost_gender,omitempty"`
+	LostBirthYear int        `json:"lost_birth_year,omitempty"`
+	LostAge       int        `json:"lost_age,omitempty"`
+	LostTime      *time.Time `json:"lost_time,omitempty"`
+	LostProvince  string     `json:"lost_province,omitempty"`
+	LostCity      string     `json:"lost_city,omitempty"`
+	OrgID         string     `json:"org_id"`
+	Score         float64    `json:"score"`
+	Reasons       []string   `json:"reasons"`
+	Linked        bool       `json:"linked"`
+}
+
+// FamilySearcherMatchesResponse 寻亲者比对结果：亲寻家与案件、家寻亲档案比对，家寻亲与亲寻家档案比对
+type FamilySearcherMatchesResponse struct {
+	Cases     []FamilyCaseMatchResponse     `json:"cases"`
+	Searchers []FamilySearcherMatchResponse `json:"searchers"`
+}
+
+// ToFamilySearcherResponse 转换为寻亲者响应
+func ToFamilySearcherResponse(s *entity.FamilySearcher) FamilySearcherResponse {
+	resp := FamilySearcherResponse{
+		ID:             s.ID,
+		Kind:           string(s.Kind),
+		Status:         string(s.Status),
+		Name:           s.Name,
+		Gender:         s.Gender,
+		BirthYear:      s.BirthYear,
+		Province:       s.Province,
+		City:           s.City,
+		LostName:       s.LostName,
+		LostGender:     s.LostGender,
+		LostBirthYear:  s.LostBirthYear,
+		LostAge:        s.LostAge,
+		LostTime:       s.LostTime,
+		LostProvince:   s.LostProvince,
+		LostCity:       s.LostCity,
+		LostDistrict:   s.LostDistrict,
+		LostAddress:    s.LostAddress,
+		Dialect:        s.Dialect,
+		Features:       s.Features,
+		Memories:       s.Memories,
+		Description:    s.Description,
+		OrgID:          s.OrgID,
+		RegisteredByID: s.RegisteredByID,
+		Contacts:       make([]FamilySearcherContactResponse, len(s.Contacts)),
+		CreatedAt:      s.CreatedAt,
+		UpdatedAt:      s.UpdatedAt,
+	}
+	for i, c := range s.Contacts {
+		resp.Contacts[i] = FamilySearcherContactResponse{
+			ID:        c.ID,
+			Name:      c.Name,
+			Relation:  c.Relation,
+			Phone:     c.Phone,
+			WeChat:    c.WeChat,
+			IsPrimary: c.IsPrimary,
+			Note:      c.Note,
+		}
+	}
+	for _, r := range s.Relatives {
+		resp.Relatives = append(resp.Relatives, FamilySearcherRelativeResponse{
+			ID:               r.ID,
+			Name:             r.Name,
+			Relation:         r.Relation,
+			Gender:           r.Gender,
+			BirthYear:        r.BirthYear,
+			Hometown:         r.Hometown,
+			Deceased:         r.Deceased,
+			LinkedSearcherID: r.LinkedSearcherID,
+			Note:             r.Note,
+		})
+	}
+	for i := range s.Cases {
+		resp.Cases = append(resp.Cases, ToFamilySearcherCaseResponse(&s.Cases[i]))
+	}
+	return resp
+}
+
+// ToFamilySearcherCaseResponse 转换为关联案件响应
+func ToFamilySearcherCaseResponse(link *entity.FamilySearcherCase) FamilySearcherCaseResponse {
+	resp := FamilySearcherCaseResponse{
+		MissingPersonID: link.MissingPersonID,
+		Relation:        link.Relation,
+		MatchScore:      link.MatchScore,
+		LinkedByID:      link.LinkedByID,
+		Note:            link.Note,
+		LinkedAt:        link.CreatedAt,
+	}
+	if link.MissingPerson != nil {
+		resp.CaseNo = link.MissingPerson.CaseNo
+		resp.Name = link.MissingPerson.Name
+		resp.Status = string(link.MissingPerson.Status)
+	}
+	return resp
+}
+
+// ToFamilySearcherMatchResponse 转换为寻亲者比对结果
+func ToFamilySearcherMatchResponse(s *entity.FamilySearcher, score float64, reasons []string) FamilySearcherMatchResponse {
+	return FamilySearcherMatchResponse{
+		ID:            s.ID,
+		Kind:          string(s.Kind),
+		Name:          s.Name,
+		LostName:      s.LostName,
+		LostGender:    s.LostGender,
+		LostBirthYear: s.LostBirthYear,
+		LostAge:       s.LostAge,
+		LostTime:      s.LostTime,
+		LostProvince:  s.LostProvince,
+		LostCity:      s.LostCity,
+		OrgID:         s.OrgID,
+		Score:         score,
+		Reasons:       reasons,
+	}
+}
@@ -23,6 +23,23 @@ type FileResponse struct {
 	EntityID     string    `json:"entity_id"`
 	Description  string    `json:"description"`
 	CreatedAt    time.Time `json:"created_at"`
+
+	// 图片处理结果
+	Width        int        `json:"width,omitempty"`
+	Height       int        `json:"height,omitempty"`
+	PHash        string     `json:"phash,omitempty"`
+	ThumbnailURL string     `json:"thumbnail_url,omitempty"`
+	ShareURL     string     `json:"share_url,omitempty"`
+	TakenAt      *time.Time `json:"taken_at,omitempty"`
+	Lat          float64    `json:"lat,omitempty"`
+	Lng          float64    `json:"lng,omitempty"`
+
+	// 音频处理结果
+	DurationMs  int     `json:"duration_ms,omitempty"`
+	AudioFormat string  `json:"audio_format,omitempty"`
+	Loudness    float64 `json:"loudness,omitempty"`
+	StreamURL   string  `json:"stream_url,omitempty"`
+	WaveformURL string  `json:"waveform_url,omitempty"`
 }
 
 // FileListRequest 文件列表请求
@@ -64,6 +81,7 @@ type UploadResponse struct {
 
 // ToFileResponse 转换为文件响应
 func ToFileResponse(file *entity.File) FileResponse {
+	variants := file.GetVariants()
 	return FileResponse{
 		ID:           file.ID,
 		FileName:     file.FileName,
@@ -79,6 +97,19 @@ func ToFileResponse(file *entity.File) FileResponse {
 		EntityID:     file.EntityID,
 		Description:  file.Description,
 		CreatedAt:    file.CreatedAt,
+		Width:        file.Width,
+		Height:       file.Height,
+		PHash:        file.PHash,
+		ThumbnailURL: variants[entity.FileVariantThumbnail].URL,
+		ShareURL:     variants[entity.FileVariantShare].URL,
+		TakenAt:      file.TakenAt,
+		Lat:          file.Lat,
+		Lng:          file.Lng,
+		DurationMs:   file.DurationMs,
+		AudioFormat:  file.AudioFormat,
+		Loudness:     file.Loudness,
+		StreamURL:    variants[entity.FileVariantStream].URL,
+		WaveformURL:  variants[entity.FileVariantWaveform].URL,
 	}
 }
 
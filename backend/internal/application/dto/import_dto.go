@@ -0,0 +1,134 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+)
+
+// ImportFieldResponse 可导入字段
+type ImportFieldResponse struct {
+	Key      string   `json:"key"`
+	Label    string   `json:"label"`
+	Required bool     `json:"required"`
+	Aliases  []string `json:"aliases"`
+	Example  string   `json:"example,omitempty"`
+}
+
+// CreateImportRequest 创建导入任务请求（multipart 表单，文件字段为 file）
+type CreateImportRequest struct {
+	Mapping        string `form:"mapping"`     // JSON：字段 -> 列名，未指定的字段按列名自动识别
+	TemplateID     string `form:"template_id"` // 使用已保存的列映射模板
+	DryRun         bool   `form:"dry_run"`     // 只校验和查重，不创建案件
+	SkipDuplicates *bool  `form:"skip_duplicates"`
+}
+
+// ImportJobListRequest 导入任务列表请求
+type ImportJobListRequest struct {
+	Page     int `form:"page,default=1" binding:"min=1"`
+	PageSize int `form:"page_size,default=10" binding:"min=1,max=100"`
+}
+
+// ImportJobResponse 导入任务响应
+type ImportJobResponse struct {
+	ID             string                   `json:"id"`
+	FileName       string                   `json:"file_name"`
+	Format         string                   `json:"format"`
+	Status         string                   `json:"status"`
+	DryRun         bool                     `json:"dry_run"`
+	SkipDuplicates bool                     `json:"skip_duplicates"`
+	SourceJobID    *string                  `json:"source_job_id,omitempty"`
+	Mapping        map[string]string        `json:"mapping"`
+	Header         []string                 `json:"header,omitempty"`
+	TotalRows      int                      `json:"total_rows"`
+	ProcessedRows  int                      `json:"processed_rows"`
+	CreatedRows    int                      `json:"created_rows"`
+	ValidRows      int                      `json:"valid_rows"`
+	FailedRows     int                      `json:"failed_rows"`
+	DuplicateRows  int                      `json:"duplicate_rows"`
+	Progress       int                      `json:"progress"`
+	CanConfirm     bool                     `json:"can_confirm"`
+	Error          string                   `json:"error,omitempty"`
+	CreatorID      string                   `json:"creator_id"`
+	StartedAt      *time.Time               `json:"started_at,omitempty"`
+	FinishedAt     *time.Time               `json:"finished_at,omitempty"`
+	CreatedAt      time.Time                `json:"created_at"`
+	Results        []entity.ImportRowResult `json:"results,omitempty"`
+}
+
+// ImportJobListResponse 导入任务列表响应
+type ImportJobListResponse = PageResult[ImportJobResponse]
+
+// CreateImportTemplateRequest 创建列映射模板请求
+type CreateImportTemplateRequest struct {
+	Name    string            `json:"name" binding:"required,max=100"`
+	Mapping map[string]string `json:"mapping" binding:"required"`
+}
+
+// ImportTemplateResponse 列映射模板响应
+type ImportTemplateResponse struct {
+	ID        string            `json:"id"`
+	Name      string            `json:"name"`
+	Mapping   map[string]string `json:"mapping"`
+	CreatorID string            `json:"creator_id"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// ToImportJobResponse 转换为导入任务响应，withResults 为 true 时包含逐行结果
+func ToImportJobResponse(job *entity.ImportJob, withResults bool) ImportJobResponse {
+	resp := ImportJobResponse{
+		ID:             job.ID,
+		FileName:       job.FileName,
+		Format:         job.Format,
+		Status:         string(job.Status),
+		DryRun:         job.DryRun,
+		SkipDuplicates: job.SkipDuplicates,
+		SourceJobID:    job.SourceJobID,
+		Mapping:        job.GetMapping(),
+		TotalRows:      job.TotalRows,
+		ProcessedRows:  job.ProcessedRows,
+		CreatedRows:    job.CreatedRows,
+		ValidRows:      job.ValidRows,
+		FailedRows:     job.FailedRows,
+		DuplicateRows:  job.DuplicateRows,
+		Progress:       job.Progress(),
+		CanConfirm:     job.CanConfirm(),
+		Error:          job.Error,
+		CreatorID:      job.CreatorID,
+		StartedAt:      job.StartedAt,
+		FinishedAt:     job.FinishedAt,
+		CreatedAt:      job.CreatedAt,
+	}
+	if withResults {
+		resp.Header = job.GetHeader()
+		resp.Results = job.GetResults()
+	}
+	return resp
+}
+
+// NewImportJobListResponse 创建导入任务列表响应
+func NewImportJobListResponse(list []ImportJobResponse, total int64, page, pageSize int) ImportJobListResponse {
+	totalPages := int(total) / pageSize
+	if int(total)%pageSize > 0 {
+		totalPages++
+	}
+
+	return ImportJobListResponse{
+		List:       list,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: totalPages,
+	}
+}
+
+// ToImportTemplateResponse 转换为列映射模板响应
+func ToImportTemplateResponse(t *entity.ImportTemplate) ImportTemplateResponse {
+	return ImportTemplateResponse{
+		ID:        t.ID,
+		Name:      t.Name,
+		Mapping:   t.GetMapping(),
+		CreatorID: t.CreatorID,
+		CreatedAt: t.CreatedAt,
+	}
+}
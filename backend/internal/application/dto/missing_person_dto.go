@@ -21,13 +21,15 @@ type CreateMissingPersonRequest struct {
 	City         string    `json:"city"`
 	District     string    `json:"district"`
 	Address      string    `json:"address"`
+	Lat          float64   `json:"lat"`
+	Lng          float64   `json:"lng"`
 	Clothes      string    `json:"clothes"`
 	Features     string    `json:"features"`
 	ContactName  string    `json:"contact_name" binding:"required"`
 	ContactPhone string    `json:"contact_phone" binding:"required"`
 	ContactRel   string    `json:"contact_rel"`
 	AltContact   string    `json:"alt_contact"`
-	UrgencyLevel string    `json:"urgency_level"`
+	UrgencyLevel string    `json:"urgency_level"` // 初始等级，创建后由紧急程度评分自动计算
 }
 
 // UpdateMissingPersonRequest 更新走失人员请求
@@ -45,21 +47,24 @@ type UpdateMissingPersonRequest struct {
 	City         string    `json:"city"`
 	District     string    `json:"district"`
 	Address      string    `json:"address"`
+	Lat          float64   `json:"lat"`
+	Lng          float64   `json:"lng"`
 	Clothes      string    `json:"clothes"`
 	Features     string    `json:"features"`
 	ContactName  string    `json:"contact_name"`
 	ContactPhone string    `json:"contact_phone"`
 	ContactRel   string    `json:"contact_rel"`
 	AltContact   string    `json:"alt_contact"`
-	UrgencyLevel string    `json:"urgency_level"`
+	UrgencyLevel string    `json:"urgency_level"` // 人工调整后定期评分不再覆盖等级
 }
 
 // MissingPersonPhoto 走失人员照片响应
 type MissingPersonPhoto struct {
-	ID        string `json:"id"`
-	URL       string `json:"url"`
-	Type      string `json:"type"`
-	IsPrimary bool   `json:"is_primary"`
+	ID           string `json:"id"`
+	URL          string `json:"url"`
+	ThumbnailURL string `json:"thumbnail_url,omitempty"`
+	Type         string `json:"type"`
+	IsPrimary    bool   `json:"is_primary"`
 }
 
 // MissingPersonResponse 走失人员响应
@@ -79,6 +84,8 @@ type MissingPersonResponse struct {
 	City          string               `json:"city"`
 	District      string               `json:"district"`
 	Address       string               `json:"address"`
+	Lat           float64              `json:"lat,omitempty"`
+	Lng           float64              `json:"lng,omitempty"`
 	Clothes       string               `json:"clothes"`
 	Features      string               `json:"features"`
 	ContactName   string               `json:"contact_name"`
@@ -87,6 +94,8 @@ type MissingPersonResponse struct {
 	AltContact    string               `json:"alt_contact"`
 	Status        string               `json:"status"`
 	Urgency       string               `json:"urgency"`
+	UrgencyScore  int                  `json:"urgency_score"`
+	UrgencyManual bool                 `json:"urgency_manual"`
 	Views         int                  `json:"views"`
 	ShareCount    int                  `json:"share_count"`
 	ReporterID    string               `json:"reporter_id"`
@@ -95,10 +104,18 @@ type MissingPersonResponse struct {
 	FoundTime     *time.Time           `json:"found_time,omitempty"`
 	FoundLocation string               `json:"found_location"`
 	FoundNote     string               `json:"found_note"`
+	StatusReason  string               `json:"status_reason,omitempty"`
+	MergedIntoID  *string              `json:"merged_into_id,omitempty"`
 	Reporter      *UserResponse        `json:"reporter,omitempty"`
 	Assignee      *UserResponse        `json:"assignee,omitempty"`
 	Photos        []MissingPersonPhoto `json:"photos,omitempty"`
 	CreatedAt     time.Time            `json:"created_at"`
+
+	// Duplicates 创建或更新时检测到的疑似重复案件
+	Duplicates []DuplicateCandidateResponse `json:"duplicates,omitempty"`
+
+	// Highlights 全文检索命中字段的高亮摘要，命中部分以 <em></em> 标记
+	Highlights map[string]string `json:"highlights,omitempty"`
 }
 
 // MissingPersonListRequest 走失人员列表请求
@@ -122,6 +139,31 @@ type MissingPersonListResponse = PageResult[MissingPersonResponse]
 // UpdateMissingPersonStatusRequest 更新状态请求
 type UpdateMissingPersonStatusRequest struct {
 	Status string `json:"status" binding:"required"`
+	Reason string `json:"reason" binding:"max=500"` // 关闭、重新打开时必填
+}
+
+// CaseStatusReasonRequest 关闭、重新打开案件请求
+type CaseStatusReasonRequest struct {
+	Reason string `json:"reason" binding:"required,max=500"`
+}
+
+// CaseTransitionResponse 案件当前可执行的状态转换
+type CaseTransitionResponse struct {
+	Event         string `json:"event"`
+	To            string `json:"to"`
+	RequireReason bool   `json:"require_reason"`
+}
+
+// MissingPersonStatusLogResponse 案件状态变更记录响应
+type MissingPersonStatusLogResponse struct {
+	ID         string        `json:"id"`
+	Event      string        `json:"event"`
+	FromStatus string        `json:"from_status"`
+	ToStatus   string        `json:"to_status"`
+	Reason     string        `json:"reason,omitempty"`
+	UserID     string        `json:"user_id"`
+	User       *UserResponse `json:"user,omitempty"`
+	CreatedAt  time.Time     `json:"created_at"`
 }
 
 // MarkFoundRequest 标记找到请求
@@ -148,7 +190,7 @@ type CreateMissingPersonTrackRequest struct {
 type MissingPersonTrackResponse struct {
 	ID              string        `json:"id"`
 	MissingPersonID string        `json:"missing_person_id"`
-	ReporterID      string        `json:"reporter_id"`
+	ReporterID      string        `json:"reporter_id,omitempty"`
 	Location        string        `json:"location"`
 	Province        string        `json:"province"`
 	City            string        `json:"city"`
@@ -160,6 +202,12 @@ type MissingPersonTrackResponse struct {
 	Lat             float64       `json:"lat"`
 	Lng             float64       `json:"lng"`
 	Status          string        `json:"status"`
+	Source          string        `json:"source"`
+	SubmitterName   string        `json:"submitter_name,omitempty"`
+	SubmitterPhone  string        `json:"submitter_phone,omitempty"`
+	VerifierID      string        `json:"verifier_id,omitempty"`
+	VerifiedAt      *time.Time    `json:"verified_at,omitempty"`
+	StatusReason    string        `json:"status_reason,omitempty"`
 	Reporter        *UserResponse `json:"reporter,omitempty"`
 	CreatedAt       time.Time     `json:"created_at"`
 }
@@ -195,6 +243,8 @@ func ToMissingPersonResponse(mp *entity.MissingPerson) MissingPersonResponse {
 		City:          mp.City,
 		District:      mp.District,
 		Address:       mp.Address,
+		Lat:           mp.Lat,
+		Lng:           mp.Lng,
 		Clothes:       mp.Clothes,
 		Features:      mp.Features,
 		ContactName:   mp.ContactName,
@@ -203,6 +253,8 @@ func ToMissingPersonResponse(mp *entity.MissingPerson) MissingPersonResponse {
 		AltContact:    mp.AltContact,
 		Status:        string(mp.Status),
 		Urgency:       string(mp.Urgency),
+		UrgencyScore:  mp.UrgencyScore,
+		UrgencyManual: mp.UrgencyManual,
 		Views:         mp.Views,
 		ShareCount:    mp.ShareCount,
 		ReporterID:    mp.ReporterID,
@@ -211,6 +263,8 @@ func ToMissingPersonResponse(mp *entity.MissingPerson) MissingPersonResponse {
 		FoundTime:     mp.FoundTime,
 		FoundLocation: mp.FoundLocation,
 		FoundNote:     mp.FoundNote,
+		StatusReason:  mp.StatusReason,
+		MergedIntoID:  mp.MergedIntoID,
 		CreatedAt:     mp.CreatedAt,
 	}
 
@@ -228,10 +282,11 @@ func ToMissingPersonResponse(mp *entity.MissingPerson) MissingPersonResponse {
 		resp.Photos = make([]MissingPersonPhoto, len(mp.Photos))
 		for i, photo := range mp.Photos {
 			resp.Photos[i] = MissingPersonPhoto{
-				ID:        photo.ID,
-				URL:       photo.URL,
-				Type:      photo.Type,
-				IsPrimary: photo.IsPrimary,
+				ID:           photo.ID,
+				URL:          photo.URL,
+				ThumbnailURL: photo.ThumbnailURL,
+				Type:         photo.Type,
+				IsPrimary:    photo.IsPrimary,
 			}
 		}
 	}
@@ -244,7 +299,6 @@ func ToMissingPersonTrackResponse(track *entity.MissingPersonTrack) MissingPerso
 	resp := MissingPersonTrackResponse{
 		ID:              track.ID,
 		MissingPersonID: track.MissingPersonID,
-		ReporterID:      track.ReporterID,
 		Location:        track.Location,
 		Province:        track.Province,
 		City:            track.City,
@@ -256,9 +310,20 @@ func ToMissingPersonTrackResponse(track *entity.MissingPersonTrack) MissingPerso
 		Lat:             track.Lat,
 		Lng:             track.Lng,
 		Status:          track.Status,
+		Source:          track.Source,
+		SubmitterName:   track.SubmitterName,
+		SubmitterPhone:  track.SubmitterPhone,
+		VerifiedAt:      track.VerifiedAt,
+		StatusReason:    track.StatusReason,
 		CreatedAt:       track.CreatedAt,
 	}
 
+	if track.ReporterID != nil {
+		resp.ReporterID = *track.ReporterID
+	}
+	if track.VerifierID != nil {
+		resp.VerifierID = *track.VerifierID
+	}
 	if track.Reporter != nil {
 		reporter := ToUserResponse(track.Reporter)
 		resp.Reporter = &reporter
@@ -282,3 +347,21 @@ func NewMissingPersonListResponse(list []MissingPersonResponse, total int64, pag
 		TotalPages: totalPages,
 	}
 }
+
+// ToMissingPersonStatusLogResponse 转换为案件状态变更记录响应
+func ToMissingPersonStatusLogResponse(log *entity.MissingPersonStatusLog) MissingPersonStatusLogResponse {
+	resp := MissingPersonStatusLogResponse{
+		ID:         log.ID,
+		Event:      log.Event,
+		FromStatus: string(log.FromStatus),
+		ToStatus:   string(log.ToStatus),
+		Reason:     log.Reason,
+		UserID:     log.UserID,
+		CreatedAt:  log.CreatedAt,
+	}
+	if log.User != nil {
+		user := ToUserResponse(log.User)
+		resp.User = &user
+	}
+	return resp
+}
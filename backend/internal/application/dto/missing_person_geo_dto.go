@@ -0,0 +1,63 @@
+package dto
+
+import (
+	"time"
+)
+
+// NearbyTracksRequest 半径范围线索查询请求
+type NearbyTracksRequest struct {
+	Lat             float64    `form:"lat" binding:"required,min=-90,max=90"`
+	Lng             float64    `form:"lng" binding:"required,min=-180,max=180"`
+	RadiusKm        float64    `form:"radius_km,default=5" binding:"gt=0,max=500"`
+	MissingPersonID string     `form:"missing_person_id"`
+	Status          string     `form:"status"`
+	Since           *time.Time `form:"since" time_format:"2006-01-02T15:04:05Z07:00"`
+	Limit           int        `form:"limit,default=100" binding:"min=1,max=1000"`
+}
+
+// MapBoundsRequest 地图视野查询请求
+type MapBoundsRequest struct {
+	MinLat          float64    `form:"min_lat" binding:"min=-90,max=90"`
+	MinLng          float64    `form:"min_lng" binding:"min=-180,max=180"`
+	MaxLat          float64    `form:"max_lat" binding:"min=-90,max=90"`
+	MaxLng          float64    `form:"max_lng" binding:"min=-180,max=180"`
+	MissingPersonID string     `form:"missing_person_id"`
+	Status          string     `form:"status"`
+	ActiveOnly      bool       `form:"active_only"`
+	Since           *time.Time `form:"since" time_format:"2006-01-02T15:04:05Z07:00"`
+	Limit           int        `form:"limit,default=500" binding:"min=1,max=1000"`
+}
+
+// NearestCasesRequest 最近活跃案件查询请求
+type NearestCasesRequest struct {
+	Lat      float64 `form:"lat" binding:"required,min=-90,max=90"`
+	Lng      float64 `form:"lng" binding:"required,min=-180,max=180"`
+	RadiusKm float64 `form:"radius_km,default=50" binding:"gt=0,max=500"`
+	Limit    int     `form:"limit,default=20" binding:"min=1,max=100"`
+}
+
+// NearbyTrackResponse 带距离的线索响应
+type NearbyTrackResponse struct {
+	MissingPersonTrackResponse
+	DistanceKm float64 `json:"distance_km"`
+}
+
+// NearestCaseResponse 带距离的案件响应
+type NearestCaseResponse struct {
+	MissingPersonResponse
+	DistanceKm     float64 `json:"distance_km"`
+	NearestTrackID string  `json:"nearest_track_id,omitempty"`
+}
+
+// MapMarkersResponse 地图视野内的案件与线索
+type MapMarkersResponse struct {
+	Cases  []MissingPersonResponse      `json:"cases"`
+	Tracks []MissingPersonTrackResponse `json:"tracks"`
+	Mode   string                       `json:"mode"`
+}
+
+// TimelineRequest 案件时间线请求
+type TimelineRequest struct {
+	MaxSpeedKmh     float64 `form:"max_speed_kmh" binding:"omitempty,gt=0,max=1000"`
+	ClusterRadiusKm float64 `form:"cluster_radius_km" binding:"omitempty,gt=0,max=50"`
+}
@@ -0,0 +1,93 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+)
+
+// DuplicateCandidateResponse 疑似重复案件响应
+type DuplicateCandidateResponse struct {
+	ID          string    `json:"id"`
+	CaseNo      string    `json:"case_no"`
+	Name        string    `json:"name"`
+	Gender      string    `json:"gender"`
+	Age         int       `json:"age"`
+	MissingTime time.Time `json:"missing_time"`
+	Province    string    `json:"province"`
+	City        string    `json:"city"`
+	District    string    `json:"district"`
+	Status      string    `json:"status"`
+	OrgID       string    `json:"org_id"`
+	Score       float64   `json:"score"`
+	Reasons     []string  `json:"reasons"`
+}
+
+// MergeMissingPersonRequest 合并重复案件请求
+type MergeMissingPersonRequest struct {
+	MergedID string `json:"merged_id" binding:"required"`
+	Reason   string `json:"reason"`
+}
+
+// MissingPersonMergeResponse 合并记录响应
+type MissingPersonMergeResponse struct {
+	ID             string        `json:"id"`
+	SurvivorID     string        `json:"survivor_id"`
+	SurvivorCaseNo string        `json:"survivor_case_no"`
+	MergedID       string        `json:"merged_id"`
+	MergedCaseNo   string        `json:"merged_case_no"`
+	OperatorID     string        `json:"operator_id"`
+	Score          float64       `json:"score"`
+	Reason         string        `json:"reason,omitempty"`
+	TrackCount     int64         `json:"track_count"`
+	PhotoCount     int64         `json:"photo_count"`
+	TaskCount      int64         `json:"task_count"`
+	FileCount      int64         `json:"file_count"`
+	Operator       *UserResponse `json:"operator,omitempty"`
+	CreatedAt      time.Time     `json:"created_at"`
+}
+
+// ToDuplicateCandidateResponse 转换为疑似重复案件响应
+func ToDuplicateCandidateResponse(mp *entity.MissingPerson, score float64, reasons []string) DuplicateCandidateResponse {
+	return DuplicateCandidateResponse{
+		ID:          mp.ID,
+		CaseNo:      mp.CaseNo,
+		Name:        mp.Name,
+		Gender:      mp.Gender,
+		Age:         mp.GetAgeAtMissing(),
+		MissingTime: mp.MissingTime,
+		Province:    mp.Province,
+		City:        mp.City,
+		District:    mp.District,
+		Status:      string(mp.Status),
+		OrgID:       mp.OrgID,
+		Score:       score,
+		Reasons:     reasons,
+	}
+}
+
+// ToMissingPersonMergeResponse 转换为合并记录响应
+func ToMissingPersonMergeResponse(record *entity.MissingPersonMerge) MissingPersonMergeResponse {
+	resp := MissingPersonMergeResponse{
+		ID:             record.ID,
+		SurvivorID:     record.SurvivorID,
+		SurvivorCaseNo: record.SurvivorCaseNo,
+		MergedID:       record.MergedID,
+		MergedCaseNo:   record.MergedCaseNo,
+		OperatorID:     record.OperatorID,
+		Score:          record.Score,
+		Reason:         record.Reason,
+		TrackCount:     record.TrackCount,
+		PhotoCount:     record.PhotoCount,
+		TaskCount:      record.TaskCount,
+		FileCount:      record.FileCount,
+		CreatedAt:      record.CreatedAt,
+	}
+
+	if record.Operator != nil {
+		operator := ToUserResponse(record.Operator)
+		resp.Operator = &operator
+	}
+
+	return resp
+}
@@ -0,0 +1,84 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+)
+
+// AddMissingPhotoRequest 上传走失人员照片请求（multipart 表单，文件字段为 file）
+type AddMissingPhotoRequest struct {
+	Type        string `form:"type"` // normal, simulated, feature，默认 normal
+	Description string `form:"description" binding:"max=500"`
+	IsPrimary   bool   `form:"is_primary"`
+}
+
+// MissingPhotoResponse 走失人员照片响应
+type MissingPhotoResponse struct {
+	ID              string     `json:"id"`
+	MissingPersonID string     `json:"missing_person_id"`
+	FileID          string     `json:"file_id,omitempty"`
+	URL             string     `json:"url"`
+	ThumbnailURL    string     `json:"thumbnail_url,omitempty"`
+	ShareURL        string     `json:"share_url,omitempty"`
+	Type            string     `json:"type"`
+	Description     string     `json:"description,omitempty"`
+	IsPrimary       bool       `json:"is_primary"`
+	Width           int        `json:"width,omitempty"`
+	Height          int        `json:"height,omitempty"`
+	TakenAt         *time.Time `json:"taken_at,omitempty"`
+	Lat             float64    `json:"lat,omitempty"`
+	Lng             float64    `json:"lng,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// PhotoTrackHint 照片 EXIF 中的拍摄时间和位置，可作为线索参考
+type PhotoTrackHint struct {
+	TakenAt *time.Time `json:"taken_at,omitempty"`
+	Lat     float64    `json:"lat,omitempty"`
+	Lng     float64    `json:"lng,omitempty"`
+	HasGPS  bool       `json:"has_gps"`
+}
+
+// PhotoMatchResponse 相似照片响应，Photo 为本案件照片，MatchedPhoto 为其它案件照片
+type PhotoMatchResponse struct {
+	ID              string                `json:"id"`
+	Distance        int                   `json:"distance"`
+	Photo           *MissingPhotoResponse `json:"photo,omitempty"`
+	MatchedPhoto    *MissingPhotoResponse `json:"matched_photo,omitempty"`
+	MatchedPersonID string                `json:"matched_person_id"`
+	MatchedCaseNo   string                `json:"matched_case_no,omitempty"`
+	MatchedName     string                `json:"matched_name,omitempty"`
+	CreatedAt       time.Time             `json:"created_at"`
+}
+
+// AddMissingPhotoResponse 上传照片结果响应
+type AddMissingPhotoResponse struct {
+	Photo     MissingPhotoResponse `json:"photo"`
+	TrackHint *PhotoTrackHint      `json:"track_hint,omitempty"`
+	Matches   []PhotoMatchResponse `json:"matches"`
+}
+
+// ToMissingPhotoResponse 转换为走失人员照片响应
+func ToMissingPhotoResponse(photo *entity.MissingPhoto) MissingPhotoResponse {
+	resp := MissingPhotoResponse{
+		ID:              photo.ID,
+		MissingPersonID: photo.MissingPersonID,
+		URL:             photo.URL,
+		ThumbnailURL:    photo.ThumbnailURL,
+		ShareURL:        photo.ShareURL,
+		Type:            photo.Type,
+		Description:     photo.Description,
+		IsPrimary:       photo.IsPrimary,
+		Width:           photo.Width,
+		Height:          photo.Height,
+		TakenAt:         photo.TakenAt,
+		Lat:             photo.Lat,
+		Lng:             photo.Lng,
+		CreatedAt:       photo.CreatedAt,
+	}
+	if photo.FileID != nil {
+		resp.FileID = *photo.FileID
+	}
+	return resp
+}
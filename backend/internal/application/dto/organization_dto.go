@@ -8,27 +8,31 @@ import (
 
 // CreateOrganizationRequest 创建组织请求
 type CreateOrganizationRequest struct {
-	Name         string `json:"name" binding:"required"`
-	Code         string `json:"code" binding:"required"`
-	Type         string `json:"type" binding:"required"`
-	ParentID     string `json:"parent_id"`
-	Description  string `json:"description"`
-	Address      string `json:"address"`
-	ContactName  string `json:"contact_name"`
-	ContactPhone string `json:"contact_phone"`
-	SortOrder    int    `json:"sort_order"`
+	Name         string  `json:"name" binding:"required"`
+	Code         string  `json:"code" binding:"required"`
+	Type         string  `json:"type" binding:"required"`
+	ParentID     string  `json:"parent_id"`
+	Description  string  `json:"description"`
+	Address      string  `json:"address"`
+	Lat          float64 `json:"lat" binding:"min=-90,max=90"`
+	Lng          float64 `json:"lng" binding:"min=-180,max=180"`
+	ContactName  string  `json:"contact_name"`
+	ContactPhone string  `json:"contact_phone"`
+	SortOrder    int     `json:"sort_order"`
 }
 
 // UpdateOrganizationRequest 更新组织请求
 type UpdateOrganizationRequest struct {
-	Name         string `json:"name"`
-	Code         string `json:"code"`
-	Description  string `json:"description"`
-	Address      string `json:"address"`
-	ContactName  string `json:"contact_name"`
-	ContactPhone string `json:"contact_phone"`
-	Status       string `json:"status"`
-	SortOrder    int    `json:"sort_order"`
+	Name         string   `json:"name"`
+	Code         string   `json:"code"`
+	Description  string   `json:"description"`
+	Address      string   `json:"address"`
+	Lat          *float64 `json:"lat" binding:"omitempty,min=-90,max=90"`
+	Lng          *float64 `json:"lng" binding:"omitempty,min=-180,max=180"`
+	ContactName  string   `json:"contact_name"`
+	ContactPhone string   `json:"contact_phone"`
+	Status       string   `json:"status"`
+	SortOrder    int      `json:"sort_order"`
 }
 
 // OrganizationResponse 组织响应
@@ -41,6 +45,8 @@ type OrganizationResponse struct {
 	ParentID     *string                `json:"parent_id,omitempty"`
 	Description  string                 `json:"description"`
 	Address      string                 `json:"address"`
+	Lat          float64                `json:"lat,omitempty"`
+	Lng          float64                `json:"lng,omitempty"`
 	ContactName  string                 `json:"contact_name"`
 	ContactPhone string                 `json:"contact_phone"`
 	Status       string                 `json:"status"`
@@ -95,6 +101,8 @@ func ToOrganizationResponse(org *entity.Organization) OrganizationResponse {
 		ParentID:     org.ParentID,
 		Description:  org.Description,
 		Address:      org.Address,
+		Lat:          org.Lat,
+		Lng:          org.Lng,
 		ContactName:  org.ContactName,
 		ContactPhone: org.ContactPhone,
 		Status:       string(org.Status),
@@ -128,6 +136,8 @@ func ToOrganizationTreeResponse(node *entity.OrgTreeNode) *OrganizationTreeRespo
 			ParentID:     node.ParentID,
 			Description:  node.Description,
 			Address:      node.Address,
+			Lat:          node.Lat,
+			Lng:          node.Lng,
 			ContactName:  node.ContactName,
 			ContactPhone: node.ContactPhone,
 			Status:       string(node.Status),
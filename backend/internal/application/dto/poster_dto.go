@@ -0,0 +1,19 @@
+package dto
+
+// 海报格式
+const (
+	PosterFormatPDF = "pdf" // A4 打印版
+	PosterFormatPNG = "png" // 朋友圈分享卡片
+)
+
+// PosterRequest 生成海报请求
+type PosterRequest struct {
+	Format string `form:"format"` // pdf, png，默认 pdf
+}
+
+// PosterFile 生成的海报文件
+type PosterFile struct {
+	Data        []byte
+	ContentType string
+	FileName    string
+}
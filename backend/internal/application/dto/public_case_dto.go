@@ -0,0 +1,142 @@
+package dto
+
+import (
+	"math"
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+)
+
+// PublicCaseListRequest 公开案件列表请求
+type PublicCaseListRequest struct {
+	Page     int    `form:"page,default=1" binding:"min=1"`
+	PageSize int    `form:"page_size,default=20" binding:"min=1,max=50"`
+	Keyword  string `form:"keyword" binding:"max=50"`
+	Gender   string `form:"gender"`
+	Province string `form:"province"`
+	City     string `form:"city"`
+}
+
+// PublicCaseResponse 公开案件响应：只列出可公开的字段，不含联系人、报案人、负责人和内部处理信息；
+// 字段名不得命中 entity.IsSensitiveField，自由文本中的电话和证件号脱敏
+type PublicCaseResponse struct {
+	ID          string               `json:"id"`
+	CaseNo      string               `json:"case_no"`
+	Name        string               `json:"name"`
+	Gender      string               `json:"gender"`
+	Age         int                  `json:"age"`
+	Height      int                  `json:"height,omitempty"`
+	Weight      int                  `json:"weight,omitempty"`
+	Description string               `json:"description"`
+	PhotoUrl    string               `json:"photo_url"`
+	Photos      []MissingPersonPhoto `json:"photos,omitempty"`
+	MissingTime time.Time            `json:"missing_time"`
+	Province    string               `json:"province"`
+	City        string               `json:"city"`
+	District    string               `json:"district"`
+	Address     string               `json:"address"`
+	Lat         float64              `json:"lat,omitempty"`
+	Lng         float64              `json:"lng,omitempty"`
+	Clothes     string               `json:"clothes"`
+	Features    string               `json:"features"`
+	Status      string               `json:"status"`
+	Urgency     string               `json:"urgency"`
+	Views       int                  `json:"views"`
+	ShareCount  int                  `json:"share_count"`
+	CreatedAt   time.Time            `json:"created_at"`
+}
+
+// PublicCaseListResponse 公开案件列表响应
+type PublicCaseListResponse = PageResult[PublicCaseResponse]
+
+// SubmitLeadRequest 公众提交线索请求
+type SubmitLeadRequest struct {
+	Description   string    `json:"description" binding:"required,min=5,max=2000"`
+	Time          time.Time `json:"time" binding:"required"`
+	Location      string    `json:"location" binding:"max=255"`
+	Province      string    `json:"province" binding:"max=50"`
+	City          string    `json:"city" binding:"max=50"`
+	District      string    `json:"district" binding:"max=50"`
+	Address       string    `json:"address" binding:"max=255"`
+	Lat           float64   `json:"lat" binding:"min=-90,max=90"`
+	Lng           float64   `json:"lng" binding:"min=-180,max=180"`
+	ContactName   string    `json:"contact_name" binding:"max=50"`
+	ContactPhone  string    `json:"contact_phone" binding:"max=20"`
+	CaptchaToken  string    `json:"captcha_token"`
+	CaptchaAnswer string    `json:"captcha_answer"`
+}
+
+// SubmitLeadResponse 公众提交线索响应
+type SubmitLeadResponse struct {
+	ID        string    `json:"id"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CaptchaResponse 验证码响应
+type CaptchaResponse struct {
+	Enabled   bool       `json:"enabled"`
+	Token     string     `json:"token,omitempty"`
+	Question  string     `json:"question,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// publicCoordinatePrecision 公开坐标保留的小数位（约 100 米）
+const publicCoordinatePrecision = 1000
+
+// ToPublicCaseResponse 转换为公开案件响应，坐标降低精度，自由文本中的电话和证件号脱敏
+func ToPublicCaseResponse(mp *entity.MissingPerson) PublicCaseResponse {
+	resp := PublicCaseResponse{
+		ID:          mp.ID,
+		CaseNo:      mp.CaseNo,
+		Name:        mp.Name,
+		Gender:      mp.Gender,
+		Age:         mp.Age,
+		Height:      mp.Height,
+		Weight:      mp.Weight,
+		Description: entity.MaskSensitiveText(mp.Description),
+		PhotoUrl:    mp.PhotoUrl,
+		MissingTime: mp.MissingTime,
+		Province:    mp.Province,
+		City:        mp.City,
+		District:    mp.District,
+		Address:     entity.MaskSensitiveText(mp.Address),
+		Lat:         math.Round(mp.Lat*publicCoordinatePrecision) / publicCoordinatePrecision,
+		Lng:         math.Round(mp.Lng*publicCoordinatePrecision) / publicCoordinatePrecision,
+		Clothes:     entity.MaskSensitiveText(mp.Clothes),
+		Features:    entity.MaskSensitiveText(mp.Features),
+		Status:      string(mp.Status),
+		Urgency:     string(mp.Urgency),
+		Views:       mp.Views,
+		ShareCount:  mp.ShareCount,
+		CreatedAt:   mp.CreatedAt,
+	}
+
+	for _, photo := range mp.Photos {
+		resp.Photos = append(resp.Photos, MissingPersonPhoto{
+			ID:           photo.ID,
+			URL:          photo.URL,
+			ThumbnailURL: photo.ThumbnailURL,
+			Type:         photo.Type,
+			IsPrimary:    photo.IsPrimary,
+		})
+	}
+
+	return resp
+}
+
+// NewPublicCaseListResponse 创建公开案件列表响应
+func NewPublicCaseListResponse(list []PublicCaseResponse, total int64, page, pageSize int) PublicCaseListResponse {
+	totalPages := int(total) / pageSize
+	if int(total)%pageSize > 0 {
+		totalPages++
+	}
+
+	return PublicCaseListResponse{
+		List:       list,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: totalPages,
+	}
+}
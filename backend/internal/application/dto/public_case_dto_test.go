@@ -0,0 +1,51 @@
+package dto
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToPublicCaseResponse(t *testing.T) {
+	mp := &entity.MissingPerson{
+		BaseEntity:   entity.BaseEntity{ID: "mp-1"},
+		CaseNo:       "MP001",
+		Name:         "张三",
+		Description:  "家属电话 13812345678",
+		Address:      "人民路 1 号，联系 010-12345678",
+		Features:     "身份证 11010519491231002X",
+		Clothes:      "红色外套",
+		Lat:          30.123456,
+		Lng:          104.987654,
+		ContactName:  "李四",
+		ContactPhone: "13900000000",
+		AltContact:   "13700000000",
+		ReporterID:   "reporter-1",
+		OrgID:        "org-1",
+		StatusReason: "内部备注",
+	}
+
+	resp := ToPublicCaseResponse(mp)
+	assert.Equal(t, "家属电话 ***", resp.Description)
+	assert.Equal(t, "人民路 1 号，联系 ***", resp.Address)
+	assert.Equal(t, "身份证 ***", resp.Features)
+	assert.Equal(t, "红色外套", resp.Clothes)
+	assert.Equal(t, 30.123, resp.Lat)
+	assert.Equal(t, 104.988, resp.Lng)
+
+	raw, err := json.Marshal(resp)
+	require.NoError(t, err)
+	var data map[string]interface{}
+	require.NoError(t, json.Unmarshal(raw, &data))
+
+	// 公开字段不得命中敏感字段规则，经 MaskSensitiveData 处理后保持不变
+	assert.Equal(t, data, entity.MaskSensitiveData(data))
+	for _, field := range []string{"contact_name", "contact_phone", "alt_contact", "reporter_id", "org_id", "status_reason"} {
+		assert.NotContains(t, data, field)
+	}
+	assert.NotContains(t, string(raw), "13900000000")
+	assert.NotContains(t, string(raw), "内部备注")
+}
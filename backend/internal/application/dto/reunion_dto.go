@@ -0,0 +1,151 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+)
+
+// ReunionRecordRequest 团聚记录请求（标记团聚、修改团聚记录）
+type ReunionRecordRequest struct {
+	ConfirmedByID       string    `json:"confirmed_by_id"` // 为空时为当前用户
+	ConfirmerName       string    `json:"confirmer_name" binding:"max=100"`
+	VerificationMethods []string  `json:"verification_methods" binding:"required,min=1,dive,oneof=dna documents family_recognition other"`
+	VerificationNote    string    `json:"verification_note" binding:"max=2000"`
+	ReunionTime         time.Time `json:"reunion_time" binding:"required"`
+	Location            string    `json:"location" binding:"max=200"`
+	Province            string    `json:"province" binding:"max=50"`
+	City                string    `json:"city" binding:"max=50"`
+	District            string    `json:"district" binding:"max=50"`
+	Address             string    `json:"address" binding:"max=200"`
+	Lat                 float64   `json:"lat"`
+	Lng                 float64   `json:"lng"`
+	PublicityConsent    bool      `json:"publicity_consent"`
+	ConsentBy           string    `json:"consent_by" binding:"max=100"`
+	Photos              []string  `json:"photos" binding:"max=20,dive,max=500"`
+	Note                string    `json:"note" binding:"max=2000"`
+}
+
+// CompleteFollowUpRequest 记录回访结果请求
+type CompleteFollowUpRequest struct {
+	Outcome   string     `json:"outcome" binding:"required,oneof=stable needs_support missing_again unreachable"`
+	Note      string     `json:"note" binding:"max=2000"`
+	VisitedAt *time.Time `json:"visited_at"`
+}
+
+// ReunionFollowUpResponse 回访响应
+type ReunionFollowUpResponse struct {
+	ID        string     `json:"id"`
+	Month     int        `json:"month"`
+	DueAt     time.Time  `json:"due_at"`
+	Status    string     `json:"status"`
+	Overdue   bool       `json:"overdue"`
+	TaskID    string     `json:"task_id,omitempty"`
+	Outcome   string     `json:"outcome,omitempty"`
+	Note      string     `json:"note,omitempty"`
+	VisitorID string     `json:"visitor_id,omitempty"`
+	VisitedAt *time.Time `json:"visited_at,omitempty"`
+}
+
+// ReunionRecordResponse 团聚记录响应
+type ReunionRecordResponse struct {
+	ID                  string                    `json:"id"`
+	MissingPersonID     string                    `json:"missing_person_id"`
+	RecordedByID        string                    `json:"recorded_by_id"`
+	ConfirmedByID       string                    `json:"confirmed_by_id"`
+	ConfirmedBy         *UserResponse             `json:"confirmed_by,omitempty"`
+	ConfirmerName       string                    `json:"confirmer_name,omitempty"`
+	VerificationMethods []string                  `json:"verification_methods"`
+	VerificationNote    string                    `json:"verification_note,omitempty"`
+	ReunionTime         time.Time                 `json:"reunion_time"`
+	Location            string                    `json:"location,omitempty"`
+	Province            string                    `json:"province,omitempty"`
+	City                string                    `json:"city,omitempty"`
+	District            string                    `json:"district,omitempty"`
+	Address             string                    `json:"address,omitempty"`
+	Lat                 float64                   `json:"lat,omitempty"`
+	Lng                 float64                   `json:"lng,omitempty"`
+	PublicityConsent    bool                      `json:"publicity_consent"`
+	ConsentBy           string                    `json:"consent_by,omitempty"`
+	Photos              []string                  `json:"photos,omitempty"`
+	Note                string                    `json:"note,omitempty"`
+	FollowUps           []ReunionFollowUpResponse `json:"follow_ups"`
+	CreatedAt           time.Time                 `json:"created_at"`
+	UpdatedAt           time.Time                 `json:"updated_at"`
+}
+
+// FollowUpReportItem 回访节点统计
+type FollowUpReportItem struct {
+	Month     int              `json:"month"`
+	Total     int64            `json:"total"`
+	Scheduled int64            `json:"scheduled"` // 未到期，尚未生成任务
+	Tasked    int64            `json:"tasked"`    // 已生成任务，待回访
+	Completed int64            `json:"completed"`
+	Overdue   int64            `json:"overdue"`  // 已过期限仍未回访
+	Outcomes  map[string]int64 `json:"outcomes"` // 已回访的结果分布
+}
+
+// FollowUpReportResponse 团聚回访长期结果报告
+type FollowUpReportResponse struct {
+	OrgID       string               `json:"org_id,omitempty"`
+	Items       []FollowUpReportItem `json:"items"`
+	GeneratedAt time.Time            `json:"generated_at"`
+}
+
+// ToReunionFollowUpResponse 转换为回访响应
+func ToReunionFollowUpResponse(f *entity.ReunionFollowUp, now time.Time) ReunionFollowUpResponse {
+	resp := ReunionFollowUpResponse{
+		ID:        f.ID,
+		Month:     f.Month,
+		DueAt:     f.DueAt,
+		Status:    string(f.Status),
+		Overdue:   f.IsOverdue(now),
+		Outcome:   string(f.Outcome),
+		Note:      f.Note,
+		VisitedAt: f.VisitedAt,
+	}
+	if f.TaskID != nil {
+		resp.TaskID = *f.TaskID
+	}
+	if f.VisitorID != nil {
+		resp.VisitorID = *f.VisitorID
+	}
+	return resp
+}
+
+// ToReunionRecordResponse 转换为团聚记录响应
+func ToReunionRecordResponse(r *entity.ReunionRecord) ReunionRecordResponse {
+	resp := ReunionRecordResponse{
+		ID:                  r.ID,
+		MissingPersonID:     r.MissingPersonID,
+		RecordedByID:        r.RecordedByID,
+		ConfirmedByID:       r.ConfirmedByID,
+		ConfirmerName:       r.ConfirmerName,
+		VerificationMethods: r.GetVerificationMethods(),
+		VerificationNote:    r.VerificationNote,
+		ReunionTime:         r.ReunionTime,
+		Location:            r.Location,
+		Province:            r.Province,
+		City:                r.City,
+		District:            r.District,
+		Address:             r.Address,
+		Lat:                 r.Lat,
+		Lng:                 r.Lng,
+		PublicityConsent:    r.PublicityConsent,
+		ConsentBy:           r.ConsentBy,
+		Photos:              r.GetPhotos(),
+		Note:                r.Note,
+		FollowUps:           make([]ReunionFollowUpResponse, len(r.FollowUps)),
+		CreatedAt:           r.CreatedAt,
+		UpdatedAt:           r.UpdatedAt,
+	}
+	if r.ConfirmedBy != nil {
+		user := ToUserResponse(r.ConfirmedBy)
+		resp.ConfirmedBy = &user
+	}
+	now := time.Now()
+	for i := range r.FollowUps {
+		resp.FollowUps[i] = ToReunionFollowUpResponse(&r.FollowUps[i], now)
+	}
+	return resp
+}
@@ -0,0 +1,115 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+)
+
+// DispatchCandidateRequest 派单候选人查询请求
+type DispatchCandidateRequest struct {
+	Limit int `form:"limit,default=20" binding:"min=1,max=100"`
+}
+
+// DispatchCandidateResponse 派单候选志愿者及评分
+type DispatchCandidateResponse struct {
+	UserID         string     `json:"user_id"`
+	Nickname       string     `json:"nickname"`
+	DistanceKm     *float64   `json:"distance_km,omitempty"`     // 位置未知时为空
+	LocationSource string     `json:"location_source,omitempty"` // home / last_known
+	LocatedAt      *time.Time `json:"located_at,omitempty"`
+	Skills         []string   `json:"skills,omitempty"`
+	Languages      []string   `json:"languages,omitempty"`
+	OpenTasks      int        `json:"open_tasks"`
+	SkillFit       float64    `json:"skill_fit"`
+	LanguageFit    float64    `json:"language_fit"`
+	MissingSkills  []string   `json:"missing_skills,omitempty"`
+	Score          float64    `json:"score"`
+	Offered        bool       `json:"offered"` // 本任务已推送过
+}
+
+// ToDispatchCandidateResponse 转换为派单候选人响应
+func ToDispatchCandidateResponse(m *entity.DispatchMatch) DispatchCandidateResponse {
+	resp := DispatchCandidateResponse{
+		UserID:         m.Candidate.UserID,
+		Nickname:       m.Candidate.Nickname,
+		LocationSource: m.Candidate.LocationSource,
+		LocatedAt:      m.Candidate.LocatedAt,
+		Skills:         m.Candidate.Skills,
+		Languages:      m.Candidate.Languages,
+		OpenTasks:      m.Candidate.OpenTasks,
+		SkillFit:       m.SkillFit,
+		LanguageFit:    m.LanguageFit,
+		MissingSkills:  m.MissingSkills,
+		Score:          m.Score,
+	}
+	if m.DistanceKm >= 0 {
+		d := m.DistanceKm
+		resp.DistanceKm = &d
+	}
+	return resp
+}
+
+// DispatchTaskRequest 手动发起派单请求
+type DispatchTaskRequest struct {
+	UserIDs []string `json:"user_ids" binding:"max=20"`             // 指定推送的志愿者，为空时按评分取前几位
+	Size    int      `json:"size" binding:"omitempty,min=1,max=20"` // 推送人数，为空时使用默认配置
+}
+
+// DeclineTaskOfferRequest 拒绝派单请求
+type DeclineTaskOfferRequest struct {
+	Reason string `json:"reason" binding:"max=200"`
+}
+
+// TaskOfferResponse 派单邀请响应
+type TaskOfferResponse struct {
+	ID          string        `json:"id"`
+	TaskID      string        `json:"task_id"`
+	UserID      string        `json:"user_id"`
+	Round       int           `json:"round"`
+	Position    int           `json:"position"`
+	Score       float64       `json:"score"`
+	DistanceKm  *float64      `json:"distance_km,omitempty"`
+	Status      string        `json:"status"`
+	ExpiresAt   time.Time     `json:"expires_at"`
+	RespondedAt *time.Time    `json:"responded_at,omitempty"`
+	Reason      string        `json:"reason,omitempty"`
+	CreatedAt   time.Time     `json:"created_at"`
+	Task        *TaskResponse `json:"task,omitempty"`
+	User        *UserResponse `json:"user,omitempty"`
+}
+
+// ToTaskOfferResponse 转换为派单邀请响应
+func ToTaskOfferResponse(o *entity.TaskOffer) TaskOfferResponse {
+	resp := TaskOfferResponse{
+		ID:          o.ID,
+		TaskID:      o.TaskID,
+		UserID:      o.UserID,
+		Round:       o.Round,
+		Position:    o.Position,
+		Score:       o.Score,
+		DistanceKm:  o.DistanceKm,
+		Status:      string(o.Status),
+		ExpiresAt:   o.ExpiresAt,
+		RespondedAt: o.RespondedAt,
+		Reason:      o.Reason,
+		CreatedAt:   o.CreatedAt,
+	}
+	if o.Task != nil {
+		task := ToTaskResponse(o.Task)
+		resp.Task = &task
+	}
+	if o.User != nil {
+		user := ToUserResponse(o.User)
+		resp.User = &user
+	}
+	return resp
+}
+
+// TaskDispatchResponse 派单结果
+type TaskDispatchResponse struct {
+	TaskID         string              `json:"task_id"`
+	DispatchStatus string              `json:"dispatch_status"`
+	DispatchRound  int                 `json:"dispatch_round"`
+	Offers         []TaskOfferResponse `json:"offers"` // 本轮推送的邀请
+}
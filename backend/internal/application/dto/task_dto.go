@@ -21,6 +21,8 @@ type CreateTaskRequest struct {
 	Address         string    `json:"address"`
 	Lat             float64   `json:"lat"`
 	Lng             float64   `json:"lng"`
+	Skills          []string  `json:"skills"`    // 所需技能，用于自动派单
+	Languages       []string  `json:"languages"` // 所需语言或方言，用于自动派单
 }
 
 // UpdateTaskRequest 更新任务请求
@@ -37,6 +39,8 @@ type UpdateTaskRequest struct {
 	Address     string    `json:"address"`
 	Lat         float64   `json:"lat"`
 	Lng         float64   `json:"lng"`
+	Skills      []string  `json:"skills"`
+	Languages   []string  `json:"languages"`
 }
 
 // TaskResponse 任务响应
@@ -61,6 +65,10 @@ type TaskResponse struct {
 	Address         string                 `json:"address"`
 	Lat             float64                `json:"lat"`
 	Lng             float64                `json:"lng"`
+	Skills          []string               `json:"skills,omitempty"`
+	Languages       []string               `json:"languages,omitempty"`
+	DispatchStatus  string                 `json:"dispatch_status,omitempty"`
+	DispatchRound   int                    `json:"dispatch_round,omitempty"`
 	Result          string                 `json:"result"`
 	Feedback        string                 `json:"feedback"`
 	Progress        int                    `json:"progress"`
@@ -185,6 +193,10 @@ func ToTaskResponse(t *entity.Task) TaskResponse {
 		Progress:        t.Progress,
 		ViewCount:       t.ViewCount,
 		CreatedAt:       t.CreatedAt,
+		Skills:          t.GetSkills(),
+		Languages:       t.GetLanguages(),
+		DispatchStatus:  string(t.DispatchStatus),
+		DispatchRound:   t.DispatchRound,
 	}
 
 	if t.Creator != nil {
@@ -0,0 +1,61 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+)
+
+// ConfirmTrackRequest 确认线索请求
+type ConfirmTrackRequest struct {
+	Reason     string `json:"reason" binding:"max=500"`
+	IsKeyPoint *bool  `json:"is_key_point"` // 为空表示保持原值
+}
+
+// RejectTrackRequest 驳回线索请求
+type RejectTrackRequest struct {
+	Reason string `json:"reason" binding:"required,max=500"`
+}
+
+// TrackVerifyResponse 线索核实结果响应
+type TrackVerifyResponse struct {
+	Track         MissingPersonTrackResponse `json:"track"`
+	VerifyTaskID  string                     `json:"verify_task_id,omitempty"`
+	VerifyOrgID   string                     `json:"verify_org_id,omitempty"`
+	Urgency       string                     `json:"urgency,omitempty"`
+	UrgencyRaised bool                       `json:"urgency_raised"`
+}
+
+// TrackLogResponse 线索核实日志响应
+type TrackLogResponse struct {
+	ID        string        `json:"id"`
+	TrackID   string        `json:"track_id"`
+	UserID    string        `json:"user_id"`
+	Action    string        `json:"action"`
+	OldStatus string        `json:"old_status"`
+	NewStatus string        `json:"new_status"`
+	Reason    string        `json:"reason"`
+	User      *UserResponse `json:"user,omitempty"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+// ToTrackLogResponse 转换为线索核实日志响应
+func ToTrackLogResponse(log *entity.MissingPersonTrackLog) TrackLogResponse {
+	resp := TrackLogResponse{
+		ID:        log.ID,
+		TrackID:   log.TrackID,
+		UserID:    log.UserID,
+		Action:    log.Action,
+		OldStatus: log.OldStatus,
+		NewStatus: log.NewStatus,
+		Reason:    log.Reason,
+		CreatedAt: log.CreatedAt,
+	}
+
+	if log.User != nil {
+		user := ToUserResponse(log.User)
+		resp.User = &user
+	}
+
+	return resp
+}
@@ -0,0 +1,109 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+)
+
+// UrgencyRuleRequest 组织紧急程度评分规则请求
+type UrgencyRuleRequest struct {
+	ChildMaxAge       int    `json:"child_max_age" binding:"min=0,max=18"`
+	ChildWeight       int    `json:"child_weight" binding:"min=0,max=100"`
+	ElderMinAge       int    `json:"elder_min_age" binding:"min=0,max=120"`
+	ElderWeight       int    `json:"elder_weight" binding:"min=0,max=100"`
+	MedicalWeight     int    `json:"medical_weight" binding:"min=0,max=100"`
+	MedicalKeywords   string `json:"medical_keywords" binding:"max=2000"`
+	GoldenHours       int    `json:"golden_hours" binding:"min=0,max=720"`
+	GoldenWeight      int    `json:"golden_weight" binding:"min=0,max=100"`
+	SeasonWeight      int    `json:"season_weight" binding:"min=0,max=100"`
+	SightingHours     int    `json:"sighting_hours" binding:"min=0,max=720"`
+	SightingWeight    int    `json:"sighting_weight" binding:"min=0,max=100"`
+	SightingMaxWeight int    `json:"sighting_max_weight" binding:"min=0,max=100"`
+	CriticalScore     int    `json:"critical_score" binding:"required,min=1"`
+	HighScore         int    `json:"high_score" binding:"required,min=1"`
+	MediumScore       int    `json:"medium_score" binding:"required,min=1"`
+	Escalate          *bool  `json:"escalate"`
+}
+
+// UrgencyRuleResponse 组织紧急程度评分规则响应
+type UrgencyRuleResponse struct {
+	OrgID             string     `json:"org_id"`
+	SourceOrgID       string     `json:"source_org_id,omitempty"` // 规则所属组织，沿用上级规则时为上级组织
+	Inherited         bool       `json:"inherited"`               // 沿用上级组织规则
+	IsDefault         bool       `json:"is_default"`              // 未配置任何规则，使用默认规则
+	ChildMaxAge       int        `json:"child_max_age"`
+	ChildWeight       int        `json:"child_weight"`
+	ElderMinAge       int        `json:"elder_min_age"`
+	ElderWeight       int        `json:"elder_weight"`
+	MedicalWeight     int        `json:"medical_weight"`
+	MedicalKeywords   string     `json:"medical_keywords,omitempty"`
+	GoldenHours       int        `json:"golden_hours"`
+	GoldenWeight      int        `json:"golden_weight"`
+	SeasonWeight      int        `json:"season_weight"`
+	SightingHours     int        `json:"sighting_hours"`
+	SightingWeight    int        `json:"sighting_weight"`
+	SightingMaxWeight int        `json:"sighting_max_weight"`
+	CriticalScore     int        `json:"critical_score"`
+	HighScore         int        `json:"high_score"`
+	MediumScore       int        `json:"medium_score"`
+	Escalate          bool       `json:"escalate"`
+	UpdatedAt         *time.Time `json:"updated_at,omitempty"`
+}
+
+// ToUrgencyRuleResponse 转换为评分规则响应，rule 为 orgID 生效的规则
+func ToUrgencyRuleResponse(orgID string, rule *entity.UrgencyRule) UrgencyRuleResponse {
+	resp := UrgencyRuleResponse{
+		OrgID:             orgID,
+		SourceOrgID:       rule.OrgID,
+		Inherited:         rule.OrgID != "" && rule.OrgID != orgID,
+		IsDefault:         rule.OrgID == "",
+		ChildMaxAge:       rule.ChildMaxAge,
+		ChildWeight:       rule.ChildWeight,
+		ElderMinAge:       rule.ElderMinAge,
+		ElderWeight:       rule.ElderWeight,
+		MedicalWeight:     rule.MedicalWeight,
+		MedicalKeywords:   rule.MedicalKeywords,
+		GoldenHours:       rule.GoldenHours,
+		GoldenWeight:      rule.GoldenWeight,
+		SeasonWeight:      rule.SeasonWeight,
+		SightingHours:     rule.SightingHours,
+		SightingWeight:    rule.SightingWeight,
+		SightingMaxWeight: rule.SightingMaxWeight,
+		CriticalScore:     rule.CriticalScore,
+		HighScore:         rule.HighScore,
+		MediumScore:       rule.MediumScore,
+		Escalate:          rule.Escalate,
+	}
+	if !rule.UpdatedAt.IsZero() {
+		resp.UpdatedAt = &rule.UpdatedAt
+	}
+	return resp
+}
+
+// UrgencyFactorResponse 紧急程度评分因素
+type UrgencyFactorResponse struct {
+	Code   string `json:"code"`
+	Points int    `json:"points"`
+	Detail string `json:"detail"`
+}
+
+// UrgencyAssessmentResponse 案件紧急程度评估响应
+type UrgencyAssessmentResponse struct {
+	MissingPersonID string                  `json:"missing_person_id"`
+	Score           int                     `json:"score"`
+	Level           string                  `json:"level"`   // 评分对应的等级
+	Urgency         string                  `json:"urgency"` // 案件当前等级，人工设置时可能与评分等级不同
+	Manual          bool                    `json:"manual"`
+	Escalated       bool                    `json:"escalated"` // 本次评分触发了升级通知
+	Factors         []UrgencyFactorResponse `json:"factors"`
+	ScoredAt        *time.Time              `json:"scored_at,omitempty"`
+	EscalatedAt     *time.Time              `json:"escalated_at,omitempty"`
+}
+
+// UrgencyRescoreResponse 批量重新评分结果
+type UrgencyRescoreResponse struct {
+	Scored    int `json:"scored"`
+	Changed   int `json:"changed"`
+	Escalated int `json:"escalated"`
+}
@@ -0,0 +1,222 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+)
+
+// VolunteerCertificationRequest 志愿者证书
+type VolunteerCertificationRequest struct {
+	Name      string     `json:"name" binding:"required,max=100"`
+	Issuer    string     `json:"issuer" binding:"max=100"`
+	CertNo    string     `json:"cert_no" binding:"max=100"`
+	IssuedAt  *time.Time `json:"issued_at"`
+	ExpiresAt *time.Time `json:"expires_at"` // 为空表示长期有效
+}
+
+// VolunteerLanguageRequest 志愿者会说的语言或方言，Name 为空时使用方言分类名称
+type VolunteerLanguageRequest struct {
+	Name           string  `json:"name" binding:"max=50"`
+	DialectGroupID *string `json:"dialect_group_id"`
+	Level          string  `json:"level" binding:"omitempty,oneof=native fluent basic"`
+}
+
+// VolunteerAvailabilityRequest 每周值班时段
+type VolunteerAvailabilityRequest struct {
+	Weekday int    `json:"weekday" binding:"min=0,max=6"` // 0 为周日
+	Start   string `json:"start" binding:"required"`      // HH:MM
+	End     string `json:"end" binding:"required"`        // HH:MM，24:00 表示当天结束
+}
+
+// VolunteerProfileRequest 保存志愿者档案请求（证书、语言、值班时段整体替换）
+type VolunteerProfileRequest struct {
+	Province        string   `json:"province" binding:"max=50"`
+	City            string   `json:"city" binding:"max=50"`
+	District        string   `json:"district" binding:"max=50"`
+	Address         string   `json:"address" binding:"max=255"`
+	HomeLat         float64  `json:"home_lat" binding:"min=-90,max=90"`
+	HomeLng         float64  `json:"home_lng" binding:"min=-180,max=180"`
+	ServiceRadiusKm float64  `json:"service_radius_km" binding:"min=0,max=1000"` // 0 表示不限
+	Skills          []string `json:"skills" binding:"max=50"`
+	Bio             string   `json:"bio" binding:"max=2000"`
+
+	Certifications []VolunteerCertificationRequest `json:"certifications" binding:"max=20,dive"`
+	Languages      []VolunteerLanguageRequest      `json:"languages" binding:"max=20,dive"`
+	Availability   []VolunteerAvailabilityRequest  `json:"availability" binding:"max=50,dive"` // 为空表示随时可用
+}
+
+// VolunteerVacationRequest 添加休假请求
+type VolunteerVacationRequest struct {
+	StartAt time.Time `json:"start_at" binding:"required"`
+	EndAt   time.Time `json:"end_at" binding:"required"`
+	Reason  string    `json:"reason" binding:"max=200"`
+}
+
+// VolunteerSearchRequest 志愿者搜索请求，技能、语言为逗号分隔且须全部满足
+type VolunteerSearchRequest struct {
+	Page           int        `form:"page,default=1" binding:"min=1"`
+	PageSize       int        `form:"page_size,default=20" binding:"min=1,max=100"`
+	Keyword        string     `form:"keyword"`
+	Province       string     `form:"province"`
+	City           string     `form:"city"`
+	Skills         string     `form:"skills"`           // 如 驾车,急救
+	Languages      string     `form:"languages"`        // 如 闽南话
+	DialectGroupID string     `form:"dialect_group_id"` // 会说该方言分类（含下级分类）
+	Lat            float64    `form:"lat" binding:"min=-90,max=90"`
+	Lng            float64    `form:"lng" binding:"min=-180,max=180"`
+	RadiusKm       float64    `form:"radius_km" binding:"min=0,max=1000"` // 需同时指定 lat、lng
+	AvailableAt    *time.Time `form:"available_at" time_format:"2006-01-02T15:04:05Z07:00"`
+	AvailableNow   bool       `form:"available_now"`
+}
+
+// ExpiringCertificationRequest 即将到期证书查询请求
+type ExpiringCertificationRequest struct {
+	Days int `form:"days,default=30" binding:"min=1,max=365"`
+}
+
+// VolunteerCertificationResponse 志愿者证书响应
+type VolunteerCertificationResponse struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	Issuer    string     `json:"issuer,omitempty"`
+	CertNo    string     `json:"cert_no,omitempty"`
+	IssuedAt  *time.Time `json:"issued_at,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Valid     bool       `json:"valid"`
+}
+
+// VolunteerLanguageResponse 志愿者语言响应
+type VolunteerLanguageResponse struct {
+	Name             string  `json:"name"`
+	DialectGroupID   *string `json:"dialect_group_id,omitempty"`
+	DialectGroupName string  `json:"dialect_group_name,omitempty"` // 方言分类全称，如 闽语/闽南片
+	Level            string  `json:"level"`
+}
+
+// VolunteerAvailabilityResponse 值班时段响应
+type VolunteerAvailabilityResponse struct {
+	Weekday int    `json:"weekday"`
+	Start   string `json:"start"`
+	End     string `json:"end"`
+}
+
+// VolunteerVacationResponse 休假响应
+type VolunteerVacationResponse struct {
+	ID      string    `json:"id"`
+	StartAt time.Time `json:"start_at"`
+	EndAt   time.Time `json:"end_at"`
+	Reason  string    `json:"reason,omitempty"`
+}
+
+// VolunteerProfileResponse 志愿者档案响应
+type VolunteerProfileResponse struct {
+	ID              string        `json:"id"`
+	UserID          string        `json:"user_id"`
+	OrgID           string        `json:"org_id"`
+	Province        string        `json:"province,omitempty"`
+	City            string        `json:"city,omitempty"`
+	District        string        `json:"district,omitempty"`
+	Address         string        `json:"address,omitempty"`
+	HomeLat         float64       `json:"home_lat,omitempty"`
+	HomeLng         float64       `json:"home_lng,omitempty"`
+	ServiceRadiusKm float64       `json:"service_radius_km"`
+	Skills          []string      `json:"skills"`
+	Bio             string        `json:"bio,omitempty"`
+	Unavailable     string        `json:"unavailable,omitempty"` // 当前不可用原因
+	DistanceKm      *float64      `json:"distance_km,omitempty"` // 搜索时与服务地点的距离
+	UpdatedAt       time.Time     `json:"updated_at"`
+	User            *UserResponse `json:"user,omitempty"`
+
+	Certifications []VolunteerCertificationResponse `json:"certifications"`
+	Languages      []VolunteerLanguageResponse      `json:"languages"`
+	Availability   []VolunteerAvailabilityResponse  `json:"availability"`
+	Vacations      []VolunteerVacationResponse      `json:"vacations"` // 尚未结束的休假
+}
+
+// VolunteerProfileListResponse 志愿者搜索结果
+type VolunteerProfileListResponse struct {
+	List       []VolunteerProfileResponse `json:"list"`
+	Total      int64                      `json:"total"`
+	Page       int                        `json:"page"`
+	PageSize   int                        `json:"page_size"`
+	TotalPages int                        `json:"total_pages"`
+}
+
+// ExpiringCertificationResponse 即将到期的证书及持有人
+type ExpiringCertificationResponse struct {
+	VolunteerCertificationResponse
+	UserID   string `json:"user_id"`
+	Nickname string `json:"nickname,omitempty"`
+}
+
+// ToVolunteerCertificationResponse 转换为志愿者证书响应
+func ToVolunteerCertificationResponse(c *entity.VolunteerCertification, now time.Time) VolunteerCertificationResponse {
+	return VolunteerCertificationResponse{
+		ID:        c.ID,
+		Name:      c.Name,
+		Issuer:    c.Issuer,
+		CertNo:    c.CertNo,
+		IssuedAt:  c.IssuedAt,
+		ExpiresAt: c.ExpiresAt,
+		Valid:     c.IsValid(now),
+	}
+}
+
+// ToVolunteerProfileResponse 转换为志愿者档案响应
+func ToVolunteerProfileResponse(p *entity.VolunteerProfile, now time.Time) VolunteerProfileResponse {
+	resp := VolunteerProfileResponse{
+		ID:              p.ID,
+		UserID:          p.UserID,
+		OrgID:           p.OrgID,
+		Province:        p.Province,
+		City:            p.City,
+		District:        p.District,
+		Address:         p.Address,
+		HomeLat:         p.HomeLat,
+		HomeLng:         p.HomeLng,
+		ServiceRadiusKm: p.ServiceRadiusKm,
+		Skills:          p.GetSkills(),
+		Bio:             p.Bio,
+		Unavailable:     p.UnavailableAt(now),
+		UpdatedAt:       p.UpdatedAt,
+		Certifications:  make([]VolunteerCertificationResponse, len(p.Certifications)),
+		Languages:       make([]VolunteerLanguageResponse, len(p.Languages)),
+		Availability:    make([]VolunteerAvailabilityResponse, len(p.Availability)),
+	}
+	if p.User != nil {
+		user := ToUserResponse(p.User)
+		resp.User = &user
+	}
+	for i := range p.Certifications {
+		resp.Certifications[i] = ToVolunteerCertificationResponse(&p.Certifications[i], now)
+	}
+	for i, l := range p.Languages {
+		resp.Languages[i] = VolunteerLanguageResponse{
+			Name:           l.Name,
+			DialectGroupID: l.DialectGroupID,
+			Level:          string(l.Level),
+		}
+		if l.DialectGroup != nil {
+			resp.Languages[i].DialectGroupName = l.DialectGroup.FullName
+		}
+	}
+	for i, a := range p.Availability {
+		resp.Availability[i] = VolunteerAvailabilityResponse{
+			Weekday: a.Weekday,
+			Start:   entity.FormatDayMinute(a.StartMinute),
+			End:     entity.FormatDayMinute(a.EndMinute),
+		}
+	}
+	vacations := p.UpcomingVacations(now)
+	resp.Vacations = make([]VolunteerVacationResponse, len(vacations))
+	for i, v := range vacations {
+		resp.Vacations[i] = VolunteerVacationResponse{
+			ID:      v.ID,
+			StartAt: v.StartAt,
+			EndAt:   v.EndAt,
+			Reason:  v.Reason,
+		}
+	}
+	return resp
+}
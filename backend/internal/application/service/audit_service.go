@@ -69,6 +69,17 @@ func (s *AuditService) Log(ctx context.Context, log *entity.AuditLog) error {
 	return nil
 }
 
+// LogSync 同步记录审计日志，用于导出等必须留痕的操作：写入失败时调用方应中止操作
+func (s *AuditService) LogSync(ctx context.Context, log *entity.AuditLog) error {
+	if log.OldValues != nil {
+		log.OldValues = entity.MaskSensitiveData(log.OldValues)
+	}
+	if log.NewValues != nil {
+		log.NewValues = entity.MaskSensitiveData(log.NewValues)
+	}
+	return s.repo.Create(ctx, log)
+}
+
 // LogFromRequest 从HTTP请求记录审计日志
 func (s *AuditService) LogFromRequest(ctx context.Context, req *http.Request, resp *http.Response, userID, orgID string, duration int64) error {
 	if !s.config.Enabled {
@@ -0,0 +1,524 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/dto"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/repository"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/bundle"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/cjkfont"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/imaging"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/logger"
+)
+
+var (
+	ErrExportAuditFailed = errors.New("export audit log could not be written")
+	ErrExportBundle      = errors.New("invalid export bundle")
+)
+
+const (
+	// caseExportFormat 导出包格式标识
+	caseExportFormat = "cntuanyuan-case-export"
+	// exportMaskedValue 无权导出的字段替换值
+	exportMaskedValue = "***"
+	// exportWorkflowLimit 导出的工作流实例上限
+	exportWorkflowLimit = 100
+	// exportOriginalMaxSize 重新编码时读取原图的上限
+	exportOriginalMaxSize = 20 << 20
+)
+
+// CaseExportAppService 案件移交导出应用服务
+// 导出包为签名 ZIP：案件字段、线索及核实记录、照片、任务及日志、工作流历史分别存为 JSON，
+// 附人工阅读用的 PDF 摘要（未加载中文字体时不生成）；manifest.json 记录每个文件的摘要并以 Ed25519 签名。
+// 字段按导出人的字段权限（FieldPermission）脱敏：权限为 none 的字段不导出；未配置字段权限时，
+// 主管以下角色默认不导出手机号、证件号等敏感字段。每次导出都同步写入审计日志，写入失败则不返回导出包。
+type CaseExportAppService struct {
+	mpRepo        repository.MissingPersonRepository
+	taskRepo      repository.TaskRepository
+	workflowRepo  repository.WorkflowRepository
+	userRepo      repository.UserRepository
+	fileService   *FileAppService
+	permService   *PermissionAppService
+	auditService  *AuditService
+	signer        *bundle.Signer
+	maxPhotoBytes int64
+	summaryFont   *cjkfont.Font
+}
+
+// NewCaseExportAppService 创建案件移交导出应用服务
+func NewCaseExportAppService(
+	mpRepo repository.MissingPersonRepository,
+	taskRepo repository.TaskRepository,
+	workflowRepo repository.WorkflowRepository,
+	userRepo repository.UserRepository,
+	fileService *FileAppService,
+	permService *PermissionAppService,
+	auditService *AuditService,
+	signer *bundle.Signer,
+	maxPhotoBytes int64,
+	summaryFont *cjkfont.Font,
+) *CaseExportAppService {
+	return &CaseExportAppService{
+		mpRepo:        mpRepo,
+		taskRepo:      taskRepo,
+		workflowRepo:  workflowRepo,
+		userRepo:      userRepo,
+		fileService:   fileService,
+		permService:   permService,
+		auditService:  auditService,
+		signer:        signer,
+		maxPhotoBytes: maxPhotoBytes,
+		summaryFont:   summaryFont,
+	}
+}
+
+// caseExportMeta 清单中的导出信息
+type caseExportMeta struct {
+	Format       string              `json:"format"`
+	CaseID       string              `json:"case_id"`
+	CaseNo       string              `json:"case_no"`
+	ExportedAt   time.Time           `json:"exported_at"`
+	ExportedBy   caseExportOperator  `json:"exported_by"`
+	Recipient    string              `json:"recipient"`
+	RecipientOrg string              `json:"recipient_org,omitempty"`
+	Purpose      string              `json:"purpose,omitempty"`
+	MaskedFields map[string][]string `json:"masked_fields,omitempty"`
+	SkippedFiles []string            `json:"skipped_files,omitempty"`
+}
+
+// caseExportOperator 导出人
+type caseExportOperator struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Role  string `json:"role"`
+	OrgID string `json:"org_id"`
+}
+
+// caseExportData 导出内容，各记录已按字段权限脱敏
+type caseExportData struct {
+	Case      map[string]any
+	Tracks    []map[string]any
+	Photos    []map[string]any
+	Tasks     []map[string]any
+	Workflows []map[string]any
+	Merges    []map[string]any
+}
+
+// Export 生成案件移交导出包
+func (s *CaseExportAppService) Export(ctx context.Context, id string, req *dto.CaseExportRequest, userID, clientIP string) (*dto.CaseExportFile, error) {
+	mp, err := s.mpRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, ErrMissingPersonNotFound
+	}
+	operator, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	masked := make(map[string]map[string]bool)
+	caseMasker := s.masker(ctx, operator, entity.ResourceMissingPerson, masked)
+	data, err := s.collect(ctx, mp, caseMasker, s.masker(ctx, operator, entity.ResourceTask, masked), s.masker(ctx, operator, entity.ResourceWorkflow, masked))
+	if err != nil {
+		return nil, err
+	}
+
+	meta := caseExportMeta{
+		Format:     caseExportFormat,
+		CaseID:     mp.ID,
+		CaseNo:     mp.CaseNo,
+		ExportedAt: time.Now(),
+		ExportedBy: caseExportOperator{
+			ID:    operator.ID,
+			Name:  operatorName(operator),
+			Role:  operator.Role,
+			OrgID: operator.OrgID,
+		},
+		Recipient:    strings.TrimSpace(req.Recipient),
+		RecipientOrg: strings.TrimSpace(req.RecipientOrg),
+		Purpose:      strings.TrimSpace(req.Purpose),
+	}
+
+	var buf bytes.Buffer
+	w := bundle.NewWriter(&buf, s.signer)
+	for _, f := range []struct {
+		name string
+		v    any
+	}{
+		{"case.json", data.Case},
+		{"tracks.json", data.Tracks},
+		{"photos.json", data.Photos},
+		{"tasks.json", data.Tasks},
+		{"workflows.json", data.Workflows},
+		{"merges.json", data.Merges},
+	} {
+		if err := w.AddJSON(f.name, f.v); err != nil {
+			return nil, err
+		}
+	}
+
+	if !req.NoPhotos && !caseMasker.masked("photos") {
+		meta.SkippedFiles = s.addPhotos(ctx, w, mp.Photos)
+	}
+
+	meta.MaskedFields = maskedFieldList(masked)
+	if s.summaryFont != nil {
+		summary, err := renderCaseExportSummary(s.summaryFont, data, &meta, s.signer.KeyID())
+		if err != nil {
+			return nil, err
+		}
+		if err := w.Add("summary.pdf", summary); err != nil {
+			return nil, err
+		}
+	} else {
+		logger.Warn("CJK font unavailable, export summary omitted", logger.String("mp_id", mp.ID))
+	}
+
+	manifest, err := w.Close(meta)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(buf.Bytes())
+	file := &dto.CaseExportFile{
+		Data:     buf.Bytes(),
+		FileName: fmt.Sprintf("case_%s_%s.zip", mp.CaseNo, meta.ExportedAt.Format("20060102150405")),
+		SHA256:   hex.EncodeToString(sum[:]),
+	}
+
+	// 审计日志写入成功后才交付导出包
+	auditLog := entity.NewAuditLog(operator.ID, operator.OrgID, entity.AuditActionExport, string(entity.ResourceMissingPerson)).
+		SetResourceID(mp.ID).
+		SetResourceName(mp.CaseNo).
+		SetDescription(fmt.Sprintf("导出案件 %s 移交给 %s", mp.CaseNo, exportRecipientText(&meta))).
+		SetUsername(operatorName(operator)).
+		AddExtra("recipient", meta.Recipient).
+		AddExtra("recipient_org", meta.RecipientOrg).
+		AddExtra("purpose", meta.Purpose).
+		AddExtra("file_name", file.FileName).
+		AddExtra("sha256", file.SHA256).
+		AddExtra("key_id", manifest.KeyID).
+		AddExtra("file_count", len(manifest.Files)).
+		AddExtra("masked_fields", meta.MaskedFields)
+	auditLog.IPAddress = clientIP
+	if err := s.auditService.LogSync(ctx, auditLog); err != nil {
+		logger.Error("Failed to write export audit log", logger.String("case_id", mp.ID), logger.Err(err))
+		return nil, ErrExportAuditFailed
+	}
+
+	logger.Info("Case exported",
+		logger.String("case_id", mp.ID),
+		logger.String("user_id", operator.ID),
+		logger.String("recipient", meta.Recipient),
+		logger.Int("files", len(manifest.Files)),
+	)
+	return file, nil
+}
+
+// PublicKey 导出包签名公钥，提供给接收方校验
+func (s *CaseExportAppService) PublicKey() *dto.CaseExportPublicKeyResponse {
+	return &dto.CaseExportPublicKeyResponse{
+		Algorithm: "Ed25519",
+		KeyID:     s.signer.KeyID(),
+		PublicKey: base64.StdEncoding.EncodeToString(s.signer.PublicKey()),
+	}
+}
+
+// Verify 校验导出包的签名和内容
+func (s *CaseExportAppService) Verify(r io.Reader) (*dto.CaseExportVerifyResponse, error) {
+	data, err := io.ReadAll(io.LimitReader(r, s.maxBundleSize()+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > s.maxBundleSize() {
+		return nil, ErrFileTooLarge
+	}
+
+	manifest, err := bundle.Verify(data, s.signer.PublicKey())
+	if err != nil {
+		if errors.Is(err, bundle.ErrInvalidBundle) {
+			return nil, ErrExportBundle
+		}
+		return &dto.CaseExportVerifyResponse{Valid: false, Error: err.Error()}, nil
+	}
+	return &dto.CaseExportVerifyResponse{
+		Valid:     true,
+		KeyID:     manifest.KeyID,
+		CreatedAt: &manifest.CreatedAt,
+		Meta:      manifest.Meta,
+		FileCount: len(manifest.Files),
+	}, nil
+}
+
+// maxBundleSize 可校验的导出包大小：照片上限加上 JSON 和 PDF 的余量
+func (s *CaseExportAppService) maxBundleSize() int64 {
+	return s.maxPhotoBytes + 50<<20
+}
+
+// collect 读取案件关联数据并脱敏
+func (s *CaseExportAppService) collect(ctx context.Context, mp *entity.MissingPerson, caseMasker, taskMasker, workflowMasker *exportMasker) (*caseExportData, error) {
+	data := &caseExportData{}
+
+	c := *mp
+	c.Photos, c.Tracks, c.Reporter, c.Assignee, c.Org = nil, nil, nil, nil, nil
+	data.Case = caseMasker.record(c)
+	if mp.Reporter != nil {
+		data.Case["reporter_name"] = caseMasker.value("reporter_name", operatorName(mp.Reporter))
+	}
+	if mp.Assignee != nil {
+		data.Case["assignee_name"] = caseMasker.value("assignee_name", operatorName(mp.Assignee))
+	}
+
+	tracks, err := s.mpRepo.GetTracks(ctx, mp.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tracks {
+		rec := caseMasker.record(t)
+		logs, err := s.mpRepo.GetTrackLogs(ctx, t.ID)
+		if err != nil {
+			return nil, err
+		}
+		for i := range logs {
+			logs[i].User = nil
+		}
+		rec["logs"] = caseMasker.records(logs)
+		data.Tracks = append(data.Tracks, rec)
+	}
+
+	data.Photos = caseMasker.records(mp.Photos)
+
+	tasks, err := s.taskRepo.FindByMissingPerson(ctx, mp.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tasks {
+		t.Creator, t.Assignee, t.Org, t.MissingPerson = nil, nil, nil, nil
+		rec := taskMasker.record(t)
+		logs, err := s.taskRepo.GetLogs(ctx, t.ID)
+		if err != nil {
+			return nil, err
+		}
+		for i := range logs {
+			logs[i].User = nil
+		}
+		rec["logs"] = taskMasker.records(logs)
+		data.Tasks = append(data.Tasks, rec)
+	}
+
+	instances, _, err := s.workflowRepo.ListInstances(ctx, &entity.WorkflowQuery{BusinessID: mp.ID, Page: 1, PageSize: exportWorkflowLimit})
+	if err != nil {
+		return nil, err
+	}
+	for _, inst := range instances {
+		i := *inst
+		i.Definition = nil
+		rec := workflowMasker.record(i)
+		transitions, err := s.workflowRepo.FindTransitionsByInstanceID(ctx, inst.ID)
+		if err != nil {
+			return nil, err
+		}
+		rec["transitions"] = workflowMasker.records(transitions)
+		data.Workflows = append(data.Workflows, rec)
+	}
+
+	merges, err := s.mpRepo.GetMergeHistory(ctx, mp.ID)
+	if err != nil {
+		return nil, err
+	}
+	data.Merges = caseMasker.records(merges)
+
+	// 保证空列表输出为 []
+	for _, list := range []*[]map[string]any{&data.Tracks, &data.Photos, &data.Tasks, &data.Workflows, &data.Merges} {
+		if *list == nil {
+			*list = []map[string]any{}
+		}
+	}
+	return data, nil
+}
+
+// addPhotos 写入照片文件：优先使用去除元数据的公开版本，超出大小上限的照片跳过并返回
+func (s *CaseExportAppService) addPhotos(ctx context.Context, w *bundle.Writer, photos []entity.MissingPhoto) []string {
+	var skipped []string
+	var total int64
+	for i, photo := range photos {
+		if photo.FileID == nil {
+			continue
+		}
+
+		content, err := s.readPhoto(ctx, *photo.FileID, s.maxPhotoBytes-total)
+		if err != nil {
+			logger.Warn("Failed to load photo for export", logger.String("photo_id", photo.ID), logger.Err(err))
+			skipped = append(skipped, photo.ID)
+			continue
+		}
+		if total+int64(len(content)) > s.maxPhotoBytes {
+			skipped = append(skipped, photo.ID)
+			continue
+		}
+		total += int64(len(content))
+
+		if err := w.Add(fmt.Sprintf("photos/%02d_%s.jpg", i+1, photo.ID), content); err != nil {
+			logger.Warn("Failed to add photo to export", logger.String("photo_id", photo.ID), logger.Err(err))
+			skipped = append(skipped, photo.ID)
+		}
+	}
+	return skipped
+}
+
+// readPhoto 读取照片的公开版本，最多读取 limit+1 字节；未生成公开版本时读取原图并重新编码，
+// 去除 EXIF/GPS 等元数据，导出包中不包含原始文件
+func (s *CaseExportAppService) readPhoto(ctx context.Context, fileID string, limit int64) ([]byte, error) {
+	reader, err := s.fileService.GetVariant(ctx, fileID, entity.FileVariantPublic)
+	if err == nil {
+		defer reader.Close()
+		return io.ReadAll(io.LimitReader(reader, limit+1))
+	}
+
+	reader, _, err = s.fileService.GetFile(ctx, fileID)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(io.LimitReader(reader, exportOriginalMaxSize))
+	if err != nil {
+		return nil, err
+	}
+	img, _, err := imaging.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	return imaging.EncodeJPEG(img, imaging.DefaultJPEGQuality)
+}
+
+// masker 读取导出人在资源上的字段权限
+func (s *CaseExportAppService) masker(ctx context.Context, operator *entity.User, resource entity.PermissionResource, masked map[string]map[string]bool) *exportMasker {
+	perms, err := s.permService.FieldPermissions(ctx, operator.ID, string(resource))
+	if err != nil {
+		// 无法读取字段权限时按最严格的默认规则处理
+		logger.Warn("Failed to load field permissions", logger.String("user_id", operator.ID), logger.Err(err))
+		perms = nil
+	}
+	if masked[string(resource)] == nil {
+		masked[string(resource)] = make(map[string]bool)
+	}
+	return &exportMasker{
+		perms:         perms,
+		maskSensitive: err != nil || !operator.HasPermission(string(entity.RoleManager)),
+		hits:          masked[string(resource)],
+	}
+}
+
+// exportMasker 按字段权限脱敏导出记录
+type exportMasker struct {
+	perms         map[string]string // field -> read/write/none
+	maskSensitive bool              // 未配置字段权限的敏感字段是否屏蔽
+	hits          map[string]bool   // 实际被屏蔽的字段
+}
+
+// masked 字段是否不可导出
+func (m *exportMasker) masked(field string) bool {
+	if perm, ok := m.perms[field]; ok {
+		return perm == "none"
+	}
+	return m.maskSensitive && entity.IsSensitiveField(field)
+}
+
+// value 按字段权限返回值
+func (m *exportMasker) value(field string, v any) any {
+	if m.masked(field) {
+		m.hits[field] = true
+		return exportMaskedValue
+	}
+	return v
+}
+
+// record 转换为 JSON 字段名的记录并脱敏
+func (m *exportMasker) record(v any) map[string]any {
+	rec := make(map[string]any)
+	if data, err := json.Marshal(v); err == nil {
+		_ = json.Unmarshal(data, &rec)
+	}
+	m.apply(rec)
+	return rec
+}
+
+// records 批量转换
+func (m *exportMasker) records(v any) []map[string]any {
+	list := []map[string]any{}
+	if data, err := json.Marshal(v); err == nil {
+		_ = json.Unmarshal(data, &list)
+	}
+	for _, rec := range list {
+		m.apply(rec)
+	}
+	return list
+}
+
+// apply 屏蔽无权导出的字段，逐层处理嵌套对象和数组（如 extra_info、日志详情中的联系方式）；
+// 空值保持原样，便于接收方区分“无数据”和“已屏蔽”
+func (m *exportMasker) apply(rec map[string]any) {
+	for k, val := range rec {
+		if val == nil || val == "" {
+			continue
+		}
+		if m.masked(k) {
+			m.hits[k] = true
+			rec[k] = exportMaskedValue
+			continue
+		}
+		m.applyNested(val)
+	}
+}
+
+// applyNested 递归脱敏嵌套值
+func (m *exportMasker) applyNested(val any) {
+	switch v := val.(type) {
+	case map[string]any:
+		m.apply(v)
+	case []any:
+		for _, item := range v {
+			m.applyNested(item)
+		}
+	}
+}
+
+// maskedFieldList 资源 -> 被屏蔽的字段（排序）
+func maskedFieldList(masked map[string]map[string]bool) map[string][]string {
+	result := make(map[string][]string)
+	for resource, fields := range masked {
+		for f := range fields {
+			result[resource] = append(result[resource], f)
+		}
+		sort.Strings(result[resource])
+	}
+	return result
+}
+
+// operatorName 用户显示名
+func operatorName(u *entity.User) string {
+	if u.RealName != "" {
+		return u.RealName
+	}
+	return u.Nickname
+}
+
+// exportRecipientText 接收方描述
+func exportRecipientText(meta *caseExportMeta) string {
+	if meta.RecipientOrg != "" {
+		return meta.RecipientOrg + " " + meta.Recipient
+	}
+	return meta.Recipient
+}
@@ -0,0 +1,269 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/pkg/cjkfont"
+	"github.com/go-pdf/fpdf"
+)
+
+// 摘要排版：页边距、字段标签宽度和颜色
+const (
+	summaryMargin     = 48
+	summaryLabelWidth = 90
+)
+
+var (
+	summaryTextColor    = [3]int{0x22, 0x22, 0x22}
+	summaryLabelColor   = [3]int{0x66, 0x66, 0x66}
+	summaryHeadingColor = [3]int{0xB0, 0x1E, 0x23}
+	summaryRuleColor    = [3]int{0xCC, 0xCC, 0xCC}
+)
+
+// caseSummaryFields PDF 摘要中的案件字段
+var caseSummaryFields = []struct{ key, label string }{
+	{"case_no", "案件编号"},
+	{"name", "姓名"},
+	{"gender", "性别"},
+	{"birth_date", "出生日期"},
+	{"age", "年龄"},
+	{"height", "身高(cm)"},
+	{"weight", "体重(kg)"},
+	{"status", "状态"},
+	{"urgency", "紧急程度"},
+	{"missing_time", "走失时间"},
+	{"province", "省份"},
+	{"city", "城市"},
+	{"district", "区县"},
+	{"address", "走失地点"},
+	{"clothes", "衣着"},
+	{"features", "体貌特征"},
+	{"description", "补充说明"},
+	{"contact_name", "联系人"},
+	{"contact_phone", "联系电话"},
+	{"contact_rel", "与走失者关系"},
+	{"alt_contact", "备用联系方式"},
+	{"reporter_name", "登记人"},
+	{"assignee_name", "负责人"},
+	{"found_time", "找到时间"},
+	{"found_location", "找到地点"},
+	{"found_note", "找到说明"},
+	{"created_at", "登记时间"},
+}
+
+// renderCaseExportSummary 生成人工阅读用的 PDF 摘要，内容与导出包中已脱敏的 JSON 一致
+func renderCaseExportSummary(font *cjkfont.Font, data *caseExportData, meta *caseExportMeta, keyID string) ([]byte, error) {
+	flow := newSummaryFlow(font)
+
+	flow.Title("案件移交摘要")
+	flow.Text(9, fmt.Sprintf("导出时间 %s    签名公钥指纹 %s", meta.ExportedAt.Format("2006-01-02 15:04"), keyID))
+
+	flow.Heading("移交信息")
+	flow.Field("接收人", meta.Recipient)
+	flow.Field("接收单位", meta.RecipientOrg)
+	flow.Field("移交事由", meta.Purpose)
+	flow.Field("导出人", meta.ExportedBy.Name)
+	if len(meta.MaskedFields) > 0 {
+		var parts []string
+		for resource, fields := range meta.MaskedFields {
+			parts = append(parts, resource+": "+strings.Join(fields, ", "))
+		}
+		sort.Strings(parts)
+		flow.Field("已屏蔽字段", strings.Join(parts, "；"))
+	}
+
+	flow.Heading("案件信息")
+	for _, f := range caseSummaryFields {
+		if v := summaryValue(data.Case[f.key]); v != "" {
+			flow.Field(f.label, v)
+		}
+	}
+
+	flow.Heading(fmt.Sprintf("线索轨迹（%d）", len(data.Tracks)))
+	for i, t := range data.Tracks {
+		flow.Field(fmt.Sprintf("#%d %s", i+1, summaryValue(t["time"])), joinNonEmpty(" ",
+			summaryValue(t["location"]), summaryValue(t["address"]), "["+summaryValue(t["status"])+"]"))
+		if d := summaryValue(t["description"]); d != "" {
+			flow.Field("", d)
+		}
+		for _, l := range summaryList(t["logs"]) {
+			flow.Field("", fmt.Sprintf("%s %s → %s %s", summaryValue(l["created_at"]), summaryValue(l["old_status"]), summaryValue(l["new_status"]), summaryValue(l["reason"])))
+		}
+	}
+
+	flow.Heading(fmt.Sprintf("照片（%d）", len(data.Photos)))
+	for i, p := range data.Photos {
+		flow.Field(fmt.Sprintf("#%d", i+1), joinNonEmpty(" ", summaryValue(p["type"]), summaryValue(p["description"]), summaryValue(p["taken_at"])))
+	}
+	if len(meta.SkippedFiles) > 0 {
+		flow.Field("未包含", fmt.Sprintf("%d 张照片超出大小限制或无法读取", len(meta.SkippedFiles)))
+	}
+
+	flow.Heading(fmt.Sprintf("任务（%d）", len(data.Tasks)))
+	for _, t := range data.Tasks {
+		flow.Field(summaryValue(t["status"]), summaryValue(t["title"]))
+		if r := summaryValue(t["result"]); r != "" {
+			flow.Field("结果", r)
+		}
+		for _, l := range summaryList(t["logs"]) {
+			flow.Field("", joinNonEmpty(" ", summaryValue(l["created_at"]), summaryValue(l["action"]), summaryValue(l["content"])))
+		}
+	}
+
+	flow.Heading(fmt.Sprintf("工作流（%d）", len(data.Workflows)))
+	for _, w := range data.Workflows {
+		flow.Field(summaryValue(w["status"]), joinNonEmpty(" ", summaryValue(w["title"]), summaryValue(w["result"])))
+		for _, tr := range summaryList(w["transitions"]) {
+			flow.Field("", joinNonEmpty(" ", summaryValue(tr["created_at"]), summaryValue(tr["action"]), summaryValue(tr["comment"])))
+		}
+	}
+
+	if len(data.Merges) > 0 {
+		flow.Heading(fmt.Sprintf("合并记录（%d）", len(data.Merges)))
+		for _, m := range data.Merges {
+			flow.Field(summaryValue(m["created_at"]), joinNonEmpty(" ", summaryValue(m["merged_case_no"]), summaryValue(m["reason"])))
+		}
+	}
+
+	flow.Space(12)
+	flow.Text(8, "本摘要由导出包中的 JSON 数据生成。包内文件的 SHA-256 记录在 manifest.json，"+
+		"并由 manifest.sig 签名，可使用发出方公布的公钥校验包内容是否被修改。")
+
+	return flow.Bytes()
+}
+
+// summaryFlow 自上而下排版的摘要文本，自动换行并在页面写满时分页
+type summaryFlow struct {
+	doc *fpdf.Fpdf
+}
+
+func newSummaryFlow(font *cjkfont.Font) *summaryFlow {
+	doc := fpdf.New("P", "pt", "A4", "")
+	doc.SetMargins(summaryMargin, summaryMargin, summaryMargin)
+	doc.SetAutoPageBreak(true, summaryMargin)
+	font.Register(doc)
+	doc.SetFont(cjkfont.Family, "", 10)
+	doc.AddPage()
+	return &summaryFlow{doc: doc}
+}
+
+// Title 文档标题
+func (f *summaryFlow) Title(s string) {
+	f.write(20, 30, summaryHeadingColor, s)
+}
+
+// Heading 小节标题，下方带分隔线
+func (f *summaryFlow) Heading(s string) {
+	f.ensure(40)
+	f.doc.Ln(10)
+	f.write(13, 18, summaryHeadingColor, s)
+	w, _ := f.doc.GetPageSize()
+	f.doc.SetFillColor(summaryRuleColor[0], summaryRuleColor[1], summaryRuleColor[2])
+	f.doc.Rect(summaryMargin, f.doc.GetY(), w-2*summaryMargin, 0.6, "F")
+	f.doc.Ln(6)
+}
+
+// Text 段落，保留原文换行
+func (f *summaryFlow) Text(size float64, s string) {
+	f.write(size, size*1.6, summaryTextColor, s)
+}
+
+// Field 字段行：标签在左，值在右并按右侧宽度换行
+func (f *summaryFlow) Field(label, value string) {
+	const size = 10
+	if strings.TrimSpace(value) == "" {
+		value = "-"
+	}
+	f.ensure(size * 1.6)
+	f.doc.SetFontSize(size)
+	f.doc.SetTextColor(summaryLabelColor[0], summaryLabelColor[1], summaryLabelColor[2])
+	f.doc.CellFormat(summaryLabelWidth, size*1.6, label, "", 0, "L", false, 0, "")
+	f.doc.SetTextColor(summaryTextColor[0], summaryTextColor[1], summaryTextColor[2])
+	f.doc.MultiCell(0, size*1.6, value, "", "L", false)
+}
+
+// Space 垂直留白
+func (f *summaryFlow) Space(h float64) {
+	f.doc.Ln(h)
+}
+
+// Bytes 输出 PDF
+func (f *summaryFlow) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := f.doc.Output(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// write 整行宽度输出文本并自动换行
+func (f *summaryFlow) write(size, lineH float64, color [3]int, s string) {
+	f.doc.SetFontSize(size)
+	f.doc.SetTextColor(color[0], color[1], color[2])
+	f.doc.MultiCell(0, lineH, s, "", "L", false)
+}
+
+// ensure 当前页剩余高度不足 h 时分页
+func (f *summaryFlow) ensure(h float64) {
+	_, pageH := f.doc.GetPageSize()
+	if f.doc.GetY()+h > pageH-summaryMargin {
+		f.doc.AddPage()
+	}
+}
+
+// summaryValue 记录值转为文本，时间格式化为本地时间
+func summaryValue(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		if t, err := time.Parse(time.RFC3339Nano, val); err == nil {
+			return t.Local().Format("2006-01-02 15:04")
+		}
+		return val
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case bool:
+		if val {
+			return "是"
+		}
+		return "否"
+	default:
+		data, _ := json.Marshal(val)
+		return string(data)
+	}
+}
+
+// summaryList 嵌套记录列表
+func summaryList(v any) []map[string]any {
+	switch list := v.(type) {
+	case []map[string]any:
+		return list
+	case []any:
+		out := make([]map[string]any, 0, len(list))
+		for _, item := range list {
+			if m, ok := item.(map[string]any); ok {
+				out = append(out, m)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+// joinNonEmpty 拼接非空文本
+func joinNonEmpty(sep string, parts ...string) string {
+	var out []string
+	for _, p := range parts {
+		if p != "" && p != "[]" {
+			out = append(out, p)
+		}
+	}
+	return strings.Join(out, sep)
+}
@@ -0,0 +1,287 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/dto"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/repository"
+	domainService "github.com/Snowitty-Re/CNtunyuan/internal/domain/service"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/logger"
+)
+
+var (
+	ErrSearchIndexDisabled = errors.New("search index disabled")
+	ErrSearchRebuilding    = errors.New("search index rebuild in progress")
+)
+
+const (
+	// caseIndexBatchSize 全量重建时每批读取的案件数
+	caseIndexBatchSize = 500
+	// caseSyncOverlap 增量同步时回退的时间窗口，容忍多实例及数据库之间的时钟偏差
+	caseSyncOverlap = time.Minute
+)
+
+// CaseSearchAppService 案件全文检索应用服务：维护索引与数据库同步，提供检索
+type CaseSearchAppService struct {
+	index  domainService.CaseSearchIndex
+	mpRepo repository.MissingPersonRepository
+
+	syncMu        sync.Mutex // 串行化全量重建与增量同步
+	mu            sync.Mutex
+	rebuilding    bool
+	lastRebuildAt time.Time
+	lastError     string
+}
+
+// NewCaseSearchAppService 创建案件全文检索应用服务，index 为 nil 时禁用索引，检索退化为数据库模糊查询
+// 服务本身为 nil 时 IndexCase、RemoveCase、Ready 均可安全调用
+func NewCaseSearchAppService(index domainService.CaseSearchIndex, mpRepo repository.MissingPersonRepository) *CaseSearchAppService {
+	return &CaseSearchAppService{index: index, mpRepo: mpRepo}
+}
+
+// Start 启动索引同步：索引从未同步过时后台全量重建，否则从上次同步点增量同步；interval > 0 时定期增量同步，ctx 取消时停止
+func (s *CaseSearchAppService) Start(ctx context.Context, interval time.Duration) {
+	if s.index == nil {
+		return
+	}
+
+	if s.Ready() {
+		go func() {
+			if err := s.Sync(ctx); err != nil && ctx.Err() == nil {
+				logger.Warn("Failed to sync search index", logger.Err(err))
+			}
+		}()
+	} else if err := s.StartReindex(); err != nil {
+		logger.Warn("Failed to start search index rebuild", logger.Err(err))
+	}
+
+	if interval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := s.Sync(ctx); err != nil && ctx.Err() == nil {
+					logger.Warn("Failed to sync search index", logger.Err(err))
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Ready 索引是否可用（已完成至少一次全量同步）
+func (s *CaseSearchAppService) Ready() bool {
+	return s != nil && s.index != nil && !s.index.Stats().SyncedAt.IsZero()
+}
+
+// IndexCase 案件写入后更新索引，已合并或已删除的案件从索引移除
+// 失败只记录日志，不影响主流程，由定期增量同步补齐
+func (s *CaseSearchAppService) IndexCase(ctx context.Context, mp *entity.MissingPerson) {
+	if s == nil || s.index == nil {
+		return
+	}
+	if mp.IsMerged() || mp.DeletedAt.Valid {
+		s.RemoveCase(ctx, mp.ID)
+		return
+	}
+	if err := s.index.Index(ctx, mp); err != nil {
+		logger.Warn("Failed to index missing person", logger.String("mp_id", mp.ID), logger.Err(err))
+	}
+}
+
+// RemoveCase 从索引移除案件
+func (s *CaseSearchAppService) RemoveCase(ctx context.Context, id string) {
+	if s == nil || s.index == nil {
+		return
+	}
+	if err := s.index.Delete(ctx, id); err != nil {
+		logger.Warn("Failed to remove missing person from index", logger.String("mp_id", id), logger.Err(err))
+	}
+}
+
+// Search 全文检索，按相关度排序并返回高亮摘要
+func (s *CaseSearchAppService) Search(ctx context.Context, keyword string, page, pageSize int) (*dto.MissingPersonListResponse, error) {
+	if s.index == nil {
+		return nil, ErrSearchIndexDisabled
+	}
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+
+	result, err := s.index.Search(ctx, domainService.CaseSearchQuery{
+		Keyword: keyword,
+		Offset:  (page - 1) * pageSize,
+		Limit:   pageSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(result.Hits))
+	for i, h := range result.Hits {
+		ids[i] = h.ID
+	}
+	persons, err := s.mpRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*entity.MissingPerson, len(persons))
+	for i := range persons {
+		byID[persons[i].ID] = &persons[i]
+	}
+
+	// 按相关度顺序输出，索引中残留的已删除案件跳过
+	list := make([]dto.MissingPersonResponse, 0, len(result.Hits))
+	for _, h := range result.Hits {
+		mp, ok := byID[h.ID]
+		if !ok {
+			continue
+		}
+		resp := dto.ToMissingPersonResponse(mp)
+		resp.Highlights = h.Highlights
+		list = append(list, resp)
+	}
+
+	resp := dto.NewMissingPersonListResponse(list, int64(result.Total), page, pageSize)
+	return &resp, nil
+}
+
+// Reindex 全量重建索引并等待完成（命令行使用）
+func (s *CaseSearchAppService) Reindex(ctx context.Context) (int, error) {
+	if s.index == nil {
+		return 0, ErrSearchIndexDisabled
+	}
+	if !s.beginRebuild() {
+		return 0, ErrSearchRebuilding
+	}
+	return s.rebuild(ctx)
+}
+
+// StartReindex 后台全量重建索引，重建期间旧索引继续提供检索
+func (s *CaseSearchAppService) StartReindex() error {
+	if s.index == nil {
+		return ErrSearchIndexDisabled
+	}
+	if !s.beginRebuild() {
+		return ErrSearchRebuilding
+	}
+	go s.rebuild(context.Background())
+	return nil
+}
+
+// Sync 增量同步：重新索引上次同步点之后修改过的案件，移除已删除及已合并的案件
+func (s *CaseSearchAppService) Sync(ctx context.Context) error {
+	if !s.Ready() {
+		return nil
+	}
+	if !s.syncMu.TryLock() {
+		return nil
+	}
+	defer s.syncMu.Unlock()
+
+	started := time.Now()
+	changed, err := s.mpRepo.FindChangedSince(ctx, s.index.Stats().SyncedAt.Add(-caseSyncOverlap))
+	if err != nil {
+		return err
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+	for i := range changed {
+		s.IndexCase(ctx, &changed[i])
+	}
+	logger.Info("Search index synced", logger.Int("changed", len(changed)))
+	return s.index.Checkpoint(ctx, started)
+}
+
+// Status 索引状态
+func (s *CaseSearchAppService) Status() *dto.CaseSearchIndexStatusResponse {
+	if s.index == nil {
+		return &dto.CaseSearchIndexStatusResponse{Engine: "database"}
+	}
+
+	stats := s.index.Stats()
+	resp := &dto.CaseSearchIndexStatusResponse{
+		Engine:    stats.Engine,
+		Documents: stats.Documents,
+		Terms:     stats.Terms,
+		Ready:     !stats.SyncedAt.IsZero(),
+	}
+	if !stats.SyncedAt.IsZero() {
+		resp.SyncedAt = &stats.SyncedAt
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	resp.Rebuilding = s.rebuilding
+	resp.LastError = s.lastError
+	if !s.lastRebuildAt.IsZero() {
+		t := s.lastRebuildAt
+		resp.LastRebuildAt = &t
+	}
+	return resp
+}
+
+// beginRebuild 标记开始重建，已在重建时返回 false
+func (s *CaseSearchAppService) beginRebuild() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.rebuilding {
+		return false
+	}
+	s.rebuilding = true
+	return true
+}
+
+// rebuild 执行全量重建，以开始时间作为同步点，重建期间的修改由下一次增量同步补齐
+func (s *CaseSearchAppService) rebuild(ctx context.Context) (int, error) {
+	s.syncMu.Lock()
+	defer s.syncMu.Unlock()
+
+	started := time.Now()
+	afterID := ""
+	n, err := s.index.Rebuild(ctx, func() ([]entity.MissingPerson, error) {
+		batch, err := s.mpRepo.FindForIndex(ctx, afterID, caseIndexBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		if len(batch) > 0 {
+			afterID = batch[len(batch)-1].ID
+		}
+		return batch, nil
+	})
+	if err == nil {
+		err = s.index.Checkpoint(ctx, started)
+	}
+
+	s.mu.Lock()
+	s.rebuilding = false
+	s.lastRebuildAt = time.Now()
+	s.lastError = ""
+	if err != nil {
+		s.lastError = err.Error()
+	}
+	s.mu.Unlock()
+
+	if err != nil {
+		logger.Error("Failed to rebuild search index", logger.Err(err))
+		return 0, err
+	}
+	logger.Info("Search index rebuilt",
+		logger.Int("documents", n),
+		logger.String("elapsed", time.Since(started).String()),
+	)
+	return n, nil
+}
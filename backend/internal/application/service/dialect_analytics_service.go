@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/dto"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/repository"
+	domainService "github.com/Snowitty-Re/CNtunyuan/internal/domain/service"
+	"github.com/Snowitty-Re/CNtunyuan/internal/infrastructure/cache"
+)
+
+var ErrDialectAnalyticsRange = errors.New("invalid analytics range")
+
+const (
+	// dialectAnalyticsDefaultDays 默认统计最近天数
+	dialectAnalyticsDefaultDays = 30
+	// dialectAnalyticsMaxDays 单次统计最大天数
+	dialectAnalyticsMaxDays = 366
+	// dialectAnalyticsDefaultLimit 默认热门地区、热门方言条数
+	dialectAnalyticsDefaultLimit = 10
+	// dialectAnalyticsCacheTTL 统计结果缓存时间
+	dialectAnalyticsCacheTTL = 5 * time.Minute
+	// dialectAnalyticsDateLayout 统计日期格式
+	dialectAnalyticsDateLayout = "2006-01-02"
+)
+
+// DialectAnalyticsAppService 方言播放统计应用服务
+type DialectAnalyticsAppService struct {
+	analyticsRepo repository.DialectAnalyticsRepository
+	cacheAside    *cache.CacheAside
+}
+
+// NewDialectAnalyticsAppService 创建方言播放统计应用服务，cacheManager 为空时不缓存统计结果
+func NewDialectAnalyticsAppService(analyticsRepo repository.DialectAnalyticsRepository, cacheManager cache.CacheManager) *DialectAnalyticsAppService {
+	s := &DialectAnalyticsAppService{analyticsRepo: analyticsRepo}
+	if cacheManager != nil {
+		s.cacheAside = cache.NewCacheAside(cacheManager)
+	}
+	return s
+}
+
+// Analytics 组织的方言播放统计：播放趋势、完成率、去重收听者、热门地区和热门方言
+func (s *DialectAnalyticsAppService) Analytics(ctx context.Context, req *dto.DialectAnalyticsRequest, orgID string) (*dto.DialectAnalyticsResponse, error) {
+	now := time.Now()
+	to := domainService.DialectPlayIntervalDay.Start(now)
+	if req.To != nil {
+		to = domainService.DialectPlayIntervalDay.Start(*req.To)
+	}
+	to = to.AddDate(0, 0, 1)
+	from := to.AddDate(0, 0, -dialectAnalyticsDefaultDays)
+	if req.From != nil {
+		from = domainService.DialectPlayIntervalDay.Start(*req.From)
+	}
+	if !from.Before(to) {
+		return nil, fmt.Errorf("%w: 开始日期晚于结束日期", ErrDialectAnalyticsRange)
+	}
+	if to.Sub(from) > dialectAnalyticsMaxDays*24*time.Hour {
+		return nil, fmt.Errorf("%w: 统计范围不能超过 %d 天", ErrDialectAnalyticsRange, dialectAnalyticsMaxDays)
+	}
+
+	interval := domainService.DialectPlayInterval(req.Interval)
+	if !interval.IsValid() {
+		interval = domainService.DialectPlayIntervalDay
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = dialectAnalyticsDefaultLimit
+	}
+
+	query := &repository.DialectAnalyticsQuery{
+		OrgID:     orgID,
+		DialectID: req.DialectID,
+		From:      from,
+		To:        to,
+	}
+	load := func() (interface{}, error) {
+		return s.compute(ctx, query, interval, limit)
+	}
+
+	if s.cacheAside == nil {
+		resp, err := load()
+		if err != nil {
+			return nil, err
+		}
+		return resp.(*dto.DialectAnalyticsResponse), nil
+	}
+
+	key := fmt.Sprintf("dialect:analytics:%s:%s:%s:%s:%s:%d", orgID, req.DialectID,
+		from.Format(dialectAnalyticsDateLayout), to.Format(dialectAnalyticsDateLayout), interval, limit)
+	var resp dto.DialectAnalyticsResponse
+	if err := s.cacheAside.GetOrSet(ctx, key, &resp, dialectAnalyticsCacheTTL, load); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// compute 从播放记录计算统计结果
+func (s *DialectAnalyticsAppService) compute(ctx context.Context, query *repository.DialectAnalyticsQuery, interval domainService.DialectPlayInterval, limit int) (*dto.DialectAnalyticsResponse, error) {
+	summary, err := s.analyticsRepo.Summary(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	days, err := s.analyticsRepo.ListenerDays(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	regions, err := s.analyticsRepo.TopRegions(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	clips, err := s.analyticsRepo.TopClips(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.DialectAnalyticsResponse{
+		OrgID:       query.OrgID,
+		DialectID:   query.DialectID,
+		From:        query.From.Format(dialectAnalyticsDateLayout),
+		To:          query.To.AddDate(0, 0, -1).Format(dialectAnalyticsDateLayout),
+		Interval:    string(interval),
+		Summary:     *summary,
+		Series:      []dto.DialectPlayPointResponse{},
+		TopRegions:  regions,
+		TopClips:    clips,
+		GeneratedAt: time.Now(),
+	}
+	for _, b := range domainService.BucketDialectPlays(days, query.From, query.To, interval) {
+		resp.Series = append(resp.Series, dto.DialectPlayPointResponse{
+			Date:           b.Start.Format(dialectAnalyticsDateLayout),
+			Plays:          b.Plays,
+			Listeners:      b.Listeners,
+			CompletionRate: b.CompletionRate,
+		})
+	}
+	return resp, nil
+}
@@ -0,0 +1,471 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/dto"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/repository"
+	domainService "github.com/Snowitty-Re/CNtunyuan/internal/domain/service"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/logger"
+)
+
+var (
+	ErrDialectCommentNotFound  = errors.New("dialect comment not found")
+	ErrDialectCommentInvalid   = errors.New("invalid dialect comment")
+	ErrDialectCommentSensitive = errors.New("comment contains sensitive words")
+	ErrDialectCommentReported  = errors.New("comment already reported")
+)
+
+// dialectCommentReplyPreview 主评论列表中每条主评论附带的回复数
+const dialectCommentReplyPreview = 3
+
+// DialectCommentAppService 方言评论应用服务：楼中楼评论、点赞、敏感词过滤、举报和管理员隐藏
+type DialectCommentAppService struct {
+	commentRepo         repository.DialectCommentRepository
+	dialectRepo         repository.DialectRepository
+	contentFilter       domainService.ContentFilter
+	rejectSensitive     bool
+	notificationService *NotificationAppService
+	auditService        *AuditService
+}
+
+// NewDialectCommentAppService 创建方言评论应用服务。contentFilter 为空时不过滤；
+// rejectSensitive 为 true 时拒绝含敏感词的评论，否则替换为 * 并标记待管理员复核
+func NewDialectCommentAppService(
+	commentRepo repository.DialectCommentRepository,
+	dialectRepo repository.DialectRepository,
+	contentFilter domainService.ContentFilter,
+	rejectSensitive bool,
+	notificationService *NotificationAppService,
+	auditService *AuditService,
+) *DialectCommentAppService {
+	return &DialectCommentAppService{
+		commentRepo:         commentRepo,
+		dialectRepo:         dialectRepo,
+		contentFilter:       contentFilter,
+		rejectSensitive:     rejectSensitive,
+		notificationService: notificationService,
+		auditService:        auditService,
+	}
+}
+
+// Create 发表评论或回复，回复时通知被回复评论的作者
+func (s *DialectCommentAppService) Create(ctx context.Context, dialectID string, req *dto.CreateDialectCommentRequest, userID string) (*dto.DialectCommentResponse, error) {
+	content := strings.TrimSpace(req.Content)
+	if content == "" {
+		return nil, fmt.Errorf("%w: 评论内容不能为空", ErrDialectCommentInvalid)
+	}
+	if utf8.RuneCountInString(content) > entity.DialectCommentMaxLength {
+		return nil, fmt.Errorf("%w: 评论不能超过 %d 字", ErrDialectCommentInvalid, entity.DialectCommentMaxLength)
+	}
+
+	d, err := s.dialectRepo.FindByID(ctx, dialectID)
+	if err != nil {
+		return nil, ErrDialectNotFound
+	}
+
+	comment := &entity.DialectComment{
+		DialectID: d.ID,
+		UserID:    userID,
+		Content:   content,
+		Status:    entity.DialectCommentVisible,
+	}
+
+	var target *entity.DialectComment
+	if req.ParentID != "" {
+		target, err = s.commentRepo.FindByIDWithUser(ctx, req.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		if target == nil || target.DialectID != d.ID {
+			return nil, ErrDialectCommentNotFound
+		}
+		if target.IsHidden() {
+			return nil, entity.ErrDialectCommentHidden
+		}
+		comment.ReplyTo(target)
+	}
+
+	if s.contentFilter != nil {
+		result, err := s.contentFilter.Check(ctx, content)
+		if err != nil {
+			return nil, err
+		}
+		if result.Hit() {
+			if s.rejectSensitive {
+				return nil, ErrDialectCommentSensitive
+			}
+			comment.Content = result.Masked
+			comment.Flagged = true
+		}
+	}
+
+	if err := s.commentRepo.Add(ctx, comment); err != nil {
+		return nil, err
+	}
+
+	if target != nil {
+		s.notifyReply(ctx, d, comment, target)
+	}
+
+	resp := dto.ToDialectCommentResponse(comment, false)
+	return &resp, nil
+}
+
+// ListThreads 分页获取主评论，每条附带最早的几条回复
+func (s *DialectCommentAppService) ListThreads(ctx context.Context, dialectID string, req *dto.DialectCommentListRequest, viewerID string, moderator bool) (*dto.PageResult[dto.DialectCommentResponse], error) {
+	pagination := repository.Pagination{Page: req.Page, PageSize: req.PageSize}
+	result, err := s.commentRepo.ListThreads(ctx, dialectID, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(result.List))
+	for _, c := range result.List {
+		if c.ReplyCount > 0 {
+			ids = append(ids, c.ID)
+		}
+	}
+	previews, err := s.commentRepo.PreviewReplies(ctx, ids, dialectCommentReplyPreview)
+	if err != nil {
+		return nil, err
+	}
+
+	likeIDs := make([]string, 0, len(result.List))
+	for _, c := range result.List {
+		likeIDs = append(likeIDs, c.ID)
+		for _, reply := range previews[c.ID] {
+			likeIDs = append(likeIDs, reply.ID)
+		}
+	}
+	liked, err := s.commentRepo.LikedIDs(ctx, viewerID, likeIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]dto.DialectCommentResponse, len(result.List))
+	for i := range result.List {
+		list[i] = s.toResponse(&result.List[i], liked, moderator)
+		replies := previews[result.List[i].ID]
+		for j := range replies {
+			list[i].Replies = append(list[i].Replies, s.toResponse(&replies[j], liked, moderator))
+		}
+	}
+	return newDialectCommentPage(list, result), nil
+}
+
+// ListReplies 分页获取主评论下的回复
+func (s *DialectCommentAppService) ListReplies(ctx context.Context, commentID string, req *dto.DialectCommentListRequest, viewerID string, moderator bool) (*dto.PageResult[dto.DialectCommentResponse], error) {
+	parent, err := s.commentRepo.FindByIDWithUser(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if parent == nil {
+		return nil, ErrDialectCommentNotFound
+	}
+
+	pagination := repository.Pagination{Page: req.Page, PageSize: req.PageSize}
+	result, err := s.commentRepo.ListReplies(ctx, parent.ThreadID(), pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(result.List))
+	for i, c := range result.List {
+		ids[i] = c.ID
+	}
+	liked, err := s.commentRepo.LikedIDs(ctx, viewerID, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]dto.DialectCommentResponse, len(result.List))
+	for i := range result.List {
+		list[i] = s.toResponse(&result.List[i], liked, moderator)
+	}
+	return newDialectCommentPage(list, result), nil
+}
+
+// Like 点赞评论
+func (s *DialectCommentAppService) Like(ctx context.Context, commentID, userID string) error {
+	comment, err := s.find(ctx, commentID)
+	if err != nil {
+		return err
+	}
+	if comment.IsHidden() {
+		return entity.ErrDialectCommentHidden
+	}
+
+	liked, err := s.commentRepo.Like(ctx, entity.NewDialectCommentLike(comment.ID, userID))
+	if err != nil {
+		return err
+	}
+	if !liked {
+		return ErrAlreadyLiked
+	}
+	return nil
+}
+
+// Unlike 取消点赞
+func (s *DialectCommentAppService) Unlike(ctx context.Context, commentID, userID string) error {
+	removed, err := s.commentRepo.Unlike(ctx, commentID, userID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrNotLiked
+	}
+	return nil
+}
+
+// Reasons 举报原因列表
+func (s *DialectCommentAppService) Reasons() []dto.DialectCommentReportReasonResponse {
+	reasons := make([]dto.DialectCommentReportReasonResponse, len(entity.DialectCommentReportReasons))
+	for i, r := range entity.DialectCommentReportReasons {
+		reasons[i] = dto.DialectCommentReportReasonResponse{Code: string(r), Label: r.Label()}
+	}
+	return reasons
+}
+
+// Report 举报评论，进入组织管理员的评论管理列表
+func (s *DialectCommentAppService) Report(ctx context.Context, commentID string, req *dto.ReportDialectCommentRequest, reporterID string) error {
+	reason := entity.DialectCommentReportReason(req.Reason)
+	if !reason.IsValid() {
+		return fmt.Errorf("%w: 无效的举报原因", ErrDialectCommentInvalid)
+	}
+	detail := strings.TrimSpace(req.Detail)
+	if reason == entity.CommentReportOther && detail == "" {
+		return fmt.Errorf("%w: 请填写举报说明", ErrDialectCommentInvalid)
+	}
+
+	comment, err := s.find(ctx, commentID)
+	if err != nil {
+		return err
+	}
+	if comment.UserID == reporterID {
+		return fmt.Errorf("%w: 不能举报自己的评论", ErrDialectCommentInvalid)
+	}
+	if comment.IsHidden() {
+		return entity.ErrDialectCommentHidden
+	}
+
+	added, err := s.commentRepo.AddReport(ctx, &entity.DialectCommentReport{
+		CommentID:  comment.ID,
+		DialectID:  comment.DialectID,
+		ReporterID: reporterID,
+		Reason:     reason,
+		Detail:     detail,
+		Status:     entity.CommentReportPending,
+	})
+	if err != nil {
+		return err
+	}
+	if !added {
+		return ErrDialectCommentReported
+	}
+	return nil
+}
+
+// ListModeration 组织的评论管理列表：命中敏感词待复核、被举报或已隐藏的评论
+func (s *DialectCommentAppService) ListModeration(ctx context.Context, req *dto.DialectCommentModerationRequest, orgID string) (*dto.PageResult[dto.DialectCommentResponse], error) {
+	result, err := s.commentRepo.ListModeration(ctx, &repository.DialectCommentQuery{
+		Pagination: repository.Pagination{Page: req.Page, PageSize: req.PageSize},
+		OrgID:      orgID,
+		DialectID:  req.DialectID,
+		Filter:     req.Filter,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]dto.DialectCommentResponse, len(result.List))
+	for i := range result.List {
+		list[i] = dto.ToDialectCommentResponse(&result.List[i], true)
+	}
+	return newDialectCommentPage(list, result), nil
+}
+
+// ListReports 评论的举报记录
+func (s *DialectCommentAppService) ListReports(ctx context.Context, commentID, orgID string) ([]dto.DialectCommentReportResponse, error) {
+	comment, err := s.findInOrg(ctx, commentID, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	reports, err := s.commentRepo.ListReports(ctx, comment.ID)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]dto.DialectCommentReportResponse, len(reports))
+	for i := range reports {
+		list[i] = dto.ToDialectCommentReportResponse(&reports[i])
+	}
+	return list, nil
+}
+
+// Hide 隐藏评论，待处理的举报视为成立
+func (s *DialectCommentAppService) Hide(ctx context.Context, commentID string, req *dto.HideDialectCommentRequest, moderatorID, orgID string) (*dto.DialectCommentResponse, error) {
+	comment, err := s.findInOrg(ctx, commentID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if err := comment.Hide(moderatorID, strings.TrimSpace(req.Reason), time.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.commentRepo.SaveModeration(ctx, comment, entity.CommentReportUpheld, moderatorID); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, moderatorID, orgID, comment, "隐藏方言评论")
+	resp := dto.ToDialectCommentResponse(comment, true)
+	return &resp, nil
+}
+
+// Unhide 恢复显示被隐藏的评论
+func (s *DialectCommentAppService) Unhide(ctx context.Context, commentID, moderatorID, orgID string) (*dto.DialectCommentResponse, error) {
+	comment, err := s.findInOrg(ctx, commentID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if err := comment.Unhide(); err != nil {
+		return nil, err
+	}
+	if err := s.commentRepo.SaveModeration(ctx, comment, "", moderatorID); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, moderatorID, orgID, comment, "恢复显示方言评论")
+	resp := dto.ToDialectCommentResponse(comment, true)
+	return &resp, nil
+}
+
+// Dismiss 复核后保留评论：清除敏感词标记，驳回待处理的举报
+func (s *DialectCommentAppService) Dismiss(ctx context.Context, commentID, moderatorID, orgID string) (*dto.DialectCommentResponse, error) {
+	comment, err := s.findInOrg(ctx, commentID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.IsHidden() {
+		return nil, entity.ErrDialectCommentHidden
+	}
+	comment.Flagged = false
+	if err := s.commentRepo.SaveModeration(ctx, comment, entity.CommentReportDismissed, moderatorID); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, moderatorID, orgID, comment, "复核保留方言评论")
+	resp := dto.ToDialectCommentResponse(comment, true)
+	return &resp, nil
+}
+
+// find 获取评论
+func (s *DialectCommentAppService) find(ctx context.Context, id string) (*entity.DialectComment, error) {
+	comment, err := s.commentRepo.FindByIDWithUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if comment == nil {
+		return nil, ErrDialectCommentNotFound
+	}
+	return comment, nil
+}
+
+// findInOrg 获取组织方言下的评论，管理员只能管理本组织方言的评论
+func (s *DialectCommentAppService) findInOrg(ctx context.Context, id, orgID string) (*entity.DialectComment, error) {
+	comment, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if comment.Dialect == nil || comment.Dialect.OrgID != orgID {
+		return nil, ErrDialectCommentNotFound
+	}
+	return comment, nil
+}
+
+// toResponse 转换为评论响应并标记当前用户是否已点赞
+func (s *DialectCommentAppService) toResponse(c *entity.DialectComment, liked map[string]bool, moderator bool) dto.DialectCommentResponse {
+	resp := dto.ToDialectCommentResponse(c, moderator)
+	resp.Liked = liked[c.ID]
+	return resp
+}
+
+// notifyReply 通知被回复评论的作者；回复楼中楼时主评论作者也会收到通知
+func (s *DialectCommentAppService) notifyReply(ctx context.Context, d *entity.Dialect, reply, target *entity.DialectComment) {
+	if s.notificationService == nil {
+		return
+	}
+
+	recipients := []string{target.UserID}
+	if target.IsReply() {
+		if root, err := s.commentRepo.FindByID(ctx, target.ThreadID()); err == nil && root.UserID != target.UserID {
+			recipients = append(recipients, root.UserID)
+		}
+	}
+
+	preview := []rune(reply.Content)
+	if len(preview) > 50 {
+		preview = append(preview[:50], []rune("…")...)
+	}
+	for _, userID := range recipients {
+		if userID == reply.UserID {
+			continue
+		}
+		req := &dto.SendNotificationRequest{
+			Title:        "评论收到新回复",
+			Content:      fmt.Sprintf("你在方言「%s」下的评论收到回复：%s", d.Title, string(preview)),
+			Type:         entity.NotificationTypeMessage,
+			Channel:      entity.NotificationChannelWebSocket,
+			Priority:     entity.PriorityNormal,
+			ToUserID:     userID,
+			FromUserID:   &reply.UserID,
+			OrgID:        d.OrgID,
+			BusinessType: dialectEntityType,
+			BusinessID:   &d.ID,
+			Data: map[string]interface{}{
+				"dialect_id": d.ID,
+				"comment_id": reply.ID,
+				"parent_id":  reply.ThreadID(),
+			},
+		}
+		if err := s.notificationService.SendNotification(ctx, req); err != nil {
+			logger.Error("Failed to notify comment author", logger.String("comment_id", reply.ID), logger.Err(err))
+		}
+	}
+}
+
+// audit 记录评论管理审计日志
+func (s *DialectCommentAppService) audit(ctx context.Context, operatorID, orgID string, comment *entity.DialectComment, desc string) {
+	if s.auditService == nil {
+		return
+	}
+	auditLog := entity.NewAuditLog(operatorID, orgID, entity.AuditActionUpdate, string(entity.ResourceDialect)).
+		SetResourceID(comment.DialectID).
+		SetDescription(desc).
+		AddExtra("comment_id", comment.ID).
+		AddExtra("comment_user_id", comment.UserID).
+		AddExtra("status", string(comment.Status))
+	if comment.Dialect != nil {
+		auditLog.SetResourceName(comment.Dialect.Title)
+	}
+	if comment.HiddenReason != "" {
+		auditLog.AddExtra("reason", comment.HiddenReason)
+	}
+	s.auditService.Log(ctx, auditLog)
+}
+
+// newDialectCommentPage 组装评论分页结果
+func newDialectCommentPage(list []dto.DialectCommentResponse, result *repository.PageResult[entity.DialectComment]) *dto.PageResult[dto.DialectCommentResponse] {
+	return &dto.PageResult[dto.DialectCommentResponse]{
+		List:       list,
+		Total:      result.Total,
+		Page:       result.Page,
+		PageSize:   result.PageSize,
+		TotalPages: result.TotalPages,
+	}
+}
@@ -0,0 +1,431 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/dto"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/repository"
+	domainService "github.com/Snowitty-Re/CNtunyuan/internal/domain/service"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/logger"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrDialectGroupNotFound = errors.New("dialect group not found")
+	ErrDialectGroupInvalid  = errors.New("invalid dialect group")
+	ErrDialectGroupExists   = errors.New("dialect group already exists")
+	ErrDialectGroupInUse    = errors.New("dialect group in use")
+)
+
+// DialectGroupAppService 方言分类应用服务
+type DialectGroupAppService struct {
+	groupRepo    repository.DialectGroupRepository
+	dialectRepo  repository.DialectRepository
+	auditService *AuditService
+}
+
+// NewDialectGroupAppService 创建方言分类应用服务
+func NewDialectGroupAppService(
+	groupRepo repository.DialectGroupRepository,
+	dialectRepo repository.DialectRepository,
+	auditService *AuditService,
+) *DialectGroupAppService {
+	return &DialectGroupAppService{
+		groupRepo:    groupRepo,
+		dialectRepo:  dialectRepo,
+		auditService: auditService,
+	}
+}
+
+// Tree 获取完整方言分类树
+func (s *DialectGroupAppService) Tree(ctx context.Context) ([]dto.DialectGroupResponse, error) {
+	groups, err := s.groupRepo.FindAllWithRegions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return buildDialectGroupTree(groups), nil
+}
+
+// GetByID 获取分类详情，含上级、直接下级及子树下的方言数
+func (s *DialectGroupAppService) GetByID(ctx context.Context, id string) (*dto.DialectGroupResponse, error) {
+	group, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := dto.ToDialectGroupResponse(group)
+	for _, ancestorID := range group.AncestorIDs() {
+		ancestor, err := s.groupRepo.FindByID(ctx, ancestorID)
+		if err != nil {
+			return nil, err
+		}
+		resp.Ancestors = append(resp.Ancestors, dto.ToDialectGroupBrief(ancestor))
+	}
+
+	subtree, err := s.groupRepo.FindSubtree(ctx, group.Path)
+	if err != nil {
+		return nil, err
+	}
+	for i := range subtree {
+		if subtree[i].ParentID != nil && *subtree[i].ParentID == group.ID {
+			resp.Children = append(resp.Children, dto.ToDialectGroupResponse(&subtree[i]))
+		}
+	}
+
+	count, err := s.groupRepo.CountDialects(ctx, group.Path)
+	if err != nil {
+		return nil, err
+	}
+	resp.DialectCount = &count
+	return &resp, nil
+}
+
+// FindByRegion 查找对应地区覆盖指定省市的分类，由深到浅排列，便于上传时推荐
+func (s *DialectGroupAppService) FindByRegion(ctx context.Context, province, city string) ([]dto.DialectGroupResponse, error) {
+	groups, err := s.groupRepo.FindByRegion(ctx, province, city)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]dto.DialectGroupResponse, 0, len(groups))
+	for depth := entity.DialectGroupVariety.Depth(); depth > 0; depth-- {
+		for i := range groups {
+			if groups[i].Level.Depth() == depth {
+				list = append(list, dto.ToDialectGroupResponse(&groups[i]))
+			}
+		}
+	}
+	return list, nil
+}
+
+// Create 创建分类
+func (s *DialectGroupAppService) Create(ctx context.Context, req *dto.DialectGroupRequest, operatorID, orgID string) (*dto.DialectGroupResponse, error) {
+	group := &entity.DialectGroup{
+		BaseEntity: entity.BaseEntity{ID: uuid.New().String()},
+	}
+	if err := s.apply(ctx, group, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.groupRepo.CreateWithRegions(ctx, group); err != nil {
+		logger.Error("Failed to create dialect group", logger.Err(err))
+		return nil, err
+	}
+
+	s.audit(ctx, operatorID, orgID, entity.AuditActionCreate, group, "创建方言分类")
+	logger.Info("Dialect group created", logger.String("group_id", group.ID), logger.String("name", group.FullName))
+
+	resp := dto.ToDialectGroupResponse(group)
+	return &resp, nil
+}
+
+// Update 修改分类，改名或移动时同步更新下级分类的路径、全称及已归类方言的地区名称
+func (s *DialectGroupAppService) Update(ctx context.Context, id string, req *dto.DialectGroupRequest, operatorID, orgID string) (*dto.DialectGroupResponse, error) {
+	group, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	oldPath, oldFullName := group.Path, group.FullName
+
+	if err := s.apply(ctx, group, req); err != nil {
+		return nil, err
+	}
+
+	var descendants []entity.DialectGroup
+	if group.Path != oldPath || group.FullName != oldFullName {
+		subtree, err := s.groupRepo.FindSubtree(ctx, oldPath)
+		if err != nil {
+			return nil, err
+		}
+		if descendants, err = replaceDialectSubtree(group, subtree); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrDialectGroupInvalid, err)
+		}
+	}
+
+	if err := s.groupRepo.UpdateWithRegions(ctx, group, descendants); err != nil {
+		logger.Error("Failed to update dialect group", logger.String("group_id", id), logger.Err(err))
+		return nil, err
+	}
+
+	s.audit(ctx, operatorID, orgID, entity.AuditActionUpdate, group, "修改方言分类")
+
+	resp := dto.ToDialectGroupResponse(group)
+	return &resp, nil
+}
+
+// Delete 删除分类，存在下级分类或已归类方言时不允许删除
+func (s *DialectGroupAppService) Delete(ctx context.Context, id string, operatorID, orgID string) error {
+	group, err := s.find(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	children, err := s.groupRepo.CountChildren(ctx, id)
+	if err != nil {
+		return err
+	}
+	if children > 0 {
+		return fmt.Errorf("%w: 存在 %d 个下级分类", ErrDialectGroupInUse, children)
+	}
+	dialects, err := s.groupRepo.CountDialects(ctx, group.Path)
+	if err != nil {
+		return err
+	}
+	if dialects > 0 {
+		return fmt.Errorf("%w: 已有 %d 条方言归入该分类", ErrDialectGroupInUse, dialects)
+	}
+
+	if err := s.groupRepo.SoftDelete(ctx, id); err != nil {
+		logger.Error("Failed to delete dialect group", logger.String("group_id", id), logger.Err(err))
+		return err
+	}
+
+	s.audit(ctx, operatorID, orgID, entity.AuditActionDelete, group, "删除方言分类")
+	return nil
+}
+
+// SeedDefaults 分类为空时导入默认方言分类，返回导入数量
+func (s *DialectGroupAppService) SeedDefaults(ctx context.Context) (int, error) {
+	count, err := s.groupRepo.Count(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if count > 0 {
+		return 0, nil
+	}
+
+	groups := defaultDialectGroups()
+	if err := s.groupRepo.CreateTree(ctx, groups); err != nil {
+		return 0, err
+	}
+	logger.Info("Default dialect groups seeded", logger.Int("groups", len(groups)))
+	return len(groups), nil
+}
+
+// MigrateLegacy 将尚未归类的历史方言按自由文本地区、省市匹配到方言分类。
+// 分类为空时先导入默认分类；dryRun 时只返回匹配结果，不写入任何数据
+func (s *DialectGroupAppService) MigrateLegacy(ctx context.Context, dryRun bool, operatorID, orgID string) (*dto.MigrateDialectGroupsResponse, error) {
+	resp := &dto.MigrateDialectGroupsResponse{
+		DryRun:    dryRun,
+		ByMethod:  make(map[string]int),
+		Unmatched: []dto.DialectGroupMatchResponse{},
+	}
+
+	var groups []entity.DialectGroup
+	count, err := s.groupRepo.Count(ctx)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case count > 0:
+		if groups, err = s.groupRepo.FindAllWithRegions(ctx); err != nil {
+			return nil, err
+		}
+	case dryRun:
+		for _, g := range defaultDialectGroups() {
+			groups = append(groups, *g)
+		}
+		resp.Seeded = len(groups)
+	default:
+		if resp.Seeded, err = s.SeedDefaults(ctx); err != nil {
+			return nil, err
+		}
+		if groups, err = s.groupRepo.FindAllWithRegions(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	dialects, err := s.dialectRepo.FindUngrouped(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp.Total = len(dialects)
+
+	matcher := domainService.NewDialectGroupMatcher(groups)
+	for _, d := range dialects {
+		item := dto.DialectGroupMatchResponse{
+			DialectID: d.ID,
+			Title:     d.Title,
+			Region:    d.Region,
+			Province:  d.Province,
+			City:      d.City,
+		}
+		m := matcher.Match(d.Region, d.Province, d.City)
+		if m == nil {
+			resp.Unmatched = append(resp.Unmatched, item)
+			continue
+		}
+
+		brief := dto.ToDialectGroupBrief(m.Group)
+		item.Method = m.Method
+		item.Group = &brief
+		resp.Matched++
+		resp.ByMethod[m.Method]++
+		if dryRun {
+			resp.Matches = append(resp.Matches, item)
+			continue
+		}
+		if err := s.dialectRepo.AssignGroup(ctx, d.ID, m.Group.ID, m.Group.Name); err != nil {
+			logger.Error("Failed to assign dialect group", logger.String("dialect_id", d.ID), logger.Err(err))
+			return nil, err
+		}
+	}
+
+	logger.Info("Legacy dialects grouped",
+		logger.Bool("dry_run", dryRun),
+		logger.Int("total", resp.Total),
+		logger.Int("matched", resp.Matched),
+		logger.Int("unmatched", len(resp.Unmatched)),
+	)
+
+	if !dryRun && operatorID != "" && s.auditService != nil {
+		auditLog := entity.NewAuditLog(operatorID, orgID, entity.AuditActionUpdate, string(entity.ResourceDialect)).
+			SetDescription("历史方言归类").
+			AddExtra("seeded", resp.Seeded).
+			AddExtra("total", resp.Total).
+			AddExtra("matched", resp.Matched)
+		s.auditService.Log(ctx, auditLog)
+	}
+	return resp, nil
+}
+
+// find 获取分类（含对应地区）
+func (s *DialectGroupAppService) find(ctx context.Context, id string) (*entity.DialectGroup, error) {
+	group, err := s.groupRepo.FindByIDWithRegions(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, ErrDialectGroupNotFound
+	}
+	return group, nil
+}
+
+// apply 将请求写入分类并校验，上级分类变化时重新计算层级和路径
+func (s *DialectGroupAppService) apply(ctx context.Context, group *entity.DialectGroup, req *dto.DialectGroupRequest) error {
+	group.Name = strings.TrimSpace(req.Name)
+	group.SetAliases(req.Aliases)
+	group.Sort = req.Sort
+	group.Description = req.Description
+	group.Regions = make([]entity.DialectGroupRegion, 0, len(req.Regions))
+	for _, r := range req.Regions {
+		group.Regions = append(group.Regions, entity.DialectGroupRegion{
+			Province: strings.TrimSpace(r.Province),
+			City:     strings.TrimSpace(r.City),
+		})
+	}
+
+	var parent *entity.DialectGroup
+	if req.ParentID != "" {
+		p, err := s.groupRepo.FindByID(ctx, req.ParentID)
+		if err != nil {
+			return fmt.Errorf("%w: 上级分类不存在", ErrDialectGroupInvalid)
+		}
+		parent = p
+	}
+	if err := group.PlaceUnder(parent); err != nil {
+		return fmt.Errorf("%w: %v", ErrDialectGroupInvalid, err)
+	}
+	if err := group.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrDialectGroupInvalid, err)
+	}
+
+	exists, err := s.groupRepo.ExistsName(ctx, group.ParentID, group.Name, group.ID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("%w: 同级已有分类 %s", ErrDialectGroupExists, group.Name)
+	}
+	return nil
+}
+
+// audit 记录分类变更审计日志
+func (s *DialectGroupAppService) audit(ctx context.Context, operatorID, orgID string, action entity.AuditAction, group *entity.DialectGroup, desc string) {
+	if s.auditService == nil {
+		return
+	}
+	auditLog := entity.NewAuditLog(operatorID, orgID, action, string(entity.ResourceDialect)).
+		SetResourceID(group.ID).
+		SetResourceName(group.FullName).
+		SetDescription(desc).
+		AddExtra("level", string(group.Level))
+	s.auditService.Log(ctx, auditLog)
+}
+
+// replaceDialectSubtree 按更新后的分类重新计算子树中下级分类的层级、路径和全称。
+// subtree 按路径排序，上级总在下级之前
+func replaceDialectSubtree(group *entity.DialectGroup, subtree []entity.DialectGroup) ([]entity.DialectGroup, error) {
+	updated := map[string]*entity.DialectGroup{group.ID: group}
+	var descendants []entity.DialectGroup
+	for i := range subtree {
+		d := &subtree[i]
+		if d.ID == group.ID || d.ParentID == nil {
+			continue
+		}
+		parent := updated[*d.ParentID]
+		if parent == nil {
+			continue
+		}
+		if err := d.PlaceUnder(parent); err != nil {
+			return nil, fmt.Errorf("下级分类 %s: %v", d.Name, err)
+		}
+		updated[d.ID] = d
+		descendants = append(descendants, *d)
+	}
+	return descendants, nil
+}
+
+// buildDialectGroupTree 按上级组装分类树，groups 已按排序值排列
+func buildDialectGroupTree(groups []entity.DialectGroup) []dto.DialectGroupResponse {
+	children := make(map[string][]*entity.DialectGroup)
+	var roots []*entity.DialectGroup
+	for i := range groups {
+		g := &groups[i]
+		if g.ParentID == nil {
+			roots = append(roots, g)
+		} else {
+			children[*g.ParentID] = append(children[*g.ParentID], g)
+		}
+	}
+
+	var build func(list []*entity.DialectGroup) []dto.DialectGroupResponse
+	build = func(list []*entity.DialectGroup) []dto.DialectGroupResponse {
+		resp := make([]dto.DialectGroupResponse, 0, len(list))
+		for _, g := range list {
+			item := dto.ToDialectGroupResponse(g)
+			item.Children = build(children[g.ID])
+			resp = append(resp, item)
+		}
+		return resp
+	}
+	return build(roots)
+}
+
+// defaultDialectGroups 将默认方言分类展开为待创建的分类列表，上级在前
+func defaultDialectGroups() []*entity.DialectGroup {
+	var groups []*entity.DialectGroup
+	var walk func(nodes []domainService.DialectTaxonomyNode, parent *entity.DialectGroup)
+	walk = func(nodes []domainService.DialectTaxonomyNode, parent *entity.DialectGroup) {
+		for i, n := range nodes {
+			g := &entity.DialectGroup{
+				BaseEntity: entity.BaseEntity{ID: uuid.New().String()},
+				Name:       n.Name,
+				Sort:       i + 1,
+			}
+			g.SetAliases(n.Aliases)
+			for _, r := range n.Regions {
+				g.Regions = append(g.Regions, domainService.ParseTaxonomyRegion(r))
+			}
+			_ = g.PlaceUnder(parent)
+			groups = append(groups, g)
+			walk(n.Children, g)
+		}
+	}
+	walk(domainService.DefaultDialectTaxonomy(), nil)
+	return groups
+}
@@ -0,0 +1,679 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/dto"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/repository"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/bundle"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/logger"
+)
+
+var (
+	ErrDialectPlaylistNotFound     = errors.New("dialect playlist not found")
+	ErrDialectPlaylistInvalid      = errors.New("invalid dialect playlist")
+	ErrDialectPlaylistForbidden    = errors.New("no permission on dialect playlist")
+	ErrDialectPlaylistItemExists   = errors.New("dialect already in playlist")
+	ErrDialectPlaylistItemNotFound = errors.New("playlist item not found")
+	ErrDialectPlaylistCollaborator = errors.New("invalid playlist collaborator")
+	ErrDialectPlaylistEmpty        = errors.New("playlist has no playable clips")
+)
+
+const (
+	// dialectPlaylistEntityType 通知中的业务类型
+	dialectPlaylistEntityType = "dialect_playlist"
+	// dialectPlaylistBundleFormat 离线包格式标识
+	dialectPlaylistBundleFormat = "cntuanyuan-dialect-playlist"
+	// dialectCollectionTTL 自动合集的有效期，过期后查看或导出时重新生成
+	dialectCollectionTTL = 24 * time.Hour
+	// dialectCollectionCandidates 生成自动合集时参与评分的候选片段数
+	dialectCollectionCandidates = 500
+)
+
+// DialectPlaylistAppService 方言歌单应用服务：个人和组织共享歌单、协作编排、按省份自动生成的精选合集，
+// 以及供无网络环境使用的离线包（签名 ZIP，含音频和清单）
+type DialectPlaylistAppService struct {
+	playlistRepo        repository.DialectPlaylistRepository
+	dialectRepo         repository.DialectRepository
+	userRepo            repository.UserRepository
+	fileService         *FileAppService
+	notificationService *NotificationAppService
+	auditService        *AuditService
+	signer              *bundle.Signer
+	maxAudioBytes       int64
+}
+
+// NewDialectPlaylistAppService 创建方言歌单应用服务，离线包与案件移交包使用同一签名密钥
+func NewDialectPlaylistAppService(
+	playlistRepo repository.DialectPlaylistRepository,
+	dialectRepo repository.DialectRepository,
+	userRepo repository.UserRepository,
+	fileService *FileAppService,
+	notificationService *NotificationAppService,
+	auditService *AuditService,
+	signer *bundle.Signer,
+	maxAudioBytes int64,
+) *DialectPlaylistAppService {
+	return &DialectPlaylistAppService{
+		playlistRepo:        playlistRepo,
+		dialectRepo:         dialectRepo,
+		userRepo:            userRepo,
+		fileService:         fileService,
+		notificationService: notificationService,
+		auditService:        auditService,
+		signer:              signer,
+		maxAudioBytes:       maxAudioBytes,
+	}
+}
+
+// Create 创建歌单
+func (s *DialectPlaylistAppService) Create(ctx context.Context, req *dto.CreateDialectPlaylistRequest, userID string) (*dto.DialectPlaylistResponse, error) {
+	operator, err := s.operator(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := entity.NewDialectPlaylist(req.Title, req.Description, entity.DialectPlaylistVisibility(req.Visibility), operator.ID, operator.OrgID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDialectPlaylistInvalid, err)
+	}
+	if err := s.playlistRepo.Create(ctx, p); err != nil {
+		logger.Error("Failed to create dialect playlist", logger.Err(err))
+		return nil, err
+	}
+
+	logger.Info("Dialect playlist created", logger.String("playlist_id", p.ID), logger.String("user_id", operator.ID))
+	return s.detail(ctx, p.ID, operator)
+}
+
+// CreateCollection 创建按省份自动生成的精选合集
+func (s *DialectPlaylistAppService) CreateCollection(ctx context.Context, req *dto.CreateDialectCollectionRequest, userID string) (*dto.DialectPlaylistResponse, error) {
+	operator, err := s.operator(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := entity.NewDialectCollection(req.Province, req.Title, req.Limit, operator.ID, operator.OrgID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDialectPlaylistInvalid, err)
+	}
+	if err := s.playlistRepo.Create(ctx, p); err != nil {
+		logger.Error("Failed to create dialect collection", logger.Err(err))
+		return nil, err
+	}
+	if err := s.generate(ctx, p); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, operator, entity.AuditActionCreate, p, fmt.Sprintf("创建%s方言精选合集", p.Province))
+	return s.detail(ctx, p.ID, operator)
+}
+
+// List 分页获取可见的歌单
+func (s *DialectPlaylistAppService) List(ctx context.Context, req *dto.DialectPlaylistListRequest, userID string) (*dto.PageResult[dto.DialectPlaylistResponse], error) {
+	operator, err := s.operator(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.playlistRepo.List(ctx, &repository.DialectPlaylistQuery{
+		Pagination: repository.Pagination{Page: req.Page, PageSize: req.PageSize},
+		UserID:     operator.ID,
+		OrgID:      operator.OrgID,
+		Scope:      req.Scope,
+		Province:   strings.TrimSpace(req.Province),
+		Keyword:    strings.TrimSpace(req.Keyword),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]dto.DialectPlaylistResponse, len(result.List))
+	for i := range result.List {
+		list[i] = dto.ToDialectPlaylistResponse(&result.List[i])
+	}
+	return &dto.PageResult[dto.DialectPlaylistResponse]{
+		List:       list,
+		Total:      result.Total,
+		Page:       result.Page,
+		PageSize:   result.PageSize,
+		TotalPages: result.TotalPages,
+	}, nil
+}
+
+// Get 获取歌单详情，过期的自动合集先重新生成
+func (s *DialectPlaylistAppService) Get(ctx context.Context, id, userID string) (*dto.DialectPlaylistResponse, error) {
+	operator, err := s.operator(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	p, err := s.findVisible(ctx, id, operator)
+	if err != nil {
+		return nil, err
+	}
+	if s.refreshStale(ctx, p) {
+		return s.detail(ctx, p.ID, operator)
+	}
+	return s.toDetail(p, operator), nil
+}
+
+// Update 修改歌单名称、说明或可见范围；自动合集始终组织内共享
+func (s *DialectPlaylistAppService) Update(ctx context.Context, id string, req *dto.UpdateDialectPlaylistRequest, userID string) (*dto.DialectPlaylistResponse, error) {
+	operator, p, err := s.findManaged(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Title != nil {
+		p.Title = strings.TrimSpace(*req.Title)
+	}
+	if req.Description != nil {
+		p.Description = strings.TrimSpace(*req.Description)
+	}
+	if req.Visibility != nil {
+		if p.IsAuto() && *req.Visibility != string(entity.DialectPlaylistOrg) {
+			return nil, entity.ErrDialectPlaylistAuto
+		}
+		p.Visibility = entity.DialectPlaylistVisibility(*req.Visibility)
+	}
+	if err := p.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDialectPlaylistInvalid, err)
+	}
+	if err := s.playlistRepo.SaveInfo(ctx, p); err != nil {
+		return nil, err
+	}
+	return s.detail(ctx, p.ID, operator)
+}
+
+// Delete 删除歌单
+func (s *DialectPlaylistAppService) Delete(ctx context.Context, id, userID string) error {
+	operator, p, err := s.findManaged(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.playlistRepo.SoftDelete(ctx, p.ID); err != nil {
+		return err
+	}
+	if p.OwnerID != operator.ID {
+		s.audit(ctx, operator, entity.AuditActionDelete, p, "删除组织共享的方言歌单")
+	}
+	return nil
+}
+
+// AddItem 添加片段，只能添加可播放的方言
+func (s *DialectPlaylistAppService) AddItem(ctx context.Context, id string, req *dto.AddDialectPlaylistItemRequest, userID string) (*dto.DialectPlaylistResponse, error) {
+	operator, p, err := s.findEditable(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := s.dialectRepo.FindByID(ctx, req.DialectID)
+	if err != nil {
+		return nil, ErrDialectNotFound
+	}
+	if !d.CanPlay() {
+		return nil, fmt.Errorf("%w: 方言尚未通过审核或已下架", ErrDialectPlaylistInvalid)
+	}
+
+	item := entity.NewDialectPlaylistItem(p.ID, d.ID, operator.ID, req.Note, req.Position)
+	added, err := s.playlistRepo.AddItem(ctx, item)
+	if err != nil {
+		return nil, err
+	}
+	if !added {
+		return nil, ErrDialectPlaylistItemExists
+	}
+	return s.detail(ctx, p.ID, operator)
+}
+
+// RemoveItem 移除片段
+func (s *DialectPlaylistAppService) RemoveItem(ctx context.Context, id, itemID, userID string) (*dto.DialectPlaylistResponse, error) {
+	operator, p, err := s.findEditable(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	removed, err := s.playlistRepo.RemoveItem(ctx, p.ID, itemID)
+	if err != nil {
+		return nil, err
+	}
+	if !removed {
+		return nil, ErrDialectPlaylistItemNotFound
+	}
+	return s.detail(ctx, p.ID, operator)
+}
+
+// Reorder 调整片段顺序，item_ids 与当前片段不一致时返回 entity.ErrDialectPlaylistStale
+func (s *DialectPlaylistAppService) Reorder(ctx context.Context, id string, req *dto.ReorderDialectPlaylistRequest, userID string) (*dto.DialectPlaylistResponse, error) {
+	operator, p, err := s.findEditable(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	positions, err := entity.ReorderDialectPlaylistItems(p.Items, req.ItemIDs)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.playlistRepo.Reorder(ctx, p.ID, positions); err != nil {
+		return nil, err
+	}
+	return s.detail(ctx, p.ID, operator)
+}
+
+// Refresh 立即重新生成自动合集
+func (s *DialectPlaylistAppService) Refresh(ctx context.Context, id, userID string) (*dto.DialectPlaylistResponse, error) {
+	operator, p, err := s.findManaged(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !p.IsAuto() {
+		return nil, fmt.Errorf("%w: 只有自动合集可以重新生成", ErrDialectPlaylistInvalid)
+	}
+	if err := s.generate(ctx, p); err != nil {
+		return nil, err
+	}
+	return s.detail(ctx, p.ID, operator)
+}
+
+// AddCollaborator 邀请同组织成员协作编排，并通知对方
+func (s *DialectPlaylistAppService) AddCollaborator(ctx context.Context, id string, req *dto.AddDialectPlaylistCollaboratorRequest, userID string) (*dto.DialectPlaylistResponse, error) {
+	operator, p, err := s.findManaged(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if p.IsAuto() {
+		return nil, entity.ErrDialectPlaylistAuto
+	}
+
+	user, err := s.userRepo.FindByID(ctx, req.UserID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	if user.ID == p.OwnerID || user.OrgID != p.OrgID {
+		return nil, fmt.Errorf("%w: 只能邀请同组织的其他成员", ErrDialectPlaylistCollaborator)
+	}
+
+	added, err := s.playlistRepo.AddCollaborator(ctx, entity.NewDialectPlaylistCollaborator(p.ID, user.ID, operator.ID))
+	if err != nil {
+		return nil, err
+	}
+	if added {
+		s.notifyCollaborator(ctx, p, operator, user.ID)
+	}
+	return s.detail(ctx, p.ID, operator)
+}
+
+// RemoveCollaborator 移除协作者；协作者也可以自行退出
+func (s *DialectPlaylistAppService) RemoveCollaborator(ctx context.Context, id, collaboratorID, userID string) (*dto.DialectPlaylistResponse, error) {
+	operator, err := s.operator(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	p, err := s.findVisible(ctx, id, operator)
+	if err != nil {
+		return nil, err
+	}
+	if collaboratorID != operator.ID && !p.CanManage(operator.ID, operator.OrgID, isManager(operator)) {
+		return nil, ErrDialectPlaylistForbidden
+	}
+
+	removed, err := s.playlistRepo.RemoveCollaborator(ctx, p.ID, collaboratorID)
+	if err != nil {
+		return nil, err
+	}
+	if !removed {
+		return nil, fmt.Errorf("%w: 该用户不是协作者", ErrDialectPlaylistCollaborator)
+	}
+	if collaboratorID == operator.ID {
+		// 自行退出后可能已无权查看
+		return nil, nil
+	}
+	return s.detail(ctx, p.ID, operator)
+}
+
+// playlistBundleMeta 离线包清单中的歌单信息
+type playlistBundleMeta struct {
+	Format       string             `json:"format"`
+	PlaylistID   string             `json:"playlist_id"`
+	Title        string             `json:"title"`
+	ExportedAt   time.Time          `json:"exported_at"`
+	ExportedBy   caseExportOperator `json:"exported_by"`
+	ItemCount    int                `json:"item_count"`
+	SkippedItems []string           `json:"skipped_items,omitempty"`
+}
+
+// playlistBundleItem 离线包中的片段，File 为包内音频路径
+type playlistBundleItem struct {
+	Position    int    `json:"position"`
+	DialectID   string `json:"dialect_id"`
+	Title       string `json:"title"`
+	Content     string `json:"content,omitempty"`
+	Region      string `json:"region"`
+	Province    string `json:"province,omitempty"`
+	City        string `json:"city,omitempty"`
+	DialectType string `json:"dialect_type"`
+	Duration    int    `json:"duration"`
+	Note        string `json:"note,omitempty"`
+	File        string `json:"file"`
+}
+
+// Export 生成离线包：playlist.json 按顺序列出片段，audio/ 下为音频（优先使用转码后的 MP3），
+// manifest.json 记录每个文件的摘要并签名。不可播放、没有音频文件或超出大小上限的片段跳过
+func (s *DialectPlaylistAppService) Export(ctx context.Context, id, userID, clientIP string) (*dto.DialectPlaylistBundle, error) {
+	operator, err := s.operator(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	p, err := s.findVisible(ctx, id, operator)
+	if err != nil {
+		return nil, err
+	}
+	if s.refreshStale(ctx, p) {
+		if p, err = s.find(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+
+	meta := playlistBundleMeta{
+		Format:     dialectPlaylistBundleFormat,
+		PlaylistID: p.ID,
+		Title:      p.Title,
+		ExportedAt: time.Now(),
+		ExportedBy: caseExportOperator{
+			ID:    operator.ID,
+			Name:  operatorName(operator),
+			Role:  operator.Role,
+			OrgID: operator.OrgID,
+		},
+	}
+
+	var buf bytes.Buffer
+	w := bundle.NewWriter(&buf, s.signer)
+	items := make([]playlistBundleItem, 0, len(p.Items))
+	var total int64
+	for _, item := range p.Items {
+		d := item.Dialect
+		if d == nil || !d.CanPlay() || d.AudioFileID == nil {
+			meta.SkippedItems = append(meta.SkippedItems, item.DialectID)
+			continue
+		}
+
+		content, ext, err := s.readAudio(ctx, *d.AudioFileID, s.maxAudioBytes-total)
+		if err != nil {
+			logger.Warn("Failed to add dialect audio to bundle", logger.String("dialect_id", d.ID), logger.Err(err))
+			meta.SkippedItems = append(meta.SkippedItems, item.DialectID)
+			continue
+		}
+		name := fmt.Sprintf("audio/%03d_%s%s", len(items)+1, d.ID, ext)
+		if err := w.Add(name, content); err != nil {
+			return nil, err
+		}
+		total += int64(len(content))
+
+		items = append(items, playlistBundleItem{
+			Position:    len(items) + 1,
+			DialectID:   d.ID,
+			Title:       d.Title,
+			Content:     d.Content,
+			Region:      d.Region,
+			Province:    d.Province,
+			City:        d.City,
+			DialectType: string(d.DialectType),
+			Duration:    d.Duration,
+			Note:        item.Note,
+			File:        name,
+		})
+	}
+	if len(items) == 0 {
+		return nil, ErrDialectPlaylistEmpty
+	}
+	meta.ItemCount = len(items)
+
+	if err := w.AddJSON("playlist.json", map[string]any{
+		"id":          p.ID,
+		"title":       p.Title,
+		"description": p.Description,
+		"kind":        p.Kind,
+		"province":    p.Province,
+		"items":       items,
+	}); err != nil {
+		return nil, err
+	}
+	manifest, err := w.Close(meta)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(buf.Bytes())
+	file := &dto.DialectPlaylistBundle{
+		Data:     buf.Bytes(),
+		FileName: fmt.Sprintf("playlist_%s_%s.zip", p.ID[:8], meta.ExportedAt.Format("20060102150405")),
+		SHA256:   hex.EncodeToString(sum[:]),
+	}
+
+	if s.auditService != nil {
+		auditLog := entity.NewAuditLog(operator.ID, operator.OrgID, entity.AuditActionExport, string(entity.ResourceDialect)).
+			SetResourceID(p.ID).
+			SetResourceName(p.Title).
+			SetDescription(fmt.Sprintf("导出方言歌单离线包（%d 条）", len(items))).
+			AddExtra("file_name", file.FileName).
+			AddExtra("sha256", file.SHA256).
+			AddExtra("key_id", manifest.KeyID).
+			AddExtra("skipped", len(meta.SkippedItems))
+		auditLog.IPAddress = clientIP
+		s.auditService.Log(ctx, auditLog)
+	}
+
+	logger.Info("Dialect playlist exported",
+		logger.String("playlist_id", p.ID),
+		logger.String("user_id", operator.ID),
+		logger.Int("items", len(items)),
+	)
+	return file, nil
+}
+
+// readAudio 读取方言音频，优先使用转码后的版本；超过 limit 字节时返回错误
+func (s *DialectPlaylistAppService) readAudio(ctx context.Context, fileID string, limit int64) ([]byte, string, error) {
+	if limit <= 0 {
+		return nil, "", ErrFileTooLarge
+	}
+
+	ext := ".mp3"
+	reader, err := s.fileService.GetVariant(ctx, fileID, entity.FileVariantStream)
+	if err != nil {
+		var file *entity.File
+		if reader, file, err = s.fileService.GetFile(ctx, fileID); err != nil {
+			return nil, "", err
+		}
+		if e := file.GetExtension(); e != "" {
+			ext = e
+		}
+	}
+	defer reader.Close()
+
+	content, err := io.ReadAll(io.LimitReader(reader, limit+1))
+	if err != nil {
+		return nil, "", err
+	}
+	if int64(len(content)) > limit {
+		return nil, "", ErrFileTooLarge
+	}
+	return content, ext, nil
+}
+
+// generate 按得分重新生成自动合集的片段
+func (s *DialectPlaylistAppService) generate(ctx context.Context, p *entity.DialectPlaylist) error {
+	candidates, err := s.playlistRepo.FindCollectionCandidates(ctx, p.Province, dialectCollectionCandidates)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	ranked := entity.RankDialectClips(candidates, p.ItemLimit)
+	items := make([]entity.DialectPlaylistItem, len(ranked))
+	for i := range ranked {
+		item := entity.NewDialectPlaylistItem(p.ID, ranked[i].DialectID, p.OwnerID, "", i+1)
+		item.Score = ranked[i].Score()
+		items[i] = *item
+	}
+	if err := s.playlistRepo.ReplaceItems(ctx, p.ID, items, now); err != nil {
+		logger.Error("Failed to generate dialect collection", logger.String("playlist_id", p.ID), logger.Err(err))
+		return err
+	}
+	p.RefreshedAt = &now
+	return nil
+}
+
+// refreshStale 自动合集过期时重新生成，生成失败时继续使用旧内容
+func (s *DialectPlaylistAppService) refreshStale(ctx context.Context, p *entity.DialectPlaylist) bool {
+	if !p.IsAuto() || (p.RefreshedAt != nil && time.Since(*p.RefreshedAt) < dialectCollectionTTL) {
+		return false
+	}
+	return s.generate(ctx, p) == nil
+}
+
+// notifyCollaborator 通知被邀请的协作者
+func (s *DialectPlaylistAppService) notifyCollaborator(ctx context.Context, p *entity.DialectPlaylist, operator *entity.User, userID string) {
+	if s.notificationService == nil {
+		return
+	}
+	req := &dto.SendNotificationRequest{
+		Title:        "邀请你协作编排方言歌单",
+		Content:      fmt.Sprintf("%s 邀请你一起编排方言歌单「%s」", operatorName(operator), p.Title),
+		Type:         entity.NotificationTypeMessage,
+		Channel:      entity.NotificationChannelWebSocket,
+		Priority:     entity.PriorityNormal,
+		ToUserID:     userID,
+		FromUserID:   &operator.ID,
+		OrgID:        p.OrgID,
+		BusinessType: dialectPlaylistEntityType,
+		BusinessID:   &p.ID,
+		Data: map[string]interface{}{
+			"playlist_id": p.ID,
+		},
+	}
+	if err := s.notificationService.SendNotification(ctx, req); err != nil {
+		logger.Error("Failed to notify playlist collaborator", logger.String("playlist_id", p.ID), logger.Err(err))
+	}
+}
+
+// audit 记录歌单审计日志
+func (s *DialectPlaylistAppService) audit(ctx context.Context, operator *entity.User, action entity.AuditAction, p *entity.DialectPlaylist, desc string) {
+	if s.auditService == nil {
+		return
+	}
+	auditLog := entity.NewAuditLog(operator.ID, operator.OrgID, action, string(entity.ResourceDialect)).
+		SetResourceID(p.ID).
+		SetResourceName(p.Title).
+		SetDescription(desc).
+		AddExtra("kind", string(p.Kind)).
+		AddExtra("province", p.Province)
+	s.auditService.Log(ctx, auditLog)
+}
+
+// operator 获取当前用户
+func (s *DialectPlaylistAppService) operator(ctx context.Context, userID string) (*entity.User, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	return user, nil
+}
+
+// find 获取歌单详情
+func (s *DialectPlaylistAppService) find(ctx context.Context, id string) (*entity.DialectPlaylist, error) {
+	p, err := s.playlistRepo.FindByIDWithItems(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if p == nil {
+		return nil, ErrDialectPlaylistNotFound
+	}
+	return p, nil
+}
+
+// findVisible 获取当前用户可见的歌单，不可见时按不存在处理
+func (s *DialectPlaylistAppService) findVisible(ctx context.Context, id string, operator *entity.User) (*entity.DialectPlaylist, error) {
+	p, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !p.CanView(operator.ID, operator.OrgID) {
+		return nil, ErrDialectPlaylistNotFound
+	}
+	return p, nil
+}
+
+// findEditable 获取当前用户可编排片段的歌单
+func (s *DialectPlaylistAppService) findEditable(ctx context.Context, id, userID string) (*entity.User, *entity.DialectPlaylist, error) {
+	operator, err := s.operator(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	p, err := s.findVisible(ctx, id, operator)
+	if err != nil {
+		return nil, nil, err
+	}
+	if p.IsAuto() {
+		return nil, nil, entity.ErrDialectPlaylistAuto
+	}
+	if !p.CanEditItems(operator.ID) {
+		return nil, nil, ErrDialectPlaylistForbidden
+	}
+	return operator, p, nil
+}
+
+// findManaged 获取当前用户可管理的歌单
+func (s *DialectPlaylistAppService) findManaged(ctx context.Context, id, userID string) (*entity.User, *entity.DialectPlaylist, error) {
+	operator, err := s.operator(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	p, err := s.findVisible(ctx, id, operator)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !p.CanManage(operator.ID, operator.OrgID, isManager(operator)) {
+		return nil, nil, ErrDialectPlaylistForbidden
+	}
+	return operator, p, nil
+}
+
+// detail 重新读取并转换为歌单详情
+func (s *DialectPlaylistAppService) detail(ctx context.Context, id string, operator *entity.User) (*dto.DialectPlaylistResponse, error) {
+	p, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.toDetail(p, operator), nil
+}
+
+// toDetail 转换为含片段和协作者的歌单响应
+func (s *DialectPlaylistAppService) toDetail(p *entity.DialectPlaylist, operator *entity.User) *dto.DialectPlaylistResponse {
+	resp := dto.ToDialectPlaylistResponse(p)
+	resp.CanEdit = p.CanEditItems(operator.ID)
+	resp.CanManage = p.CanManage(operator.ID, operator.OrgID, isManager(operator))
+	resp.Items = make([]dto.DialectPlaylistItemResponse, len(p.Items))
+	for i := range p.Items {
+		resp.Items[i] = dto.ToDialectPlaylistItemResponse(&p.Items[i])
+	}
+	resp.Collaborators = make([]dto.DialectPlaylistCollaboratorResponse, len(p.Collaborators))
+	for i := range p.Collaborators {
+		resp.Collaborators[i] = dto.ToDialectPlaylistCollaboratorResponse(&p.Collaborators[i])
+	}
+	return &resp
+}
+
+// isManager 是否为主管及以上角色
+func isManager(user *entity.User) bool {
+	return user.HasPermission(string(entity.RoleManager))
+}
@@ -0,0 +1,492 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/dto"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/repository"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/logger"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrDialectReviewNotFound   = errors.New("dialect review not found")
+	ErrDialectReviewQueueEmpty = errors.New("no dialect review to claim")
+)
+
+const (
+	// DialectReviewWorkflowCategory 方言审核流程分类：组织发布了该分类的流程时按流程审核，
+	// 流程中每个审批节点需要一名不同的审核员通过
+	DialectReviewWorkflowCategory = "dialect_review"
+	// dialectReviewBusinessKey 方言审核流程实例的业务标识
+	dialectReviewBusinessKey = "dialect_review"
+	// dialectReviewClaimBatch 认领下一条时每次读取的候选数
+	dialectReviewClaimBatch = 20
+)
+
+// DialectReviewAppService 方言审核应用服务：上传的方言进入所属组织的审核队列，
+// 审核员认领后通过或驳回，结果通知上传者
+type DialectReviewAppService struct {
+	reviewRepo          repository.DialectReviewRepository
+	workflowRepo        repository.WorkflowRepository
+	workflowService     *WorkflowAppService
+	notificationService *NotificationAppService
+	auditService        *AuditService
+}
+
+// NewDialectReviewAppService 创建方言审核应用服务
+func NewDialectReviewAppService(
+	reviewRepo repository.DialectReviewRepository,
+	workflowRepo repository.WorkflowRepository,
+	workflowService *WorkflowAppService,
+	notificationService *NotificationAppService,
+	auditService *AuditService,
+) *DialectReviewAppService {
+	return &DialectReviewAppService{
+		reviewRepo:          reviewRepo,
+		workflowRepo:        workflowRepo,
+		workflowService:     workflowService,
+		notificationService: notificationService,
+		auditService:        auditService,
+	}
+}
+
+// Submit 将待审核的方言加入审核队列，已在队列中时直接返回。
+// 组织发布了方言审核流程时同时启动流程实例，所需通过人数为流程的审批节点数；
+// duplicate 不为空时标记为疑似重复上传，供审核员对照
+func (s *DialectReviewAppService) Submit(ctx context.Context, d *entity.Dialect, duplicate *entity.DialectMatch) (*entity.DialectReview, error) {
+	existing, err := s.reviewRepo.FindOpenByDialect(ctx, d.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return existing, nil
+	}
+
+	def := s.findWorkflow(ctx, d.OrgID)
+	review := entity.NewDialectReview(d, countApprovalNodes(def))
+	review.ID = uuid.New().String()
+	if duplicate != nil {
+		review.FlagDuplicate(duplicate.Dialect.ID, duplicate.Similarity)
+	}
+	if def != nil {
+		instance, err := s.workflowService.StartInstance(ctx, &dto.StartWorkflowInstanceRequest{
+			DefinitionID: def.ID,
+			BusinessKey:  dialectReviewBusinessKey,
+			BusinessID:   review.ID,
+			Title:        fmt.Sprintf("方言审核：%s", d.Title),
+			StartedBy:    d.UploaderID,
+			OrgID:        d.OrgID,
+		})
+		if err != nil {
+			// 流程启动失败不影响入队，仍按流程要求的人数审核
+			logger.Warn("Failed to start dialect review workflow", logger.String("dialect_id", d.ID), logger.Err(err))
+		} else {
+			review.WorkflowInstanceID = &instance.ID
+		}
+	}
+
+	if err := s.reviewRepo.Create(ctx, review); err != nil {
+		logger.Error("Failed to create dialect review", logger.Err(err))
+		return nil, err
+	}
+
+	logger.Info("Dialect submitted for review",
+		logger.String("dialect_id", d.ID),
+		logger.String("review_id", review.ID),
+		logger.Int("required_approvals", review.RequiredApprovals),
+		logger.Bool("duplicate", review.IsDuplicate()),
+	)
+	return review, nil
+}
+
+// List 查询组织的审核队列
+func (s *DialectReviewAppService) List(ctx context.Context, req *dto.DialectReviewListRequest, reviewerID, orgID string) (*dto.DialectReviewListResponse, error) {
+	query := &repository.DialectReviewQuery{
+		Pagination: repository.Pagination{Page: req.Page, PageSize: req.PageSize},
+		OrgID:      orgID,
+		Status:     entity.DialectReviewStatus(req.Status),
+		Duplicate:  req.Duplicate,
+	}
+	if req.Mine {
+		query.ReviewerID = reviewerID
+		query.Status = entity.DialectReviewClaimed
+	}
+
+	result, err := s.reviewRepo.List(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]dto.DialectReviewResponse, len(result.List))
+	for i := range result.List {
+		list[i] = dto.ToDialectReviewResponse(&result.List[i])
+	}
+	return &dto.DialectReviewListResponse{
+		List:       list,
+		Total:      result.Total,
+		Page:       result.Page,
+		PageSize:   result.PageSize,
+		TotalPages: result.TotalPages,
+	}, nil
+}
+
+// GetByID 获取审核详情（含审核历史）
+func (s *DialectReviewAppService) GetByID(ctx context.Context, id, orgID string) (*dto.DialectReviewResponse, error) {
+	review, err := s.find(ctx, id, orgID)
+	if err != nil {
+		return nil, err
+	}
+	resp := dto.ToDialectReviewResponse(review)
+	return &resp, nil
+}
+
+// Claim 认领审核，认领超过 entity.DialectReviewClaimTTL 未处理时其他审核员可重新认领
+func (s *DialectReviewAppService) Claim(ctx context.Context, id, reviewerID, orgID string) (*dto.DialectReviewResponse, error) {
+	review, err := s.find(ctx, id, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.claim(ctx, review, reviewerID); err != nil {
+		return nil, err
+	}
+	resp := dto.ToDialectReviewResponse(review)
+	return &resp, nil
+}
+
+// ClaimNext 认领队列中最早提交的、该审核员可审核的一条
+func (s *DialectReviewAppService) ClaimNext(ctx context.Context, reviewerID, orgID string) (*dto.DialectReviewResponse, error) {
+	now := time.Now()
+	candidates, err := s.reviewRepo.FindClaimable(ctx, orgID, reviewerID, now.Add(-entity.DialectReviewClaimTTL), dialectReviewClaimBatch)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range candidates {
+		review, err := s.find(ctx, c.ID, orgID)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.claim(ctx, review, reviewerID); err != nil {
+			// 已被其他审核员抢先认领，继续尝试下一条
+			if errors.Is(err, entity.ErrDialectReviewClaimed) || errors.Is(err, entity.ErrDialectReviewClosed) {
+				continue
+			}
+			return nil, err
+		}
+		resp := dto.ToDialectReviewResponse(review)
+		return &resp, nil
+	}
+	return nil, ErrDialectReviewQueueEmpty
+}
+
+// claim 认领并保存，以条件更新保证同一时间只有一名审核员持有认领
+func (s *DialectReviewAppService) claim(ctx context.Context, review *entity.DialectReview, reviewerID string) error {
+	now := time.Now()
+	if err := review.Claim(reviewerID, now); err != nil {
+		return err
+	}
+	ok, err := s.reviewRepo.Claim(ctx, review, now.Add(-entity.DialectReviewClaimTTL))
+	if err != nil {
+		logger.Error("Failed to claim dialect review", logger.Err(err))
+		return err
+	}
+	if !ok {
+		return entity.ErrDialectReviewClaimed
+	}
+
+	logger.Info("Dialect review claimed", logger.String("review_id", review.ID), logger.String("reviewer_id", reviewerID))
+	return nil
+}
+
+// Release 放弃认领，审核回到队列。force 为 true 时可释放其他审核员的认领
+func (s *DialectReviewAppService) Release(ctx context.Context, id, reviewerID, orgID string, force bool) (*dto.DialectReviewResponse, error) {
+	review, err := s.find(ctx, id, orgID)
+	if err != nil {
+		return nil, err
+	}
+	var claimedBy string
+	if review.ReviewerID != nil {
+		claimedBy = *review.ReviewerID
+	}
+	if err := review.Release(reviewerID, force, time.Now()); err != nil {
+		return nil, err
+	}
+	ok, err := s.reviewRepo.Release(ctx, review, claimedBy)
+	if err != nil {
+		logger.Error("Failed to release dialect review", logger.Err(err))
+		return nil, err
+	}
+	if !ok {
+		return nil, entity.ErrDialectReviewNotClaimed
+	}
+	review.Reviewer = nil
+
+	if claimedBy != reviewerID {
+		s.audit(ctx, reviewerID, orgID, entity.AuditActionUpdate, review, "释放其他审核员的方言审核认领")
+	}
+	logger.Info("Dialect review released", logger.String("review_id", review.ID), logger.String("reviewer_id", claimedBy))
+	resp := dto.ToDialectReviewResponse(review)
+	return &resp, nil
+}
+
+// Approve 审核通过。通过人数达到要求时方言上线并通知上传者，否则回到队列等待下一位审核员
+func (s *DialectReviewAppService) Approve(ctx context.Context, id string, req *dto.ApproveDialectReviewRequest, reviewerID, orgID string) (*dto.DialectReviewResponse, error) {
+	review, err := s.find(ctx, id, orgID)
+	if err != nil {
+		return nil, err
+	}
+	decision, err := review.Approve(reviewerID, req.Comment, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.saveDecision(ctx, review, decision); err != nil {
+		return nil, err
+	}
+
+	desc := fmt.Sprintf("方言审核通过（%d/%d）", review.Approvals, review.RequiredApprovals)
+	s.audit(ctx, reviewerID, orgID, entity.AuditActionApprove, review, desc)
+	resp := dto.ToDialectReviewResponse(review)
+	return &resp, nil
+}
+
+// Reject 审核驳回，方言下线并通知上传者驳回原因
+func (s *DialectReviewAppService) Reject(ctx context.Context, id string, req *dto.RejectDialectReviewRequest, reviewerID, orgID string) (*dto.DialectReviewResponse, error) {
+	review, err := s.find(ctx, id, orgID)
+	if err != nil {
+		return nil, err
+	}
+	reason := entity.DialectReviewReason(req.ReasonCode)
+	decision, err := review.Reject(reviewerID, reason, req.Comment, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.saveDecision(ctx, review, decision); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, reviewerID, orgID, entity.AuditActionReject, review, "方言审核驳回："+reason.Label())
+	resp := dto.ToDialectReviewResponse(review)
+	return &resp, nil
+}
+
+// saveDecision 保存审核员结论，同步流程任务，审核结束时通知上传者
+func (s *DialectReviewAppService) saveDecision(ctx context.Context, review *entity.DialectReview, decision *entity.DialectReviewDecision) error {
+	ok, err := s.reviewRepo.SaveDecision(ctx, review, decision)
+	if err != nil {
+		logger.Error("Failed to save dialect review decision", logger.Err(err))
+		return err
+	}
+	if !ok {
+		// 认领已超时并被其他审核员认领
+		return entity.ErrDialectReviewNotClaimed
+	}
+	if review.Dialect != nil && review.IsClosed() {
+		review.Dialect.Status = review.DialectStatus()
+	}
+	if !review.IsClosed() {
+		review.Reviewer = nil
+	}
+
+	s.syncWorkflow(ctx, review, decision)
+	if review.IsClosed() {
+		s.notifyUploader(ctx, review, decision.ReviewerID)
+	}
+
+	logger.Info("Dialect review decided",
+		logger.String("review_id", review.ID),
+		logger.String("reviewer_id", decision.ReviewerID),
+		logger.String("action", string(decision.Action)),
+		logger.String("status", string(review.Status)),
+	)
+	return nil
+}
+
+// syncWorkflow 将审核员结论同步到流程实例：认领当前节点的任务并通过或驳回。
+// 审核结果以审核队列为准，同步失败只记录日志
+func (s *DialectReviewAppService) syncWorkflow(ctx context.Context, review *entity.DialectReview, decision *entity.DialectReviewDecision) {
+	if review.WorkflowInstanceID == nil || s.workflowService == nil {
+		return
+	}
+	taskID, err := s.workflowService.ClaimInstanceTask(ctx, *review.WorkflowInstanceID, decision.ReviewerID)
+	if err == nil {
+		if decision.Action == entity.DialectReviewActionApprove {
+			err = s.workflowService.ApproveTask(ctx, taskID, &dto.ApproveTaskRequest{Comment: decision.Comment}, decision.ReviewerID)
+		} else {
+			comment := decision.ReasonCode.Label()
+			if decision.Comment != "" {
+				comment += "：" + decision.Comment
+			}
+			err = s.workflowService.RejectTask(ctx, taskID, &dto.RejectTaskRequest{Comment: comment}, decision.ReviewerID)
+		}
+	}
+	if err != nil {
+		logger.Warn("Failed to sync dialect review workflow",
+			logger.String("review_id", review.ID),
+			logger.String("instance_id", *review.WorkflowInstanceID),
+			logger.Err(err),
+		)
+	}
+}
+
+// notifyUploader 通知上传者审核结果
+func (s *DialectReviewAppService) notifyUploader(ctx context.Context, review *entity.DialectReview, reviewerID string) {
+	if s.notificationService == nil {
+		return
+	}
+	title := "方言"
+	if review.Dialect != nil {
+		title = fmt.Sprintf("方言「%s」", review.Dialect.Title)
+	}
+
+	req := &dto.SendNotificationRequest{
+		Type:         entity.NotificationTypeSystem,
+		Channel:      entity.NotificationChannelWebSocket,
+		Priority:     entity.PriorityNormal,
+		ToUserID:     review.UploaderID,
+		FromUserID:   &reviewerID,
+		OrgID:        review.OrgID,
+		BusinessType: dialectEntityType,
+		BusinessID:   &review.DialectID,
+		Data: map[string]interface{}{
+			"dialect_id": review.DialectID,
+			"review_id":  review.ID,
+			"status":     string(review.Status),
+		},
+	}
+	if review.Status == entity.DialectReviewApproved {
+		req.Title = "方言审核通过"
+		req.Content = fmt.Sprintf("你上传的%s已通过审核，现已公开。", title)
+	} else {
+		req.Title = "方言审核未通过"
+		req.Content = fmt.Sprintf("你上传的%s未通过审核，原因：%s。", title, review.ReasonCode.Label())
+		if review.Comment != "" {
+			req.Content += "审核意见：" + review.Comment
+		}
+		req.Data["reason_code"] = string(review.ReasonCode)
+	}
+
+	if err := s.notificationService.SendNotification(ctx, req); err != nil {
+		logger.Error("Failed to notify dialect uploader", logger.String("review_id", review.ID), logger.Err(err))
+	}
+}
+
+// Reasons 驳回原因列表
+func (s *DialectReviewAppService) Reasons() []dto.DialectReviewReasonResponse {
+	reasons := make([]dto.DialectReviewReasonResponse, len(entity.DialectReviewReasons))
+	for i, r := range entity.DialectReviewReasons {
+		reasons[i] = dto.DialectReviewReasonResponse{Code: string(r), Label: r.Label()}
+	}
+	return reasons
+}
+
+// Stats 组织审核队列及各审核员统计
+func (s *DialectReviewAppService) Stats(ctx context.Context, req *dto.DialectReviewStatsRequest, orgID string) (*dto.DialectReviewStatsResponse, error) {
+	counts, err := s.reviewRepo.CountByStatus(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	var since *time.Time
+	if req.Days > 0 {
+		t := time.Now().AddDate(0, 0, -req.Days)
+		since = &t
+	}
+	stats, err := s.reviewRepo.ReviewerStats(ctx, orgID, since)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.DialectReviewStatsResponse{
+		Pending:   counts[entity.DialectReviewPending],
+		Claimed:   counts[entity.DialectReviewClaimed],
+		Approved:  counts[entity.DialectReviewApproved],
+		Rejected:  counts[entity.DialectReviewRejected],
+		Reviewers: make([]dto.DialectReviewerStatsResponse, len(stats)),
+	}
+	for i := range stats {
+		st := &stats[i]
+		resp.Reviewers[i] = dto.DialectReviewerStatsResponse{
+			ReviewerID:    st.ReviewerID,
+			ReviewerName:  st.Nickname,
+			Total:         st.Total(),
+			Approved:      st.Approved,
+			Rejected:      st.Rejected,
+			AvgSeconds:    int(st.AvgSeconds + 0.5),
+			LastDecidedAt: st.LastDecidedAt,
+		}
+	}
+	return resp, nil
+}
+
+// find 获取组织内的审核
+func (s *DialectReviewAppService) find(ctx context.Context, id, orgID string) (*entity.DialectReview, error) {
+	review, err := s.reviewRepo.FindByIDWithDecisions(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if review == nil || review.OrgID != orgID {
+		return nil, ErrDialectReviewNotFound
+	}
+	return review, nil
+}
+
+// findWorkflow 查找组织已发布的方言审核流程，没有时返回 nil
+func (s *DialectReviewAppService) findWorkflow(ctx context.Context, orgID string) *entity.WorkflowDefinition {
+	if s.workflowRepo == nil || s.workflowService == nil {
+		return nil
+	}
+	defs, _, err := s.workflowRepo.ListDefinitions(ctx, orgID, DialectReviewWorkflowCategory, 1, 20)
+	if err != nil {
+		logger.Warn("Failed to find dialect review workflow", logger.String("org_id", orgID), logger.Err(err))
+		return nil
+	}
+	for _, def := range defs {
+		if !def.IsActive() {
+			continue
+		}
+		// 列表不含节点，重新读取
+		full, err := s.workflowRepo.FindDefinitionByID(ctx, def.ID)
+		if err != nil || full == nil {
+			logger.Warn("Failed to load dialect review workflow", logger.String("definition_id", def.ID), logger.Err(err))
+			return nil
+		}
+		return full
+	}
+	return nil
+}
+
+// countApprovalNodes 流程的审批节点数，即需要的审核员人数
+func countApprovalNodes(def *entity.WorkflowDefinition) int {
+	if def == nil {
+		return 1
+	}
+	n := 0
+	for _, node := range def.Nodes {
+		if node.Type == entity.NodeTypeApproval {
+			n++
+		}
+	}
+	return n
+}
+
+// audit 记录审核操作日志
+func (s *DialectReviewAppService) audit(ctx context.Context, operatorID, orgID string, action entity.AuditAction, review *entity.DialectReview, desc string) {
+	if s.auditService == nil {
+		return
+	}
+	auditLog := entity.NewAuditLog(operatorID, orgID, action, string(entity.ResourceDialect)).
+		SetResourceID(review.DialectID).
+		SetDescription(desc).
+		AddExtra("review_id", review.ID).
+		AddExtra("status", string(review.Status))
+	if review.Dialect != nil {
+		auditLog.SetResourceName(review.Dialect.Title)
+	}
+	if review.ReasonCode != "" {
+		auditLog.AddExtra("reason_code", string(review.ReasonCode))
+	}
+	s.auditService.Log(ctx, auditLog)
+}
@@ -3,10 +3,15 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"math"
+	"mime/multipart"
+	"sort"
 
 	"github.com/Snowitty-Re/CNtunyuan/internal/application/dto"
 	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
 	"github.com/Snowitty-Re/CNtunyuan/internal/domain/repository"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/audio"
 	"github.com/Snowitty-Re/CNtunyuan/pkg/logger"
 )
 
@@ -14,20 +19,63 @@ var (
 	ErrDialectNotFound = errors.New("dialect not found")
 	ErrAlreadyLiked    = errors.New("already liked")
 	ErrNotLiked        = errors.New("not liked")
+	ErrDialectInvalid  = errors.New("invalid dialect")
 )
 
+// dialectEntityType 方言音频文件绑定的实体类型
+const dialectEntityType = "dialect"
+
 // DialectAppService 方言应用服务
 type DialectAppService struct {
-	dialectRepo repository.DialectRepository
+	dialectRepo         repository.DialectRepository
+	groupRepo           repository.DialectGroupRepository
+	fileService         *FileAppService
+	reviewService       *DialectReviewAppService
+	duplicateSimilarity float64
 }
 
-// NewDialectAppService 创建方言应用服务
-func NewDialectAppService(dialectRepo repository.DialectRepository) *DialectAppService {
-	return &DialectAppService{dialectRepo: dialectRepo}
+// NewDialectAppService 创建方言应用服务，duplicateSimilarity 为重复录音判定的声纹相似度
+func NewDialectAppService(dialectRepo repository.DialectRepository, groupRepo repository.DialectGroupRepository, fileService *FileAppService, reviewService *DialectReviewAppService, duplicateSimilarity float64) *DialectAppService {
+	if duplicateSimilarity <= 0 || duplicateSimilarity > 1 {
+		duplicateSimilarity = audio.DefaultDuplicateSimilarity
+	}
+	return &DialectAppService{
+		dialectRepo:         dialectRepo,
+		groupRepo:           groupRepo,
+		fileService:         fileService,
+		reviewService:       reviewService,
+		duplicateSimilarity: duplicateSimilarity,
+	}
 }
 
-// Create 创建方言
+// UploadAudio 上传方言音频：服务端探测真实格式和时长，归一化响度并转码，超过时长限制的音频不保留
+func (s *DialectAppService) UploadAudio(ctx context.Context, file multipart.File, header *multipart.FileHeader, uploaderID string) (*dto.FileResponse, error) {
+	f, err := s.fileService.UploadAudio(ctx, file, header, uploaderID)
+	if err != nil {
+		return nil, err
+	}
+	if f.DurationSeconds() > entity.MaxDialectDuration {
+		if err := s.fileService.Delete(ctx, f.ID); err != nil {
+			logger.Warn("Failed to delete oversized dialect audio", logger.String("file_id", f.ID), logger.Err(err))
+		}
+		return nil, fmt.Errorf("%w: 音频时长 %d 秒，超过 %d 秒限制", ErrDialectInvalid, f.DurationSeconds(), entity.MaxDialectDuration)
+	}
+
+	resp := dto.ToFileResponse(f)
+	return &resp, nil
+}
+
+// Create 创建方言，时长、大小和格式取自已上传音频的探测结果，创建后进入所属组织的审核队列，
+// 声纹与已有方言高度相似时在审核队列中标记为疑似重复
 func (s *DialectAppService) Create(ctx context.Context, req *dto.CreateDialectRequest, uploaderID string, orgID string) (*dto.DialectResponse, error) {
+	audioFile, err := s.fileService.FindAudio(ctx, req.AudioFileID)
+	if err != nil {
+		return nil, err
+	}
+	if audioFile.UploaderID != uploaderID || audioFile.EntityID != "" {
+		return nil, fmt.Errorf("%w: 音频文件不可用", ErrDialectInvalid)
+	}
+
 	d := &entity.Dialect{
 		Title:       req.Title,
 		Content:     req.Content,
@@ -35,25 +83,59 @@ func (s *DialectAppService) Create(ctx context.Context, req *dto.CreateDialectRe
 		Province:    req.Province,
 		City:        req.City,
 		DialectType: entity.DialectType(req.DialectType),
-		AudioUrl:    req.AudioUrl,
-		Duration:    req.Duration,
-		FileSize:    req.FileSize,
-		Format:      req.Format,
+		AudioUrl:    audioFile.URL,
+		Duration:    audioFile.DurationSeconds(),
+		FileSize:    int(audioFile.Size),
+		Format:      audioFile.AudioFormat,
+		AudioFileID: &audioFile.ID,
+		Fingerprint: audioFile.AudioFingerprint,
 		Tags:        req.Tags,
 		Description: req.Description,
 		UploaderID:  uploaderID,
 		OrgID:       orgID,
 		Status:      entity.DialectStatusPending,
 	}
+	variants := audioFile.GetVariants()
+	if stream, ok := variants[entity.FileVariantStream]; ok {
+		d.FileSize = int(stream.Size)
+		d.Format = string(audio.StreamFormat)
+	}
+	d.WaveformUrl = variants[entity.FileVariantWaveform].URL
+
+	var group *entity.DialectGroup
+	if req.GroupID != "" {
+		if group, err = s.findGroup(ctx, req.GroupID); err != nil {
+			return nil, err
+		}
+		d.AssignGroup(group)
+	}
 
 	if req.DialectType == "" {
 		d.DialectType = entity.DialectTypePhrase
 	}
+	if err := d.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDialectInvalid, err)
+	}
 
 	if err := s.dialectRepo.Create(ctx, d); err != nil {
 		logger.Error("Failed to create dialect", logger.Err(err))
 		return nil, err
 	}
+	d.Group = group
+	if err := s.fileService.BindToEntity(ctx, audioFile.ID, dialectEntityType, d.ID); err != nil {
+		logger.Warn("Failed to bind dialect audio", logger.String("file_id", audioFile.ID), logger.Err(err))
+	}
+	if s.reviewService != nil {
+		var duplicate *entity.DialectMatch
+		if matches, err := s.findSimilar(ctx, d, 1); err != nil {
+			logger.Warn("Failed to check duplicate dialect", logger.String("dialect_id", d.ID), logger.Err(err))
+		} else if len(matches) > 0 {
+			duplicate = &matches[0]
+		}
+		if _, err := s.reviewService.Submit(ctx, d, duplicate); err != nil {
+			logger.Error("Failed to submit dialect for review", logger.String("dialect_id", d.ID), logger.Err(err))
+		}
+	}
 
 	logger.Info("Dialect created", logger.String("dialect_id", d.ID))
 
@@ -67,12 +149,98 @@ func (s *DialectAppService) GetByID(ctx context.Context, id string) (*dto.Dialec
 	if err != nil {
 		return nil, ErrDialectNotFound
 	}
+	if d.GroupID != nil {
+		if d.Group, err = s.groupRepo.FindByIDWithRegions(ctx, *d.GroupID); err != nil {
+			return nil, err
+		}
+	}
 
 	resp := dto.ToDialectResponse(d)
 	return &resp, nil
 }
 
-// List 列表查询
+// FindSimilar 查找与指定方言声纹相似的其它方言，即疑似同一段录音，按相似度从高到低排列
+func (s *DialectAppService) FindSimilar(ctx context.Context, id string, req *dto.SimilarDialectRequest) ([]dto.SimilarDialectResponse, error) {
+	d, err := s.dialectRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, ErrDialectNotFound
+	}
+
+	matches, err := s.findSimilar(ctx, d, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]dto.SimilarDialectResponse, 0, len(matches))
+	for _, m := range matches {
+		list = append(list, dto.SimilarDialectResponse{
+			Dialect:    dto.ToDialectResponse(m.Dialect),
+			Similarity: m.Similarity,
+		})
+	}
+	return list, nil
+}
+
+// findSimilar 在时长相近的方言中比对声纹指纹，返回相似度不低于阈值的前 limit 条（含完整方言信息）。
+// 尚未计算指纹的方言（如未启用音频处理时上传的）不参与比对
+func (s *DialectAppService) findSimilar(ctx context.Context, d *entity.Dialect, limit int) ([]entity.DialectMatch, error) {
+	fp, err := audio.ParseFingerprint(d.Fingerprint)
+	if err != nil {
+		return nil, nil
+	}
+
+	// 同一段录音剪掉首尾后时长仍应相近
+	candidates, err := s.dialectRepo.FindFingerprints(ctx, d.ID, d.Duration/2, d.Duration*2+1)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []entity.DialectMatch
+	for i := range candidates {
+		other, err := audio.ParseFingerprint(candidates[i].Fingerprint)
+		if err != nil {
+			continue
+		}
+		if similarity := audio.Similarity(fp, other); similarity >= s.duplicateSimilarity {
+			matches = append(matches, entity.DialectMatch{
+				Dialect:    &candidates[i],
+				Similarity: math.Round(similarity*1000) / 1000,
+			})
+		}
+	}
+	if len(matches) == 0 {
+		return nil, nil
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Similarity > matches[j].Similarity
+	})
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	// 候选只加载了指纹，重新加载完整信息
+	ids := make([]string, len(matches))
+	for i, m := range matches {
+		ids[i] = m.Dialect.ID
+	}
+	dialects, err := s.dialectRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*entity.Dialect, len(dialects))
+	for i := range dialects {
+		byID[dialects[i].ID] = &dialects[i]
+	}
+	result := matches[:0]
+	for _, m := range matches {
+		if full, ok := byID[m.Dialect.ID]; ok {
+			m.Dialect = full
+			result = append(result, m)
+		}
+	}
+	return result, nil
+}
+
+// List 列表查询，按方言分类筛选时包含该分类的全部下级
 func (s *DialectAppService) List(ctx context.Context, req *dto.DialectListRequest) (*dto.DialectListResponse, error) {
 	query := repository.NewDialectQuery()
 	query.Page = req.Page
@@ -85,6 +253,13 @@ func (s *DialectAppService) List(ctx context.Context, req *dto.DialectListReques
 	query.Status = entity.DialectStatus(req.Status)
 	query.SortBy = req.SortBy
 	query.SortOrder = req.SortOrder
+	if req.GroupID != "" {
+		group, err := s.findGroup(ctx, req.GroupID)
+		if err != nil {
+			return nil, err
+		}
+		query.GroupPath = group.Path
+	}
 
 	result, err := s.dialectRepo.List(ctx, query)
 	if err != nil {
@@ -122,6 +297,13 @@ func (s *DialectAppService) Update(ctx context.Context, id string, req *dto.Upda
 	if req.City != "" {
 		d.City = req.City
 	}
+	var group *entity.DialectGroup
+	if req.GroupID != "" {
+		if group, err = s.findGroup(ctx, req.GroupID); err != nil {
+			return nil, err
+		}
+		d.AssignGroup(group)
+	}
 	if req.DialectType != "" {
 		d.DialectType = entity.DialectType(req.DialectType)
 	}
@@ -136,11 +318,24 @@ func (s *DialectAppService) Update(ctx context.Context, id string, req *dto.Upda
 		logger.Error("Failed to update dialect", logger.Err(err))
 		return nil, err
 	}
+	d.Group = group
 
 	resp := dto.ToDialectResponse(d)
 	return &resp, nil
 }
 
+// findGroup 获取方言分类（含对应地区）
+func (s *DialectAppService) findGroup(ctx context.Context, id string) (*entity.DialectGroup, error) {
+	group, err := s.groupRepo.FindByIDWithRegions(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, ErrDialectGroupNotFound
+	}
+	return group, nil
+}
+
 // Delete 删除
 func (s *DialectAppService) Delete(ctx context.Context, id string) error {
 	if err := s.dialectRepo.SoftDelete(ctx, id); err != nil {
@@ -152,17 +347,13 @@ func (s *DialectAppService) Delete(ctx context.Context, id string) error {
 
 // UpdateStatus 更新状态
 func (s *DialectAppService) UpdateStatus(ctx context.Context, id string, status string) error {
-	if _, err := s.dialectRepo.FindByID(ctx, id); err != nil {
+	d, err := s.dialectRepo.FindByID(ctx, id)
+	if err != nil {
 		return ErrDialectNotFound
 	}
 
-	if err := s.dialectRepo.Update(ctx, &entity.Dialect{
-		Status: entity.DialectStatus(status),
-	}); err != nil {
-		return err
-	}
-
-	return nil
+	d.Status = entity.DialectStatus(status)
+	return s.dialectRepo.Update(ctx, d)
 }
 
 // Feature 设为精选
@@ -187,9 +378,19 @@ func (s *DialectAppService) Unfeature(ctx context.Context, id string) error {
 	return s.dialectRepo.Update(ctx, d)
 }
 
-// IncrementPlayCount 增加播放次数
-func (s *DialectAppService) IncrementPlayCount(ctx context.Context, id string) error {
-	return s.dialectRepo.IncrementPlayCount(ctx, id)
+// RecordPlay 记录播放，同一收听者在去重窗口内的重复播放只更新播放时长，不增加播放次数
+func (s *DialectAppService) RecordPlay(ctx context.Context, id string, req *dto.DialectPlayRequest, userID, ip, userAgent string) (*dto.DialectPlayResponse, error) {
+	d, err := s.dialectRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, ErrDialectNotFound
+	}
+
+	log := entity.NewDialectPlayLog(d.ID, userID, ip, userAgent, req.Duration)
+	counted, err := s.dialectRepo.RecordPlay(ctx, log, log.CreatedAt.Add(-entity.DialectPlayDedupWindow))
+	if err != nil {
+		return nil, err
+	}
+	return &dto.DialectPlayResponse{Counted: counted}, nil
 }
 
 // Like 点赞
@@ -211,52 +412,6 @@ func (s *DialectAppService) HasLiked(ctx context.Context, dialectID string, user
 	return s.dialectRepo.HasLiked(ctx, dialectID, userID)
 }
 
-// AddComment 添加评论
-func (s *DialectAppService) AddComment(ctx context.Context, dialectID string, req *dto.CreateDialectCommentRequest, userID string) (*dto.DialectCommentResponse, error) {
-	comment := &entity.DialectComment{
-		DialectID: dialectID,
-		UserID:    userID,
-		Content:   req.Content,
-	}
-	if req.ParentID != "" {
-		comment.ParentID = &req.ParentID
-	}
-
-	if err := s.dialectRepo.AddComment(ctx, comment); err != nil {
-		return nil, err
-	}
-
-	resp := dto.ToDialectCommentResponse(comment)
-	return &resp, nil
-}
-
-// GetComments 获取评论
-func (s *DialectAppService) GetComments(ctx context.Context, dialectID string, page, pageSize int) (*dto.PageResult[dto.DialectCommentResponse], error) {
-	pagination := repository.Pagination{Page: page, PageSize: pageSize}
-	result, err := s.dialectRepo.GetComments(ctx, dialectID, pagination)
-	if err != nil {
-		return nil, err
-	}
-
-	list := make([]dto.DialectCommentResponse, len(result.List))
-	for i, c := range result.List {
-		list[i] = dto.ToDialectCommentResponse(&c)
-	}
-
-	totalPages := int(result.Total) / pageSize
-	if int(result.Total)%pageSize > 0 {
-		totalPages++
-	}
-
-	return &dto.PageResult[dto.DialectCommentResponse]{
-		List:       list,
-		Total:      result.Total,
-		Page:       result.Page,
-		PageSize:   result.PageSize,
-		TotalPages: totalPages,
-	}, nil
-}
-
 // GetFeatured 获取精选方言
 func (s *DialectAppService) GetFeatured(ctx context.Context, page, pageSize int) (*dto.DialectListResponse, error) {
 	pagination := repository.Pagination{Page: page, PageSize: pageSize}
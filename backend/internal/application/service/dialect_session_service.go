@@ -0,0 +1,426 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/dto"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/repository"
+	domainService "github.com/Snowitty-Re/CNtunyuan/internal/domain/service"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/logger"
+)
+
+var (
+	ErrDialectSessionNotFound = errors.New("dialect session not found")
+	ErrDialectSessionInvalid  = errors.New("invalid dialect session operation")
+)
+
+const (
+	// dialectRankingLimit 推断结果保留的候选数
+	dialectRankingLimit = 10
+	// dialectProvinceClipLimit 按省辨识时读取的候选片段数，需覆盖尽可能多的省份
+	dialectProvinceClipLimit = 2000
+	// dialectScopedClipLimit 按市、片区辨识时读取的候选片段数
+	dialectScopedClipLimit = 300
+)
+
+// DialectSessionAppService 方言辨识应用服务：为只会说方言的被寻获人员逐级播放方言片段，推断可能的籍贯
+type DialectSessionAppService struct {
+	sessionRepo  repository.DialectSessionRepository
+	dialectRepo  repository.DialectRepository
+	mpRepo       repository.MissingPersonRepository
+	auditService *AuditService
+	inference    *domainService.DialectOriginInference
+}
+
+// NewDialectSessionAppService 创建方言辨识应用服务
+func NewDialectSessionAppService(
+	sessionRepo repository.DialectSessionRepository,
+	dialectRepo repository.DialectRepository,
+	mpRepo repository.MissingPersonRepository,
+	auditService *AuditService,
+) *DialectSessionAppService {
+	return &DialectSessionAppService{
+		sessionRepo:  sessionRepo,
+		dialectRepo:  dialectRepo,
+		mpRepo:       mpRepo,
+		auditService: auditService,
+		inference:    domainService.NewDialectOriginInference(),
+	}
+}
+
+// Start 开始方言辨识
+func (s *DialectSessionAppService) Start(ctx context.Context, req *dto.StartDialectSessionRequest, operatorID, orgID string) (*dto.DialectSessionResponse, error) {
+	session := &entity.DialectSession{
+		SubjectName: strings.TrimSpace(req.SubjectName),
+		Status:      entity.DialectSessionInProgress,
+		Note:        req.Note,
+		OperatorID:  operatorID,
+		OrgID:       orgID,
+	}
+	if req.MissingPersonID != "" {
+		if _, err := s.mpRepo.FindByID(ctx, req.MissingPersonID); err != nil {
+			return nil, ErrMissingPersonNotFound
+		}
+		personID := req.MissingPersonID
+		session.MissingPersonID = &personID
+	}
+	if err := narrowSession(session, req.Province, req.City); err != nil {
+		return nil, err
+	}
+
+	if err := s.sessionRepo.Create(ctx, session); err != nil {
+		logger.Error("Failed to create dialect session", logger.Err(err))
+		return nil, err
+	}
+
+	logger.Info("Dialect session started",
+		logger.String("session_id", session.ID),
+		logger.String("operator_id", operatorID),
+	)
+
+	return s.detail(ctx, session)
+}
+
+// GetByID 获取辨识会话，辨识中时附带下一批推荐片段
+func (s *DialectSessionAppService) GetByID(ctx context.Context, id string) (*dto.DialectSessionResponse, error) {
+	session, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.detail(ctx, session)
+}
+
+// Answer 记录受测者对片段的反应，并按累计反应缩小或放宽范围
+func (s *DialectSessionAppService) Answer(ctx context.Context, id string, req *dto.DialectAnswerRequest) (*dto.DialectSessionResponse, error) {
+	session, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !session.IsInProgress() {
+		return nil, entity.ErrDialectSessionClosed
+	}
+	for _, played := range session.PlayedDialectIDs() {
+		if played == req.DialectID {
+			return nil, fmt.Errorf("%w: 该片段已记录反应", ErrDialectSessionInvalid)
+		}
+	}
+
+	dialect, err := s.dialectRepo.FindByID(ctx, req.DialectID)
+	if err != nil {
+		return nil, ErrDialectNotFound
+	}
+
+	answer := &entity.DialectSessionAnswer{
+		SessionID:   session.ID,
+		DialectID:   dialect.ID,
+		Stage:       session.Stage,
+		Province:    dialect.Province,
+		City:        dialect.City,
+		Region:      dialect.Region,
+		Recognition: entity.DialectRecognition(req.Recognition),
+		Note:        req.Note,
+	}
+	session.Answers = append(session.Answers, *answer)
+	stage, province, city := s.inference.Advance(session, session.Answers)
+	session.Narrow(stage, province, city)
+
+	if err := s.sessionRepo.AddAnswer(ctx, session, answer); err != nil {
+		logger.Error("Failed to save dialect answer", logger.String("session_id", id), logger.Err(err))
+		return nil, err
+	}
+	session.Answers[len(session.Answers)-1] = *answer
+	session.Answers[len(session.Answers)-1].Dialect = dialect
+
+	return s.detail(ctx, session)
+}
+
+// Narrow 手动调整辨识范围（如受测者提到了地名）
+func (s *DialectSessionAppService) Narrow(ctx context.Context, id string, req *dto.NarrowDialectSessionRequest) (*dto.DialectSessionResponse, error) {
+	session, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !session.IsInProgress() {
+		return nil, entity.ErrDialectSessionClosed
+	}
+	if err := narrowSession(session, req.Province, req.City); err != nil {
+		return nil, err
+	}
+	if err := s.sessionRepo.UpdateSession(ctx, session); err != nil {
+		return nil, err
+	}
+	return s.detail(ctx, session)
+}
+
+// Complete 完成辨识，保存推断结果
+func (s *DialectSessionAppService) Complete(ctx context.Context, id string, req *dto.CompleteDialectSessionRequest, operatorID, orgID string) (*dto.DialectSessionResponse, error) {
+	session, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := json.Marshal(s.rank(session))
+	if err != nil {
+		return nil, err
+	}
+	if err := session.Complete(string(result)); err != nil {
+		return nil, err
+	}
+	if note := strings.TrimSpace(req.Note); note != "" {
+		session.Note = strings.TrimSpace(session.Note + "\n" + note)
+	}
+
+	if err := s.sessionRepo.UpdateSession(ctx, session); err != nil {
+		logger.Error("Failed to complete dialect session", logger.String("session_id", id), logger.Err(err))
+		return nil, err
+	}
+
+	if s.auditService != nil {
+		auditLog := entity.NewAuditLog(operatorID, orgID, entity.AuditActionUpdate, string(entity.ResourceDialect)).
+			SetResourceID(session.ID).
+			SetDescription("完成方言辨识").
+			AddExtra("answers", len(session.Answers)).
+			AddExtra("result", session.Result)
+		s.auditService.Log(ctx, auditLog)
+	}
+
+	return s.detail(ctx, session)
+}
+
+// Cancel 取消辨识
+func (s *DialectSessionAppService) Cancel(ctx context.Context, id string) (*dto.DialectSessionResponse, error) {
+	session, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := session.Cancel(); err != nil {
+		return nil, err
+	}
+	if err := s.sessionRepo.UpdateSession(ctx, session); err != nil {
+		return nil, err
+	}
+	return s.detail(ctx, session)
+}
+
+// Attach 将辨识结果关联到案件：track 生成一条待核实线索，note 仅作为案件备注关联
+func (s *DialectSessionAppService) Attach(ctx context.Context, id string, req *dto.AttachDialectSessionRequest, operatorID, orgID string) (*dto.DialectSessionResponse, error) {
+	session, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	mp, err := s.mpRepo.FindByID(ctx, req.MissingPersonID)
+	if err != nil {
+		return nil, ErrMissingPersonNotFound
+	}
+	if err := session.Attach(mp.ID, req.Mode); err != nil {
+		return nil, err
+	}
+
+	ranking := s.rank(session)
+	if req.Mode == entity.DialectAttachTrack {
+		if len(ranking) == 0 {
+			return nil, fmt.Errorf("%w: 辨识没有得出可能的籍贯，只能作为备注关联", ErrDialectSessionInvalid)
+		}
+		top := ranking[0]
+		track := &entity.MissingPersonTrack{
+			MissingPersonID: mp.ID,
+			ReporterID:      &operatorID,
+			Location:        regionLabel(top.Province, top.City, top.Region),
+			Province:        top.Province,
+			City:            top.City,
+			Time:            *session.CompletedAt,
+			Description:     describeRanking(ranking),
+			Status:          entity.TrackStatusPending,
+			Source:          entity.TrackSourceVolunteer,
+		}
+		err = s.sessionRepo.AttachTrack(ctx, session, track)
+	} else {
+		err = s.sessionRepo.UpdateSession(ctx, session)
+	}
+	if err != nil {
+		logger.Error("Failed to attach dialect session", logger.String("session_id", id), logger.Err(err))
+		return nil, err
+	}
+
+	if s.auditService != nil {
+		auditLog := entity.NewAuditLog(operatorID, orgID, entity.AuditActionUpdate, string(entity.ResourceMissingPerson)).
+			SetResourceID(mp.ID).
+			SetResourceName(mp.CaseNo).
+			SetDescription("关联方言辨识结果").
+			AddExtra("session_id", session.ID).
+			AddExtra("mode", req.Mode)
+		if session.TrackID != nil {
+			auditLog.AddExtra("track_id", *session.TrackID)
+		}
+		s.auditService.Log(ctx, auditLog)
+	}
+
+	return s.detail(ctx, session)
+}
+
+// List 分页查询辨识会话
+func (s *DialectSessionAppService) List(ctx context.Context, req *dto.DialectSessionListRequest, operatorID string) (*dto.DialectSessionListResponse, error) {
+	query := &repository.DialectSessionQuery{
+		Pagination: repository.Pagination{Page: req.Page, PageSize: req.PageSize},
+		Status:     entity.DialectSessionStatus(req.Status),
+	}
+	if req.Mine {
+		query.OperatorID = operatorID
+	}
+	return s.list(ctx, query)
+}
+
+// ListByCase 获取关联到案件的辨识会话
+func (s *DialectSessionAppService) ListByCase(ctx context.Context, personID string, req *dto.DialectSessionListRequest) (*dto.DialectSessionListResponse, error) {
+	if _, err := s.mpRepo.FindByID(ctx, personID); err != nil {
+		return nil, ErrMissingPersonNotFound
+	}
+	return s.list(ctx, &repository.DialectSessionQuery{
+		Pagination:      repository.Pagination{Page: req.Page, PageSize: req.PageSize},
+		MissingPersonID: personID,
+		Status:          entity.DialectSessionStatus(req.Status),
+	})
+}
+
+func (s *DialectSessionAppService) list(ctx context.Context, query *repository.DialectSessionQuery) (*dto.DialectSessionListResponse, error) {
+	result, err := s.sessionRepo.List(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]dto.DialectSessionResponse, len(result.List))
+	for i := range result.List {
+		list[i] = dto.ToDialectSessionResponse(&result.List[i])
+		list[i].Ranking = savedRanking(&result.List[i])
+	}
+
+	return &dto.DialectSessionListResponse{
+		List:       list,
+		Total:      result.Total,
+		Page:       result.Page,
+		PageSize:   result.PageSize,
+		TotalPages: result.TotalPages,
+	}, nil
+}
+
+// find 查找会话（含受测者反应）
+func (s *DialectSessionAppService) find(ctx context.Context, id string) (*entity.DialectSession, error) {
+	session, err := s.sessionRepo.FindByIDWithAnswers(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil {
+		return nil, ErrDialectSessionNotFound
+	}
+	return session, nil
+}
+
+// detail 会话详情：推断排名，辨识中时附带下一批推荐片段
+func (s *DialectSessionAppService) detail(ctx context.Context, session *entity.DialectSession) (*dto.DialectSessionResponse, error) {
+	resp := dto.ToDialectSessionResponse(session)
+	resp.Ranking = s.rank(session)
+	if !session.IsInProgress() {
+		return &resp, nil
+	}
+
+	query := &repository.DialectClipQuery{
+		ExcludeIDs: session.PlayedDialectIDs(),
+		Limit:      dialectProvinceClipLimit,
+	}
+	if session.Stage != entity.DialectStageProvince {
+		query.Province = session.Province
+		query.Limit = dialectScopedClipLimit
+	}
+	if session.Stage == entity.DialectStageRegion {
+		query.City = session.City
+	}
+	clips, err := s.dialectRepo.FindIdentificationClips(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, d := range s.inference.PickClips(session, clips, domainService.DefaultDialectBatchSize) {
+		resp.NextClips = append(resp.NextClips, dto.ToDialectResponse(&d))
+	}
+	return &resp, nil
+}
+
+// rank 已完成的会话使用保存的结果，否则按当前反应计算
+func (s *DialectSessionAppService) rank(session *entity.DialectSession) []dto.DialectRegionCandidate {
+	if session.Result != "" {
+		return savedRanking(session)
+	}
+	list := []dto.DialectRegionCandidate{}
+	for _, c := range s.inference.Rank(session.Answers, dialectRankingLimit) {
+		list = append(list, dto.DialectRegionCandidate{
+			Province:   c.Province,
+			City:       c.City,
+			Region:     c.Region,
+			Score:      c.Score,
+			Confidence: c.Confidence,
+			Evidence:   c.Evidence,
+		})
+	}
+	return list
+}
+
+// savedRanking 解析完成时保存的推断结果
+func savedRanking(session *entity.DialectSession) []dto.DialectRegionCandidate {
+	list := []dto.DialectRegionCandidate{}
+	if session.Result == "" {
+		return list
+	}
+	if err := json.Unmarshal([]byte(session.Result), &list); err != nil {
+		logger.Warn("Invalid dialect session result", logger.String("session_id", session.ID), logger.Err(err))
+	}
+	return list
+}
+
+// narrowSession 根据已知省、市设置辨识阶段
+func narrowSession(session *entity.DialectSession, province, city string) error {
+	province, city = strings.TrimSpace(province), strings.TrimSpace(city)
+	switch {
+	case province == "" && city != "":
+		return fmt.Errorf("%w: 指定城市时必须指定省份", ErrDialectSessionInvalid)
+	case city != "":
+		session.Narrow(entity.DialectStageRegion, province, city)
+	case province != "":
+		session.Narrow(entity.DialectStageCity, province, "")
+	default:
+		session.Narrow(entity.DialectStageProvince, "", "")
+	}
+	return nil
+}
+
+// regionLabel 地区显示名
+func regionLabel(province, city, region string) string {
+	parts := []string{province}
+	if city != "" {
+		parts = append(parts, city)
+	}
+	if region != "" && region != city && region != province {
+		parts = append(parts, region)
+	}
+	return strings.Join(parts, " ")
+}
+
+// describeRanking 线索描述：列出前三个可能籍贯及置信度
+func describeRanking(ranking []dto.DialectRegionCandidate) string {
+	var b strings.Builder
+	b.WriteString("方言辨识推断籍贯：")
+	for i, c := range ranking {
+		if i == 3 {
+			break
+		}
+		if i > 0 {
+			b.WriteString("；")
+		}
+		fmt.Fprintf(&b, "%s（%.0f%%）", regionLabel(c.Province, c.City, c.Region), c.Confidence*100)
+	}
+	return b.String()
+}
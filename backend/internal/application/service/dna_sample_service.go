@@ -0,0 +1,487 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/dto"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/repository"
+	"github.com/Snowitty-Re/CNtunyuan/internal/infrastructure/permission"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/logger"
+)
+
+var (
+	ErrDNASampleNotFound       = errors.New("dna sample not found")
+	ErrDNASampleInvalid        = errors.New("invalid dna sample")
+	ErrDNASampleExists         = errors.New("dna sample number already registered")
+	ErrDNASampleForbidden      = errors.New("no access to dna sample")
+	ErrDNASampleFieldForbidden = errors.New("no write permission on dna sample field")
+	ErrDNASampleAuditFailed    = errors.New("dna sample audit log failed")
+)
+
+// dnaMaskedValue 无权查看的字段替换值
+const dnaMaskedValue = "***"
+
+// DNASampleAppService DNA / 血样登记应用服务
+// 只记录保管链和送检状态元数据，不保存原始遗传数据。字段按 FieldPermission（资源 dna_sample）控制：
+// 权限为 none 的字段不可查看，非 write 的字段不可修改；未配置时敏感字段仅主管及以上可查看。
+// 样本的查看和修改限于操作人数据权限范围内的组织，主管以下角色只能查看自己采集的样本。
+// 每次查看均同步写入审计日志，写入失败时拒绝返回数据。
+type DNASampleAppService struct {
+	sampleRepo   repository.DNASampleRepository
+	mpRepo       repository.MissingPersonRepository
+	userRepo     repository.UserRepository
+	permService  *PermissionAppService
+	auditService *AuditService
+	dataPerm     permission.DataPermissionProvider
+}
+
+// NewDNASampleAppService 创建 DNA 样本应用服务
+func NewDNASampleAppService(
+	sampleRepo repository.DNASampleRepository,
+	mpRepo repository.MissingPersonRepository,
+	userRepo repository.UserRepository,
+	permService *PermissionAppService,
+	auditService *AuditService,
+	dataPerm permission.DataPermissionProvider,
+) *DNASampleAppService {
+	return &DNASampleAppService{
+		sampleRepo:   sampleRepo,
+		mpRepo:       mpRepo,
+		userRepo:     userRepo,
+		permService:  permService,
+		auditService: auditService,
+		dataPerm:     dataPerm,
+	}
+}
+
+// Create 为案件登记采集的样本
+func (s *DNASampleAppService) Create(ctx context.Context, personID string, req *dto.CreateDNASampleRequest, operatorID string) (*dto.DNASampleResponse, error) {
+	operator, access, err := s.operatorAccess(ctx, operatorID)
+	if err != nil {
+		return nil, err
+	}
+	mp, err := s.mpRepo.FindByID(ctx, personID)
+	if err != nil {
+		return nil, ErrMissingPersonNotFound
+	}
+	if !access.scope.HasPermission(mp.OrgID) {
+		return nil, ErrDNASampleForbidden
+	}
+
+	if err := access.checkWrite(map[string]bool{
+		"sample_no":       true,
+		"donor_name":      req.DonorName != "",
+		"donor_relation":  req.DonorRelation != "",
+		"donor_phone":     req.DonorPhone != "",
+		"collection_site": true,
+		"holder":          req.Holder != "",
+		"note":            req.Note != "",
+	}); err != nil {
+		return nil, err
+	}
+
+	sampleNo := strings.TrimSpace(req.SampleNo)
+	existing, err := s.sampleRepo.FindBySampleNo(ctx, sampleNo)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrDNASampleExists
+	}
+
+	holder := strings.TrimSpace(req.Holder)
+	if holder == "" {
+		holder = operatorName(operator)
+	}
+	sample := &entity.DNASample{
+		MissingPersonID: mp.ID,
+		OrgID:           mp.OrgID,
+		SampleNo:        sampleNo,
+		SubjectType:     entity.DNASampleSubject(req.SubjectType),
+		SampleType:      entity.DNASampleType(req.SampleType),
+		DonorName:       strings.TrimSpace(req.DonorName),
+		DonorRelation:   strings.TrimSpace(req.DonorRelation),
+		DonorPhone:      strings.TrimSpace(req.DonorPhone),
+		CollectedAt:     req.CollectedAt,
+		CollectionSite:  strings.TrimSpace(req.CollectionSite),
+		CollectorID:     operator.ID,
+		Holder:          holder,
+		Status:          entity.DNASampleStatusCollected,
+		Note:            req.Note,
+	}
+	if err := validateDNASample(sample); err != nil {
+		return nil, err
+	}
+
+	log := sample.CollectLog(operator.ID)
+	if err := s.sampleRepo.CreateWithLog(ctx, sample, log); err != nil {
+		logger.Error("Failed to create dna sample", logger.String("mp_id", mp.ID), logger.Err(err))
+		return nil, err
+	}
+
+	s.audit(ctx, operator, entity.AuditActionCreate, sample, "登记 DNA 样本", map[string]interface{}{
+		"subject_type": sample.SubjectType,
+		"sample_type":  sample.SampleType,
+	})
+
+	resp := access.sample(sample)
+	return &resp, nil
+}
+
+// GetByID 查看样本
+func (s *DNASampleAppService) GetByID(ctx context.Context, id, operatorID string) (*dto.DNASampleResponse, error) {
+	operator, access, sample, err := s.viewable(ctx, id, operatorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.auditRead(ctx, operator, sample, "查看 DNA 样本", access); err != nil {
+		return nil, err
+	}
+
+	resp := access.sample(sample)
+	return &resp, nil
+}
+
+// List 查询样本，只返回数据权限范围内组织的样本，主管以下角色只返回自己采集的样本
+func (s *DNASampleAppService) List(ctx context.Context, personID string, req *dto.DNASampleListRequest, operatorID string) (*dto.DNASampleListResponse, error) {
+	operator, access, err := s.operatorAccess(ctx, operatorID)
+	if err != nil {
+		return nil, err
+	}
+
+	query := &repository.DNASampleQuery{
+		Pagination:      repository.Pagination{Page: req.Page, PageSize: req.PageSize},
+		MissingPersonID: personID,
+		OrgID:           req.OrgID,
+		Status:          entity.DNASampleStatus(req.Status),
+	}
+	if !access.scope.CanAccessAll() {
+		if req.OrgID != "" && !access.scope.HasPermission(req.OrgID) {
+			return nil, ErrDNASampleForbidden
+		}
+		// 不信任请求中的组织，限定为操作人可访问的组织
+		query.OrgIDs = access.scope.AccessibleOrgIDs
+		if len(query.OrgIDs) == 0 {
+			query.OrgIDs = []string{operator.OrgID}
+		}
+	}
+	if !access.manager {
+		query.CollectorID = operator.ID
+	}
+
+	result, err := s.sampleRepo.List(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	auditLog := entity.NewAuditLog(operator.ID, operator.OrgID, entity.AuditActionQuery, string(entity.ResourceDNASample)).
+		SetDescription("查询 DNA 样本列表").
+		SetUsername(operatorName(operator)).
+		AddExtra("missing_person_id", personID).
+		AddExtra("status", req.Status).
+		AddExtra("count", len(result.List))
+	if err := s.auditService.LogSync(ctx, auditLog); err != nil {
+		logger.Error("Failed to write dna sample audit log", logger.Err(err))
+		return nil, ErrDNASampleAuditFailed
+	}
+
+	list := make([]dto.DNASampleResponse, len(result.List))
+	for i := range result.List {
+		list[i] = access.sample(&result.List[i])
+	}
+	return &dto.DNASampleListResponse{
+		List:       list,
+		Total:      result.Total,
+		Page:       result.Page,
+		PageSize:   result.PageSize,
+		TotalPages: result.TotalPages,
+	}, nil
+}
+
+// Update 修改样本采集信息
+func (s *DNASampleAppService) Update(ctx context.Context, id string, req *dto.UpdateDNASampleRequest, operatorID string) (*dto.DNASampleResponse, error) {
+	operator, access, sample, err := s.viewable(ctx, id, operatorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := access.checkWrite(map[string]bool{
+		"donor_name":      req.DonorName != nil,
+		"donor_relation":  req.DonorRelation != nil,
+		"donor_phone":     req.DonorPhone != nil,
+		"collection_site": req.CollectionSite != "",
+		"note":            req.Note != nil,
+	}); err != nil {
+		return nil, err
+	}
+
+	if req.SampleType != "" {
+		sample.SampleType = entity.DNASampleType(req.SampleType)
+	}
+	if req.DonorName != nil {
+		sample.DonorName = strings.TrimSpace(*req.DonorName)
+	}
+	if req.DonorRelation != nil {
+		sample.DonorRelation = strings.TrimSpace(*req.DonorRelation)
+	}
+	if req.DonorPhone != nil {
+		sample.DonorPhone = strings.TrimSpace(*req.DonorPhone)
+	}
+	if req.CollectedAt != nil {
+		sample.CollectedAt = *req.CollectedAt
+	}
+	if req.CollectionSite != "" {
+		sample.CollectionSite = strings.TrimSpace(req.CollectionSite)
+	}
+	if req.Note != nil {
+		sample.Note = *req.Note
+	}
+	if err := validateDNASample(sample); err != nil {
+		return nil, err
+	}
+
+	if err := s.sampleRepo.UpdateInfo(ctx, sample); err != nil {
+		logger.Error("Failed to update dna sample", logger.String("sample_id", id), logger.Err(err))
+		return nil, err
+	}
+
+	s.audit(ctx, operator, entity.AuditActionUpdate, sample, "修改 DNA 样本采集信息", nil)
+
+	resp := access.sample(sample)
+	return &resp, nil
+}
+
+// RecordCustody 登记保管链事件（移交、送检、结果、退回、销毁）
+func (s *DNASampleAppService) RecordCustody(ctx context.Context, id string, req *dto.DNASampleCustodyRequest, operatorID string) (*dto.DNASampleResponse, error) {
+	operator, access, sample, err := s.viewable(ctx, id, operatorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := access.checkWrite(map[string]bool{
+		"holder":      req.Holder != "",
+		"lab_name":    req.LabName != "",
+		"lab_case_no": req.LabCaseNo != "",
+		"result":      req.Result != "",
+		"result_ref":  req.ResultRef != "",
+	}); err != nil {
+		return nil, err
+	}
+
+	event := entity.DNASampleCustodyEvent{
+		Action:    req.Action,
+		Holder:    strings.TrimSpace(req.Holder),
+		Location:  strings.TrimSpace(req.Location),
+		Note:      req.Note,
+		LabName:   strings.TrimSpace(req.LabName),
+		LabCaseNo: strings.TrimSpace(req.LabCaseNo),
+		Result:    entity.DNASampleResult(req.Result),
+		ResultRef: strings.TrimSpace(req.ResultRef),
+	}
+	if req.OccurredAt != nil {
+		event.OccurredAt = *req.OccurredAt
+	}
+
+	log, err := sample.ApplyCustody(event, operator.ID)
+	if err != nil {
+		if errors.Is(err, entity.ErrDNASampleTransitionNotAllowed) || errors.Is(err, entity.ErrGeneticDataNotAllowed) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %v", ErrDNASampleInvalid, err)
+	}
+
+	if err := s.sampleRepo.UpdateWithLog(ctx, sample, log); err != nil {
+		logger.Error("Failed to record dna sample custody", logger.String("sample_id", id), logger.String("action", req.Action), logger.Err(err))
+		return nil, err
+	}
+
+	s.audit(ctx, operator, entity.AuditActionUpdate, sample, fmt.Sprintf("样本保管链 %s：%s -> %s", log.Action, log.FromStatus, log.ToStatus), map[string]interface{}{
+		"action":      log.Action,
+		"from_status": log.FromStatus,
+		"to_status":   log.ToStatus,
+	})
+
+	resp := access.sample(sample)
+	return &resp, nil
+}
+
+// GetCustodyLogs 获取样本保管链记录
+func (s *DNASampleAppService) GetCustodyLogs(ctx context.Context, id, operatorID string) ([]dto.DNASampleCustodyLogResponse, error) {
+	operator, access, sample, err := s.viewable(ctx, id, operatorID)
+	if err != nil {
+		return nil, err
+	}
+
+	logs, err := s.sampleRepo.GetCustodyLogs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.auditRead(ctx, operator, sample, "查看 DNA 样本保管链", access); err != nil {
+		return nil, err
+	}
+
+	list := make([]dto.DNASampleCustodyLogResponse, len(logs))
+	for i := range logs {
+		list[i] = access.custodyLog(&logs[i])
+	}
+	return list, nil
+}
+
+// operatorAccess 读取操作人的数据权限范围及其在样本资源上的字段权限
+func (s *DNASampleAppService) operatorAccess(ctx context.Context, operatorID string) (*entity.User, *dnaSampleAccess, error) {
+	operator, err := s.userRepo.FindByID(ctx, operatorID)
+	if err != nil {
+		return nil, nil, ErrUserNotFound
+	}
+
+	scope, err := permission.BuildDataPermissionContext(ctx, s.dataPerm, operator.ID, operator.OrgID, operator.Role)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	perms, err := s.permService.FieldPermissions(ctx, operator.ID, string(entity.ResourceDNASample))
+	if err != nil {
+		// 无法读取字段权限时按最严格的默认规则处理
+		logger.Warn("Failed to load dna sample field permissions", logger.String("user_id", operator.ID), logger.Err(err))
+		return operator, &dnaSampleAccess{scope: scope}, nil
+	}
+	return operator, &dnaSampleAccess{
+		scope:   scope,
+		perms:   perms,
+		manager: operator.HasPermission(string(entity.RoleManager)),
+	}, nil
+}
+
+// viewable 读取样本并检查操作人是否可以访问：样本所属组织在数据权限范围内，且为主管及以上或样本采集人
+func (s *DNASampleAppService) viewable(ctx context.Context, id, operatorID string) (*entity.User, *dnaSampleAccess, *entity.DNASample, error) {
+	operator, access, err := s.operatorAccess(ctx, operatorID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	sample, err := s.sampleRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, nil, nil, ErrDNASampleNotFound
+	}
+	if !access.scope.HasPermission(sample.OrgID) || (!access.manager && sample.CollectorID != operator.ID) {
+		return nil, nil, nil, ErrDNASampleForbidden
+	}
+	return operator, access, sample, nil
+}
+
+// auditRead 同步记录查看操作，写入失败时拒绝返回数据
+func (s *DNASampleAppService) auditRead(ctx context.Context, operator *entity.User, sample *entity.DNASample, desc string, access *dnaSampleAccess) error {
+	auditLog := entity.NewAuditLog(operator.ID, operator.OrgID, entity.AuditActionQuery, string(entity.ResourceDNASample)).
+		SetResourceID(sample.ID).
+		SetResourceName(access.value("sample_no", sample.SampleNo)).
+		SetDescription(desc).
+		SetUsername(operatorName(operator)).
+		AddExtra("missing_person_id", sample.MissingPersonID)
+	if err := s.auditService.LogSync(ctx, auditLog); err != nil {
+		logger.Error("Failed to write dna sample audit log", logger.String("sample_id", sample.ID), logger.Err(err))
+		return ErrDNASampleAuditFailed
+	}
+	return nil
+}
+
+// audit 记录修改操作（数据已保存，审计写入失败只记录错误日志）
+func (s *DNASampleAppService) audit(ctx context.Context, operator *entity.User, action entity.AuditAction, sample *entity.DNASample, desc string, extra map[string]interface{}) {
+	auditLog := entity.NewAuditLog(operator.ID, operator.OrgID, action, string(entity.ResourceDNASample)).
+		SetResourceID(sample.ID).
+		SetResourceName(sample.SampleNo).
+		SetDescription(desc).
+		SetUsername(operatorName(operator)).
+		AddExtra("missing_person_id", sample.MissingPersonID)
+	for k, v := range extra {
+		auditLog.AddExtra(k, v)
+	}
+	if err := s.auditService.LogSync(ctx, auditLog); err != nil {
+		logger.Error("Failed to write dna sample audit log", logger.String("sample_id", sample.ID), logger.Err(err))
+	}
+}
+
+// validateDNASample 验证样本，区分原始遗传数据错误
+func validateDNASample(sample *entity.DNASample) error {
+	if err := sample.Validate(); err != nil {
+		if errors.Is(err, entity.ErrGeneticDataNotAllowed) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", ErrDNASampleInvalid, err)
+	}
+	return nil
+}
+
+// dnaSampleAccess 操作人在样本资源上的字段权限
+type dnaSampleAccess struct {
+	scope   *permission.DataPermissionContext // 可访问的组织范围
+	perms   map[string]string                 // field -> read/write/none
+	manager bool                              // 主管及以上：可查看范围内全部样本及未配置权限的敏感字段
+}
+
+// readable 字段是否可查看
+func (a *dnaSampleAccess) readable(field string) bool {
+	if perm, ok := a.perms[field]; ok {
+		return perm != "none"
+	}
+	return a.manager || !entity.IsResourceSensitiveField(string(entity.ResourceDNASample), field)
+}
+
+// checkWrite 检查请求中设置的字段是否可写，仅限制已配置字段权限的字段
+func (a *dnaSampleAccess) checkWrite(fields map[string]bool) error {
+	for field, set := range fields {
+		if !set {
+			continue
+		}
+		if perm, ok := a.perms[field]; ok && perm != "write" {
+			return fmt.Errorf("%w: %s", ErrDNASampleFieldForbidden, field)
+		}
+	}
+	return nil
+}
+
+// value 按字段权限返回值
+func (a *dnaSampleAccess) value(field, v string) string {
+	if v != "" && !a.readable(field) {
+		return dnaMaskedValue
+	}
+	return v
+}
+
+// sample 转换为脱敏后的样本响应
+func (a *dnaSampleAccess) sample(sample *entity.DNASample) dto.DNASampleResponse {
+	resp := dto.ToDNASampleResponse(sample)
+	for field, ptr := range map[string]*string{
+		"sample_no":       &resp.SampleNo,
+		"donor_name":      &resp.DonorName,
+		"donor_relation":  &resp.DonorRelation,
+		"donor_phone":     &resp.DonorPhone,
+		"collection_site": &resp.CollectionSite,
+		"holder":          &resp.Holder,
+		"lab_name":        &resp.LabName,
+		"lab_case_no":     &resp.LabCaseNo,
+		"result":          &resp.Result,
+		"result_ref":      &resp.ResultRef,
+		"note":            &resp.Note,
+	} {
+		if masked := a.value(field, *ptr); masked != *ptr {
+			*ptr = masked
+			resp.MaskedFields = append(resp.MaskedFields, field)
+		}
+	}
+	sort.Strings(resp.MaskedFields)
+	return resp
+}
+
+// custodyLog 转换为脱敏后的保管链记录响应
+func (a *dnaSampleAccess) custodyLog(log *entity.DNASampleCustodyLog) dto.DNASampleCustodyLogResponse {
+	resp := dto.ToDNASampleCustodyLogResponse(log)
+	resp.Holder = a.value("holder", resp.Holder)
+	resp.Note = a.value("note", resp.Note)
+	return resp
+}
@@ -0,0 +1,526 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/dto"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/repository"
+	domainService "github.com/Snowitty-Re/CNtunyuan/internal/domain/service"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/hanzi"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/logger"
+)
+
+var (
+	ErrFamilySearcherNotFound = errors.New("family searcher not found")
+	ErrFamilySearcherInvalid  = errors.New("invalid family searcher")
+	ErrSearcherCaseNotLinked  = errors.New("case not linked to searcher")
+)
+
+// 亲属关系图节点类型
+const (
+	familyNodeSearcher = "searcher"
+	familyNodeRelative = "relative"
+	familyNodeCase     = "case"
+)
+
+// FamilySearcherAppService 寻亲者应用服务：寻亲者档案、亲属关系及与案件的双向比对
+type FamilySearcherAppService struct {
+	searcherRepo repository.FamilySearcherRepository
+	mpRepo       repository.MissingPersonRepository
+	auditService *AuditService
+	matcher      *domainService.FamilySearchMatcher
+}
+
+// NewFamilySearcherAppService 创建寻亲者应用服务
+func NewFamilySearcherAppService(
+	searcherRepo repository.FamilySearcherRepository,
+	mpRepo repository.MissingPersonRepository,
+	auditService *AuditService,
+) *FamilySearcherAppService {
+	return &FamilySearcherAppService{
+		searcherRepo: searcherRepo,
+		mpRepo:       mpRepo,
+		auditService: auditService,
+		matcher:      domainService.NewFamilySearchMatcher(domainService.DefaultFamilyMatchThreshold),
+	}
+}
+
+// Create 登记寻亲者
+func (s *FamilySearcherAppService) Create(ctx context.Context, req *dto.FamilySearcherRequest, operatorID, orgID string) (*dto.FamilySearcherResponse, error) {
+	searcher := &entity.FamilySearcher{
+		Status:         entity.FamilySearcherStatusActive,
+		OrgID:          orgID,
+		RegisteredByID: operatorID,
+	}
+	if err := s.apply(ctx, searcher, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.searcherRepo.CreateWithDetails(ctx, searcher); err != nil {
+		logger.Error("Failed to create family searcher", logger.Err(err))
+		return nil, err
+	}
+
+	s.audit(ctx, operatorID, orgID, entity.AuditActionCreate, searcher, "登记寻亲者")
+
+	logger.Info("Family searcher registered",
+		logger.String("searcher_id", searcher.ID),
+		logger.String("kind", string(searcher.Kind)),
+		logger.String("operator_id", operatorID),
+	)
+
+	return s.GetByID(ctx, searcher.ID)
+}
+
+// GetByID 获取寻亲者详情
+func (s *FamilySearcherAppService) GetByID(ctx context.Context, id string) (*dto.FamilySearcherResponse, error) {
+	searcher, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	resp := dto.ToFamilySearcherResponse(searcher)
+	return &resp, nil
+}
+
+// List 分页查询寻亲者
+func (s *FamilySearcherAppService) List(ctx context.Context, req *dto.FamilySearcherListRequest) (*dto.FamilySearcherListResponse, error) {
+	result, err := s.searcherRepo.List(ctx, &repository.FamilySearcherQuery{
+		Pagination: repository.Pagination{Page: req.Page, PageSize: req.PageSize},
+		Kind:       entity.FamilySearcherKind(req.Kind),
+		Status:     entity.FamilySearcherStatus(req.Status),
+		OrgID:      req.OrgID,
+		Keyword:    strings.TrimSpace(req.Keyword),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]dto.FamilySearcherResponse, len(result.List))
+	for i := range result.List {
+		list[i] = dto.ToFamilySearcherResponse(&result.List[i])
+	}
+
+	return &dto.FamilySearcherListResponse{
+		List:       list,
+		Total:      result.Total,
+		Page:       result.Page,
+		PageSize:   result.PageSize,
+		TotalPages: result.TotalPages,
+	}, nil
+}
+
+// Update 修改寻亲者，联系人、亲属整体替换
+func (s *FamilySearcherAppService) Update(ctx context.Context, id string, req *dto.FamilySearcherRequest, operatorID, orgID string) (*dto.FamilySearcherResponse, error) {
+	searcher, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Status != "" {
+		searcher.Status = entity.FamilySearcherStatus(req.Status)
+	}
+	searcher.Cases = nil
+	if err := s.apply(ctx, searcher, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.searcherRepo.UpdateWithDetails(ctx, searcher); err != nil {
+		logger.Error("Failed to update family searcher", logger.String("searcher_id", id), logger.Err(err))
+		return nil, err
+	}
+
+	s.audit(ctx, operatorID, orgID, entity.AuditActionUpdate, searcher, "修改寻亲者")
+
+	return s.GetByID(ctx, id)
+}
+
+// Delete 删除寻亲者
+func (s *FamilySearcherAppService) Delete(ctx context.Context, id, operatorID, orgID string) error {
+	searcher, err := s.find(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.searcherRepo.SoftDelete(ctx, id); err != nil {
+		return err
+	}
+
+	s.audit(ctx, operatorID, orgID, entity.AuditActionDelete, searcher, "删除寻亲者")
+	return nil
+}
+
+// LinkCase 关联案件，记录关联时的比对得分
+func (s *FamilySearcherAppService) LinkCase(ctx context.Context, id string, req *dto.LinkSearcherCaseRequest, operatorID, orgID string) (*dto.FamilySearcherResponse, error) {
+	searcher, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	mp, err := s.mpRepo.FindByID(ctx, req.MissingPersonID)
+	if err != nil {
+		return nil, ErrMissingPersonNotFound
+	}
+	if searcher.LinkedCase(mp.ID) != nil {
+		return nil, entity.ErrSearcherCaseAlreadyLinked
+	}
+
+	score, _ := s.matcher.Score(domainService.ProfileFromSearcher(searcher), domainService.ProfileFromMissingPerson(mp))
+	link := &entity.FamilySearcherCase{
+		SearcherID:      searcher.ID,
+		MissingPersonID: mp.ID,
+		Relation:        strings.TrimSpace(req.Relation),
+		MatchScore:      score,
+		LinkedByID:      operatorID,
+		Note:            req.Note,
+	}
+	if err := s.searcherRepo.LinkCase(ctx, link); err != nil {
+		logger.Error("Failed to link case to family searcher", logger.String("searcher_id", id), logger.Err(err))
+		return nil, err
+	}
+
+	if s.auditService != nil {
+		auditLog := entity.NewAuditLog(operatorID, orgID, entity.AuditActionUpdate, string(entity.ResourceFamilySearcher)).
+			SetResourceID(searcher.ID).
+			SetResourceName(searcher.Name).
+			SetDescription(fmt.Sprintf("关联案件 %s", mp.CaseNo)).
+			AddExtra("missing_person_id", mp.ID).
+			AddExtra("relation", link.Relation).
+			AddExtra("match_score", score)
+		s.auditService.Log(ctx, auditLog)
+	}
+
+	return s.GetByID(ctx, id)
+}
+
+// UnlinkCase 取消关联案件
+func (s *FamilySearcherAppService) UnlinkCase(ctx context.Context, id, personID, operatorID, orgID string) error {
+	searcher, err := s.find(ctx, id)
+	if err != nil {
+		return err
+	}
+	removed, err := s.searcherRepo.UnlinkCase(ctx, id, personID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrSearcherCaseNotLinked
+	}
+
+	if s.auditService != nil {
+		auditLog := entity.NewAuditLog(operatorID, orgID, entity.AuditActionUpdate, string(entity.ResourceFamilySearcher)).
+			SetResourceID(searcher.ID).
+			SetResourceName(searcher.Name).
+			SetDescription("取消关联案件").
+			AddExtra("missing_person_id", personID)
+		s.auditService.Log(ctx, auditLog)
+	}
+	return nil
+}
+
+// ListByCase 获取关联到案件的寻亲者
+func (s *FamilySearcherAppService) ListByCase(ctx context.Context, personID string) ([]dto.FamilySearcherResponse, error) {
+	if _, err := s.mpRepo.FindByID(ctx, personID); err != nil {
+		return nil, ErrMissingPersonNotFound
+	}
+	searchers, err := s.searcherRepo.FindByCase(ctx, personID)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]dto.FamilySearcherResponse, len(searchers))
+	for i := range searchers {
+		list[i] = dto.ToFamilySearcherResponse(&searchers[i])
+	}
+	return list, nil
+}
+
+// Graph 寻亲者的亲属关系图：本人、亲属（含已登记为寻亲者的亲属）、反向指向本人的寻亲者及关联案件
+func (s *FamilySearcherAppService) Graph(ctx context.Context, id string) (*dto.FamilySearcherGraphResponse, error) {
+	root, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	reverse, err := s.searcherRepo.FindRelativesLinkedTo(ctx, root.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, r := range root.Relatives {
+		if r.LinkedSearcherID != nil {
+			ids = append(ids, *r.LinkedSearcherID)
+		}
+	}
+	for _, r := range reverse {
+		ids = append(ids, r.SearcherID)
+	}
+	linked, err := s.searcherRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	searchers := make(map[string]*entity.FamilySearcher, len(linked))
+	for i := range linked {
+		searchers[linked[i].ID] = &linked[i]
+	}
+
+	graph := &dto.FamilySearcherGraphResponse{RootID: root.ID}
+	seen := make(map[string]bool)
+	addSearcher := func(sr *entity.FamilySearcher) {
+		if seen[sr.ID] {
+			return
+		}
+		seen[sr.ID] = true
+		graph.Nodes = append(graph.Nodes, dto.FamilyGraphNode{
+			ID: sr.ID, Type: familyNodeSearcher, Label: sr.Name, Kind: string(sr.Kind), Status: string(sr.Status),
+		})
+	}
+	addSearcher(root)
+
+	for _, r := range root.Relatives {
+		to := r.ID
+		if r.LinkedSearcherID != nil {
+			if sr, ok := searchers[*r.LinkedSearcherID]; ok {
+				addSearcher(sr)
+				to = sr.ID
+			}
+		}
+		if to == r.ID {
+			graph.Nodes = append(graph.Nodes, dto.FamilyGraphNode{ID: r.ID, Type: familyNodeRelative, Label: r.Name})
+		}
+		graph.Edges = append(graph.Edges, dto.FamilyGraphEdge{From: root.ID, To: to, Relation: r.Relation})
+	}
+
+	for _, r := range reverse {
+		sr, ok := searchers[r.SearcherID]
+		if !ok {
+			continue
+		}
+		addSearcher(sr)
+		graph.Edges = append(graph.Edges, dto.FamilyGraphEdge{From: sr.ID, To: root.ID, Relation: r.Relation})
+	}
+
+	for _, c := range root.Cases {
+		label, status := c.MissingPersonID, ""
+		if c.MissingPerson != nil {
+			label, status = c.MissingPerson.Name, string(c.MissingPerson.Status)
+		}
+		graph.Nodes = append(graph.Nodes, dto.FamilyGraphNode{ID: c.MissingPersonID, Type: familyNodeCase, Label: label, Status: status})
+		graph.Edges = append(graph.Edges, dto.FamilyGraphEdge{From: root.ID, To: c.MissingPersonID, Relation: c.Relation})
+	}
+
+	return graph, nil
+}
+
+// FindMatches 为寻亲者查找可能的亲人：亲寻家档案比对案件和家寻亲档案，家寻亲档案比对亲寻家档案
+func (s *FamilySearcherAppService) FindMatches(ctx context.Context, id string) (*dto.FamilySearcherMatchesResponse, error) {
+	searcher, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	profile := domainService.ProfileFromSearcher(searcher)
+	query := &repository.FamilyMatchCandidateQuery{
+		Kind:       searcher.MatchKind(),
+		Gender:     searcher.LostGender,
+		Province:   searcher.LostProvince,
+		NamePinyin: searcher.LostNamePinyin,
+		ExcludeID:  searcher.ID,
+	}
+	resp := &dto.FamilySearcherMatchesResponse{
+		Cases:     []dto.FamilyCaseMatchResponse{},
+		Searchers: []dto.FamilySearcherMatchResponse{},
+	}
+
+	if searcher.Kind == entity.FamilySearcherSeekingFamily {
+		persons, err := s.searcherRepo.FindCaseCandidates(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		profiles := make([]domainService.SearchProfile, len(persons))
+		for i := range persons {
+			profiles[i] = domainService.ProfileFromMissingPerson(&persons[i])
+		}
+		for _, m := range s.matcher.Match(profile, profiles) {
+			mp := &persons[m.Index]
+			resp.Cases = append(resp.Cases, dto.FamilyCaseMatchResponse{
+				DuplicateCandidateResponse: dto.ToDuplicateCandidateResponse(mp, m.Score, m.Reasons),
+				Linked:                     searcher.LinkedCase(mp.ID) != nil,
+			})
+		}
+	}
+
+	candidates, err := s.searcherRepo.FindMatchCandidates(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	linked := make(map[string]bool)
+	for _, r := range searcher.Relatives {
+		if r.LinkedSearcherID != nil {
+			linked[*r.LinkedSearcherID] = true
+		}
+	}
+	for _, m := range s.matchSearchers(profile, candidates) {
+		m.Linked = linked[m.ID]
+		resp.Searchers = append(resp.Searchers, m)
+	}
+
+	return resp, nil
+}
+
+// FindCaseMatches 为案件查找可能是走失者本人的亲寻家档案
+func (s *FamilySearcherAppService) FindCaseMatches(ctx context.Context, personID string) ([]dto.FamilySearcherMatchResponse, error) {
+	mp, err := s.mpRepo.FindByID(ctx, personID)
+	if err != nil {
+		return nil, ErrMissingPersonNotFound
+	}
+
+	namePinyin := mp.NamePinyin
+	if namePinyin == "" {
+		namePinyin = hanzi.ToPinyin(hanzi.NormalizeName(mp.Name))
+	}
+	candidates, err := s.searcherRepo.FindMatchCandidates(ctx, &repository.FamilyMatchCandidateQuery{
+		Kind:       entity.FamilySearcherSeekingFamily,
+		Gender:     mp.Gender,
+		Province:   mp.Province,
+		NamePinyin: namePinyin,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	linked, err := s.searcherRepo.FindByCase(ctx, mp.ID)
+	if err != nil {
+		return nil, err
+	}
+	linkedIDs := make(map[string]bool, len(linked))
+	for _, sr := range linked {
+		linkedIDs[sr.ID] = true
+	}
+
+	list := s.matchSearchers(domainService.ProfileFromMissingPerson(mp), candidates)
+	for i := range list {
+		list[i].Linked = linkedIDs[list[i].ID]
+	}
+	return list, nil
+}
+
+// matchSearchers 将画像与候选寻亲者比对
+func (s *FamilySearcherAppService) matchSearchers(profile domainService.SearchProfile, candidates []entity.FamilySearcher) []dto.FamilySearcherMatchResponse {
+	profiles := make([]domainService.SearchProfile, len(candidates))
+	for i := range candidates {
+		profiles[i] = domainService.ProfileFromSearcher(&candidates[i])
+	}
+
+	list := []dto.FamilySearcherMatchResponse{}
+	for _, m := range s.matcher.Match(profile, profiles) {
+		list = append(list, dto.ToFamilySearcherMatchResponse(&candidates[m.Index], m.Score, m.Reasons))
+	}
+	return list
+}
+
+// find 查找寻亲者（含联系人、亲属、关联案件）
+func (s *FamilySearcherAppService) find(ctx context.Context, id string) (*entity.FamilySearcher, error) {
+	searcher, err := s.searcherRepo.FindByIDWithDetails(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if searcher == nil {
+		return nil, ErrFamilySearcherNotFound
+	}
+	return searcher, nil
+}
+
+// apply 将请求写入寻亲者档案并校验
+func (s *FamilySearcherAppService) apply(ctx context.Context, searcher *entity.FamilySearcher, req *dto.FamilySearcherRequest) error {
+	searcher.Kind = entity.FamilySearcherKind(req.Kind)
+	searcher.Name = strings.TrimSpace(req.Name)
+	searcher.Gender = req.Gender
+	searcher.BirthYear = req.BirthYear
+	searcher.Province = strings.TrimSpace(req.Province)
+	searcher.City = strings.TrimSpace(req.City)
+	searcher.LostName = strings.TrimSpace(req.LostName)
+	searcher.LostNamePinyin = hanzi.ToPinyin(hanzi.NormalizeName(searcher.LostName))
+	searcher.LostGender = req.LostGender
+	searcher.LostBirthYear = req.LostBirthYear
+	searcher.LostAge = req.LostAge
+	searcher.LostTime = req.LostTime
+	searcher.LostProvince = strings.TrimSpace(req.LostProvince)
+	searcher.LostCity = strings.TrimSpace(req.LostCity)
+	searcher.LostDistrict = strings.TrimSpace(req.LostDistrict)
+	searcher.LostAddress = strings.TrimSpace(req.LostAddress)
+	searcher.Dialect = strings.TrimSpace(req.Dialect)
+	searcher.Features = req.Features
+	searcher.Memories = req.Memories
+	searcher.Description = req.Description
+
+	searcher.Contacts = make([]entity.FamilySearcherContact, len(req.Contacts))
+	for i, c := range req.Contacts {
+		searcher.Contacts[i] = entity.FamilySearcherContact{
+			Name:      strings.TrimSpace(c.Name),
+			Relation:  strings.TrimSpace(c.Relation),
+			Phone:     strings.TrimSpace(c.Phone),
+			WeChat:    strings.TrimSpace(c.WeChat),
+			IsPrimary: c.IsPrimary,
+			Note:      c.Note,
+		}
+	}
+
+	var linkedIDs []string
+	searcher.Relatives = make([]entity.FamilySearcherRelative, len(req.Relatives))
+	for i, r := range req.Relatives {
+		rel := entity.FamilySearcherRelative{
+			Name:      strings.TrimSpace(r.Name),
+			Relation:  strings.TrimSpace(r.Relation),
+			Gender:    r.Gender,
+			BirthYear: r.BirthYear,
+			Hometown:  strings.TrimSpace(r.Hometown),
+			Deceased:  r.Deceased,
+			Note:      r.Note,
+		}
+		if r.LinkedSearcherID != nil && *r.LinkedSearcherID != "" {
+			id := *r.LinkedSearcherID
+			rel.LinkedSearcherID = &id
+			linkedIDs = append(linkedIDs, id)
+		}
+		searcher.Relatives[i] = rel
+	}
+
+	if err := searcher.Validate(); err != nil {
+		return fmt.Errorf("%w: %s", ErrFamilySearcherInvalid, err.Error())
+	}
+
+	// 亲属指向的寻亲者必须存在
+	if len(linkedIDs) > 0 {
+		found, err := s.searcherRepo.FindByIDs(ctx, linkedIDs)
+		if err != nil {
+			return err
+		}
+		exists := make(map[string]bool, len(found))
+		for _, sr := range found {
+			exists[sr.ID] = true
+		}
+		for _, id := range linkedIDs {
+			if !exists[id] {
+				return fmt.Errorf("%w: 关联的寻亲者 %s 不存在", ErrFamilySearcherInvalid, id)
+			}
+		}
+	}
+	return nil
+}
+
+// audit 记录寻亲者操作审计日志
+func (s *FamilySearcherAppService) audit(ctx context.Context, operatorID, orgID string, action entity.AuditAction, searcher *entity.FamilySearcher, description string) {
+	if s.auditService == nil {
+		return
+	}
+	auditLog := entity.NewAuditLog(operatorID, orgID, action, string(entity.ResourceFamilySearcher)).
+		SetResourceID(searcher.ID).
+		SetResourceName(searcher.Name).
+		SetDescription(description).
+		AddExtra("kind", searcher.Kind).
+		AddExtra("status", searcher.Status)
+	s.auditService.Log(ctx, auditLog)
+}
@@ -1,15 +1,23 @@
 package service
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math"
 	"mime/multipart"
+	"path/filepath"
+	"strings"
 
 	"github.com/Snowitty-Re/CNtunyuan/internal/application/dto"
 	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
 	"github.com/Snowitty-Re/CNtunyuan/internal/domain/repository"
 	domainService "github.com/Snowitty-Re/CNtunyuan/internal/domain/service"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/audio"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/imaging"
 	"github.com/Snowitty-Re/CNtunyuan/pkg/logger"
 )
 
@@ -17,28 +25,43 @@ var (
 	ErrFileNotFound       = fmt.Errorf("file not found")
 	ErrFileTypeNotAllowed = fmt.Errorf("file type not allowed")
 	ErrFileTooLarge       = fmt.Errorf("file too large")
+	ErrInvalidImage       = fmt.Errorf("invalid image")
+	ErrVariantNotFound    = fmt.Errorf("file variant not found")
+	ErrInvalidAudio       = fmt.Errorf("invalid audio")
+	ErrAudioTooLong       = fmt.Errorf("audio too long")
 )
 
 // FileAppService 文件应用服务
 type FileAppService struct {
-	fileRepo       repository.FileRepository
-	storageService domainService.StorageService
-	maxFileSize    int64
-	allowedTypes   []string
+	fileRepo        repository.FileRepository
+	storageService  domainService.StorageService
+	originalStorage domainService.StorageService // 图片原图（含 EXIF/GPS），不对外公开
+	maxFileSize     int64
+	allowedTypes    []string
+	imagePipeline   *imaging.Pipeline
+	audioPipeline   *audio.Pipeline
 }
 
 // NewFileAppService 创建文件应用服务
+// originalStorage 保存图片原图，必须是不对外提供访问的存储
+// imagePipeline、audioPipeline 为空时通用上传接口将图片、音频按普通文件保存，不生成衍生版本
 func NewFileAppService(
 	fileRepo repository.FileRepository,
 	storageService domainService.StorageService,
+	originalStorage domainService.StorageService,
 	maxFileSize int64,
 	allowedTypes []string,
+	imagePipeline *imaging.Pipeline,
+	audioPipeline *audio.Pipeline,
 ) *FileAppService {
 	return &FileAppService{
-		fileRepo:       fileRepo,
-		storageService: storageService,
-		maxFileSize:    maxFileSize,
-		allowedTypes:   allowedTypes,
+		fileRepo:        fileRepo,
+		storageService:  storageService,
+		originalStorage: originalStorage,
+		maxFileSize:     maxFileSize,
+		allowedTypes:    allowedTypes,
+		imagePipeline:   imagePipeline,
+		audioPipeline:   audioPipeline,
 	}
 }
 
@@ -57,6 +80,42 @@ func (s *FileAppService) UploadFile(ctx context.Context, file multipart.File, he
 		return nil, ErrFileTooLarge
 	}
 
+	// 图片走处理流水线，无法解码的图片（如 HEIC）按普通文件保存
+	if s.imagePipeline != nil && isImageUpload(header) {
+		data, err := s.readAll(file)
+		if err != nil {
+			return nil, err
+		}
+		uploadedFile, err := s.saveImage(ctx, data, header, uploaderID)
+		if err == nil {
+			resp := dto.ToFileResponse(uploadedFile)
+			return &resp, nil
+		}
+		if err != ErrInvalidImage {
+			return nil, err
+		}
+		logger.Warn("Image processing skipped", logger.String("filename", header.Filename))
+		file = nopCloserFile{bytes.NewReader(data)}
+	}
+
+	// 音频探测真实格式和时长并转码，无法识别的音频按普通文件保存
+	if s.audioPipeline != nil && isAudioUpload(header) {
+		data, err := s.readAll(file)
+		if err != nil {
+			return nil, err
+		}
+		uploadedFile, err := s.saveAudio(ctx, data, header, uploaderID)
+		if err == nil {
+			resp := dto.ToFileResponse(uploadedFile)
+			return &resp, nil
+		}
+		if err != ErrInvalidAudio {
+			return nil, err
+		}
+		logger.Warn("Audio processing skipped", logger.String("filename", header.Filename))
+		file = nopCloserFile{bytes.NewReader(data)}
+	}
+
 	// 上传文件到存储
 	uploadedFile, err := s.storageService.Upload(ctx, file, header.Filename, header.Size, header.Header.Get("Content-Type"))
 	if err != nil {
@@ -86,6 +145,275 @@ func (s *FileAppService) UploadFile(ctx context.Context, file multipart.File, he
 	return &resp, nil
 }
 
+// UploadImage 上传图片：原图（含 EXIF）保存在非公开存储，仅内部下载；生成去除元数据的公开图、缩略图、
+// 带水印的分享图，并计算感知哈希；EXIF 中的拍摄时间和位置保存为线索参考
+func (s *FileAppService) UploadImage(ctx context.Context, file multipart.File, header *multipart.FileHeader, uploaderID string) (*entity.File, error) {
+	if s.maxFileSize > 0 && header.Size > s.maxFileSize {
+		return nil, ErrFileTooLarge
+	}
+	data, err := s.readAll(file)
+	if err != nil {
+		return nil, err
+	}
+	return s.saveImage(ctx, data, header, uploaderID)
+}
+
+// saveImage 处理并保存图片
+func (s *FileAppService) saveImage(ctx context.Context, data []byte, header *multipart.FileHeader, uploaderID string) (*entity.File, error) {
+	pipeline := s.imagePipeline
+	if pipeline == nil {
+		pipeline = imaging.NewPipeline(imaging.Options{})
+	}
+
+	result, err := pipeline.Process(data)
+	if err != nil {
+		logger.Warn("Failed to process image", logger.String("filename", header.Filename), logger.Err(err))
+		return nil, ErrInvalidImage
+	}
+
+	// 原图带有 GPS 等 EXIF 信息，不能放在公开存储中
+	original, err := s.originalStorage.Upload(ctx, bytes.NewReader(data), header.Filename, int64(len(data)), header.Header.Get("Content-Type"))
+	if err != nil {
+		logger.Error("Failed to upload image to storage", logger.Err(err))
+		return nil, err
+	}
+	var paths []string
+	rollback := func() {
+		if err := s.originalStorage.Delete(ctx, original.Path); err != nil {
+			logger.Warn("Failed to delete image original", logger.String("path", original.Path), logger.Err(err))
+		}
+		s.deletePaths(ctx, paths)
+	}
+
+	base := strings.TrimSuffix(header.Filename, filepath.Ext(header.Filename))
+	variants := make(map[string]entity.FileVariant, 3)
+	for name, content := range map[string][]byte{
+		entity.FileVariantPublic:    result.Public,
+		entity.FileVariantThumbnail: result.Thumbnail,
+		entity.FileVariantShare:     result.Share,
+	} {
+		f, err := s.storageService.Upload(ctx, bytes.NewReader(content), base+"_"+name+".jpg", int64(len(content)), "image/jpeg")
+		if err != nil {
+			rollback()
+			logger.Error("Failed to upload image variant", logger.String("variant", name), logger.Err(err))
+			return nil, err
+		}
+		paths = append(paths, f.Path)
+		variants[name] = entity.FileVariant{Path: f.Path, URL: f.URL}
+	}
+
+	// 对外展示使用去除元数据的公开版本，原图只能通过下载接口获取
+	original.URL = variants[entity.FileVariantPublic].URL
+	original.UploaderID = uploaderID
+	original.FileType = entity.FileTypeImage
+	original.Width = result.Width
+	original.Height = result.Height
+	original.PHash = imaging.FormatHash(result.PHash)
+	if meta := result.Metadata; meta != nil {
+		original.TakenAt = meta.TakenAt
+		if meta.HasGPS {
+			original.Lat, original.Lng = meta.Lat, meta.Lng
+		}
+	}
+	if err := original.SetVariants(variants); err != nil {
+		rollback()
+		return nil, err
+	}
+
+	if err := s.fileRepo.Create(ctx, original); err != nil {
+		rollback()
+		logger.Error("Failed to save image record to database", logger.Err(err))
+		return nil, err
+	}
+
+	logger.Info("Image processed",
+		logger.String("file_id", original.ID),
+		logger.String("phash", original.PHash),
+		logger.Int("width", result.Width),
+		logger.Int("height", result.Height),
+	)
+	return original, nil
+}
+
+// UploadAudio 上传音频：从内容探测真实格式和时长，响度归一化后转码为统一的 MP3 并生成波形；
+// 未部署 ffmpeg 时只保存原文件及探测结果。无法识别的音频返回 ErrInvalidAudio
+func (s *FileAppService) UploadAudio(ctx context.Context, file multipart.File, header *multipart.FileHeader, uploaderID string) (*entity.File, error) {
+	if s.maxFileSize > 0 && header.Size > s.maxFileSize {
+		return nil, ErrFileTooLarge
+	}
+	data, err := s.readAll(file)
+	if err != nil {
+		return nil, err
+	}
+	return s.saveAudio(ctx, data, header, uploaderID)
+}
+
+// saveAudio 处理并保存音频
+func (s *FileAppService) saveAudio(ctx context.Context, data []byte, header *multipart.FileHeader, uploaderID string) (*entity.File, error) {
+	pipeline := s.audioPipeline
+	if pipeline == nil {
+		pipeline = audio.NewPipeline(nil, audio.Options{})
+	}
+
+	var info *audio.Info
+	result, err := pipeline.Process(ctx, data)
+	switch {
+	case err == nil:
+		info = result.Source
+	case errors.Is(err, audio.ErrCodecUnavailable):
+		if info, err = audio.Probe(data); err != nil {
+			return nil, ErrInvalidAudio
+		}
+		logger.Warn("Audio transcoding unavailable, saving original only", logger.String("filename", header.Filename))
+	case errors.Is(err, audio.ErrTooLong):
+		return nil, ErrAudioTooLong
+	default:
+		logger.Warn("Failed to process audio", logger.String("filename", header.Filename), logger.Err(err))
+		return nil, ErrInvalidAudio
+	}
+
+	// 扩展名和 MIME 类型以探测结果为准
+	base := strings.TrimSuffix(header.Filename, filepath.Ext(header.Filename))
+	original, err := s.storageService.Upload(ctx, bytes.NewReader(data), base+info.Format.Ext(), int64(len(data)), info.Format.MimeType())
+	if err != nil {
+		logger.Error("Failed to upload audio to storage", logger.Err(err))
+		return nil, err
+	}
+	paths := []string{original.Path}
+
+	original.OriginalName = header.Filename
+	original.UploaderID = uploaderID
+	original.FileType = entity.FileTypeAudio
+	original.AudioFormat = string(info.Format)
+	original.DurationMs = int(info.Duration.Milliseconds())
+
+	if result != nil {
+		waveform, err := json.Marshal(result.Waveform)
+		if err != nil {
+			s.deletePaths(ctx, paths)
+			return nil, err
+		}
+		variants := make(map[string]entity.FileVariant, 2)
+		for _, v := range []struct {
+			name, filename, contentType string
+			content                     []byte
+		}{
+			{entity.FileVariantStream, base + "_stream" + result.Format.Ext(), result.Format.MimeType(), result.Audio},
+			{entity.FileVariantWaveform, base + "_waveform.json", "application/json", waveform},
+		} {
+			f, err := s.storageService.Upload(ctx, bytes.NewReader(v.content), v.filename, int64(len(v.content)), v.contentType)
+			if err != nil {
+				s.deletePaths(ctx, paths)
+				logger.Error("Failed to upload audio variant", logger.String("variant", v.name), logger.Err(err))
+				return nil, err
+			}
+			paths = append(paths, f.Path)
+			variants[v.name] = entity.FileVariant{Path: f.Path, URL: f.URL, Size: f.Size}
+		}
+
+		// 播放使用归一化后的流媒体版本，时长以转码结果为准
+		original.URL = variants[entity.FileVariantStream].URL
+		original.DurationMs = int(result.Duration.Milliseconds())
+		original.AudioFingerprint = result.Fingerprint.String()
+		if !math.IsInf(result.Loudness, 0) {
+			original.Loudness = math.Round(result.Loudness*10) / 10
+		}
+		if err := original.SetVariants(variants); err != nil {
+			s.deletePaths(ctx, paths)
+			return nil, err
+		}
+	}
+
+	if err := s.fileRepo.Create(ctx, original); err != nil {
+		s.deletePaths(ctx, paths)
+		logger.Error("Failed to save audio record to database", logger.Err(err))
+		return nil, err
+	}
+
+	logger.Info("Audio processed",
+		logger.String("file_id", original.ID),
+		logger.String("format", original.AudioFormat),
+		logger.Int("duration_ms", original.DurationMs),
+		logger.Bool("transcoded", result != nil),
+	)
+	return original, nil
+}
+
+// FindAudio 获取已完成探测的音频文件
+func (s *FileAppService) FindAudio(ctx context.Context, id string) (*entity.File, error) {
+	file, err := s.fileRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, ErrFileNotFound
+	}
+	if !file.IsAudio() || file.DurationMs <= 0 {
+		return nil, ErrInvalidAudio
+	}
+	return file, nil
+}
+
+// readAll 读取上传内容，超过大小限制时返回 ErrFileTooLarge
+func (s *FileAppService) readAll(file io.Reader) ([]byte, error) {
+	if s.maxFileSize <= 0 {
+		return io.ReadAll(file)
+	}
+	data, err := io.ReadAll(io.LimitReader(file, s.maxFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > s.maxFileSize {
+		return nil, ErrFileTooLarge
+	}
+	return data, nil
+}
+
+// storageOf 返回文件主体所在的存储：经流水线处理的图片原图保存在非公开存储中
+func (s *FileAppService) storageOf(file *entity.File) domainService.StorageService {
+	if file.IsImage() && file.VariantURL(entity.FileVariantPublic) != "" {
+		return s.originalStorage
+	}
+	return s.storageService
+}
+
+// deletePaths 删除存储中的文件，用于失败回滚
+func (s *FileAppService) deletePaths(ctx context.Context, paths []string) {
+	for _, path := range paths {
+		if err := s.storageService.Delete(ctx, path); err != nil {
+			logger.Warn("Failed to delete physical file", logger.String("path", path), logger.Err(err))
+		}
+	}
+}
+
+// isImageUpload 是否为可处理的图片
+func isImageUpload(header *multipart.FileHeader) bool {
+	if entity.DetectFileType(header.Header.Get("Content-Type")) == entity.FileTypeImage {
+		return true
+	}
+	switch strings.ToLower(filepath.Ext(header.Filename)) {
+	case ".jpg", ".jpeg", ".png", ".gif":
+		return true
+	}
+	return false
+}
+
+// isAudioUpload 是否为可处理的音频
+func isAudioUpload(header *multipart.FileHeader) bool {
+	if entity.DetectFileType(header.Header.Get("Content-Type")) == entity.FileTypeAudio {
+		return true
+	}
+	switch strings.ToLower(filepath.Ext(header.Filename)) {
+	case ".wav", ".mp3", ".aac", ".m4a", ".amr", ".awb":
+		return true
+	}
+	return false
+}
+
+// nopCloserFile 将内存数据包装为 multipart.File
+type nopCloserFile struct {
+	*bytes.Reader
+}
+
+func (nopCloserFile) Close() error { return nil }
+
 // UploadFiles 批量上传文件
 func (s *FileAppService) UploadFiles(ctx context.Context, files []multipart.File, headers []*multipart.FileHeader, uploaderID string) ([]dto.FileResponse, error) {
 	responses := make([]dto.FileResponse, 0, len(files))
@@ -119,7 +447,7 @@ func (s *FileAppService) GetFile(ctx context.Context, id string) (io.ReadCloser,
 		return nil, nil, ErrFileNotFound
 	}
 
-	reader, err := s.storageService.Download(ctx, file.Path)
+	reader, err := s.storageOf(file).Download(ctx, file.Path)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -127,6 +455,20 @@ func (s *FileAppService) GetFile(ctx context.Context, id string) (io.ReadCloser,
 	return reader, file, nil
 }
 
+// GetVariant 获取文件衍生版本内容
+func (s *FileAppService) GetVariant(ctx context.Context, id string, name string) (io.ReadCloser, error) {
+	file, err := s.fileRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, ErrFileNotFound
+	}
+
+	variant, ok := file.GetVariants()[name]
+	if !ok || variant.Path == "" {
+		return nil, ErrVariantNotFound
+	}
+	return s.storageService.Download(ctx, variant.Path)
+}
+
 // List 文件列表
 func (s *FileAppService) List(ctx context.Context, req *dto.FileListRequest) (*dto.FileListResponse, error) {
 	query := repository.NewFileQuery()
@@ -165,10 +507,15 @@ func (s *FileAppService) Delete(ctx context.Context, id string) error {
 		return err
 	}
 
-	// 删除物理文件
-	if err := s.storageService.Delete(ctx, file.Path); err != nil {
+	// 删除物理文件（含图片衍生版本）
+	if err := s.storageOf(file).Delete(ctx, file.Path); err != nil {
 		logger.Warn("Failed to delete physical file", logger.String("path", file.Path), logger.Err(err))
 	}
+	var paths []string
+	for _, v := range file.GetVariants() {
+		paths = append(paths, v.Path)
+	}
+	s.deletePaths(ctx, paths)
 
 	return nil
 }
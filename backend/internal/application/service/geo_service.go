@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/dto"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/repository"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/geo"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/logger"
+)
+
+var (
+	ErrInvalidCoordinate = errors.New("invalid coordinate")
+	ErrInvalidBounds     = errors.New("invalid bounds")
+)
+
+// GeoAppService 地理空间查询应用服务
+type GeoAppService struct {
+	geoRepo repository.GeoRepository
+}
+
+// NewGeoAppService 创建地理空间查询应用服务
+func NewGeoAppService(geoRepo repository.GeoRepository) *GeoAppService {
+	return &GeoAppService{geoRepo: geoRepo}
+}
+
+// NearbyTracks 查询某点半径范围内的线索
+func (s *GeoAppService) NearbyTracks(ctx context.Context, req *dto.NearbyTracksRequest) ([]dto.NearbyTrackResponse, error) {
+	if !geo.ValidCoordinate(req.Lat, req.Lng) {
+		return nil, ErrInvalidCoordinate
+	}
+
+	list, err := s.geoRepo.FindTracksWithinRadius(ctx, &repository.GeoRadiusQuery{
+		Lat:             req.Lat,
+		Lng:             req.Lng,
+		RadiusKm:        req.RadiusKm,
+		MissingPersonID: req.MissingPersonID,
+		Status:          req.Status,
+		Since:           req.Since,
+		Limit:           req.Limit,
+	})
+	if err != nil {
+		logger.Error("Failed to query nearby tracks", logger.Err(err))
+		return nil, err
+	}
+
+	result := make([]dto.NearbyTrackResponse, len(list))
+	for i := range list {
+		result[i] = dto.NearbyTrackResponse{
+			MissingPersonTrackResponse: dto.ToMissingPersonTrackResponse(&list[i].Track),
+			DistanceKm:                 list[i].DistanceKm,
+		}
+	}
+	return result, nil
+}
+
+// MapMarkers 查询地图视野内的案件与线索
+func (s *GeoAppService) MapMarkers(ctx context.Context, req *dto.MapBoundsRequest) (*dto.MapMarkersResponse, error) {
+	bounds := geo.BBox{MinLat: req.MinLat, MinLng: req.MinLng, MaxLat: req.MaxLat, MaxLng: req.MaxLng}
+	if !bounds.Valid() {
+		return nil, ErrInvalidBounds
+	}
+
+	query := &repository.GeoBoundsQuery{
+		Bounds:          bounds,
+		MissingPersonID: req.MissingPersonID,
+		Status:          req.Status,
+		ActiveOnly:      req.ActiveOnly,
+		Since:           req.Since,
+		Limit:           req.Limit,
+	}
+
+	resp := &dto.MapMarkersResponse{
+		Cases:  []dto.MissingPersonResponse{},
+		Tracks: []dto.MissingPersonTrackResponse{},
+		Mode:   "geohash",
+	}
+	if s.geoRepo.SupportsPostGIS() {
+		resp.Mode = "postgis"
+	}
+
+	// 指定案件时只返回该案件的线索
+	if req.MissingPersonID == "" {
+		cases, err := s.geoRepo.FindCasesInBounds(ctx, query)
+		if err != nil {
+			logger.Error("Failed to query cases in bounds", logger.Err(err))
+			return nil, err
+		}
+		for i := range cases {
+			resp.Cases = append(resp.Cases, dto.ToMissingPersonResponse(&cases[i]))
+		}
+	}
+
+	// 案件状态筛选不作用于线索
+	query.Status = ""
+	tracks, err := s.geoRepo.FindTracksInBounds(ctx, query)
+	if err != nil {
+		logger.Error("Failed to query tracks in bounds", logger.Err(err))
+		return nil, err
+	}
+	for i := range tracks {
+		resp.Tracks = append(resp.Tracks, dto.ToMissingPersonTrackResponse(&tracks[i]))
+	}
+
+	return resp, nil
+}
+
+// NearestActiveCases 查询距离某点最近的活跃案件
+func (s *GeoAppService) NearestActiveCases(ctx context.Context, req *dto.NearestCasesRequest) ([]dto.NearestCaseResponse, error) {
+	if !geo.ValidCoordinate(req.Lat, req.Lng) {
+		return nil, ErrInvalidCoordinate
+	}
+
+	list, err := s.geoRepo.FindNearestActiveCases(ctx, &repository.GeoRadiusQuery{
+		Lat:      req.Lat,
+		Lng:      req.Lng,
+		RadiusKm: req.RadiusKm,
+		Limit:    req.Limit,
+	})
+	if err != nil {
+		logger.Error("Failed to query nearest cases", logger.Err(err))
+		return nil, err
+	}
+
+	result := make([]dto.NearestCaseResponse, len(list))
+	for i := range list {
+		result[i] = dto.NearestCaseResponse{
+			MissingPersonResponse: dto.ToMissingPersonResponse(&list[i].Person),
+			DistanceKm:            list[i].DistanceKm,
+			NearestTrackID:        list[i].NearestTrackID,
+		}
+	}
+	return result, nil
+}
+
+// BackfillGeohash 为历史数据补齐 geohash
+func (s *GeoAppService) BackfillGeohash(ctx context.Context) (int64, error) {
+	return s.geoRepo.BackfillGeohash(ctx)
+}
@@ -0,0 +1,296 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/geo"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/hanzi"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/spreadsheet"
+)
+
+// importField 可导入字段，Label 为模板表头，Aliases 用于自动识别合作机构表格的列名
+type importField struct {
+	Key      string
+	Label    string
+	Required bool
+	Aliases  []string
+	Example  string
+}
+
+// importFields 可导入字段，顺序即模板列顺序
+var importFields = []importField{
+	{Key: "name", Label: "姓名", Required: true, Aliases: []string{"名字", "走失者姓名", "失踪人姓名", "name"}, Example: "张三"},
+	{Key: "gender", Label: "性别", Required: true, Aliases: []string{"sex", "gender"}, Example: "男"},
+	{Key: "birth_date", Label: "出生日期", Aliases: []string{"生日", "birthday", "birth_date"}, Example: "2016-03-12"},
+	{Key: "age", Label: "年龄", Aliases: []string{"走失时年龄", "age"}, Example: "8"},
+	{Key: "height", Label: "身高", Aliases: []string{"身高cm", "height"}, Example: "130"},
+	{Key: "weight", Label: "体重", Aliases: []string{"体重kg", "weight"}, Example: "25"},
+	{Key: "missing_time", Label: "走失时间", Required: true, Aliases: []string{"失踪时间", "走失日期", "失踪日期", "missing_time", "missing_date"}, Example: "2024-05-01 14:00"},
+	{Key: "province", Label: "省份", Aliases: []string{"省", "province"}, Example: "广东省"},
+	{Key: "city", Label: "城市", Aliases: []string{"市", "city"}, Example: "深圳市"},
+	{Key: "district", Label: "区县", Aliases: []string{"区", "县", "district"}, Example: "南山区"},
+	{Key: "address", Label: "走失地点", Aliases: []string{"详细地址", "走失地址", "失踪地点", "地址", "address"}, Example: "科技园地铁站附近"},
+	{Key: "lat", Label: "纬度", Aliases: []string{"lat", "latitude"}},
+	{Key: "lng", Label: "经度", Aliases: []string{"lng", "lon", "longitude"}},
+	{Key: "clothes", Label: "衣着", Aliases: []string{"穿着", "衣着特征", "clothes"}, Example: "蓝色校服，白色运动鞋"},
+	{Key: "features", Label: "体貌特征", Aliases: []string{"特征", "外貌特征", "features"}, Example: "左眉有痣"},
+	{Key: "description", Label: "补充说明", Aliases: []string{"描述", "备注", "情况说明", "description"}},
+	{Key: "contact_name", Label: "联系人", Required: true, Aliases: []string{"联系人姓名", "报案人", "contact_name"}, Example: "李四"},
+	{Key: "contact_phone", Label: "联系电话", Required: true, Aliases: []string{"联系人电话", "电话", "手机", "手机号", "contact_phone"}, Example: "13800000000"},
+	{Key: "contact_rel", Label: "与走失者关系", Aliases: []string{"关系", "contact_rel"}, Example: "父亲"},
+	{Key: "alt_contact", Label: "备用联系方式", Aliases: []string{"备用电话", "alt_contact"}},
+	{Key: "urgency", Label: "紧急程度", Aliases: []string{"urgency", "urgency_level"}, Example: "高"},
+}
+
+// importTimeLayouts 可识别的日期时间格式
+var importTimeLayouts = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02T15:04:05Z07:00",
+	"2006-01-02",
+	"2006/01/02 15:04:05",
+	"2006/01/02 15:04",
+	"2006/01/02",
+	"2006/1/2 15:04:05",
+	"2006/1/2 15:04",
+	"2006/1/2",
+	"2006-1-2 15:04",
+	"2006-1-2",
+	"2006.1.2",
+	"2006年1月2日 15:04",
+	"2006年1月2日15时04分",
+	"2006年1月2日",
+	"20060102",
+}
+
+// normalizeHeader 列名归一化：去掉空白、括号中的单位和星号，英文转小写
+func normalizeHeader(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for _, pair := range [][2]string{{"(", ")"}, {"（", "）"}} {
+		if i := strings.Index(s, pair[0]); i >= 0 {
+			if j := strings.Index(s[i:], pair[1]); j >= 0 {
+				s = s[:i] + s[i+j+len(pair[1]):]
+			}
+		}
+	}
+	return strings.NewReplacer(" ", "", "\t", "", "*", "", "_", "", "-", "").Replace(s)
+}
+
+// resolveMapping 合并显式映射与自动识别，返回字段 -> 列序号；显式映射的列名不存在时返回错误
+func resolveMapping(header []string, explicit map[string]string) (map[string]int, error) {
+	columns := make(map[string]int, len(header))
+	for i, h := range header {
+		key := normalizeHeader(h)
+		if _, ok := columns[key]; !ok && key != "" {
+			columns[key] = i
+		}
+	}
+
+	known := make(map[string]bool, len(importFields))
+	for _, f := range importFields {
+		known[f.Key] = true
+	}
+
+	result := make(map[string]int)
+	for field, column := range explicit {
+		if !known[field] {
+			return nil, fmt.Errorf("%w: 未知字段 %s", ErrImportMapping, field)
+		}
+		if strings.TrimSpace(column) == "" {
+			continue
+		}
+		i, ok := columns[normalizeHeader(column)]
+		if !ok {
+			return nil, fmt.Errorf("%w: 表格中没有列 %s", ErrImportMapping, column)
+		}
+		result[field] = i
+	}
+
+	for _, f := range importFields {
+		if _, ok := result[f.Key]; ok {
+			continue
+		}
+		if _, ok := explicit[f.Key]; ok {
+			// 显式映射为空表示不导入该字段
+			continue
+		}
+		for _, name := range append([]string{f.Label}, f.Aliases...) {
+			if i, ok := columns[normalizeHeader(name)]; ok {
+				result[f.Key] = i
+				break
+			}
+		}
+	}
+
+	var missing []string
+	for _, f := range importFields {
+		if _, ok := result[f.Key]; f.Required && !ok {
+			missing = append(missing, f.Label)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: 缺少必填列 %s", ErrImportMapping, strings.Join(missing, "、"))
+	}
+	return result, nil
+}
+
+// mappingNames 字段 -> 列名，用于保存到任务
+func mappingNames(header []string, mapping map[string]int) map[string]string {
+	names := make(map[string]string, len(mapping))
+	for field, i := range mapping {
+		names[field] = header[i]
+	}
+	return names
+}
+
+// parseImportRow 将一行转换为案件，返回的错误信息用于错误报告
+func parseImportRow(row []string, mapping map[string]int, reporterID, orgID string) (*entity.MissingPerson, []string) {
+	get := func(field string) string {
+		i, ok := mapping[field]
+		if !ok {
+			return ""
+		}
+		return spreadsheet.Cell(row, i)
+	}
+
+	var errs []string
+	mp := &entity.MissingPerson{
+		Name:         get("name"),
+		Province:     get("province"),
+		City:         get("city"),
+		District:     get("district"),
+		Address:      get("address"),
+		Clothes:      get("clothes"),
+		Features:     get("features"),
+		Description:  get("description"),
+		ContactName:  get("contact_name"),
+		ContactPhone: get("contact_phone"),
+		ContactRel:   get("contact_rel"),
+		AltContact:   get("alt_contact"),
+		ReporterID:   reporterID,
+		OrgID:        orgID,
+		Status:       entity.MissingStatusMissing,
+		Urgency:      entity.UrgencyLevelMedium,
+	}
+
+	if v := get("gender"); v != "" {
+		gender, ok := parseGender(v)
+		if !ok {
+			errs = append(errs, fmt.Sprintf("性别无法识别：%s", v))
+		}
+		mp.Gender = gender
+	}
+
+	if v := get("missing_time"); v != "" {
+		t, ok := parseImportTime(v)
+		switch {
+		case !ok:
+			errs = append(errs, fmt.Sprintf("走失时间格式错误：%s", v))
+		case t.After(time.Now()):
+			errs = append(errs, "走失时间晚于当前时间")
+		default:
+			mp.MissingTime = t
+		}
+	}
+	if v := get("birth_date"); v != "" {
+		if t, ok := parseImportTime(v); ok {
+			mp.BirthDate = &t
+		} else {
+			errs = append(errs, fmt.Sprintf("出生日期格式错误：%s", v))
+		}
+	}
+
+	for _, n := range []struct {
+		field, label, unit string
+		max                int
+		dst                *int
+	}{
+		{"age", "年龄", "岁", 150, &mp.Age},
+		{"height", "身高", "cm", 300, &mp.Height},
+		{"weight", "体重", "kg", 500, &mp.Weight},
+	} {
+		v := get(n.field)
+		if v == "" {
+			continue
+		}
+		num, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(strings.ToLower(v), n.unit)), 64)
+		if err != nil || num < 0 || num > float64(n.max) {
+			errs = append(errs, fmt.Sprintf("%s无效：%s", n.label, v))
+			continue
+		}
+		*n.dst = int(num + 0.5)
+	}
+	if mp.Age == 0 && mp.BirthDate != nil && !mp.MissingTime.IsZero() {
+		mp.Age = mp.GetAgeAtMissing()
+	}
+
+	if lat, lng := get("lat"), get("lng"); lat != "" || lng != "" {
+		la, err1 := strconv.ParseFloat(lat, 64)
+		ln, err2 := strconv.ParseFloat(lng, 64)
+		if err1 != nil || err2 != nil || !geo.ValidCoordinate(la, ln) {
+			errs = append(errs, fmt.Sprintf("经纬度无效：%s, %s", lat, lng))
+		} else {
+			mp.Lat, mp.Lng = la, ln
+		}
+	}
+
+	if v := get("urgency"); v != "" {
+		urgency, ok := parseUrgency(v)
+		if !ok {
+			errs = append(errs, fmt.Sprintf("紧急程度无法识别：%s", v))
+		} else {
+			mp.Urgency = urgency
+		}
+	}
+
+	if err := mp.Validate(); err != nil {
+		errs = append(errs, err.Error())
+	}
+	mp.NamePinyin = hanzi.ToPinyin(hanzi.NormalizeName(mp.Name))
+	return mp, errs
+}
+
+// parseImportTime 解析日期时间，支持常见文本格式和 Excel 日期序列号
+func parseImportTime(v string) (time.Time, bool) {
+	v = strings.TrimSpace(v)
+	for _, layout := range importTimeLayouts {
+		if t, err := time.ParseInLocation(layout, v, time.Local); err == nil {
+			return t, true
+		}
+	}
+	// Excel 日期单元格（1927-05-18 至 2173-10-14）
+	if serial, err := strconv.ParseFloat(v, 64); err == nil && serial >= 10000 && serial < 100000 {
+		return spreadsheet.ExcelTime(serial, time.Local), true
+	}
+	return time.Time{}, false
+}
+
+// parseGender 性别统一为 male/female
+func parseGender(v string) (string, bool) {
+	switch strings.ToLower(strings.TrimSpace(v)) {
+	case "男", "男性", "male", "m":
+		return "male", true
+	case "女", "女性", "female", "f":
+		return "female", true
+	}
+	return v, false
+}
+
+// parseUrgency 紧急程度支持中英文
+func parseUrgency(v string) (entity.UrgencyLevel, bool) {
+	switch strings.ToLower(strings.TrimSpace(v)) {
+	case "紧急", "特急", string(entity.UrgencyLevelCritical):
+		return entity.UrgencyLevelCritical, true
+	case "高", string(entity.UrgencyLevelHigh):
+		return entity.UrgencyLevelHigh, true
+	case "中", "一般", string(entity.UrgencyLevelMedium):
+		return entity.UrgencyLevelMedium, true
+	case "低", string(entity.UrgencyLevelLow):
+		return entity.UrgencyLevelLow, true
+	}
+	return "", false
+}
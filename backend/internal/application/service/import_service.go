@@ -0,0 +1,586 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"strings"
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/dto"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/repository"
+	domainService "github.com/Snowitty-Re/CNtunyuan/internal/domain/service"
+	"github.com/Snowitty-Re/CNtunyuan/internal/infrastructure/websocket"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/logger"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/spreadsheet"
+)
+
+var (
+	ErrImportNotFound         = errors.New("import job not found")
+	ErrImportTemplateNotFound = errors.New("import template not found")
+	ErrImportMapping          = errors.New("invalid column mapping")
+	ErrImportFile             = errors.New("invalid import file")
+	ErrImportTooManyRows      = errors.New("too many rows")
+	ErrImportNotConfirmable   = errors.New("import job cannot be confirmed")
+	ErrImportNotFinished      = errors.New("import job not finished")
+)
+
+const (
+	// MaxImportFileSize 导入文件大小上限
+	MaxImportFileSize = 10 << 20
+	// MaxImportRows 单次导入行数上限
+	MaxImportRows = 5000
+
+	importConcurrency     = 2                      // 同时执行的导入任务数
+	importProgressEvery   = 500 * time.Millisecond // 进度上报间隔
+	importCaseNoAttempts  = 3                      // 案件编号冲突时的重试次数
+	importDuplicateReason = "疑似重复"
+)
+
+// ImportAppService 案件批量导入应用服务
+// 合作机构的 Excel/CSV 表格按列映射逐行转换为案件，经 MissingPerson.Validate 校验并与已有案件、
+// 同一文件中的前序行查重；任务在后台执行，进度通过 WebSocket 推送给发起人。
+// 预检（dry run）只校验不入库，确认后以相同文件和映射生成正式导入任务。
+type ImportAppService struct {
+	importRepo     repository.ImportRepository
+	mpRepo         repository.MissingPersonRepository
+	storageService domainService.StorageService
+	wsManager      *websocket.Manager
+	searchService  *CaseSearchAppService
+	matcher        *domainService.DuplicateMatcher
+	slots          chan struct{}
+}
+
+// NewImportAppService 创建批量导入应用服务
+func NewImportAppService(
+	importRepo repository.ImportRepository,
+	mpRepo repository.MissingPersonRepository,
+	storageService domainService.StorageService,
+	wsManager *websocket.Manager,
+	searchService *CaseSearchAppService,
+) *ImportAppService {
+	return &ImportAppService{
+		importRepo:     importRepo,
+		mpRepo:         mpRepo,
+		storageService: storageService,
+		searchService:  searchService,
+		wsManager:      wsManager,
+		matcher:        domainService.NewDuplicateMatcher(domainService.DefaultDuplicateThreshold),
+		slots:          make(chan struct{}, importConcurrency),
+	}
+}
+
+// Fields 可导入字段
+func (s *ImportAppService) Fields() []dto.ImportFieldResponse {
+	list := make([]dto.ImportFieldResponse, len(importFields))
+	for i, f := range importFields {
+		list[i] = dto.ImportFieldResponse{
+			Key:      f.Key,
+			Label:    f.Label,
+			Required: f.Required,
+			Aliases:  f.Aliases,
+			Example:  f.Example,
+		}
+	}
+	return list
+}
+
+// Template 导入模板（带 BOM 的 UTF-8 CSV，Excel 可直接打开）
+func (s *ImportAppService) Template() []byte {
+	header := make([]string, len(importFields))
+	example := make([]string, len(importFields))
+	for i, f := range importFields {
+		header[i] = f.Label
+		if f.Required {
+			header[i] += "*"
+		}
+		example[i] = f.Example
+	}
+	return writeCSV([][]string{header, example})
+}
+
+// Create 上传表格并创建导入任务，任务在后台执行
+func (s *ImportAppService) Create(ctx context.Context, file multipart.File, header *multipart.FileHeader, req *dto.CreateImportRequest, creatorID, orgID string) (*dto.ImportJobResponse, error) {
+	if header.Size > MaxImportFileSize {
+		return nil, ErrFileTooLarge
+	}
+	data, err := io.ReadAll(io.LimitReader(file, MaxImportFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > MaxImportFileSize {
+		return nil, ErrFileTooLarge
+	}
+
+	sheet, err := readImportSheet(header.Filename, data)
+	if err != nil {
+		return nil, err
+	}
+
+	explicit := make(map[string]string)
+	if req.TemplateID != "" {
+		template, err := s.importRepo.FindTemplateByID(ctx, req.TemplateID)
+		if err != nil || template.OrgID != orgID {
+			return nil, ErrImportTemplateNotFound
+		}
+		explicit = template.GetMapping()
+	}
+	if req.Mapping != "" {
+		var m map[string]string
+		if err := json.Unmarshal([]byte(req.Mapping), &m); err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrImportMapping, err.Error())
+		}
+		for k, v := range m {
+			explicit[k] = v
+		}
+	}
+	mapping, err := resolveMapping(sheet.Header(), explicit)
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := s.storageService.Upload(ctx, bytes.NewReader(data), header.Filename, int64(len(data)), header.Header.Get("Content-Type"))
+	if err != nil {
+		logger.Error("Failed to store import file", logger.Err(err))
+		return nil, err
+	}
+
+	job := &entity.ImportJob{
+		OrgID:          orgID,
+		CreatorID:      creatorID,
+		FileName:       header.Filename,
+		FilePath:       stored.Path,
+		Format:         sheet.Format,
+		Status:         entity.ImportStatusPending,
+		DryRun:         req.DryRun,
+		SkipDuplicates: req.SkipDuplicates == nil || *req.SkipDuplicates,
+		TotalRows:      len(sheet.Records()),
+	}
+	job.SetMapping(mappingNames(sheet.Header(), mapping))
+	job.SetHeader(sheet.Header())
+
+	if err := s.importRepo.Create(ctx, job); err != nil {
+		s.storageService.Delete(ctx, stored.Path)
+		return nil, err
+	}
+
+	logger.Info("Import job created",
+		logger.String("job_id", job.ID),
+		logger.String("file", job.FileName),
+		logger.Int("rows", job.TotalRows),
+		logger.String("dry_run", fmt.Sprint(job.DryRun)),
+	)
+
+	// 先生成响应，后台任务启动后会并发修改 job
+	resp := dto.ToImportJobResponse(job, false)
+	go s.run(job, sheet, mapping)
+
+	return &resp, nil
+}
+
+// Confirm 确认预检任务，以相同文件和映射创建正式导入任务
+func (s *ImportAppService) Confirm(ctx context.Context, id string, creatorID, orgID string) (*dto.ImportJobResponse, error) {
+	source, err := s.findJob(ctx, id, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if !source.CanConfirm() {
+		return nil, ErrImportNotConfirmable
+	}
+
+	sheet, mapping, err := s.loadSheet(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+
+	job := &entity.ImportJob{
+		OrgID:          source.OrgID,
+		CreatorID:      creatorID,
+		FileName:       source.FileName,
+		FilePath:       source.FilePath,
+		Format:         source.Format,
+		Status:         entity.ImportStatusPending,
+		SkipDuplicates: source.SkipDuplicates,
+		SourceJobID:    &source.ID,
+		Mapping:        source.Mapping,
+		Header:         source.Header,
+		TotalRows:      len(sheet.Records()),
+	}
+	if err := s.importRepo.Create(ctx, job); err != nil {
+		return nil, err
+	}
+
+	logger.Info("Import job confirmed", logger.String("job_id", job.ID), logger.String("source_job_id", source.ID))
+
+	// 先生成响应，后台任务启动后会并发修改 job
+	resp := dto.ToImportJobResponse(job, false)
+	go s.run(job, sheet, mapping)
+
+	return &resp, nil
+}
+
+// Get 导入任务详情（含逐行结果，预检任务即为预览）
+func (s *ImportAppService) Get(ctx context.Context, id string, orgID string) (*dto.ImportJobResponse, error) {
+	job, err := s.findJob(ctx, id, orgID)
+	if err != nil {
+		return nil, err
+	}
+	resp := dto.ToImportJobResponse(job, true)
+	return &resp, nil
+}
+
+// List 导入任务列表
+func (s *ImportAppService) List(ctx context.Context, req *dto.ImportJobListRequest, orgID string) (*dto.ImportJobListResponse, error) {
+	result, err := s.importRepo.List(ctx, orgID, repository.Pagination{Page: req.Page, PageSize: req.PageSize})
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]dto.ImportJobResponse, len(result.List))
+	for i := range result.List {
+		list[i] = dto.ToImportJobResponse(&result.List[i], false)
+	}
+	resp := dto.NewImportJobListResponse(list, result.Total, result.Page, result.PageSize)
+	return &resp, nil
+}
+
+// ErrorReport 错误报告：失败和重复的行及原因，附原始单元格（带 BOM 的 UTF-8 CSV）
+func (s *ImportAppService) ErrorReport(ctx context.Context, id string, orgID string) ([]byte, string, error) {
+	job, err := s.findJob(ctx, id, orgID)
+	if err != nil {
+		return nil, "", err
+	}
+	if !job.IsFinished() {
+		return nil, "", ErrImportNotFinished
+	}
+
+	rows := [][]string{append([]string{"行号", "处理结果", "原因"}, job.GetHeader()...)}
+	for _, r := range job.GetResults() {
+		if r.Status != entity.ImportRowFailed && r.Status != entity.ImportRowDuplicate {
+			continue
+		}
+		reason := strings.Join(r.Errors, "；")
+		if len(r.Duplicates) > 0 {
+			reason = importDuplicateReason + "：" + duplicateText(r.Duplicates)
+		}
+		status := "校验失败"
+		if r.Status == entity.ImportRowDuplicate {
+			status = "已跳过"
+		}
+		rows = append(rows, append([]string{fmt.Sprint(r.Row), status, reason}, r.Values...))
+	}
+
+	name := strings.TrimSuffix(job.FileName, "."+job.Format) + "_errors.csv"
+	return writeCSV(rows), name, nil
+}
+
+// CreateTemplate 保存列映射模板
+func (s *ImportAppService) CreateTemplate(ctx context.Context, req *dto.CreateImportTemplateRequest, creatorID, orgID string) (*dto.ImportTemplateResponse, error) {
+	known := make(map[string]bool, len(importFields))
+	for _, f := range importFields {
+		known[f.Key] = true
+	}
+	for field := range req.Mapping {
+		if !known[field] {
+			return nil, fmt.Errorf("%w: 未知字段 %s", ErrImportMapping, field)
+		}
+	}
+
+	template := &entity.ImportTemplate{
+		OrgID:     orgID,
+		CreatorID: creatorID,
+		Name:      strings.TrimSpace(req.Name),
+	}
+	template.SetMapping(req.Mapping)
+	if err := s.importRepo.CreateTemplate(ctx, template); err != nil {
+		return nil, err
+	}
+
+	resp := dto.ToImportTemplateResponse(template)
+	return &resp, nil
+}
+
+// ListTemplates 组织的列映射模板
+func (s *ImportAppService) ListTemplates(ctx context.Context, orgID string) ([]dto.ImportTemplateResponse, error) {
+	templates, err := s.importRepo.ListTemplates(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]dto.ImportTemplateResponse, len(templates))
+	for i := range templates {
+		list[i] = dto.ToImportTemplateResponse(&templates[i])
+	}
+	return list, nil
+}
+
+// DeleteTemplate 删除列映射模板
+func (s *ImportAppService) DeleteTemplate(ctx context.Context, id string, orgID string) error {
+	template, err := s.importRepo.FindTemplateByID(ctx, id)
+	if err != nil || template.OrgID != orgID {
+		return ErrImportTemplateNotFound
+	}
+	return s.importRepo.DeleteTemplate(ctx, id)
+}
+
+// RecoverInterrupted 服务启动时将上次未执行完的任务标记为失败
+func (s *ImportAppService) RecoverInterrupted(ctx context.Context) {
+	jobs, err := s.importRepo.FindRunning(ctx)
+	if err != nil {
+		logger.Warn("Failed to find interrupted import jobs", logger.Err(err))
+		return
+	}
+	for i := range jobs {
+		job := &jobs[i]
+		job.Finish(errors.New("服务重启，任务中断，请重新导入"))
+		if err := s.importRepo.Update(ctx, job); err != nil {
+			logger.Warn("Failed to mark interrupted import job", logger.String("job_id", job.ID), logger.Err(err))
+		}
+	}
+}
+
+// run 后台逐行执行导入任务
+func (s *ImportAppService) run(job *entity.ImportJob, sheet *spreadsheet.Sheet, mapping map[string]int) {
+	s.slots <- struct{}{}
+	defer func() { <-s.slots }()
+
+	ctx := context.Background()
+	var results []entity.ImportRowResult
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error("Import job panicked", logger.String("job_id", job.ID), logger.String("panic", fmt.Sprint(r)))
+			job.SetResults(results)
+			job.Finish(fmt.Errorf("内部错误：%v", r))
+			s.importRepo.Update(ctx, job)
+			s.notify(job)
+		}
+	}()
+
+	job.Start()
+	if err := s.importRepo.UpdateProgress(ctx, job); err != nil {
+		logger.Warn("Failed to update import progress", logger.String("job_id", job.ID), logger.Err(err))
+	}
+	s.notify(job)
+
+	header := sheet.Header()
+	seen := make(map[string][]importedRow) // 姓名拼音 -> 已通过校验的前序行
+	lastNotify := time.Now()
+
+	for i, row := range sheet.Records() {
+		if spreadsheet.IsBlank(row) {
+			job.TotalRows--
+			continue
+		}
+		result := s.processRow(ctx, job, row, i+2, mapping, seen)
+		if result.Status == entity.ImportRowFailed || result.Status == entity.ImportRowDuplicate {
+			result.Values = padRow(row, len(header))
+		}
+		results = append(results, result)
+		job.RecordRow(result.Status)
+
+		if time.Since(lastNotify) >= importProgressEvery {
+			lastNotify = time.Now()
+			if err := s.importRepo.UpdateProgress(ctx, job); err != nil {
+				logger.Warn("Failed to update import progress", logger.String("job_id", job.ID), logger.Err(err))
+			}
+			s.notify(job)
+		}
+	}
+
+	job.SetResults(results)
+	job.Finish(nil)
+	if err := s.importRepo.Update(ctx, job); err != nil {
+		logger.Error("Failed to save import job", logger.String("job_id", job.ID), logger.Err(err))
+	}
+	s.notify(job)
+
+	logger.Info("Import job finished",
+		logger.String("job_id", job.ID),
+		logger.Int("created", job.CreatedRows),
+		logger.Int("valid", job.ValidRows),
+		logger.Int("failed", job.FailedRows),
+		logger.Int("duplicates", job.DuplicateRows),
+	)
+}
+
+// importedRow 已通过校验的行，用于同一文件内查重
+type importedRow struct {
+	row    int
+	person *entity.MissingPerson
+}
+
+// processRow 校验、查重并（非预检时）创建案件
+func (s *ImportAppService) processRow(ctx context.Context, job *entity.ImportJob, row []string, rowNo int, mapping map[string]int, seen map[string][]importedRow) entity.ImportRowResult {
+	mp, errs := parseImportRow(row, mapping, job.CreatorID, job.OrgID)
+	result := entity.ImportRowResult{Row: rowNo, Name: mp.Name}
+	if len(errs) > 0 {
+		result.Status = entity.ImportRowFailed
+		result.Errors = errs
+		return result
+	}
+
+	result.Duplicates = s.findDuplicates(ctx, mp, seen[mp.NamePinyin])
+	seen[mp.NamePinyin] = append(seen[mp.NamePinyin], importedRow{row: rowNo, person: mp})
+	if len(result.Duplicates) > 0 && job.SkipDuplicates {
+		result.Status = entity.ImportRowDuplicate
+		return result
+	}
+
+	if job.DryRun {
+		result.Status = entity.ImportRowValid
+		return result
+	}
+
+	if err := s.createCase(ctx, mp); err != nil {
+		logger.Warn("Failed to create imported case", logger.String("job_id", job.ID), logger.Int("row", rowNo), logger.Err(err))
+		result.Status = entity.ImportRowFailed
+		result.Errors = []string{"保存失败：" + err.Error()}
+		return result
+	}
+	result.Status = entity.ImportRowCreated
+	result.CaseID = mp.ID
+	result.CaseNo = mp.CaseNo
+	return result
+}
+
+// findDuplicates 与同一文件的前序行及已有案件查重
+func (s *ImportAppService) findDuplicates(ctx context.Context, mp *entity.MissingPerson, previous []importedRow) []entity.ImportDuplicate {
+	var list []entity.ImportDuplicate
+	for _, p := range previous {
+		if score, _ := s.matcher.Score(mp, p.person); score >= s.matcher.Threshold() {
+			list = append(list, entity.ImportDuplicate{Name: p.person.Name, Row: p.row, Score: score})
+		}
+	}
+
+	candidates, err := s.mpRepo.FindDuplicateCandidates(ctx, mp, 0)
+	if err != nil {
+		logger.Warn("Failed to find duplicate candidates", logger.String("name", mp.Name), logger.Err(err))
+		return list
+	}
+	for _, m := range s.matcher.Match(mp, candidates) {
+		list = append(list, entity.ImportDuplicate{CaseID: m.Person.ID, CaseNo: m.Person.CaseNo, Name: m.Person.Name, Score: m.Score})
+	}
+	return list
+}
+
+// createCase 保存案件，案件编号冲突时重新生成
+func (s *ImportAppService) createCase(ctx context.Context, mp *entity.MissingPerson) error {
+	var err error
+	for attempt := 0; attempt < importCaseNoAttempts; attempt++ {
+		mp.CaseNo = ""
+		mp.AssignCaseNo()
+		if err = s.mpRepo.Create(ctx, mp); err == nil {
+			s.searchService.IndexCase(ctx, mp)
+			return nil
+		}
+	}
+	return err
+}
+
+// notify 推送任务进度给发起人
+func (s *ImportAppService) notify(job *entity.ImportJob) {
+	if s.wsManager == nil {
+		return
+	}
+
+	msg := entity.NewWebSocketMessage(entity.WSMessageTypeImportProgress, "案件导入", job.FileName)
+	msg.Data = map[string]interface{}{
+		"job_id":         job.ID,
+		"status":         job.Status,
+		"dry_run":        job.DryRun,
+		"total_rows":     job.TotalRows,
+		"processed_rows": job.ProcessedRows,
+		"created_rows":   job.CreatedRows,
+		"valid_rows":     job.ValidRows,
+		"failed_rows":    job.FailedRows,
+		"duplicate_rows": job.DuplicateRows,
+		"progress":       job.Progress(),
+	}
+	if job.Error != "" {
+		msg.Data["error"] = job.Error
+	}
+	if err := s.wsManager.SendToUser(job.CreatorID, msg); err != nil {
+		logger.Warn("Failed to send import progress", logger.String("job_id", job.ID), logger.Err(err))
+	}
+}
+
+// findJob 查找本组织的导入任务
+func (s *ImportAppService) findJob(ctx context.Context, id string, orgID string) (*entity.ImportJob, error) {
+	job, err := s.importRepo.FindByID(ctx, id)
+	if err != nil || job.OrgID != orgID {
+		return nil, ErrImportNotFound
+	}
+	return job, nil
+}
+
+// loadSheet 从存储读取任务的原表格并还原列映射
+func (s *ImportAppService) loadSheet(ctx context.Context, job *entity.ImportJob) (*spreadsheet.Sheet, map[string]int, error) {
+	reader, err := s.storageService.Download(ctx, job.FilePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(io.LimitReader(reader, MaxImportFileSize+1))
+	if err != nil {
+		return nil, nil, err
+	}
+	sheet, err := readImportSheet(job.FileName, data)
+	if err != nil {
+		return nil, nil, err
+	}
+	mapping, err := resolveMapping(sheet.Header(), job.GetMapping())
+	if err != nil {
+		return nil, nil, err
+	}
+	return sheet, mapping, nil
+}
+
+// readImportSheet 读取表格并检查行数
+func readImportSheet(name string, data []byte) (*spreadsheet.Sheet, error) {
+	sheet, err := spreadsheet.Read(name, data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrImportFile, err)
+	}
+	if len(sheet.Records()) == 0 {
+		return nil, fmt.Errorf("%w: 没有数据行", ErrImportFile)
+	}
+	if len(sheet.Records()) > MaxImportRows {
+		return nil, fmt.Errorf("%w: 最多 %d 行", ErrImportTooManyRows, MaxImportRows)
+	}
+	return sheet, nil
+}
+
+// duplicateText 重复说明
+func duplicateText(list []entity.ImportDuplicate) string {
+	parts := make([]string, len(list))
+	for i, d := range list {
+		if d.Row > 0 {
+			parts[i] = fmt.Sprintf("与第 %d 行 %s 重复（%.0f 分）", d.Row, d.Name, d.Score)
+		} else {
+			parts[i] = fmt.Sprintf("%s %s（%.0f 分）", d.CaseNo, d.Name, d.Score)
+		}
+	}
+	return strings.Join(parts, "；")
+}
+
+// padRow 补齐到表头长度
+func padRow(row []string, n int) []string {
+	values := make([]string, max(n, len(row)))
+	copy(values, row)
+	return values
+}
+
+// writeCSV 输出带 BOM 的 UTF-8 CSV
+func writeCSV(rows [][]string) []byte {
+	var buf bytes.Buffer
+	buf.Write([]byte{0xEF, 0xBB, 0xBF})
+	w := csv.NewWriter(&buf)
+	w.WriteAll(rows)
+	return buf.Bytes()
+}
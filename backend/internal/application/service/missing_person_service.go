@@ -3,26 +3,44 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/Snowitty-Re/CNtunyuan/internal/application/dto"
 	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
 	"github.com/Snowitty-Re/CNtunyuan/internal/domain/repository"
+	domainService "github.com/Snowitty-Re/CNtunyuan/internal/domain/service"
+	"github.com/Snowitty-Re/CNtunyuan/internal/infrastructure/permission"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/hanzi"
 	"github.com/Snowitty-Re/CNtunyuan/pkg/logger"
 )
 
 var (
-	ErrMissingPersonNotFound = errors.New("missing person not found")
-	ErrInvalidStatus         = errors.New("invalid status")
+	ErrMissingPersonNotFound  = errors.New("missing person not found")
+	ErrInvalidStatus          = errors.New("invalid status")
+	ErrInvalidMerge           = errors.New("invalid merge")
+	ErrMissingPersonForbidden = errors.New("no access to missing person")
 )
 
 // MissingPersonAppService 走失人员应用服务
 type MissingPersonAppService struct {
-	mpRepo repository.MissingPersonRepository
+	mpRepo         repository.MissingPersonRepository
+	auditService   *AuditService
+	searchService  *CaseSearchAppService
+	urgencyService *UrgencyAppService
+	matcher        *domainService.DuplicateMatcher
+	dataPerm       permission.DataPermissionProvider
 }
 
 // NewMissingPersonAppService 创建走失人员应用服务
-func NewMissingPersonAppService(mpRepo repository.MissingPersonRepository) *MissingPersonAppService {
-	return &MissingPersonAppService{mpRepo: mpRepo}
+func NewMissingPersonAppService(mpRepo repository.MissingPersonRepository, auditService *AuditService, searchService *CaseSearchAppService, urgencyService *UrgencyAppService, dataPerm permission.DataPermissionProvider) *MissingPersonAppService {
+	return &MissingPersonAppService{
+		mpRepo:         mpRepo,
+		auditService:   auditService,
+		searchService:  searchService,
+		urgencyService: urgencyService,
+		matcher:        domainService.NewDuplicateMatcher(domainService.DefaultDuplicateThreshold),
+		dataPerm:       dataPerm,
+	}
 }
 
 // Create 创建走失人员
@@ -41,6 +59,8 @@ func (s *MissingPersonAppService) Create(ctx context.Context, req *dto.CreateMis
 		City:         req.City,
 		District:     req.District,
 		Address:      req.Address,
+		Lat:          req.Lat,
+		Lng:          req.Lng,
 		Clothes:      req.Clothes,
 		Features:     req.Features,
 		ContactName:  req.ContactName,
@@ -56,6 +76,7 @@ func (s *MissingPersonAppService) Create(ctx context.Context, req *dto.CreateMis
 	if req.UrgencyLevel == "" {
 		mp.Urgency = entity.UrgencyLevelMedium
 	}
+	mp.NamePinyin = hanzi.ToPinyin(hanzi.NormalizeName(mp.Name))
 
 	if err := s.mpRepo.Create(ctx, mp); err != nil {
 		logger.Error("Failed to create missing person", logger.Err(err))
@@ -63,8 +84,11 @@ func (s *MissingPersonAppService) Create(ctx context.Context, req *dto.CreateMis
 	}
 
 	logger.Info("Missing person created", logger.String("mp_id", mp.ID))
+	s.searchService.IndexCase(ctx, mp)
+	s.urgencyService.RefreshCase(ctx, mp)
 
 	resp := dto.ToMissingPersonResponse(mp)
+	resp.Duplicates = s.detectDuplicates(ctx, mp)
 	return &resp, nil
 }
 
@@ -162,6 +186,10 @@ func (s *MissingPersonAppService) Update(ctx context.Context, id string, req *dt
 	if req.Address != "" {
 		mp.Address = req.Address
 	}
+	if req.Lat != 0 || req.Lng != 0 {
+		mp.Lat = req.Lat
+		mp.Lng = req.Lng
+	}
 	if req.Clothes != "" {
 		mp.Clothes = req.Clothes
 	}
@@ -180,16 +208,22 @@ func (s *MissingPersonAppService) Update(ctx context.Context, id string, req *dt
 	if req.AltContact != "" {
 		mp.AltContact = req.AltContact
 	}
-	if req.UrgencyLevel != "" {
+	// 人工调整紧急程度后，定期评分只更新评分不再覆盖等级
+	if req.UrgencyLevel != "" && entity.UrgencyLevel(req.UrgencyLevel) != mp.Urgency {
 		mp.Urgency = entity.UrgencyLevel(req.UrgencyLevel)
+		mp.UrgencyManual = true
 	}
+	mp.NamePinyin = hanzi.ToPinyin(hanzi.NormalizeName(mp.Name))
 
 	if err := s.mpRepo.Update(ctx, mp); err != nil {
 		logger.Error("Failed to update missing person", logger.Err(err))
 		return nil, err
 	}
+	s.searchService.IndexCase(ctx, mp)
+	s.urgencyService.RefreshCase(ctx, mp)
 
 	resp := dto.ToMissingPersonResponse(mp)
+	resp.Duplicates = s.detectDuplicates(ctx, mp)
 	return &resp, nil
 }
 
@@ -199,77 +233,165 @@ func (s *MissingPersonAppService) Delete(ctx context.Context, id string) error {
 		logger.Error("Failed to delete missing person", logger.Err(err))
 		return err
 	}
+	s.searchService.RemoveCase(ctx, id)
 	return nil
 }
 
-// UpdateStatus 更新状态
-func (s *MissingPersonAppService) UpdateStatus(ctx context.Context, id string, status string) error {
+// UpdateStatus 按状态机将案件转换到目标状态
+func (s *MissingPersonAppService) UpdateStatus(ctx context.Context, id string, status, reason, operatorID, orgID string) error {
 	mp, err := s.mpRepo.FindByID(ctx, id)
 	if err != nil {
 		return ErrMissingPersonNotFound
 	}
 
-	if !mp.CanUpdate() {
-		return errors.New("cannot update closed case")
+	t, ok := entity.FindCaseTransitionTo(mp.Status, entity.MissingStatus(status))
+	if !ok {
+		return fmt.Errorf("%w: %s -> %s", entity.ErrCaseTransitionNotAllowed, mp.Status, status)
 	}
 
-	newStatus := entity.MissingStatus(status)
-	mp.Status = newStatus
-
-	if err := s.mpRepo.UpdateStatus(ctx, id, newStatus); err != nil {
+	var log *entity.MissingPersonStatusLog
+	switch t.Event {
+	case entity.CaseEventReunite:
+		// 团聚必须登记团聚记录，见 ReunionAppService.Reunite
+		return ErrReunionRecordRequired
+	case entity.CaseEventMarkFound:
+		log, err = mp.MarkFound(operatorID, "", reason)
+	case entity.CaseEventReopen:
+		log, err = mp.Reopen(operatorID, reason)
+	default:
+		log, err = mp.Transition(t.Event, operatorID, reason)
+	}
+	if err != nil {
 		return err
 	}
 
-	return nil
+	return s.applyTransition(ctx, mp, log, orgID)
 }
 
 // MarkFound 标记找到
-func (s *MissingPersonAppService) MarkFound(ctx context.Context, id string, req *dto.MarkFoundRequest) error {
+func (s *MissingPersonAppService) MarkFound(ctx context.Context, id string, req *dto.MarkFoundRequest, operatorID, orgID string) error {
 	mp, err := s.mpRepo.FindByID(ctx, id)
 	if err != nil {
 		return ErrMissingPersonNotFound
 	}
 
-	if err := mp.MarkFound(req.Location, req.Note); err != nil {
+	log, err := mp.MarkFound(operatorID, req.Location, req.Note)
+	if err != nil {
 		return err
 	}
 
-	// 更新数据库
-	if err := s.mpRepo.Update(ctx, mp); err != nil {
+	return s.applyTransition(ctx, mp, log, orgID)
+}
+
+// Close 关闭案件
+func (s *MissingPersonAppService) Close(ctx context.Context, id, reason, operatorID, orgID string) error {
+	mp, err := s.mpRepo.FindByID(ctx, id)
+	if err != nil {
+		return ErrMissingPersonNotFound
+	}
+
+	log, err := mp.Close(operatorID, reason)
+	if err != nil {
 		return err
 	}
 
-	return nil
+	return s.applyTransition(ctx, mp, log, orgID)
 }
 
-// MarkReunited 标记团聚
-func (s *MissingPersonAppService) MarkReunited(ctx context.Context, id string) error {
+// Reopen 重新打开已找到或已关闭的案件
+func (s *MissingPersonAppService) Reopen(ctx context.Context, id, reason, operatorID, orgID string) error {
 	mp, err := s.mpRepo.FindByID(ctx, id)
 	if err != nil {
 		return ErrMissingPersonNotFound
 	}
 
-	if err := mp.MarkReunited(); err != nil {
+	log, err := mp.Reopen(operatorID, reason)
+	if err != nil {
 		return err
 	}
 
-	if err := s.mpRepo.Update(ctx, mp); err != nil {
+	return s.applyTransition(ctx, mp, log, orgID)
+}
+
+// GetStatusLogs 获取案件状态变更记录
+func (s *MissingPersonAppService) GetStatusLogs(ctx context.Context, id string) ([]dto.MissingPersonStatusLogResponse, error) {
+	if _, err := s.mpRepo.FindByID(ctx, id); err != nil {
+		return nil, ErrMissingPersonNotFound
+	}
+
+	logs, err := s.mpRepo.GetStatusLogs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]dto.MissingPersonStatusLogResponse, len(logs))
+	for i := range logs {
+		list[i] = dto.ToMissingPersonStatusLogResponse(&logs[i])
+	}
+	return list, nil
+}
+
+// GetTransitions 获取案件当前可执行的状态转换
+func (s *MissingPersonAppService) GetTransitions(ctx context.Context, id string) ([]dto.CaseTransitionResponse, error) {
+	mp, err := s.mpRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, ErrMissingPersonNotFound
+	}
+	if mp.IsMerged() {
+		return []dto.CaseTransitionResponse{}, nil
+	}
+
+	transitions := entity.AvailableCaseTransitions(mp.Status)
+	list := make([]dto.CaseTransitionResponse, len(transitions))
+	for i, t := range transitions {
+		list[i] = dto.CaseTransitionResponse{
+			Event:         t.Event,
+			To:            string(t.To),
+			RequireReason: t.RequireReason,
+		}
+	}
+	return list, nil
+}
+
+// applyTransition 持久化状态转换及变更记录，并记录审计日志
+func (s *MissingPersonAppService) applyTransition(ctx context.Context, mp *entity.MissingPerson, log *entity.MissingPersonStatusLog, orgID string) error {
+	if err := s.mpRepo.TransitionStatus(ctx, mp, log); err != nil {
+		logger.Error("Failed to transition missing person status",
+			logger.String("mp_id", mp.ID),
+			logger.String("event", log.Event),
+			logger.Err(err),
+		)
 		return err
 	}
 
+	if s.auditService != nil {
+		auditLog := entity.NewAuditLog(log.UserID, orgID, entity.AuditActionUpdate, string(entity.ResourceMissingPerson)).
+			SetResourceID(mp.ID).
+			SetResourceName(mp.CaseNo).
+			SetDescription(fmt.Sprintf("案件状态 %s -> %s", log.FromStatus, log.ToStatus)).
+			AddExtra("event", log.Event).
+			AddExtra("from_status", string(log.FromStatus)).
+			AddExtra("to_status", string(log.ToStatus)).
+			AddExtra("reason", log.Reason)
+		s.auditService.Log(ctx, auditLog)
+	}
+
+	// 重新打开的案件恢复评分，已结束的案件保留最后一次评分
+	s.urgencyService.RefreshCase(ctx, mp)
 	return nil
 }
 
 // AddTrack 添加轨迹
 func (s *MissingPersonAppService) AddTrack(ctx context.Context, personID string, req *dto.CreateMissingPersonTrackRequest, reporterID string) (*dto.MissingPersonTrackResponse, error) {
 	// 检查案件是否存在
-	if _, err := s.mpRepo.FindByID(ctx, personID); err != nil {
+	mp, err := s.mpRepo.FindByID(ctx, personID)
+	if err != nil {
 		return nil, ErrMissingPersonNotFound
 	}
 
 	track := &entity.MissingPersonTrack{
 		MissingPersonID: personID,
-		ReporterID:      reporterID,
+		ReporterID:      &reporterID,
 		Location:        req.Location,
 		Province:        req.Province,
 		City:            req.City,
@@ -280,12 +402,14 @@ func (s *MissingPersonAppService) AddTrack(ctx context.Context, personID string,
 		IsKeyPoint:      req.IsKeyPoint,
 		Lat:             req.Lat,
 		Lng:             req.Lng,
-		Status:          "pending",
+		Status:          entity.TrackStatusPending,
+		Source:          entity.TrackSourceVolunteer,
 	}
 
 	if err := s.mpRepo.AddTrack(ctx, track); err != nil {
 		return nil, err
 	}
+	s.urgencyService.RefreshCase(ctx, mp)
 
 	resp := dto.ToMissingPersonTrackResponse(track)
 	return &resp, nil
@@ -323,8 +447,12 @@ func (s *MissingPersonAppService) GetStats(ctx context.Context) (*dto.MissingPer
 	}, nil
 }
 
-// Search 搜索
+// Search 搜索，检索索引可用时走全文检索，否则退化为数据库模糊查询
 func (s *MissingPersonAppService) Search(ctx context.Context, keyword string, page, pageSize int) (*dto.MissingPersonListResponse, error) {
+	if s.searchService.Ready() {
+		return s.searchService.Search(ctx, keyword, page, pageSize)
+	}
+
 	pagination := repository.Pagination{Page: page, PageSize: pageSize}
 	result, err := s.mpRepo.Search(ctx, keyword, pagination)
 	if err != nil {
@@ -339,3 +467,131 @@ func (s *MissingPersonAppService) Search(ctx context.Context, keyword string, pa
 	resp := dto.NewMissingPersonListResponse(list, result.Total, result.Page, result.PageSize)
 	return &resp, nil
 }
+
+// FindDuplicates 查找疑似重复案件
+func (s *MissingPersonAppService) FindDuplicates(ctx context.Context, id string) ([]dto.DuplicateCandidateResponse, error) {
+	mp, err := s.mpRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, ErrMissingPersonNotFound
+	}
+
+	if mp.NamePinyin == "" {
+		mp.NamePinyin = hanzi.ToPinyin(hanzi.NormalizeName(mp.Name))
+	}
+
+	return s.matchDuplicates(ctx, mp)
+}
+
+// Merge 将重复案件合并到保留案件，两个案件都须在操作人的数据权限范围内
+func (s *MissingPersonAppService) Merge(ctx context.Context, survivorID string, req *dto.MergeMissingPersonRequest, operatorID, orgID, role string) (*dto.MissingPersonMergeResponse, error) {
+	survivor, err := s.mpRepo.FindByID(ctx, survivorID)
+	if err != nil {
+		return nil, ErrMissingPersonNotFound
+	}
+	merged, err := s.mpRepo.FindByID(ctx, req.MergedID)
+	if err != nil {
+		return nil, ErrMissingPersonNotFound
+	}
+
+	dpCtx, err := permission.BuildDataPermissionContext(ctx, s.dataPerm, operatorID, orgID, role)
+	if err != nil {
+		return nil, err
+	}
+	if !dpCtx.HasPermission(survivor.OrgID) || !dpCtx.HasPermission(merged.OrgID) {
+		return nil, ErrMissingPersonForbidden
+	}
+
+	score, _ := s.matcher.Score(survivor, merged)
+	from := merged.Status
+	if err := merged.MergeInto(survivor); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidMerge, err.Error())
+	}
+
+	record := entity.NewMissingPersonMerge(survivor, merged, operatorID, orgID, req.Reason)
+	record.Score = score
+
+	statusLog := entity.NewMergeStatusLog(merged, from, operatorID, req.Reason)
+
+	if err := s.mpRepo.Merge(ctx, survivor, merged, record, statusLog); err != nil {
+		logger.Error("Failed to merge missing person",
+			logger.String("survivor_id", survivor.ID),
+			logger.String("merged_id", merged.ID),
+			logger.Err(err),
+		)
+		return nil, err
+	}
+
+	logger.Info("Missing person merged",
+		logger.String("survivor_id", survivor.ID),
+		logger.String("merged_id", merged.ID),
+	)
+	s.searchService.IndexCase(ctx, survivor)
+	s.searchService.RemoveCase(ctx, merged.ID)
+
+	// 记录审计日志
+	if s.auditService != nil {
+		auditLog := entity.NewAuditLog(operatorID, orgID, entity.AuditActionUpdate, string(entity.ResourceMissingPerson)).
+			SetResourceID(survivor.ID).
+			SetResourceName(survivor.CaseNo).
+			SetDescription(fmt.Sprintf("合并重复案件 %s 到 %s", merged.CaseNo, survivor.CaseNo)).
+			AddExtra("merge_id", record.ID).
+			AddExtra("merged_id", merged.ID).
+			AddExtra("merged_case_no", merged.CaseNo).
+			AddExtra("score", score).
+			AddExtra("reason", req.Reason).
+			AddExtra("track_count", record.TrackCount).
+			AddExtra("photo_count", record.PhotoCount).
+			AddExtra("task_count", record.TaskCount).
+			AddExtra("file_count", record.FileCount)
+		s.auditService.Log(ctx, auditLog)
+	}
+
+	resp := dto.ToMissingPersonMergeResponse(record)
+	return &resp, nil
+}
+
+// GetMergeHistory 获取合并记录
+func (s *MissingPersonAppService) GetMergeHistory(ctx context.Context, id string) ([]dto.MissingPersonMergeResponse, error) {
+	records, err := s.mpRepo.GetMergeHistory(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]dto.MissingPersonMergeResponse, len(records))
+	for i := range records {
+		list[i] = dto.ToMissingPersonMergeResponse(&records[i])
+	}
+	return list, nil
+}
+
+// detectDuplicates 创建、更新后执行查重，查重失败不影响主流程
+func (s *MissingPersonAppService) detectDuplicates(ctx context.Context, mp *entity.MissingPerson) []dto.DuplicateCandidateResponse {
+	list, err := s.matchDuplicates(ctx, mp)
+	if err != nil {
+		logger.Warn("Failed to detect duplicate cases", logger.String("mp_id", mp.ID), logger.Err(err))
+		return nil
+	}
+
+	if len(list) > 0 {
+		logger.Info("Possible duplicate cases detected",
+			logger.String("mp_id", mp.ID),
+			logger.Int("count", len(list)),
+		)
+	}
+	return list
+}
+
+// matchDuplicates 查询候选案件并打分
+func (s *MissingPersonAppService) matchDuplicates(ctx context.Context, mp *entity.MissingPerson) ([]dto.DuplicateCandidateResponse, error) {
+	candidates, err := s.mpRepo.FindDuplicateCandidates(ctx, mp, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := s.matcher.Match(mp, candidates)
+	list := make([]dto.DuplicateCandidateResponse, len(matches))
+	for i, m := range matches {
+		list[i] = dto.ToDuplicateCandidateResponse(m.Person, m.Score, m.Reasons)
+	}
+	return list, nil
+}
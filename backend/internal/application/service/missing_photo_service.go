@@ -0,0 +1,293 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"mime/multipart"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/dto"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/repository"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/imaging"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/logger"
+)
+
+var (
+	ErrPhotoNotFound    = errors.New("photo not found")
+	ErrInvalidPhotoType = errors.New("invalid photo type")
+)
+
+// missingPhotoEntityType 照片文件绑定的实体类型
+const missingPhotoEntityType = "missing_person"
+
+// MissingPhotoAppService 走失人员照片应用服务
+// 照片经图片流水线处理：公开图与分享图去除 EXIF，拍摄时间和位置仅作为线索参考保留，
+// 感知哈希用于发现不同案件中视觉相同的照片
+type MissingPhotoAppService struct {
+	mpRepo              repository.MissingPersonRepository
+	fileService         *FileAppService
+	similarityThreshold int
+}
+
+// NewMissingPhotoAppService 创建走失人员照片应用服务
+func NewMissingPhotoAppService(mpRepo repository.MissingPersonRepository, fileService *FileAppService, similarityThreshold int) *MissingPhotoAppService {
+	if similarityThreshold <= 0 {
+		similarityThreshold = imaging.DefaultSimilarityThreshold
+	}
+	return &MissingPhotoAppService{
+		mpRepo:              mpRepo,
+		fileService:         fileService,
+		similarityThreshold: similarityThreshold,
+	}
+}
+
+// AddPhoto 上传照片，返回照片、EXIF 线索参考及与其它案件的相似照片
+func (s *MissingPhotoAppService) AddPhoto(ctx context.Context, mpID string, file multipart.File, header *multipart.FileHeader, req *dto.AddMissingPhotoRequest, uploaderID string) (*dto.AddMissingPhotoResponse, error) {
+	photoType := req.Type
+	if photoType == "" {
+		photoType = entity.PhotoTypeNormal
+	}
+	if !entity.IsValidPhotoType(photoType) {
+		return nil, ErrInvalidPhotoType
+	}
+
+	mp, err := s.mpRepo.FindByID(ctx, mpID)
+	if err != nil {
+		return nil, ErrMissingPersonNotFound
+	}
+
+	f, err := s.fileService.UploadImage(ctx, file, header, uploaderID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.fileService.BindToEntity(ctx, f.ID, missingPhotoEntityType, mp.ID); err != nil {
+		logger.Warn("Failed to bind photo file", logger.String("file_id", f.ID), logger.Err(err))
+	}
+
+	variants := f.GetVariants()
+	photo := &entity.MissingPhoto{
+		MissingPersonID: mp.ID,
+		URL:             f.URL,
+		Type:            photoType,
+		Description:     req.Description,
+		FileID:          &f.ID,
+		ThumbnailURL:    variants[entity.FileVariantThumbnail].URL,
+		ShareURL:        variants[entity.FileVariantShare].URL,
+		PHash:           f.PHash,
+		Width:           f.Width,
+		Height:          f.Height,
+	}
+	// 模拟画像（年龄推演）为生成图片，其 EXIF 不代表走失人员的行踪
+	if photoType != entity.PhotoTypeSimulated {
+		photo.TakenAt, photo.Lat, photo.Lng = f.TakenAt, f.Lat, f.Lng
+	}
+
+	if err := s.mpRepo.AddPhoto(ctx, photo); err != nil {
+		logger.Error("Failed to save missing photo", logger.String("mp_id", mp.ID), logger.Err(err))
+		return nil, err
+	}
+	if req.IsPrimary || mp.PhotoUrl == "" {
+		if err := s.mpRepo.SetPrimaryPhoto(ctx, photo); err != nil {
+			logger.Error("Failed to set primary photo", logger.String("photo_id", photo.ID), logger.Err(err))
+		}
+	}
+
+	resp := &dto.AddMissingPhotoResponse{
+		Photo:     dto.ToMissingPhotoResponse(photo),
+		TrackHint: trackHint(photo),
+		Matches:   []dto.PhotoMatchResponse{},
+	}
+
+	// 相似照片检测失败不影响上传结果
+	matches, err := s.detectMatches(ctx, photo)
+	if err != nil {
+		logger.Error("Failed to detect similar photos", logger.String("photo_id", photo.ID), logger.Err(err))
+		return resp, nil
+	}
+	if len(matches) > 0 {
+		logger.Warn("Similar photos found in other cases",
+			logger.String("mp_id", mp.ID),
+			logger.String("photo_id", photo.ID),
+			logger.Int("matches", len(matches)),
+		)
+		resp.Matches = s.toMatchResponses(ctx, mp.ID, matches)
+	}
+
+	return resp, nil
+}
+
+// ListPhotos 获取案件照片
+func (s *MissingPhotoAppService) ListPhotos(ctx context.Context, mpID string) ([]dto.MissingPhotoResponse, error) {
+	if _, err := s.mpRepo.FindByID(ctx, mpID); err != nil {
+		return nil, ErrMissingPersonNotFound
+	}
+
+	photos, err := s.mpRepo.GetPhotos(ctx, mpID)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]dto.MissingPhotoResponse, len(photos))
+	for i := range photos {
+		list[i] = dto.ToMissingPhotoResponse(&photos[i])
+	}
+	return list, nil
+}
+
+// SetPrimary 设为主照片
+func (s *MissingPhotoAppService) SetPrimary(ctx context.Context, mpID, photoID string) (*dto.MissingPhotoResponse, error) {
+	photo, err := s.find(ctx, mpID, photoID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.mpRepo.SetPrimaryPhoto(ctx, photo); err != nil {
+		return nil, err
+	}
+
+	resp := dto.ToMissingPhotoResponse(photo)
+	return &resp, nil
+}
+
+// DeletePhoto 删除照片及其文件
+func (s *MissingPhotoAppService) DeletePhoto(ctx context.Context, mpID, photoID string) error {
+	photo, err := s.find(ctx, mpID, photoID)
+	if err != nil {
+		return err
+	}
+	if err := s.mpRepo.DeletePhoto(ctx, photo); err != nil {
+		return err
+	}
+
+	if photo.FileID != nil {
+		if err := s.fileService.Delete(ctx, *photo.FileID); err != nil {
+			logger.Warn("Failed to delete photo file", logger.String("file_id", *photo.FileID), logger.Err(err))
+		}
+	}
+	return nil
+}
+
+// GetMatches 获取与案件照片视觉相同的其它案件照片
+func (s *MissingPhotoAppService) GetMatches(ctx context.Context, mpID string) ([]dto.PhotoMatchResponse, error) {
+	if _, err := s.mpRepo.FindByID(ctx, mpID); err != nil {
+		return nil, ErrMissingPersonNotFound
+	}
+
+	matches, err := s.mpRepo.GetPhotoMatches(ctx, mpID)
+	if err != nil {
+		return nil, err
+	}
+	return s.toMatchResponses(ctx, mpID, matches), nil
+}
+
+// find 查找案件下的照片
+func (s *MissingPhotoAppService) find(ctx context.Context, mpID, photoID string) (*entity.MissingPhoto, error) {
+	photo, err := s.mpRepo.FindPhotoByID(ctx, photoID)
+	if err != nil || photo.MissingPersonID != mpID {
+		return nil, ErrPhotoNotFound
+	}
+	return photo, nil
+}
+
+// detectMatches 与其它案件照片比较感知哈希，保存并返回相似记录
+func (s *MissingPhotoAppService) detectMatches(ctx context.Context, photo *entity.MissingPhoto) ([]entity.MissingPhotoMatch, error) {
+	hash, err := imaging.ParseHash(photo.PHash)
+	if err != nil {
+		return nil, nil
+	}
+
+	candidates, err := s.mpRepo.FindPhotoHashes(ctx, photo.MissingPersonID)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []entity.MissingPhotoMatch
+	for i := range candidates {
+		other, err := imaging.ParseHash(candidates[i].PHash)
+		if err != nil {
+			continue
+		}
+		if distance := imaging.HammingDistance(hash, other); distance <= s.similarityThreshold {
+			matches = append(matches, entity.MissingPhotoMatch{
+				PhotoID:         photo.ID,
+				MissingPersonID: photo.MissingPersonID,
+				MatchedPhotoID:  candidates[i].ID,
+				MatchedPersonID: candidates[i].MissingPersonID,
+				Distance:        distance,
+			})
+		}
+	}
+	if len(matches) == 0 {
+		return nil, nil
+	}
+
+	if err := s.mpRepo.AddPhotoMatches(ctx, matches); err != nil {
+		return nil, err
+	}
+
+	// 重新加载以带出双方照片
+	all, err := s.mpRepo.GetPhotoMatches(ctx, photo.MissingPersonID)
+	if err != nil {
+		return nil, err
+	}
+	saved := all[:0]
+	for _, m := range all {
+		if m.PhotoID == photo.ID {
+			saved = append(saved, m)
+		}
+	}
+	return saved, nil
+}
+
+// toMatchResponses 按案件视角转换相似记录：Photo 为本案件照片，Matched* 为对方案件
+func (s *MissingPhotoAppService) toMatchResponses(ctx context.Context, mpID string, matches []entity.MissingPhotoMatch) []dto.PhotoMatchResponse {
+	persons := make(map[string]*entity.MissingPerson)
+	list := make([]dto.PhotoMatchResponse, 0, len(matches))
+	for _, m := range matches {
+		own, other, otherPersonID := m.Photo, m.MatchedPhoto, m.MatchedPersonID
+		if m.MissingPersonID != mpID {
+			own, other, otherPersonID = m.MatchedPhoto, m.Photo, m.MissingPersonID
+		}
+
+		resp := dto.PhotoMatchResponse{
+			ID:              m.ID,
+			Distance:        m.Distance,
+			MatchedPersonID: otherPersonID,
+			CreatedAt:       m.CreatedAt,
+		}
+		if own != nil {
+			photo := dto.ToMissingPhotoResponse(own)
+			resp.Photo = &photo
+		}
+		if other != nil {
+			photo := dto.ToMissingPhotoResponse(other)
+			resp.MatchedPhoto = &photo
+		}
+
+		person, ok := persons[otherPersonID]
+		if !ok {
+			person, _ = s.mpRepo.FindByID(ctx, otherPersonID)
+			persons[otherPersonID] = person
+		}
+		if person != nil {
+			resp.MatchedCaseNo = person.CaseNo
+			resp.MatchedName = person.Name
+		}
+
+		list = append(list, resp)
+	}
+	return list
+}
+
+// trackHint 从照片 EXIF 生成线索参考，没有拍摄时间和位置时返回空
+func trackHint(photo *entity.MissingPhoto) *dto.PhotoTrackHint {
+	hasGPS := photo.Lat != 0 || photo.Lng != 0
+	if photo.TakenAt == nil && !hasGPS {
+		return nil
+	}
+	return &dto.PhotoTrackHint{
+		TakenAt: photo.TakenAt,
+		Lat:     photo.Lat,
+		Lng:     photo.Lng,
+		HasGPS:  hasGPS,
+	}
+}
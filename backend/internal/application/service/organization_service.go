@@ -57,6 +57,8 @@ func (s *OrganizationAppService) Create(ctx context.Context, req *dto.CreateOrga
 
 	org.Description = req.Description
 	org.Address = req.Address
+	org.Lat = req.Lat
+	org.Lng = req.Lng
 	org.ContactName = req.ContactName
 	org.ContactPhone = req.ContactPhone
 	org.SortOrder = req.SortOrder
@@ -147,6 +149,10 @@ func (s *OrganizationAppService) Update(ctx context.Context, id string, req *dto
 	if req.Address != "" {
 		org.Address = req.Address
 	}
+	if req.Lat != nil && req.Lng != nil {
+		org.Lat = *req.Lat
+		org.Lng = *req.Lng
+	}
 	if req.ContactName != "" {
 		org.ContactName = req.ContactName
 	}
@@ -57,6 +57,7 @@ type PermissionService interface {
 	
 	// Filter operations
 	FilterFields(ctx context.Context, userID string, resource string, data interface{}) (interface{}, error)
+	FieldPermissions(ctx context.Context, userID string, resource string) (map[string]string, error)
 	
 	// Init operations
 	InitSystemPermissions(ctx context.Context) error
@@ -469,6 +470,17 @@ func (s *PermissionAppService) CheckDataPermission(ctx context.Context, userID s
 
 // FilterFields 过滤字段
 func (s *PermissionAppService) FilterFields(ctx context.Context, userID string, resource string, data interface{}) (interface{}, error) {
+	fieldPerms, err := s.FieldPermissions(ctx, userID, resource)
+	if err != nil {
+		return nil, err
+	}
+	
+	// 过滤字段
+	return s.filterStructFields(data, fieldPerms), nil
+}
+
+// FieldPermissions 获取用户在资源上的字段权限（field -> read/write/none），多个角色取最宽松的权限
+func (s *PermissionAppService) FieldPermissions(ctx context.Context, userID string, resource string) (map[string]string, error) {
 	// 获取用户角色
 	roles, err := s.permRepo.ListRolesByUserID(ctx, userID)
 	if err != nil {
@@ -491,9 +503,7 @@ func (s *PermissionAppService) FilterFields(ctx context.Context, userID string,
 			}
 		}
 	}
-	
-	// 过滤字段
-	return s.filterStructFields(data, fieldPerms), nil
+	return fieldPerms, nil
 }
 
 // filterStructFields 过滤结构体字段
@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/dto"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/repository"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/imaging"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/logger"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/poster"
+)
+
+var (
+	ErrPosterFormat    = errors.New("unsupported poster format")
+	ErrCaseNotPostable = errors.New("case is not active")
+)
+
+// posterPhotoMaxSize 海报照片读取上限
+const posterPhotoMaxSize = 20 << 20
+
+// PosterAppService 寻人海报应用服务
+// 海报只展示公开信息：联系方式使用案件所属组织的联系人，不展示家属电话；
+// 文本中的手机号、证件号按公示板规则脱敏
+type PosterAppService struct {
+	mpRepo      repository.MissingPersonRepository
+	orgRepo     repository.OrganizationRepository
+	fileService *FileAppService
+	renderer    *poster.Renderer
+	casePageURL string
+}
+
+// NewPosterAppService 创建寻人海报应用服务
+// casePageURL 为二维码跳转的公开案件页，{id} 替换为案件 ID
+func NewPosterAppService(
+	mpRepo repository.MissingPersonRepository,
+	orgRepo repository.OrganizationRepository,
+	fileService *FileAppService,
+	renderer *poster.Renderer,
+	casePageURL string,
+) *PosterAppService {
+	return &PosterAppService{
+		mpRepo:      mpRepo,
+		orgRepo:     orgRepo,
+		fileService: fileService,
+		renderer:    renderer,
+		casePageURL: casePageURL,
+	}
+}
+
+// Render 生成海报，每次生成计一次分享
+func (s *PosterAppService) Render(ctx context.Context, id string, format string) (*dto.PosterFile, error) {
+	if format == "" {
+		format = dto.PosterFormatPDF
+	}
+	if format != dto.PosterFormatPDF && format != dto.PosterFormatPNG {
+		return nil, ErrPosterFormat
+	}
+
+	mp, err := s.mpRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, ErrMissingPersonNotFound
+	}
+	if mp.IsMerged() || (mp.Status != entity.MissingStatusMissing && mp.Status != entity.MissingStatusSearching) {
+		return nil, ErrCaseNotPostable
+	}
+
+	content := s.buildContent(ctx, mp)
+
+	file := &dto.PosterFile{FileName: fmt.Sprintf("poster_%s.%s", mp.CaseNo, format)}
+	switch format {
+	case dto.PosterFormatPNG:
+		file.ContentType = "image/png"
+		file.Data, err = s.renderer.PNG(content)
+	default:
+		file.ContentType = "application/pdf"
+		file.Data, err = s.renderer.PDF(content)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.mpRepo.IncrementShareCount(ctx, mp.ID); err != nil {
+		logger.Warn("Failed to increment share count", logger.String("mp_id", mp.ID), logger.Err(err))
+	}
+
+	logger.Info("Poster rendered",
+		logger.String("mp_id", mp.ID),
+		logger.String("format", format),
+		logger.Int("size", len(file.Data)),
+	)
+	return file, nil
+}
+
+// buildContent 组装海报内容
+func (s *PosterAppService) buildContent(ctx context.Context, mp *entity.MissingPerson) *poster.Content {
+	content := &poster.Content{
+		Name:   mp.Name,
+		Photo:  s.loadPhoto(ctx, mp),
+		CaseNo: mp.CaseNo,
+		URL:    strings.ReplaceAll(s.casePageURL, "{id}", mp.ID),
+	}
+
+	basic := genderText(mp.Gender)
+	if age := mp.GetAgeAtMissing(); age > 0 {
+		basic = strings.TrimSpace(fmt.Sprintf("%s %d岁", basic, age))
+	}
+	content.Summary = append(content.Summary, poster.Field{Label: "性别年龄", Value: basic})
+	if mp.Height > 0 {
+		content.Summary = append(content.Summary, poster.Field{Label: "身高", Value: fmt.Sprintf("约 %dcm", mp.Height)})
+	}
+	if !mp.MissingTime.IsZero() {
+		content.Summary = append(content.Summary, poster.Field{Label: "走失时间", Value: mp.MissingTime.Format("2006年01月02日 15:04")})
+	}
+
+	place := mp.Province + mp.City + mp.District + entity.MaskSensitiveText(mp.Address)
+	content.Details = []poster.Field{
+		{Label: "走失地点", Value: place},
+		{Label: "体貌特征", Value: entity.MaskSensitiveText(mp.Features)},
+		{Label: "衣着", Value: entity.MaskSensitiveText(mp.Clothes)},
+	}
+
+	if org, err := s.orgRepo.FindByID(ctx, mp.OrgID); err == nil {
+		content.Org = org.Name
+		if org.ContactPhone != "" {
+			content.Contacts = append(content.Contacts, poster.Field{
+				Label: "联系电话",
+				Value: strings.TrimSpace(org.ContactPhone + " " + org.ContactName),
+			})
+		}
+	}
+	content.Contacts = append(content.Contacts, poster.Field{Label: "报警电话", Value: "110"})
+	return content
+}
+
+// loadPhoto 读取主照片的公开版本；未生成公开版本时读取原图并重新编码以去除元数据
+func (s *PosterAppService) loadPhoto(ctx context.Context, mp *entity.MissingPerson) []byte {
+	photo := primaryPhoto(mp.Photos)
+	if photo == nil || photo.FileID == nil {
+		return nil
+	}
+
+	reader, err := s.fileService.GetVariant(ctx, *photo.FileID, entity.FileVariantPublic)
+	if err == nil {
+		defer reader.Close()
+		data, err := io.ReadAll(io.LimitReader(reader, posterPhotoMaxSize))
+		if err != nil {
+			logger.Warn("Failed to read poster photo", logger.String("photo_id", photo.ID), logger.Err(err))
+			return nil
+		}
+		return data
+	}
+
+	reader, _, err = s.fileService.GetFile(ctx, *photo.FileID)
+	if err != nil {
+		logger.Warn("Failed to load poster photo", logger.String("photo_id", photo.ID), logger.Err(err))
+		return nil
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(io.LimitReader(reader, posterPhotoMaxSize))
+	if err != nil {
+		return nil
+	}
+	img, _, err := imaging.Decode(data)
+	if err != nil {
+		return nil
+	}
+	encoded, err := imaging.EncodeJPEG(img, imaging.DefaultJPEGQuality)
+	if err != nil {
+		return nil
+	}
+	return encoded
+}
+
+// primaryPhoto 主照片，未设置时取第一张普通照片
+func primaryPhoto(photos []entity.MissingPhoto) *entity.MissingPhoto {
+	var first *entity.MissingPhoto
+	for i := range photos {
+		if photos[i].IsPrimary {
+			return &photos[i]
+		}
+		if first == nil && photos[i].Type != entity.PhotoTypeSimulated {
+			first = &photos[i]
+		}
+	}
+	return first
+}
+
+// genderText 性别显示文本
+func genderText(gender string) string {
+	switch strings.ToLower(gender) {
+	case "male", "m":
+		return "男"
+	case "female", "f":
+		return "女"
+	}
+	return gender
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/dto"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/repository"
+	"github.com/Snowitty-Re/CNtunyuan/internal/infrastructure/cache"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/captcha"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/geo"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/logger"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/utils"
+)
+
+var (
+	ErrCaptchaRequired  = errors.New("captcha required")
+	ErrCaptchaInvalid   = errors.New("captcha invalid")
+	ErrInvalidLeadPhone = errors.New("invalid contact phone")
+	ErrInvalidLeadTime  = errors.New("invalid lead time")
+)
+
+// leadFutureTolerance 线索时间允许超前的时长（客户端时钟误差）
+const leadFutureTolerance = 10 * time.Minute
+
+// PublicCaseAppService 公开案件公示与线索提交应用服务（无需登录）
+type PublicCaseAppService struct {
+	mpRepo       repository.MissingPersonRepository
+	captcha      *captcha.Generator
+	cacheManager cache.CacheManager
+}
+
+// NewPublicCaseAppService 创建公开案件应用服务
+// captchaGen 为空表示不启用验证码；cacheManager 为空时不做验证码防重放
+func NewPublicCaseAppService(mpRepo repository.MissingPersonRepository, captchaGen *captcha.Generator, cacheManager cache.CacheManager) *PublicCaseAppService {
+	return &PublicCaseAppService{
+		mpRepo:       mpRepo,
+		captcha:      captchaGen,
+		cacheManager: cacheManager,
+	}
+}
+
+// List 公开案件列表（仅寻找中的案件）
+func (s *PublicCaseAppService) List(ctx context.Context, req *dto.PublicCaseListRequest) (*dto.PublicCaseListResponse, error) {
+	query := repository.NewMissingPersonQuery()
+	query.Page = req.Page
+	query.PageSize = req.PageSize
+	query.Keyword = strings.TrimSpace(req.Keyword)
+	query.Gender = req.Gender
+	query.Province = req.Province
+	query.City = req.City
+	query.PublicOnly = true
+
+	result, err := s.mpRepo.List(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]dto.PublicCaseResponse, len(result.List))
+	for i := range result.List {
+		list[i] = dto.ToPublicCaseResponse(&result.List[i])
+	}
+
+	resp := dto.NewPublicCaseListResponse(list, result.Total, result.Page, result.PageSize)
+	return &resp, nil
+}
+
+// GetByID 公开案件详情
+func (s *PublicCaseAppService) GetByID(ctx context.Context, id string) (*dto.PublicCaseResponse, error) {
+	mp, err := s.findPublicCase(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mpRepo.IncrementViews(ctx, id)
+
+	resp := dto.ToPublicCaseResponse(mp)
+	return &resp, nil
+}
+
+// NewCaptcha 获取线索提交验证码
+func (s *PublicCaseAppService) NewCaptcha() (*dto.CaptchaResponse, error) {
+	if s.captcha == nil {
+		return &dto.CaptchaResponse{Enabled: false}, nil
+	}
+
+	c, err := s.captcha.Generate()
+	if err != nil {
+		return nil, err
+	}
+	return &dto.CaptchaResponse{
+		Enabled:   true,
+		Token:     c.Token,
+		Question:  c.Question,
+		ExpiresAt: &c.ExpiresAt,
+	}, nil
+}
+
+// SubmitLead 公众提交线索，生成待核实（pending）的轨迹记录
+func (s *PublicCaseAppService) SubmitLead(ctx context.Context, caseID string, req *dto.SubmitLeadRequest, clientIP string) (*dto.SubmitLeadResponse, error) {
+	if err := s.verifyCaptcha(ctx, req.CaptchaToken, req.CaptchaAnswer); err != nil {
+		return nil, err
+	}
+
+	phone := strings.TrimSpace(req.ContactPhone)
+	if phone != "" && !utils.IsValidPhone(phone) {
+		return nil, ErrInvalidLeadPhone
+	}
+	if req.Time.After(time.Now().Add(leadFutureTolerance)) {
+		return nil, ErrInvalidLeadTime
+	}
+
+	mp, err := s.findPublicCase(ctx, caseID)
+	if err != nil {
+		return nil, err
+	}
+
+	track := &entity.MissingPersonTrack{
+		MissingPersonID: mp.ID,
+		Location:        strings.TrimSpace(req.Location),
+		Province:        req.Province,
+		City:            req.City,
+		District:        req.District,
+		Address:         req.Address,
+		Time:            req.Time,
+		Description:     strings.TrimSpace(req.Description),
+		Status:          entity.TrackStatusPending,
+		Source:          entity.TrackSourcePublic,
+		SubmitterName:   strings.TrimSpace(req.ContactName),
+		SubmitterPhone:  phone,
+		SubmitterIP:     clientIP,
+	}
+	if geo.ValidCoordinate(req.Lat, req.Lng) {
+		track.Lat = req.Lat
+		track.Lng = req.Lng
+	}
+
+	if err := s.mpRepo.AddTrack(ctx, track); err != nil {
+		logger.Error("Failed to save public lead", logger.String("mp_id", mp.ID), logger.Err(err))
+		return nil, err
+	}
+
+	logger.Info("Public lead submitted",
+		logger.String("mp_id", mp.ID),
+		logger.String("track_id", track.ID),
+		logger.String("ip", clientIP),
+	)
+
+	return &dto.SubmitLeadResponse{
+		ID:        track.ID,
+		Status:    track.Status,
+		CreatedAt: track.CreatedAt,
+	}, nil
+}
+
+// findPublicCase 查找可公开的案件，已合并、已找到或已结案的案件视为不存在
+func (s *PublicCaseAppService) findPublicCase(ctx context.Context, id string) (*entity.MissingPerson, error) {
+	mp, err := s.mpRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, ErrMissingPersonNotFound
+	}
+	if mp.IsMerged() || (mp.Status != entity.MissingStatusMissing && mp.Status != entity.MissingStatusSearching) {
+		return nil, ErrMissingPersonNotFound
+	}
+	return mp, nil
+}
+
+// verifyCaptcha 校验验证码，启用缓存时同一验证码只能使用一次
+func (s *PublicCaseAppService) verifyCaptcha(ctx context.Context, token, answer string) error {
+	if s.captcha == nil {
+		return nil
+	}
+	if token == "" || answer == "" {
+		return ErrCaptchaRequired
+	}
+
+	nonce, err := s.captcha.Verify(token, answer)
+	if err != nil {
+		return ErrCaptchaInvalid
+	}
+
+	if s.cacheManager != nil {
+		ok, err := s.cacheManager.SetNX(ctx, "captcha:used:"+nonce, 1, s.captcha.TTL())
+		if err != nil {
+			logger.Warn("Failed to record captcha usage", logger.Err(err))
+			return nil
+		}
+		if !ok {
+			return ErrCaptchaInvalid
+		}
+	}
+	return nil
+}
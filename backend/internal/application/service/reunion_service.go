@@ -0,0 +1,367 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/dto"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/repository"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/logger"
+)
+
+var (
+	ErrReunionNotFound         = errors.New("reunion record not found")
+	ErrReunionInvalid          = errors.New("invalid reunion record")
+	ErrReunionRecordRequired   = errors.New("marking a case reunited requires a reunion record")
+	ErrReunionFollowUpNotFound = errors.New("reunion follow-up not found")
+)
+
+// followUpBatchSize 每次生成回访任务时读取的到期回访数
+const followUpBatchSize = 200
+
+// ReunionAppService 团聚记录应用服务：记录身份确认、团聚信息，并按 1、3、12 个月生成回访任务
+type ReunionAppService struct {
+	mpRepo       repository.MissingPersonRepository
+	reunionRepo  repository.ReunionRepository
+	taskService  *TaskAppService
+	auditService *AuditService
+
+	leadTime   time.Duration // 回访到期前提前生成任务的时间
+	dispatchMu sync.Mutex
+}
+
+// NewReunionAppService 创建团聚记录应用服务
+func NewReunionAppService(
+	mpRepo repository.MissingPersonRepository,
+	reunionRepo repository.ReunionRepository,
+	taskService *TaskAppService,
+	auditService *AuditService,
+	leadTime time.Duration,
+) *ReunionAppService {
+	return &ReunionAppService{
+		mpRepo:       mpRepo,
+		reunionRepo:  reunionRepo,
+		taskService:  taskService,
+		auditService: auditService,
+		leadTime:     leadTime,
+	}
+}
+
+// Start 定期为即将到期的回访生成任务，interval <= 0 时不启动，ctx 取消时停止
+func (s *ReunionAppService) Start(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if _, err := s.DispatchDueFollowUps(ctx); err != nil && ctx.Err() == nil {
+					logger.Warn("Failed to dispatch reunion follow-ups", logger.Err(err))
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Reunite 标记案件团聚并保存团聚记录，同时生成回访计划
+func (s *ReunionAppService) Reunite(ctx context.Context, personID string, req *dto.ReunionRecordRequest, operatorID, orgID string) (*dto.ReunionRecordResponse, error) {
+	mp, err := s.mpRepo.FindByID(ctx, personID)
+	if err != nil {
+		return nil, ErrMissingPersonNotFound
+	}
+
+	record := &entity.ReunionRecord{
+		MissingPersonID: mp.ID,
+		RecordedByID:    operatorID,
+	}
+	if err := applyReunionRequest(record, req, operatorID); err != nil {
+		return nil, err
+	}
+	record.ScheduleFollowUps()
+
+	log, err := mp.MarkReunited(operatorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.reunionRepo.CreateWithTransition(ctx, mp, log, record); err != nil {
+		logger.Error("Failed to save reunion record", logger.String("mp_id", mp.ID), logger.Err(err))
+		return nil, err
+	}
+
+	if s.auditService != nil {
+		auditLog := entity.NewAuditLog(operatorID, orgID, entity.AuditActionUpdate, string(entity.ResourceMissingPerson)).
+			SetResourceID(mp.ID).
+			SetResourceName(mp.CaseNo).
+			SetDescription(fmt.Sprintf("案件状态 %s -> %s，登记团聚记录", log.FromStatus, log.ToStatus)).
+			AddExtra("event", log.Event).
+			AddExtra("reunion_id", record.ID).
+			AddExtra("verification_methods", record.VerificationMethods).
+			AddExtra("publicity_consent", record.PublicityConsent)
+		s.auditService.Log(ctx, auditLog)
+	}
+
+	logger.Info("Case reunited",
+		logger.String("mp_id", mp.ID),
+		logger.String("reunion_id", record.ID),
+		logger.String("operator_id", operatorID),
+	)
+
+	// 补登的历史团聚可能已有回访到期
+	s.dispatchRecord(ctx, mp, record)
+
+	return s.GetRecord(ctx, mp.ID)
+}
+
+// GetRecord 获取案件的团聚记录
+func (s *ReunionAppService) GetRecord(ctx context.Context, personID string) (*dto.ReunionRecordResponse, error) {
+	record, err := s.reunionRepo.FindByMissingPersonID(ctx, personID)
+	if err != nil {
+		return nil, err
+	}
+	if record == nil {
+		return nil, ErrReunionNotFound
+	}
+
+	resp := dto.ToReunionRecordResponse(record)
+	return &resp, nil
+}
+
+// UpdateRecord 修改团聚记录，团聚时间变更时顺延尚未生成任务的回访
+func (s *ReunionAppService) UpdateRecord(ctx context.Context, personID string, req *dto.ReunionRecordRequest, operatorID, orgID string) (*dto.ReunionRecordResponse, error) {
+	record, err := s.reunionRepo.FindByMissingPersonID(ctx, personID)
+	if err != nil {
+		return nil, err
+	}
+	if record == nil {
+		return nil, ErrReunionNotFound
+	}
+
+	previousTime := record.ReunionTime
+	if err := applyReunionRequest(record, req, operatorID); err != nil {
+		return nil, err
+	}
+
+	// 关联数据不随记录保存，避免覆盖回访状态
+	followUps := record.FollowUps
+	record.FollowUps = nil
+	record.ConfirmedBy = nil
+	if err := s.reunionRepo.Update(ctx, record); err != nil {
+		logger.Error("Failed to update reunion record", logger.String("reunion_id", record.ID), logger.Err(err))
+		return nil, err
+	}
+	record.FollowUps = followUps
+
+	if !record.ReunionTime.Equal(previousTime) {
+		if err := s.reunionRepo.RescheduleFollowUps(ctx, record); err != nil {
+			logger.Error("Failed to reschedule reunion follow-ups", logger.String("reunion_id", record.ID), logger.Err(err))
+			return nil, err
+		}
+	}
+
+	if s.auditService != nil {
+		auditLog := entity.NewAuditLog(operatorID, orgID, entity.AuditActionUpdate, string(entity.ResourceMissingPerson)).
+			SetResourceID(personID).
+			SetDescription("修改团聚记录").
+			AddExtra("reunion_id", record.ID).
+			AddExtra("publicity_consent", record.PublicityConsent)
+		s.auditService.Log(ctx, auditLog)
+	}
+
+	return s.GetRecord(ctx, personID)
+}
+
+// CompleteFollowUp 记录回访结果，并完成关联的回访任务
+func (s *ReunionAppService) CompleteFollowUp(ctx context.Context, id string, req *dto.CompleteFollowUpRequest, userID string) (*dto.ReunionFollowUpResponse, error) {
+	followUp, err := s.reunionRepo.FindFollowUpByID(ctx, id)
+	if err != nil {
+		return nil, ErrReunionFollowUpNotFound
+	}
+
+	var visitedAt time.Time
+	if req.VisitedAt != nil {
+		visitedAt = *req.VisitedAt
+	}
+	if err := followUp.Complete(entity.FollowUpOutcome(req.Outcome), req.Note, userID, visitedAt); err != nil {
+		return nil, err
+	}
+
+	if err := s.reunionRepo.UpdateFollowUp(ctx, followUp); err != nil {
+		logger.Error("Failed to update reunion follow-up", logger.String("follow_up_id", id), logger.Err(err))
+		return nil, err
+	}
+
+	// 任务未认领或已结束时不影响回访结果的记录
+	if followUp.TaskID != nil {
+		result := fmt.Sprintf("回访结果：%s", req.Outcome)
+		if req.Note != "" {
+			result += "\n" + req.Note
+		}
+		if err := s.taskService.Complete(ctx, *followUp.TaskID, &dto.CompleteTaskRequest{Result: result}, userID); err != nil {
+			logger.Warn("Follow-up task not completed",
+				logger.String("follow_up_id", id),
+				logger.String("task_id", *followUp.TaskID),
+				logger.Err(err),
+			)
+		}
+	}
+
+	resp := dto.ToReunionFollowUpResponse(followUp, time.Now())
+	return &resp, nil
+}
+
+// DispatchDueFollowUps 为即将到期的回访生成回访任务，返回生成的任务数
+func (s *ReunionAppService) DispatchDueFollowUps(ctx context.Context) (int, error) {
+	s.dispatchMu.Lock()
+	defer s.dispatchMu.Unlock()
+
+	created := 0
+	for {
+		due, err := s.reunionRepo.FindDueFollowUps(ctx, time.Now().Add(s.leadTime), followUpBatchSize)
+		if err != nil {
+			return created, err
+		}
+		if len(due) == 0 {
+			return created, nil
+		}
+
+		progressed := false
+		for i := range due {
+			followUp := &due[i]
+			if followUp.Reunion == nil {
+				continue
+			}
+			mp, err := s.mpRepo.FindByID(ctx, followUp.MissingPersonID)
+			if err != nil {
+				logger.Warn("Reunion follow-up case missing", logger.String("follow_up_id", followUp.ID), logger.Err(err))
+				continue
+			}
+			if s.dispatchFollowUp(ctx, mp, followUp.Reunion, followUp) {
+				created++
+				progressed = true
+			}
+		}
+		// 本批全部失败时停止，等待下次调度重试
+		if !progressed || len(due) < followUpBatchSize {
+			return created, nil
+		}
+	}
+}
+
+// dispatchRecord 为团聚记录中已到期的回访生成任务
+func (s *ReunionAppService) dispatchRecord(ctx context.Context, mp *entity.MissingPerson, record *entity.ReunionRecord) {
+	s.dispatchMu.Lock()
+	defer s.dispatchMu.Unlock()
+
+	before := time.Now().Add(s.leadTime)
+	for i := range record.FollowUps {
+		followUp := &record.FollowUps[i]
+		if followUp.Status == entity.FollowUpStatusScheduled && !followUp.DueAt.After(before) {
+			s.dispatchFollowUp(ctx, mp, record, followUp)
+		}
+	}
+}
+
+// dispatchFollowUp 为单个回访生成任务并记录任务ID
+func (s *ReunionAppService) dispatchFollowUp(ctx context.Context, mp *entity.MissingPerson, record *entity.ReunionRecord, followUp *entity.ReunionFollowUp) bool {
+	task, err := s.taskService.CreateFollowUpTask(ctx, mp, record, followUp, record.RecordedByID)
+	if err != nil {
+		logger.Warn("Failed to create follow-up task", logger.String("follow_up_id", followUp.ID), logger.Err(err))
+		return false
+	}
+
+	followUp.TaskID = &task.ID
+	followUp.Status = entity.FollowUpStatusTasked
+	reunion := followUp.Reunion
+	followUp.Reunion = nil
+	err = s.reunionRepo.UpdateFollowUp(ctx, followUp)
+	followUp.Reunion = reunion
+	if err != nil {
+		logger.Error("Failed to link follow-up task", logger.String("follow_up_id", followUp.ID), logger.String("task_id", task.ID), logger.Err(err))
+		return false
+	}
+	return true
+}
+
+// GetFollowUpReport 团聚回访长期结果报告，orgID 为空时统计全部
+func (s *ReunionAppService) GetFollowUpReport(ctx context.Context, orgID string) (*dto.FollowUpReportResponse, error) {
+	now := time.Now()
+	stats, err := s.reunionRepo.FollowUpStats(ctx, orgID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.FollowUpReportItem, len(entity.FollowUpMonths))
+	index := make(map[int]int, len(entity.FollowUpMonths))
+	for i, month := range entity.FollowUpMonths {
+		items[i] = dto.FollowUpReportItem{Month: month, Outcomes: make(map[string]int64)}
+		index[month] = i
+	}
+
+	for _, st := range stats {
+		i, ok := index[st.Month]
+		if !ok {
+			continue
+		}
+		item := &items[i]
+		item.Total += st.Count
+		switch st.Status {
+		case entity.FollowUpStatusScheduled:
+			item.Scheduled += st.Count
+		case entity.FollowUpStatusTasked:
+			item.Tasked += st.Count
+		case entity.FollowUpStatusCompleted:
+			item.Completed += st.Count
+			item.Outcomes[string(st.Outcome)] += st.Count
+		}
+		if st.Overdue {
+			item.Overdue += st.Count
+		}
+	}
+
+	return &dto.FollowUpReportResponse{
+		OrgID:       orgID,
+		Items:       items,
+		GeneratedAt: now,
+	}, nil
+}
+
+// applyReunionRequest 将请求写入团聚记录并验证
+func applyReunionRequest(record *entity.ReunionRecord, req *dto.ReunionRecordRequest, operatorID string) error {
+	record.ConfirmedByID = strings.TrimSpace(req.ConfirmedByID)
+	if record.ConfirmedByID == "" {
+		record.ConfirmedByID = operatorID
+	}
+	if err := record.SetVerificationMethods(req.VerificationMethods); err != nil {
+		return fmt.Errorf("%w: %v", ErrReunionInvalid, err)
+	}
+	record.ConfirmerName = strings.TrimSpace(req.ConfirmerName)
+	record.VerificationNote = req.VerificationNote
+	record.ReunionTime = req.ReunionTime
+	record.Location = req.Location
+	record.Province = req.Province
+	record.City = req.City
+	record.District = req.District
+	record.Address = req.Address
+	record.Lat = req.Lat
+	record.Lng = req.Lng
+	record.PublicityConsent = req.PublicityConsent
+	record.ConsentBy = strings.TrimSpace(req.ConsentBy)
+	record.SetPhotos(req.Photos)
+	record.Note = req.Note
+
+	if err := record.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrReunionInvalid, err)
+	}
+	return nil
+}
@@ -0,0 +1,558 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/dto"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/repository"
+	domainService "github.com/Snowitty-Re/CNtunyuan/internal/domain/service"
+	"github.com/Snowitty-Re/CNtunyuan/internal/infrastructure/websocket"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/logger"
+)
+
+var (
+	ErrTaskOfferNotFound   = errors.New("task offer not found")
+	ErrTaskNotDispatchable = errors.New("only pending tasks can be dispatched")
+	ErrTaskOfferTaken      = errors.New("task has already been assigned")
+	ErrNoDispatchCandidate = errors.New("no available volunteer for this task")
+)
+
+const (
+	taskEntityType = "task"
+
+	// dispatchBatchSize 每次检查最多推送的任务数
+	dispatchBatchSize = 100
+)
+
+// TaskDispatchOptions 自动派单参数
+type TaskDispatchOptions struct {
+	OfferSize     int           // 每轮推送的志愿者数
+	OfferTTL      time.Duration // 邀请有效期，超时视为拒绝
+	MaxRounds     int           // 自动派单最多推送轮数，之后转人工分配
+	MaxOpenTasks  int           // 在办任务达到该数量的志愿者不再派单，0 不限
+	MaxDistanceKm float64       // 超过该距离的志愿者不参与派单，0 不限
+}
+
+// TaskDispatchAppService 任务自动派单应用服务：为待分配任务按评分推送给志愿者，先接单者获得任务
+type TaskDispatchAppService struct {
+	taskRepo            repository.TaskRepository
+	dispatchRepo        repository.TaskDispatchRepository
+	providers           []domainService.DispatchCandidateProvider
+	notificationService *NotificationAppService
+	wsManager           *websocket.Manager
+	opts                TaskDispatchOptions
+}
+
+// NewTaskDispatchAppService 创建任务自动派单应用服务，并注册接单、拒绝的 WebSocket 消息处理器
+// 未传入 providers 时只按仓储中的在办任务数和最近任务地点评分，技能、语言和档期不参与
+func NewTaskDispatchAppService(
+	taskRepo repository.TaskRepository,
+	dispatchRepo repository.TaskDispatchRepository,
+	notificationService *NotificationAppService,
+	wsManager *websocket.Manager,
+	opts TaskDispatchOptions,
+	providers ...domainService.DispatchCandidateProvider,
+) *TaskDispatchAppService {
+	if opts.OfferSize <= 0 {
+		opts.OfferSize = 3
+	}
+	if opts.OfferTTL <= 0 {
+		opts.OfferTTL = 10 * time.Minute
+	}
+	if opts.MaxRounds <= 0 {
+		opts.MaxRounds = 3
+	}
+	s := &TaskDispatchAppService{
+		taskRepo:            taskRepo,
+		dispatchRepo:        dispatchRepo,
+		providers:           providers,
+		notificationService: notificationService,
+		wsManager:           wsManager,
+		opts:                opts,
+	}
+	if wsManager != nil {
+		wsManager.HandleFunc(entity.WSMessageTypeTaskOfferAccept, s.handleAccept)
+		wsManager.HandleFunc(entity.WSMessageTypeTaskOfferDecline, s.handleDecline)
+	}
+	return s
+}
+
+// Start 启动定时派单：处理超时邀请，并为没有待响应邀请的待分配任务推送下一轮，ctx 取消时停止
+func (s *TaskDispatchAppService) Start(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if _, err := s.DispatchPending(ctx); err != nil && ctx.Err() == nil {
+					logger.Warn("Failed to dispatch pending tasks", logger.Err(err))
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// DispatchPending 将超时邀请标记为超时，并为需要的待分配任务推送下一轮，返回处理的任务数
+func (s *TaskDispatchAppService) DispatchPending(ctx context.Context) (int, error) {
+	expired, err := s.dispatchRepo.ExpireOffers(ctx, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	for i := range expired {
+		s.pushClosed(&expired[i], "邀请已超时")
+	}
+
+	tasks, err := s.dispatchRepo.FindTasksToDispatch(ctx, dispatchBatchSize)
+	if err != nil {
+		return 0, err
+	}
+	for i := range tasks {
+		if err := s.dispatchNext(ctx, &tasks[i]); err != nil {
+			logger.Warn("Failed to dispatch task", logger.String("task_id", tasks[i].ID), logger.Err(err))
+		}
+	}
+	if len(expired) > 0 || len(tasks) > 0 {
+		logger.Info("Pending tasks dispatched", logger.Int("expired_offers", len(expired)), logger.Int("tasks", len(tasks)))
+	}
+	return len(tasks), nil
+}
+
+// Candidates 预览任务的候选志愿者及评分
+func (s *TaskDispatchAppService) Candidates(ctx context.Context, taskID string, req *dto.DispatchCandidateRequest, orgID string) ([]dto.DispatchCandidateResponse, error) {
+	task, err := s.findTask(ctx, taskID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	matches, err := s.rank(ctx, task)
+	if err != nil {
+		return nil, err
+	}
+	offered, err := s.offeredUsers(ctx, task.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(matches) > req.Limit {
+		matches = matches[:req.Limit]
+	}
+	list := make([]dto.DispatchCandidateResponse, len(matches))
+	for i := range matches {
+		list[i] = dto.ToDispatchCandidateResponse(&matches[i])
+		list[i].Offered = offered[matches[i].Candidate.UserID]
+	}
+	return list, nil
+}
+
+// Dispatch 主管手动推送一轮：指定志愿者，或按评分取未推送过的前几位。已转人工的任务也可重新推送
+func (s *TaskDispatchAppService) Dispatch(ctx context.Context, taskID string, req *dto.DispatchTaskRequest, orgID string) (*dto.TaskDispatchResponse, error) {
+	task, err := s.findTask(ctx, taskID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if !task.CanDispatch() {
+		return nil, ErrTaskNotDispatchable
+	}
+
+	matches, err := s.rank(ctx, task)
+	if err != nil {
+		return nil, err
+	}
+	size := s.opts.OfferSize
+	if len(req.UserIDs) > 0 {
+		wanted := make(map[string]bool, len(req.UserIDs))
+		for _, id := range req.UserIDs {
+			wanted[id] = true
+		}
+		selected := matches[:0]
+		for _, m := range matches {
+			if wanted[m.Candidate.UserID] {
+				selected = append(selected, m)
+			}
+		}
+		matches, size = selected, len(selected)
+	} else {
+		offered, err := s.offeredUsers(ctx, task.ID)
+		if err != nil {
+			return nil, err
+		}
+		matches = excludeOffered(matches, offered)
+		if req.Size > 0 {
+			size = req.Size
+		}
+	}
+	if len(matches) == 0 {
+		return nil, ErrNoDispatchCandidate
+	}
+
+	offers, err := s.offer(ctx, task, matches, size)
+	if err != nil {
+		return nil, err
+	}
+	if offers == nil {
+		return nil, ErrTaskNotDispatchable
+	}
+
+	resp := &dto.TaskDispatchResponse{
+		TaskID:         task.ID,
+		DispatchStatus: string(task.DispatchStatus),
+		DispatchRound:  task.DispatchRound,
+		Offers:         make([]dto.TaskOfferResponse, len(offers)),
+	}
+	for i, o := range offers {
+		resp.Offers[i] = dto.ToTaskOfferResponse(o)
+	}
+	return resp, nil
+}
+
+// Accept 志愿者接单
+func (s *TaskDispatchAppService) Accept(ctx context.Context, offerID, userID string) (*dto.TaskOfferResponse, error) {
+	offer, err := s.findOffer(ctx, offerID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := offer.Accept(time.Now()); err != nil {
+		return nil, err
+	}
+
+	accepted, withdrawn, err := s.dispatchRepo.Accept(ctx, offer)
+	if err != nil {
+		logger.Error("Failed to accept task offer", logger.String("offer_id", offer.ID), logger.Err(err))
+		return nil, err
+	}
+	if !accepted {
+		task, err := s.taskRepo.FindByID(ctx, offer.TaskID)
+		if err != nil || task.CanDispatch() {
+			return nil, entity.ErrTaskOfferClosed
+		}
+		// 任务已由他人接单或已人工分配，撤回剩余邀请
+		closed, err := s.dispatchRepo.WithdrawByTask(ctx, offer.TaskID)
+		if err != nil {
+			logger.Warn("Failed to withdraw task offers", logger.String("task_id", offer.TaskID), logger.Err(err))
+		}
+		for i := range closed {
+			s.pushClosed(&closed[i], "任务已分配")
+		}
+		return nil, ErrTaskOfferTaken
+	}
+
+	for i := range withdrawn {
+		s.pushClosed(&withdrawn[i], "任务已被其他志愿者接单")
+	}
+	if offer.Task != nil {
+		offer.Task.Assign(offer.UserID)
+		offer.Task.DispatchStatus = entity.TaskDispatchAccepted
+		s.notifyCreator(ctx, offer.Task, "任务已被接单",
+			fmt.Sprintf("任务「%s」已由志愿者在第 %d 轮派单中接单。", offer.Task.Title, offer.Round))
+	}
+
+	logger.Info("Task offer accepted",
+		logger.String("offer_id", offer.ID),
+		logger.String("task_id", offer.TaskID),
+		logger.String("user_id", userID),
+	)
+
+	resp := dto.ToTaskOfferResponse(offer)
+	return &resp, nil
+}
+
+// Decline 志愿者拒绝派单，本轮无人待响应时立即推送下一轮
+func (s *TaskDispatchAppService) Decline(ctx context.Context, offerID string, req *dto.DeclineTaskOfferRequest, userID string) (*dto.TaskOfferResponse, error) {
+	offer, err := s.findOffer(ctx, offerID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := offer.Decline(req.Reason, time.Now()); err != nil {
+		return nil, err
+	}
+
+	saved, err := s.dispatchRepo.Respond(ctx, offer)
+	if err != nil {
+		return nil, err
+	}
+	if !saved {
+		return nil, entity.ErrTaskOfferClosed
+	}
+
+	if offer.Task != nil && offer.Task.CanDispatch() {
+		open, err := s.dispatchRepo.CountOpen(ctx, offer.TaskID)
+		if err == nil && open == 0 {
+			if err := s.dispatchNext(ctx, offer.Task); err != nil {
+				logger.Warn("Failed to dispatch next round", logger.String("task_id", offer.TaskID), logger.Err(err))
+			}
+		}
+	}
+
+	resp := dto.ToTaskOfferResponse(offer)
+	return &resp, nil
+}
+
+// MyOffers 当前志愿者待响应的派单邀请
+func (s *TaskDispatchAppService) MyOffers(ctx context.Context, userID string) ([]dto.TaskOfferResponse, error) {
+	offers, err := s.dispatchRepo.ListOpenByUser(ctx, userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	list := make([]dto.TaskOfferResponse, len(offers))
+	for i := range offers {
+		list[i] = dto.ToTaskOfferResponse(&offers[i])
+	}
+	return list, nil
+}
+
+// TaskOffers 任务的派单记录
+func (s *TaskDispatchAppService) TaskOffers(ctx context.Context, taskID, orgID string) ([]dto.TaskOfferResponse, error) {
+	task, err := s.findTask(ctx, taskID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	offers, err := s.dispatchRepo.ListByTask(ctx, task.ID)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]dto.TaskOfferResponse, len(offers))
+	for i := range offers {
+		list[i] = dto.ToTaskOfferResponse(&offers[i])
+	}
+	return list, nil
+}
+
+// dispatchNext 自动推送下一轮；已达最大轮数或没有新的候选人时转人工分配并通知任务创建人
+func (s *TaskDispatchAppService) dispatchNext(ctx context.Context, task *entity.Task) error {
+	var matches []entity.DispatchMatch
+	if task.DispatchRound < s.opts.MaxRounds {
+		ranked, err := s.rank(ctx, task)
+		if err != nil {
+			return err
+		}
+		offered, err := s.offeredUsers(ctx, task.ID)
+		if err != nil {
+			return err
+		}
+		matches = excludeOffered(ranked, offered)
+	}
+	if len(matches) > 0 {
+		_, err := s.offer(ctx, task, matches, s.opts.OfferSize)
+		return err
+	}
+
+	task.DispatchStatus = entity.TaskDispatchExhausted
+	if err := s.dispatchRepo.UpdateTaskDispatch(ctx, task); err != nil {
+		return err
+	}
+	s.notifyCreator(ctx, task, "任务无人接单",
+		fmt.Sprintf("任务「%s」经过 %d 轮派单仍无人接单，请手动分配。", task.Title, task.DispatchRound))
+	logger.Info("Task dispatch exhausted", logger.String("task_id", task.ID), logger.Int("rounds", task.DispatchRound))
+	return nil
+}
+
+// offer 向排名前 size 位的候选人推送一轮邀请；任务已被并发推送或已分配时返回 nil
+func (s *TaskDispatchAppService) offer(ctx context.Context, task *entity.Task, matches []entity.DispatchMatch, size int) ([]*entity.TaskOffer, error) {
+	if len(matches) > size {
+		matches = matches[:size]
+	}
+	now := time.Now()
+	task.DispatchRound++
+	task.DispatchStatus = entity.TaskDispatchOffering
+	task.DispatchedAt = &now
+
+	offers := make([]*entity.TaskOffer, len(matches))
+	for i := range matches {
+		offers[i] = entity.NewTaskOffer(task, &matches[i], task.DispatchRound, i+1, now.Add(s.opts.OfferTTL))
+	}
+	saved, err := s.dispatchRepo.CreateOffers(ctx, task, offers)
+	if err != nil || !saved {
+		return nil, err
+	}
+
+	for _, o := range offers {
+		s.pushOffer(task, o)
+	}
+	logger.Info("Task offered",
+		logger.String("task_id", task.ID),
+		logger.Int("round", task.DispatchRound),
+		logger.Int("offers", len(offers)),
+	)
+	return offers, nil
+}
+
+// rank 取候选人，经各 Provider 补充后评分排序
+func (s *TaskDispatchAppService) rank(ctx context.Context, task *entity.Task) ([]entity.DispatchMatch, error) {
+	candidates, err := s.dispatchRepo.FindCandidates(ctx, task.OrgID)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range s.providers {
+		if candidates, err = p.Enrich(ctx, task, candidates); err != nil {
+			return nil, err
+		}
+	}
+	return entity.RankDispatchCandidates(task, candidates, entity.DispatchOptions{
+		MaxDistanceKm: s.opts.MaxDistanceKm,
+		MaxOpenTasks:  s.opts.MaxOpenTasks,
+	}), nil
+}
+
+// offeredUsers 任务已推送过的志愿者
+func (s *TaskDispatchAppService) offeredUsers(ctx context.Context, taskID string) (map[string]bool, error) {
+	userIDs, err := s.dispatchRepo.OfferedUserIDs(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	offered := make(map[string]bool, len(userIDs))
+	for _, id := range userIDs {
+		offered[id] = true
+	}
+	return offered, nil
+}
+
+// excludeOffered 去掉已推送过的候选人，每位志愿者对同一任务只推送一次
+func excludeOffered(matches []entity.DispatchMatch, offered map[string]bool) []entity.DispatchMatch {
+	rest := make([]entity.DispatchMatch, 0, len(matches))
+	for _, m := range matches {
+		if !offered[m.Candidate.UserID] {
+			rest = append(rest, m)
+		}
+	}
+	return rest
+}
+
+// findTask 查找本组织的任务
+func (s *TaskDispatchAppService) findTask(ctx context.Context, id, orgID string) (*entity.Task, error) {
+	task, err := s.taskRepo.FindByID(ctx, id)
+	if err != nil || task.OrgID != orgID {
+		return nil, ErrTaskNotFound
+	}
+	return task, nil
+}
+
+// findOffer 查找推送给该志愿者的邀请
+func (s *TaskDispatchAppService) findOffer(ctx context.Context, id, userID string) (*entity.TaskOffer, error) {
+	offer, err := s.dispatchRepo.FindByIDWithTask(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if offer == nil || offer.UserID != userID {
+		return nil, ErrTaskOfferNotFound
+	}
+	return offer, nil
+}
+
+// pushOffer 通过 WebSocket 向志愿者推送派单邀请，离线志愿者上线后可在邀请列表中查看
+func (s *TaskDispatchAppService) pushOffer(task *entity.Task, offer *entity.TaskOffer) {
+	if s.wsManager == nil {
+		return
+	}
+	msg := entity.NewWebSocketMessage(entity.WSMessageTypeTaskOffer, "新的任务邀请", task.Title)
+	msg.Data = map[string]interface{}{
+		"offer_id":   offer.ID,
+		"task_id":    task.ID,
+		"task_type":  task.Type,
+		"priority":   task.Priority,
+		"location":   task.Location,
+		"deadline":   task.Deadline,
+		"round":      offer.Round,
+		"expires_at": offer.ExpiresAt,
+	}
+	if offer.DistanceKm != nil {
+		msg.Data["distance_km"] = *offer.DistanceKm
+	}
+	if err := s.wsManager.SendToUser(offer.UserID, msg); err != nil {
+		logger.Warn("Failed to push task offer", logger.String("offer_id", offer.ID), logger.Err(err))
+	}
+}
+
+// pushClosed 通知志愿者邀请已失效
+func (s *TaskDispatchAppService) pushClosed(offer *entity.TaskOffer, reason string) {
+	if s.wsManager == nil {
+		return
+	}
+	msg := entity.NewWebSocketMessage(entity.WSMessageTypeTaskOfferClosed, "任务邀请已失效", reason)
+	msg.Data = map[string]interface{}{
+		"offer_id": offer.ID,
+		"task_id":  offer.TaskID,
+		"status":   offer.Status,
+	}
+	if err := s.wsManager.SendToUser(offer.UserID, msg); err != nil {
+		logger.Warn("Failed to push task offer closed", logger.String("offer_id", offer.ID), logger.Err(err))
+	}
+}
+
+// notifyCreator 通知任务创建人派单结果
+func (s *TaskDispatchAppService) notifyCreator(ctx context.Context, task *entity.Task, title, content string) {
+	if s.notificationService == nil {
+		return
+	}
+	req := &dto.SendNotificationRequest{
+		Type:         entity.NotificationTypeTask,
+		Channel:      entity.NotificationChannelWebSocket,
+		Priority:     entity.PriorityNormal,
+		Title:        title,
+		Content:      content,
+		ToUserID:     task.CreatorID,
+		OrgID:        task.OrgID,
+		BusinessType: taskEntityType,
+		BusinessID:   &task.ID,
+		Data: map[string]interface{}{
+			"task_id":         task.ID,
+			"dispatch_status": string(task.DispatchStatus),
+			"dispatch_round":  task.DispatchRound,
+		},
+	}
+	if task.AssigneeID != nil {
+		req.Data["assignee_id"] = *task.AssigneeID
+	}
+	if err := s.notificationService.SendNotification(ctx, req); err != nil {
+		logger.Error("Failed to notify task creator", logger.String("task_id", task.ID), logger.Err(err))
+	}
+}
+
+// taskOfferMessage 客户端上行的接单、拒绝消息
+type taskOfferMessage struct {
+	OfferID string `json:"offer_id"`
+	Reason  string `json:"reason"`
+}
+
+// handleAccept 处理 WebSocket 接单消息
+func (s *TaskDispatchAppService) handleAccept(userID, orgID string, data json.RawMessage) *entity.WebSocketMessage {
+	var req taskOfferMessage
+	if err := json.Unmarshal(data, &req); err != nil || req.OfferID == "" {
+		return taskOfferResult(req.OfferID, "accept", ErrTaskOfferNotFound)
+	}
+	_, err := s.Accept(context.Background(), req.OfferID, userID)
+	return taskOfferResult(req.OfferID, "accept", err)
+}
+
+// handleDecline 处理 WebSocket 拒绝消息
+func (s *TaskDispatchAppService) handleDecline(userID, orgID string, data json.RawMessage) *entity.WebSocketMessage {
+	var req taskOfferMessage
+	if err := json.Unmarshal(data, &req); err != nil || req.OfferID == "" {
+		return taskOfferResult(req.OfferID, "decline", ErrTaskOfferNotFound)
+	}
+	_, err := s.Decline(context.Background(), req.OfferID, &dto.DeclineTaskOfferRequest{Reason: req.Reason}, userID)
+	return taskOfferResult(req.OfferID, "decline", err)
+}
+
+// taskOfferResult 接单、拒绝的处理结果消息
+func taskOfferResult(offerID, action string, err error) *entity.WebSocketMessage {
+	msg := entity.NewWebSocketMessage(entity.WSMessageTypeTaskOfferResult, "", "")
+	msg.Data = map[string]interface{}{
+		"offer_id": offerID,
+		"action":   action,
+		"success":  err == nil,
+	}
+	if err != nil {
+		msg.Data["error"] = err.Error()
+	}
+	return msg
+}
@@ -0,0 +1,306 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	domainService "github.com/Snowitty-Re/CNtunyuan/internal/domain/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockTaskDispatchRepository 任务派单仓储Mock
+type MockTaskDispatchRepository struct {
+	mock.Mock
+}
+
+func (m *MockTaskDispatchRepository) Create(ctx context.Context, offer *entity.TaskOffer) error {
+	args := m.Called(ctx, offer)
+	return args.Error(0)
+}
+
+func (m *MockTaskDispatchRepository) Update(ctx context.Context, offer *entity.TaskOffer) error {
+	args := m.Called(ctx, offer)
+	return args.Error(0)
+}
+
+func (m *MockTaskDispatchRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockTaskDispatchRepository) SoftDelete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockTaskDispatchRepository) FindByID(ctx context.Context, id string) (*entity.TaskOffer, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.TaskOffer), args.Error(1)
+}
+
+func (m *MockTaskDispatchRepository) FindAll(ctx context.Context) ([]entity.TaskOffer, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]entity.TaskOffer), args.Error(1)
+}
+
+func (m *MockTaskDispatchRepository) Count(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockTaskDispatchRepository) Exists(ctx context.Context, id string) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTaskDispatchRepository) FindCandidates(ctx context.Context, orgID string) ([]entity.DispatchCandidate, error) {
+	args := m.Called(ctx, orgID)
+	return args.Get(0).([]entity.DispatchCandidate), args.Error(1)
+}
+
+func (m *MockTaskDispatchRepository) OfferedUserIDs(ctx context.Context, taskID string) ([]string, error) {
+	args := m.Called(ctx, taskID)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockTaskDispatchRepository) CreateOffers(ctx context.Context, task *entity.Task, offers []*entity.TaskOffer) (bool, error) {
+	args := m.Called(ctx, task, offers)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTaskDispatchRepository) FindByIDWithTask(ctx context.Context, id string) (*entity.TaskOffer, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.TaskOffer), args.Error(1)
+}
+
+func (m *MockTaskDispatchRepository) ListByTask(ctx context.Context, taskID string) ([]entity.TaskOffer, error) {
+	args := m.Called(ctx, taskID)
+	return args.Get(0).([]entity.TaskOffer), args.Error(1)
+}
+
+func (m *MockTaskDispatchRepository) ListOpenByUser(ctx context.Context, userID string, now time.Time) ([]entity.TaskOffer, error) {
+	args := m.Called(ctx, userID, now)
+	return args.Get(0).([]entity.TaskOffer), args.Error(1)
+}
+
+func (m *MockTaskDispatchRepository) Accept(ctx context.Context, offer *entity.TaskOffer) (bool, []entity.TaskOffer, error) {
+	args := m.Called(ctx, offer)
+	return args.Bool(0), args.Get(1).([]entity.TaskOffer), args.Error(2)
+}
+
+func (m *MockTaskDispatchRepository) Respond(ctx context.Context, offer *entity.TaskOffer) (bool, error) {
+	args := m.Called(ctx, offer)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTaskDispatchRepository) ExpireOffers(ctx context.Context, now time.Time) ([]entity.TaskOffer, error) {
+	args := m.Called(ctx, now)
+	return args.Get(0).([]entity.TaskOffer), args.Error(1)
+}
+
+func (m *MockTaskDispatchRepository) WithdrawByTask(ctx context.Context, taskID string) ([]entity.TaskOffer, error) {
+	args := m.Called(ctx, taskID)
+	return args.Get(0).([]entity.TaskOffer), args.Error(1)
+}
+
+func (m *MockTaskDispatchRepository) CountOpen(ctx context.Context, taskID string) (int64, error) {
+	args := m.Called(ctx, taskID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockTaskDispatchRepository) FindTasksToDispatch(ctx context.Context, limit int) ([]entity.Task, error) {
+	args := m.Called(ctx, limit)
+	return args.Get(0).([]entity.Task), args.Error(1)
+}
+
+func (m *MockTaskDispatchRepository) UpdateTaskDispatch(ctx context.Context, task *entity.Task) error {
+	args := m.Called(ctx, task)
+	return args.Error(0)
+}
+
+// dispatchProviderFunc 以函数实现 DispatchCandidateProvider
+type dispatchProviderFunc func(ctx context.Context, task *entity.Task, candidates []entity.DispatchCandidate) ([]entity.DispatchCandidate, error)
+
+func (f dispatchProviderFunc) Enrich(ctx context.Context, task *entity.Task, candidates []entity.DispatchCandidate) ([]entity.DispatchCandidate, error) {
+	return f(ctx, task, candidates)
+}
+
+func dispatchTask(round int) entity.Task {
+	task := entity.Task{Title: "沿河搜寻", OrgID: "org-001", Status: entity.TaskStatusPending, DispatchRound: round, Lat: 30, Lng: 104}
+	task.ID = "task-001"
+	return task
+}
+
+// offerUsers 按推送顺序列出邀请的志愿者
+func offerUsers(offers []*entity.TaskOffer) []string {
+	users := make([]string, len(offers))
+	for i, o := range offers {
+		users[i] = o.UserID
+	}
+	return users
+}
+
+func TestTaskDispatchAppService_DispatchPending(t *testing.T) {
+	ctx := context.Background()
+	candidates := func() []entity.DispatchCandidate {
+		return []entity.DispatchCandidate{
+			{UserID: "far", Lat: 31, Lng: 104},
+			{UserID: "busy", Lat: 30, Lng: 104, OpenTasks: 2},
+			{UserID: "near", Lat: 30.01, Lng: 104},
+			{UserID: "unknown"},
+		}
+	}
+	// 档期来源：排除休假的志愿者
+	schedule := dispatchProviderFunc(func(_ context.Context, _ *entity.Task, list []entity.DispatchCandidate) ([]entity.DispatchCandidate, error) {
+		for i := range list {
+			if list[i].UserID == "near" {
+				list[i].Unavailable = "休假中"
+			}
+		}
+		return list, nil
+	})
+
+	tests := []struct {
+		name       string
+		round      int
+		providers  []domainService.DispatchCandidateProvider
+		offered    []string
+		candidates []entity.DispatchCandidate
+		wantOffers []string
+		exhausted  bool
+	}{
+		{
+			name:       "first round offers the top candidates",
+			candidates: candidates(),
+			wantOffers: []string{"near", "busy"},
+		},
+		{
+			name:       "providers filter candidates",
+			providers:  []domainService.DispatchCandidateProvider{schedule},
+			candidates: candidates(),
+			wantOffers: []string{"busy", "unknown"},
+		},
+		{
+			name:       "next round skips volunteers already offered",
+			round:      1,
+			offered:    []string{"near", "busy"},
+			candidates: candidates(),
+			wantOffers: []string{"unknown", "far"},
+		},
+		{
+			name:       "no candidates left",
+			round:      1,
+			offered:    []string{"near", "busy", "unknown", "far"},
+			candidates: candidates(),
+			exhausted:  true,
+		},
+		{
+			name:       "empty organization",
+			candidates: []entity.DispatchCandidate{},
+			exhausted:  true,
+		},
+		{
+			name:      "max rounds reached",
+			round:     3,
+			exhausted: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockTaskDispatchRepository)
+			service := NewTaskDispatchAppService(nil, repo, nil, nil, TaskDispatchOptions{OfferSize: 2, MaxRounds: 3}, tt.providers...)
+
+			repo.On("ExpireOffers", ctx, mock.AnythingOfType("time.Time")).Return([]entity.TaskOffer{}, nil).Once()
+			repo.On("FindTasksToDispatch", ctx, dispatchBatchSize).Return([]entity.Task{dispatchTask(tt.round)}, nil).Once()
+			if tt.candidates != nil {
+				repo.On("FindCandidates", ctx, "org-001").Return(tt.candidates, nil).Once()
+				repo.On("OfferedUserIDs", ctx, "task-001").Return(tt.offered, nil).Once()
+			}
+			var saved []*entity.TaskOffer
+			if tt.wantOffers != nil {
+				repo.On("CreateOffers", ctx, mock.MatchedBy(func(task *entity.Task) bool {
+					return task.DispatchRound == tt.round+1 && task.DispatchStatus == entity.TaskDispatchOffering
+				}), mock.Anything).Run(func(args mock.Arguments) {
+					saved = args.Get(2).([]*entity.TaskOffer)
+				}).Return(true, nil).Once()
+			}
+			if tt.exhausted {
+				repo.On("UpdateTaskDispatch", ctx, mock.MatchedBy(func(task *entity.Task) bool {
+					return task.DispatchStatus == entity.TaskDispatchExhausted
+				})).Return(nil).Once()
+			}
+
+			n, err := service.DispatchPending(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, 1, n)
+			if tt.wantOffers != nil {
+				assert.Equal(t, tt.wantOffers, offerUsers(saved))
+				for i, o := range saved {
+					assert.Equal(t, tt.round+1, o.Round)
+					assert.Equal(t, i+1, o.Position)
+					assert.True(t, o.IsOpen(time.Now()))
+				}
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTaskDispatchAppService_DispatchPendingErrors(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("provider error skips the task", func(t *testing.T) {
+		failing := dispatchProviderFunc(func(context.Context, *entity.Task, []entity.DispatchCandidate) ([]entity.DispatchCandidate, error) {
+			return nil, errors.New("profile store unavailable")
+		})
+		repo := new(MockTaskDispatchRepository)
+		service := NewTaskDispatchAppService(nil, repo, nil, nil, TaskDispatchOptions{}, failing)
+
+		repo.On("ExpireOffers", ctx, mock.AnythingOfType("time.Time")).Return([]entity.TaskOffer{}, nil).Once()
+		repo.On("FindTasksToDispatch", ctx, dispatchBatchSize).Return([]entity.Task{dispatchTask(0)}, nil).Once()
+		repo.On("FindCandidates", ctx, "org-001").Return([]entity.DispatchCandidate{{UserID: "near"}}, nil).Once()
+
+		n, err := service.DispatchPending(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("concurrent round not saved", func(t *testing.T) {
+		repo := new(MockTaskDispatchRepository)
+		service := NewTaskDispatchAppService(nil, repo, nil, nil, TaskDispatchOptions{})
+
+		repo.On("ExpireOffers", ctx, mock.AnythingOfType("time.Time")).Return([]entity.TaskOffer{}, nil).Once()
+		repo.On("FindTasksToDispatch", ctx, dispatchBatchSize).Return([]entity.Task{dispatchTask(0)}, nil).Once()
+		repo.On("FindCandidates", ctx, "org-001").Return([]entity.DispatchCandidate{{UserID: "near"}}, nil).Once()
+		repo.On("OfferedUserIDs", ctx, "task-001").Return([]string{}, nil).Once()
+		repo.On("CreateOffers", ctx, mock.AnythingOfType("*entity.Task"), mock.Anything).Return(false, nil).Once()
+
+		_, err := service.DispatchPending(ctx)
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("expire failure stops the run", func(t *testing.T) {
+		repo := new(MockTaskDispatchRepository)
+		service := NewTaskDispatchAppService(nil, repo, nil, nil, TaskDispatchOptions{})
+
+		repo.On("ExpireOffers", ctx, mock.AnythingOfType("time.Time")).Return([]entity.TaskOffer{}, errors.New("db down")).Once()
+
+		_, err := service.DispatchPending(ctx)
+		assert.Error(t, err)
+		repo.AssertExpectations(t)
+	})
+}
@@ -3,6 +3,8 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/Snowitty-Re/CNtunyuan/internal/application/dto"
 	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
@@ -50,6 +52,8 @@ func (s *TaskAppService) Create(ctx context.Context, req *dto.CreateTaskRequest,
 	if req.Priority == "" {
 		task.Priority = entity.TaskPriorityMedium
 	}
+	task.SetSkills(req.Skills)
+	task.SetLanguages(req.Languages)
 
 	if err := s.taskRepo.Create(ctx, task); err != nil {
 		logger.Error("Failed to create task", logger.Err(err))
@@ -62,6 +66,111 @@ func (s *TaskAppService) Create(ctx context.Context, req *dto.CreateTaskRequest,
 	return &resp, nil
 }
 
+// verifyTaskDeadline 线索核实任务的默认期限
+const verifyTaskDeadline = 24 * time.Hour
+
+// CreateVerifyTask 为已确认的线索创建待分配的实地核实任务
+func (s *TaskAppService) CreateVerifyTask(ctx context.Context, mp *entity.MissingPerson, track *entity.MissingPersonTrack, orgID, creatorID string) (*dto.TaskResponse, error) {
+	title := fmt.Sprintf("核实线索：%s", mp.Name)
+	task, err := entity.NewTask(title, entity.TaskTypeVerify, creatorID, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(verifyTaskDeadline)
+	task.Description = fmt.Sprintf("案件 %s 的线索（%s）已确认，请前往现场核实。\n%s", mp.CaseNo, track.ID, track.Description)
+	task.Status = entity.TaskStatusPending
+	task.Priority = verifyTaskPriority(mp.Urgency)
+	task.Deadline = &deadline
+	task.MissingPersonID = &mp.ID
+	task.Location = track.Location
+	task.Province = track.Province
+	task.City = track.City
+	task.District = track.District
+	task.Address = track.Address
+	task.Lat = track.Lat
+	task.Lng = track.Lng
+
+	if err := s.taskRepo.Create(ctx, task); err != nil {
+		logger.Error("Failed to create verify task", logger.String("track_id", track.ID), logger.Err(err))
+		return nil, err
+	}
+
+	s.taskRepo.AddLog(ctx, &entity.TaskLog{
+		TaskID:    task.ID,
+		UserID:    creatorID,
+		Action:    "create",
+		NewStatus: string(task.Status),
+		Content:   "Created from confirmed sighting " + track.ID,
+	})
+
+	logger.Info("Verify task created",
+		logger.String("task_id", task.ID),
+		logger.String("track_id", track.ID),
+		logger.String("org_id", orgID),
+	)
+
+	resp := dto.ToTaskResponse(task)
+	return &resp, nil
+}
+
+// CreateFollowUpTask 为团聚后的回访节点创建待分配的回访任务，期限为回访到期日
+func (s *TaskAppService) CreateFollowUpTask(ctx context.Context, mp *entity.MissingPerson, record *entity.ReunionRecord, followUp *entity.ReunionFollowUp, creatorID string) (*dto.TaskResponse, error) {
+	title := fmt.Sprintf("团聚回访（%d个月）：%s", followUp.Month, mp.Name)
+	task, err := entity.NewTask(title, entity.TaskTypeFollow, creatorID, mp.OrgID)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := followUp.DueAt
+	task.Description = fmt.Sprintf("案件 %s 已于 %s 团聚，请在期限前完成 %d 个月回访，并记录回访结果。",
+		mp.CaseNo, record.ReunionTime.Format("2006-01-02"), followUp.Month)
+	task.Status = entity.TaskStatusPending
+	task.Deadline = &deadline
+	task.MissingPersonID = &mp.ID
+	task.Location = record.Location
+	task.Province = record.Province
+	task.City = record.City
+	task.District = record.District
+	task.Address = record.Address
+	task.Lat = record.Lat
+	task.Lng = record.Lng
+
+	if err := s.taskRepo.Create(ctx, task); err != nil {
+		logger.Error("Failed to create follow-up task", logger.String("follow_up_id", followUp.ID), logger.Err(err))
+		return nil, err
+	}
+
+	s.taskRepo.AddLog(ctx, &entity.TaskLog{
+		TaskID:    task.ID,
+		UserID:    creatorID,
+		Action:    "create",
+		NewStatus: string(task.Status),
+		Content:   fmt.Sprintf("Created from reunion follow-up %s (%d months)", followUp.ID, followUp.Month),
+	})
+
+	logger.Info("Follow-up task created",
+		logger.String("task_id", task.ID),
+		logger.String("follow_up_id", followUp.ID),
+		logger.String("org_id", mp.OrgID),
+	)
+
+	resp := dto.ToTaskResponse(task)
+	return &resp, nil
+}
+
+// verifyTaskPriority 按案件紧急程度确定核实任务优先级
+func verifyTaskPriority(urgency entity.UrgencyLevel) entity.TaskPriority {
+	switch urgency {
+	case entity.UrgencyLevelCritical:
+		return entity.TaskPriorityUrgent
+	case entity.UrgencyLevelHigh:
+		return entity.TaskPriorityHigh
+	default:
+		return entity.TaskPriorityMedium
+	}
+}
+
 // GetByID 根据ID获取
 func (s *TaskAppService) GetByID(ctx context.Context, id string) (*dto.TaskResponse, error) {
 	task, err := s.taskRepo.FindByID(ctx, id)
@@ -141,6 +250,12 @@ func (s *TaskAppService) Update(ctx context.Context, id string, req *dto.UpdateT
 	if req.Address != "" {
 		task.Address = req.Address
 	}
+	if req.Skills != nil {
+		task.SetSkills(req.Skills)
+	}
+	if req.Languages != nil {
+		task.SetLanguages(req.Languages)
+	}
 
 	if err := s.taskRepo.Update(ctx, task); err != nil {
 		logger.Error("Failed to update task", logger.Err(err))
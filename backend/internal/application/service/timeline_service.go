@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/dto"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/repository"
+	domainService "github.com/Snowitty-Re/CNtunyuan/internal/domain/service"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/geo"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/logger"
+)
+
+// TimelineAppService 案件时间线应用服务
+type TimelineAppService struct {
+	mpRepo repository.MissingPersonRepository
+}
+
+// NewTimelineAppService 创建案件时间线应用服务
+func NewTimelineAppService(mpRepo repository.MissingPersonRepository) *TimelineAppService {
+	return &TimelineAppService{mpRepo: mpRepo}
+}
+
+// GetTimeline 重建案件移动路径，返回 GeoJSON 要素集合
+// 包含：移动路径（LineString）、定位点（Point）、线索聚类（Polygon/Point）、推测搜索区域（Polygon）
+func (s *TimelineAppService) GetTimeline(ctx context.Context, personID string, req *dto.TimelineRequest) (*geo.FeatureCollection, error) {
+	mp, err := s.mpRepo.FindByID(ctx, personID)
+	if err != nil {
+		return nil, ErrMissingPersonNotFound
+	}
+
+	tracks, err := s.mpRepo.GetTracks(ctx, personID)
+	if err != nil {
+		logger.Error("Failed to get tracks", logger.String("mp_id", personID), logger.Err(err))
+		return nil, err
+	}
+
+	analyzer := domainService.NewTimelineAnalyzer(req.MaxSpeedKmh, req.ClusterRadiusKm)
+	tl := analyzer.Analyze(mp, tracks, time.Now())
+
+	return buildTimelineGeoJSON(mp.ID, tl), nil
+}
+
+// buildTimelineGeoJSON 将时间线转换为 GeoJSON，要素通过 properties.kind 区分
+func buildTimelineGeoJSON(personID string, tl *domainService.Timeline) *geo.FeatureCollection {
+	fc := geo.NewFeatureCollection()
+
+	if len(tl.Path) >= 2 {
+		fc.Add(geo.NewFeature(geo.NewLineStringGeometry(tl.Path), map[string]interface{}{
+			"kind":              "path",
+			"missing_person_id": personID,
+			"total_distance_km": round2(tl.TotalDistanceKm),
+			"point_count":       len(tl.Path),
+			"implausible_count": tl.ImplausibleCount,
+			"unlocated_count":   tl.UnlocatedCount,
+		}))
+	}
+
+	for _, p := range tl.Points {
+		kind := "sighting"
+		if p.Origin {
+			kind = "origin"
+		}
+		fc.Add(geo.NewFeature(geo.NewPointGeometry(p.Point), map[string]interface{}{
+			"kind":         kind,
+			"track_id":     p.TrackID,
+			"time":         p.Time,
+			"location":     p.Location,
+			"status":       p.Status,
+			"is_key_point": p.IsKeyPoint,
+			"implausible":  p.Implausible,
+			"distance_km":  round2(p.DistanceKm),
+			"speed_kmh":    round2(p.SpeedKmh),
+			"cluster_id":   p.ClusterID,
+		}))
+	}
+
+	for _, c := range tl.Clusters {
+		if c.Count < 2 {
+			continue
+		}
+		props := map[string]interface{}{
+			"kind":          "cluster",
+			"cluster_id":    c.ID,
+			"count":         c.Count,
+			"start":         c.Start,
+			"end":           c.End,
+			"has_key_point": c.HasKeyPoint,
+		}
+		hull := geo.ConvexHull(c.Points)
+		if len(hull) >= 3 {
+			fc.Add(geo.NewFeature(geo.NewPolygonGeometry(hull), props))
+		} else {
+			fc.Add(geo.NewFeature(geo.NewPointGeometry(c.Center), props))
+		}
+	}
+
+	if area := tl.SearchArea; area != nil {
+		fc.Add(geo.NewFeature(geo.NewPolygonGeometry(area.Polygon), map[string]interface{}{
+			"kind":            "search_area",
+			"center":          []float64{area.Center.Lng, area.Center.Lat},
+			"radius_km":       round2(area.RadiusKm),
+			"anchor_track_id": area.AnchorTrackID,
+			"anchor_time":     area.AnchorTime,
+			"speed_kmh":       round2(area.SpeedKmh),
+		}))
+	}
+
+	return fc
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
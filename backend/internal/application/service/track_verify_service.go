@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/dto"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/repository"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/geo"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/logger"
+)
+
+var (
+	ErrTrackNotFound         = errors.New("track not found")
+	ErrTrackInvalidStatus    = errors.New("invalid track status transition")
+	ErrTrackReasonRequired   = errors.New("reason is required")
+	ErrCaseNotActiveForTrack = errors.New("case is not active")
+)
+
+// 线索核实日志动作
+const (
+	trackActionVerify  = "verify"
+	trackActionConfirm = "confirm"
+	trackActionReject  = "reject"
+)
+
+// TrackVerifyAppService 线索核实应用服务
+// 线索生命周期：pending -> verifying -> confirmed / rejected（pending 也可直接确认或驳回）
+type TrackVerifyAppService struct {
+	mpRepo      repository.MissingPersonRepository
+	geoRepo     repository.GeoRepository
+	taskService *TaskAppService
+}
+
+// NewTrackVerifyAppService 创建线索核实应用服务
+func NewTrackVerifyAppService(mpRepo repository.MissingPersonRepository, geoRepo repository.GeoRepository, taskService *TaskAppService) *TrackVerifyAppService {
+	return &TrackVerifyAppService{
+		mpRepo:      mpRepo,
+		geoRepo:     geoRepo,
+		taskService: taskService,
+	}
+}
+
+// StartVerify 开始核实线索
+func (s *TrackVerifyAppService) StartVerify(ctx context.Context, mpID, trackID, userID string) (*dto.MissingPersonTrackResponse, error) {
+	_, track, err := s.find(ctx, mpID, trackID)
+	if err != nil {
+		return nil, err
+	}
+
+	oldStatus := track.Status
+	if err := track.StartVerify(userID); err != nil {
+		return nil, ErrTrackInvalidStatus
+	}
+	if err := s.save(ctx, track, userID, trackActionVerify, oldStatus, ""); err != nil {
+		return nil, err
+	}
+
+	resp := dto.ToMissingPersonTrackResponse(track)
+	return &resp, nil
+}
+
+// Confirm 确认线索：为最近的组织创建核实任务，关键节点会提升案件紧急程度
+func (s *TrackVerifyAppService) Confirm(ctx context.Context, mpID, trackID string, req *dto.ConfirmTrackRequest, userID string) (*dto.TrackVerifyResponse, error) {
+	mp, track, err := s.find(ctx, mpID, trackID)
+	if err != nil {
+		return nil, err
+	}
+	if !mp.IsActive() {
+		return nil, ErrCaseNotActiveForTrack
+	}
+
+	reason := strings.TrimSpace(req.Reason)
+	oldStatus := track.Status
+	if err := track.Confirm(userID, reason); err != nil {
+		return nil, ErrTrackInvalidStatus
+	}
+	if req.IsKeyPoint != nil {
+		track.IsKeyPoint = *req.IsKeyPoint
+	}
+	if err := s.save(ctx, track, userID, trackActionConfirm, oldStatus, reason); err != nil {
+		return nil, err
+	}
+
+	resp := &dto.TrackVerifyResponse{Track: dto.ToMissingPersonTrackResponse(track)}
+
+	if track.IsKeyPoint && mp.RaiseUrgency() {
+		if err := s.mpRepo.UpdateUrgency(ctx, mp.ID, mp.Urgency); err != nil {
+			logger.Error("Failed to raise case urgency", logger.String("mp_id", mp.ID), logger.Err(err))
+		} else {
+			resp.UrgencyRaised = true
+		}
+	}
+	resp.Urgency = string(mp.Urgency)
+
+	// 核实任务创建失败不影响线索确认结果
+	orgID := s.nearestOrgID(ctx, mp, track)
+	task, err := s.taskService.CreateVerifyTask(ctx, mp, track, orgID, userID)
+	if err != nil {
+		logger.Error("Failed to spawn verify task", logger.String("track_id", track.ID), logger.Err(err))
+	} else {
+		resp.VerifyTaskID = task.ID
+		resp.VerifyOrgID = orgID
+	}
+
+	return resp, nil
+}
+
+// Reject 驳回线索
+func (s *TrackVerifyAppService) Reject(ctx context.Context, mpID, trackID string, req *dto.RejectTrackRequest, userID string) (*dto.MissingPersonTrackResponse, error) {
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, ErrTrackReasonRequired
+	}
+
+	_, track, err := s.find(ctx, mpID, trackID)
+	if err != nil {
+		return nil, err
+	}
+
+	oldStatus := track.Status
+	if err := track.Reject(userID, reason); err != nil {
+		return nil, ErrTrackInvalidStatus
+	}
+	if err := s.save(ctx, track, userID, trackActionReject, oldStatus, reason); err != nil {
+		return nil, err
+	}
+
+	resp := dto.ToMissingPersonTrackResponse(track)
+	return &resp, nil
+}
+
+// GetLogs 获取线索核实日志
+func (s *TrackVerifyAppService) GetLogs(ctx context.Context, mpID, trackID string) ([]dto.TrackLogResponse, error) {
+	if _, _, err := s.find(ctx, mpID, trackID); err != nil {
+		return nil, err
+	}
+
+	logs, err := s.mpRepo.GetTrackLogs(ctx, trackID)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]dto.TrackLogResponse, len(logs))
+	for i := range logs {
+		list[i] = dto.ToTrackLogResponse(&logs[i])
+	}
+	return list, nil
+}
+
+// find 查找案件及其线索
+func (s *TrackVerifyAppService) find(ctx context.Context, mpID, trackID string) (*entity.MissingPerson, *entity.MissingPersonTrack, error) {
+	mp, err := s.mpRepo.FindByID(ctx, mpID)
+	if err != nil {
+		return nil, nil, ErrMissingPersonNotFound
+	}
+	track, err := s.mpRepo.FindTrackByID(ctx, trackID)
+	if err != nil || track.MissingPersonID != mp.ID {
+		return nil, nil, ErrTrackNotFound
+	}
+	return mp, track, nil
+}
+
+// save 保存线索状态并记录核实日志
+func (s *TrackVerifyAppService) save(ctx context.Context, track *entity.MissingPersonTrack, userID, action, oldStatus, reason string) error {
+	log := &entity.MissingPersonTrackLog{
+		TrackID:   track.ID,
+		UserID:    userID,
+		Action:    action,
+		OldStatus: oldStatus,
+		NewStatus: track.Status,
+		Reason:    reason,
+	}
+	if err := s.mpRepo.UpdateTrackStatus(ctx, track, log); err != nil {
+		logger.Error("Failed to update track status", logger.String("track_id", track.ID), logger.Err(err))
+		return err
+	}
+
+	logger.Info("Track status changed",
+		logger.String("track_id", track.ID),
+		logger.String("action", action),
+		logger.String("status", track.Status),
+	)
+	return nil
+}
+
+// nearestOrgID 按线索位置（无坐标时用走失地点）选择最近的组织，找不到时交给案件所属组织
+func (s *TrackVerifyAppService) nearestOrgID(ctx context.Context, mp *entity.MissingPerson, track *entity.MissingPersonTrack) string {
+	lat, lng := track.Lat, track.Lng
+	if !geo.ValidCoordinate(lat, lng) {
+		lat, lng = mp.Lat, mp.Lng
+	}
+	if !geo.ValidCoordinate(lat, lng) {
+		return mp.OrgID
+	}
+
+	nearest, err := s.geoRepo.FindNearestOrg(ctx, lat, lng)
+	if err != nil {
+		logger.Warn("Failed to find nearest org", logger.String("track_id", track.ID), logger.Err(err))
+		return mp.OrgID
+	}
+	if nearest == nil {
+		return mp.OrgID
+	}
+	return nearest.Org.ID
+}
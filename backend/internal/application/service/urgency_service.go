@@ -0,0 +1,462 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/dto"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/repository"
+	domainService "github.com/Snowitty-Re/CNtunyuan/internal/domain/service"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/logger"
+)
+
+var (
+	ErrUrgencyRuleInvalid    = errors.New("invalid urgency rule")
+	ErrUrgencyCaseNotActive  = errors.New("case is not active")
+	ErrUrgencyRescoreRunning = errors.New("urgency rescore in progress")
+)
+
+// urgencyBatchSize 批量评分时每批读取的案件数
+const urgencyBatchSize = 500
+
+// UrgencyAppService 案件紧急程度评分应用服务：按组织规则定期重新评分，升级为紧急时通知组织管理员
+type UrgencyAppService struct {
+	mpRepo              repository.MissingPersonRepository
+	ruleRepo            repository.UrgencyRuleRepository
+	orgRepo             repository.OrganizationRepository
+	userRepo            repository.UserRepository
+	notificationService *NotificationAppService
+
+	running sync.Mutex
+}
+
+// NewUrgencyAppService 创建紧急程度评分应用服务
+// 服务本身为 nil 时 RefreshCase 可安全调用
+func NewUrgencyAppService(
+	mpRepo repository.MissingPersonRepository,
+	ruleRepo repository.UrgencyRuleRepository,
+	orgRepo repository.OrganizationRepository,
+	userRepo repository.UserRepository,
+	notificationService *NotificationAppService,
+) *UrgencyAppService {
+	return &UrgencyAppService{
+		mpRepo:              mpRepo,
+		ruleRepo:            ruleRepo,
+		orgRepo:             orgRepo,
+		userRepo:            userRepo,
+		notificationService: notificationService,
+	}
+}
+
+// Start 定期重新评分进行中的案件，interval <= 0 时不启动，ctx 取消时停止
+func (s *UrgencyAppService) Start(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if _, err := s.RescoreAll(ctx); err != nil && !errors.Is(err, ErrUrgencyRescoreRunning) && ctx.Err() == nil {
+					logger.Warn("Failed to rescore case urgency", logger.Err(err))
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// RescoreAll 重新评分所有进行中的案件
+func (s *UrgencyAppService) RescoreAll(ctx context.Context) (*dto.UrgencyRescoreResponse, error) {
+	if !s.running.TryLock() {
+		return nil, ErrUrgencyRescoreRunning
+	}
+	defer s.running.Unlock()
+
+	resolver, err := s.newRuleResolver(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dto.UrgencyRescoreResponse{}
+	afterID := ""
+	for {
+		batch, err := s.mpRepo.FindActiveForScoring(ctx, afterID, urgencyBatchSize)
+		if err != nil {
+			return result, err
+		}
+		if len(batch) == 0 {
+			break
+		}
+		afterID = batch[len(batch)-1].ID
+
+		sightings, err := s.recentSightings(ctx, resolver, batch)
+		if err != nil {
+			return result, err
+		}
+		now := time.Now()
+		for i := range batch {
+			mp := &batch[i]
+			rule := resolver.resolve(ctx, mp.OrgID)
+			_, changed, escalated, err := s.apply(ctx, mp, rule, sightings[mp.ID], now)
+			if err != nil {
+				logger.Warn("Failed to save case urgency", logger.String("mp_id", mp.ID), logger.Err(err))
+				continue
+			}
+			result.Scored++
+			if changed {
+				result.Changed++
+			}
+			if escalated {
+				result.Escalated++
+			}
+		}
+	}
+
+	logger.Info("Case urgency rescored",
+		logger.Int("scored", result.Scored),
+		logger.Int("changed", result.Changed),
+		logger.Int("escalated", result.Escalated),
+	)
+	return result, nil
+}
+
+// RefreshCase 案件创建、修改或新增线索后重新评分，失败只记录日志，由定期评分补齐
+func (s *UrgencyAppService) RefreshCase(ctx context.Context, mp *entity.MissingPerson) {
+	if s == nil || !mp.IsActive() || mp.IsMerged() {
+		return
+	}
+	if _, _, err := s.score(ctx, mp); err != nil {
+		logger.Warn("Failed to refresh case urgency", logger.String("mp_id", mp.ID), logger.Err(err))
+	}
+}
+
+// Assess 评估案件紧急程度（不保存）
+func (s *UrgencyAppService) Assess(ctx context.Context, id string) (*dto.UrgencyAssessmentResponse, error) {
+	mp, err := s.mpRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, ErrMissingPersonNotFound
+	}
+
+	resolver, err := s.newRuleResolver(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rule := resolver.resolve(ctx, mp.OrgID)
+	sightings, err := s.countSightings(ctx, mp.ID, rule)
+	if err != nil {
+		return nil, err
+	}
+	assessment := domainService.NewUrgencyScorer(rule).Score(mp, domainService.UrgencySignals{RecentSightings: sightings})
+
+	resp := toUrgencyAssessmentResponse(mp, assessment)
+	return &resp, nil
+}
+
+// Rescore 立即重新评分单个案件并清除人工设置，恢复自动评级
+func (s *UrgencyAppService) Rescore(ctx context.Context, id string) (*dto.UrgencyAssessmentResponse, error) {
+	mp, err := s.mpRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, ErrMissingPersonNotFound
+	}
+	if !mp.IsActive() || mp.IsMerged() {
+		return nil, ErrUrgencyCaseNotActive
+	}
+
+	mp.UrgencyManual = false
+	assessment, escalated, err := s.score(ctx, mp)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := toUrgencyAssessmentResponse(mp, assessment)
+	resp.Escalated = escalated
+	return &resp, nil
+}
+
+// GetRule 获取组织生效的评分规则
+func (s *UrgencyAppService) GetRule(ctx context.Context, orgID string) (*dto.UrgencyRuleResponse, error) {
+	if _, err := s.orgRepo.FindByID(ctx, orgID); err != nil {
+		return nil, ErrOrganizationNotFound
+	}
+
+	resolver, err := s.newRuleResolver(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp := dto.ToUrgencyRuleResponse(orgID, resolver.resolve(ctx, orgID))
+	return &resp, nil
+}
+
+// SaveRule 配置组织的评分规则
+func (s *UrgencyAppService) SaveRule(ctx context.Context, orgID string, req *dto.UrgencyRuleRequest) (*dto.UrgencyRuleResponse, error) {
+	if _, err := s.orgRepo.FindByID(ctx, orgID); err != nil {
+		return nil, ErrOrganizationNotFound
+	}
+
+	rule, err := s.ruleRepo.FindByOrgID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if rule == nil {
+		rule = entity.DefaultUrgencyRule()
+		rule.OrgID = orgID
+	}
+
+	rule.ChildMaxAge = req.ChildMaxAge
+	rule.ChildWeight = req.ChildWeight
+	rule.ElderMinAge = req.ElderMinAge
+	rule.ElderWeight = req.ElderWeight
+	rule.MedicalWeight = req.MedicalWeight
+	rule.MedicalKeywords = strings.TrimSpace(req.MedicalKeywords)
+	rule.GoldenHours = req.GoldenHours
+	rule.GoldenWeight = req.GoldenWeight
+	rule.SeasonWeight = req.SeasonWeight
+	rule.SightingHours = req.SightingHours
+	rule.SightingWeight = req.SightingWeight
+	rule.SightingMaxWeight = req.SightingMaxWeight
+	rule.CriticalScore = req.CriticalScore
+	rule.HighScore = req.HighScore
+	rule.MediumScore = req.MediumScore
+	if req.Escalate != nil {
+		rule.Escalate = *req.Escalate
+	}
+	if err := rule.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUrgencyRuleInvalid, err.Error())
+	}
+
+	if rule.ID == "" {
+		err = s.ruleRepo.Create(ctx, rule)
+	} else {
+		err = s.ruleRepo.Update(ctx, rule)
+	}
+	if err != nil {
+		logger.Error("Failed to save urgency rule", logger.String("org_id", orgID), logger.Err(err))
+		return nil, err
+	}
+
+	resp := dto.ToUrgencyRuleResponse(orgID, rule)
+	return &resp, nil
+}
+
+// DeleteRule 删除组织的评分规则，恢复沿用上级组织规则
+func (s *UrgencyAppService) DeleteRule(ctx context.Context, orgID string) error {
+	return s.ruleRepo.DeleteByOrgID(ctx, orgID)
+}
+
+// score 按组织规则评分单个案件并保存
+func (s *UrgencyAppService) score(ctx context.Context, mp *entity.MissingPerson) (domainService.UrgencyAssessment, bool, error) {
+	resolver, err := s.newRuleResolver(ctx)
+	if err != nil {
+		return domainService.UrgencyAssessment{}, false, err
+	}
+	rule := resolver.resolve(ctx, mp.OrgID)
+	sightings, err := s.countSightings(ctx, mp.ID, rule)
+	if err != nil {
+		return domainService.UrgencyAssessment{}, false, err
+	}
+
+	assessment, _, escalated, err := s.apply(ctx, mp, rule, sightings, time.Now())
+	return assessment, escalated, err
+}
+
+// apply 评分并保存，首次升级为紧急且规则要求时通知组织管理员
+func (s *UrgencyAppService) apply(ctx context.Context, mp *entity.MissingPerson, rule *entity.UrgencyRule, sightings int, now time.Time) (assessment domainService.UrgencyAssessment, changed, escalated bool, err error) {
+	assessment = domainService.NewUrgencyScorer(rule).Score(mp, domainService.UrgencySignals{RecentSightings: sightings, Now: now})
+
+	oldLevel, oldScore := mp.Urgency, mp.UrgencyScore
+	escalated = mp.ApplyUrgency(assessment.Level, assessment.Score, now)
+	if err = s.mpRepo.UpdateUrgencyScore(ctx, mp); err != nil {
+		return assessment, false, false, err
+	}
+	if escalated && rule.Escalate {
+		s.escalate(ctx, mp, assessment)
+	}
+	return assessment, oldLevel != mp.Urgency || oldScore != mp.UrgencyScore, escalated, nil
+}
+
+// countSightings 统计单个案件规则窗口内的线索数
+func (s *UrgencyAppService) countSightings(ctx context.Context, id string, rule *entity.UrgencyRule) (int, error) {
+	if rule.SightingHours <= 0 || rule.SightingWeight <= 0 {
+		return 0, nil
+	}
+	since := time.Now().Add(-time.Duration(rule.SightingHours) * time.Hour)
+	counts, err := s.mpRepo.CountRecentTracks(ctx, []string{id}, since)
+	if err != nil {
+		return 0, err
+	}
+	return counts[id], nil
+}
+
+// recentSightings 按规则分组统计一批案件窗口内的线索数
+func (s *UrgencyAppService) recentSightings(ctx context.Context, resolver *urgencyRuleResolver, batch []entity.MissingPerson) (map[string]int, error) {
+	groups := make(map[*entity.UrgencyRule][]string)
+	for i := range batch {
+		rule := resolver.resolve(ctx, batch[i].OrgID)
+		if rule.SightingHours > 0 && rule.SightingWeight > 0 {
+			groups[rule] = append(groups[rule], batch[i].ID)
+		}
+	}
+
+	result := make(map[string]int)
+	now := time.Now()
+	for rule, ids := range groups {
+		counts, err := s.mpRepo.CountRecentTracks(ctx, ids, now.Add(-time.Duration(rule.SightingHours)*time.Hour))
+		if err != nil {
+			return nil, err
+		}
+		for id, n := range counts {
+			result[id] = n
+		}
+	}
+	return result, nil
+}
+
+// escalate 通知案件所属组织的管理员，组织无管理员时逐级向上查找
+func (s *UrgencyAppService) escalate(ctx context.Context, mp *entity.MissingPerson, assessment domainService.UrgencyAssessment) {
+	if s.notificationService == nil {
+		return
+	}
+
+	managers, orgID := s.findManagers(ctx, mp.OrgID)
+	if len(managers) == 0 {
+		logger.Warn("No manager to notify for critical case", logger.String("mp_id", mp.ID), logger.String("org_id", mp.OrgID))
+		return
+	}
+
+	details := make([]string, len(assessment.Factors))
+	for i, f := range assessment.Factors {
+		details[i] = f.Detail
+	}
+	businessID := mp.ID
+	req := &dto.BatchSendNotificationRequest{
+		Title:        fmt.Sprintf("案件升级为紧急：%s（%s）", mp.Name, mp.CaseNo),
+		Content:      fmt.Sprintf("紧急程度评分 %d，%s。请尽快安排搜寻力量。", assessment.Score, strings.Join(details, "；")),
+		Type:         entity.NotificationTypeAlert,
+		Channel:      entity.NotificationChannelWebSocket,
+		Priority:     entity.PriorityUrgent,
+		ToUserIDs:    managers,
+		OrgID:        orgID,
+		BusinessType: "missing_person",
+		BusinessID:   &businessID,
+		Data: map[string]interface{}{
+			"missing_person_id": mp.ID,
+			"case_no":           mp.CaseNo,
+			"urgency":           string(mp.Urgency),
+			"score":             assessment.Score,
+		},
+	}
+	if err := s.notificationService.SendBatchNotifications(ctx, req); err != nil {
+		logger.Error("Failed to send urgency escalation", logger.String("mp_id", mp.ID), logger.Err(err))
+		return
+	}
+	logger.Info("Critical case escalated",
+		logger.String("mp_id", mp.ID),
+		logger.String("org_id", orgID),
+		logger.Int("managers", len(managers)),
+	)
+}
+
+// findManagers 查找组织（或最近的上级组织）中在职的管理员
+func (s *UrgencyAppService) findManagers(ctx context.Context, orgID string) ([]string, string) {
+	path, err := s.orgRepo.FindPath(ctx, orgID)
+	if err != nil {
+		return nil, ""
+	}
+	for i := len(path) - 1; i >= 0; i-- {
+		query := repository.NewUserQuery()
+		query.OrgID = path[i].ID
+		query.Status = entity.UserStatusActive
+		query.PageSize = 200
+		users, err := s.userRepo.List(ctx, query)
+		if err != nil {
+			logger.Warn("Failed to list org users", logger.String("org_id", path[i].ID), logger.Err(err))
+			continue
+		}
+
+		var ids []string
+		for _, u := range users.List {
+			if entity.HasRole(u.Role, string(entity.RoleManager)) {
+				ids = append(ids, u.ID)
+			}
+		}
+		if len(ids) > 0 {
+			return ids, path[i].ID
+		}
+	}
+	return nil, ""
+}
+
+// urgencyRuleResolver 解析组织生效的评分规则：自身规则优先，其次最近上级组织的规则，最后默认规则
+type urgencyRuleResolver struct {
+	orgRepo  repository.OrganizationRepository
+	rules    map[string]*entity.UrgencyRule
+	resolved map[string]*entity.UrgencyRule
+	fallback *entity.UrgencyRule
+}
+
+// newRuleResolver 加载所有组织规则
+func (s *UrgencyAppService) newRuleResolver(ctx context.Context) (*urgencyRuleResolver, error) {
+	rules, err := s.ruleRepo.ListAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r := &urgencyRuleResolver{
+		orgRepo:  s.orgRepo,
+		rules:    make(map[string]*entity.UrgencyRule, len(rules)),
+		resolved: make(map[string]*entity.UrgencyRule),
+		fallback: entity.DefaultUrgencyRule(),
+	}
+	for i := range rules {
+		r.rules[rules[i].OrgID] = &rules[i]
+	}
+	return r, nil
+}
+
+// resolve 组织生效的评分规则
+func (r *urgencyRuleResolver) resolve(ctx context.Context, orgID string) *entity.UrgencyRule {
+	if rule, ok := r.resolved[orgID]; ok {
+		return rule
+	}
+
+	rule := r.fallback
+	if own, ok := r.rules[orgID]; ok {
+		rule = own
+	} else if len(r.rules) > 0 {
+		path, _ := r.orgRepo.FindPath(ctx, orgID)
+		for i := len(path) - 1; i >= 0; i-- {
+			if inherited, ok := r.rules[path[i].ID]; ok {
+				rule = inherited
+				break
+			}
+		}
+	}
+	r.resolved[orgID] = rule
+	return rule
+}
+
+// toUrgencyAssessmentResponse 转换为评估响应
+func toUrgencyAssessmentResponse(mp *entity.MissingPerson, assessment domainService.UrgencyAssessment) dto.UrgencyAssessmentResponse {
+	factors := make([]dto.UrgencyFactorResponse, len(assessment.Factors))
+	for i, f := range assessment.Factors {
+		factors[i] = dto.UrgencyFactorResponse{Code: f.Code, Points: f.Points, Detail: f.Detail}
+	}
+	return dto.UrgencyAssessmentResponse{
+		MissingPersonID: mp.ID,
+		Score:           assessment.Score,
+		Level:           string(assessment.Level),
+		Urgency:         string(mp.Urgency),
+		Manual:          mp.UrgencyManual,
+		Factors:         factors,
+		ScoredAt:        mp.UrgencyScoredAt,
+		EscalatedAt:     mp.UrgencyEscalatedAt,
+	}
+}
@@ -9,11 +9,24 @@ import (
 	"github.com/Snowitty-Re/CNtunyuan/internal/application/service/mocks"
 	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
 	"github.com/Snowitty-Re/CNtunyuan/internal/domain/repository"
-	pkgErrors "github.com/Snowitty-Re/CNtunyuan/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
+var errUserStore = errors.New("record not found")
+
+func testUser(id, role string) *entity.User {
+	return &entity.User{
+		BaseEntity: entity.BaseEntity{ID: id},
+		Nickname:   "Test User",
+		Phone:      "13800138000",
+		Role:       role,
+		OrgID:      "org-001",
+		Status:     entity.UserStatusActive,
+	}
+}
+
 func TestUserAppService_Create(t *testing.T) {
 	mockRepo := new(mocks.UserRepositoryMock)
 	service := NewUserAppService(mockRepo)
@@ -24,7 +37,7 @@ func TestUserAppService_Create(t *testing.T) {
 		req     *dto.CreateUserRequest
 		mock    func()
 		wantErr bool
-		errCode pkgErrors.ErrorCode
+		err     error
 	}{
 		{
 			name: "success - create user",
@@ -36,10 +49,9 @@ func TestUserAppService_Create(t *testing.T) {
 				OrgID:    "org-001",
 			},
 			mock: func() {
-				mockRepo.On("ExistsByPhone", ctx, "13800138000").Return(false, nil).Once()
+				mockRepo.On("ExistsPhone", ctx, "13800138000").Return(false, nil).Once()
 				mockRepo.On("Create", ctx, mock.AnythingOfType("*entity.User")).Return(nil).Once()
 			},
-			wantErr: false,
 		},
 		{
 			name: "fail - phone already exists",
@@ -51,10 +63,42 @@ func TestUserAppService_Create(t *testing.T) {
 				OrgID:    "org-001",
 			},
 			mock: func() {
-				mockRepo.On("ExistsByPhone", ctx, "13800138000").Return(true, nil).Once()
+				mockRepo.On("ExistsPhone", ctx, "13800138000").Return(true, nil).Once()
+			},
+			wantErr: true,
+			err:     ErrPhoneExists,
+		},
+		{
+			name: "fail - email already exists",
+			req: &dto.CreateUserRequest{
+				Nickname: "Test User",
+				Phone:    "13800138001",
+				Email:    "taken@example.com",
+				Password: "password123",
+				Role:     "volunteer",
+				OrgID:    "org-001",
+			},
+			mock: func() {
+				mockRepo.On("ExistsPhone", ctx, "13800138001").Return(false, nil).Once()
+				mockRepo.On("ExistsEmail", ctx, "taken@example.com").Return(true, nil).Once()
 			},
 			wantErr: true,
-			errCode: pkgErrors.CodeUserExists,
+			err:     ErrEmailExists,
+		},
+		{
+			name: "fail - invalid role",
+			req: &dto.CreateUserRequest{
+				Nickname: "Test User",
+				Phone:    "13800138002",
+				Password: "password123",
+				Role:     "guest",
+				OrgID:    "org-001",
+			},
+			mock: func() {
+				mockRepo.On("ExistsPhone", ctx, "13800138002").Return(false, nil).Once()
+			},
+			wantErr: true,
+			err:     ErrInvalidRole,
 		},
 		{
 			name: "fail - invalid phone",
@@ -65,35 +109,38 @@ func TestUserAppService_Create(t *testing.T) {
 				Role:     "volunteer",
 				OrgID:    "org-001",
 			},
-			mock:    func() {},
+			mock: func() {
+				mockRepo.On("ExistsPhone", ctx, "invalid").Return(false, nil).Once()
+			},
 			wantErr: true,
-			errCode: pkgErrors.CodeInvalidParam,
 		},
 		{
 			name: "fail - password too short",
 			req: &dto.CreateUserRequest{
 				Nickname: "Test User",
-				Phone:    "13800138001",
+				Phone:    "13800138003",
 				Password: "123",
 				Role:     "volunteer",
 				OrgID:    "org-001",
 			},
-			mock:    func() {},
+			mock: func() {
+				mockRepo.On("ExistsPhone", ctx, "13800138003").Return(false, nil).Once()
+			},
 			wantErr: true,
-			errCode: pkgErrors.CodeInvalidParam,
 		},
 		{
 			name: "fail - empty nickname",
 			req: &dto.CreateUserRequest{
 				Nickname: "",
-				Phone:    "13800138002",
+				Phone:    "13800138004",
 				Password: "password123",
 				Role:     "volunteer",
 				OrgID:    "org-001",
 			},
-			mock:    func() {},
+			mock: func() {
+				mockRepo.On("ExistsPhone", ctx, "13800138004").Return(false, nil).Once()
+			},
 			wantErr: true,
-			errCode: pkgErrors.CodeInvalidParam,
 		},
 	}
 
@@ -104,12 +151,11 @@ func TestUserAppService_Create(t *testing.T) {
 
 			if tt.wantErr {
 				assert.Error(t, err)
-				if tt.errCode != 0 {
-					assert.Equal(t, tt.errCode, pkgErrors.GetCode(err))
+				if tt.err != nil {
+					assert.ErrorIs(t, err, tt.err)
 				}
 			} else {
-				assert.NoError(t, err)
-				assert.NotNil(t, resp)
+				require.NoError(t, err)
 				assert.Equal(t, tt.req.Nickname, resp.Nickname)
 				assert.Equal(t, tt.req.Phone, resp.Phone)
 			}
@@ -129,32 +175,21 @@ func TestUserAppService_GetByID(t *testing.T) {
 		userID  string
 		mock    func()
 		wantErr bool
-		errCode pkgErrors.ErrorCode
 	}{
 		{
 			name:   "success - get user by id",
 			userID: "user-001",
 			mock: func() {
-				user := &entity.User{
-					ID:       "user-001",
-					Nickname: "Test User",
-					Phone:    "13800138000",
-					Role:     "volunteer",
-					OrgID:    "org-001",
-					Status:   entity.UserStatusActive,
-				}
-				mockRepo.On("FindByID", ctx, "user-001").Return(user, nil).Once()
+				mockRepo.On("FindByID", ctx, "user-001").Return(testUser("user-001", "volunteer"), nil).Once()
 			},
-			wantErr: false,
 		},
 		{
 			name:   "fail - user not found",
 			userID: "user-notfound",
 			mock: func() {
-				mockRepo.On("FindByID", ctx, "user-notfound").Return(nil, errors.New("user not found")).Once()
+				mockRepo.On("FindByID", ctx, "user-notfound").Return(nil, errUserStore).Once()
 			},
 			wantErr: true,
-			errCode: pkgErrors.CodeUserNotFound,
 		},
 	}
 
@@ -164,13 +199,9 @@ func TestUserAppService_GetByID(t *testing.T) {
 			resp, err := service.GetByID(ctx, tt.userID)
 
 			if tt.wantErr {
-				assert.Error(t, err)
-				if tt.errCode != 0 {
-					assert.Equal(t, tt.errCode, pkgErrors.GetCode(err))
-				}
+				assert.ErrorIs(t, err, ErrUserNotFound)
 			} else {
-				assert.NoError(t, err)
-				assert.NotNil(t, resp)
+				require.NoError(t, err)
 				assert.Equal(t, tt.userID, resp.ID)
 			}
 
@@ -183,76 +214,81 @@ func TestUserAppService_Update(t *testing.T) {
 	mockRepo := new(mocks.UserRepositoryMock)
 	service := NewUserAppService(mockRepo)
 	ctx := context.Background()
+	admin := testUser("admin-001", "admin")
+	manager := testUser("manager-001", "manager")
 
 	tests := []struct {
-		name    string
-		userID  string
-		req     *dto.UpdateUserRequest
-		mock    func()
-		wantErr bool
-		errCode pkgErrors.ErrorCode
+		name     string
+		userID   string
+		req      *dto.UpdateUserRequest
+		operator *entity.User
+		mock     func()
+		err      error
 	}{
 		{
-			name:   "success - update user",
-			userID: "user-001",
-			req: &dto.UpdateUserRequest{
-				Nickname: "Updated Name",
-				Email:    "updated@example.com",
-			},
+			name:     "success - update user",
+			userID:   "user-001",
+			req:      &dto.UpdateUserRequest{Nickname: "Updated Name", Email: "updated@example.com"},
+			operator: admin,
 			mock: func() {
-				user := &entity.User{
-					ID:       "user-001",
-					Nickname: "Test User",
-					Phone:    "13800138000",
-				}
-				mockRepo.On("FindByID", ctx, "user-001").Return(user, nil).Once()
-				mockRepo.On("Update", ctx, mock.AnythingOfType("*entity.User")).Return(nil).Once()
+				mockRepo.On("FindByID", ctx, "user-001").Return(testUser("user-001", "volunteer"), nil).Once()
+				mockRepo.On("Update", ctx, mock.MatchedBy(func(u *entity.User) bool {
+					return u.Nickname == "Updated Name" && u.Email == "updated@example.com"
+				})).Return(nil).Once()
 			},
-			wantErr: false,
 		},
 		{
-			name:   "fail - user not found",
-			userID: "user-notfound",
-			req: &dto.UpdateUserRequest{
-				Nickname: "Updated Name",
+			name:     "fail - user not found",
+			userID:   "user-notfound",
+			req:      &dto.UpdateUserRequest{Nickname: "Updated Name"},
+			operator: admin,
+			mock: func() {
+				mockRepo.On("FindByID", ctx, "user-notfound").Return(nil, errUserStore).Once()
 			},
+			err: ErrUserNotFound,
+		},
+		{
+			name:     "fail - manager cannot modify admin",
+			userID:   "admin-002",
+			req:      &dto.UpdateUserRequest{Nickname: "Updated Name"},
+			operator: manager,
 			mock: func() {
-				mockRepo.On("FindByID", ctx, "user-notfound").Return(nil, errors.New("user not found")).Once()
+				mockRepo.On("FindByID", ctx, "admin-002").Return(testUser("admin-002", "admin"), nil).Once()
 			},
-			wantErr: true,
-			errCode: pkgErrors.CodeUserNotFound,
+			err: ErrCannotModify,
 		},
 		{
-			name:   "fail - invalid email",
-			userID: "user-001",
-			req: &dto.UpdateUserRequest{
-				Email: "invalid-email",
+			name:     "fail - invalid role",
+			userID:   "user-001",
+			req:      &dto.UpdateUserRequest{Role: "guest"},
+			operator: admin,
+			mock: func() {
+				mockRepo.On("FindByID", ctx, "user-001").Return(testUser("user-001", "volunteer"), nil).Once()
 			},
+			err: ErrInvalidRole,
+		},
+		{
+			name:     "fail - role above operator",
+			userID:   "user-001",
+			req:      &dto.UpdateUserRequest{Role: "super_admin"},
+			operator: admin,
 			mock: func() {
-				user := &entity.User{
-					ID:    "user-001",
-					Phone: "13800138000",
-				}
-				mockRepo.On("FindByID", ctx, "user-001").Return(user, nil).Once()
+				mockRepo.On("FindByID", ctx, "user-001").Return(testUser("user-001", "volunteer"), nil).Once()
 			},
-			wantErr: true,
-			errCode: pkgErrors.CodeInvalidParam,
+			err: ErrCannotModify,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mock()
-			resp, err := service.Update(ctx, tt.userID, tt.req)
+			resp, err := service.Update(ctx, tt.userID, tt.req, tt.operator)
 
-			if tt.wantErr {
-				assert.Error(t, err)
-				if tt.errCode != 0 {
-					assert.Equal(t, tt.errCode, pkgErrors.GetCode(err))
-				}
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
 			} else {
-				assert.NoError(t, err)
-				assert.NotNil(t, resp)
+				require.NoError(t, err)
+				assert.Equal(t, tt.req.Nickname, resp.Nickname)
 			}
 
 			mockRepo.AssertExpectations(t)
@@ -264,49 +300,51 @@ func TestUserAppService_Delete(t *testing.T) {
 	mockRepo := new(mocks.UserRepositoryMock)
 	service := NewUserAppService(mockRepo)
 	ctx := context.Background()
+	volunteer := testUser("user-002", "volunteer")
 
 	tests := []struct {
-		name    string
-		userID  string
-		mock    func()
-		wantErr bool
-		errCode pkgErrors.ErrorCode
+		name     string
+		userID   string
+		operator *entity.User
+		mock     func()
+		err      error
 	}{
 		{
-			name:   "success - delete user",
-			userID: "user-001",
+			name:     "success - delete user",
+			userID:   "user-001",
+			operator: testUser("admin-001", "admin"),
 			mock: func() {
-				user := &entity.User{
-					ID:     "user-001",
-					Role:   "volunteer",
-					Status: entity.UserStatusActive,
-				}
-				mockRepo.On("FindByID", ctx, "user-001").Return(user, nil).Once()
-				mockRepo.On("Delete", ctx, "user-001").Return(nil).Once()
+				mockRepo.On("FindByID", ctx, "user-001").Return(testUser("user-001", "volunteer"), nil).Once()
+				mockRepo.On("SoftDelete", ctx, "user-001").Return(nil).Once()
 			},
-			wantErr: false,
 		},
 		{
-			name:   "fail - user not found",
-			userID: "user-notfound",
+			name:     "fail - user not found",
+			userID:   "user-notfound",
+			operator: testUser("admin-001", "admin"),
 			mock: func() {
-				mockRepo.On("FindByID", ctx, "user-notfound").Return(nil, errors.New("user not found")).Once()
+				mockRepo.On("FindByID", ctx, "user-notfound").Return(nil, errUserStore).Once()
 			},
-			wantErr: true,
-			errCode: pkgErrors.CodeUserNotFound,
+			err: ErrUserNotFound,
+		},
+		{
+			name:     "fail - volunteer cannot delete others",
+			userID:   "user-001",
+			operator: volunteer,
+			mock: func() {
+				mockRepo.On("FindByID", ctx, "user-001").Return(testUser("user-001", "volunteer"), nil).Once()
+			},
+			err: ErrCannotModify,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mock()
-			err := service.Delete(ctx, tt.userID)
+			err := service.Delete(ctx, tt.userID, tt.operator)
 
-			if tt.wantErr {
-				assert.Error(t, err)
-				if tt.errCode != 0 {
-					assert.Equal(t, tt.errCode, pkgErrors.GetCode(err))
-				}
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
 			} else {
 				assert.NoError(t, err)
 			}
@@ -325,6 +363,7 @@ func TestUserAppService_List(t *testing.T) {
 		name    string
 		req     *dto.UserListRequest
 		mock    func()
+		wantLen int
 		wantErr bool
 	}{
 		{
@@ -335,14 +374,21 @@ func TestUserAppService_List(t *testing.T) {
 				Keyword:  "test",
 			},
 			mock: func() {
-				users := []entity.User{
-					{ID: "user-001", Nickname: "Test User 1", Phone: "13800138000"},
-					{ID: "user-002", Nickname: "Test User 2", Phone: "13800138001"},
-				}
+				users := []entity.User{*testUser("user-001", "volunteer"), *testUser("user-002", "volunteer")}
 				result := repository.NewPageResult(users, 2, 1, 10)
-				mockRepo.On("List", ctx, mock.AnythingOfType("*repository.UserQuery")).Return(result, nil).Once()
+				mockRepo.On("List", ctx, mock.MatchedBy(func(q *repository.UserQuery) bool {
+					return q.Keyword == "test" && q.Page == 1 && q.PageSize == 10
+				})).Return(result, nil).Once()
 			},
-			wantErr: false,
+			wantLen: 2,
+		},
+		{
+			name: "fail - repository error",
+			req:  &dto.UserListRequest{Page: 1, PageSize: 10},
+			mock: func() {
+				mockRepo.On("List", ctx, mock.AnythingOfType("*repository.UserQuery")).Return(nil, errUserStore).Once()
+			},
+			wantErr: true,
 		},
 	}
 
@@ -354,9 +400,8 @@ func TestUserAppService_List(t *testing.T) {
 			if tt.wantErr {
 				assert.Error(t, err)
 			} else {
-				assert.NoError(t, err)
-				assert.NotNil(t, resp)
-				assert.GreaterOrEqual(t, len(resp.List), 0)
+				require.NoError(t, err)
+				assert.Len(t, resp.List, tt.wantLen)
 			}
 
 			mockRepo.AssertExpectations(t)
@@ -370,48 +415,44 @@ func TestUserAppService_UpdateStatus(t *testing.T) {
 	ctx := context.Background()
 
 	tests := []struct {
-		name    string
-		userID  string
-		status  string
-		mock    func()
-		wantErr bool
+		name     string
+		userID   string
+		status   entity.UserStatus
+		operator *entity.User
+		mock     func()
+		err      error
 	}{
 		{
-			name:   "success - activate user",
-			userID: "user-001",
-			status: "active",
+			name:     "success - activate user",
+			userID:   "user-001",
+			status:   entity.UserStatusActive,
+			operator: testUser("manager-001", "manager"),
 			mock: func() {
-				user := &entity.User{
-					ID:     "user-001",
-					Status: entity.UserStatusInactive,
-				}
+				user := testUser("user-001", "volunteer")
+				user.Status = entity.UserStatusInactive
 				mockRepo.On("FindByID", ctx, "user-001").Return(user, nil).Once()
 				mockRepo.On("UpdateStatus", ctx, "user-001", entity.UserStatusActive).Return(nil).Once()
 			},
-			wantErr: false,
 		},
 		{
-			name:   "fail - invalid status",
-			userID: "user-001",
-			status: "invalid",
+			name:     "fail - cannot modify super admin",
+			userID:   "root",
+			status:   entity.UserStatusInactive,
+			operator: testUser("admin-001", "admin"),
 			mock: func() {
-				user := &entity.User{
-					ID:     "user-001",
-					Status: entity.UserStatusActive,
-				}
-				mockRepo.On("FindByID", ctx, "user-001").Return(user, nil).Once()
+				mockRepo.On("FindByID", ctx, "root").Return(testUser("root", "super_admin"), nil).Once()
 			},
-			wantErr: true,
+			err: ErrCannotModify,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mock()
-			err := service.UpdateStatus(ctx, tt.userID, tt.status)
+			err := service.UpdateStatus(ctx, tt.userID, tt.status, tt.operator)
 
-			if tt.wantErr {
-				assert.Error(t, err)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
 			} else {
 				assert.NoError(t, err)
 			}
@@ -426,6 +467,12 @@ func TestUserAppService_ChangePassword(t *testing.T) {
 	service := NewUserAppService(mockRepo)
 	ctx := context.Background()
 
+	withPassword := func() *entity.User {
+		user := testUser("user-001", "volunteer")
+		require.NoError(t, user.SetPassword("oldpassword123"))
+		return user
+	}
+
 	tests := []struct {
 		name    string
 		userID  string
@@ -441,14 +488,11 @@ func TestUserAppService_ChangePassword(t *testing.T) {
 				NewPassword: "newpassword123",
 			},
 			mock: func() {
-				user := &entity.User{
-					ID:       "user-001",
-					Password: "$2a$10$...",
-				}
-				mockRepo.On("FindByID", ctx, "user-001").Return(user, nil).Once()
-				mockRepo.On("Update", ctx, mock.AnythingOfType("*entity.User")).Return(nil).Once()
+				mockRepo.On("FindByID", ctx, "user-001").Return(withPassword(), nil).Once()
+				mockRepo.On("Update", ctx, mock.MatchedBy(func(u *entity.User) bool {
+					return u.CheckPassword("newpassword123")
+				})).Return(nil).Once()
 			},
-			wantErr: false,
 		},
 		{
 			name:   "fail - user not found",
@@ -458,7 +502,19 @@ func TestUserAppService_ChangePassword(t *testing.T) {
 				NewPassword: "newpassword123",
 			},
 			mock: func() {
-				mockRepo.On("FindByID", ctx, "user-notfound").Return(nil, errors.New("user not found")).Once()
+				mockRepo.On("FindByID", ctx, "user-notfound").Return(nil, errUserStore).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name:   "fail - wrong old password",
+			userID: "user-001",
+			req: &dto.ChangePasswordRequest{
+				OldPassword: "wrongpassword",
+				NewPassword: "newpassword123",
+			},
+			mock: func() {
+				mockRepo.On("FindByID", ctx, "user-001").Return(withPassword(), nil).Once()
 			},
 			wantErr: true,
 		},
@@ -470,11 +526,7 @@ func TestUserAppService_ChangePassword(t *testing.T) {
 				NewPassword: "123",
 			},
 			mock: func() {
-				user := &entity.User{
-					ID:       "user-001",
-					Password: "$2a$10$...",
-				}
-				mockRepo.On("FindByID", ctx, "user-001").Return(user, nil).Once()
+				mockRepo.On("FindByID", ctx, "user-001").Return(withPassword(), nil).Once()
 			},
 			wantErr: true,
 		},
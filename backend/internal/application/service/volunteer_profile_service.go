@@ -0,0 +1,350 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/dto"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/repository"
+	domainService "github.com/Snowitty-Re/CNtunyuan/internal/domain/service"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/geo"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/logger"
+)
+
+var (
+	ErrVolunteerProfileNotFound  = errors.New("volunteer profile not found")
+	ErrVolunteerProfileInvalid   = errors.New("invalid volunteer profile")
+	ErrVolunteerVacationNotFound = errors.New("volunteer vacation not found")
+)
+
+var _ domainService.DispatchCandidateProvider = (*VolunteerProfileAppService)(nil)
+
+// VolunteerProfileAppService 志愿者档案应用服务：技能、证书、语言方言、服务范围和档期，
+// 供任务派单（作为 DispatchCandidateProvider）和工作流按条件查找志愿者
+type VolunteerProfileAppService struct {
+	profileRepo repository.VolunteerProfileRepository
+	userRepo    repository.UserRepository
+	groupRepo   repository.DialectGroupRepository
+}
+
+// NewVolunteerProfileAppService 创建志愿者档案应用服务
+func NewVolunteerProfileAppService(
+	profileRepo repository.VolunteerProfileRepository,
+	userRepo repository.UserRepository,
+	groupRepo repository.DialectGroupRepository,
+) *VolunteerProfileAppService {
+	return &VolunteerProfileAppService{
+		profileRepo: profileRepo,
+		userRepo:    userRepo,
+		groupRepo:   groupRepo,
+	}
+}
+
+// Get 获取志愿者档案，orgID 非空时只能查看本组织的志愿者
+func (s *VolunteerProfileAppService) Get(ctx context.Context, userID, orgID string) (*dto.VolunteerProfileResponse, error) {
+	profile, err := s.profileRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if profile == nil || (orgID != "" && profile.OrgID != orgID) {
+		return nil, ErrVolunteerProfileNotFound
+	}
+	resp := dto.ToVolunteerProfileResponse(profile, time.Now())
+	return &resp, nil
+}
+
+// Save 创建或更新志愿者档案，证书、语言和值班时段整体替换。orgID 非空时只能修改本组织的志愿者
+func (s *VolunteerProfileAppService) Save(ctx context.Context, userID string, req *dto.VolunteerProfileRequest, operatorID, orgID string) (*dto.VolunteerProfileResponse, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil || (orgID != "" && user.OrgID != orgID) {
+		return nil, ErrUserNotFound
+	}
+
+	profile, err := s.profileRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if profile == nil {
+		profile = &entity.VolunteerProfile{UserID: user.ID}
+	}
+	profile.OrgID = user.OrgID
+	if err := s.apply(ctx, profile, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.profileRepo.SaveWithDetails(ctx, profile); err != nil {
+		logger.Error("Failed to save volunteer profile", logger.String("user_id", userID), logger.Err(err))
+		return nil, err
+	}
+
+	logger.Info("Volunteer profile saved",
+		logger.String("user_id", userID),
+		logger.String("operator_id", operatorID),
+	)
+
+	return s.Get(ctx, userID, "")
+}
+
+// AddVacation 为志愿者添加休假
+func (s *VolunteerProfileAppService) AddVacation(ctx context.Context, userID string, req *dto.VolunteerVacationRequest) (*dto.VolunteerVacationResponse, error) {
+	profile, err := s.find(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	vacation := &entity.VolunteerVacation{
+		ProfileID: profile.ID,
+		StartAt:   req.StartAt,
+		EndAt:     req.EndAt,
+		Reason:    strings.TrimSpace(req.Reason),
+	}
+	if err := vacation.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrVolunteerProfileInvalid, err.Error())
+	}
+	if err := s.profileRepo.AddVacation(ctx, vacation); err != nil {
+		return nil, err
+	}
+
+	return &dto.VolunteerVacationResponse{
+		ID:      vacation.ID,
+		StartAt: vacation.StartAt,
+		EndAt:   vacation.EndAt,
+		Reason:  vacation.Reason,
+	}, nil
+}
+
+// DeleteVacation 删除志愿者的休假
+func (s *VolunteerProfileAppService) DeleteVacation(ctx context.Context, userID, vacationID string) error {
+	profile, err := s.find(ctx, userID)
+	if err != nil {
+		return err
+	}
+	deleted, err := s.profileRepo.DeleteVacation(ctx, profile.ID, vacationID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrVolunteerVacationNotFound
+	}
+	return nil
+}
+
+// Search 按技能、证书、语言方言、服务地点和档期查找本组织的在职志愿者。
+// 指定服务地点时按距离由近到远排列，否则按档案更新时间排列
+func (s *VolunteerProfileAppService) Search(ctx context.Context, req *dto.VolunteerSearchRequest, orgID string) (*dto.VolunteerProfileListResponse, error) {
+	now := time.Now()
+	criteria := &entity.VolunteerCriteria{
+		Skills:    splitQueryList(req.Skills),
+		Languages: splitQueryList(req.Languages),
+		Lat:       req.Lat,
+		Lng:       req.Lng,
+		RadiusKm:  req.RadiusKm,
+		At:        req.AvailableAt,
+	}
+	if req.AvailableNow {
+		criteria.At = &now
+	}
+
+	query := &repository.VolunteerProfileQuery{
+		OrgID:     orgID,
+		Keyword:   strings.TrimSpace(req.Keyword),
+		Province:  req.Province,
+		City:      req.City,
+		Skills:    criteria.Skills,
+		Languages: criteria.Languages,
+	}
+	if req.DialectGroupID != "" {
+		group, err := s.groupRepo.FindByID(ctx, req.DialectGroupID)
+		if err != nil {
+			return nil, ErrDialectGroupNotFound
+		}
+		query.DialectGroupPath = group.Path
+	}
+	located := geo.ValidCoordinate(req.Lat, req.Lng)
+	if located && req.RadiusKm > 0 {
+		query.Bounds = geo.BBoxAround(req.Lat, req.Lng, req.RadiusKm)
+	}
+
+	profiles, err := s.profileRepo.Search(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	type matched struct {
+		profile  *entity.VolunteerProfile
+		distance float64
+	}
+	var results []matched
+	for i := range profiles {
+		if ok, d := profiles[i].Match(criteria, now); ok {
+			results = append(results, matched{profile: &profiles[i], distance: d})
+		}
+	}
+	if located {
+		sort.SliceStable(results, func(i, j int) bool { return results[i].distance < results[j].distance })
+	}
+
+	total := len(results)
+	start := (req.Page - 1) * req.PageSize
+	if start > total {
+		start = total
+	}
+	end := start + req.PageSize
+	if end > total {
+		end = total
+	}
+	page := results[start:end]
+
+	list := make([]dto.VolunteerProfileResponse, len(page))
+	for i, r := range page {
+		list[i] = dto.ToVolunteerProfileResponse(r.profile, now)
+		if r.distance >= 0 {
+			d := r.distance
+			list[i].DistanceKm = &d
+		}
+	}
+	result := repository.NewPageResult(list, int64(total), req.Page, req.PageSize)
+	return &dto.VolunteerProfileListResponse{
+		List:       result.List,
+		Total:      result.Total,
+		Page:       result.Page,
+		PageSize:   result.PageSize,
+		TotalPages: result.TotalPages,
+	}, nil
+}
+
+// ExpiringCertifications 本组织在 days 天内到期的证书，便于提醒志愿者续证
+func (s *VolunteerProfileAppService) ExpiringCertifications(ctx context.Context, req *dto.ExpiringCertificationRequest, orgID string) ([]dto.ExpiringCertificationResponse, error) {
+	now := time.Now()
+	expiring, err := s.profileRepo.FindExpiringCertifications(ctx, orgID, now, now.AddDate(0, 0, req.Days))
+	if err != nil {
+		return nil, err
+	}
+	list := make([]dto.ExpiringCertificationResponse, len(expiring))
+	for i := range expiring {
+		list[i] = dto.ExpiringCertificationResponse{
+			VolunteerCertificationResponse: dto.ToVolunteerCertificationResponse(&expiring[i].Certification, now),
+			UserID:                         expiring[i].Profile.UserID,
+		}
+		if expiring[i].Profile.User != nil {
+			list[i].Nickname = expiring[i].Profile.User.Nickname
+		}
+	}
+	return list, nil
+}
+
+// Enrich 用志愿者档案补充派单候选人的常住地、技能、语言、服务半径和当前是否可用
+func (s *VolunteerProfileAppService) Enrich(ctx context.Context, task *entity.Task, candidates []entity.DispatchCandidate) ([]entity.DispatchCandidate, error) {
+	userIDs := make([]string, len(candidates))
+	for i := range candidates {
+		userIDs[i] = candidates[i].UserID
+	}
+	profiles, err := s.profileRepo.FindByUserIDs(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	byUser := make(map[string]*entity.VolunteerProfile, len(profiles))
+	for i := range profiles {
+		byUser[profiles[i].UserID] = &profiles[i]
+	}
+
+	now := time.Now()
+	for i := range candidates {
+		if p, ok := byUser[candidates[i].UserID]; ok {
+			p.ApplyTo(&candidates[i], now)
+		}
+	}
+	return candidates, nil
+}
+
+// find 获取志愿者档案（含明细）
+func (s *VolunteerProfileAppService) find(ctx context.Context, userID string) (*entity.VolunteerProfile, error) {
+	profile, err := s.profileRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if profile == nil {
+		return nil, ErrVolunteerProfileNotFound
+	}
+	return profile, nil
+}
+
+// apply 将请求写入档案并校验，语言关联的方言分类须存在
+func (s *VolunteerProfileAppService) apply(ctx context.Context, profile *entity.VolunteerProfile, req *dto.VolunteerProfileRequest) error {
+	profile.Province = strings.TrimSpace(req.Province)
+	profile.City = strings.TrimSpace(req.City)
+	profile.District = strings.TrimSpace(req.District)
+	profile.Address = strings.TrimSpace(req.Address)
+	profile.HomeLat = req.HomeLat
+	profile.HomeLng = req.HomeLng
+	profile.ServiceRadiusKm = req.ServiceRadiusKm
+	profile.Bio = strings.TrimSpace(req.Bio)
+	profile.SetSkills(req.Skills)
+
+	profile.Certifications = make([]entity.VolunteerCertification, len(req.Certifications))
+	for i, c := range req.Certifications {
+		profile.Certifications[i] = entity.VolunteerCertification{
+			Name:      strings.TrimSpace(c.Name),
+			Issuer:    strings.TrimSpace(c.Issuer),
+			CertNo:    strings.TrimSpace(c.CertNo),
+			IssuedAt:  c.IssuedAt,
+			ExpiresAt: c.ExpiresAt,
+		}
+	}
+
+	profile.Languages = make([]entity.VolunteerLanguage, len(req.Languages))
+	for i, l := range req.Languages {
+		lang := entity.VolunteerLanguage{
+			Name:  strings.TrimSpace(l.Name),
+			Level: entity.LanguageLevel(l.Level),
+		}
+		if lang.Level == "" {
+			lang.Level = entity.LanguageLevelFluent
+		}
+		if l.DialectGroupID != nil && *l.DialectGroupID != "" {
+			group, err := s.groupRepo.FindByID(ctx, *l.DialectGroupID)
+			if err != nil {
+				return ErrDialectGroupNotFound
+			}
+			lang.DialectGroupID = &group.ID
+			if lang.Name == "" {
+				lang.Name = group.Name
+			}
+		}
+		profile.Languages[i] = lang
+	}
+
+	profile.Availability = make([]entity.VolunteerAvailability, len(req.Availability))
+	for i, a := range req.Availability {
+		start, err := entity.ParseDayMinute(a.Start)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrVolunteerProfileInvalid, err.Error())
+		}
+		end, err := entity.ParseDayMinute(a.End)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrVolunteerProfileInvalid, err.Error())
+		}
+		profile.Availability[i] = entity.VolunteerAvailability{Weekday: a.Weekday, StartMinute: start, EndMinute: end}
+	}
+
+	if err := profile.Validate(); err != nil {
+		return fmt.Errorf("%w: %s", ErrVolunteerProfileInvalid, err.Error())
+	}
+	return nil
+}
+
+// splitQueryList 解析逗号分隔的查询参数，兼容中文逗号
+func splitQueryList(s string) []string {
+	var list []string
+	for _, f := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == '，' }) {
+		if f = strings.TrimSpace(f); f != "" {
+			list = append(list, f)
+		}
+	}
+	return list
+}
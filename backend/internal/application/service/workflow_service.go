@@ -839,6 +839,37 @@ func (s *WorkflowAppService) TransferTask(ctx context.Context, taskID string, to
 	return nil
 }
 
+// ClaimInstanceTask 认领流程实例当前节点未分配处理人的待办任务（按角色分配的节点），返回任务 ID。
+// 已分配给该用户的待办任务直接返回
+func (s *WorkflowAppService) ClaimInstanceTask(ctx context.Context, instanceID string, userID string) (string, error) {
+	tasks, err := s.taskRepo.FindActiveTasksByInstance(ctx, instanceID)
+	if err != nil {
+		return "", err
+	}
+
+	var unassigned *entity.WorkflowTask
+	for _, task := range tasks {
+		if task.AssigneeID != nil && *task.AssigneeID == userID {
+			return task.ID, nil
+		}
+		if task.AssigneeID == nil && unassigned == nil {
+			unassigned = task
+		}
+	}
+	if unassigned == nil {
+		return "", ErrWorkflowTaskNotFound
+	}
+
+	unassigned.AssigneeID = &userID
+	if err := s.taskRepo.Update(ctx, unassigned); err != nil {
+		logger.Error("Failed to claim task", logger.Err(err))
+		return "", err
+	}
+
+	logger.Info("Task claimed", logger.String("task_id", unassigned.ID), logger.String("user_id", userID))
+	return unassigned.ID, nil
+}
+
 // DelegateTask 委托任务
 func (s *WorkflowAppService) DelegateTask(ctx context.Context, taskID string, toUserID string, req *dto.DelegateTaskRequest, userID string) error {
 	task, err := s.taskRepo.FindByID(ctx, taskID)
@@ -4,13 +4,13 @@ import (
 	"context"
 	"errors"
 	"testing"
-	"time"
 
 	"github.com/Snowitty-Re/CNtunyuan/internal/application/dto"
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/service/mocks"
 	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
-	pkgErrors "github.com/Snowitty-Re/CNtunyuan/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // MockWorkflowRepository 工作流仓储Mock
@@ -18,12 +18,22 @@ type MockWorkflowRepository struct {
 	mock.Mock
 }
 
-func (m *MockWorkflowRepository) Create(ctx context.Context, workflow *entity.WorkflowDefinition) error {
-	args := m.Called(ctx, workflow)
+func (m *MockWorkflowRepository) CreateDefinition(ctx context.Context, def *entity.WorkflowDefinition) error {
+	args := m.Called(ctx, def)
 	return args.Error(0)
 }
 
-func (m *MockWorkflowRepository) FindByID(ctx context.Context, id string) (*entity.WorkflowDefinition, error) {
+func (m *MockWorkflowRepository) UpdateDefinition(ctx context.Context, def *entity.WorkflowDefinition) error {
+	args := m.Called(ctx, def)
+	return args.Error(0)
+}
+
+func (m *MockWorkflowRepository) DeleteDefinition(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockWorkflowRepository) FindDefinitionByID(ctx context.Context, id string) (*entity.WorkflowDefinition, error) {
 	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -31,7 +41,7 @@ func (m *MockWorkflowRepository) FindByID(ctx context.Context, id string) (*enti
 	return args.Get(0).(*entity.WorkflowDefinition), args.Error(1)
 }
 
-func (m *MockWorkflowRepository) FindByKey(ctx context.Context, key string, version int) (*entity.WorkflowDefinition, error) {
+func (m *MockWorkflowRepository) FindDefinitionByKey(ctx context.Context, key string, version int) (*entity.WorkflowDefinition, error) {
 	args := m.Called(ctx, key, version)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -39,26 +49,131 @@ func (m *MockWorkflowRepository) FindByKey(ctx context.Context, key string, vers
 	return args.Get(0).(*entity.WorkflowDefinition), args.Error(1)
 }
 
-func (m *MockWorkflowRepository) Update(ctx context.Context, workflow *entity.WorkflowDefinition) error {
-	args := m.Called(ctx, workflow)
+func (m *MockWorkflowRepository) FindActiveDefinitionByKey(ctx context.Context, key string) (*entity.WorkflowDefinition, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.WorkflowDefinition), args.Error(1)
+}
+
+func (m *MockWorkflowRepository) ListDefinitions(ctx context.Context, orgID, category string, page, pageSize int) ([]*entity.WorkflowDefinition, int64, error) {
+	args := m.Called(ctx, orgID, category, page, pageSize)
+	return args.Get(0).([]*entity.WorkflowDefinition), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockWorkflowRepository) GetLatestVersion(ctx context.Context, key string) (int, error) {
+	args := m.Called(ctx, key)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockWorkflowRepository) CreateNode(ctx context.Context, node *entity.WorkflowNode) error {
+	args := m.Called(ctx, node)
 	return args.Error(0)
 }
 
-func (m *MockWorkflowRepository) Delete(ctx context.Context, id string) error {
+func (m *MockWorkflowRepository) UpdateNode(ctx context.Context, node *entity.WorkflowNode) error {
+	args := m.Called(ctx, node)
+	return args.Error(0)
+}
+
+func (m *MockWorkflowRepository) DeleteNode(ctx context.Context, id string) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
 
-func (m *MockWorkflowRepository) List(ctx context.Context, query *entity.WorkflowQuery) ([]*entity.WorkflowDefinition, int64, error) {
-	args := m.Called(ctx, query)
-	return args.Get(0).([]*entity.WorkflowDefinition), args.Get(1).(int64), args.Error(2)
+func (m *MockWorkflowRepository) FindNodeByID(ctx context.Context, id string) (*entity.WorkflowNode, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.WorkflowNode), args.Error(1)
+}
+
+func (m *MockWorkflowRepository) FindNodesByWorkflowID(ctx context.Context, workflowID string) ([]*entity.WorkflowNode, error) {
+	args := m.Called(ctx, workflowID)
+	return args.Get(0).([]*entity.WorkflowNode), args.Error(1)
 }
 
-func (m *MockWorkflowRepository) Publish(ctx context.Context, id string) error {
+func (m *MockWorkflowRepository) DeleteNodesByWorkflowID(ctx context.Context, workflowID string) error {
+	args := m.Called(ctx, workflowID)
+	return args.Error(0)
+}
+
+func (m *MockWorkflowRepository) CreateInstance(ctx context.Context, instance *entity.WorkflowInstance) error {
+	args := m.Called(ctx, instance)
+	return args.Error(0)
+}
+
+func (m *MockWorkflowRepository) UpdateInstance(ctx context.Context, instance *entity.WorkflowInstance) error {
+	args := m.Called(ctx, instance)
+	return args.Error(0)
+}
+
+func (m *MockWorkflowRepository) DeleteInstance(ctx context.Context, id string) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
 
+func (m *MockWorkflowRepository) FindInstanceByID(ctx context.Context, id string) (*entity.WorkflowInstance, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.WorkflowInstance), args.Error(1)
+}
+
+func (m *MockWorkflowRepository) FindInstanceByBusinessKey(ctx context.Context, businessKey string) (*entity.WorkflowInstance, error) {
+	args := m.Called(ctx, businessKey)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.WorkflowInstance), args.Error(1)
+}
+
+func (m *MockWorkflowRepository) ListInstances(ctx context.Context, query *entity.WorkflowQuery) ([]*entity.WorkflowInstance, int64, error) {
+	args := m.Called(ctx, query)
+	return args.Get(0).([]*entity.WorkflowInstance), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockWorkflowRepository) ListMyInstances(ctx context.Context, userID string, page, pageSize int) ([]*entity.WorkflowInstance, int64, error) {
+	args := m.Called(ctx, userID, page, pageSize)
+	return args.Get(0).([]*entity.WorkflowInstance), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockWorkflowRepository) ListMyTodoInstances(ctx context.Context, userID string, page, pageSize int) ([]*entity.WorkflowInstance, int64, error) {
+	args := m.Called(ctx, userID, page, pageSize)
+	return args.Get(0).([]*entity.WorkflowInstance), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockWorkflowRepository) ListMyDoneInstances(ctx context.Context, userID string, page, pageSize int) ([]*entity.WorkflowInstance, int64, error) {
+	args := m.Called(ctx, userID, page, pageSize)
+	return args.Get(0).([]*entity.WorkflowInstance), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockWorkflowRepository) CreateTransition(ctx context.Context, transition *entity.WorkflowTransition) error {
+	args := m.Called(ctx, transition)
+	return args.Error(0)
+}
+
+func (m *MockWorkflowRepository) FindTransitionsByInstanceID(ctx context.Context, instanceID string) ([]*entity.WorkflowTransition, error) {
+	args := m.Called(ctx, instanceID)
+	return args.Get(0).([]*entity.WorkflowTransition), args.Error(1)
+}
+
+func (m *MockWorkflowRepository) GetInstanceStats(ctx context.Context, orgID string) (*entity.WorkflowStats, error) {
+	args := m.Called(ctx, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.WorkflowStats), args.Error(1)
+}
+
+func (m *MockWorkflowRepository) GetUserTaskStats(ctx context.Context, userID string) (map[string]int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(map[string]int64), args.Error(1)
+}
+
 // MockWorkflowTaskRepository 工作流任务仓储Mock
 type MockWorkflowTaskRepository struct {
 	mock.Mock
@@ -69,6 +184,16 @@ func (m *MockWorkflowTaskRepository) Create(ctx context.Context, task *entity.Wo
 	return args.Error(0)
 }
 
+func (m *MockWorkflowTaskRepository) Update(ctx context.Context, task *entity.WorkflowTask) error {
+	args := m.Called(ctx, task)
+	return args.Error(0)
+}
+
+func (m *MockWorkflowTaskRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
 func (m *MockWorkflowTaskRepository) FindByID(ctx context.Context, id string) (*entity.WorkflowTask, error) {
 	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
@@ -82,103 +207,188 @@ func (m *MockWorkflowTaskRepository) FindByInstanceID(ctx context.Context, insta
 	return args.Get(0).([]*entity.WorkflowTask), args.Error(1)
 }
 
-func (m *MockWorkflowTaskRepository) FindPendingByAssignee(ctx context.Context, assigneeID string, page, pageSize int) ([]*entity.WorkflowTask, int64, error) {
-	args := m.Called(ctx, assigneeID, page, pageSize)
+func (m *MockWorkflowTaskRepository) FindByNodeID(ctx context.Context, instanceID, nodeID string) ([]*entity.WorkflowTask, error) {
+	args := m.Called(ctx, instanceID, nodeID)
+	return args.Get(0).([]*entity.WorkflowTask), args.Error(1)
+}
+
+func (m *MockWorkflowTaskRepository) List(ctx context.Context, query *entity.WorkflowTaskQuery) ([]*entity.WorkflowTask, int64, error) {
+	args := m.Called(ctx, query)
 	return args.Get(0).([]*entity.WorkflowTask), args.Get(1).(int64), args.Error(2)
 }
 
-func (m *MockWorkflowTaskRepository) Update(ctx context.Context, task *entity.WorkflowTask) error {
-	args := m.Called(ctx, task)
-	return args.Error(0)
+func (m *MockWorkflowTaskRepository) FindTodoTasks(ctx context.Context, userID string, page, pageSize int) ([]*entity.WorkflowTask, int64, error) {
+	args := m.Called(ctx, userID, page, pageSize)
+	return args.Get(0).([]*entity.WorkflowTask), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockWorkflowTaskRepository) FindDoneTasks(ctx context.Context, userID string, page, pageSize int) ([]*entity.WorkflowTask, int64, error) {
+	args := m.Called(ctx, userID, page, pageSize)
+	return args.Get(0).([]*entity.WorkflowTask), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockWorkflowTaskRepository) FindTasksByInstance(ctx context.Context, instanceID string) ([]*entity.WorkflowTask, error) {
+	args := m.Called(ctx, instanceID)
+	return args.Get(0).([]*entity.WorkflowTask), args.Error(1)
+}
+
+func (m *MockWorkflowTaskRepository) FindActiveTasksByInstance(ctx context.Context, instanceID string) ([]*entity.WorkflowTask, error) {
+	args := m.Called(ctx, instanceID)
+	return args.Get(0).([]*entity.WorkflowTask), args.Error(1)
 }
 
-func (m *MockWorkflowTaskRepository) Complete(ctx context.Context, taskID string, action entity.WorkflowAction, comment string, userID string) error {
-	args := m.Called(ctx, taskID, action, comment, userID)
+func (m *MockWorkflowTaskRepository) FindActiveTaskByInstanceAndNode(ctx context.Context, instanceID, nodeID string) (*entity.WorkflowTask, error) {
+	args := m.Called(ctx, instanceID, nodeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.WorkflowTask), args.Error(1)
+}
+
+func (m *MockWorkflowTaskRepository) CountTodoByUser(ctx context.Context, userID string) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockWorkflowTaskRepository) CountByInstance(ctx context.Context, instanceID string) (int64, error) {
+	args := m.Called(ctx, instanceID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockWorkflowTaskRepository) CountCompletedByInstanceAndNode(ctx context.Context, instanceID, nodeID string) (int64, error) {
+	args := m.Called(ctx, instanceID, nodeID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockWorkflowTaskRepository) CreateDelegation(ctx context.Context, delegation *entity.WorkflowDelegation) error {
+	args := m.Called(ctx, delegation)
 	return args.Error(0)
 }
 
-// MockUserRepositoryForWorkflow 用户仓储Mock
-type MockUserRepositoryForWorkflow struct {
-	mock.Mock
+func (m *MockWorkflowTaskRepository) UpdateDelegation(ctx context.Context, delegation *entity.WorkflowDelegation) error {
+	args := m.Called(ctx, delegation)
+	return args.Error(0)
 }
 
-func (m *MockUserRepositoryForWorkflow) FindByID(ctx context.Context, id string) (*entity.User, error) {
+func (m *MockWorkflowTaskRepository) FindDelegationByID(ctx context.Context, id string) (*entity.WorkflowDelegation, error) {
 	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).(*entity.User), args.Error(1)
+	return args.Get(0).(*entity.WorkflowDelegation), args.Error(1)
 }
 
-func TestWorkflowAppService_CreateDefinition(t *testing.T) {
+func (m *MockWorkflowTaskRepository) FindActiveDelegation(ctx context.Context, taskID string) (*entity.WorkflowDelegation, error) {
+	args := m.Called(ctx, taskID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.WorkflowDelegation), args.Error(1)
+}
+
+func (m *MockWorkflowTaskRepository) CreateReminder(ctx context.Context, reminder *entity.WorkflowReminder) error {
+	args := m.Called(ctx, reminder)
+	return args.Error(0)
+}
+
+func (m *MockWorkflowTaskRepository) UpdateReminder(ctx context.Context, reminder *entity.WorkflowReminder) error {
+	args := m.Called(ctx, reminder)
+	return args.Error(0)
+}
+
+func (m *MockWorkflowTaskRepository) FindReminderByTaskID(ctx context.Context, taskID string) (*entity.WorkflowReminder, error) {
+	args := m.Called(ctx, taskID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.WorkflowReminder), args.Error(1)
+}
+
+func newWorkflowTestService() (*WorkflowAppService, *MockWorkflowRepository, *MockWorkflowTaskRepository) {
 	workflowRepo := new(MockWorkflowRepository)
 	taskRepo := new(MockWorkflowTaskRepository)
-	userRepo := new(MockUserRepositoryForWorkflow)
-	service := NewWorkflowAppService(workflowRepo, taskRepo, userRepo)
+	return NewWorkflowAppService(workflowRepo, taskRepo, new(mocks.UserRepositoryMock)), workflowRepo, taskRepo
+}
+
+func workflowNode(id string, nodeType entity.WorkflowNodeType) entity.WorkflowNode {
+	return entity.WorkflowNode{BaseEntity: entity.BaseEntity{ID: id}, Name: id, Type: nodeType}
+}
+
+func workflowTask(id, assigneeID string, status entity.TaskStatus) *entity.WorkflowTask {
+	task := &entity.WorkflowTask{WorkflowInstanceID: "inst-001", WorkflowNodeID: "approve"}
+	task.ID = id
+	task.Status = status
+	if assigneeID != "" {
+		task.AssigneeID = &assigneeID
+	}
+	return task
+}
+
+func TestWorkflowAppService_CreateDefinition(t *testing.T) {
 	ctx := context.Background()
+	req := func() *dto.CreateWorkflowDefinitionRequest {
+		return &dto.CreateWorkflowDefinitionRequest{
+			Name:        "请假审批",
+			Key:         "leave_request",
+			Description: "员工请假审批流程",
+			Category:    "人事",
+			OrgID:       "org-001",
+			Nodes: []dto.CreateWorkflowNodeRequest{
+				{ID: "start", Name: "发起", Type: string(entity.NodeTypeStart)},
+				{ID: "approve", Name: "审批", Type: string(entity.NodeTypeApproval)},
+			},
+		}
+	}
 
 	tests := []struct {
-		name    string
-		req     *dto.CreateWorkflowDefinitionRequest
-		mock    func()
-		wantErr bool
-		errCode pkgErrors.ErrorCode
+		name        string
+		mock        func(repo *MockWorkflowRepository)
+		wantErr     bool
+		wantVersion int
 	}{
 		{
-			name: "success - create workflow definition",
-			req: &dto.CreateWorkflowDefinitionRequest{
-				Name:        "请假审批",
-				Key:         "leave_request",
-				Description: "员工请假审批流程",
-				Category:    "人事",
-				OrgID:       "org-001",
-			},
-			mock: func() {
-				workflowRepo.On("Create", ctx, mock.AnythingOfType("*entity.WorkflowDefinition")).Return(nil).Once()
+			name: "success - new version with start node",
+			mock: func(repo *MockWorkflowRepository) {
+				repo.On("GetLatestVersion", ctx, "leave_request").Return(1, nil).Once()
+				repo.On("CreateDefinition", ctx, mock.MatchedBy(func(def *entity.WorkflowDefinition) bool {
+					return def.Version == 2 && def.Category == "人事"
+				})).Return(nil).Once()
+				repo.On("CreateNode", ctx, mock.AnythingOfType("*entity.WorkflowNode")).Return(nil).Twice()
+				repo.On("UpdateDefinition", ctx, mock.MatchedBy(func(def *entity.WorkflowDefinition) bool {
+					return def.StartNodeID != nil && *def.StartNodeID == "start"
+				})).Return(nil).Once()
 			},
-			wantErr: false,
+			wantVersion: 2,
 		},
 		{
-			name: "fail - duplicate key",
-			req: &dto.CreateWorkflowDefinitionRequest{
-				Name:     "请假审批",
-				Key:      "leave_request",
-				Category: "人事",
-				OrgID:    "org-001",
-			},
-			mock: func() {
-				workflowRepo.On("Create", ctx, mock.AnythingOfType("*entity.WorkflowDefinition")).Return(errors.New("duplicate key")).Once()
+			name: "fail - version lookup",
+			mock: func(repo *MockWorkflowRepository) {
+				repo.On("GetLatestVersion", ctx, "leave_request").Return(0, errors.New("db down")).Once()
 			},
 			wantErr: true,
-			errCode: pkgErrors.CodeWorkflowInstanceExists,
 		},
 		{
-			name: "fail - empty name",
-			req: &dto.CreateWorkflowDefinitionRequest{
-				Name:     "",
-				Key:      "leave_request",
-				Category: "人事",
-				OrgID:    "org-001",
+			name: "fail - duplicate key",
+			mock: func(repo *MockWorkflowRepository) {
+				repo.On("GetLatestVersion", ctx, "leave_request").Return(0, nil).Once()
+				repo.On("CreateDefinition", ctx, mock.AnythingOfType("*entity.WorkflowDefinition")).Return(errors.New("duplicate key")).Once()
 			},
-			mock:    func() {},
 			wantErr: true,
-			errCode: pkgErrors.CodeInvalidParam,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tt.mock()
-			resp, err := service.CreateDefinition(ctx, tt.req)
+			service, workflowRepo, _ := newWorkflowTestService()
+			tt.mock(workflowRepo)
+			resp, err := service.CreateDefinition(ctx, req())
 
 			if tt.wantErr {
 				assert.Error(t, err)
-				if tt.errCode != 0 {
-					assert.Equal(t, tt.errCode, pkgErrors.GetCode(err))
-				}
 			} else {
-				assert.NoError(t, err)
-				assert.NotNil(t, resp)
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantVersion, resp.Version)
+				assert.Equal(t, "leave_request", resp.Key)
 			}
 
 			workflowRepo.AssertExpectations(t)
@@ -187,60 +397,56 @@ func TestWorkflowAppService_CreateDefinition(t *testing.T) {
 }
 
 func TestWorkflowAppService_GetDefinition(t *testing.T) {
-	workflowRepo := new(MockWorkflowRepository)
-	taskRepo := new(MockWorkflowTaskRepository)
-	userRepo := new(MockUserRepositoryForWorkflow)
-	service := NewWorkflowAppService(workflowRepo, taskRepo, userRepo)
 	ctx := context.Background()
 
 	tests := []struct {
-		name         string
-		workflowID   string
-		mock         func()
-		wantErr      bool
-		errCode      pkgErrors.ErrorCode
+		name       string
+		workflowID string
+		mock       func(repo *MockWorkflowRepository)
+		err        error
+		wantErr    bool
 	}{
 		{
 			name:       "success - get workflow definition",
 			workflowID: "wf-001",
-			mock: func() {
-				workflow := &entity.WorkflowDefinition{
-					ID:          "wf-001",
-					Name:        "请假审批",
-					Key:         "leave_request",
-					Description: "员工请假审批流程",
-					Status:      entity.WorkflowStatusActive,
-					Version:     1,
-					CreatedAt:   time.Now(),
-				}
-				workflowRepo.On("FindByID", ctx, "wf-001").Return(workflow, nil).Once()
+			mock: func(repo *MockWorkflowRepository) {
+				def := entity.NewWorkflowDefinition("请假审批", "leave_request", "org-001")
+				def.ID = "wf-001"
+				repo.On("FindDefinitionByID", ctx, "wf-001").Return(def, nil).Once()
 			},
-			wantErr: false,
 		},
 		{
 			name:       "fail - workflow not found",
 			workflowID: "wf-notfound",
-			mock: func() {
-				workflowRepo.On("FindByID", ctx, "wf-notfound").Return(nil, errors.New("not found")).Once()
+			mock: func(repo *MockWorkflowRepository) {
+				repo.On("FindDefinitionByID", ctx, "wf-notfound").Return(nil, nil).Once()
+			},
+			wantErr: true,
+			err:     ErrWorkflowNotFound,
+		},
+		{
+			name:       "fail - repository error",
+			workflowID: "wf-001",
+			mock: func(repo *MockWorkflowRepository) {
+				repo.On("FindDefinitionByID", ctx, "wf-001").Return(nil, errors.New("db down")).Once()
 			},
 			wantErr: true,
-			errCode: pkgErrors.CodeWorkflowNotFound,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tt.mock()
+			service, workflowRepo, _ := newWorkflowTestService()
+			tt.mock(workflowRepo)
 			resp, err := service.GetDefinition(ctx, tt.workflowID)
 
 			if tt.wantErr {
 				assert.Error(t, err)
-				if tt.errCode != 0 {
-					assert.Equal(t, tt.errCode, pkgErrors.GetCode(err))
+				if tt.err != nil {
+					assert.ErrorIs(t, err, tt.err)
 				}
 			} else {
-				assert.NoError(t, err)
-				assert.NotNil(t, resp)
+				require.NoError(t, err)
 				assert.Equal(t, tt.workflowID, resp.ID)
 			}
 
@@ -250,133 +456,138 @@ func TestWorkflowAppService_GetDefinition(t *testing.T) {
 }
 
 func TestWorkflowAppService_ListDefinitions(t *testing.T) {
-	workflowRepo := new(MockWorkflowRepository)
-	taskRepo := new(MockWorkflowTaskRepository)
-	userRepo := new(MockUserRepositoryForWorkflow)
-	service := NewWorkflowAppService(workflowRepo, taskRepo, userRepo)
 	ctx := context.Background()
+	service, workflowRepo, _ := newWorkflowTestService()
 
-	tests := []struct {
-		name    string
-		req     *dto.WorkflowListRequest
-		mock    func()
-		wantErr bool
-	}{
-		{
-			name: "success - list workflow definitions",
-			req: &dto.WorkflowListRequest{
-				Page:     1,
-				PageSize: 10,
-				Keyword:  "请假",
-				Category: "人事",
-				Status:   "active",
-			},
-			mock: func() {
-				workflows := []*entity.WorkflowDefinition{
-					{ID: "wf-001", Name: "请假审批", Key: "leave_request"},
-					{ID: "wf-002", Name: "加班申请", Key: "overtime_request"},
-				}
-				workflowRepo.On("List", ctx, mock.AnythingOfType("*entity.WorkflowQuery")).Return(workflows, int64(2), nil).Once()
-			},
-			wantErr: false,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			tt.mock()
-			resp, err := service.ListDefinitions(ctx, tt.req)
-
-			if tt.wantErr {
-				assert.Error(t, err)
-			} else {
-				assert.NoError(t, err)
-				assert.NotNil(t, resp)
-				assert.GreaterOrEqual(t, len(resp.List), 0)
-			}
-
-			workflowRepo.AssertExpectations(t)
-		})
+	defs := []*entity.WorkflowDefinition{
+		entity.NewWorkflowDefinition("请假审批", "leave_request", "org-001"),
+		entity.NewWorkflowDefinition("加班申请", "overtime_request", "org-001"),
 	}
+	workflowRepo.On("ListDefinitions", ctx, "org-001", "人事", 1, 10).Return(defs, int64(12), nil).Once()
+
+	resp, err := service.ListDefinitions(ctx, &dto.ListWorkflowDefinitionsRequest{
+		OrgID:    "org-001",
+		Category: "人事",
+		Page:     1,
+		PageSize: 10,
+	})
+	require.NoError(t, err)
+	assert.Len(t, resp.List, 2)
+	assert.Equal(t, int64(12), resp.Total)
+	assert.Equal(t, "overtime_request", resp.List[1].Key)
+	workflowRepo.AssertExpectations(t)
 }
 
 func TestWorkflowAppService_ApproveTask(t *testing.T) {
-	workflowRepo := new(MockWorkflowRepository)
-	taskRepo := new(MockWorkflowTaskRepository)
-	userRepo := new(MockUserRepositoryForWorkflow)
-	service := NewWorkflowAppService(workflowRepo, taskRepo, userRepo)
 	ctx := context.Background()
+	req := &dto.ApproveTaskRequest{Comment: "同意"}
+
+	// 审批节点之后只剩结束节点，审批通过即流程结束
+	instance := func() *entity.WorkflowInstance {
+		def := entity.NewWorkflowDefinition("请假审批", "leave_request", "org-001")
+		def.Nodes = []entity.WorkflowNode{
+			workflowNode("start", entity.NodeTypeStart),
+			workflowNode("approve", entity.NodeTypeApproval),
+			workflowNode("end", entity.NodeTypeEnd),
+		}
+		inst := &entity.WorkflowInstance{Status: entity.InstanceStatusProcessing, Definition: def}
+		inst.ID = "inst-001"
+		return inst
+	}
 
 	tests := []struct {
 		name    string
 		taskID  string
 		userID  string
-		req     *dto.ApproveTaskRequest
-		mock    func()
+		mock    func(workflowRepo *MockWorkflowRepository, taskRepo *MockWorkflowTaskRepository)
+		err     error
 		wantErr bool
-		errCode pkgErrors.ErrorCode
 	}{
 		{
-			name:   "success - approve task",
+			name:   "success - last approval completes the instance",
 			taskID: "task-001",
 			userID: "user-001",
-			req: &dto.ApproveTaskRequest{
-				Action:  "approve",
-				Comment: "同意",
+			mock: func(workflowRepo *MockWorkflowRepository, taskRepo *MockWorkflowTaskRepository) {
+				taskRepo.On("FindByID", ctx, "task-001").Return(workflowTask("task-001", "user-001", entity.TaskStatusPending), nil).Once()
+				taskRepo.On("Update", ctx, mock.MatchedBy(func(task *entity.WorkflowTask) bool {
+					return task.Status == entity.TaskStatusCompleted && task.ApprovalComment == "同意"
+				})).Return(nil).Once()
+				workflowRepo.On("CreateTransition", ctx, mock.AnythingOfType("*entity.WorkflowTransition")).Return(nil).Once()
+				workflowRepo.On("FindInstanceByID", ctx, "inst-001").Return(instance(), nil).Once()
+				taskRepo.On("FindActiveTasksByInstance", ctx, "inst-001").Return([]*entity.WorkflowTask{}, nil).Once()
+				workflowRepo.On("UpdateInstance", ctx, mock.MatchedBy(func(inst *entity.WorkflowInstance) bool {
+					return inst.Status == entity.InstanceStatusApproved
+				})).Return(nil).Once()
 			},
-			mock: func() {
-				task := &entity.WorkflowTask{
-					ID:         "task-001",
-					AssigneeID: "user-001",
-					Status:     entity.WorkflowTaskStatusPending,
-				}
-				taskRepo.On("FindByID", ctx, "task-001").Return(task, nil).Once()
-				taskRepo.On("Complete", ctx, "task-001", entity.WorkflowActionApprove, "同意", "user-001").Return(nil).Once()
+		},
+		{
+			name:   "success - other approvers still pending",
+			taskID: "task-001",
+			userID: "user-001",
+			mock: func(workflowRepo *MockWorkflowRepository, taskRepo *MockWorkflowTaskRepository) {
+				taskRepo.On("FindByID", ctx, "task-001").Return(workflowTask("task-001", "user-001", entity.TaskStatusPending), nil).Once()
+				taskRepo.On("Update", ctx, mock.AnythingOfType("*entity.WorkflowTask")).Return(nil).Once()
+				workflowRepo.On("CreateTransition", ctx, mock.AnythingOfType("*entity.WorkflowTransition")).Return(nil).Once()
+				workflowRepo.On("FindInstanceByID", ctx, "inst-001").Return(instance(), nil).Once()
+				taskRepo.On("FindActiveTasksByInstance", ctx, "inst-001").
+					Return([]*entity.WorkflowTask{workflowTask("task-002", "user-002", entity.TaskStatusPending)}, nil).Once()
+			},
+		},
+		{
+			name:   "success - delegated approver",
+			taskID: "task-001",
+			userID: "user-002",
+			mock: func(workflowRepo *MockWorkflowRepository, taskRepo *MockWorkflowTaskRepository) {
+				taskRepo.On("FindByID", ctx, "task-001").Return(workflowTask("task-001", "user-001", entity.TaskStatusPending), nil).Once()
+				taskRepo.On("FindActiveDelegation", ctx, "task-001").Return(&entity.WorkflowDelegation{ToUserID: "user-002"}, nil).Once()
+				taskRepo.On("Update", ctx, mock.AnythingOfType("*entity.WorkflowTask")).Return(nil).Once()
+				workflowRepo.On("CreateTransition", ctx, mock.AnythingOfType("*entity.WorkflowTransition")).Return(nil).Once()
+				workflowRepo.On("FindInstanceByID", ctx, "inst-001").Return(instance(), nil).Once()
+				taskRepo.On("FindActiveTasksByInstance", ctx, "inst-001").Return([]*entity.WorkflowTask{}, nil).Once()
+				workflowRepo.On("UpdateInstance", ctx, mock.AnythingOfType("*entity.WorkflowInstance")).Return(nil).Once()
 			},
-			wantErr: false,
 		},
 		{
 			name:   "fail - task not found",
 			taskID: "task-notfound",
 			userID: "user-001",
-			req: &dto.ApproveTaskRequest{
-				Action: "approve",
-			},
-			mock: func() {
-				taskRepo.On("FindByID", ctx, "task-notfound").Return(nil, errors.New("not found")).Once()
+			mock: func(_ *MockWorkflowRepository, taskRepo *MockWorkflowTaskRepository) {
+				taskRepo.On("FindByID", ctx, "task-notfound").Return(nil, nil).Once()
 			},
 			wantErr: true,
-			errCode: pkgErrors.CodeWorkflowNotFound,
+			err:     ErrTaskNotFound,
 		},
 		{
-			name:   "fail - invalid action",
+			name:   "fail - task already completed",
 			taskID: "task-001",
 			userID: "user-001",
-			req: &dto.ApproveTaskRequest{
-				Action: "invalid",
+			mock: func(_ *MockWorkflowRepository, taskRepo *MockWorkflowTaskRepository) {
+				taskRepo.On("FindByID", ctx, "task-001").Return(workflowTask("task-001", "user-001", entity.TaskStatusCompleted), nil).Once()
 			},
-			mock: func() {
-				task := &entity.WorkflowTask{
-					ID:         "task-001",
-					AssigneeID: "user-001",
-					Status:     entity.WorkflowTaskStatusPending,
-				}
-				taskRepo.On("FindByID", ctx, "task-001").Return(task, nil).Once()
+			wantErr: true,
+		},
+		{
+			name:   "fail - not the assignee",
+			taskID: "task-001",
+			userID: "user-003",
+			mock: func(_ *MockWorkflowRepository, taskRepo *MockWorkflowTaskRepository) {
+				taskRepo.On("FindByID", ctx, "task-001").Return(workflowTask("task-001", "user-001", entity.TaskStatusPending), nil).Once()
+				taskRepo.On("FindActiveDelegation", ctx, "task-001").Return(nil, nil).Once()
 			},
 			wantErr: true,
-			errCode: pkgErrors.CodeInvalidParam,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tt.mock()
-			err := service.ApproveTask(ctx, tt.taskID, tt.userID, tt.req)
+			service, workflowRepo, taskRepo := newWorkflowTestService()
+			tt.mock(workflowRepo, taskRepo)
+			err := service.ApproveTask(ctx, tt.taskID, req, tt.userID)
 
 			if tt.wantErr {
 				assert.Error(t, err)
-				if tt.errCode != 0 {
-					assert.Equal(t, tt.errCode, pkgErrors.GetCode(err))
+				if tt.err != nil {
+					assert.ErrorIs(t, err, tt.err)
 				}
 			} else {
 				assert.NoError(t, err)
@@ -388,51 +599,20 @@ func TestWorkflowAppService_ApproveTask(t *testing.T) {
 	}
 }
 
-func TestWorkflowAppService_GetTodoList(t *testing.T) {
-	workflowRepo := new(MockWorkflowRepository)
-	taskRepo := new(MockWorkflowTaskRepository)
-	userRepo := new(MockUserRepositoryForWorkflow)
-	service := NewWorkflowAppService(workflowRepo, taskRepo, userRepo)
+func TestWorkflowAppService_ListTodoTasks(t *testing.T) {
 	ctx := context.Background()
+	service, _, taskRepo := newWorkflowTestService()
 
-	tests := []struct {
-		name    string
-		userID  string
-		req     *dto.WorkflowTodoRequest
-		mock    func()
-		wantErr bool
-	}{
-		{
-			name:   "success - get todo list",
-			userID: "user-001",
-			req: &dto.WorkflowTodoRequest{
-				Page:     1,
-				PageSize: 10,
-			},
-			mock: func() {
-				tasks := []*entity.WorkflowTask{
-					{ID: "task-001", AssigneeID: "user-001", Status: entity.WorkflowTaskStatusPending},
-					{ID: "task-002", AssigneeID: "user-001", Status: entity.WorkflowTaskStatusPending},
-				}
-				taskRepo.On("FindPendingByAssignee", ctx, "user-001", 1, 10).Return(tasks, int64(2), nil).Once()
-			},
-			wantErr: false,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			tt.mock()
-			resp, err := service.GetTodoList(ctx, tt.userID, tt.req)
-
-			if tt.wantErr {
-				assert.Error(t, err)
-			} else {
-				assert.NoError(t, err)
-				assert.NotNil(t, resp)
-			}
-
-			taskRepo.AssertExpectations(t)
-		})
+	tasks := []*entity.WorkflowTask{
+		workflowTask("task-001", "user-001", entity.TaskStatusPending),
+		workflowTask("task-002", "user-001", entity.TaskStatusPending),
 	}
+	taskRepo.On("FindTodoTasks", ctx, "user-001", 1, 10).Return(tasks, int64(2), nil).Once()
+
+	resp, err := service.ListTodoTasks(ctx, "user-001", 1, 10)
+	require.NoError(t, err)
+	assert.Len(t, resp.List, 2)
+	assert.Equal(t, "task-001", resp.List[0].ID)
+	assert.Equal(t, int64(2), resp.Total)
+	taskRepo.AssertExpectations(t)
 }
@@ -21,6 +21,14 @@ type Config struct {
 	Log          LogConfig          `mapstructure:"log"`
 	Notification NotificationConfig `mapstructure:"notification"`
 	System       SystemConfig       `mapstructure:"system"`
+	Public       PublicConfig       `mapstructure:"public"`
+	Poster       PosterConfig       `mapstructure:"poster"`
+	Export       ExportConfig       `mapstructure:"export"`
+	Search       SearchConfig       `mapstructure:"search"`
+	Urgency      UrgencyConfig      `mapstructure:"urgency"`
+	Reunion      ReunionConfig      `mapstructure:"reunion"`
+	Sensitive    SensitiveConfig    `mapstructure:"sensitive"`
+	Dispatch     DispatchConfig     `mapstructure:"dispatch"`
 }
 
 // ServerConfig 服务器配置
@@ -133,6 +141,19 @@ type StorageConfig struct {
 	COSSecretKey string `mapstructure:"cos_secret_key"`
 	COSBucket    string `mapstructure:"cos_bucket"`
 	COSRegion    string `mapstructure:"cos_region"`
+	// 图片处理配置
+	ImageProcessing    bool   `mapstructure:"image_processing"`     // 是否生成缩略图/分享图并去除元数据
+	ImageThumbnailSize int    `mapstructure:"image_thumbnail_size"` // 缩略图最长边
+	ImageShareSize     int    `mapstructure:"image_share_size"`     // 分享图最长边
+	ImageWatermark     string `mapstructure:"image_watermark"`      // 分享图水印文字（仅支持 ASCII）
+	ImageSimilarity    int    `mapstructure:"image_similarity"`     // 相同照片判定的感知哈希汉明距离
+	ImageOriginalPath  string `mapstructure:"image_original_path"`  // 图片原图（含 EXIF）存储目录，不能位于公开的 local_path 下
+	// 音频处理配置
+	AudioProcessing     bool    `mapstructure:"audio_processing"`      // 是否对音频做响度归一化、转码并生成波形
+	AudioFFmpegPath     string  `mapstructure:"audio_ffmpeg_path"`     // 本地 ffmpeg 路径，为空时在 PATH 中查找
+	AudioTargetLoudness float64 `mapstructure:"audio_target_loudness"` // 归一化目标响度（LUFS）
+	AudioBitrate        int     `mapstructure:"audio_bitrate"`         // 转码码率（kbps）
+	AudioSimilarity     float64 `mapstructure:"audio_similarity"`      // 重复录音判定的声纹指纹相似度（0~1）
 }
 
 // SMSConfig 短信配置
@@ -199,6 +220,69 @@ type SystemConfig struct {
 	RateLimit         int    `mapstructure:"rate_limit"`
 }
 
+// PublicConfig 公开接口配置（案件公示板、公众线索提交）
+type PublicConfig struct {
+	BoardRateLimit float64 `mapstructure:"board_rate_limit"` // 公示板每 IP 每秒请求数
+	BoardBurst     int     `mapstructure:"board_burst"`
+	LeadRateLimit  float64 `mapstructure:"lead_rate_limit"` // 线索提交每 IP 每秒请求数
+	LeadBurst      int     `mapstructure:"lead_burst"`
+	CaptchaEnabled bool    `mapstructure:"captcha_enabled"`
+	CaptchaSecret  string  `mapstructure:"captcha_secret"` // 验证码签名密钥，启用验证码时必须配置且不能与 JWT 密钥相同
+	CaptchaTTL     int     `mapstructure:"captcha_ttl"`    // 秒
+}
+
+// PosterConfig 寻人海报配置
+type PosterConfig struct {
+	FontPath    string `mapstructure:"font_path"`     // 中文 TrueType 字体（生成海报和移交摘要 PDF），为空时自动查找系统字体
+	CasePageURL string `mapstructure:"case_page_url"` // 二维码跳转的公开案件页，{id} 替换为案件 ID
+}
+
+// ExportConfig 导出配置（案件移交包、方言歌单离线包）
+type ExportConfig struct {
+	SigningKey     string `mapstructure:"signing_key"`      // Ed25519 私钥种子（32 字节，Base64）
+	SigningKeyFile string `mapstructure:"signing_key_file"` // 未配置 signing_key 时使用的私钥文件，不存在时首次启动自动生成
+	MaxPhotoBytes  int64  `mapstructure:"max_photo_bytes"`  // 导出包中照片总大小上限
+	MaxAudioBytes  int64  `mapstructure:"max_audio_bytes"`  // 方言离线包中音频总大小上限
+}
+
+// SearchConfig 案件全文检索配置
+type SearchConfig struct {
+	Engine       string `mapstructure:"engine"`        // memory: 内置中文全文索引；database: 数据库模糊查询
+	IndexPath    string `mapstructure:"index_path"`    // 索引快照文件，为空时不持久化，每次启动全量重建
+	SyncInterval int    `mapstructure:"sync_interval"` // 增量同步间隔（秒），用于同步其它实例或外部写入的修改，0 为不同步
+}
+
+// UrgencyConfig 案件紧急程度评分配置（评分规则按组织在系统中配置）
+type UrgencyConfig struct {
+	Enabled  bool `mapstructure:"enabled"`  // 是否定期重新评分
+	Interval int  `mapstructure:"interval"` // 重新评分间隔（秒）
+}
+
+// ReunionConfig 团聚回访配置
+type ReunionConfig struct {
+	Enabled  bool `mapstructure:"enabled"`   // 是否定期生成回访任务
+	Interval int  `mapstructure:"interval"`  // 检查到期回访的间隔（秒）
+	LeadDays int  `mapstructure:"lead_days"` // 回访到期前提前生成任务的天数
+}
+
+// SensitiveConfig 用户评论敏感词过滤配置
+type SensitiveConfig struct {
+	Enabled  bool   `mapstructure:"enabled"`
+	DictPath string `mapstructure:"dict_path"` // 本地词表，每行一个词，与内置词典合并
+	Action   string `mapstructure:"action"`    // mask: 替换为 * 并标记待审；reject: 拒绝发布
+}
+
+// DispatchConfig 任务自动派单配置
+type DispatchConfig struct {
+	Enabled       bool    `mapstructure:"enabled"`         // 是否自动为待分配任务派单
+	Interval      int     `mapstructure:"interval"`        // 检查超时邀请和待派单任务的间隔（秒）
+	OfferSize     int     `mapstructure:"offer_size"`      // 每轮推送的志愿者数
+	OfferTTL      int     `mapstructure:"offer_ttl"`       // 邀请有效期（秒），超时视为拒绝
+	MaxRounds     int     `mapstructure:"max_rounds"`      // 最多推送轮数，之后转人工分配
+	MaxOpenTasks  int     `mapstructure:"max_open_tasks"`  // 在办任务达到该数量的志愿者不再派单，0 不限
+	MaxDistanceKm float64 `mapstructure:"max_distance_km"` // 超过该距离的志愿者不参与派单，0 不限
+}
+
 var globalConfig *Config
 
 // LoadConfig 加载配置
@@ -283,7 +367,18 @@ func setDefaults() {
 	viper.SetDefault("storage.local_path", "./uploads")
 	viper.SetDefault("storage.base_url", "http://localhost:8080/uploads")
 	viper.SetDefault("storage.max_file_size", 52428800) // 50MB
-	viper.SetDefault("storage.allowed_types", "jpg,png,gif,mp4,mp3,wav")
+	viper.SetDefault("storage.allowed_types", "jpg,png,gif,mp4,mp3,wav,aac,m4a,amr")
+	viper.SetDefault("storage.image_processing", true)
+	viper.SetDefault("storage.image_thumbnail_size", 320)
+	viper.SetDefault("storage.image_share_size", 1080)
+	viper.SetDefault("storage.image_watermark", "CNTUANYUAN")
+	viper.SetDefault("storage.image_similarity", 10)
+	viper.SetDefault("storage.image_original_path", "./data/originals")
+	viper.SetDefault("storage.audio_processing", true)
+	viper.SetDefault("storage.audio_ffmpeg_path", "")
+	viper.SetDefault("storage.audio_target_loudness", -16)
+	viper.SetDefault("storage.audio_bitrate", 64)
+	viper.SetDefault("storage.audio_similarity", 0.75)
 
 	// SMS defaults
 	viper.SetDefault("sms.provider", "aliyun")
@@ -318,4 +413,48 @@ func setDefaults() {
 	viper.SetDefault("system.enable_wechat_login", true)
 	viper.SetDefault("system.enable_sms_login", false)
 	viper.SetDefault("system.rate_limit", 100)
+
+	// Public defaults
+	viper.SetDefault("public.board_rate_limit", 5)
+	viper.SetDefault("public.board_burst", 20)
+	viper.SetDefault("public.lead_rate_limit", 0.05) // 平均每 20 秒 1 条
+	viper.SetDefault("public.lead_burst", 3)
+	viper.SetDefault("public.captcha_enabled", true)
+	viper.SetDefault("public.captcha_ttl", 300)
+
+	// Poster defaults
+	viper.SetDefault("poster.case_page_url", "http://localhost:8080/public/cases/{id}")
+
+	// Export defaults
+	viper.SetDefault("export.signing_key_file", "./data/keys/export_signing.key")
+	viper.SetDefault("export.max_photo_bytes", 100<<20)
+	viper.SetDefault("export.max_audio_bytes", 200<<20)
+
+	// Search defaults
+	viper.SetDefault("search.engine", "memory")
+	viper.SetDefault("search.index_path", "./data/search/cases.idx")
+	viper.SetDefault("search.sync_interval", 300)
+
+	// Urgency defaults
+	viper.SetDefault("urgency.enabled", true)
+	viper.SetDefault("urgency.interval", 1800)
+
+	// Reunion defaults
+	viper.SetDefault("reunion.enabled", true)
+	viper.SetDefault("reunion.interval", 3600)
+	viper.SetDefault("reunion.lead_days", 7)
+
+	// Sensitive defaults
+	viper.SetDefault("sensitive.enabled", true)
+	viper.SetDefault("sensitive.dict_path", "./config/sensitive_words.txt")
+	viper.SetDefault("sensitive.action", "mask")
+
+	// Dispatch defaults
+	viper.SetDefault("dispatch.enabled", true)
+	viper.SetDefault("dispatch.interval", 60)
+	viper.SetDefault("dispatch.offer_size", 3)
+	viper.SetDefault("dispatch.offer_ttl", 600)
+	viper.SetDefault("dispatch.max_rounds", 3)
+	viper.SetDefault("dispatch.max_open_tasks", 3)
+	viper.SetDefault("dispatch.max_distance_km", 100)
 }
@@ -7,8 +7,11 @@ package di
 
 import (
 	"context"
+	"encoding/base64"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/Snowitty-Re/CNtunyuan/internal/application/service"
 	"github.com/Snowitty-Re/CNtunyuan/internal/config"
@@ -20,11 +23,21 @@ import (
 	"github.com/Snowitty-Re/CNtunyuan/internal/infrastructure/storage"
 	"github.com/Snowitty-Re/CNtunyuan/internal/infrastructure/wechat"
 	"github.com/Snowitty-Re/CNtunyuan/internal/infrastructure/permission"
+	"github.com/Snowitty-Re/CNtunyuan/internal/infrastructure/search"
+	"github.com/Snowitty-Re/CNtunyuan/internal/infrastructure/sensitive"
 	"github.com/Snowitty-Re/CNtunyuan/internal/infrastructure/websocket"
 	infraCache "github.com/Snowitty-Re/CNtunyuan/internal/infrastructure/cache"
 	"github.com/Snowitty-Re/CNtunyuan/internal/interfaces/http/handler"
 	"github.com/Snowitty-Re/CNtunyuan/internal/interfaces/http/middleware"
 	"github.com/Snowitty-Re/CNtunyuan/internal/interfaces/http/router"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/audio"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/bundle"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/captcha"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/cjkfont"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/imaging"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/logger"
+	pkgmiddleware "github.com/Snowitty-Re/CNtunyuan/pkg/middleware"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/poster"
 	"gorm.io/gorm"
 )
 
@@ -38,8 +51,29 @@ type Container struct {
 	UserService              *service.UserAppService
 	OrganizationService      *service.OrganizationAppService
 	MissingPersonService     *service.MissingPersonAppService
+	GeoService               *service.GeoAppService
+	TimelineService          *service.TimelineAppService
+	PublicCaseService        *service.PublicCaseAppService
+	TrackVerifyService       *service.TrackVerifyAppService
+	MissingPhotoService      *service.MissingPhotoAppService
+	PosterService            *service.PosterAppService
+	ImportService            *service.ImportAppService
+	CaseExportService        *service.CaseExportAppService
+	CaseSearchService        *service.CaseSearchAppService
+	UrgencyService           *service.UrgencyAppService
+	ReunionService           *service.ReunionAppService
+	DNASampleService         *service.DNASampleAppService
+	FamilySearcherService    *service.FamilySearcherAppService
 	DialectService           *service.DialectAppService
+	DialectSessionService    *service.DialectSessionAppService
+	DialectGroupService      *service.DialectGroupAppService
+	DialectReviewService     *service.DialectReviewAppService
+	DialectCommentService    *service.DialectCommentAppService
+	DialectAnalyticsService  *service.DialectAnalyticsAppService
+	DialectPlaylistService   *service.DialectPlaylistAppService
 	TaskService              *service.TaskAppService
+	TaskDispatchService      *service.TaskDispatchAppService
+	VolunteerProfileService  *service.VolunteerProfileAppService
 	FileService              *service.FileAppService
 	DashboardService         *service.DashboardService
 	AuditService             *service.AuditService
@@ -50,8 +84,26 @@ type Container struct {
 	UserHandler              *handler.UserHandler
 	OrganizationHandler      *handler.OrganizationHandler
 	MissingPersonHandler     *handler.MissingPersonHandler
+	MissingPersonGeoHandler  *handler.MissingPersonGeoHandler
+	PublicCaseHandler        *handler.PublicCaseHandler
+	TrackVerifyHandler       *handler.TrackVerifyHandler
+	MissingPhotoHandler      *handler.MissingPhotoHandler
+	ImportHandler            *handler.ImportHandler
+	CaseExportHandler        *handler.CaseExportHandler
+	CaseSearchHandler        *handler.CaseSearchHandler
+	UrgencyHandler           *handler.UrgencyHandler
+	ReunionHandler           *handler.ReunionHandler
+	DNASampleHandler         *handler.DNASampleHandler
+	FamilySearcherHandler    *handler.FamilySearcherHandler
 	DialectHandler           *handler.DialectHandler
+	DialectSessionHandler    *handler.DialectSessionHandler
+	DialectGroupHandler      *handler.DialectGroupHandler
+	DialectReviewHandler     *handler.DialectReviewHandler
+	DialectCommentHandler    *handler.DialectCommentHandler
+	DialectPlaylistHandler   *handler.DialectPlaylistHandler
 	TaskHandler              *handler.TaskHandler
+	TaskDispatchHandler      *handler.TaskDispatchHandler
+	VolunteerProfileHandler  *handler.VolunteerProfileHandler
 	UploadHandler            *handler.UploadHandler
 	DashboardHandler         *handler.DashboardHandler
 	AuditHandler             *handler.AuditHandler
@@ -65,6 +117,8 @@ type Container struct {
 	DataPermissionMiddleware *middleware.DataPermissionMiddleware
 	RBACMiddleware           *middleware.RBACMiddleware
 	Router                   *router.Router
+
+	stopJobs context.CancelFunc // 停止后台定时任务
 }
 
 // NewContainer 手动创建依赖容器
@@ -114,6 +168,7 @@ func NewContainer(cfg *config.Config) (*Container, error) {
 	userRepo := infraRepo.NewUserRepository(db)
 	orgRepo := infraRepo.NewOrganizationRepository(db)
 	mpRepo := infraRepo.NewMissingPersonRepository(db)
+	geoRepo := infraRepo.NewGeoRepository(db)
 	dialectRepo := infraRepo.NewDialectRepository(db)
 	taskRepo := infraRepo.NewTaskRepository(db)
 	fileRepo := infraRepo.NewFileRepository(db)
@@ -132,6 +187,19 @@ func NewContainer(cfg *config.Config) (*Container, error) {
 	notifRepo := infraRepo.NewNotificationRepository(db)
 	notifSettingRepo := infraRepo.NewNotificationSettingRepository(db)
 	msgTemplateRepo := infraRepo.NewMessageTemplateRepository(db)
+	importRepo := infraRepo.NewImportRepository(db)
+	urgencyRuleRepo := infraRepo.NewUrgencyRuleRepository(db)
+	reunionRepo := infraRepo.NewReunionRepository(db)
+	dnaSampleRepo := infraRepo.NewDNASampleRepository(db)
+	familySearcherRepo := infraRepo.NewFamilySearcherRepository(db)
+	dialectSessionRepo := infraRepo.NewDialectSessionRepository(db)
+	dialectGroupRepo := infraRepo.NewDialectGroupRepository(db)
+	dialectReviewRepo := infraRepo.NewDialectReviewRepository(db)
+	dialectCommentRepo := infraRepo.NewDialectCommentRepository(db)
+	dialectAnalyticsRepo := infraRepo.NewDialectAnalyticsRepository(db)
+	dialectPlaylistRepo := infraRepo.NewDialectPlaylistRepository(db)
+	taskDispatchRepo := infraRepo.NewTaskDispatchRepository(db)
+	volunteerProfileRepo := infraRepo.NewVolunteerProfileRepository(db)
 
 	// 创建领域服务
 	authService := domainService.NewAuthService(userRepo, tokenService, redisCache, wechatClient)
@@ -139,14 +207,37 @@ func NewContainer(cfg *config.Config) (*Container, error) {
 	// 创建应用服务
 	userService := service.NewUserAppService(userRepo)
 	orgService := service.NewOrganizationAppService(orgRepo)
-	mpService := service.NewMissingPersonAppService(mpRepo)
-	dialectService := service.NewDialectAppService(dialectRepo)
 	taskService := service.NewTaskAppService(taskRepo)
+	var imagePipeline *imaging.Pipeline
+	if cfg.Storage.ImageProcessing {
+		imagePipeline = imaging.NewPipeline(imaging.Options{
+			ThumbnailSize: cfg.Storage.ImageThumbnailSize,
+			ShareSize:     cfg.Storage.ImageShareSize,
+			Watermark:     cfg.Storage.ImageWatermark,
+		})
+	}
+	// 音频处理：本地 ffmpeg 不可用时只探测真实格式和时长，不做归一化和转码
+	var audioPipeline *audio.Pipeline
+	if cfg.Storage.AudioProcessing {
+		var codec audio.Codec
+		if ffmpeg, err := audio.NewFFmpeg(cfg.Storage.AudioFFmpegPath); err == nil {
+			codec = ffmpeg
+		} else {
+			logger.Warn("ffmpeg not found, audio uploads will not be transcoded", logger.Err(err))
+		}
+		audioPipeline = audio.NewPipeline(codec, audio.Options{
+			TargetLoudness: cfg.Storage.AudioTargetLoudness,
+			Bitrate:        cfg.Storage.AudioBitrate,
+		})
+	}
 	fileService := service.NewFileAppService(
 		fileRepo,
 		storageService,
+		storage.NewPrivateLocalStorage(cfg.Storage.ImageOriginalPath, cfg.Storage.MaxFileSize),
 		cfg.Storage.MaxFileSize,
 		strings.Split(cfg.Storage.AllowedTypes, ","),
+		imagePipeline,
+		audioPipeline,
 	)
 	dashboardService := service.NewDashboardService(
 		userRepo,
@@ -159,6 +250,70 @@ func NewContainer(cfg *config.Config) (*Container, error) {
 	
 	// Phase 1: 创建审计服务
 	auditService := service.NewAuditService(auditLogRepo)
+
+	// 案件全文检索：内置索引从快照恢复后增量同步，engine 为 database 时使用数据库模糊查询
+	var caseSearchIndex domainService.CaseSearchIndex
+	switch cfg.Search.Engine {
+	case "database":
+	default:
+		caseSearchIndex = search.NewMemoryCaseIndex(cfg.Search.IndexPath)
+	}
+	caseSearchService := service.NewCaseSearchAppService(caseSearchIndex, mpRepo)
+
+	// Phase 4: 创建WebSocket管理器和通知服务
+	wsManager := websocket.NewManager()
+	notificationService := service.NewNotificationAppService(
+		notifRepo,
+		notifSettingRepo,
+		msgTemplateRepo,
+		wsManager,
+	)
+
+	// 案件紧急程度评分：按组织规则定期重新评分，升级为紧急时通知组织管理员
+	urgencyService := service.NewUrgencyAppService(mpRepo, urgencyRuleRepo, orgRepo, userRepo, notificationService)
+
+	// 创建数据权限提供者
+	dataPermissionProvider := permission.NewDataPermissionProvider(db)
+
+	mpService := service.NewMissingPersonAppService(mpRepo, auditService, caseSearchService, urgencyService, dataPermissionProvider)
+	geoService := service.NewGeoAppService(geoRepo)
+	timelineService := service.NewTimelineAppService(mpRepo)
+	trackVerifyService := service.NewTrackVerifyAppService(mpRepo, geoRepo, taskService)
+
+	// 团聚记录：团聚后 1、3、12 个月的回访在到期前生成回访任务
+	reunionService := service.NewReunionAppService(mpRepo, reunionRepo, taskService, auditService, time.Duration(cfg.Reunion.LeadDays)*24*time.Hour)
+
+	// 志愿者档案：技能、证书、语言方言、服务范围和档期，派单时用于补充候选人信息
+	volunteerProfileService := service.NewVolunteerProfileAppService(volunteerProfileRepo, userRepo, dialectGroupRepo)
+
+	// 任务自动派单：按距离、技能、语言和在办任务数向志愿者推送待分配任务，超时无人接单时推送下一轮
+	taskDispatchService := service.NewTaskDispatchAppService(taskRepo, taskDispatchRepo, notificationService, wsManager, service.TaskDispatchOptions{
+		OfferSize:     cfg.Dispatch.OfferSize,
+		OfferTTL:      time.Duration(cfg.Dispatch.OfferTTL) * time.Second,
+		MaxRounds:     cfg.Dispatch.MaxRounds,
+		MaxOpenTasks:  cfg.Dispatch.MaxOpenTasks,
+		MaxDistanceKm: cfg.Dispatch.MaxDistanceKm,
+	}, volunteerProfileService)
+
+	missingPhotoService := service.NewMissingPhotoAppService(mpRepo, fileService, cfg.Storage.ImageSimilarity)
+
+	// 中文 TrueType 字体：寻人海报和移交摘要 PDF 嵌入字体子集，分享卡片 PNG 用于光栅化
+	cjkFont, err := cjkfont.Load(cfg.Poster.FontPath)
+	if err != nil {
+		logger.Warn("CJK font unavailable, posters and export summaries disabled", logger.String("font_path", cfg.Poster.FontPath), logger.Err(err))
+		cjkFont = nil
+	}
+	posterService := service.NewPosterAppService(mpRepo, orgRepo, fileService, poster.NewRenderer(cjkFont), cfg.Poster.CasePageURL)
+
+	// 公开案件公示板与公众线索提交
+	var captchaGen *captcha.Generator
+	if cfg.Public.CaptchaEnabled {
+		if cfg.Public.CaptchaSecret == "" || cfg.Public.CaptchaSecret == cfg.JWT.Secret {
+			return nil, errors.New("启用验证码时必须配置独立的 public.captcha_secret")
+		}
+		captchaGen = captcha.NewGenerator(cfg.Public.CaptchaSecret, time.Duration(cfg.Public.CaptchaTTL)*time.Second)
+	}
+	publicCaseService := service.NewPublicCaseAppService(mpRepo, captchaGen, cacheManager)
 	
 	// Phase 2: 创建工作流服务
 	workflowService := service.NewWorkflowAppService(
@@ -169,18 +324,76 @@ func NewContainer(cfg *config.Config) (*Container, error) {
 	
 	// Phase 3: 创建权限服务
 	permissionService := service.NewPermissionAppService(permRepo)
-	
-	// Phase 4: 创建WebSocket管理器和通知服务
-	wsManager := websocket.NewManager()
-	notificationService := service.NewNotificationAppService(
-		notifRepo,
-		notifSettingRepo,
-		msgTemplateRepo,
-		wsManager,
+
+	// 案件移交导出：导出包使用 Ed25519 签名，私钥来自配置或独立的密钥文件
+	var exportSigner *bundle.Signer
+	if cfg.Export.SigningKey != "" {
+		seed, err := base64.StdEncoding.DecodeString(cfg.Export.SigningKey)
+		if err != nil {
+			return nil, fmt.Errorf("导出签名密钥格式错误: %w", err)
+		}
+		if exportSigner, err = bundle.NewSigner(seed); err != nil {
+			return nil, fmt.Errorf("导出签名密钥无效: %w", err)
+		}
+	} else if cfg.Export.SigningKeyFile != "" {
+		if exportSigner, err = bundle.LoadOrCreateSigner(cfg.Export.SigningKeyFile); err != nil {
+			return nil, fmt.Errorf("加载导出签名密钥失败: %w", err)
+		}
+	} else {
+		return nil, errors.New("未配置导出签名密钥：请设置 export.signing_key 或 export.signing_key_file")
+	}
+	caseExportService := service.NewCaseExportAppService(
+		mpRepo,
+		taskRepo,
+		workflowRepo,
+		userRepo,
+		fileService,
+		permissionService,
+		auditService,
+		exportSigner,
+		cfg.Export.MaxPhotoBytes,
+		cjkFont,
 	)
 
-	// 创建数据权限提供者
-	dataPermissionProvider := permission.NewDataPermissionProvider(db)
+	// DNA / 血样登记：只记录保管链元数据，按字段权限脱敏并审计每次查看
+	dnaSampleService := service.NewDNASampleAppService(dnaSampleRepo, mpRepo, userRepo, permissionService, auditService, dataPermissionProvider)
+
+	// 寻亲者档案：与案件独立，亲寻家档案与案件、家寻亲档案双向比对
+	familySearcherService := service.NewFamilySearcherAppService(familySearcherRepo, mpRepo, auditService)
+
+	// 方言辨识：为只会说方言的被寻获人员逐级播放片段推断籍贯，结果可关联到案件
+	dialectSessionService := service.NewDialectSessionAppService(dialectSessionRepo, dialectRepo, mpRepo, auditService)
+
+	// 方言分类：方言大区 → 方言片 → 地点方言，由管理员维护，历史方言按地区文本归类
+	dialectGroupService := service.NewDialectGroupAppService(dialectGroupRepo, dialectRepo, auditService)
+
+	// 方言审核：上传的方言进入组织审核队列，组织发布了方言审核流程时需多名审核员依次通过
+	dialectReviewService := service.NewDialectReviewAppService(dialectReviewRepo, workflowRepo, workflowService, notificationService, auditService)
+	dialectService := service.NewDialectAppService(dialectRepo, dialectGroupRepo, fileService, dialectReviewService, cfg.Storage.AudioSimilarity)
+
+	// 方言评论：内置词典加本地词表过滤敏感词，action 为 reject 时拒绝发布
+	var contentFilter domainService.ContentFilter
+	if cfg.Sensitive.Enabled {
+		contentFilter = sensitive.NewDictFilter(cfg.Sensitive.DictPath)
+	}
+	dialectCommentService := service.NewDialectCommentAppService(dialectCommentRepo, dialectRepo, contentFilter, cfg.Sensitive.Action == "reject", notificationService, auditService)
+	dialectAnalyticsService := service.NewDialectAnalyticsAppService(dialectAnalyticsRepo, cacheManager)
+
+	// 方言歌单：离线包与案件移交包使用同一签名密钥
+	dialectPlaylistService := service.NewDialectPlaylistAppService(
+		dialectPlaylistRepo,
+		dialectRepo,
+		userRepo,
+		fileService,
+		notificationService,
+		auditService,
+		exportSigner,
+		cfg.Export.MaxAudioBytes,
+	)
+
+	// 案件批量导入：进度通过 WebSocket 推送，上次未执行完的任务标记为失败
+	importService := service.NewImportAppService(importRepo, mpRepo, storageService, wsManager, caseSearchService)
+	importService.RecoverInterrupted(context.Background())
 
 	// 创建 Middleware
 	authMiddleware := middleware.NewAuthMiddleware(authService)
@@ -192,9 +405,31 @@ func NewContainer(cfg *config.Config) (*Container, error) {
 	authHandler := handler.NewAuthHandler(authService, authMiddleware)
 	userHandler := handler.NewUserHandler(userService)
 	orgHandler := handler.NewOrganizationHandler(orgService)
-	mpHandler := handler.NewMissingPersonHandler(mpService)
-	dialectHandler := handler.NewDialectHandler(dialectService)
+	mpHandler := handler.NewMissingPersonHandler(mpService, posterService)
+	mpGeoHandler := handler.NewMissingPersonGeoHandler(geoService, timelineService)
+	publicCaseHandler := handler.NewPublicCaseHandler(
+		publicCaseService,
+		pkgmiddleware.RateLimitMiddleware(cfg.Public.BoardRateLimit, cfg.Public.BoardBurst),
+		pkgmiddleware.RateLimitMiddleware(cfg.Public.LeadRateLimit, cfg.Public.LeadBurst),
+	)
+	trackVerifyHandler := handler.NewTrackVerifyHandler(trackVerifyService)
+	missingPhotoHandler := handler.NewMissingPhotoHandler(missingPhotoService)
+	importHandler := handler.NewImportHandler(importService)
+	caseExportHandler := handler.NewCaseExportHandler(caseExportService)
+	caseSearchHandler := handler.NewCaseSearchHandler(caseSearchService)
+	urgencyHandler := handler.NewUrgencyHandler(urgencyService)
+	reunionHandler := handler.NewReunionHandler(reunionService)
+	dnaSampleHandler := handler.NewDNASampleHandler(dnaSampleService)
+	familySearcherHandler := handler.NewFamilySearcherHandler(familySearcherService)
+	dialectHandler := handler.NewDialectHandler(dialectService, dialectAnalyticsService)
+	dialectSessionHandler := handler.NewDialectSessionHandler(dialectSessionService)
+	dialectGroupHandler := handler.NewDialectGroupHandler(dialectGroupService)
+	dialectReviewHandler := handler.NewDialectReviewHandler(dialectReviewService)
+	dialectCommentHandler := handler.NewDialectCommentHandler(dialectCommentService)
+	dialectPlaylistHandler := handler.NewDialectPlaylistHandler(dialectPlaylistService)
 	taskHandler := handler.NewTaskHandler(taskService)
+	taskDispatchHandler := handler.NewTaskDispatchHandler(taskDispatchService)
+	volunteerProfileHandler := handler.NewVolunteerProfileHandler(volunteerProfileService)
 	uploadHandler := handler.NewUploadHandler(fileService)
 	dashboardHandler := handler.NewDashboardHandler(dashboardService)
 	auditHandler := handler.NewAuditHandler(auditService)
@@ -209,8 +444,26 @@ func NewContainer(cfg *config.Config) (*Container, error) {
 		userHandler,
 		orgHandler,
 		mpHandler,
+		mpGeoHandler,
+		publicCaseHandler,
+		trackVerifyHandler,
+		missingPhotoHandler,
+		importHandler,
+		caseExportHandler,
+		caseSearchHandler,
+		urgencyHandler,
+		reunionHandler,
+		dnaSampleHandler,
+		familySearcherHandler,
+		dialectSessionHandler,
+		dialectGroupHandler,
+		dialectReviewHandler,
+		dialectCommentHandler,
+		dialectPlaylistHandler,
 		dialectHandler,
 		taskHandler,
+		taskDispatchHandler,
+		volunteerProfileHandler,
 		uploadHandler,
 		dashboardHandler,
 		auditHandler,
@@ -227,6 +480,19 @@ func NewContainer(cfg *config.Config) (*Container, error) {
 	// 启动WebSocket管理器
 	go wsManager.Start(context.Background())
 
+	// 启动后台定时任务，Container.Close 时停止
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	caseSearchService.Start(jobsCtx, time.Duration(cfg.Search.SyncInterval)*time.Second)
+	if cfg.Urgency.Enabled {
+		urgencyService.Start(jobsCtx, time.Duration(cfg.Urgency.Interval)*time.Second)
+	}
+	if cfg.Reunion.Enabled {
+		reunionService.Start(jobsCtx, time.Duration(cfg.Reunion.Interval)*time.Second)
+	}
+	if cfg.Dispatch.Enabled {
+		taskDispatchService.Start(jobsCtx, time.Duration(cfg.Dispatch.Interval)*time.Second)
+	}
+
 	return &Container{
 		Config:                   cfg,
 		DB:                       db,
@@ -236,8 +502,29 @@ func NewContainer(cfg *config.Config) (*Container, error) {
 		UserService:              userService,
 		OrganizationService:      orgService,
 		MissingPersonService:     mpService,
+		GeoService:               geoService,
+		TimelineService:          timelineService,
+		PublicCaseService:        publicCaseService,
+		TrackVerifyService:       trackVerifyService,
+		MissingPhotoService:      missingPhotoService,
+		PosterService:            posterService,
+		ImportService:            importService,
+		CaseExportService:        caseExportService,
+		CaseSearchService:        caseSearchService,
+		UrgencyService:           urgencyService,
+		ReunionService:           reunionService,
+		DNASampleService:         dnaSampleService,
+		FamilySearcherService:    familySearcherService,
 		DialectService:           dialectService,
+		DialectSessionService:    dialectSessionService,
+		DialectGroupService:      dialectGroupService,
+		DialectReviewService:     dialectReviewService,
+		DialectCommentService:    dialectCommentService,
+		DialectAnalyticsService:  dialectAnalyticsService,
+		DialectPlaylistService:   dialectPlaylistService,
 		TaskService:              taskService,
+		TaskDispatchService:      taskDispatchService,
+		VolunteerProfileService:  volunteerProfileService,
 		FileService:              fileService,
 		DashboardService:         dashboardService,
 		AuditService:             auditService,
@@ -248,8 +535,26 @@ func NewContainer(cfg *config.Config) (*Container, error) {
 		UserHandler:              userHandler,
 		OrganizationHandler:      orgHandler,
 		MissingPersonHandler:     mpHandler,
+		MissingPersonGeoHandler:  mpGeoHandler,
+		PublicCaseHandler:        publicCaseHandler,
+		TrackVerifyHandler:       trackVerifyHandler,
+		MissingPhotoHandler:      missingPhotoHandler,
+		ImportHandler:            importHandler,
+		CaseExportHandler:        caseExportHandler,
+		CaseSearchHandler:        caseSearchHandler,
+		UrgencyHandler:           urgencyHandler,
+		ReunionHandler:           reunionHandler,
+		DNASampleHandler:         dnaSampleHandler,
+		FamilySearcherHandler:    familySearcherHandler,
 		DialectHandler:           dialectHandler,
+		DialectSessionHandler:    dialectSessionHandler,
+		DialectGroupHandler:      dialectGroupHandler,
+		DialectReviewHandler:     dialectReviewHandler,
+		DialectCommentHandler:    dialectCommentHandler,
+		DialectPlaylistHandler:   dialectPlaylistHandler,
 		TaskHandler:              taskHandler,
+		TaskDispatchHandler:      taskDispatchHandler,
+		VolunteerProfileHandler:  volunteerProfileHandler,
 		UploadHandler:            uploadHandler,
 		DashboardHandler:         dashboardHandler,
 		AuditHandler:             auditHandler,
@@ -263,5 +568,13 @@ func NewContainer(cfg *config.Config) (*Container, error) {
 		DataPermissionMiddleware: dataPermissionMiddleware,
 		RBACMiddleware:           rbacMiddleware,
 		Router:                   r,
+		stopJobs:                 stopJobs,
 	}, nil
 }
+
+// Close 停止后台定时任务
+func (c *Container) Close() {
+	if c.stopJobs != nil {
+		c.stopJobs()
+	}
+}
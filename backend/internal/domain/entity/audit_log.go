@@ -2,6 +2,7 @@ package entity
 
 import (
 	"encoding/json"
+	"regexp"
 	"time"
 
 	"github.com/google/uuid"
@@ -249,6 +250,7 @@ func IsSensitiveField(field string) bool {
 		"email",
 		"bank_card", "bankcard",
 		"credit_card", "creditcard",
+		"contact_phone", "alt_contact",
 	}
 	
 	lowerField := string([]byte(field))
@@ -260,6 +262,21 @@ func IsSensitiveField(field string) bool {
 	return false
 }
 
+// sensitiveTextPatterns 自由文本中的敏感信息
+var sensitiveTextPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`\d{17}[\dXx]`),               // 身份证号
+	regexp.MustCompile(`(?:\+?86[- ]?)?1[3-9]\d{9}`), // 手机号
+	regexp.MustCompile(`0\d{2,3}-?\d{7,8}`),          // 固定电话
+}
+
+// MaskSensitiveText 脱敏自由文本中的手机号、固定电话和身份证号
+func MaskSensitiveText(text string) string {
+	for _, re := range sensitiveTextPatterns {
+		text = re.ReplaceAllString(text, "***")
+	}
+	return text
+}
+
 // MaskSensitiveData 脱敏敏感数据
 func MaskSensitiveData(data map[string]interface{}) map[string]interface{} {
 	if data == nil {
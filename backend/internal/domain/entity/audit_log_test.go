@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsSensitiveField(t *testing.T) {
+	assert.True(t, IsSensitiveField("phone"))
+	assert.True(t, IsSensitiveField("contact_phone"))
+	assert.True(t, IsSensitiveField("alt_contact"))
+	assert.False(t, IsSensitiveField("name"))
+	assert.False(t, IsSensitiveField("telegram"))
+	assert.False(t, IsSensitiveField("contact_rel"))
+}
+
+func TestMaskSensitiveData(t *testing.T) {
+	masked := MaskSensitiveData(map[string]interface{}{
+		"name":          "张三",
+		"contact_phone": "13812345678",
+		"alt_contact":   "010-12345678",
+	})
+	assert.Equal(t, "张三", masked["name"])
+	assert.Equal(t, "***", masked["contact_phone"])
+	assert.Equal(t, "***", masked["alt_contact"])
+	assert.Nil(t, MaskSensitiveData(nil))
+}
+
+func TestMaskSensitiveText(t *testing.T) {
+	assert.Equal(t, "请联系 *** 或 ***", MaskSensitiveText("请联系 13812345678 或 010-12345678"))
+	assert.Equal(t, "身份证 ***", MaskSensitiveText("身份证 11010519491231002X"))
+	assert.Equal(t, "身高170厘米", MaskSensitiveText("身高170厘米"))
+}
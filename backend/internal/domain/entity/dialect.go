@@ -16,6 +16,9 @@ const (
 	DialectStatusPending  DialectStatus = "pending"
 )
 
+// MaxDialectDuration 方言音频最长时长（秒）
+const MaxDialectDuration = 300
+
 // DialectType 方言类型
 type DialectType string
 
@@ -33,13 +36,17 @@ type Dialect struct {
 	Title        string        `gorm:"size:100;not null" json:"title"`
 	Content      string        `gorm:"type:text" json:"content,omitempty"`
 	Region       string        `gorm:"size:100" json:"region"`
+	GroupID      *string       `gorm:"type:uuid;index" json:"group_id,omitempty"`
 	Province     string        `gorm:"size:50" json:"province,omitempty"`
 	City         string        `gorm:"size:50" json:"city,omitempty"`
 	DialectType  DialectType   `gorm:"size:20;default:'phrase'" json:"dialect_type"`
 	AudioUrl     string        `gorm:"size:255;not null" json:"audio_url"`
-	Duration     int           `json:"duration"`                        // 秒
+	Duration     int           `json:"duration"`                        // 秒，由服务端探测
 	FileSize     int           `json:"file_size"`                       // 字节
 	Format       string        `gorm:"size:10" json:"format,omitempty"` // mp3, wav, etc.
+	AudioFileID  *string       `gorm:"type:uuid;index" json:"audio_file_id,omitempty"`
+	WaveformUrl  string        `gorm:"size:255" json:"waveform_url,omitempty"`
+	Fingerprint  string        `gorm:"type:text" json:"-"` // 声纹指纹，用于发现重复上传
 	Status       DialectStatus `gorm:"size:20;default:'active'" json:"status"`
 	IsFeatured   bool          `gorm:"default:false" json:"is_featured"`
 	PlayCount    int           `gorm:"default:0" json:"play_count"`
@@ -54,6 +61,7 @@ type Dialect struct {
 
 	Uploader *User         `gorm:"foreignKey:UploaderID" json:"uploader,omitempty"`
 	Org      *Organization `gorm:"foreignKey:OrgID" json:"org,omitempty"`
+	Group    *DialectGroup `gorm:"foreignKey:GroupID" json:"group,omitempty"`
 }
 
 // TableName 表名
@@ -72,12 +80,25 @@ func (d *Dialect) Validate() error {
 	if d.Region == "" {
 		return errors.New("地区不能为空")
 	}
-	if d.Duration <= 0 || d.Duration > 300 {
+	if d.Duration <= 0 || d.Duration > MaxDialectDuration {
 		return errors.New("音频时长必须在1-300秒之间")
 	}
 	return nil
 }
 
+// AssignGroup 归入方言分类，地区名称统一为分类名称；未填写省市且分类只对应一个地区时补全省市
+func (d *Dialect) AssignGroup(g *DialectGroup) {
+	d.GroupID = &g.ID
+	d.Region = g.Name
+	if d.Province != "" {
+		return
+	}
+	if r := g.SingleRegion(); r != nil {
+		d.Province = r.Province
+		d.City = r.City
+	}
+}
+
 // IsActive 是否活跃
 func (d *Dialect) IsActive() bool {
 	return d.Status == DialectStatusActive
@@ -137,17 +158,33 @@ func (d *Dialect) Reject() {
 	d.Status = DialectStatusInactive
 }
 
-// DialectComment 方言评论
+// DialectMatch 声纹相似的方言
+type DialectMatch struct {
+	Dialect    *Dialect
+	Similarity float64
+}
+
+// DialectComment 方言评论。评论分两层：ParentID 为空的是主评论，回复一律挂在主评论下，
+// 回复某条回复时以 ReplyToUserID 记录被回复的用户
 type DialectComment struct {
 	BaseEntity
-	DialectID  string  `gorm:"type:uuid;not null;index" json:"dialect_id"`
-	UserID     string  `gorm:"type:uuid;not null" json:"user_id"`
-	Content    string  `gorm:"type:text;not null" json:"content"`
-	ParentID   *string `gorm:"type:uuid;index" json:"parent_id,omitempty"`
-	ReplyCount int     `gorm:"default:0" json:"reply_count"`
-	LikeCount  int     `gorm:"default:0" json:"like_count"`
-
-	User *User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	DialectID     string               `gorm:"type:uuid;not null;index" json:"dialect_id"`
+	UserID        string               `gorm:"type:uuid;not null" json:"user_id"`
+	Content       string               `gorm:"type:text;not null" json:"content"`
+	ParentID      *string              `gorm:"type:uuid;index" json:"parent_id,omitempty"`
+	ReplyToUserID *string              `gorm:"type:uuid" json:"reply_to_user_id,omitempty"`
+	ReplyCount    int                  `gorm:"default:0" json:"reply_count"`
+	LikeCount     int                  `gorm:"default:0" json:"like_count"`
+	Status        DialectCommentStatus `gorm:"size:20;default:visible;index" json:"status"`
+	Flagged       bool                 `gorm:"default:false" json:"flagged"` // 命中敏感词，等待管理员复核
+	ReportCount   int                  `gorm:"default:0" json:"report_count"`
+	HiddenBy      *string              `gorm:"type:uuid" json:"hidden_by,omitempty"`
+	HiddenReason  string               `gorm:"size:200" json:"hidden_reason,omitempty"`
+	HiddenAt      *time.Time           `json:"hidden_at,omitempty"`
+
+	Dialect     *Dialect `gorm:"foreignKey:DialectID" json:"dialect,omitempty"`
+	User        *User    `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	ReplyToUser *User    `gorm:"foreignKey:ReplyToUserID" json:"reply_to_user,omitempty"`
 }
 
 // TableName 表名
@@ -168,15 +205,17 @@ func (DialectLike) TableName() string {
 	return "ty_dialect_likes"
 }
 
-// DialectPlayLog 方言播放记录
+// DialectPlayLog 方言播放记录。同一收听者在 DialectPlayDedupWindow 内重复播放同一方言只保留一条记录，
+// 播放时长取上报的最大值
 type DialectPlayLog struct {
-	ID        string    `gorm:"type:uuid;primaryKey" json:"id"`
-	DialectID string    `gorm:"type:uuid;not null;index" json:"dialect_id"`
-	UserID    *string   `gorm:"type:uuid;index" json:"user_id,omitempty"`
-	IP        string    `gorm:"size:50" json:"ip,omitempty"`
-	UserAgent string    `gorm:"size:255" json:"user_agent,omitempty"`
-	Duration  int       `json:"duration"` // 播放时长（秒）
-	CreatedAt time.Time `json:"created_at"`
+	ID          string    `gorm:"type:uuid;primaryKey" json:"id"`
+	DialectID   string    `gorm:"type:uuid;not null;index;index:idx_dialect_play_listener" json:"dialect_id"`
+	UserID      *string   `gorm:"type:uuid;index" json:"user_id,omitempty"`
+	ListenerKey string    `gorm:"size:64;index:idx_dialect_play_listener" json:"listener_key,omitempty"` // 收听者标识，见 DialectListenerKey
+	IP          string    `gorm:"size:50" json:"ip,omitempty"`
+	UserAgent   string    `gorm:"size:255" json:"user_agent,omitempty"`
+	Duration    int       `json:"duration"` // 播放时长（秒）
+	CreatedAt   time.Time `gorm:"index:idx_dialect_play_listener" json:"created_at"`
 }
 
 // TableName 表名
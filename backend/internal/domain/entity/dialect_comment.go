@@ -0,0 +1,169 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DialectCommentStatus 方言评论状态
+type DialectCommentStatus string
+
+const (
+	DialectCommentVisible DialectCommentStatus = "visible" // 正常显示
+	DialectCommentHidden  DialectCommentStatus = "hidden"  // 被管理员隐藏，保留楼层但不显示内容
+)
+
+// DialectCommentMaxLength 评论最大字数
+const DialectCommentMaxLength = 500
+
+var (
+	ErrDialectCommentHidden  = errors.New("comment is hidden")
+	ErrDialectCommentVisible = errors.New("comment is not hidden")
+)
+
+// IsHidden 是否已被隐藏
+func (c *DialectComment) IsHidden() bool {
+	return c.Status == DialectCommentHidden
+}
+
+// IsReply 是否为回复
+func (c *DialectComment) IsReply() bool {
+	return c.ParentID != nil
+}
+
+// ThreadID 所在主评论的 ID
+func (c *DialectComment) ThreadID() string {
+	if c.ParentID != nil {
+		return *c.ParentID
+	}
+	return c.ID
+}
+
+// ReplyTo 作为 target 的回复：挂在 target 所在的主评论下，并记录被回复的用户
+func (c *DialectComment) ReplyTo(target *DialectComment) {
+	threadID := target.ThreadID()
+	c.ParentID = &threadID
+	c.DialectID = target.DialectID
+	if target.IsReply() {
+		userID := target.UserID
+		c.ReplyToUserID = &userID
+	}
+}
+
+// Hide 管理员隐藏评论，同时视为已复核
+func (c *DialectComment) Hide(moderatorID, reason string, now time.Time) error {
+	if c.IsHidden() {
+		return ErrDialectCommentHidden
+	}
+	c.Status = DialectCommentHidden
+	c.HiddenBy = &moderatorID
+	c.HiddenReason = reason
+	c.HiddenAt = &now
+	c.Flagged = false
+	return nil
+}
+
+// Unhide 恢复显示
+func (c *DialectComment) Unhide() error {
+	if !c.IsHidden() {
+		return ErrDialectCommentVisible
+	}
+	c.Status = DialectCommentVisible
+	c.HiddenBy = nil
+	c.HiddenReason = ""
+	c.HiddenAt = nil
+	return nil
+}
+
+// DialectCommentLike 评论点赞
+type DialectCommentLike struct {
+	ID        string    `gorm:"type:uuid;primaryKey" json:"id"`
+	CommentID string    `gorm:"type:uuid;not null;index:idx_dialect_comment_like,unique" json:"comment_id"`
+	UserID    string    `gorm:"type:uuid;not null;index:idx_dialect_comment_like,unique" json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName 表名
+func (DialectCommentLike) TableName() string {
+	return "ty_dialect_comment_likes"
+}
+
+// NewDialectCommentLike 创建评论点赞
+func NewDialectCommentLike(commentID, userID string) *DialectCommentLike {
+	return &DialectCommentLike{
+		ID:        uuid.New().String(),
+		CommentID: commentID,
+		UserID:    userID,
+		CreatedAt: time.Now(),
+	}
+}
+
+// DialectCommentReportReason 评论举报原因
+type DialectCommentReportReason string
+
+const (
+	CommentReportSpam    DialectCommentReportReason = "spam"    // 广告引流
+	CommentReportAbuse   DialectCommentReportReason = "abuse"   // 辱骂、人身攻击
+	CommentReportFraud   DialectCommentReportReason = "fraud"   // 疑似诈骗
+	CommentReportPrivacy DialectCommentReportReason = "privacy" // 泄露个人隐私
+	CommentReportOther   DialectCommentReportReason = "other"   // 其他，须填写说明
+)
+
+// DialectCommentReportReasons 全部举报原因，按展示顺序排列
+var DialectCommentReportReasons = []DialectCommentReportReason{
+	CommentReportSpam,
+	CommentReportAbuse,
+	CommentReportFraud,
+	CommentReportPrivacy,
+	CommentReportOther,
+}
+
+var dialectCommentReportReasonLabels = map[DialectCommentReportReason]string{
+	CommentReportSpam:    "广告引流",
+	CommentReportAbuse:   "辱骂、人身攻击",
+	CommentReportFraud:   "疑似诈骗",
+	CommentReportPrivacy: "泄露个人隐私",
+	CommentReportOther:   "其他",
+}
+
+// Label 原因说明
+func (r DialectCommentReportReason) Label() string {
+	return dialectCommentReportReasonLabels[r]
+}
+
+// IsValid 是否为有效原因
+func (r DialectCommentReportReason) IsValid() bool {
+	_, ok := dialectCommentReportReasonLabels[r]
+	return ok
+}
+
+// DialectCommentReportStatus 举报处理状态
+type DialectCommentReportStatus string
+
+const (
+	CommentReportPending   DialectCommentReportStatus = "pending"   // 待处理
+	CommentReportUpheld    DialectCommentReportStatus = "upheld"    // 举报成立，评论已隐藏
+	CommentReportDismissed DialectCommentReportStatus = "dismissed" // 举报不成立
+)
+
+// DialectCommentReport 评论举报，每名用户对同一评论只能举报一次
+type DialectCommentReport struct {
+	BaseEntity
+	CommentID  string                     `gorm:"type:uuid;not null;index:idx_dialect_comment_report,unique" json:"comment_id"`
+	DialectID  string                     `gorm:"type:uuid;not null;index" json:"dialect_id"`
+	ReporterID string                     `gorm:"type:uuid;not null;index:idx_dialect_comment_report,unique" json:"reporter_id"`
+	Reason     DialectCommentReportReason `gorm:"size:30;not null" json:"reason"`
+	Detail     string                     `gorm:"size:500" json:"detail,omitempty"`
+	Status     DialectCommentReportStatus `gorm:"size:20;default:pending;index" json:"status"`
+	HandledBy  *string                    `gorm:"type:uuid" json:"handled_by,omitempty"`
+	HandledAt  *time.Time                 `json:"handled_at,omitempty"`
+
+	Reporter *User `gorm:"foreignKey:ReporterID" json:"reporter,omitempty"`
+}
+
+// TableName 表名
+func (DialectCommentReport) TableName() string {
+	return "ty_dialect_comment_reports"
+}
@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDialectComment_ThreadAndModeration(t *testing.T) {
+	root := &DialectComment{BaseEntity: BaseEntity{ID: "c1"}, DialectID: "d1", UserID: "u1"}
+	reply := &DialectComment{UserID: "u2"}
+	reply.ReplyTo(root)
+	assert.Equal(t, "c1", *reply.ParentID)
+	assert.Equal(t, "d1", reply.DialectID)
+	assert.Nil(t, reply.ReplyToUserID, "direct reply needs no reply target")
+
+	reply.ID = "c2"
+	nested := &DialectComment{UserID: "u3"}
+	nested.ReplyTo(reply)
+	assert.Equal(t, "c1", *nested.ParentID, "replies always hang off the top-level comment")
+	assert.Equal(t, "u2", *nested.ReplyToUserID)
+	assert.Equal(t, "c1", nested.ThreadID())
+
+	now := time.Now()
+	root.Flagged = true
+	assert.NoError(t, root.Hide("m1", "广告", now))
+	assert.True(t, root.IsHidden())
+	assert.False(t, root.Flagged)
+	assert.ErrorIs(t, root.Hide("m1", "", now), ErrDialectCommentHidden)
+	assert.NoError(t, root.Unhide())
+	assert.Nil(t, root.HiddenBy)
+	assert.Empty(t, root.HiddenReason)
+	assert.ErrorIs(t, root.Unhide(), ErrDialectCommentVisible)
+
+	assert.True(t, CommentReportFraud.IsValid())
+	assert.False(t, DialectCommentReportReason("bad").IsValid())
+	assert.Equal(t, "疑似诈骗", CommentReportFraud.Label())
+}
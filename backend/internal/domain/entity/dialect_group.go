@@ -0,0 +1,198 @@
+package entity
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// DialectGroupLevel 方言分类层级
+type DialectGroupLevel string
+
+const (
+	DialectGroupFamily   DialectGroupLevel = "family"   // 方言大区，如吴语
+	DialectGroupSubgroup DialectGroupLevel = "subgroup" // 方言片，如太湖片
+	DialectGroupVariety  DialectGroupLevel = "variety"  // 地点方言，如苏州话
+)
+
+// dialectGroupPathSep 分类路径分隔符
+const dialectGroupPathSep = "/"
+
+// Depth 层级深度，方言大区为 1
+func (l DialectGroupLevel) Depth() int {
+	switch l {
+	case DialectGroupFamily:
+		return 1
+	case DialectGroupSubgroup:
+		return 2
+	case DialectGroupVariety:
+		return 3
+	}
+	return 0
+}
+
+// ChildLevel 下一级层级，地点方言没有下级
+func (l DialectGroupLevel) ChildLevel() DialectGroupLevel {
+	switch l {
+	case DialectGroupFamily:
+		return DialectGroupSubgroup
+	case DialectGroupSubgroup:
+		return DialectGroupVariety
+	}
+	return ""
+}
+
+// DialectGroup 方言分类：方言大区 → 方言片 → 地点方言
+// Path 为从根到自身的 ID 路径（如 /a/b/c/），用于按任意层级查询子树
+type DialectGroup struct {
+	BaseEntity
+	ParentID    *string           `gorm:"type:uuid;index" json:"parent_id,omitempty"`
+	Level       DialectGroupLevel `gorm:"size:20;not null" json:"level"`
+	Name        string            `gorm:"size:50;not null" json:"name"`
+	Aliases     string            `gorm:"type:json" json:"aliases,omitempty"` // 别称，如粤语的"广东话"、"白话"
+	Path        string            `gorm:"size:255;not null;index" json:"path"`
+	FullName    string            `gorm:"size:200" json:"full_name"` // 如 吴语/太湖片/苏州话
+	Sort        int               `gorm:"default:0" json:"sort"`
+	Description string            `gorm:"type:text" json:"description,omitempty"`
+
+	Regions  []DialectGroupRegion `gorm:"foreignKey:GroupID" json:"regions,omitempty"`
+	Children []*DialectGroup      `gorm:"-" json:"children,omitempty"`
+}
+
+// TableName 表名
+func (DialectGroup) TableName() string {
+	return "ty_dialect_groups"
+}
+
+// DialectGroupRegion 方言分类对应的行政区，City 为空表示整个省
+type DialectGroupRegion struct {
+	BaseEntity
+	GroupID  string `gorm:"type:uuid;not null;index" json:"group_id"`
+	Province string `gorm:"size:50;not null;index:idx_dialect_group_region" json:"province"`
+	City     string `gorm:"size:50;index:idx_dialect_group_region" json:"city,omitempty"`
+}
+
+// TableName 表名
+func (DialectGroupRegion) TableName() string {
+	return "ty_dialect_group_regions"
+}
+
+// Covers 是否覆盖指定省市，city 为空时只比较省
+func (r *DialectGroupRegion) Covers(province, city string) bool {
+	if r.Province != province {
+		return false
+	}
+	return r.City == "" || city == "" || r.City == city
+}
+
+// Validate 验证
+func (g *DialectGroup) Validate() error {
+	if strings.TrimSpace(g.Name) == "" {
+		return errors.New("分类名称不能为空")
+	}
+	if strings.Contains(g.Name, dialectGroupPathSep) {
+		return errors.New("分类名称不能包含 /")
+	}
+	if g.Level.Depth() == 0 {
+		return errors.New("无效的分类层级")
+	}
+	if (g.ParentID == nil) != (g.Level == DialectGroupFamily) {
+		return errors.New("只有方言大区没有上级分类")
+	}
+	for _, r := range g.Regions {
+		if r.Province == "" {
+			return errors.New("对应地区的省份不能为空")
+		}
+	}
+	return nil
+}
+
+// GetAliases 获取别称
+func (g *DialectGroup) GetAliases() []string {
+	var aliases []string
+	if g.Aliases != "" {
+		_ = json.Unmarshal([]byte(g.Aliases), &aliases)
+	}
+	return aliases
+}
+
+// SetAliases 设置别称，去掉空白、重复及与名称相同的项
+func (g *DialectGroup) SetAliases(aliases []string) {
+	seen := map[string]bool{g.Name: true}
+	var list []string
+	for _, a := range aliases {
+		a = strings.TrimSpace(a)
+		if a == "" || seen[a] {
+			continue
+		}
+		seen[a] = true
+		list = append(list, a)
+	}
+	if len(list) == 0 {
+		g.Aliases = ""
+		return
+	}
+	data, _ := json.Marshal(list)
+	g.Aliases = string(data)
+}
+
+// Names 名称及全部别称
+func (g *DialectGroup) Names() []string {
+	return append([]string{g.Name}, g.GetAliases()...)
+}
+
+// PlaceUnder 挂到上级分类下（parent 为空表示作为方言大区），重新计算层级、路径和全称。
+// 需在 ID 确定后调用
+func (g *DialectGroup) PlaceUnder(parent *DialectGroup) error {
+	if parent == nil {
+		g.ParentID = nil
+		g.Level = DialectGroupFamily
+		g.Path = dialectGroupPathSep + g.ID + dialectGroupPathSep
+		g.FullName = g.Name
+		return nil
+	}
+	if parent.ID == g.ID || g.IsAncestorOf(parent) {
+		return errors.New("不能挂到自身或下级分类下")
+	}
+	level := parent.Level.ChildLevel()
+	if level == "" {
+		return errors.New("地点方言下不能再添加分类")
+	}
+	g.ParentID = &parent.ID
+	g.Level = level
+	g.Path = parent.Path + g.ID + dialectGroupPathSep
+	g.FullName = parent.FullName + dialectGroupPathSep + g.Name
+	return nil
+}
+
+// IsAncestorOf 是否为 other 的上级（不含自身）
+func (g *DialectGroup) IsAncestorOf(other *DialectGroup) bool {
+	return g.Path != "" && other.Path != g.Path && strings.HasPrefix(other.Path, g.Path)
+}
+
+// AncestorIDs 从方言大区到直接上级的 ID 列表
+func (g *DialectGroup) AncestorIDs() []string {
+	ids := strings.Split(strings.Trim(g.Path, dialectGroupPathSep), dialectGroupPathSep)
+	if len(ids) <= 1 {
+		return nil
+	}
+	return ids[:len(ids)-1]
+}
+
+// CoversRegion 对应地区是否覆盖指定省市
+func (g *DialectGroup) CoversRegion(province, city string) bool {
+	for i := range g.Regions {
+		if g.Regions[i].Covers(province, city) {
+			return true
+		}
+	}
+	return false
+}
+
+// SingleRegion 只对应一个地区时返回该地区，用于补全方言的省市
+func (g *DialectGroup) SingleRegion() *DialectGroupRegion {
+	if len(g.Regions) != 1 {
+		return nil
+	}
+	return &g.Regions[0]
+}
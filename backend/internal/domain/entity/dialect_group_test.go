@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDialectGroup_PlaceUnder(t *testing.T) {
+	wu := &DialectGroup{BaseEntity: BaseEntity{ID: "wu"}, Name: "吴语"}
+	assert.NoError(t, wu.PlaceUnder(nil))
+	assert.Equal(t, DialectGroupFamily, wu.Level)
+	assert.Equal(t, "/wu/", wu.Path)
+
+	taihu := &DialectGroup{BaseEntity: BaseEntity{ID: "taihu"}, Name: "太湖片"}
+	assert.NoError(t, taihu.PlaceUnder(wu))
+	suzhou := &DialectGroup{BaseEntity: BaseEntity{ID: "suzhou"}, Name: "苏州话"}
+	assert.NoError(t, suzhou.PlaceUnder(taihu))
+	assert.Equal(t, DialectGroupVariety, suzhou.Level)
+	assert.Equal(t, "/wu/taihu/suzhou/", suzhou.Path)
+	assert.Equal(t, "吴语/太湖片/苏州话", suzhou.FullName)
+	assert.Equal(t, []string{"wu", "taihu"}, suzhou.AncestorIDs())
+	assert.True(t, wu.IsAncestorOf(suzhou))
+	assert.False(t, suzhou.IsAncestorOf(suzhou))
+
+	assert.Error(t, (&DialectGroup{BaseEntity: BaseEntity{ID: "x"}, Name: "x"}).PlaceUnder(suzhou), "variety has no children")
+	assert.Error(t, wu.PlaceUnder(suzhou), "cannot move under own descendant")
+
+	suzhou.SetAliases([]string{" 吴侬软语 ", "苏州话", "", "吴侬软语"})
+	assert.Equal(t, []string{"苏州话", "吴侬软语"}, suzhou.Names())
+	assert.Error(t, (&DialectGroup{Name: "苏州话", Level: DialectGroupVariety}).Validate(), "variety needs a parent")
+	assert.NoError(t, suzhou.Validate())
+
+	suzhou.Regions = []DialectGroupRegion{{Province: "江苏", City: "苏州"}}
+	d := &Dialect{Region: "苏州方言"}
+	d.AssignGroup(suzhou)
+	assert.Equal(t, "suzhou", *d.GroupID)
+	assert.Equal(t, "苏州话", d.Region)
+	assert.Equal(t, "苏州", d.City)
+	assert.True(t, suzhou.CoversRegion("江苏", ""))
+	assert.False(t, suzhou.CoversRegion("江苏", "无锡"))
+}
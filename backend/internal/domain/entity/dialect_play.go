@@ -0,0 +1,88 @@
+package entity
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DialectPlayDedupWindow 播放去重窗口：同一收听者在窗口内重复播放（刷新页面、重新加载）不增加播放次数
+const DialectPlayDedupWindow = 30 * time.Minute
+
+// DialectListenerKey 收听者标识：登录用户按用户 ID，否则按 IP 与 User-Agent 的摘要
+func DialectListenerKey(userID, ip, userAgent string) string {
+	if userID != "" {
+		return "user:" + userID
+	}
+	sum := sha1.Sum([]byte(ip + "|" + userAgent))
+	return "anon:" + hex.EncodeToString(sum[:12])
+}
+
+// NewDialectPlayLog 创建播放记录
+func NewDialectPlayLog(dialectID, userID, ip, userAgent string, duration int) *DialectPlayLog {
+	log := &DialectPlayLog{
+		ID:          uuid.New().String(),
+		DialectID:   dialectID,
+		ListenerKey: DialectListenerKey(userID, ip, userAgent),
+		IP:          ip,
+		UserAgent:   userAgent,
+		Duration:    duration,
+		CreatedAt:   time.Now(),
+	}
+	if userID != "" {
+		log.UserID = &userID
+	}
+	if len(log.UserAgent) > 255 {
+		log.UserAgent = log.UserAgent[:255]
+	}
+	return log
+}
+
+// Completion 播放完成度（0-1），播放时长超过片段时长按 1 计；未上报时长或片段时长未知时返回 false
+func (l *DialectPlayLog) Completion(clipDuration int) (float64, bool) {
+	if l.Duration <= 0 || clipDuration <= 0 {
+		return 0, false
+	}
+	if l.Duration >= clipDuration {
+		return 1, true
+	}
+	return float64(l.Duration) / float64(clipDuration), true
+}
+
+// DialectPlaySummary 播放汇总
+type DialectPlaySummary struct {
+	Plays          int64   `json:"plays"`
+	Listeners      int64   `json:"listeners"`       // 去重收听者数
+	Reported       int64   `json:"reported"`        // 上报了播放时长的播放数，完成率据此计算
+	CompletionRate float64 `json:"completion_rate"` // 平均完成度
+}
+
+// DialectListenerDay 某收听者某天的播放汇总，用于按日、周、月统计去重收听者
+type DialectListenerDay struct {
+	Day           time.Time `json:"day"`
+	ListenerKey   string    `json:"listener_key"`
+	Plays         int64     `json:"plays"`
+	Reported      int64     `json:"reported"`
+	CompletionSum float64   `json:"completion_sum"`
+}
+
+// DialectRegionPlays 按方言地区汇总的播放
+type DialectRegionPlays struct {
+	Region    string `json:"region"`
+	Plays     int64  `json:"plays"`
+	Listeners int64  `json:"listeners"`
+	Clips     int64  `json:"clips"`
+}
+
+// DialectClipPlays 单条方言的播放汇总
+type DialectClipPlays struct {
+	DialectID      string  `json:"dialect_id"`
+	Title          string  `json:"title"`
+	Region         string  `json:"region"`
+	Duration       int     `json:"duration"`
+	Plays          int64   `json:"plays"`
+	Listeners      int64   `json:"listeners"`
+	CompletionRate float64 `json:"completion_rate"`
+}
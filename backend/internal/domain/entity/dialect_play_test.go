@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDialectPlayLog_ListenerAndCompletion(t *testing.T) {
+	assert.Equal(t, "user:u1", DialectListenerKey("u1", "1.2.3.4", "ua"))
+	anon := DialectListenerKey("", "1.2.3.4", "ua")
+	assert.Len(t, anon, len("anon:")+24)
+	assert.Equal(t, anon, DialectListenerKey("", "1.2.3.4", "ua"))
+	assert.NotEqual(t, anon, DialectListenerKey("", "1.2.3.4", "other"))
+
+	log := NewDialectPlayLog("d1", "", "1.2.3.4", strings.Repeat("x", 300), 30)
+	assert.NotEmpty(t, log.ID)
+	assert.Nil(t, log.UserID)
+	assert.Equal(t, anon[:5], log.ListenerKey[:5])
+	assert.Len(t, log.UserAgent, 255)
+
+	rate, ok := log.Completion(60)
+	assert.True(t, ok)
+	assert.InDelta(t, 0.5, rate, 1e-9)
+	rate, _ = log.Completion(20)
+	assert.Equal(t, 1.0, rate)
+	_, ok = log.Completion(0)
+	assert.False(t, ok)
+	_, ok = NewDialectPlayLog("d1", "u1", "", "", 0).Completion(60)
+	assert.False(t, ok)
+}
@@ -0,0 +1,299 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrDialectPlaylistAuto  = errors.New("auto-generated collection cannot be edited manually")
+	ErrDialectPlaylistFull  = errors.New("dialect playlist is full")
+	ErrDialectPlaylistStale = errors.New("dialect playlist items have changed")
+)
+
+// DialectPlaylistVisibility 歌单可见范围
+type DialectPlaylistVisibility string
+
+const (
+	DialectPlaylistPrivate DialectPlaylistVisibility = "private" // 仅创建者和协作者可见
+	DialectPlaylistOrg     DialectPlaylistVisibility = "org"     // 组织内共享
+)
+
+// DialectPlaylistKind 歌单类型
+type DialectPlaylistKind string
+
+const (
+	DialectPlaylistManual DialectPlaylistKind = "manual" // 手动编排的歌单
+	DialectPlaylistAuto   DialectPlaylistKind = "auto"   // 按省份自动生成的精选合集
+)
+
+const (
+	// DialectPlaylistMaxItems 歌单最多包含的片段数
+	DialectPlaylistMaxItems = 200
+	// DialectCollectionDefaultItems 自动合集默认片段数
+	DialectCollectionDefaultItems = 30
+)
+
+// DialectPlaylist 方言歌单：志愿者外出做籍贯辨识前预先编排的有序片段集合
+type DialectPlaylist struct {
+	BaseEntity
+	Title         string                    `gorm:"size:100;not null" json:"title"`
+	Description   string                    `gorm:"size:500" json:"description,omitempty"`
+	Visibility    DialectPlaylistVisibility `gorm:"size:20;not null;default:'private';index" json:"visibility"`
+	Kind          DialectPlaylistKind       `gorm:"size:20;not null;default:'manual';index" json:"kind"`
+	Province      string                    `gorm:"size:50;index" json:"province,omitempty"` // 自动合集的省份
+	ItemLimit     int                       `gorm:"default:0" json:"item_limit,omitempty"`   // 自动合集的片段数
+	ItemCount     int                       `gorm:"default:0" json:"item_count"`
+	TotalDuration int                       `gorm:"default:0" json:"total_duration"` // 秒
+	RefreshedAt   *time.Time                `json:"refreshed_at,omitempty"`          // 自动合集最近一次生成时间
+
+	OwnerID string `gorm:"type:uuid;not null;index" json:"owner_id"`
+	OrgID   string `gorm:"type:uuid;not null;index" json:"org_id"`
+
+	Owner         *User                         `gorm:"foreignKey:OwnerID" json:"owner,omitempty"`
+	Items         []DialectPlaylistItem         `gorm:"foreignKey:PlaylistID" json:"items,omitempty"`
+	Collaborators []DialectPlaylistCollaborator `gorm:"foreignKey:PlaylistID" json:"collaborators,omitempty"`
+}
+
+// TableName 表名
+func (DialectPlaylist) TableName() string {
+	return "ty_dialect_playlists"
+}
+
+// NewDialectPlaylist 创建手动歌单
+func NewDialectPlaylist(title, description string, visibility DialectPlaylistVisibility, ownerID, orgID string) (*DialectPlaylist, error) {
+	p := &DialectPlaylist{
+		Title:       strings.TrimSpace(title),
+		Description: strings.TrimSpace(description),
+		Visibility:  visibility,
+		Kind:        DialectPlaylistManual,
+		OwnerID:     ownerID,
+		OrgID:       orgID,
+	}
+	if p.Visibility == "" {
+		p.Visibility = DialectPlaylistPrivate
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// NewDialectCollection 创建按省份自动生成的精选合集，组织内共享
+func NewDialectCollection(province, title string, limit int, ownerID, orgID string) (*DialectPlaylist, error) {
+	province = strings.TrimSpace(province)
+	title = strings.TrimSpace(title)
+	if title == "" {
+		title = fmt.Sprintf("%s方言精选", province)
+	}
+	if limit <= 0 || limit > DialectPlaylistMaxItems {
+		limit = DialectCollectionDefaultItems
+	}
+	p := &DialectPlaylist{
+		Title:      title,
+		Visibility: DialectPlaylistOrg,
+		Kind:       DialectPlaylistAuto,
+		Province:   province,
+		ItemLimit:  limit,
+		OwnerID:    ownerID,
+		OrgID:      orgID,
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Validate 验证歌单
+func (p *DialectPlaylist) Validate() error {
+	if p.Title == "" {
+		return errors.New("歌单名称不能为空")
+	}
+	if len([]rune(p.Title)) > 100 {
+		return errors.New("歌单名称不能超过100个字")
+	}
+	if p.Visibility != DialectPlaylistPrivate && p.Visibility != DialectPlaylistOrg {
+		return fmt.Errorf("无效的可见范围: %s", p.Visibility)
+	}
+	if p.IsAuto() && p.Province == "" {
+		return errors.New("自动合集必须指定省份")
+	}
+	return nil
+}
+
+// IsAuto 是否为自动生成的合集
+func (p *DialectPlaylist) IsAuto() bool {
+	return p.Kind == DialectPlaylistAuto
+}
+
+// IsCollaborator 用户是否为协作者
+func (p *DialectPlaylist) IsCollaborator(userID string) bool {
+	for _, c := range p.Collaborators {
+		if c.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// CanView 用户能否查看：创建者、协作者，或组织共享歌单的同组织成员
+func (p *DialectPlaylist) CanView(userID, orgID string) bool {
+	if p.OwnerID == userID || p.IsCollaborator(userID) {
+		return true
+	}
+	return p.Visibility == DialectPlaylistOrg && p.OrgID == orgID
+}
+
+// CanEditItems 用户能否编排片段：创建者和协作者，自动合集只能重新生成
+func (p *DialectPlaylist) CanEditItems(userID string) bool {
+	if p.IsAuto() {
+		return false
+	}
+	return p.OwnerID == userID || p.IsCollaborator(userID)
+}
+
+// CanManage 用户能否修改歌单信息、协作者或删除歌单：创建者，或组织共享歌单所在组织的主管
+func (p *DialectPlaylist) CanManage(userID, orgID string, manager bool) bool {
+	if p.OwnerID == userID {
+		return true
+	}
+	return manager && p.Visibility == DialectPlaylistOrg && p.OrgID == orgID
+}
+
+// DialectPlaylistItem 歌单中的片段，按 Position 从小到大排列
+type DialectPlaylistItem struct {
+	ID         string    `gorm:"type:uuid;primaryKey" json:"id"`
+	PlaylistID string    `gorm:"type:uuid;not null;index:idx_dialect_playlist_item,unique" json:"playlist_id"`
+	DialectID  string    `gorm:"type:uuid;not null;index:idx_dialect_playlist_item,unique" json:"dialect_id"`
+	Position   int       `gorm:"not null;default:0" json:"position"`
+	Note       string    `gorm:"size:200" json:"note,omitempty"`   // 现场播放提示，如“先放这条”
+	Score      float64   `gorm:"default:0" json:"score,omitempty"` // 自动合集的排序得分
+	AddedBy    string    `gorm:"type:uuid;not null" json:"added_by"`
+	CreatedAt  time.Time `json:"created_at"`
+
+	Dialect *Dialect `gorm:"foreignKey:DialectID" json:"dialect,omitempty"`
+}
+
+// TableName 表名
+func (DialectPlaylistItem) TableName() string {
+	return "ty_dialect_playlist_items"
+}
+
+// NewDialectPlaylistItem 创建歌单片段
+func NewDialectPlaylistItem(playlistID, dialectID, addedBy, note string, position int) *DialectPlaylistItem {
+	return &DialectPlaylistItem{
+		ID:         uuid.New().String(),
+		PlaylistID: playlistID,
+		DialectID:  dialectID,
+		Position:   position,
+		Note:       strings.TrimSpace(note),
+		AddedBy:    addedBy,
+		CreatedAt:  time.Now(),
+	}
+}
+
+// ReorderDialectPlaylistItems 按 itemIDs 的顺序重新编号。itemIDs 必须恰好包含当前全部片段，
+// 其他协作者在此期间增删过片段时返回 ErrDialectPlaylistStale，由客户端刷新后重试
+func ReorderDialectPlaylistItems(items []DialectPlaylistItem, itemIDs []string) (map[string]int, error) {
+	if len(itemIDs) != len(items) {
+		return nil, ErrDialectPlaylistStale
+	}
+	current := make(map[string]bool, len(items))
+	for _, item := range items {
+		current[item.ID] = true
+	}
+	positions := make(map[string]int, len(itemIDs))
+	for i, id := range itemIDs {
+		if !current[id] {
+			return nil, ErrDialectPlaylistStale
+		}
+		if _, dup := positions[id]; dup {
+			return nil, ErrDialectPlaylistStale
+		}
+		positions[id] = i + 1
+	}
+	return positions, nil
+}
+
+// DialectPlaylistCollaborator 歌单协作者，可增删和排序片段
+type DialectPlaylistCollaborator struct {
+	ID         string    `gorm:"type:uuid;primaryKey" json:"id"`
+	PlaylistID string    `gorm:"type:uuid;not null;index:idx_dialect_playlist_collaborator,unique" json:"playlist_id"`
+	UserID     string    `gorm:"type:uuid;not null;index:idx_dialect_playlist_collaborator,unique;index" json:"user_id"`
+	AddedBy    string    `gorm:"type:uuid;not null" json:"added_by"`
+	CreatedAt  time.Time `json:"created_at"`
+
+	User *User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+// TableName 表名
+func (DialectPlaylistCollaborator) TableName() string {
+	return "ty_dialect_playlist_collaborators"
+}
+
+// NewDialectPlaylistCollaborator 创建歌单协作者
+func NewDialectPlaylistCollaborator(playlistID, userID, addedBy string) *DialectPlaylistCollaborator {
+	return &DialectPlaylistCollaborator{
+		ID:         uuid.New().String(),
+		PlaylistID: playlistID,
+		UserID:     userID,
+		AddedBy:    addedBy,
+		CreatedAt:  time.Now(),
+	}
+}
+
+// 精选合集评分权重
+const (
+	dialectCollectionFeaturedWeight   = 3.0 // 精选片段加分
+	dialectCollectionCompletionWeight = 4.0 // 平均完成度权重
+	dialectCollectionCompletionPrior  = 0.5 // 播放样本不足时的先验完成度
+	dialectCollectionPriorPlays       = 5.0 // 先验完成度相当的播放次数
+)
+
+// DialectClipCandidate 精选合集的候选片段及其播放完成情况
+type DialectClipCandidate struct {
+	DialectID     string    `json:"dialect_id"`
+	IsFeatured    bool      `json:"is_featured"`
+	LikeCount     int       `json:"like_count"`
+	Reported      int64     `json:"reported"`       // 上报了播放时长的播放数
+	CompletionSum float64   `json:"completion_sum"` // 完成度之和
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// Completion 平滑后的平均完成度：播放样本少时向先验值收缩，避免一两次完整播放就排到最前
+func (c *DialectClipCandidate) Completion() float64 {
+	return (c.CompletionSum + dialectCollectionCompletionPrior*dialectCollectionPriorPlays) /
+		(float64(c.Reported) + dialectCollectionPriorPlays)
+}
+
+// Score 精选合集得分：精选加分、点赞数（取对数，避免热门片段垄断）和平均完成度
+func (c *DialectClipCandidate) Score() float64 {
+	score := math.Log1p(float64(c.LikeCount)) + dialectCollectionCompletionWeight*c.Completion()
+	if c.IsFeatured {
+		score += dialectCollectionFeaturedWeight
+	}
+	return score
+}
+
+// RankDialectClips 按得分从高到低取前 limit 个候选片段，同分时较新的片段在前
+func RankDialectClips(candidates []DialectClipCandidate, limit int) []DialectClipCandidate {
+	ranked := make([]DialectClipCandidate, len(candidates))
+	copy(ranked, candidates)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		si, sj := ranked[i].Score(), ranked[j].Score()
+		if si != sj {
+			return si > sj
+		}
+		return ranked[i].CreatedAt.After(ranked[j].CreatedAt)
+	})
+	if limit > 0 && len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+	return ranked
+}
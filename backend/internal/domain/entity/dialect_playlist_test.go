@@ -0,0 +1,68 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDialectPlaylist_AccessAndReorder(t *testing.T) {
+	p, err := NewDialectPlaylist("  实地走访  ", "", "", "u1", "o1")
+	assert.NoError(t, err)
+	assert.Equal(t, "实地走访", p.Title)
+	assert.Equal(t, DialectPlaylistPrivate, p.Visibility)
+	p.Collaborators = []DialectPlaylistCollaborator{{UserID: "u2"}}
+
+	assert.True(t, p.CanView("u2", "o2"), "collaborators see private playlists")
+	assert.False(t, p.CanView("u3", "o1"))
+	assert.True(t, p.CanEditItems("u2"))
+	assert.False(t, p.CanManage("u2", "o1", false))
+	assert.False(t, p.CanManage("m1", "o1", true), "managers cannot manage private playlists")
+	p.Visibility = DialectPlaylistOrg
+	assert.True(t, p.CanView("u3", "o1"))
+	assert.False(t, p.CanEditItems("u3"))
+	assert.True(t, p.CanManage("m1", "o1", true))
+
+	items := []DialectPlaylistItem{{ID: "a"}, {ID: "b"}, {ID: "c"}}
+	positions, err := ReorderDialectPlaylistItems(items, []string{"c", "a", "b"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"c": 1, "a": 2, "b": 3}, positions)
+	_, err = ReorderDialectPlaylistItems(items, []string{"c", "a"})
+	assert.ErrorIs(t, err, ErrDialectPlaylistStale)
+	_, err = ReorderDialectPlaylistItems(items, []string{"c", "a", "a"})
+	assert.ErrorIs(t, err, ErrDialectPlaylistStale)
+	_, err = ReorderDialectPlaylistItems(items, []string{"c", "a", "x"})
+	assert.ErrorIs(t, err, ErrDialectPlaylistStale)
+
+	c, err := NewDialectCollection("四川", "", 0, "m1", "o1")
+	assert.NoError(t, err)
+	assert.Equal(t, "四川方言精选", c.Title)
+	assert.Equal(t, DialectCollectionDefaultItems, c.ItemLimit)
+	assert.False(t, c.CanEditItems("m1"), "auto collections are regenerated, not edited")
+	_, err = NewDialectCollection(" ", "", 0, "m1", "o1")
+	assert.Error(t, err)
+}
+
+func TestRankDialectClips(t *testing.T) {
+	now := time.Now()
+	candidates := []DialectClipCandidate{
+		{DialectID: "plain", CreatedAt: now},
+		{DialectID: "featured", IsFeatured: true, CreatedAt: now},
+		{DialectID: "liked", LikeCount: 50, CreatedAt: now},
+		{DialectID: "skipped", Reported: 40, CompletionSum: 4, CreatedAt: now},
+		{DialectID: "lucky", Reported: 1, CompletionSum: 1, CreatedAt: now},
+		{DialectID: "newer", CreatedAt: now.Add(time.Hour)},
+	}
+
+	ranked := RankDialectClips(candidates, 0)
+	ids := make([]string, len(ranked))
+	for i := range ranked {
+		ids[i] = ranked[i].DialectID
+	}
+	assert.Equal(t, []string{"liked", "featured", "lucky", "newer", "plain", "skipped"}, ids)
+	assert.InDelta(t, 0.5, candidates[0].Completion(), 1e-9, "no plays falls back to the prior")
+	assert.Less(t, candidates[4].Completion(), 0.6, "a single full play barely moves the average")
+	assert.Len(t, RankDialectClips(candidates, 2), 2)
+	assert.Equal(t, "plain", candidates[0].DialectID, "input is not reordered")
+}
@@ -0,0 +1,316 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// DialectReviewStatus 方言审核状态
+type DialectReviewStatus string
+
+const (
+	DialectReviewPending  DialectReviewStatus = "pending"  // 待认领
+	DialectReviewClaimed  DialectReviewStatus = "claimed"  // 已认领，审核中
+	DialectReviewApproved DialectReviewStatus = "approved" // 已通过
+	DialectReviewRejected DialectReviewStatus = "rejected" // 已驳回
+)
+
+// DialectReviewAction 审核操作
+type DialectReviewAction string
+
+const (
+	DialectReviewActionApprove DialectReviewAction = "approve"
+	DialectReviewActionReject  DialectReviewAction = "reject"
+)
+
+// DialectReviewReason 驳回原因
+type DialectReviewReason string
+
+const (
+	DialectReasonPoorQuality   DialectReviewReason = "poor_quality"  // 音质差、噪音大
+	DialectReasonWrongDialect  DialectReviewReason = "wrong_dialect" // 方言或地区标注错误
+	DialectReasonNotDialect    DialectReviewReason = "not_dialect"   // 不是方言（普通话、外语等）
+	DialectReasonInappropriate DialectReviewReason = "inappropriate" // 内容不当
+	DialectReasonPrivacy       DialectReviewReason = "privacy"       // 含个人隐私信息
+	DialectReasonDuplicate     DialectReviewReason = "duplicate"     // 重复上传
+	DialectReasonCopyright     DialectReviewReason = "copyright"     // 版权问题
+	DialectReasonOther         DialectReviewReason = "other"         // 其他，须填写说明
+)
+
+// DialectReviewReasons 全部驳回原因，按展示顺序排列
+var DialectReviewReasons = []DialectReviewReason{
+	DialectReasonPoorQuality,
+	DialectReasonWrongDialect,
+	DialectReasonNotDialect,
+	DialectReasonInappropriate,
+	DialectReasonPrivacy,
+	DialectReasonDuplicate,
+	DialectReasonCopyright,
+	DialectReasonOther,
+}
+
+var dialectReviewReasonLabels = map[DialectReviewReason]string{
+	DialectReasonPoorQuality:   "音质差",
+	DialectReasonWrongDialect:  "方言或地区标注错误",
+	DialectReasonNotDialect:    "不是方言",
+	DialectReasonInappropriate: "内容不当",
+	DialectReasonPrivacy:       "含个人隐私信息",
+	DialectReasonDuplicate:     "重复上传",
+	DialectReasonCopyright:     "版权问题",
+	DialectReasonOther:         "其他",
+}
+
+// Label 驳回原因名称
+func (r DialectReviewReason) Label() string {
+	return dialectReviewReasonLabels[r]
+}
+
+// IsValid 是否为有效的驳回原因
+func (r DialectReviewReason) IsValid() bool {
+	_, ok := dialectReviewReasonLabels[r]
+	return ok
+}
+
+// DialectReviewClaimTTL 认领超时时间，超时未处理的审核自动回到队列
+const DialectReviewClaimTTL = 30 * time.Minute
+
+var (
+	ErrDialectReviewClosed       = errors.New("审核已结束")
+	ErrDialectReviewNotClaimed   = errors.New("审核未被当前审核员认领")
+	ErrDialectReviewClaimed      = errors.New("审核已被其他审核员认领")
+	ErrDialectReviewDuplicate    = errors.New("同一审核员不能重复审核同一条方言")
+	ErrDialectReviewReasonNeeded = errors.New("驳回须选择有效的原因，选择其他时须填写说明")
+)
+
+// DialectReview 方言审核：方言上传后进入所属组织的审核队列，审核员认领后通过或驳回。
+// 组织配置了方言审核流程时，需要 RequiredApprovals 名不同审核员依次通过
+type DialectReview struct {
+	BaseEntity
+	DialectID          string              `gorm:"type:uuid;not null;index" json:"dialect_id"`
+	OrgID              string              `gorm:"type:uuid;not null;index:idx_dialect_review_queue" json:"org_id"`
+	UploaderID         string              `gorm:"type:uuid;not null" json:"uploader_id"`
+	Status             DialectReviewStatus `gorm:"size:20;not null;default:'pending';index:idx_dialect_review_queue" json:"status"`
+	RequiredApprovals  int                 `gorm:"not null;default:1" json:"required_approvals"`
+	Approvals          int                 `gorm:"not null;default:0" json:"approvals"`
+	ReviewerID         *string             `gorm:"type:uuid;index" json:"reviewer_id,omitempty"` // 当前认领人
+	ClaimedAt          *time.Time          `json:"claimed_at,omitempty"`
+	WorkflowInstanceID *string             `gorm:"type:uuid" json:"workflow_instance_id,omitempty"`
+	ReasonCode         DialectReviewReason `gorm:"size:30" json:"reason_code,omitempty"`
+	Comment            string              `gorm:"type:text" json:"comment,omitempty"`
+	DecidedAt          *time.Time          `json:"decided_at,omitempty"`
+
+	// 提交时声纹指纹与已有方言高度相似，疑似重复上传，由审核员确认
+	DuplicateOfID       *string `gorm:"type:uuid;index" json:"duplicate_of_id,omitempty"`
+	DuplicateSimilarity float64 `gorm:"default:0" json:"duplicate_similarity,omitempty"`
+
+	Dialect     *Dialect                `gorm:"foreignKey:DialectID" json:"dialect,omitempty"`
+	Reviewer    *User                   `gorm:"foreignKey:ReviewerID" json:"reviewer,omitempty"`
+	DuplicateOf *Dialect                `gorm:"foreignKey:DuplicateOfID" json:"duplicate_of,omitempty"`
+	Decisions   []DialectReviewDecision `gorm:"foreignKey:ReviewID" json:"decisions,omitempty"`
+}
+
+// TableName 表名
+func (DialectReview) TableName() string {
+	return "ty_dialect_reviews"
+}
+
+// DialectReviewDecision 审核员的一次审核结论，用于审核历史和审核员统计
+type DialectReviewDecision struct {
+	BaseEntity
+	ReviewID      string              `gorm:"type:uuid;not null;index" json:"review_id"`
+	OrgID         string              `gorm:"type:uuid;not null;index:idx_dialect_decision_reviewer" json:"org_id"`
+	ReviewerID    string              `gorm:"type:uuid;not null;index:idx_dialect_decision_reviewer" json:"reviewer_id"`
+	Action        DialectReviewAction `gorm:"size:20;not null" json:"action"`
+	ReasonCode    DialectReviewReason `gorm:"size:30" json:"reason_code,omitempty"`
+	Comment       string              `gorm:"type:text" json:"comment,omitempty"`
+	Stage         int                 `gorm:"not null" json:"stage"`                    // 第几位审核员，从 1 开始
+	HandleSeconds int                 `gorm:"not null;default:0" json:"handle_seconds"` // 认领到给出结论的用时
+
+	Reviewer *User `gorm:"foreignKey:ReviewerID" json:"reviewer,omitempty"`
+}
+
+// TableName 表名
+func (DialectReviewDecision) TableName() string {
+	return "ty_dialect_review_decisions"
+}
+
+// NewDialectReview 为待审核的方言创建审核，requiredApprovals 小于 1 时按 1 处理
+func NewDialectReview(d *Dialect, requiredApprovals int) *DialectReview {
+	if requiredApprovals < 1 {
+		requiredApprovals = 1
+	}
+	return &DialectReview{
+		DialectID:         d.ID,
+		OrgID:             d.OrgID,
+		UploaderID:        d.UploaderID,
+		Status:            DialectReviewPending,
+		RequiredApprovals: requiredApprovals,
+	}
+}
+
+// FlagDuplicate 标记为疑似重复上传，保留相似度最高的一条
+func (r *DialectReview) FlagDuplicate(dialectID string, similarity float64) {
+	if dialectID == "" || dialectID == r.DialectID || similarity <= r.DuplicateSimilarity {
+		return
+	}
+	r.DuplicateOfID = &dialectID
+	r.DuplicateSimilarity = similarity
+}
+
+// IsDuplicate 是否疑似重复上传
+func (r *DialectReview) IsDuplicate() bool {
+	return r.DuplicateOfID != nil
+}
+
+// IsClosed 是否已结束
+func (r *DialectReview) IsClosed() bool {
+	return r.Status == DialectReviewApproved || r.Status == DialectReviewRejected
+}
+
+// IsClaimExpired 认领是否已超时
+func (r *DialectReview) IsClaimExpired(now time.Time) bool {
+	return r.Status == DialectReviewClaimed && r.ClaimedAt != nil && now.Sub(*r.ClaimedAt) > DialectReviewClaimTTL
+}
+
+// IsClaimedBy 是否由指定审核员认领且未超时
+func (r *DialectReview) IsClaimedBy(reviewerID string, now time.Time) bool {
+	return r.Status == DialectReviewClaimed && r.ReviewerID != nil && *r.ReviewerID == reviewerID && !r.IsClaimExpired(now)
+}
+
+// HasDecided 审核员是否已给出过结论
+func (r *DialectReview) HasDecided(reviewerID string) bool {
+	for i := range r.Decisions {
+		if r.Decisions[i].ReviewerID == reviewerID {
+			return true
+		}
+	}
+	return false
+}
+
+// CanClaim 检查审核员能否认领：审核未结束、未被他人有效认领、该审核员未审核过
+func (r *DialectReview) CanClaim(reviewerID string, now time.Time) error {
+	if r.IsClosed() {
+		return ErrDialectReviewClosed
+	}
+	if r.Status == DialectReviewClaimed && !r.IsClaimExpired(now) && !r.IsClaimedBy(reviewerID, now) {
+		return ErrDialectReviewClaimed
+	}
+	if r.HasDecided(reviewerID) {
+		return ErrDialectReviewDuplicate
+	}
+	return nil
+}
+
+// Claim 认领
+func (r *DialectReview) Claim(reviewerID string, now time.Time) error {
+	if err := r.CanClaim(reviewerID, now); err != nil {
+		return err
+	}
+	r.Status = DialectReviewClaimed
+	r.ReviewerID = &reviewerID
+	r.ClaimedAt = &now
+	return nil
+}
+
+// Release 放弃认领，回到队列。force 为 true 时可释放他人的认领（管理员）
+func (r *DialectReview) Release(reviewerID string, force bool, now time.Time) error {
+	if r.IsClosed() {
+		return ErrDialectReviewClosed
+	}
+	if r.Status != DialectReviewClaimed || (!force && !r.IsClaimedBy(reviewerID, now)) {
+		return ErrDialectReviewNotClaimed
+	}
+	r.releaseClaim()
+	return nil
+}
+
+// Approve 审核员通过，返回本次结论。通过人数达到要求时审核结束，否则回到队列等待下一位审核员
+func (r *DialectReview) Approve(reviewerID, comment string, now time.Time) (*DialectReviewDecision, error) {
+	decision, err := r.decide(reviewerID, DialectReviewActionApprove, "", comment, now)
+	if err != nil {
+		return nil, err
+	}
+	r.Approvals++
+	if r.Approvals >= r.RequiredApprovals {
+		r.close(DialectReviewApproved, "", comment, now)
+	} else {
+		r.releaseClaim()
+	}
+	return decision, nil
+}
+
+// Reject 审核员驳回，任一审核员驳回即结束审核
+func (r *DialectReview) Reject(reviewerID string, reason DialectReviewReason, comment string, now time.Time) (*DialectReviewDecision, error) {
+	if !reason.IsValid() || (reason == DialectReasonOther && comment == "") {
+		return nil, ErrDialectReviewReasonNeeded
+	}
+	decision, err := r.decide(reviewerID, DialectReviewActionReject, reason, comment, now)
+	if err != nil {
+		return nil, err
+	}
+	r.close(DialectReviewRejected, reason, comment, now)
+	return decision, nil
+}
+
+// decide 记录审核员结论，要求审核员持有有效认领
+func (r *DialectReview) decide(reviewerID string, action DialectReviewAction, reason DialectReviewReason, comment string, now time.Time) (*DialectReviewDecision, error) {
+	if r.IsClosed() {
+		return nil, ErrDialectReviewClosed
+	}
+	if !r.IsClaimedBy(reviewerID, now) {
+		return nil, ErrDialectReviewNotClaimed
+	}
+	decision := DialectReviewDecision{
+		ReviewID:      r.ID,
+		OrgID:         r.OrgID,
+		ReviewerID:    reviewerID,
+		Action:        action,
+		ReasonCode:    reason,
+		Comment:       comment,
+		Stage:         r.Approvals + 1,
+		HandleSeconds: int(now.Sub(*r.ClaimedAt).Seconds()),
+	}
+	r.Decisions = append(r.Decisions, decision)
+	return &r.Decisions[len(r.Decisions)-1], nil
+}
+
+// close 结束审核，保留最后一位审核员
+func (r *DialectReview) close(status DialectReviewStatus, reason DialectReviewReason, comment string, now time.Time) {
+	r.Status = status
+	r.ReasonCode = reason
+	r.Comment = comment
+	r.DecidedAt = &now
+}
+
+// releaseClaim 清除认领
+func (r *DialectReview) releaseClaim() {
+	r.Status = DialectReviewPending
+	r.ReviewerID = nil
+	r.ClaimedAt = nil
+}
+
+// DialectStatus 审核结束后方言应处的状态，未结束时返回空
+func (r *DialectReview) DialectStatus() DialectStatus {
+	switch r.Status {
+	case DialectReviewApproved:
+		return DialectStatusActive
+	case DialectReviewRejected:
+		return DialectStatusInactive
+	}
+	return ""
+}
+
+// DialectReviewerStats 审核员审核统计
+type DialectReviewerStats struct {
+	ReviewerID    string     `json:"reviewer_id"`
+	Nickname      string     `json:"nickname"`
+	Approved      int64      `json:"approved"`
+	Rejected      int64      `json:"rejected"`
+	AvgSeconds    float64    `json:"avg_seconds"` // 平均处理用时
+	LastDecidedAt *time.Time `json:"last_decided_at,omitempty"`
+}
+
+// Total 审核总数
+func (s *DialectReviewerStats) Total() int64 {
+	return s.Approved + s.Rejected
+}
@@ -0,0 +1,70 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDialectReview_FlagDuplicate(t *testing.T) {
+	review := NewDialectReview(&Dialect{BaseEntity: BaseEntity{ID: "d1"}, OrgID: "o1", UploaderID: "u1"}, 1)
+	assert.False(t, review.IsDuplicate())
+
+	review.FlagDuplicate("d1", 0.99)
+	assert.False(t, review.IsDuplicate(), "a dialect is not a duplicate of itself")
+	review.FlagDuplicate("d2", 0.8)
+	review.FlagDuplicate("d3", 0.78)
+	assert.True(t, review.IsDuplicate())
+	assert.Equal(t, "d2", *review.DuplicateOfID)
+	review.FlagDuplicate("d4", 0.9)
+	assert.Equal(t, "d4", *review.DuplicateOfID)
+	assert.Equal(t, 0.9, review.DuplicateSimilarity)
+}
+
+func TestDialectReview_TwoReviewers(t *testing.T) {
+	now := time.Now()
+	review := NewDialectReview(&Dialect{BaseEntity: BaseEntity{ID: "d1"}, OrgID: "o1", UploaderID: "u1"}, 2)
+	review.ID = "r1"
+
+	assert.NoError(t, review.Claim("a", now))
+	assert.ErrorIs(t, review.Claim("b", now), ErrDialectReviewClaimed)
+	assert.NoError(t, review.Claim("b", now.Add(DialectReviewClaimTTL+time.Second)), "expired claim can be taken over")
+	assert.ErrorIs(t, review.Release("a", false, now), ErrDialectReviewNotClaimed)
+	assert.NoError(t, review.Release("a", true, now))
+	assert.Equal(t, DialectReviewPending, review.Status)
+
+	_, err := review.Approve("a", "", now)
+	assert.ErrorIs(t, err, ErrDialectReviewNotClaimed)
+	assert.NoError(t, review.Claim("a", now))
+	decision, err := review.Approve("a", "清晰", now.Add(90*time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, decision.Stage)
+	assert.Equal(t, 90, decision.HandleSeconds)
+	assert.Equal(t, DialectReviewPending, review.Status, "waits for the second reviewer")
+	assert.Nil(t, review.ReviewerID)
+	assert.Empty(t, review.DialectStatus())
+
+	assert.ErrorIs(t, review.Claim("a", now), ErrDialectReviewDuplicate)
+	assert.NoError(t, review.Claim("b", now))
+	_, err = review.Reject("b", DialectReasonOther, "", now)
+	assert.ErrorIs(t, err, ErrDialectReviewReasonNeeded)
+	_, err = review.Reject("b", "bad", "x", now)
+	assert.ErrorIs(t, err, ErrDialectReviewReasonNeeded)
+	decision, err = review.Approve("b", "", now)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, decision.Stage)
+	assert.Equal(t, DialectReviewApproved, review.Status)
+	assert.Equal(t, DialectStatusActive, review.DialectStatus())
+	assert.NotNil(t, review.DecidedAt)
+	assert.ErrorIs(t, review.Claim("c", now), ErrDialectReviewClosed)
+
+	single := NewDialectReview(&Dialect{BaseEntity: BaseEntity{ID: "d2"}}, 0)
+	assert.Equal(t, 1, single.RequiredApprovals)
+	assert.NoError(t, single.Claim("a", now))
+	_, err = single.Reject("a", DialectReasonPoorQuality, "", now)
+	assert.NoError(t, err)
+	assert.Equal(t, DialectReviewRejected, single.Status)
+	assert.Equal(t, DialectStatusInactive, single.DialectStatus())
+	assert.Equal(t, "音质差", single.ReasonCode.Label())
+}
@@ -0,0 +1,173 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrDialectSessionClosed   = errors.New("dialect session is not in progress")
+	ErrDialectSessionAttached = errors.New("dialect session already attached to a case")
+)
+
+// DialectSessionStatus 方言辨识会话状态
+type DialectSessionStatus string
+
+const (
+	DialectSessionInProgress DialectSessionStatus = "in_progress" // 辨识中
+	DialectSessionCompleted  DialectSessionStatus = "completed"   // 已完成
+	DialectSessionCancelled  DialectSessionStatus = "cancelled"   // 已取消
+)
+
+// DialectSessionStage 辨识阶段，按省、市、片区逐级缩小范围
+type DialectSessionStage string
+
+const (
+	DialectStageProvince DialectSessionStage = "province"
+	DialectStageCity     DialectSessionStage = "city"
+	DialectStageRegion   DialectSessionStage = "region"
+)
+
+// DialectRecognition 受测者听到方言片段后的反应
+type DialectRecognition string
+
+const (
+	DialectRecognitionNative     DialectRecognition = "native"     // 认出是家乡话
+	DialectRecognitionFamiliar   DialectRecognition = "familiar"   // 很熟悉、能听懂
+	DialectRecognitionPartial    DialectRecognition = "partial"    // 部分听懂或有些耳熟
+	DialectRecognitionUnfamiliar DialectRecognition = "unfamiliar" // 完全听不懂
+)
+
+// Weight 反应对应的证据权重，听不懂为负
+func (r DialectRecognition) Weight() int {
+	switch r {
+	case DialectRecognitionNative:
+		return 4
+	case DialectRecognitionFamiliar:
+		return 2
+	case DialectRecognitionPartial:
+		return 1
+	case DialectRecognitionUnfamiliar:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// IsValid 是否为有效反应
+func (r DialectRecognition) IsValid() bool {
+	return r.Weight() != 0
+}
+
+// 辨识结果关联到案件的方式
+const (
+	DialectAttachTrack = "track" // 生成一条待核实线索
+	DialectAttachNote  = "note"  // 仅作为案件备注关联
+)
+
+// DialectSession 方言辨识会话：为只会说方言的被寻获人员播放方言片段，逐级推断籍贯
+type DialectSession struct {
+	BaseEntity
+	MissingPersonID *string              `gorm:"type:uuid;index" json:"missing_person_id,omitempty"`
+	SubjectName     string               `gorm:"size:50" json:"subject_name,omitempty"` // 受测者称呼（可为临时编号）
+	Status          DialectSessionStatus `gorm:"size:20;not null;default:'in_progress';index" json:"status"`
+	Stage           DialectSessionStage  `gorm:"size:20;not null;default:'province'" json:"stage"`
+	Province        string               `gorm:"size:50" json:"province,omitempty"` // 已缩小到的省份
+	City            string               `gorm:"size:50" json:"city,omitempty"`     // 已缩小到的城市
+	Note            string               `gorm:"type:text" json:"note,omitempty"`
+	Result          string               `gorm:"type:text" json:"result,omitempty"` // 完成时的推断结果（JSON）
+	CompletedAt     *time.Time           `json:"completed_at,omitempty"`
+
+	// 关联到案件的方式及生成的线索
+	AttachMode string  `gorm:"size:20" json:"attach_mode,omitempty"`
+	TrackID    *string `gorm:"type:uuid" json:"track_id,omitempty"`
+
+	OperatorID string `gorm:"type:uuid;not null;index" json:"operator_id"`
+	OrgID      string `gorm:"type:uuid;not null;index" json:"org_id"`
+
+	Answers []DialectSessionAnswer `gorm:"foreignKey:SessionID" json:"answers,omitempty"`
+}
+
+// TableName 表名
+func (DialectSession) TableName() string {
+	return "ty_dialect_sessions"
+}
+
+// IsInProgress 是否辨识中
+func (s *DialectSession) IsInProgress() bool {
+	return s.Status == DialectSessionInProgress
+}
+
+// PlayedDialectIDs 已播放的方言片段
+func (s *DialectSession) PlayedDialectIDs() []string {
+	ids := make([]string, 0, len(s.Answers))
+	for _, r := range s.Answers {
+		ids = append(ids, r.DialectID)
+	}
+	return ids
+}
+
+// Narrow 设置当前辨识阶段及范围
+func (s *DialectSession) Narrow(stage DialectSessionStage, province, city string) {
+	s.Stage = stage
+	s.Province = province
+	s.City = city
+}
+
+// Complete 完成辨识并保存推断结果
+func (s *DialectSession) Complete(result string) error {
+	if !s.IsInProgress() {
+		return ErrDialectSessionClosed
+	}
+	now := time.Now()
+	s.Status = DialectSessionCompleted
+	s.Result = result
+	s.CompletedAt = &now
+	return nil
+}
+
+// Cancel 取消辨识
+func (s *DialectSession) Cancel() error {
+	if !s.IsInProgress() {
+		return ErrDialectSessionClosed
+	}
+	s.Status = DialectSessionCancelled
+	return nil
+}
+
+// Attach 关联到案件
+func (s *DialectSession) Attach(personID, mode string) error {
+	if s.Status != DialectSessionCompleted {
+		return fmt.Errorf("%w: 只有已完成的辨识才能关联案件", ErrDialectSessionClosed)
+	}
+	if s.AttachMode != "" {
+		return ErrDialectSessionAttached
+	}
+	if mode != DialectAttachTrack && mode != DialectAttachNote {
+		return fmt.Errorf("无效的关联方式: %s", mode)
+	}
+	s.MissingPersonID = &personID
+	s.AttachMode = mode
+	return nil
+}
+
+// DialectSessionAnswer 受测者对单个方言片段的反应，冗余记录片段所属地区以免片段修改后影响结果
+type DialectSessionAnswer struct {
+	BaseEntity
+	SessionID   string              `gorm:"type:uuid;not null;index" json:"session_id"`
+	DialectID   string              `gorm:"type:uuid;not null" json:"dialect_id"`
+	Stage       DialectSessionStage `gorm:"size:20;not null" json:"stage"`
+	Province    string              `gorm:"size:50" json:"province,omitempty"`
+	City        string              `gorm:"size:50" json:"city,omitempty"`
+	Region      string              `gorm:"size:100" json:"region,omitempty"`
+	Recognition DialectRecognition  `gorm:"size:20;not null" json:"recognition"`
+	Note        string              `gorm:"size:500" json:"note,omitempty"`
+
+	Dialect *Dialect `gorm:"foreignKey:DialectID" json:"dialect,omitempty"`
+}
+
+// TableName 表名
+func (DialectSessionAnswer) TableName() string {
+	return "ty_dialect_session_answers"
+}
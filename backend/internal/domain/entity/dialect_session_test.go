@@ -0,0 +1,25 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDialectSession_Lifecycle(t *testing.T) {
+	assert.True(t, DialectRecognitionNative.Weight() > DialectRecognitionFamiliar.Weight())
+	assert.True(t, DialectRecognitionUnfamiliar.Weight() < 0)
+	assert.False(t, DialectRecognition("maybe").IsValid())
+
+	session := &DialectSession{Status: DialectSessionInProgress, Stage: DialectStageProvince}
+	assert.ErrorIs(t, session.Attach("p1", DialectAttachNote), ErrDialectSessionClosed, "must complete before attaching")
+
+	assert.NoError(t, session.Complete(`[]`))
+	assert.NotNil(t, session.CompletedAt)
+	assert.ErrorIs(t, session.Cancel(), ErrDialectSessionClosed)
+
+	assert.Error(t, session.Attach("p1", "email"))
+	assert.NoError(t, session.Attach("p1", DialectAttachTrack))
+	assert.Equal(t, "p1", *session.MissingPersonID)
+	assert.ErrorIs(t, session.Attach("p2", DialectAttachNote), ErrDialectSessionAttached)
+}
@@ -0,0 +1,286 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	ErrDNASampleTransitionNotAllowed = errors.New("dna sample transition not allowed")
+	ErrGeneticDataNotAllowed         = errors.New("raw genetic data must not be stored")
+)
+
+// DNASampleSubject 样本采集对象
+type DNASampleSubject string
+
+const (
+	DNASampleSubjectMissingPerson DNASampleSubject = "missing_person" // 走失者本人（如找到的疑似人员、遗留物品）
+	DNASampleSubjectFamilyMember  DNASampleSubject = "family_member"  // 寻亲家属
+)
+
+// DNASampleType 样本类型
+type DNASampleType string
+
+const (
+	DNASampleTypeBlood  DNASampleType = "blood"  // 血样（血卡）
+	DNASampleTypeSaliva DNASampleType = "saliva" // 口腔拭子
+	DNASampleTypeHair   DNASampleType = "hair"   // 毛发
+	DNASampleTypeOther  DNASampleType = "other"  // 其他
+)
+
+// DNASampleStatus 样本状态
+type DNASampleStatus string
+
+const (
+	DNASampleStatusCollected DNASampleStatus = "collected" // 已采集，由志愿者或机构保管
+	DNASampleStatusSubmitted DNASampleStatus = "submitted" // 已送检
+	DNASampleStatusResulted  DNASampleStatus = "resulted"  // 已出比对结果
+	DNASampleStatusRejected  DNASampleStatus = "rejected"  // 实验室退回（样本不合格等）
+	DNASampleStatusDestroyed DNASampleStatus = "destroyed" // 已销毁
+)
+
+// DNASampleResult 比对结果
+type DNASampleResult string
+
+const (
+	DNASampleResultMatch        DNASampleResult = "match"        // 比中
+	DNASampleResultNoMatch      DNASampleResult = "no_match"     // 未比中
+	DNASampleResultInconclusive DNASampleResult = "inconclusive" // 无法判定
+)
+
+// 保管链事件
+const (
+	DNACustodyCollect  = "collect"  // 采集
+	DNACustodyTransfer = "transfer" // 移交保管
+	DNACustodySubmit   = "submit"   // 送检
+	DNACustodyResult   = "result"   // 登记比对结果
+	DNACustodyReject   = "reject"   // 实验室退回
+	DNACustodyDestroy  = "destroy"  // 销毁
+)
+
+// dnaSampleTransitions 保管链事件允许的状态转换（移交保管不改变状态）
+var dnaSampleTransitions = map[string]map[DNASampleStatus]DNASampleStatus{
+	DNACustodyTransfer: {
+		DNASampleStatusCollected: DNASampleStatusCollected,
+		DNASampleStatusRejected:  DNASampleStatusRejected,
+	},
+	DNACustodySubmit: {
+		DNASampleStatusCollected: DNASampleStatusSubmitted,
+		DNASampleStatusRejected:  DNASampleStatusSubmitted,
+	},
+	DNACustodyResult: {
+		DNASampleStatusSubmitted: DNASampleStatusResulted,
+	},
+	DNACustodyReject: {
+		DNASampleStatusSubmitted: DNASampleStatusRejected,
+	},
+	DNACustodyDestroy: {
+		DNASampleStatusCollected: DNASampleStatusDestroyed,
+		DNASampleStatusResulted:  DNASampleStatusDestroyed,
+		DNASampleStatusRejected:  DNASampleStatusDestroyed,
+	},
+}
+
+// geneticMarkerPattern 常见 STR 基因座名称后跟分型数值（如 "D8S1179: 13,14"、"TH01=6"）
+var geneticMarkerPattern = regexp.MustCompile(`(?i)\b(D\d{1,2}S\d{2,4}|TH01|TPOX|CSF1PO|FGA|vWA|Penta ?[DE]|AMEL(?:OGENIN)?)\b\s*[:：=]?\s*[\dXY]`)
+
+// ContainsGeneticProfile 文本中是否包含 STR 分型等原始遗传数据（出现两个及以上基因座分型时判定）
+func ContainsGeneticProfile(text string) bool {
+	return len(geneticMarkerPattern.FindAllStringIndex(text, 2)) >= 2
+}
+
+// DNASample DNA / 血样采集记录
+// 仅记录样本编号、采集、送检、结果编号等保管链元数据，不保存任何原始遗传数据
+type DNASample struct {
+	BaseEntity
+	MissingPersonID string           `gorm:"type:uuid;not null;index" json:"missing_person_id"`
+	OrgID           string           `gorm:"type:uuid;not null;index" json:"org_id"`
+	SampleNo        string           `gorm:"size:50;not null;uniqueIndex" json:"sample_no"`
+	SubjectType     DNASampleSubject `gorm:"size:20;not null" json:"subject_type"`
+	SampleType      DNASampleType    `gorm:"size:20;not null" json:"sample_type"`
+
+	// 采样对象为家属时的信息
+	DonorName     string `gorm:"size:50" json:"donor_name,omitempty"`
+	DonorRelation string `gorm:"size:20" json:"donor_relation,omitempty"`
+	DonorPhone    string `gorm:"size:20" json:"donor_phone,omitempty"`
+
+	// 采集
+	CollectedAt    time.Time `gorm:"not null" json:"collected_at"`
+	CollectionSite string    `gorm:"size:200;not null" json:"collection_site"`
+	CollectorID    string    `gorm:"type:uuid;not null;index" json:"collector_id"`
+	Holder         string    `gorm:"size:100" json:"holder,omitempty"` // 当前保管人或保管机构
+
+	// 送检及结果
+	Status      DNASampleStatus `gorm:"size:20;not null;default:'collected';index" json:"status"`
+	LabName     string          `gorm:"size:100" json:"lab_name,omitempty"`
+	LabCaseNo   string          `gorm:"size:100" json:"lab_case_no,omitempty"` // 实验室受理编号
+	SubmittedAt *time.Time      `json:"submitted_at,omitempty"`
+	Result      DNASampleResult `gorm:"size:20" json:"result,omitempty"`
+	ResultRef   string          `gorm:"size:100" json:"result_ref,omitempty"` // 比对结果文书编号
+	ResultAt    *time.Time      `json:"result_at,omitempty"`
+	Note        string          `gorm:"type:text" json:"note,omitempty"`
+
+	Collector *User `gorm:"foreignKey:CollectorID" json:"collector,omitempty"`
+}
+
+// TableName 表名
+func (DNASample) TableName() string {
+	return "ty_dna_samples"
+}
+
+// Validate 验证样本记录
+func (s *DNASample) Validate() error {
+	if strings.TrimSpace(s.SampleNo) == "" {
+		return errors.New("样本编号不能为空")
+	}
+	switch s.SubjectType {
+	case DNASampleSubjectMissingPerson:
+	case DNASampleSubjectFamilyMember:
+		if strings.TrimSpace(s.DonorName) == "" || strings.TrimSpace(s.DonorRelation) == "" {
+			return errors.New("家属样本必须填写采样人姓名及与走失者关系")
+		}
+	default:
+		return fmt.Errorf("无效的采样对象: %s", s.SubjectType)
+	}
+	switch s.SampleType {
+	case DNASampleTypeBlood, DNASampleTypeSaliva, DNASampleTypeHair, DNASampleTypeOther:
+	default:
+		return fmt.Errorf("无效的样本类型: %s", s.SampleType)
+	}
+	if s.CollectedAt.IsZero() || s.CollectedAt.After(time.Now().Add(time.Hour)) {
+		return errors.New("采集时间无效")
+	}
+	if strings.TrimSpace(s.CollectionSite) == "" {
+		return errors.New("采集地点不能为空")
+	}
+	if s.CollectorID == "" {
+		return errors.New("采集人不能为空")
+	}
+	return checkGeneticData(s.Note, s.ResultRef, s.LabCaseNo)
+}
+
+// ApplyCustody 执行保管链事件，返回保管记录
+func (s *DNASample) ApplyCustody(event DNASampleCustodyEvent, operatorID string) (*DNASampleCustodyLog, error) {
+	to, ok := dnaSampleTransitions[event.Action][s.Status]
+	if !ok {
+		return nil, fmt.Errorf("%w: 当前状态 %s 不能执行 %s", ErrDNASampleTransitionNotAllowed, s.Status, event.Action)
+	}
+	if err := checkGeneticData(event.Note, event.ResultRef, event.LabCaseNo); err != nil {
+		return nil, err
+	}
+
+	at := event.OccurredAt
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	switch event.Action {
+	case DNACustodyTransfer:
+		if strings.TrimSpace(event.Holder) == "" {
+			return nil, errors.New("移交保管必须填写接收人")
+		}
+	case DNACustodySubmit:
+		if strings.TrimSpace(event.LabName) == "" {
+			return nil, errors.New("送检必须填写实验室")
+		}
+		s.LabName = event.LabName
+		s.LabCaseNo = event.LabCaseNo
+		s.SubmittedAt = &at
+		s.Result = ""
+		s.ResultRef = ""
+		s.ResultAt = nil
+	case DNACustodyResult:
+		switch event.Result {
+		case DNASampleResultMatch, DNASampleResultNoMatch, DNASampleResultInconclusive:
+		default:
+			return nil, fmt.Errorf("无效的比对结果: %s", event.Result)
+		}
+		if strings.TrimSpace(event.ResultRef) == "" {
+			return nil, errors.New("登记比对结果必须填写结果文书编号")
+		}
+		s.Result = event.Result
+		s.ResultRef = event.ResultRef
+		s.ResultAt = &at
+	case DNACustodyReject, DNACustodyDestroy:
+		if strings.TrimSpace(event.Note) == "" {
+			return nil, fmt.Errorf("%s 必须填写原因", event.Action)
+		}
+	}
+
+	log := &DNASampleCustodyLog{
+		SampleID:   s.ID,
+		Action:     event.Action,
+		FromStatus: s.Status,
+		ToStatus:   to,
+		OperatorID: operatorID,
+		Holder:     event.Holder,
+		Location:   event.Location,
+		Note:       event.Note,
+		OccurredAt: at,
+	}
+	if event.Holder != "" {
+		s.Holder = event.Holder
+	}
+	s.Status = to
+	return log, nil
+}
+
+// CollectLog 样本采集的保管记录
+func (s *DNASample) CollectLog(operatorID string) *DNASampleCustodyLog {
+	return &DNASampleCustodyLog{
+		SampleID:   s.ID,
+		Action:     DNACustodyCollect,
+		ToStatus:   s.Status,
+		OperatorID: operatorID,
+		Holder:     s.Holder,
+		Location:   s.CollectionSite,
+		OccurredAt: s.CollectedAt,
+	}
+}
+
+// checkGeneticData 拒绝包含原始遗传数据的文本
+func checkGeneticData(texts ...string) error {
+	for _, t := range texts {
+		if ContainsGeneticProfile(t) {
+			return ErrGeneticDataNotAllowed
+		}
+	}
+	return nil
+}
+
+// DNASampleCustodyEvent 保管链事件参数
+type DNASampleCustodyEvent struct {
+	Action     string
+	Holder     string
+	Location   string
+	Note       string
+	OccurredAt time.Time
+	LabName    string
+	LabCaseNo  string
+	Result     DNASampleResult
+	ResultRef  string
+}
+
+// DNASampleCustodyLog 样本保管链记录
+type DNASampleCustodyLog struct {
+	BaseEntity
+	SampleID   string          `gorm:"type:uuid;not null;index" json:"sample_id"`
+	Action     string          `gorm:"size:20;not null" json:"action"`
+	FromStatus DNASampleStatus `gorm:"size:20" json:"from_status,omitempty"`
+	ToStatus   DNASampleStatus `gorm:"size:20;not null" json:"to_status"`
+	OperatorID string          `gorm:"type:uuid;not null" json:"operator_id"`
+	Holder     string          `gorm:"size:100" json:"holder,omitempty"`
+	Location   string          `gorm:"size:200" json:"location,omitempty"`
+	Note       string          `gorm:"type:text" json:"note,omitempty"`
+	OccurredAt time.Time       `gorm:"not null" json:"occurred_at"`
+
+	Operator *User `gorm:"foreignKey:OperatorID" json:"operator,omitempty"`
+}
+
+// TableName 表名
+func (DNASampleCustodyLog) TableName() string {
+	return "ty_dna_sample_custody_logs"
+}
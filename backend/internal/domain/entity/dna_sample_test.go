@@ -0,0 +1,60 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDNASample_Custody(t *testing.T) {
+	sample := &DNASample{
+		SampleNo:       "XY-2026-001",
+		SubjectType:    DNASampleSubjectFamilyMember,
+		SampleType:     DNASampleTypeBlood,
+		CollectedAt:    time.Now().Add(-time.Hour),
+		CollectionSite: "市寻亲服务站",
+		CollectorID:    "u1",
+		Status:         DNASampleStatusCollected,
+	}
+	assert.Error(t, sample.Validate(), "family sample requires donor")
+	sample.DonorName = "李某"
+	sample.DonorRelation = "母亲"
+	assert.NoError(t, sample.Validate())
+
+	_, err := sample.ApplyCustody(DNASampleCustodyEvent{Action: DNACustodyResult, Result: DNASampleResultMatch, ResultRef: "R-1"}, "u1")
+	assert.ErrorIs(t, err, ErrDNASampleTransitionNotAllowed)
+
+	log, err := sample.ApplyCustody(DNASampleCustodyEvent{Action: DNACustodyTransfer, Holder: "区公安分局"}, "u1")
+	assert.NoError(t, err)
+	assert.Equal(t, DNASampleStatusCollected, log.ToStatus)
+	assert.Equal(t, "区公安分局", sample.Holder)
+
+	_, err = sample.ApplyCustody(DNASampleCustodyEvent{Action: DNACustodySubmit, LabName: "市局DNA实验室", LabCaseNo: "L-88"}, "u1")
+	assert.NoError(t, err)
+	assert.Equal(t, DNASampleStatusSubmitted, sample.Status)
+	assert.NotNil(t, sample.SubmittedAt)
+
+	_, err = sample.ApplyCustody(DNASampleCustodyEvent{Action: DNACustodyResult, Result: DNASampleResultMatch}, "u1")
+	assert.Error(t, err, "result requires document reference")
+	_, err = sample.ApplyCustody(DNASampleCustodyEvent{Action: DNACustodyResult, Result: DNASampleResultMatch, ResultRef: "R-1"}, "u1")
+	assert.NoError(t, err)
+	assert.Equal(t, DNASampleStatusResulted, sample.Status)
+
+	_, err = sample.ApplyCustody(DNASampleCustodyEvent{Action: DNACustodyDestroy}, "u1")
+	assert.Error(t, err, "destroy requires reason")
+}
+
+func TestContainsGeneticProfile(t *testing.T) {
+	assert.False(t, ContainsGeneticProfile("血卡已交市局，受理号 D2026-118"))
+	assert.False(t, ContainsGeneticProfile("TH01 基因座检测完成"))
+	assert.True(t, ContainsGeneticProfile("D8S1179: 13,14; D21S11: 29,30"))
+	assert.True(t, ContainsGeneticProfile("TH01=6,9 vWA 16/17"))
+
+	sample := &DNASample{
+		SampleNo: "S1", SubjectType: DNASampleSubjectMissingPerson, SampleType: DNASampleTypeHair,
+		CollectedAt: time.Now(), CollectionSite: "站点", CollectorID: "u1",
+		Note: "D3S1358 15 16, FGA 22 24",
+	}
+	assert.ErrorIs(t, sample.Validate(), ErrGeneticDataNotAllowed)
+}
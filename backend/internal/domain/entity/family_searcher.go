@@ -0,0 +1,233 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrSearcherCaseAlreadyLinked = errors.New("case already linked to searcher")
+	ErrSearcherSelfLink          = errors.New("searcher cannot be linked to itself")
+)
+
+// FamilySearcherKind 寻亲者类型
+type FamilySearcherKind string
+
+const (
+	FamilySearcherSeekingRelative FamilySearcherKind = "seeking_relative" // 家寻亲：家人寻找走失的孩子或亲人
+	FamilySearcherSeekingFamily   FamilySearcherKind = "seeking_family"   // 亲寻家：走失者本人寻找原生家庭
+)
+
+// FamilySearcherStatus 寻亲者状态
+type FamilySearcherStatus string
+
+const (
+	FamilySearcherStatusActive FamilySearcherStatus = "active" // 寻找中
+	FamilySearcherStatusFound  FamilySearcherStatus = "found"  // 已找到
+	FamilySearcherStatusClosed FamilySearcherStatus = "closed" // 已关闭
+)
+
+// FamilySearcher 寻亲者档案，与案件（MissingPerson）相互独立，可关联多个案件
+//
+// Lost* 字段描述被寻找的人：家寻亲时为走失的亲人，亲寻家时为登记人本人走失时的情况
+type FamilySearcher struct {
+	BaseEntity
+	Kind   FamilySearcherKind   `gorm:"size:20;not null;index" json:"kind"`
+	Status FamilySearcherStatus `gorm:"size:20;not null;default:'active';index" json:"status"`
+
+	// 登记人
+	Name      string `gorm:"size:50;not null" json:"name"`
+	Gender    string `gorm:"size:10" json:"gender,omitempty"`
+	BirthYear int    `json:"birth_year,omitempty"`
+	Province  string `gorm:"size:50" json:"province,omitempty"` // 现居住地
+	City      string `gorm:"size:50" json:"city,omitempty"`
+
+	// 被寻找的人
+	LostName       string     `gorm:"size:50" json:"lost_name,omitempty"` // 姓名或乳名，亲寻家时为记得的小名
+	LostNamePinyin string     `gorm:"size:100;index" json:"-"`
+	LostGender     string     `gorm:"size:10;index" json:"lost_gender,omitempty"`
+	LostBirthYear  int        `json:"lost_birth_year,omitempty"`
+	LostAge        int        `json:"lost_age,omitempty"` // 走失时年龄（可为估计值）
+	LostTime       *time.Time `json:"lost_time,omitempty"`
+	LostProvince   string     `gorm:"size:50;index" json:"lost_province,omitempty"`
+	LostCity       string     `gorm:"size:50" json:"lost_city,omitempty"`
+	LostDistrict   string     `gorm:"size:50" json:"lost_district,omitempty"`
+	LostAddress    string     `gorm:"size:255" json:"lost_address,omitempty"`
+	Dialect        string     `gorm:"size:50" json:"dialect,omitempty"` // 口音或方言
+	Features       string     `gorm:"type:text" json:"features,omitempty"`
+	Memories       string     `gorm:"type:text" json:"memories,omitempty"` // 记忆片段：家乡环境、家人、走失经过等
+	Description    string     `gorm:"type:text" json:"description,omitempty"`
+
+	OrgID          string `gorm:"type:uuid;not null;index" json:"org_id"`
+	RegisteredByID string `gorm:"type:uuid;not null" json:"registered_by_id"`
+
+	Contacts  []FamilySearcherContact  `gorm:"foreignKey:SearcherID" json:"contacts,omitempty"`
+	Relatives []FamilySearcherRelative `gorm:"foreignKey:SearcherID" json:"relatives,omitempty"`
+	Cases     []FamilySearcherCase     `gorm:"foreignKey:SearcherID" json:"cases,omitempty"`
+}
+
+// TableName 表名
+func (FamilySearcher) TableName() string {
+	return "ty_family_searchers"
+}
+
+// Validate 验证寻亲者档案
+func (s *FamilySearcher) Validate() error {
+	switch s.Kind {
+	case FamilySearcherSeekingRelative, FamilySearcherSeekingFamily:
+	default:
+		return fmt.Errorf("无效的寻亲类型: %s", s.Kind)
+	}
+	switch s.Status {
+	case FamilySearcherStatusActive, FamilySearcherStatusFound, FamilySearcherStatusClosed:
+	default:
+		return fmt.Errorf("无效的状态: %s", s.Status)
+	}
+	if strings.TrimSpace(s.Name) == "" {
+		return errors.New("登记人姓名不能为空")
+	}
+	if s.Kind == FamilySearcherSeekingRelative && strings.TrimSpace(s.LostName) == "" {
+		return errors.New("家寻亲必须填写被寻找人姓名")
+	}
+	if s.LostTime != nil && s.LostTime.After(time.Now()) {
+		return errors.New("走失时间不能晚于当前时间")
+	}
+	if len(s.Contacts) == 0 {
+		return errors.New("至少需要一位联系人")
+	}
+	primary := 0
+	for i := range s.Contacts {
+		if err := s.Contacts[i].Validate(); err != nil {
+			return err
+		}
+		if s.Contacts[i].IsPrimary {
+			primary++
+		}
+	}
+	if primary > 1 {
+		return errors.New("只能有一位主要联系人")
+	}
+	for i := range s.Relatives {
+		if err := s.Relatives[i].Validate(); err != nil {
+			return err
+		}
+		if s.ID != "" && s.Relatives[i].LinkedSearcherID != nil && *s.Relatives[i].LinkedSearcherID == s.ID {
+			return ErrSearcherSelfLink
+		}
+	}
+	return nil
+}
+
+// IsActive 是否寻找中
+func (s *FamilySearcher) IsActive() bool {
+	return s.Status == FamilySearcherStatusActive
+}
+
+// PrimaryContact 主要联系人，未指定时取第一位
+func (s *FamilySearcher) PrimaryContact() *FamilySearcherContact {
+	for i := range s.Contacts {
+		if s.Contacts[i].IsPrimary {
+			return &s.Contacts[i]
+		}
+	}
+	if len(s.Contacts) > 0 {
+		return &s.Contacts[0]
+	}
+	return nil
+}
+
+// MatchKind 可与之比对的寻亲者类型：家寻亲与亲寻家互相比对
+func (s *FamilySearcher) MatchKind() FamilySearcherKind {
+	if s.Kind == FamilySearcherSeekingFamily {
+		return FamilySearcherSeekingRelative
+	}
+	return FamilySearcherSeekingFamily
+}
+
+// LinkedCase 查找已关联的案件
+func (s *FamilySearcher) LinkedCase(personID string) *FamilySearcherCase {
+	for i := range s.Cases {
+		if s.Cases[i].MissingPersonID == personID {
+			return &s.Cases[i]
+		}
+	}
+	return nil
+}
+
+// FamilySearcherContact 寻亲者联系人
+type FamilySearcherContact struct {
+	BaseEntity
+	SearcherID string `gorm:"type:uuid;not null;index" json:"searcher_id"`
+	Name       string `gorm:"size:50;not null" json:"name"`
+	Relation   string `gorm:"size:20" json:"relation,omitempty"` // 与登记人关系
+	Phone      string `gorm:"size:20;not null" json:"phone"`
+	WeChat     string `gorm:"size:50" json:"wechat,omitempty"`
+	IsPrimary  bool   `gorm:"default:false" json:"is_primary"`
+	Note       string `gorm:"size:255" json:"note,omitempty"`
+}
+
+// TableName 表名
+func (FamilySearcherContact) TableName() string {
+	return "ty_family_searcher_contacts"
+}
+
+// Validate 验证联系人
+func (c *FamilySearcherContact) Validate() error {
+	if strings.TrimSpace(c.Name) == "" {
+		return errors.New("联系人姓名不能为空")
+	}
+	if strings.TrimSpace(c.Phone) == "" {
+		return errors.New("联系人电话不能为空")
+	}
+	return nil
+}
+
+// FamilySearcherRelative 寻亲者的亲属关系，LinkedSearcherID 指向同样登记为寻亲者的亲属，构成亲属关系图
+type FamilySearcherRelative struct {
+	BaseEntity
+	SearcherID       string  `gorm:"type:uuid;not null;index" json:"searcher_id"`
+	Name             string  `gorm:"size:50" json:"name,omitempty"`
+	Relation         string  `gorm:"size:20;not null" json:"relation"` // 与被寻找人的关系：父亲、母亲、哥哥等
+	Gender           string  `gorm:"size:10" json:"gender,omitempty"`
+	BirthYear        int     `json:"birth_year,omitempty"`
+	Hometown         string  `gorm:"size:255" json:"hometown,omitempty"`
+	Deceased         bool    `gorm:"default:false" json:"deceased"`
+	LinkedSearcherID *string `gorm:"type:uuid;index" json:"linked_searcher_id,omitempty"`
+	Note             string  `gorm:"size:255" json:"note,omitempty"`
+}
+
+// TableName 表名
+func (FamilySearcherRelative) TableName() string {
+	return "ty_family_searcher_relatives"
+}
+
+// Validate 验证亲属
+func (r *FamilySearcherRelative) Validate() error {
+	if strings.TrimSpace(r.Relation) == "" {
+		return errors.New("亲属关系不能为空")
+	}
+	if strings.TrimSpace(r.Name) == "" && r.LinkedSearcherID == nil {
+		return errors.New("亲属姓名不能为空")
+	}
+	return nil
+}
+
+// FamilySearcherCase 寻亲者与案件的关联
+type FamilySearcherCase struct {
+	BaseEntity
+	SearcherID      string  `gorm:"type:uuid;not null;uniqueIndex:idx_searcher_case" json:"searcher_id"`
+	MissingPersonID string  `gorm:"type:uuid;not null;uniqueIndex:idx_searcher_case;index" json:"missing_person_id"`
+	Relation        string  `gorm:"size:20" json:"relation,omitempty"` // 案件走失者与登记人的关系，亲寻家时可能为"本人"
+	MatchScore      float64 `json:"match_score,omitempty"`             // 由比对结果确认关联时的得分
+	LinkedByID      string  `gorm:"type:uuid;not null" json:"linked_by_id"`
+	Note            string  `gorm:"size:255" json:"note,omitempty"`
+
+	MissingPerson *MissingPerson `gorm:"foreignKey:MissingPersonID" json:"missing_person,omitempty"`
+}
+
+// TableName 表名
+func (FamilySearcherCase) TableName() string {
+	return "ty_family_searcher_cases"
+}
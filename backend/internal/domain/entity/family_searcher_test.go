@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFamilySearcher_Validate(t *testing.T) {
+	searcher := &FamilySearcher{
+		Kind:     FamilySearcherSeekingRelative,
+		Status:   FamilySearcherStatusActive,
+		Name:     "王建国",
+		Contacts: []FamilySearcherContact{{Name: "王建国", Phone: "13800000000"}},
+	}
+	assert.Error(t, searcher.Validate(), "seeking relative requires lost name")
+
+	searcher.LostName = "王小明"
+	assert.NoError(t, searcher.Validate())
+	assert.Equal(t, FamilySearcherSeekingFamily, searcher.MatchKind())
+
+	searcher.Contacts = append(searcher.Contacts, FamilySearcherContact{Name: "李芳", Phone: "13900000000", IsPrimary: true})
+	assert.Equal(t, "李芳", searcher.PrimaryContact().Name)
+	searcher.Contacts[0].IsPrimary = true
+	assert.Error(t, searcher.Validate(), "only one primary contact")
+
+	self := &FamilySearcher{
+		BaseEntity: BaseEntity{ID: "s1"},
+		Kind:       FamilySearcherSeekingFamily,
+		Status:     FamilySearcherStatusActive,
+		Name:       "张丽",
+		Contacts:   []FamilySearcherContact{{Name: "张丽", Phone: "13700000000"}},
+	}
+	assert.NoError(t, self.Validate(), "seeking family may not remember birth name")
+	assert.Equal(t, FamilySearcherSeekingRelative, self.MatchKind())
+
+	id := "s1"
+	self.Relatives = []FamilySearcherRelative{{Relation: "姐姐", LinkedSearcherID: &id}}
+	assert.ErrorIs(t, self.Validate(), ErrSearcherSelfLink)
+}
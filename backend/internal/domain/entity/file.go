@@ -1,9 +1,11 @@
 package entity
 
 import (
+	"encoding/json"
 	"errors"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
 // FileType 文件类型
@@ -41,6 +43,41 @@ type File struct {
 	EntityID     string      `gorm:"type:uuid;index" json:"entity_id"` // 关联实体ID
 	Description  string      `gorm:"type:text" json:"description"`
 	IsDeleted    bool        `gorm:"default:false" json:"is_deleted"`
+
+	// 图片处理结果（仅图片）
+	Width    int        `gorm:"default:0" json:"width,omitempty"`
+	Height   int        `gorm:"default:0" json:"height,omitempty"`
+	PHash    string     `gorm:"size:16;index" json:"phash,omitempty"`
+	TakenAt  *time.Time `json:"taken_at,omitempty"` // EXIF 拍摄时间
+	Lat      float64    `json:"lat,omitempty"`      // EXIF 拍摄位置，仅作线索参考
+	Lng      float64    `json:"lng,omitempty"`
+	Variants string     `gorm:"type:json" json:"-"` // 衍生版本，见 FileVariant
+
+	// 音频处理结果（仅音频），由服务端探测，不采用客户端声明
+	DurationMs       int     `gorm:"default:0" json:"duration_ms,omitempty"`
+	AudioFormat      string  `gorm:"size:10" json:"audio_format,omitempty"` // 原始文件的实际格式
+	Loudness         float64 `json:"loudness,omitempty"`                    // 原始积分响度（LUFS）
+	AudioFingerprint string  `gorm:"type:text" json:"-"`                    // 声纹指纹，见 audio.Fingerprint
+}
+
+// 图片衍生版本
+const (
+	FileVariantPublic    = "public"    // 去除元数据的公开版本
+	FileVariantThumbnail = "thumbnail" // 缩略图
+	FileVariantShare     = "share"     // 带水印的分享版本
+)
+
+// 音频衍生版本
+const (
+	FileVariantStream   = "stream"   // 响度归一化后转码的 MP3
+	FileVariantWaveform = "waveform" // 播放器波形数据（JSON）
+)
+
+// FileVariant 文件衍生版本
+type FileVariant struct {
+	Path string `json:"path"`
+	URL  string `json:"url"`
+	Size int64  `json:"size,omitempty"`
 }
 
 // TableName 表名
@@ -87,6 +124,35 @@ func (f *File) IsDocument() bool {
 	return f.FileType == FileTypeDocument
 }
 
+// SetVariants 设置衍生版本
+func (f *File) SetVariants(variants map[string]FileVariant) error {
+	data, err := json.Marshal(variants)
+	if err != nil {
+		return err
+	}
+	f.Variants = string(data)
+	return nil
+}
+
+// GetVariants 获取衍生版本
+func (f *File) GetVariants() map[string]FileVariant {
+	variants := make(map[string]FileVariant)
+	if f.Variants != "" {
+		_ = json.Unmarshal([]byte(f.Variants), &variants)
+	}
+	return variants
+}
+
+// VariantURL 获取衍生版本URL，不存在时返回空
+func (f *File) VariantURL(name string) string {
+	return f.GetVariants()[name].URL
+}
+
+// DurationSeconds 音频时长（秒，向上取整）
+func (f *File) DurationSeconds() int {
+	return (f.DurationMs + 999) / 1000
+}
+
 // MarkAsDeleted 标记为已删除
 func (f *File) MarkAsDeleted() {
 	f.IsDeleted = true
@@ -0,0 +1,225 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ImportStatus 导入任务状态
+type ImportStatus string
+
+const (
+	ImportStatusPending   ImportStatus = "pending"
+	ImportStatusRunning   ImportStatus = "running"
+	ImportStatusCompleted ImportStatus = "completed"
+	ImportStatusFailed    ImportStatus = "failed"
+)
+
+// 导入行处理结果
+const (
+	ImportRowValid     = "valid"     // 校验通过（预检）
+	ImportRowCreated   = "created"   // 已创建案件
+	ImportRowFailed    = "failed"    // 校验失败
+	ImportRowDuplicate = "duplicate" // 疑似重复，已跳过
+)
+
+// WSMessageTypeImportProgress 导入进度 WebSocket 消息类型
+const WSMessageTypeImportProgress = "import_progress"
+
+// ImportJob 案件批量导入任务
+// 表格原文件保存在存储中，预检（DryRun）任务确认后以相同文件和列映射重新执行
+type ImportJob struct {
+	BaseEntity
+	OrgID          string       `gorm:"type:uuid;not null;index" json:"org_id"`
+	CreatorID      string       `gorm:"type:uuid;not null;index" json:"creator_id"`
+	FileName       string       `gorm:"size:255;not null" json:"file_name"`
+	FilePath       string       `gorm:"size:500;not null" json:"-"`
+	Format         string       `gorm:"size:10;not null" json:"format"` // csv, xlsx
+	Status         ImportStatus `gorm:"size:20;not null;default:'pending';index" json:"status"`
+	DryRun         bool         `gorm:"default:false" json:"dry_run"`
+	SkipDuplicates bool         `gorm:"default:true" json:"skip_duplicates"`
+	SourceJobID    *string      `gorm:"type:uuid" json:"source_job_id,omitempty"` // 由预检任务确认生成时指向预检任务
+
+	Mapping string `gorm:"type:text" json:"-"` // 字段 -> 列名，见 GetMapping
+	Header  string `gorm:"type:text" json:"-"` // 原表头，用于生成错误报告
+	Results string `gorm:"type:text" json:"-"` // 逐行结果，见 ImportRowResult
+
+	TotalRows     int        `gorm:"default:0" json:"total_rows"`
+	ProcessedRows int        `gorm:"default:0" json:"processed_rows"`
+	CreatedRows   int        `gorm:"default:0" json:"created_rows"`
+	ValidRows     int        `gorm:"default:0" json:"valid_rows"`
+	FailedRows    int        `gorm:"default:0" json:"failed_rows"`
+	DuplicateRows int        `gorm:"default:0" json:"duplicate_rows"`
+	Error         string     `gorm:"type:text" json:"error,omitempty"`
+	StartedAt     *time.Time `json:"started_at,omitempty"`
+	FinishedAt    *time.Time `json:"finished_at,omitempty"`
+}
+
+// TableName 表名
+func (ImportJob) TableName() string {
+	return "ty_import_jobs"
+}
+
+// ImportRowResult 单行导入结果，Values 仅在失败或重复时保留原始单元格
+type ImportRowResult struct {
+	Row        int               `json:"row"` // 表格中的行号（表头为第 1 行）
+	Status     string            `json:"status"`
+	Name       string            `json:"name,omitempty"`
+	CaseID     string            `json:"case_id,omitempty"`
+	CaseNo     string            `json:"case_no,omitempty"`
+	Errors     []string          `json:"errors,omitempty"`
+	Duplicates []ImportDuplicate `json:"duplicates,omitempty"`
+	Values     []string          `json:"values,omitempty"`
+}
+
+// ImportDuplicate 疑似重复的已有案件或同一文件中的前序行
+type ImportDuplicate struct {
+	CaseID string  `json:"case_id,omitempty"`
+	CaseNo string  `json:"case_no,omitempty"`
+	Name   string  `json:"name"`
+	Row    int     `json:"row,omitempty"` // 同一文件中的重复行
+	Score  float64 `json:"score"`
+}
+
+// GetMapping 获取列映射
+func (j *ImportJob) GetMapping() map[string]string {
+	return decodeStringMap(j.Mapping)
+}
+
+// SetMapping 设置列映射
+func (j *ImportJob) SetMapping(mapping map[string]string) {
+	j.Mapping = encodeJSON(mapping)
+}
+
+// GetHeader 获取原表头
+func (j *ImportJob) GetHeader() []string {
+	var header []string
+	if j.Header != "" {
+		_ = json.Unmarshal([]byte(j.Header), &header)
+	}
+	return header
+}
+
+// SetHeader 设置原表头
+func (j *ImportJob) SetHeader(header []string) {
+	j.Header = encodeJSON(header)
+}
+
+// GetResults 获取逐行结果
+func (j *ImportJob) GetResults() []ImportRowResult {
+	var results []ImportRowResult
+	if j.Results != "" {
+		_ = json.Unmarshal([]byte(j.Results), &results)
+	}
+	return results
+}
+
+// SetResults 设置逐行结果并更新计数
+func (j *ImportJob) SetResults(results []ImportRowResult) {
+	j.Results = encodeJSON(results)
+	j.ProcessedRows = len(results)
+	j.CreatedRows, j.ValidRows, j.FailedRows, j.DuplicateRows = 0, 0, 0, 0
+	for _, r := range results {
+		j.count(r.Status)
+	}
+}
+
+// RecordRow 记录一行处理完成，用于执行过程中上报进度
+func (j *ImportJob) RecordRow(status string) {
+	j.ProcessedRows++
+	j.count(status)
+}
+
+// count 累加单行结果计数
+func (j *ImportJob) count(status string) {
+	switch status {
+	case ImportRowCreated:
+		j.CreatedRows++
+	case ImportRowValid:
+		j.ValidRows++
+	case ImportRowFailed:
+		j.FailedRows++
+	case ImportRowDuplicate:
+		j.DuplicateRows++
+	}
+}
+
+// Start 开始执行
+func (j *ImportJob) Start() {
+	now := time.Now()
+	j.Status = ImportStatusRunning
+	j.StartedAt = &now
+}
+
+// Finish 执行结束，err 不为空时标记失败
+func (j *ImportJob) Finish(err error) {
+	now := time.Now()
+	j.FinishedAt = &now
+	if err != nil {
+		j.Status = ImportStatusFailed
+		j.Error = err.Error()
+		return
+	}
+	j.Status = ImportStatusCompleted
+}
+
+// IsFinished 是否已结束
+func (j *ImportJob) IsFinished() bool {
+	return j.Status == ImportStatusCompleted || j.Status == ImportStatusFailed
+}
+
+// CanConfirm 预检完成且存在可导入的行时可以确认导入
+func (j *ImportJob) CanConfirm() bool {
+	return j.DryRun && j.Status == ImportStatusCompleted && j.ValidRows > 0
+}
+
+// Progress 进度百分比
+func (j *ImportJob) Progress() int {
+	if j.TotalRows == 0 {
+		if j.IsFinished() {
+			return 100
+		}
+		return 0
+	}
+	return j.ProcessedRows * 100 / j.TotalRows
+}
+
+// ImportTemplate 列映射模板，用于合作机构定期推送的固定格式表格
+type ImportTemplate struct {
+	BaseEntity
+	OrgID     string `gorm:"type:uuid;not null;index" json:"org_id"`
+	CreatorID string `gorm:"type:uuid;not null" json:"creator_id"`
+	Name      string `gorm:"size:100;not null" json:"name"`
+	Mapping   string `gorm:"type:text;not null" json:"-"` // 字段 -> 列名
+}
+
+// TableName 表名
+func (ImportTemplate) TableName() string {
+	return "ty_import_templates"
+}
+
+// GetMapping 获取列映射
+func (t *ImportTemplate) GetMapping() map[string]string {
+	return decodeStringMap(t.Mapping)
+}
+
+// SetMapping 设置列映射
+func (t *ImportTemplate) SetMapping(mapping map[string]string) {
+	t.Mapping = encodeJSON(mapping)
+}
+
+func decodeStringMap(s string) map[string]string {
+	m := make(map[string]string)
+	if s != "" {
+		_ = json.Unmarshal([]byte(s), &m)
+	}
+	return m
+}
+
+func encodeJSON(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
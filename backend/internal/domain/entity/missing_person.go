@@ -6,7 +6,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Snowitty-Re/CNtunyuan/pkg/geo"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 // MissingStatus 走失状态
@@ -35,6 +37,7 @@ type MissingPerson struct {
 	BaseEntity
 	CaseNo      string         `gorm:"size:50;uniqueIndex" json:"case_no"`
 	Name        string         `gorm:"size:50;not null" json:"name"`
+	NamePinyin  string         `gorm:"size:100;index" json:"-"` // 姓名全拼，用于查重
 	Gender      string         `gorm:"size:10;not null" json:"gender"`
 	BirthDate   *time.Time     `json:"birth_date,omitempty"`
 	Age         int            `json:"age"`
@@ -50,6 +53,9 @@ type MissingPerson struct {
 	City        string    `gorm:"size:50" json:"city,omitempty"`
 	District    string    `gorm:"size:50" json:"district,omitempty"`
 	Address     string    `gorm:"size:255" json:"address,omitempty"`
+	Lat         float64   `json:"lat,omitempty"`
+	Lng         float64   `json:"lng,omitempty"`
+	Geohash     string    `gorm:"size:12;index" json:"-"`
 	Clothes     string    `gorm:"type:text" json:"clothes,omitempty"`
 	Features    string    `gorm:"type:text" json:"features,omitempty"`
 
@@ -65,6 +71,12 @@ type MissingPerson struct {
 	Views      int           `gorm:"default:0" json:"views"`
 	ShareCount int           `gorm:"default:0" json:"share_count"`
 
+	// 紧急程度评分，UrgencyManual 为 true 时保留人工设置的等级，只更新评分
+	UrgencyScore       int        `gorm:"default:0" json:"urgency_score"`
+	UrgencyManual      bool       `gorm:"default:false" json:"urgency_manual"`
+	UrgencyScoredAt    *time.Time `json:"urgency_scored_at,omitempty"`
+	UrgencyEscalatedAt *time.Time `json:"urgency_escalated_at,omitempty"`
+
 	// 关联
 	ReporterID string  `gorm:"type:uuid;not null;index" json:"reporter_id"`
 	OrgID      string  `gorm:"type:uuid;not null;index" json:"org_id"`
@@ -75,6 +87,12 @@ type MissingPerson struct {
 	FoundLocation string     `gorm:"size:255" json:"found_location,omitempty"`
 	FoundNote     string     `gorm:"type:text" json:"found_note,omitempty"`
 
+	// StatusReason 最近一次状态变更的原因（关闭、重新打开等），完整记录见状态变更记录
+	StatusReason string `gorm:"type:text" json:"status_reason,omitempty"`
+
+	// 合并信息（被合并的重复案件指向保留案件）
+	MergedIntoID *string `gorm:"type:uuid;index" json:"merged_into_id,omitempty"`
+
 	Reporter *User                `gorm:"foreignKey:ReporterID" json:"reporter,omitempty"`
 	Org      *Organization        `gorm:"foreignKey:OrgID" json:"org,omitempty"`
 	Assignee *User                `gorm:"foreignKey:AssignedTo" json:"assignee,omitempty"`
@@ -86,6 +104,14 @@ func (MissingPerson) TableName() string {
 	return "ty_missing_persons"
 }
 
+// BeforeSave 保存前根据经纬度计算 geohash
+func (m *MissingPerson) BeforeSave(tx *gorm.DB) error {
+	if geo.ValidCoordinate(m.Lat, m.Lng) {
+		m.Geohash = geo.Encode(m.Lat, m.Lng, geo.DefaultGeohashPrecision)
+	}
+	return nil
+}
+
 // Validate 验证
 func (m *MissingPerson) Validate() error {
 	if m.Name == "" {
@@ -122,45 +148,105 @@ func (m *MissingPerson) CanUpdate() bool {
 }
 
 // StartSearch 开始搜索
-func (m *MissingPerson) StartSearch() error {
-	if m.Status != MissingStatusMissing {
-		return errors.New("只有待寻找状态才能开始搜索")
-	}
-	m.Status = MissingStatusSearching
-	return nil
+func (m *MissingPerson) StartSearch(userID string) (*MissingPersonStatusLog, error) {
+	return m.Transition(CaseEventStartSearch, userID, "")
 }
 
 // MarkFound 标记为已找到
-func (m *MissingPerson) MarkFound(location, note string) error {
-	if m.IsFound() {
-		return errors.New("该案件已被标记为找到")
+func (m *MissingPerson) MarkFound(userID, location, note string) (*MissingPersonStatusLog, error) {
+	log, err := m.Transition(CaseEventMarkFound, userID, note)
+	if err != nil {
+		return nil, err
 	}
 	now := time.Now()
-	m.Status = MissingStatusFound
 	m.FoundTime = &now
 	m.FoundLocation = location
 	m.FoundNote = note
-	return nil
+	return log, nil
 }
 
 // MarkReunited 标记为已团聚
-func (m *MissingPerson) MarkReunited() error {
-	if m.Status != MissingStatusFound {
-		return errors.New("只有已找到状态才能标记为团聚")
+func (m *MissingPerson) MarkReunited(userID string) (*MissingPersonStatusLog, error) {
+	return m.Transition(CaseEventReunite, userID, "")
+}
+
+// Close 关闭案件，必须填写原因
+func (m *MissingPerson) Close(userID, reason string) (*MissingPersonStatusLog, error) {
+	return m.Transition(CaseEventClose, userID, reason)
+}
+
+// Reopen 重新打开已找到或已关闭的案件，必须填写原因；从已找到恢复搜索时清空找到信息
+func (m *MissingPerson) Reopen(userID, reason string) (*MissingPersonStatusLog, error) {
+	wasFound := m.Status == MissingStatusFound
+	log, err := m.Transition(CaseEventReopen, userID, reason)
+	if err != nil {
+		return nil, err
 	}
-	m.Status = MissingStatusReunited
-	return nil
+	if wasFound {
+		m.FoundTime = nil
+		m.FoundLocation = ""
+		m.FoundNote = ""
+	}
+	return log, nil
 }
 
-// Close 关闭案件
-func (m *MissingPerson) Close(reason string) error {
-	if m.Status == MissingStatusClosed {
-		return errors.New("案件已关闭")
+// IsMerged 是否已被合并到其它案件
+func (m *MissingPerson) IsMerged() bool {
+	return m.MergedIntoID != nil && *m.MergedIntoID != ""
+}
+
+// MergeInto 合并到保留案件
+func (m *MissingPerson) MergeInto(survivor *MissingPerson) error {
+	if m.ID == survivor.ID {
+		return errors.New("不能将案件合并到自身")
+	}
+	if m.IsMerged() {
+		return errors.New("该案件已被合并")
 	}
+	if survivor.IsMerged() {
+		return errors.New("保留案件已被合并到其它案件")
+	}
+	m.MergedIntoID = &survivor.ID
 	m.Status = MissingStatusClosed
+	m.FoundNote = fmt.Sprintf("重复案件，已合并至 %s", survivor.CaseNo)
 	return nil
 }
 
+// RaiseUrgency 紧急程度提升一级，已是最高级时返回 false
+func (m *MissingPerson) RaiseUrgency() bool {
+	switch m.Urgency {
+	case UrgencyLevelLow:
+		m.Urgency = UrgencyLevelMedium
+	case UrgencyLevelMedium, "":
+		m.Urgency = UrgencyLevelHigh
+	case UrgencyLevelHigh:
+		m.Urgency = UrgencyLevelCritical
+	default:
+		return false
+	}
+	return true
+}
+
+// ApplyUrgency 应用紧急程度评分结果，案件首次升级为紧急时返回 true（需通知升级）
+// 降为非紧急后清除升级时间，再次升级时重新通知
+func (m *MissingPerson) ApplyUrgency(level UrgencyLevel, score int, now time.Time) bool {
+	m.UrgencyScore = score
+	m.UrgencyScoredAt = &now
+	if !m.UrgencyManual {
+		m.Urgency = level
+	}
+
+	if m.Urgency != UrgencyLevelCritical {
+		m.UrgencyEscalatedAt = nil
+		return false
+	}
+	if m.UrgencyEscalatedAt != nil {
+		return false
+	}
+	m.UrgencyEscalatedAt = &now
+	return true
+}
+
 // AssignTo 分配给某人
 func (m *MissingPerson) AssignTo(userID string) {
 	m.AssignedTo = &userID
@@ -183,11 +269,25 @@ func (m *MissingPerson) GetAgeAtMissing() int {
 	return age
 }
 
+// 线索状态
+const (
+	TrackStatusPending   = "pending"
+	TrackStatusVerifying = "verifying"
+	TrackStatusConfirmed = "confirmed"
+	TrackStatusRejected  = "rejected"
+)
+
+// 线索来源
+const (
+	TrackSourceVolunteer = "volunteer" // 登录用户（志愿者/工作人员）录入
+	TrackSourcePublic    = "public"    // 公众匿名提交
+)
+
 // MissingPersonTrack 轨迹记录
 type MissingPersonTrack struct {
 	BaseEntity
 	MissingPersonID string    `gorm:"type:uuid;not null;index" json:"missing_person_id"`
-	ReporterID      string    `gorm:"type:uuid;not null" json:"reporter_id"`
+	ReporterID      *string   `gorm:"type:uuid;index" json:"reporter_id,omitempty"` // 公众提交时为空
 	Location        string    `gorm:"size:255" json:"location"`
 	Province        string    `gorm:"size:50" json:"province,omitempty"`
 	City            string    `gorm:"size:50" json:"city,omitempty"`
@@ -200,10 +300,23 @@ type MissingPersonTrack struct {
 	AudioUrl        string    `gorm:"size:255" json:"audio_url,omitempty"`
 	Lat             float64   `json:"lat,omitempty"`
 	Lng             float64   `json:"lng,omitempty"`
+	Geohash         string    `gorm:"size:12;index" json:"-"`
 	Status          string    `gorm:"size:20;default:'pending'" json:"status"`
 	IsKeyPoint      bool      `gorm:"default:false" json:"is_key_point"`
 
+	// 线索来源及公众提交人信息（仅内部可见）
+	Source         string `gorm:"size:20;default:'volunteer'" json:"source"`
+	SubmitterName  string `gorm:"size:50" json:"submitter_name,omitempty"`
+	SubmitterPhone string `gorm:"size:20" json:"submitter_phone,omitempty"`
+	SubmitterIP    string `gorm:"size:64" json:"-"`
+
+	// 核实信息
+	VerifierID   *string    `gorm:"type:uuid;index" json:"verifier_id,omitempty"`
+	VerifiedAt   *time.Time `json:"verified_at,omitempty"`
+	StatusReason string     `gorm:"type:text" json:"status_reason,omitempty"`
+
 	Reporter *User `gorm:"foreignKey:ReporterID" json:"reporter,omitempty"`
+	Verifier *User `gorm:"foreignKey:VerifierID" json:"verifier,omitempty"`
 }
 
 // TableName 表名
@@ -211,14 +324,103 @@ func (MissingPersonTrack) TableName() string {
 	return "ty_missing_person_tracks"
 }
 
+// BeforeSave 保存前根据经纬度计算 geohash
+func (t *MissingPersonTrack) BeforeSave(tx *gorm.DB) error {
+	if geo.ValidCoordinate(t.Lat, t.Lng) {
+		t.Geohash = geo.Encode(t.Lat, t.Lng, geo.DefaultGeohashPrecision)
+	}
+	return nil
+}
+
+// IsFinal 是否已完成核实（确认或驳回）
+func (t *MissingPersonTrack) IsFinal() bool {
+	return t.Status == TrackStatusConfirmed || t.Status == TrackStatusRejected
+}
+
+// StartVerify 开始核实
+func (t *MissingPersonTrack) StartVerify(userID string) error {
+	if t.Status != TrackStatusPending {
+		return errors.New("只有待核实的线索才能开始核实")
+	}
+	t.Status = TrackStatusVerifying
+	t.VerifierID = &userID
+	return nil
+}
+
+// Confirm 确认线索
+func (t *MissingPersonTrack) Confirm(userID, reason string) error {
+	if t.IsFinal() {
+		return errors.New("线索已完成核实")
+	}
+	t.finish(TrackStatusConfirmed, userID, reason)
+	return nil
+}
+
+// Reject 驳回线索，必须填写原因
+func (t *MissingPersonTrack) Reject(userID, reason string) error {
+	if t.IsFinal() {
+		return errors.New("线索已完成核实")
+	}
+	if strings.TrimSpace(reason) == "" {
+		return errors.New("驳回线索必须填写原因")
+	}
+	t.finish(TrackStatusRejected, userID, reason)
+	return nil
+}
+
+// finish 完成核实
+func (t *MissingPersonTrack) finish(status, userID, reason string) {
+	now := time.Now()
+	t.Status = status
+	t.VerifierID = &userID
+	t.VerifiedAt = &now
+	t.StatusReason = reason
+}
+
+// MissingPersonTrackLog 线索核实日志
+type MissingPersonTrackLog struct {
+	BaseEntity
+	TrackID   string `gorm:"type:uuid;not null;index" json:"track_id"`
+	UserID    string `gorm:"type:uuid;not null" json:"user_id"`
+	Action    string `gorm:"size:50;not null" json:"action"`
+	OldStatus string `gorm:"size:20" json:"old_status,omitempty"`
+	NewStatus string `gorm:"size:20" json:"new_status,omitempty"`
+	Reason    string `gorm:"type:text" json:"reason,omitempty"`
+
+	User *User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+// TableName 表名
+func (MissingPersonTrackLog) TableName() string {
+	return "ty_missing_person_track_logs"
+}
+
+// 照片类型
+const (
+	PhotoTypeNormal    = "normal"    // 普通照片
+	PhotoTypeSimulated = "simulated" // 年龄模拟（推测现貌）
+	PhotoTypeFeature   = "feature"   // 体貌特征特写
+)
+
 // MissingPhoto 走失人员照片
 type MissingPhoto struct {
 	BaseEntity
 	MissingPersonID string `gorm:"type:uuid;not null;index" json:"missing_person_id"`
-	URL             string `gorm:"size:500;not null" json:"url"`
+	URL             string `gorm:"size:500;not null" json:"url"`         // 去除元数据的公开版本
 	Type            string `gorm:"size:20;default:'normal'" json:"type"` // normal, simulated, feature
 	Description     string `gorm:"type:text" json:"description,omitempty"`
 	IsPrimary       bool   `gorm:"default:false" json:"is_primary"`
+
+	// 图片处理结果
+	FileID       *string    `gorm:"type:uuid;index" json:"file_id,omitempty"`
+	ThumbnailURL string     `gorm:"size:500" json:"thumbnail_url,omitempty"`
+	ShareURL     string     `gorm:"size:500" json:"share_url,omitempty"`
+	PHash        string     `gorm:"size:16;index" json:"-"`
+	Width        int        `gorm:"default:0" json:"width,omitempty"`
+	Height       int        `gorm:"default:0" json:"height,omitempty"`
+	TakenAt      *time.Time `json:"taken_at,omitempty"` // EXIF 拍摄时间，作为线索参考
+	Lat          float64    `json:"lat,omitempty"`      // EXIF 拍摄位置，作为线索参考
+	Lng          float64    `json:"lng,omitempty"`
 }
 
 // TableName 表名
@@ -226,6 +428,30 @@ func (MissingPhoto) TableName() string {
 	return "ty_missing_photos"
 }
 
+// IsValidPhotoType 是否为有效的照片类型
+func IsValidPhotoType(t string) bool {
+	return t == PhotoTypeNormal || t == PhotoTypeSimulated || t == PhotoTypeFeature
+}
+
+// MissingPhotoMatch 不同案件间视觉相同的照片（感知哈希相近）
+type MissingPhotoMatch struct {
+	BaseEntity
+	PhotoID         string `gorm:"type:uuid;not null;index" json:"photo_id"`
+	MissingPersonID string `gorm:"type:uuid;not null;index" json:"missing_person_id"`
+	MatchedPhotoID  string `gorm:"type:uuid;not null;index" json:"matched_photo_id"`
+	MatchedPersonID string `gorm:"type:uuid;not null;index" json:"matched_person_id"`
+	Distance        int    `gorm:"not null" json:"distance"` // 感知哈希汉明距离
+
+	Photo         *MissingPhoto  `gorm:"foreignKey:PhotoID" json:"photo,omitempty"`
+	MatchedPhoto  *MissingPhoto  `gorm:"foreignKey:MatchedPhotoID" json:"matched_photo,omitempty"`
+	MatchedPerson *MissingPerson `gorm:"foreignKey:MatchedPersonID" json:"matched_person,omitempty"`
+}
+
+// TableName 表名
+func (MissingPhotoMatch) TableName() string {
+	return "ty_missing_photo_matches"
+}
+
 // MissingPersonStats 走失人员统计
 type MissingPersonStats struct {
 	Total        int64 `json:"total"`
@@ -239,6 +465,13 @@ type MissingPersonStats struct {
 	ThisMonthNew int64 `json:"this_month_new"`
 }
 
+// AssignCaseNo 未设置案件编号时生成编号
+func (m *MissingPerson) AssignCaseNo() {
+	if m.CaseNo == "" {
+		m.CaseNo = generateCaseNo()
+	}
+}
+
 // generateCaseNo 生成案件编号 (格式: CASE-YYYYMMDD-XXXX)
 func generateCaseNo() string {
 	now := time.Now()
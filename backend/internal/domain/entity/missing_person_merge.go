@@ -0,0 +1,40 @@
+package entity
+
+// MissingPersonMerge 重复案件合并记录
+type MissingPersonMerge struct {
+	BaseEntity
+	SurvivorID     string  `gorm:"type:uuid;not null;index" json:"survivor_id"`
+	SurvivorCaseNo string  `gorm:"size:50" json:"survivor_case_no"`
+	MergedID       string  `gorm:"type:uuid;not null;index" json:"merged_id"`
+	MergedCaseNo   string  `gorm:"size:50" json:"merged_case_no"`
+	OperatorID     string  `gorm:"type:uuid;not null" json:"operator_id"`
+	OrgID          string  `gorm:"type:uuid;index" json:"org_id"`
+	Score          float64 `json:"score"`
+	Reason         string  `gorm:"type:text" json:"reason,omitempty"`
+
+	// 迁移的关联数据数量
+	TrackCount int64 `gorm:"default:0" json:"track_count"`
+	PhotoCount int64 `gorm:"default:0" json:"photo_count"`
+	TaskCount  int64 `gorm:"default:0" json:"task_count"`
+	FileCount  int64 `gorm:"default:0" json:"file_count"`
+
+	Operator *User `gorm:"foreignKey:OperatorID" json:"operator,omitempty"`
+}
+
+// TableName 表名
+func (MissingPersonMerge) TableName() string {
+	return "ty_missing_person_merges"
+}
+
+// NewMissingPersonMerge 创建合并记录
+func NewMissingPersonMerge(survivor, merged *MissingPerson, operatorID, orgID, reason string) *MissingPersonMerge {
+	return &MissingPersonMerge{
+		SurvivorID:     survivor.ID,
+		SurvivorCaseNo: survivor.CaseNo,
+		MergedID:       merged.ID,
+		MergedCaseNo:   merged.CaseNo,
+		OperatorID:     operatorID,
+		OrgID:          orgID,
+		Reason:         reason,
+	}
+}
@@ -0,0 +1,136 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrCaseTransitionNotAllowed     = errors.New("case status transition not allowed")
+	ErrCaseTransitionReasonRequired = errors.New("case status transition requires a reason")
+	ErrCaseMerged                   = errors.New("case has been merged into another case")
+)
+
+// 案件状态事件
+const (
+	CaseEventStartSearch = "start_search" // 开始搜索
+	CaseEventMarkFound   = "mark_found"   // 标记找到
+	CaseEventReunite     = "reunite"      // 标记团聚
+	CaseEventClose       = "close"        // 关闭案件
+	CaseEventReopen      = "reopen"       // 重新打开
+	CaseEventMerge       = "merge"        // 作为重复案件被合并（不经过状态机，仅记录）
+)
+
+// CaseTransition 案件状态转换
+type CaseTransition struct {
+	From          MissingStatus
+	To            MissingStatus
+	Event         string
+	RequireReason bool
+}
+
+// GetCaseTransitions 获取案件状态转换规则
+func GetCaseTransitions() []CaseTransition {
+	return []CaseTransition{
+		{MissingStatusMissing, MissingStatusSearching, CaseEventStartSearch, false},
+		{MissingStatusMissing, MissingStatusFound, CaseEventMarkFound, false},
+		{MissingStatusSearching, MissingStatusFound, CaseEventMarkFound, false},
+		{MissingStatusFound, MissingStatusReunited, CaseEventReunite, false},
+		{MissingStatusMissing, MissingStatusClosed, CaseEventClose, true},
+		{MissingStatusSearching, MissingStatusClosed, CaseEventClose, true},
+		{MissingStatusFound, MissingStatusClosed, CaseEventClose, true},
+		{MissingStatusReunited, MissingStatusClosed, CaseEventClose, true},
+		// 找到信息有误时恢复搜索；已关闭的案件重新登记为待寻找
+		{MissingStatusFound, MissingStatusSearching, CaseEventReopen, true},
+		{MissingStatusClosed, MissingStatusMissing, CaseEventReopen, true},
+	}
+}
+
+// FindCaseTransition 查找当前状态下事件对应的转换
+func FindCaseTransition(from MissingStatus, event string) (CaseTransition, bool) {
+	for _, t := range GetCaseTransitions() {
+		if t.From == from && t.Event == event {
+			return t, true
+		}
+	}
+	return CaseTransition{}, false
+}
+
+// FindCaseTransitionTo 查找当前状态到目标状态的转换
+func FindCaseTransitionTo(from, to MissingStatus) (CaseTransition, bool) {
+	for _, t := range GetCaseTransitions() {
+		if t.From == from && t.To == to {
+			return t, true
+		}
+	}
+	return CaseTransition{}, false
+}
+
+// AvailableCaseTransitions 当前状态下可执行的转换
+func AvailableCaseTransitions(from MissingStatus) []CaseTransition {
+	var list []CaseTransition
+	for _, t := range GetCaseTransitions() {
+		if t.From == from {
+			list = append(list, t)
+		}
+	}
+	return list
+}
+
+// Transition 按状态机执行状态转换，返回状态变更记录；已合并的重复案件不再允许任何转换
+func (m *MissingPerson) Transition(event, userID, reason string) (*MissingPersonStatusLog, error) {
+	if m.IsMerged() {
+		return nil, fmt.Errorf("%w: 请在保留案件上操作", ErrCaseMerged)
+	}
+	t, ok := FindCaseTransition(m.Status, event)
+	if !ok {
+		return nil, fmt.Errorf("%w: 当前状态 %s 不能执行 %s", ErrCaseTransitionNotAllowed, m.Status, event)
+	}
+	reason = strings.TrimSpace(reason)
+	if t.RequireReason && reason == "" {
+		return nil, fmt.Errorf("%w: %s 必须填写原因", ErrCaseTransitionReasonRequired, event)
+	}
+
+	log := &MissingPersonStatusLog{
+		MissingPersonID: m.ID,
+		UserID:          userID,
+		Event:           event,
+		FromStatus:      m.Status,
+		ToStatus:        t.To,
+		Reason:          reason,
+	}
+	m.Status = t.To
+	m.StatusReason = reason
+	return log, nil
+}
+
+// MissingPersonStatusLog 案件状态变更记录
+type MissingPersonStatusLog struct {
+	BaseEntity
+	MissingPersonID string        `gorm:"type:uuid;not null;index" json:"missing_person_id"`
+	UserID          string        `gorm:"type:uuid;not null" json:"user_id"`
+	Event           string        `gorm:"size:30;not null" json:"event"`
+	FromStatus      MissingStatus `gorm:"size:20;not null" json:"from_status"`
+	ToStatus        MissingStatus `gorm:"size:20;not null" json:"to_status"`
+	Reason          string        `gorm:"type:text" json:"reason,omitempty"`
+
+	User *User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+// TableName 表名
+func (MissingPersonStatusLog) TableName() string {
+	return "ty_missing_person_status_logs"
+}
+
+// NewMergeStatusLog 被合并案件的状态变更记录
+func NewMergeStatusLog(merged *MissingPerson, from MissingStatus, userID, reason string) *MissingPersonStatusLog {
+	return &MissingPersonStatusLog{
+		MissingPersonID: merged.ID,
+		UserID:          userID,
+		Event:           CaseEventMerge,
+		FromStatus:      from,
+		ToStatus:        merged.Status,
+		Reason:          reason,
+	}
+}
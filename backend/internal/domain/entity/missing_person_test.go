@@ -0,0 +1,130 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMissingPersonTrack_VerifyLifecycle(t *testing.T) {
+	track := &MissingPersonTrack{Status: TrackStatusPending}
+
+	assert.NoError(t, track.StartVerify("u1"))
+	assert.Equal(t, TrackStatusVerifying, track.Status)
+	assert.Error(t, track.StartVerify("u1"))
+
+	assert.NoError(t, track.Confirm("u2", "现场照片一致"))
+	assert.Equal(t, TrackStatusConfirmed, track.Status)
+	assert.Equal(t, "u2", *track.VerifierID)
+	assert.NotNil(t, track.VerifiedAt)
+
+	assert.Error(t, track.Reject("u2", "误报"))
+	assert.Error(t, track.Confirm("u2", ""))
+}
+
+func TestMissingPersonTrack_RejectRequiresReason(t *testing.T) {
+	track := &MissingPersonTrack{Status: TrackStatusPending}
+
+	assert.Error(t, track.Reject("u1", "  "))
+	assert.Equal(t, TrackStatusPending, track.Status)
+
+	assert.NoError(t, track.Reject("u1", "描述与案件不符"))
+	assert.Equal(t, TrackStatusRejected, track.Status)
+	assert.Equal(t, "描述与案件不符", track.StatusReason)
+}
+
+func TestMissingPerson_RaiseUrgency(t *testing.T) {
+	mp := &MissingPerson{Urgency: UrgencyLevelLow}
+
+	assert.True(t, mp.RaiseUrgency())
+	assert.Equal(t, UrgencyLevelMedium, mp.Urgency)
+	assert.True(t, mp.RaiseUrgency())
+	assert.True(t, mp.RaiseUrgency())
+	assert.Equal(t, UrgencyLevelCritical, mp.Urgency)
+	assert.False(t, mp.RaiseUrgency())
+}
+
+func TestMissingPerson_StatusTransitions(t *testing.T) {
+	mp := &MissingPerson{BaseEntity: BaseEntity{ID: "mp1"}, Status: MissingStatusMissing}
+
+	_, err := mp.MarkReunited("u1")
+	assert.ErrorIs(t, err, ErrCaseTransitionNotAllowed)
+	assert.Equal(t, MissingStatusMissing, mp.Status)
+
+	log, err := mp.StartSearch("u1")
+	assert.NoError(t, err)
+	assert.Equal(t, MissingStatusMissing, log.FromStatus)
+	assert.Equal(t, MissingStatusSearching, log.ToStatus)
+	assert.Equal(t, "mp1", log.MissingPersonID)
+
+	_, err = mp.MarkFound("u1", "火车站", "民警发现")
+	assert.NoError(t, err)
+	assert.Equal(t, MissingStatusFound, mp.Status)
+	assert.NotNil(t, mp.FoundTime)
+
+	_, err = mp.Close("u1", " ")
+	assert.ErrorIs(t, err, ErrCaseTransitionReasonRequired)
+	assert.Equal(t, MissingStatusFound, mp.Status)
+}
+
+func TestMissingPerson_Reopen(t *testing.T) {
+	mp := &MissingPerson{Status: MissingStatusSearching}
+	_, err := mp.Reopen("u1", "信息有误")
+	assert.ErrorIs(t, err, ErrCaseTransitionNotAllowed)
+
+	_, err = mp.MarkFound("u1", "火车站", "民警发现")
+	assert.NoError(t, err)
+	_, err = mp.Reopen("u1", "")
+	assert.ErrorIs(t, err, ErrCaseTransitionReasonRequired)
+
+	log, err := mp.Reopen("u1", "认错人")
+	assert.NoError(t, err)
+	assert.Equal(t, MissingStatusSearching, mp.Status)
+	assert.Equal(t, CaseEventReopen, log.Event)
+	assert.Equal(t, "认错人", mp.StatusReason)
+	assert.Nil(t, mp.FoundTime)
+	assert.Empty(t, mp.FoundLocation)
+
+	_, err = mp.Close("u1", "家属撤案")
+	assert.NoError(t, err)
+	_, err = mp.Reopen("u1", "家属重新报案")
+	assert.NoError(t, err)
+	assert.Equal(t, MissingStatusMissing, mp.Status)
+}
+
+func TestMissingPerson_MergedRejectsTransitions(t *testing.T) {
+	survivor := &MissingPerson{BaseEntity: BaseEntity{ID: "mp1"}, CaseNo: "TY001", Status: MissingStatusSearching}
+	dup := &MissingPerson{BaseEntity: BaseEntity{ID: "mp2"}, Status: MissingStatusSearching}
+	assert.NoError(t, dup.MergeInto(survivor))
+	assert.Equal(t, MissingStatusClosed, dup.Status)
+
+	_, err := dup.Reopen("u1", "家属重新报案")
+	assert.ErrorIs(t, err, ErrCaseMerged)
+	_, err = dup.Close("u1", "重复")
+	assert.ErrorIs(t, err, ErrCaseMerged)
+	assert.Equal(t, MissingStatusClosed, dup.Status)
+	assert.Equal(t, "mp1", *dup.MergedIntoID)
+}
+
+func TestMissingPerson_ApplyUrgency(t *testing.T) {
+	now := time.Now()
+	mp := &MissingPerson{Urgency: UrgencyLevelMedium}
+
+	assert.False(t, mp.ApplyUrgency(UrgencyLevelHigh, 50, now))
+	assert.Equal(t, UrgencyLevelHigh, mp.Urgency)
+	assert.Equal(t, 50, mp.UrgencyScore)
+
+	assert.True(t, mp.ApplyUrgency(UrgencyLevelCritical, 80, now))
+	assert.NotNil(t, mp.UrgencyEscalatedAt)
+	assert.False(t, mp.ApplyUrgency(UrgencyLevelCritical, 85, now), "already escalated")
+
+	assert.False(t, mp.ApplyUrgency(UrgencyLevelHigh, 60, now))
+	assert.Nil(t, mp.UrgencyEscalatedAt)
+	assert.True(t, mp.ApplyUrgency(UrgencyLevelCritical, 75, now), "escalates again after downgrade")
+
+	manual := &MissingPerson{Urgency: UrgencyLevelLow, UrgencyManual: true}
+	assert.False(t, manual.ApplyUrgency(UrgencyLevelCritical, 90, now))
+	assert.Equal(t, UrgencyLevelLow, manual.Urgency)
+	assert.Equal(t, 90, manual.UrgencyScore)
+}
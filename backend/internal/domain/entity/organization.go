@@ -38,6 +38,8 @@ type Organization struct {
 	ParentID     *string        `gorm:"type:uuid;index" json:"parent_id,omitempty"`
 	Description  string         `gorm:"type:text" json:"description,omitempty"`
 	Address      string         `gorm:"size:255" json:"address,omitempty"`
+	Lat          float64        `json:"lat,omitempty"`
+	Lng          float64        `json:"lng,omitempty"`
 	ContactName  string         `gorm:"size:50" json:"contact_name,omitempty"`
 	ContactPhone string         `gorm:"size:20" json:"contact_phone,omitempty"`
 	Status       OrgStatus      `gorm:"size:20;not null;default:'active'" json:"status"`
@@ -12,16 +12,18 @@ type PermissionResource string
 
 // 系统资源常量定义
 const (
-	ResourceUser          PermissionResource = "user"
-	ResourceOrganization  PermissionResource = "organization"
-	ResourceTask          PermissionResource = "task"
-	ResourceMissingPerson PermissionResource = "missing_person"
-	ResourceDialect       PermissionResource = "dialect"
-	ResourceFile          PermissionResource = "file"
-	ResourceWorkflow      PermissionResource = "workflow"
-	ResourceAuditLog      PermissionResource = "audit_log"
-	ResourceDashboard     PermissionResource = "dashboard"
-	ResourceSystem        PermissionResource = "system"
+	ResourceUser           PermissionResource = "user"
+	ResourceOrganization   PermissionResource = "organization"
+	ResourceTask           PermissionResource = "task"
+	ResourceMissingPerson  PermissionResource = "missing_person"
+	ResourceDialect        PermissionResource = "dialect"
+	ResourceFile           PermissionResource = "file"
+	ResourceWorkflow       PermissionResource = "workflow"
+	ResourceAuditLog       PermissionResource = "audit_log"
+	ResourceDashboard      PermissionResource = "dashboard"
+	ResourceSystem         PermissionResource = "system"
+	ResourceDNASample      PermissionResource = "dna_sample"
+	ResourceFamilySearcher PermissionResource = "family_searcher"
 )
 
 // PermissionAction 权限操作类型
@@ -330,6 +332,8 @@ func GenerateSystemPermissions() []*Permission {
 		ResourceAuditLog,
 		ResourceDashboard,
 		ResourceSystem,
+		ResourceDNASample,
+		ResourceFamilySearcher,
 	}
 	
 	actions := []PermissionAction{
@@ -408,6 +412,7 @@ func GenerateSystemRoles(orgID string) []*RoleEntity {
 var SensitiveFields = map[string][]string{
 	"user":          {"password", "id_card", "phone", "email"},
 	"missing_person": {"id_card", "contact_phone"},
+	"dna_sample":     {"sample_no", "donor_name", "donor_phone", "lab_case_no", "result", "result_ref"},
 }
 
 // IsResourceSensitiveField 检查是否是敏感字段（按资源）
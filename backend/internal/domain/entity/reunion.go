@@ -0,0 +1,242 @@
+package entity
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrFollowUpAlreadyCompleted = errors.New("follow-up already completed")
+	ErrFollowUpInvalidOutcome   = errors.New("invalid follow-up outcome")
+)
+
+// ReunionVerification 身份确认方式
+type ReunionVerification string
+
+const (
+	ReunionVerificationDNA       ReunionVerification = "dna"                // DNA 比对
+	ReunionVerificationDocuments ReunionVerification = "documents"          // 证件、户籍资料
+	ReunionVerificationFamily    ReunionVerification = "family_recognition" // 家属辨认
+	ReunionVerificationOther     ReunionVerification = "other"              // 其他
+)
+
+// IsValidReunionVerification 是否为有效的身份确认方式
+func IsValidReunionVerification(v ReunionVerification) bool {
+	switch v {
+	case ReunionVerificationDNA, ReunionVerificationDocuments, ReunionVerificationFamily, ReunionVerificationOther:
+		return true
+	default:
+		return false
+	}
+}
+
+// FollowUpMonths 团聚后回访节点（月）
+var FollowUpMonths = []int{1, 3, 12}
+
+// ReunionRecord 团聚记录
+type ReunionRecord struct {
+	BaseEntity
+	MissingPersonID string `gorm:"type:uuid;uniqueIndex;not null" json:"missing_person_id"`
+	RecordedByID    string `gorm:"type:uuid;not null" json:"recorded_by_id"`
+
+	// 身份确认：确认人为系统用户，ConfirmerName 记录外部确认人（如派出所民警）
+	ConfirmedByID       string `gorm:"type:uuid;not null;index" json:"confirmed_by_id"`
+	ConfirmerName       string `gorm:"size:100" json:"confirmer_name,omitempty"`
+	VerificationMethods string `gorm:"size:100;not null" json:"verification_methods"` // 逗号分隔
+	VerificationNote    string `gorm:"type:text" json:"verification_note,omitempty"`
+
+	// 团聚时间地点
+	ReunionTime time.Time `gorm:"not null" json:"reunion_time"`
+	Location    string    `gorm:"size:200" json:"location,omitempty"`
+	Province    string    `gorm:"size:50" json:"province,omitempty"`
+	City        string    `gorm:"size:50" json:"city,omitempty"`
+	District    string    `gorm:"size:50" json:"district,omitempty"`
+	Address     string    `gorm:"size:200" json:"address,omitempty"`
+	Lat         float64   `json:"lat,omitempty"`
+	Lng         float64   `json:"lng,omitempty"`
+
+	// 宣传授权：未授权时不得用于公开报道
+	PublicityConsent bool   `gorm:"not null;default:false" json:"publicity_consent"`
+	ConsentBy        string `gorm:"size:100" json:"consent_by,omitempty"`
+
+	Photos string `gorm:"type:text" json:"photos,omitempty"` // 照片 URL 列表（JSON 数组）
+	Note   string `gorm:"type:text" json:"note,omitempty"`
+
+	ConfirmedBy *User             `gorm:"foreignKey:ConfirmedByID" json:"confirmed_by,omitempty"`
+	FollowUps   []ReunionFollowUp `gorm:"foreignKey:ReunionID" json:"follow_ups,omitempty"`
+}
+
+// TableName 表名
+func (ReunionRecord) TableName() string {
+	return "ty_reunion_records"
+}
+
+// SetVerificationMethods 设置身份确认方式（去重）
+func (r *ReunionRecord) SetVerificationMethods(methods []string) error {
+	var list []string
+	seen := make(map[string]bool)
+	for _, m := range methods {
+		m = strings.TrimSpace(m)
+		if m == "" || seen[m] {
+			continue
+		}
+		if !IsValidReunionVerification(ReunionVerification(m)) {
+			return fmt.Errorf("无效的身份确认方式: %s", m)
+		}
+		seen[m] = true
+		list = append(list, m)
+	}
+	if len(list) == 0 {
+		return errors.New("至少需要一种身份确认方式")
+	}
+	r.VerificationMethods = strings.Join(list, ",")
+	return nil
+}
+
+// GetVerificationMethods 获取身份确认方式
+func (r *ReunionRecord) GetVerificationMethods() []string {
+	if r.VerificationMethods == "" {
+		return nil
+	}
+	return strings.Split(r.VerificationMethods, ",")
+}
+
+// SetPhotos 设置照片 URL 列表
+func (r *ReunionRecord) SetPhotos(urls []string) {
+	if len(urls) == 0 {
+		r.Photos = ""
+		return
+	}
+	data, _ := json.Marshal(urls)
+	r.Photos = string(data)
+}
+
+// GetPhotos 获取照片 URL 列表
+func (r *ReunionRecord) GetPhotos() []string {
+	if r.Photos == "" {
+		return nil
+	}
+	var urls []string
+	_ = json.Unmarshal([]byte(r.Photos), &urls)
+	return urls
+}
+
+// Validate 验证团聚记录
+func (r *ReunionRecord) Validate() error {
+	if r.ConfirmedByID == "" {
+		return errors.New("身份确认人不能为空")
+	}
+	if r.VerificationMethods == "" {
+		return errors.New("至少需要一种身份确认方式")
+	}
+	if r.ReunionTime.IsZero() {
+		return errors.New("团聚时间不能为空")
+	}
+	if r.ReunionTime.After(time.Now().Add(time.Hour)) {
+		return errors.New("团聚时间不能晚于当前时间")
+	}
+	if r.PublicityConsent && strings.TrimSpace(r.ConsentBy) == "" {
+		return errors.New("同意宣传时必须填写授权人")
+	}
+	return nil
+}
+
+// ScheduleFollowUps 按回访节点生成回访计划
+func (r *ReunionRecord) ScheduleFollowUps() {
+	r.FollowUps = make([]ReunionFollowUp, len(FollowUpMonths))
+	for i, months := range FollowUpMonths {
+		r.FollowUps[i] = ReunionFollowUp{
+			MissingPersonID: r.MissingPersonID,
+			Month:           months,
+			DueAt:           r.ReunionTime.AddDate(0, months, 0),
+			Status:          FollowUpStatusScheduled,
+		}
+	}
+}
+
+// FollowUpStatus 回访状态
+type FollowUpStatus string
+
+const (
+	FollowUpStatusScheduled FollowUpStatus = "scheduled" // 待生成任务
+	FollowUpStatusTasked    FollowUpStatus = "tasked"    // 已生成回访任务
+	FollowUpStatusCompleted FollowUpStatus = "completed" // 已回访
+)
+
+// FollowUpOutcome 回访结果
+type FollowUpOutcome string
+
+const (
+	FollowUpOutcomeStable       FollowUpOutcome = "stable"        // 生活稳定
+	FollowUpOutcomeNeedsSupport FollowUpOutcome = "needs_support" // 需要帮扶
+	FollowUpOutcomeMissingAgain FollowUpOutcome = "missing_again" // 再次走失
+	FollowUpOutcomeUnreachable  FollowUpOutcome = "unreachable"   // 无法联系
+)
+
+// IsValidFollowUpOutcome 是否为有效的回访结果
+func IsValidFollowUpOutcome(o FollowUpOutcome) bool {
+	switch o {
+	case FollowUpOutcomeStable, FollowUpOutcomeNeedsSupport, FollowUpOutcomeMissingAgain, FollowUpOutcomeUnreachable:
+		return true
+	default:
+		return false
+	}
+}
+
+// ReunionFollowUp 团聚后回访
+type ReunionFollowUp struct {
+	BaseEntity
+	ReunionID       string          `gorm:"type:uuid;not null;index" json:"reunion_id"`
+	MissingPersonID string          `gorm:"type:uuid;not null;index" json:"missing_person_id"`
+	Month           int             `gorm:"not null" json:"month"`
+	DueAt           time.Time       `gorm:"not null;index" json:"due_at"`
+	Status          FollowUpStatus  `gorm:"size:20;not null;default:'scheduled';index" json:"status"`
+	TaskID          *string         `gorm:"type:uuid" json:"task_id,omitempty"`
+	Outcome         FollowUpOutcome `gorm:"size:20" json:"outcome,omitempty"`
+	Note            string          `gorm:"type:text" json:"note,omitempty"`
+	VisitorID       *string         `gorm:"type:uuid" json:"visitor_id,omitempty"`
+	VisitedAt       *time.Time      `json:"visited_at,omitempty"`
+
+	Reunion *ReunionRecord `gorm:"foreignKey:ReunionID" json:"-"`
+}
+
+// TableName 表名
+func (ReunionFollowUp) TableName() string {
+	return "ty_reunion_follow_ups"
+}
+
+// IsOverdue 是否已过回访期限仍未回访
+func (f *ReunionFollowUp) IsOverdue(now time.Time) bool {
+	return f.Status != FollowUpStatusCompleted && now.After(f.DueAt)
+}
+
+// Complete 记录回访结果
+func (f *ReunionFollowUp) Complete(outcome FollowUpOutcome, note, visitorID string, visitedAt time.Time) error {
+	if f.Status == FollowUpStatusCompleted {
+		return ErrFollowUpAlreadyCompleted
+	}
+	if !IsValidFollowUpOutcome(outcome) {
+		return fmt.Errorf("%w: %s", ErrFollowUpInvalidOutcome, outcome)
+	}
+	if visitedAt.IsZero() {
+		visitedAt = time.Now()
+	}
+	f.Status = FollowUpStatusCompleted
+	f.Outcome = outcome
+	f.Note = note
+	f.VisitorID = &visitorID
+	f.VisitedAt = &visitedAt
+	return nil
+}
+
+// FollowUpStat 回访统计（按回访节点、状态、结果分组）
+type FollowUpStat struct {
+	Month   int             `json:"month"`
+	Status  FollowUpStatus  `json:"status"`
+	Outcome FollowUpOutcome `json:"outcome"`
+	Overdue bool            `json:"overdue"`
+	Count   int64           `json:"count"`
+}
@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReunionRecord(t *testing.T) {
+	reunionTime := time.Date(2026, 1, 31, 10, 0, 0, 0, time.Local)
+	record := &ReunionRecord{MissingPersonID: "mp1", ConfirmedByID: "u1", ReunionTime: reunionTime}
+
+	assert.Error(t, record.SetVerificationMethods([]string{"dna", "palm_reading"}))
+	assert.Error(t, record.SetVerificationMethods([]string{" "}))
+	assert.NoError(t, record.SetVerificationMethods([]string{"dna", "family_recognition", "dna"}))
+	assert.Equal(t, []string{"dna", "family_recognition"}, record.GetVerificationMethods())
+
+	record.SetPhotos([]string{"/a.jpg", "/b.jpg"})
+	assert.Equal(t, []string{"/a.jpg", "/b.jpg"}, record.GetPhotos())
+	record.SetPhotos(nil)
+	assert.Nil(t, record.GetPhotos())
+
+	assert.NoError(t, record.Validate())
+	record.PublicityConsent = true
+	assert.Error(t, record.Validate(), "consent requires who gave it")
+	record.ConsentBy = "张三（父亲）"
+	assert.NoError(t, record.Validate())
+
+	record.ScheduleFollowUps()
+	assert.Len(t, record.FollowUps, 3)
+	assert.Equal(t, 1, record.FollowUps[0].Month)
+	assert.Equal(t, reunionTime.AddDate(0, 1, 0), record.FollowUps[0].DueAt)
+	assert.Equal(t, reunionTime.AddDate(1, 0, 0), record.FollowUps[2].DueAt)
+	assert.Equal(t, FollowUpStatusScheduled, record.FollowUps[2].Status)
+	assert.Equal(t, "mp1", record.FollowUps[2].MissingPersonID)
+}
+
+func TestReunionFollowUp_Complete(t *testing.T) {
+	now := time.Now()
+	f := &ReunionFollowUp{Month: 1, DueAt: now.Add(-time.Hour), Status: FollowUpStatusTasked}
+	assert.True(t, f.IsOverdue(now))
+
+	assert.ErrorIs(t, f.Complete("fine", "", "u1", now), ErrFollowUpInvalidOutcome)
+	assert.NoError(t, f.Complete(FollowUpOutcomeNeedsSupport, "需要低保申请协助", "u1", time.Time{}))
+	assert.Equal(t, FollowUpStatusCompleted, f.Status)
+	assert.NotNil(t, f.VisitedAt)
+	assert.False(t, f.IsOverdue(now))
+
+	assert.ErrorIs(t, f.Complete(FollowUpOutcomeStable, "", "u1", now), ErrFollowUpAlreadyCompleted)
+}
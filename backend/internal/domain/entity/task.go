@@ -71,6 +71,13 @@ type Task struct {
 	Lat      float64 `json:"lat,omitempty"`
 	Lng      float64 `json:"lng,omitempty"`
 
+	// 派单
+	Skills         string             `gorm:"size:255" json:"skills,omitempty"`    // 所需技能，逗号分隔
+	Languages      string             `gorm:"size:255" json:"languages,omitempty"` // 所需语言或方言，逗号分隔
+	DispatchStatus TaskDispatchStatus `gorm:"size:20;index" json:"dispatch_status,omitempty"`
+	DispatchRound  int                `gorm:"default:0" json:"dispatch_round,omitempty"`
+	DispatchedAt   *time.Time         `json:"dispatched_at,omitempty"` // 最近一轮推送时间
+
 	// 结果
 	Result       string `gorm:"type:text" json:"result,omitempty"`
 	ResultPhotos string `gorm:"type:json" json:"result_photos,omitempty"`
@@ -148,6 +155,31 @@ func (t *Task) Assign(assigneeID string) error {
 	return nil
 }
 
+// CanDispatch 是否可以自动派单：只有待分配的任务
+func (t *Task) CanDispatch() bool {
+	return t.Status == TaskStatusPending
+}
+
+// GetSkills 所需技能
+func (t *Task) GetSkills() []string {
+	return splitTags(t.Skills)
+}
+
+// SetSkills 设置所需技能
+func (t *Task) SetSkills(skills []string) {
+	t.Skills = joinTags(skills)
+}
+
+// GetLanguages 所需语言或方言
+func (t *Task) GetLanguages() []string {
+	return splitTags(t.Languages)
+}
+
+// SetLanguages 设置所需语言或方言
+func (t *Task) SetLanguages(languages []string) {
+	t.Languages = joinTags(languages)
+}
+
 // Start 开始任务
 func (t *Task) Start() error {
 	if !t.CanStart() {
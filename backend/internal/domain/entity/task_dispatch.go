@@ -0,0 +1,255 @@
+package entity
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/pkg/geo"
+)
+
+var (
+	ErrTaskOfferClosed = errors.New("派单邀请已失效")
+)
+
+// TaskDispatchStatus 任务自动派单状态
+type TaskDispatchStatus string
+
+const (
+	TaskDispatchNone      TaskDispatchStatus = ""          // 尚未派单
+	TaskDispatchOffering  TaskDispatchStatus = "offering"  // 已推送给候选志愿者，等待响应
+	TaskDispatchAccepted  TaskDispatchStatus = "accepted"  // 志愿者已接单
+	TaskDispatchExhausted TaskDispatchStatus = "exhausted" // 多轮推送无人接单，需人工分配
+)
+
+// TaskOfferStatus 派单邀请状态
+type TaskOfferStatus string
+
+const (
+	TaskOfferPending   TaskOfferStatus = "pending"   // 等待响应
+	TaskOfferAccepted  TaskOfferStatus = "accepted"  // 已接单
+	TaskOfferDeclined  TaskOfferStatus = "declined"  // 已拒绝
+	TaskOfferExpired   TaskOfferStatus = "expired"   // 超时未响应
+	TaskOfferWithdrawn TaskOfferStatus = "withdrawn" // 任务已被他人接单或已人工分配
+)
+
+// 派单 WebSocket 消息类型
+const (
+	WSMessageTypeTaskOffer        = "task_offer"         // 下行：派单邀请
+	WSMessageTypeTaskOfferClosed  = "task_offer_closed"  // 下行：邀请已失效
+	WSMessageTypeTaskOfferAccept  = "task_offer_accept"  // 上行：接单，data 为 {"offer_id"}
+	WSMessageTypeTaskOfferDecline = "task_offer_decline" // 上行：拒绝，data 为 {"offer_id", "reason"}
+	WSMessageTypeTaskOfferResult  = "task_offer_result"  // 下行：接单或拒绝的处理结果
+)
+
+// TaskOffer 派单邀请：自动派单每轮向排名靠前的几位志愿者推送，先接单者获得任务
+type TaskOffer struct {
+	BaseEntity
+	TaskID      string          `gorm:"type:uuid;not null;index" json:"task_id"`
+	OrgID       string          `gorm:"type:uuid;not null;index" json:"org_id"`
+	UserID      string          `gorm:"type:uuid;not null;index:idx_task_offer_user" json:"user_id"`
+	Round       int             `gorm:"not null;default:1" json:"round"`
+	Position    int             `gorm:"not null;default:1" json:"position"` // 本轮排名
+	Score       float64         `gorm:"default:0" json:"score"`
+	DistanceKm  *float64        `json:"distance_km,omitempty"`
+	Status      TaskOfferStatus `gorm:"size:20;not null;default:'pending';index:idx_task_offer_user" json:"status"`
+	ExpiresAt   time.Time       `gorm:"not null;index" json:"expires_at"`
+	RespondedAt *time.Time      `json:"responded_at,omitempty"`
+	Reason      string          `gorm:"size:200" json:"reason,omitempty"` // 拒绝原因
+
+	Task *Task `gorm:"foreignKey:TaskID" json:"task,omitempty"`
+	User *User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+// TableName 表名
+func (TaskOffer) TableName() string {
+	return "ty_task_offers"
+}
+
+// NewTaskOffer 根据排序结果创建派单邀请
+func NewTaskOffer(task *Task, match *DispatchMatch, round, position int, expiresAt time.Time) *TaskOffer {
+	o := &TaskOffer{
+		TaskID:    task.ID,
+		OrgID:     task.OrgID,
+		UserID:    match.Candidate.UserID,
+		Round:     round,
+		Position:  position,
+		Score:     match.Score,
+		Status:    TaskOfferPending,
+		ExpiresAt: expiresAt,
+	}
+	if match.DistanceKm >= 0 {
+		d := match.DistanceKm
+		o.DistanceKm = &d
+	}
+	return o
+}
+
+// IsOpen 是否仍可响应
+func (o *TaskOffer) IsOpen(now time.Time) bool {
+	return o.Status == TaskOfferPending && now.Before(o.ExpiresAt)
+}
+
+// Accept 接单
+func (o *TaskOffer) Accept(now time.Time) error {
+	if !o.IsOpen(now) {
+		return ErrTaskOfferClosed
+	}
+	o.Status = TaskOfferAccepted
+	o.RespondedAt = &now
+	return nil
+}
+
+// Decline 拒绝
+func (o *TaskOffer) Decline(reason string, now time.Time) error {
+	if !o.IsOpen(now) {
+		return ErrTaskOfferClosed
+	}
+	o.Status = TaskOfferDeclined
+	o.Reason = strings.TrimSpace(reason)
+	o.RespondedAt = &now
+	return nil
+}
+
+// 派单评分权重，满分 100
+const (
+	dispatchDistanceWeight = 40.0
+	dispatchSkillWeight    = 20.0
+	dispatchLanguageWeight = 20.0
+	dispatchLoadWeight     = 20.0
+
+	dispatchDistanceHalfKm     = 20.0 // 距离达到该值时距离得分减半
+	dispatchUnknownDistanceFit = 0.25 // 位置未知时的距离得分比例
+)
+
+// 候选人位置来源
+const (
+	DispatchLocationHome      = "home"       // 常住地
+	DispatchLocationLastKnown = "last_known" // 最近一次任务地点
+)
+
+// DispatchCandidate 派单候选志愿者。位置、技能、语言和档期由候选来源补充，未知时既不加分也不排除
+type DispatchCandidate struct {
+	UserID         string     `json:"user_id"`
+	Nickname       string     `json:"nickname"`
+	Lat            float64    `json:"lat,omitempty"`
+	Lng            float64    `json:"lng,omitempty"`
+	LocationSource string     `json:"location_source,omitempty"` // home / last_known
+	LocatedAt      *time.Time `json:"located_at,omitempty"`
+	Skills         []string   `json:"skills,omitempty"`
+	Languages      []string   `json:"languages,omitempty"`      // 会说的语言和方言
+	OpenTasks      int        `json:"open_tasks"`               // 在办任务数（已分配、进行中）
+	ServiceRadius  float64    `json:"service_radius,omitempty"` // 服务半径（公里），0 表示不限
+	Unavailable    string     `json:"unavailable,omitempty"`    // 不可用原因（如休假、不在值班时段），非空时排除
+}
+
+// DispatchOptions 派单排序参数
+type DispatchOptions struct {
+	MaxDistanceKm float64 // 超过该距离的志愿者不参与派单，0 表示不限
+	MaxOpenTasks  int     // 在办任务达到该数量的志愿者不参与派单，0 表示不限
+}
+
+// DispatchMatch 候选志愿者的排序结果
+type DispatchMatch struct {
+	Candidate     DispatchCandidate `json:"candidate"`
+	DistanceKm    float64           `json:"distance_km"` // -1 表示位置未知
+	SkillFit      float64           `json:"skill_fit"`   // 0~1，任务所需技能的覆盖比例
+	LanguageFit   float64           `json:"language_fit"`
+	Score         float64           `json:"score"` // 0~100
+	MissingSkills []string          `json:"missing_skills,omitempty"`
+}
+
+// RankDispatchCandidates 按距离、技能、语言和在办任务数为候选志愿者打分并从高到低排序。
+// 不可用、超出服务半径或最大距离、在办任务已满的志愿者直接排除；同分时在办任务少的在前
+func RankDispatchCandidates(task *Task, candidates []DispatchCandidate, opts DispatchOptions) []DispatchMatch {
+	skills := task.GetSkills()
+	languages := task.GetLanguages()
+	taskLocated := geo.ValidCoordinate(task.Lat, task.Lng)
+
+	matches := make([]DispatchMatch, 0, len(candidates))
+	for _, c := range candidates {
+		if c.Unavailable != "" {
+			continue
+		}
+		if opts.MaxOpenTasks > 0 && c.OpenTasks >= opts.MaxOpenTasks {
+			continue
+		}
+
+		m := DispatchMatch{Candidate: c, DistanceKm: -1}
+		distanceFit := dispatchUnknownDistanceFit
+		if taskLocated && geo.ValidCoordinate(c.Lat, c.Lng) {
+			m.DistanceKm = geo.Distance(task.Lat, task.Lng, c.Lat, c.Lng)
+			if opts.MaxDistanceKm > 0 && m.DistanceKm > opts.MaxDistanceKm {
+				continue
+			}
+			if c.ServiceRadius > 0 && m.DistanceKm > c.ServiceRadius {
+				continue
+			}
+			distanceFit = 1 / (1 + m.DistanceKm/dispatchDistanceHalfKm)
+		}
+
+		m.SkillFit, m.MissingSkills = tagCoverage(skills, c.Skills)
+		m.LanguageFit, _ = tagCoverage(languages, c.Languages)
+		loadFit := 1 / float64(1+c.OpenTasks)
+
+		m.Score = dispatchDistanceWeight*distanceFit +
+			dispatchSkillWeight*m.SkillFit +
+			dispatchLanguageWeight*m.LanguageFit +
+			dispatchLoadWeight*loadFit
+		matches = append(matches, m)
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		if matches[i].Candidate.OpenTasks != matches[j].Candidate.OpenTasks {
+			return matches[i].Candidate.OpenTasks < matches[j].Candidate.OpenTasks
+		}
+		return matches[i].Candidate.UserID < matches[j].Candidate.UserID
+	})
+	return matches
+}
+
+// tagCoverage 所需标签被覆盖的比例及缺少的标签，不区分大小写；没有要求时视为全部满足
+func tagCoverage(required, have []string) (float64, []string) {
+	if len(required) == 0 {
+		return 1, nil
+	}
+	set := make(map[string]bool, len(have))
+	for _, h := range have {
+		set[strings.ToLower(strings.TrimSpace(h))] = true
+	}
+	var missing []string
+	for _, r := range required {
+		if !set[strings.ToLower(r)] {
+			missing = append(missing, r)
+		}
+	}
+	return float64(len(required)-len(missing)) / float64(len(required)), missing
+}
+
+// splitTags 解析逗号分隔的标签，兼容中文逗号和顿号，去掉空白和重复项
+func splitTags(s string) []string {
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == '，' || r == '、'
+	})
+	tags := make([]string, 0, len(fields))
+	seen := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		f = strings.TrimSpace(f)
+		key := strings.ToLower(f)
+		if f == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		tags = append(tags, f)
+	}
+	return tags
+}
+
+// joinTags 标签列表存储为逗号分隔的字符串
+func joinTags(tags []string) string {
+	return strings.Join(splitTags(strings.Join(tags, ",")), ",")
+}
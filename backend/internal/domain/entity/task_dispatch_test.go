@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRankDispatchCandidates(t *testing.T) {
+	task := &Task{Lat: 39.9, Lng: 116.4}
+	task.SetSkills([]string{"驾驶", " 驾驶", ""})
+	task.SetLanguages([]string{"粤语"})
+	assert.Equal(t, "驾驶", task.Skills)
+
+	candidates := []DispatchCandidate{
+		{UserID: "near", Lat: 39.91, Lng: 116.41},
+		{UserID: "skilled", Lat: 40.0, Lng: 116.4, Skills: []string{"驾驶"}, Languages: []string{"粤语", "普通话"}, OpenTasks: 1},
+		{UserID: "unknown", Skills: []string{"驾驶"}},
+		{UserID: "far", Lat: 31.23, Lng: 121.47, Skills: []string{"驾驶"}, Languages: []string{"粤语"}},
+		{UserID: "busy", Lat: 39.9, Lng: 116.4, OpenTasks: 3},
+		{UserID: "vacation", Lat: 39.9, Lng: 116.4, Unavailable: "休假中"},
+		{UserID: "radius", Lat: 39.99, Lng: 116.4, ServiceRadius: 5},
+	}
+
+	matches := RankDispatchCandidates(task, candidates, DispatchOptions{MaxDistanceKm: 100, MaxOpenTasks: 3})
+	ids := make([]string, len(matches))
+	for i := range matches {
+		ids[i] = matches[i].Candidate.UserID
+	}
+	assert.Equal(t, []string{"skilled", "near", "unknown"}, ids)
+	assert.Equal(t, 1.0, matches[0].SkillFit)
+	assert.Equal(t, []string{"驾驶"}, matches[1].MissingSkills)
+	assert.Equal(t, -1.0, matches[2].DistanceKm, "unknown location is kept but scored low")
+	assert.InDelta(t, 11.1, matches[0].DistanceKm, 0.5)
+
+	offer := NewTaskOffer(task, &matches[2], 1, 3, time.Now().Add(time.Minute))
+	assert.Nil(t, offer.DistanceKm)
+	assert.Equal(t, TaskOfferPending, offer.Status)
+}
+
+func TestTaskOffer_Respond(t *testing.T) {
+	now := time.Now()
+	offer := &TaskOffer{Status: TaskOfferPending, ExpiresAt: now.Add(time.Minute)}
+	assert.NoError(t, offer.Decline(" 在外地 ", now))
+	assert.Equal(t, "在外地", offer.Reason)
+	assert.ErrorIs(t, offer.Accept(now), ErrTaskOfferClosed)
+
+	expired := &TaskOffer{Status: TaskOfferPending, ExpiresAt: now.Add(-time.Second)}
+	assert.ErrorIs(t, expired.Accept(now), ErrTaskOfferClosed)
+	assert.False(t, expired.IsOpen(now))
+}
@@ -0,0 +1,116 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+)
+
+// UrgencyRule 组织紧急程度评分规则
+// 未配置规则的组织沿用最近上级组织的规则，均未配置时使用 DefaultUrgencyRule
+type UrgencyRule struct {
+	BaseEntity
+	OrgID string `gorm:"type:uuid;uniqueIndex;not null" json:"org_id"`
+
+	// 年龄：儿童、老人加分
+	ChildMaxAge int `gorm:"not null" json:"child_max_age"`
+	ChildWeight int `gorm:"not null" json:"child_weight"`
+	ElderMinAge int `gorm:"not null" json:"elder_min_age"`
+	ElderWeight int `gorm:"not null" json:"elder_weight"`
+
+	// 体貌特征、描述中出现疾病或认知障碍关键词时加分，MedicalKeywords 为内置词表之外的补充（逗号分隔）
+	MedicalWeight   int    `gorm:"not null" json:"medical_weight"`
+	MedicalKeywords string `gorm:"type:text" json:"medical_keywords,omitempty"`
+
+	// 黄金搜救时间内全额加分，三倍时间内减半
+	GoldenHours  int `gorm:"not null" json:"golden_hours"`
+	GoldenWeight int `gorm:"not null" json:"golden_weight"`
+
+	// 严寒、酷暑季节加分，儿童、老人及病患全额，其他减半
+	SeasonWeight int `gorm:"not null" json:"season_weight"`
+
+	// 近期线索：窗口内每条未驳回的线索加分，不超过上限
+	SightingHours     int `gorm:"not null" json:"sighting_hours"`
+	SightingWeight    int `gorm:"not null" json:"sighting_weight"`
+	SightingMaxWeight int `gorm:"not null" json:"sighting_max_weight"`
+
+	// 等级阈值（总分）
+	CriticalScore int `gorm:"not null" json:"critical_score"`
+	HighScore     int `gorm:"not null" json:"high_score"`
+	MediumScore   int `gorm:"not null" json:"medium_score"`
+
+	// Escalate 案件升级为紧急时是否通知组织管理员
+	Escalate bool `gorm:"not null;default:true" json:"escalate"`
+}
+
+// TableName 表名
+func (UrgencyRule) TableName() string {
+	return "ty_urgency_rules"
+}
+
+// DefaultUrgencyRule 默认评分规则
+func DefaultUrgencyRule() *UrgencyRule {
+	return &UrgencyRule{
+		ChildMaxAge:       14,
+		ChildWeight:       35,
+		ElderMinAge:       65,
+		ElderWeight:       30,
+		MedicalWeight:     25,
+		GoldenHours:       72,
+		GoldenWeight:      20,
+		SeasonWeight:      10,
+		SightingHours:     72,
+		SightingWeight:    5,
+		SightingMaxWeight: 15,
+		CriticalScore:     70,
+		HighScore:         45,
+		MediumScore:       20,
+		Escalate:          true,
+	}
+}
+
+// Validate 验证评分规则
+func (r *UrgencyRule) Validate() error {
+	for _, v := range []int{
+		r.ChildMaxAge, r.ChildWeight, r.ElderMinAge, r.ElderWeight, r.MedicalWeight,
+		r.GoldenHours, r.GoldenWeight, r.SeasonWeight,
+		r.SightingHours, r.SightingWeight, r.SightingMaxWeight,
+	} {
+		if v < 0 {
+			return errors.New("评分规则参数不能为负数")
+		}
+	}
+	if r.ElderMinAge > 0 && r.ElderMinAge <= r.ChildMaxAge {
+		return errors.New("老人年龄下限必须大于儿童年龄上限")
+	}
+	if !(r.CriticalScore > r.HighScore && r.HighScore > r.MediumScore && r.MediumScore > 0) {
+		return errors.New("等级阈值必须满足 紧急 > 高 > 中 > 0")
+	}
+	return nil
+}
+
+// ExtraKeywords 补充的疾病、认知障碍关键词
+func (r *UrgencyRule) ExtraKeywords() []string {
+	var list []string
+	for _, kw := range strings.FieldsFunc(r.MedicalKeywords, func(c rune) bool {
+		return c == ',' || c == '，' || c == '、' || c == ';' || c == '；' || c == '\n'
+	}) {
+		if kw = strings.TrimSpace(kw); kw != "" {
+			list = append(list, kw)
+		}
+	}
+	return list
+}
+
+// Level 按总分计算紧急程度
+func (r *UrgencyRule) Level(score int) UrgencyLevel {
+	switch {
+	case score >= r.CriticalScore:
+		return UrgencyLevelCritical
+	case score >= r.HighScore:
+		return UrgencyLevelHigh
+	case score >= r.MediumScore:
+		return UrgencyLevelMedium
+	default:
+		return UrgencyLevelLow
+	}
+}
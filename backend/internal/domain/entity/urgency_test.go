@@ -0,0 +1,22 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUrgencyRule(t *testing.T) {
+	rule := DefaultUrgencyRule()
+	assert.NoError(t, rule.Validate())
+	assert.Equal(t, UrgencyLevelCritical, rule.Level(70))
+	assert.Equal(t, UrgencyLevelHigh, rule.Level(69))
+	assert.Equal(t, UrgencyLevelMedium, rule.Level(20))
+	assert.Equal(t, UrgencyLevelLow, rule.Level(0))
+
+	rule.MedicalKeywords = "帕金森， 脑梗;;走路不稳"
+	assert.Equal(t, []string{"帕金森", "脑梗", "走路不稳"}, rule.ExtraKeywords())
+
+	rule.HighScore = rule.CriticalScore
+	assert.Error(t, rule.Validate())
+}
@@ -0,0 +1,332 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/pkg/geo"
+)
+
+// LanguageLevel 语言熟练程度
+type LanguageLevel string
+
+const (
+	LanguageLevelNative LanguageLevel = "native" // 母语
+	LanguageLevelFluent LanguageLevel = "fluent" // 流利
+	LanguageLevelBasic  LanguageLevel = "basic"  // 能听懂、简单交流
+)
+
+const (
+	// minutesPerDay 每天的分钟数，值班时段以当天零点起的分钟数表示
+	minutesPerDay = 24 * 60
+
+	// volunteerRecentLocation 最近任务地点在该时长内时优先于常住地
+	volunteerRecentLocation = 24 * time.Hour
+)
+
+// 志愿者不可用原因
+const (
+	VolunteerUnavailableVacation = "休假中"
+	VolunteerUnavailableOffDuty  = "不在值班时段"
+)
+
+// VolunteerProfile 志愿者档案：常住地、服务半径、技能、证书、会说的语言方言和值班时间
+type VolunteerProfile struct {
+	BaseEntity
+	UserID          string  `gorm:"type:uuid;not null;uniqueIndex" json:"user_id"`
+	OrgID           string  `gorm:"type:uuid;not null;index" json:"org_id"`
+	Province        string  `gorm:"size:50;index" json:"province,omitempty"`
+	City            string  `gorm:"size:50" json:"city,omitempty"`
+	District        string  `gorm:"size:50" json:"district,omitempty"`
+	Address         string  `gorm:"size:255" json:"address,omitempty"`
+	HomeLat         float64 `gorm:"type:decimal(10,8)" json:"home_lat,omitempty"`
+	HomeLng         float64 `gorm:"type:decimal(11,8)" json:"home_lng,omitempty"`
+	ServiceRadiusKm float64 `gorm:"type:decimal(8,2);default:0" json:"service_radius_km"` // 0 表示不限
+	Skills          string  `gorm:"size:500" json:"skills,omitempty"`                     // 逗号分隔，如 驾车,急救,心理疏导
+	Bio             string  `gorm:"type:text" json:"bio,omitempty"`
+
+	User           *User                    `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Certifications []VolunteerCertification `gorm:"foreignKey:ProfileID" json:"certifications,omitempty"`
+	Languages      []VolunteerLanguage      `gorm:"foreignKey:ProfileID" json:"languages,omitempty"`
+	Availability   []VolunteerAvailability  `gorm:"foreignKey:ProfileID" json:"availability,omitempty"`
+	Vacations      []VolunteerVacation      `gorm:"foreignKey:ProfileID" json:"vacations,omitempty"`
+}
+
+// TableName 表名
+func (VolunteerProfile) TableName() string {
+	return "ty_volunteer_profiles"
+}
+
+// VolunteerCertification 志愿者证书，ExpiresAt 为空表示长期有效
+type VolunteerCertification struct {
+	BaseEntity
+	ProfileID string     `gorm:"type:uuid;not null;index" json:"profile_id"`
+	Name      string     `gorm:"size:100;not null;index" json:"name"` // 如 红十字救护员证
+	Issuer    string     `gorm:"size:100" json:"issuer,omitempty"`
+	CertNo    string     `gorm:"size:100" json:"cert_no,omitempty"`
+	IssuedAt  *time.Time `json:"issued_at,omitempty"`
+	ExpiresAt *time.Time `gorm:"index" json:"expires_at,omitempty"`
+}
+
+// TableName 表名
+func (VolunteerCertification) TableName() string {
+	return "ty_volunteer_certifications"
+}
+
+// IsValid 证书在 now 时是否有效
+func (c *VolunteerCertification) IsValid(now time.Time) bool {
+	return c.ExpiresAt == nil || c.ExpiresAt.After(now)
+}
+
+// VolunteerLanguage 志愿者会说的语言或方言，DialectGroupID 关联方言分类
+type VolunteerLanguage struct {
+	BaseEntity
+	ProfileID      string        `gorm:"type:uuid;not null;index" json:"profile_id"`
+	Name           string        `gorm:"size:50;not null;index" json:"name"` // 如 闽南话、普通话
+	DialectGroupID *string       `gorm:"type:uuid;index" json:"dialect_group_id,omitempty"`
+	Level          LanguageLevel `gorm:"size:20;not null;default:'fluent'" json:"level"`
+
+	DialectGroup *DialectGroup `gorm:"foreignKey:DialectGroupID" json:"dialect_group,omitempty"`
+}
+
+// TableName 表名
+func (VolunteerLanguage) TableName() string {
+	return "ty_volunteer_languages"
+}
+
+// Names 语言名称，关联方言分类时包含分类名称、别称及各级上级分类名称（会说苏州话也算会说吴语）
+func (l *VolunteerLanguage) Names() []string {
+	names := []string{l.Name}
+	if l.DialectGroup != nil {
+		names = append(names, strings.Split(l.DialectGroup.FullName, dialectGroupPathSep)...)
+		names = append(names, l.DialectGroup.GetAliases()...)
+	}
+	return splitTags(strings.Join(names, ","))
+}
+
+// VolunteerAvailability 每周值班时段，Weekday 0 为周日，时段为当天零点起的分钟数 [StartMinute, EndMinute)
+type VolunteerAvailability struct {
+	BaseEntity
+	ProfileID   string `gorm:"type:uuid;not null;index" json:"profile_id"`
+	Weekday     int    `gorm:"not null" json:"weekday"`
+	StartMinute int    `gorm:"not null" json:"start_minute"`
+	EndMinute   int    `gorm:"not null" json:"end_minute"`
+}
+
+// TableName 表名
+func (VolunteerAvailability) TableName() string {
+	return "ty_volunteer_availability"
+}
+
+// Covers 时段是否包含 t（按 t 所在时区的星期和时刻）
+func (a *VolunteerAvailability) Covers(t time.Time) bool {
+	minute := t.Hour()*60 + t.Minute()
+	return int(t.Weekday()) == a.Weekday && minute >= a.StartMinute && minute < a.EndMinute
+}
+
+// ParseDayMinute 解析 HH:MM 格式的时刻为当天零点起的分钟数，允许 24:00 表示当天结束
+func ParseDayMinute(s string) (int, error) {
+	var h, m int
+	if _, err := fmt.Sscanf(strings.TrimSpace(s), "%d:%d", &h, &m); err != nil {
+		return 0, fmt.Errorf("无效的时刻 %q", s)
+	}
+	minute := h*60 + m
+	if h < 0 || m < 0 || m >= 60 || minute > minutesPerDay {
+		return 0, fmt.Errorf("无效的时刻 %q", s)
+	}
+	return minute, nil
+}
+
+// FormatDayMinute 将当天零点起的分钟数格式化为 HH:MM
+func FormatDayMinute(minute int) string {
+	return fmt.Sprintf("%02d:%02d", minute/60, minute%60)
+}
+
+// VolunteerVacation 休假时段 [StartAt, EndAt)，期间不参与派单
+type VolunteerVacation struct {
+	BaseEntity
+	ProfileID string    `gorm:"type:uuid;not null;index" json:"profile_id"`
+	StartAt   time.Time `gorm:"not null" json:"start_at"`
+	EndAt     time.Time `gorm:"not null;index" json:"end_at"`
+	Reason    string    `gorm:"size:200" json:"reason,omitempty"`
+}
+
+// TableName 表名
+func (VolunteerVacation) TableName() string {
+	return "ty_volunteer_vacations"
+}
+
+// Covers 休假时段是否包含 t
+func (v *VolunteerVacation) Covers(t time.Time) bool {
+	return !t.Before(v.StartAt) && t.Before(v.EndAt)
+}
+
+// Validate 验证休假时段
+func (v *VolunteerVacation) Validate() error {
+	if !v.EndAt.After(v.StartAt) {
+		return errors.New("休假结束时间须晚于开始时间")
+	}
+	return nil
+}
+
+// Validate 验证档案
+func (p *VolunteerProfile) Validate() error {
+	if (p.HomeLat != 0 || p.HomeLng != 0) && !geo.ValidCoordinate(p.HomeLat, p.HomeLng) {
+		return errors.New("无效的常住地坐标")
+	}
+	if p.ServiceRadiusKm < 0 {
+		return errors.New("服务半径不能为负数")
+	}
+	for _, c := range p.Certifications {
+		if strings.TrimSpace(c.Name) == "" {
+			return errors.New("证书名称不能为空")
+		}
+		if c.IssuedAt != nil && c.ExpiresAt != nil && !c.ExpiresAt.After(*c.IssuedAt) {
+			return errors.New("证书有效期须晚于发证日期")
+		}
+	}
+	for _, l := range p.Languages {
+		if strings.TrimSpace(l.Name) == "" {
+			return errors.New("语言名称不能为空")
+		}
+	}
+	for _, a := range p.Availability {
+		if a.Weekday < 0 || a.Weekday > 6 {
+			return errors.New("无效的星期")
+		}
+		if a.StartMinute < 0 || a.EndMinute > minutesPerDay || a.StartMinute >= a.EndMinute {
+			return errors.New("无效的值班时段")
+		}
+	}
+	for i := range p.Vacations {
+		if err := p.Vacations[i].Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetSkills 获取技能
+func (p *VolunteerProfile) GetSkills() []string {
+	return splitTags(p.Skills)
+}
+
+// SetSkills 设置技能
+func (p *VolunteerProfile) SetSkills(skills []string) {
+	p.Skills = joinTags(skills)
+}
+
+// SkillTags 技能及有效证书名称，用于按技能筛选和派单匹配
+func (p *VolunteerProfile) SkillTags(now time.Time) []string {
+	tags := p.GetSkills()
+	for _, c := range p.Certifications {
+		if c.IsValid(now) {
+			tags = append(tags, c.Name)
+		}
+	}
+	return splitTags(strings.Join(tags, ","))
+}
+
+// LanguageTags 会说的语言、方言及其所属方言分类名称
+func (p *VolunteerProfile) LanguageTags() []string {
+	var tags []string
+	for i := range p.Languages {
+		tags = append(tags, p.Languages[i].Names()...)
+	}
+	return splitTags(strings.Join(tags, ","))
+}
+
+// HasHome 是否填写了常住地坐标
+func (p *VolunteerProfile) HasHome() bool {
+	return geo.ValidCoordinate(p.HomeLat, p.HomeLng)
+}
+
+// UnavailableAt 在 t 时不可用的原因，可用时返回空。未设置值班时段视为随时可用
+func (p *VolunteerProfile) UnavailableAt(t time.Time) string {
+	for i := range p.Vacations {
+		if p.Vacations[i].Covers(t) {
+			return VolunteerUnavailableVacation
+		}
+	}
+	if len(p.Availability) == 0 {
+		return ""
+	}
+	local := t.Local()
+	for i := range p.Availability {
+		if p.Availability[i].Covers(local) {
+			return ""
+		}
+	}
+	return VolunteerUnavailableOffDuty
+}
+
+// UpcomingVacations 尚未结束的休假，按开始时间先后排列
+func (p *VolunteerProfile) UpcomingVacations(now time.Time) []VolunteerVacation {
+	var list []VolunteerVacation
+	for _, v := range p.Vacations {
+		if v.EndAt.After(now) {
+			list = append(list, v)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].StartAt.Before(list[j].StartAt) })
+	return list
+}
+
+// ApplyTo 用档案补充派单候选人：最近一天内有任务地点时沿用，否则使用常住地；
+// 技能、语言与档案合并，并设置服务半径和当前是否可用
+func (p *VolunteerProfile) ApplyTo(c *DispatchCandidate, now time.Time) {
+	recent := c.LocatedAt != nil && now.Sub(*c.LocatedAt) <= volunteerRecentLocation &&
+		geo.ValidCoordinate(c.Lat, c.Lng)
+	if p.HasHome() && !recent {
+		c.Lat, c.Lng = p.HomeLat, p.HomeLng
+		c.LocationSource = DispatchLocationHome
+		c.LocatedAt = nil
+	}
+	c.Skills = splitTags(strings.Join(append(c.Skills, p.SkillTags(now)...), ","))
+	c.Languages = splitTags(strings.Join(append(c.Languages, p.LanguageTags()...), ","))
+	c.ServiceRadius = p.ServiceRadiusKm
+	if reason := p.UnavailableAt(now); reason != "" {
+		c.Unavailable = reason
+	}
+}
+
+// VolunteerCriteria 志愿者筛选条件，空值不限
+type VolunteerCriteria struct {
+	Skills    []string // 须全部具备，有效证书名称也算
+	Languages []string // 须全部会说，所属方言分类名称也算
+	Lat       float64  // 与 Lng 一起指定服务地点
+	Lng       float64
+	RadiusKm  float64    // 常住地与服务地点的最大距离
+	At        *time.Time // 该时刻有空（不在休假且在值班时段内）
+}
+
+// Match 档案是否满足筛选条件，返回常住地与服务地点的距离（-1 表示未指定地点或位置未知）。
+// 指定服务地点时，未填写常住地或超出本人服务半径的志愿者不满足
+func (p *VolunteerProfile) Match(c *VolunteerCriteria, now time.Time) (bool, float64) {
+	if fit, _ := tagCoverage(c.Skills, p.SkillTags(now)); fit < 1 {
+		return false, -1
+	}
+	if fit, _ := tagCoverage(c.Languages, p.LanguageTags()); fit < 1 {
+		return false, -1
+	}
+	if c.At != nil && p.UnavailableAt(*c.At) != "" {
+		return false, -1
+	}
+	if !geo.ValidCoordinate(c.Lat, c.Lng) {
+		return true, -1
+	}
+	if !p.HasHome() {
+		return false, -1
+	}
+	d := geo.Distance(c.Lat, c.Lng, p.HomeLat, p.HomeLng)
+	if c.RadiusKm > 0 && d > c.RadiusKm {
+		return false, d
+	}
+	if p.ServiceRadiusKm > 0 && d > p.ServiceRadiusKm {
+		return false, d
+	}
+	return true, d
+}
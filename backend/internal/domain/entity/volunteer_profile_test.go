@@ -0,0 +1,99 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVolunteerProfile_Availability(t *testing.T) {
+	start, err := ParseDayMinute("09:00")
+	assert.NoError(t, err)
+	end, err := ParseDayMinute("24:00")
+	assert.NoError(t, err)
+	assert.Equal(t, "24:00", FormatDayMinute(end))
+	_, err = ParseDayMinute("25:00")
+	assert.Error(t, err)
+
+	// 2026-10-17 为周六
+	saturday := time.Date(2026, 10, 17, 10, 0, 0, 0, time.Local)
+	profile := &VolunteerProfile{
+		Availability: []VolunteerAvailability{{Weekday: int(time.Saturday), StartMinute: start, EndMinute: end}},
+	}
+	assert.NoError(t, profile.Validate())
+	assert.Empty(t, profile.UnavailableAt(saturday))
+	assert.Equal(t, VolunteerUnavailableOffDuty, profile.UnavailableAt(saturday.Add(-2*time.Hour)))
+	assert.Equal(t, VolunteerUnavailableOffDuty, profile.UnavailableAt(saturday.AddDate(0, 0, 1)))
+
+	profile.Vacations = []VolunteerVacation{{StartAt: saturday.Add(-time.Hour), EndAt: saturday.Add(time.Hour)}}
+	assert.Equal(t, VolunteerUnavailableVacation, profile.UnavailableAt(saturday))
+	assert.Empty(t, profile.UnavailableAt(saturday.Add(time.Hour)))
+	assert.Empty(t, profile.UpcomingVacations(saturday.Add(time.Hour)))
+
+	profile.Availability[0].EndMinute = start
+	assert.Error(t, profile.Validate())
+}
+
+func TestVolunteerProfile_ApplyTo(t *testing.T) {
+	now := time.Now()
+	expired := now.AddDate(0, 0, -1)
+	group := &DialectGroup{Name: "闽南片", FullName: "闽语/闽南片", Aliases: `["闽南话"]`}
+	profile := &VolunteerProfile{
+		HomeLat:         24.48,
+		HomeLng:         118.09,
+		ServiceRadiusKm: 30,
+		Certifications: []VolunteerCertification{
+			{Name: "救护员证"},
+			{Name: "心理咨询师", ExpiresAt: &expired},
+		},
+		Languages: []VolunteerLanguage{{Name: "厦门话", DialectGroup: group}},
+	}
+	profile.SetSkills([]string{"驾车", " 驾车"})
+	assert.Equal(t, "驾车", profile.Skills)
+
+	stale := now.Add(-48 * time.Hour)
+	c := DispatchCandidate{UserID: "u1", Lat: 39.9, Lng: 116.4, LocationSource: DispatchLocationLastKnown, LocatedAt: &stale}
+	profile.ApplyTo(&c, now)
+	assert.Equal(t, DispatchLocationHome, c.LocationSource)
+	assert.Equal(t, 24.48, c.Lat)
+	assert.Equal(t, []string{"驾车", "救护员证"}, c.Skills)
+	assert.Equal(t, []string{"厦门话", "闽语", "闽南片", "闽南话"}, c.Languages)
+	assert.Equal(t, 30.0, c.ServiceRadius)
+	assert.Empty(t, c.Unavailable)
+
+	recent := now.Add(-time.Hour)
+	c = DispatchCandidate{UserID: "u1", Lat: 24.6, Lng: 118.1, LocationSource: DispatchLocationLastKnown, LocatedAt: &recent}
+	profile.ApplyTo(&c, now)
+	assert.Equal(t, DispatchLocationLastKnown, c.LocationSource, "recent task location wins over home")
+}
+
+func TestVolunteerProfile_Match(t *testing.T) {
+	now := time.Now()
+	group := &DialectGroup{Name: "苏州话", FullName: "吴语/太湖片/苏州话"}
+	profile := &VolunteerProfile{
+		HomeLat:         31.30,
+		HomeLng:         120.62,
+		ServiceRadiusKm: 50,
+		Skills:          "驾车,急救",
+		Languages:       []VolunteerLanguage{{Name: "苏州话", DialectGroup: group}},
+	}
+
+	ok, d := profile.Match(&VolunteerCriteria{Skills: []string{"驾车"}, Languages: []string{"吴语"}}, now)
+	assert.True(t, ok)
+	assert.Equal(t, -1.0, d)
+
+	ok, _ = profile.Match(&VolunteerCriteria{Languages: []string{"粤语"}}, now)
+	assert.False(t, ok)
+
+	// 上海距苏州约 80 公里，超出本人 50 公里服务半径
+	ok, d = profile.Match(&VolunteerCriteria{Lat: 31.23, Lng: 121.47}, now)
+	assert.False(t, ok)
+	assert.InDelta(t, 81, d, 5)
+
+	ok, _ = profile.Match(&VolunteerCriteria{Lat: 31.32, Lng: 120.7, RadiusKm: 20}, now)
+	assert.True(t, ok)
+
+	ok, _ = (&VolunteerProfile{}).Match(&VolunteerCriteria{Lat: 31.32, Lng: 120.7}, now)
+	assert.False(t, ok, "volunteers without home location are excluded from location search")
+}
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+)
+
+// DialectAnalyticsRepository 方言播放统计仓储接口，数据来自播放记录
+type DialectAnalyticsRepository interface {
+	// Summary 播放汇总
+	Summary(ctx context.Context, query *DialectAnalyticsQuery) (*entity.DialectPlaySummary, error)
+
+	// ListenerDays 按天、收听者汇总播放，按日期排列
+	ListenerDays(ctx context.Context, query *DialectAnalyticsQuery) ([]entity.DialectListenerDay, error)
+
+	// TopRegions 播放最多的方言地区
+	TopRegions(ctx context.Context, query *DialectAnalyticsQuery, limit int) ([]entity.DialectRegionPlays, error)
+
+	// TopClips 播放最多的方言
+	TopClips(ctx context.Context, query *DialectAnalyticsQuery, limit int) ([]entity.DialectClipPlays, error)
+}
+
+// DialectAnalyticsQuery 方言播放统计条件，时间范围为 [From, To)
+type DialectAnalyticsQuery struct {
+	OrgID     string // 方言所属组织
+	DialectID string
+	From      time.Time
+	To        time.Time
+}
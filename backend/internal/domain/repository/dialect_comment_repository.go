@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+)
+
+// 评论管理列表筛选
+const (
+	DialectCommentFilterFlagged  = "flagged"  // 命中敏感词待复核
+	DialectCommentFilterReported = "reported" // 有待处理的举报
+	DialectCommentFilterHidden   = "hidden"   // 已隐藏
+)
+
+// DialectCommentQuery 评论管理查询
+type DialectCommentQuery struct {
+	Pagination
+	OrgID     string
+	DialectID string
+	Filter    string // 为空时列出待复核或有待处理举报的评论
+}
+
+// DialectCommentRepository 方言评论仓储接口
+type DialectCommentRepository interface {
+	Repository[entity.DialectComment]
+
+	// FindByIDWithUser 获取评论（含方言、作者和被回复用户），不存在时返回 nil
+	FindByIDWithUser(ctx context.Context, id string) (*entity.DialectComment, error)
+
+	// Add 添加评论，同时更新方言评论数，回复还会更新主评论的回复数
+	Add(ctx context.Context, comment *entity.DialectComment) error
+
+	// ListThreads 分页查询方言的主评论，按时间倒序
+	ListThreads(ctx context.Context, dialectID string, pagination Pagination) (*PageResult[entity.DialectComment], error)
+
+	// ListReplies 分页查询主评论下的回复，按时间正序
+	ListReplies(ctx context.Context, parentID string, pagination Pagination) (*PageResult[entity.DialectComment], error)
+
+	// PreviewReplies 每条主评论最早的 limit 条回复，按主评论 ID 分组
+	PreviewReplies(ctx context.Context, parentIDs []string, limit int) (map[string][]entity.DialectComment, error)
+
+	// Like 点赞评论，已点赞时返回 false
+	Like(ctx context.Context, like *entity.DialectCommentLike) (bool, error)
+
+	// Unlike 取消点赞，未点赞时返回 false
+	Unlike(ctx context.Context, commentID, userID string) (bool, error)
+
+	// LikedIDs 用户点赞过的评论
+	LikedIDs(ctx context.Context, userID string, commentIDs []string) (map[string]bool, error)
+
+	// AddReport 举报评论并更新举报数，该用户已举报过时返回 false
+	AddReport(ctx context.Context, report *entity.DialectCommentReport) (bool, error)
+
+	// ListReports 评论的举报记录，按时间倒序
+	ListReports(ctx context.Context, commentID string) ([]entity.DialectCommentReport, error)
+
+	// SaveModeration 保存管理员对评论的处理；reportStatus 不为空时同时处理该评论待处理的举报
+	SaveModeration(ctx context.Context, comment *entity.DialectComment, reportStatus entity.DialectCommentReportStatus, handledBy string) error
+
+	// ListModeration 分页查询组织中需要管理的评论，按举报数、时间倒序
+	ListModeration(ctx context.Context, query *DialectCommentQuery) (*PageResult[entity.DialectComment], error)
+}
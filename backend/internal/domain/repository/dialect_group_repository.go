@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+)
+
+// DialectGroupRepository 方言分类仓储接口
+type DialectGroupRepository interface {
+	Repository[entity.DialectGroup]
+
+	// FindByIDWithRegions 获取分类及其对应地区，不存在时返回 nil
+	FindByIDWithRegions(ctx context.Context, id string) (*entity.DialectGroup, error)
+
+	// FindAllWithRegions 获取全部分类（含对应地区），按排序值、名称排列
+	FindAllWithRegions(ctx context.Context) ([]entity.DialectGroup, error)
+
+	// FindSubtree 获取 path 下的全部分类（含自身）
+	FindSubtree(ctx context.Context, path string) ([]entity.DialectGroup, error)
+
+	// FindByRegion 查找对应地区覆盖指定省市的分类，city 为空时只按省匹配
+	FindByRegion(ctx context.Context, province, city string) ([]entity.DialectGroup, error)
+
+	// ExistsName 同一上级下是否已有同名分类
+	ExistsName(ctx context.Context, parentID *string, name string, excludeID string) (bool, error)
+
+	// CreateWithRegions 创建分类及其对应地区
+	CreateWithRegions(ctx context.Context, group *entity.DialectGroup) error
+
+	// CreateTree 批量创建分类（须按上级在前排列），用于导入默认分类
+	CreateTree(ctx context.Context, groups []*entity.DialectGroup) error
+
+	// UpdateWithRegions 更新分类并替换对应地区，同时更新移动或改名后下级分类的层级、路径和全称，
+	// 已归入该分类的方言地区名称随分类改名
+	UpdateWithRegions(ctx context.Context, group *entity.DialectGroup, descendants []entity.DialectGroup) error
+
+	// CountChildren 统计直接下级数
+	CountChildren(ctx context.Context, id string) (int64, error)
+
+	// CountDialects 统计 path 子树下已归类的方言数
+	CountDialects(ctx context.Context, path string) (int64, error)
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+)
+
+// 歌单列表范围
+const (
+	DialectPlaylistScopeMine   = "mine"   // 自己创建的
+	DialectPlaylistScopeShared = "shared" // 作为协作者参与的
+	DialectPlaylistScopeOrg    = "org"    // 组织内共享的
+	DialectPlaylistScopeAuto   = "auto"   // 组织的自动精选合集
+)
+
+// DialectPlaylistQuery 歌单查询，只返回 UserID 可见的歌单
+type DialectPlaylistQuery struct {
+	Pagination
+	UserID   string
+	OrgID    string
+	Scope    string // 为空时返回全部可见歌单
+	Province string
+	Keyword  string
+}
+
+// DialectPlaylistRepository 方言歌单仓储接口
+type DialectPlaylistRepository interface {
+	Repository[entity.DialectPlaylist]
+
+	// FindByIDWithItems 获取歌单（含创建者、按顺序排列的片段及协作者），不存在时返回 nil；
+	// 片段的方言已删除时 Dialect 为空
+	FindByIDWithItems(ctx context.Context, id string) (*entity.DialectPlaylist, error)
+
+	// List 分页查询歌单，按更新时间倒序
+	List(ctx context.Context, query *DialectPlaylistQuery) (*PageResult[entity.DialectPlaylist], error)
+
+	// SaveInfo 保存歌单名称、说明和可见范围
+	SaveInfo(ctx context.Context, playlist *entity.DialectPlaylist) error
+
+	// AddItem 添加片段：Position 为 0 时追加到末尾，否则插入到该位置；方言已在歌单中时返回 false，
+	// 片段数达到上限时返回 entity.ErrDialectPlaylistFull
+	AddItem(ctx context.Context, item *entity.DialectPlaylistItem) (bool, error)
+
+	// RemoveItem 移除片段并收紧后续片段的位置，片段不存在时返回 false
+	RemoveItem(ctx context.Context, playlistID, itemID string) (bool, error)
+
+	// Reorder 按 positions（片段 ID -> 位置）更新片段顺序
+	Reorder(ctx context.Context, playlistID string, positions map[string]int) error
+
+	// ReplaceItems 替换全部片段并记录生成时间，用于重新生成自动合集
+	ReplaceItems(ctx context.Context, playlistID string, items []entity.DialectPlaylistItem, refreshedAt time.Time) error
+
+	// AddCollaborator 添加协作者，已是协作者时返回 false
+	AddCollaborator(ctx context.Context, collaborator *entity.DialectPlaylistCollaborator) (bool, error)
+
+	// RemoveCollaborator 移除协作者，不是协作者时返回 false
+	RemoveCollaborator(ctx context.Context, playlistID, userID string) (bool, error)
+
+	// FindCollectionCandidates 省份内可用于精选合集的片段及其播放完成情况，最多 limit 条
+	FindCollectionCandidates(ctx context.Context, province string, limit int) ([]entity.DialectClipCandidate, error)
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
 )
@@ -37,12 +38,6 @@ type DialectRepository interface {
 	// DecrementLikeCount 减少点赞数
 	DecrementLikeCount(ctx context.Context, id string) error
 
-	// AddComment 添加评论
-	AddComment(ctx context.Context, comment *entity.DialectComment) error
-
-	// GetComments 获取评论
-	GetComments(ctx context.Context, dialectID string, pagination Pagination) (*PageResult[entity.DialectComment], error)
-
 	// AddLike 添加点赞
 	AddLike(ctx context.Context, like *entity.DialectLike) error
 
@@ -55,8 +50,35 @@ type DialectRepository interface {
 	// AddPlayLog 添加播放记录
 	AddPlayLog(ctx context.Context, log *entity.DialectPlayLog) error
 
+	// RecordPlay 记录播放：同一收听者 since 之后已有播放记录时只更新播放时长（取较大值）并返回 false，
+	// 否则添加记录、增加播放次数并返回 true
+	RecordPlay(ctx context.Context, log *entity.DialectPlayLog, since time.Time) (bool, error)
+
 	// GetStats 获取统计
 	GetStats(ctx context.Context) (*entity.DialectStats, error)
+
+	// FindUngrouped 查找尚未归入方言分类的方言，用于迁移历史数据
+	FindUngrouped(ctx context.Context) ([]entity.Dialect, error)
+
+	// AssignGroup 设置方言分类，并将地区名称统一为分类名称
+	AssignGroup(ctx context.Context, id, groupID, region string) error
+
+	// FindIdentificationClips 查找可用于方言辨识的片段，按精选、播放次数排序
+	FindIdentificationClips(ctx context.Context, query *DialectClipQuery) ([]entity.Dialect, error)
+
+	// FindFingerprints 获取时长在 [minDuration, maxDuration] 秒内、已计算声纹指纹的其它方言（仅加载 id、时长、指纹）
+	FindFingerprints(ctx context.Context, excludeID string, minDuration, maxDuration int) ([]entity.Dialect, error)
+
+	// FindByIDs 批量查找，不保证顺序
+	FindByIDs(ctx context.Context, ids []string) ([]entity.Dialect, error)
+}
+
+// DialectClipQuery 方言辨识片段查询条件
+type DialectClipQuery struct {
+	Province   string
+	City       string
+	ExcludeIDs []string
+	Limit      int
 }
 
 // DialectQuery 方言查询参数
@@ -64,6 +86,7 @@ type DialectQuery struct {
 	Pagination
 	Keyword    string               `json:"keyword"`
 	Region     string               `json:"region"`
+	GroupPath  string               `json:"group_path"` // 方言分类路径，匹配该分类及其全部下级
 	Province   string               `json:"province"`
 	City       string               `json:"city"`
 	Type       entity.DialectType   `json:"type"`
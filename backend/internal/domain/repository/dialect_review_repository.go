@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+)
+
+// DialectReviewRepository 方言审核仓储接口
+type DialectReviewRepository interface {
+	Repository[entity.DialectReview]
+
+	// FindByIDWithDecisions 获取审核（含方言、当前认领人和审核历史），不存在时返回 nil
+	FindByIDWithDecisions(ctx context.Context, id string) (*entity.DialectReview, error)
+
+	// FindOpenByDialect 获取方言未结束的审核，不存在时返回 nil
+	FindOpenByDialect(ctx context.Context, dialectID string) (*entity.DialectReview, error)
+
+	// List 分页查询审核队列，按提交时间先后排列
+	List(ctx context.Context, query *DialectReviewQuery) (*PageResult[entity.DialectReview], error)
+
+	// FindClaimable 按提交时间先后获取组织中可由该审核员认领的审核（未认领或认领已超时，且该审核员未审核过）
+	FindClaimable(ctx context.Context, orgID, reviewerID string, staleBefore time.Time, limit int) ([]entity.DialectReview, error)
+
+	// Claim 认领审核：仅当审核未认领、认领早于 staleBefore 已超时或已由同一审核员认领时成功
+	Claim(ctx context.Context, review *entity.DialectReview, staleBefore time.Time) (bool, error)
+
+	// Release 释放 claimedBy 持有的认领，认领人已变化时返回 false
+	Release(ctx context.Context, review *entity.DialectReview, claimedBy string) (bool, error)
+
+	// SaveDecision 保存审核员结论并更新审核；审核结束时同时更新方言状态。认领人已变化时返回 false
+	SaveDecision(ctx context.Context, review *entity.DialectReview, decision *entity.DialectReviewDecision) (bool, error)
+
+	// CountByStatus 统计组织中各状态的审核数
+	CountByStatus(ctx context.Context, orgID string) (map[entity.DialectReviewStatus]int64, error)
+
+	// ReviewerStats 统计组织中各审核员的审核结论，since 为空时统计全部
+	ReviewerStats(ctx context.Context, orgID string, since *time.Time) ([]entity.DialectReviewerStats, error)
+}
+
+// DialectReviewQuery 方言审核查询条件
+type DialectReviewQuery struct {
+	Pagination
+	OrgID      string
+	Status     entity.DialectReviewStatus
+	ReviewerID string // 当前认领人
+	DialectID  string
+	Duplicate  bool // 只看疑似重复上传
+}
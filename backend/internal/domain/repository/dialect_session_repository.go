@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+)
+
+// DialectSessionRepository 方言辨识会话仓储接口
+type DialectSessionRepository interface {
+	Repository[entity.DialectSession]
+
+	// FindByIDWithAnswers 查找会话（含受测者反应），不存在时返回 nil
+	FindByIDWithAnswers(ctx context.Context, id string) (*entity.DialectSession, error)
+
+	// AddAnswer 保存受测者反应及会话当前阶段（同一事务）
+	AddAnswer(ctx context.Context, session *entity.DialectSession, answer *entity.DialectSessionAnswer) error
+
+	// UpdateSession 更新会话（不含反应）
+	UpdateSession(ctx context.Context, session *entity.DialectSession) error
+
+	// AttachTrack 保存辨识结果生成的线索并关联会话（同一事务）
+	AttachTrack(ctx context.Context, session *entity.DialectSession, track *entity.MissingPersonTrack) error
+
+	// List 分页查询
+	List(ctx context.Context, query *DialectSessionQuery) (*PageResult[entity.DialectSession], error)
+}
+
+// DialectSessionQuery 方言辨识会话查询条件
+type DialectSessionQuery struct {
+	Pagination
+	MissingPersonID string
+	OperatorID      string
+	OrgID           string
+	Status          entity.DialectSessionStatus
+}
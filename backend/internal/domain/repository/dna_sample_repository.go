@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+)
+
+// DNASampleRepository DNA 样本仓储接口
+type DNASampleRepository interface {
+	Repository[entity.DNASample]
+
+	// CreateWithLog 保存样本及采集记录（同一事务）
+	CreateWithLog(ctx context.Context, sample *entity.DNASample, log *entity.DNASampleCustodyLog) error
+
+	// UpdateWithLog 保存样本状态及保管链记录（同一事务，以事件前状态为条件）
+	UpdateWithLog(ctx context.Context, sample *entity.DNASample, log *entity.DNASampleCustodyLog) error
+
+	// UpdateInfo 更新采集信息（不含状态及送检结果）
+	UpdateInfo(ctx context.Context, sample *entity.DNASample) error
+
+	// FindBySampleNo 根据样本编号查找，不存在时返回 nil
+	FindBySampleNo(ctx context.Context, sampleNo string) (*entity.DNASample, error)
+
+	// List 分页查询
+	List(ctx context.Context, query *DNASampleQuery) (*PageResult[entity.DNASample], error)
+
+	// GetCustodyLogs 获取样本保管链记录
+	GetCustodyLogs(ctx context.Context, sampleID string) ([]entity.DNASampleCustodyLog, error)
+}
+
+// DNASampleQuery DNA 样本查询条件
+type DNASampleQuery struct {
+	Pagination
+	MissingPersonID string
+	CollectorID     string
+	OrgID           string
+	OrgIDs          []string // 限定的组织范围（数据权限）
+	Status          entity.DNASampleStatus
+}
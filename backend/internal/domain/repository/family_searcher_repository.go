@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+)
+
+// FamilySearcherRepository 寻亲者仓储接口
+type FamilySearcherRepository interface {
+	Repository[entity.FamilySearcher]
+
+	// CreateWithDetails 保存寻亲者及联系人、亲属（同一事务）
+	CreateWithDetails(ctx context.Context, searcher *entity.FamilySearcher) error
+
+	// UpdateWithDetails 更新寻亲者并整体替换联系人、亲属（同一事务）
+	UpdateWithDetails(ctx context.Context, searcher *entity.FamilySearcher) error
+
+	// FindByIDWithDetails 查找寻亲者（含联系人、亲属、关联案件），不存在时返回 nil
+	FindByIDWithDetails(ctx context.Context, id string) (*entity.FamilySearcher, error)
+
+	// FindByIDs 批量查找寻亲者
+	FindByIDs(ctx context.Context, ids []string) ([]entity.FamilySearcher, error)
+
+	// List 分页查询
+	List(ctx context.Context, query *FamilySearcherQuery) (*PageResult[entity.FamilySearcher], error)
+
+	// LinkCase 关联案件
+	LinkCase(ctx context.Context, link *entity.FamilySearcherCase) error
+
+	// UnlinkCase 取消关联案件，关联不存在时返回 false
+	UnlinkCase(ctx context.Context, searcherID, personID string) (bool, error)
+
+	// FindByCase 查找关联到案件的寻亲者
+	FindByCase(ctx context.Context, personID string) ([]entity.FamilySearcher, error)
+
+	// FindRelativesLinkedTo 查找其他寻亲者档案中指向该寻亲者的亲属关系
+	FindRelativesLinkedTo(ctx context.Context, searcherID string) ([]entity.FamilySearcherRelative, error)
+
+	// FindMatchCandidates 查找可比对的寻亲中档案
+	FindMatchCandidates(ctx context.Context, query *FamilyMatchCandidateQuery) ([]entity.FamilySearcher, error)
+
+	// FindCaseCandidates 查找可与亲寻家档案比对的寻找中案件
+	FindCaseCandidates(ctx context.Context, query *FamilyMatchCandidateQuery) ([]entity.MissingPerson, error)
+}
+
+// FamilySearcherQuery 寻亲者查询条件
+type FamilySearcherQuery struct {
+	Pagination
+	Kind    entity.FamilySearcherKind
+	Status  entity.FamilySearcherStatus
+	OrgID   string
+	Keyword string // 匹配登记人、被寻找人姓名及联系人电话
+}
+
+// FamilyMatchCandidateQuery 寻亲比对候选查询条件，Gender、Province 为空时不限
+type FamilyMatchCandidateQuery struct {
+	Kind       entity.FamilySearcherKind
+	Gender     string
+	Province   string
+	NamePinyin string // 不限省份时仍召回同音姓名
+	ExcludeID  string
+	Limit      int
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/geo"
+)
+
+// GeoRepository 地理空间查询仓储接口
+// 优先使用 PostGIS，不可用时（MySQL 或未安装扩展）回退到 geohash 索引列 + 经纬度范围过滤
+type GeoRepository interface {
+	// FindTracksWithinRadius 查找某点半径范围内的线索
+	FindTracksWithinRadius(ctx context.Context, query *GeoRadiusQuery) ([]TrackDistance, error)
+
+	// FindTracksInBounds 查找包围盒内的线索
+	FindTracksInBounds(ctx context.Context, query *GeoBoundsQuery) ([]entity.MissingPersonTrack, error)
+
+	// FindCasesInBounds 查找包围盒内的案件（按走失地点）
+	FindCasesInBounds(ctx context.Context, query *GeoBoundsQuery) ([]entity.MissingPerson, error)
+
+	// FindNearestActiveCases 查找距离某点最近的活跃案件（按走失地点及线索位置取最近距离）
+	FindNearestActiveCases(ctx context.Context, query *GeoRadiusQuery) ([]CaseDistance, error)
+
+	// FindNearestOrg 查找距离某点最近的、已设置坐标的活跃组织，没有时返回 nil
+	FindNearestOrg(ctx context.Context, lat, lng float64) (*OrgDistance, error)
+
+	// BackfillGeohash 为历史数据补齐 geohash 列
+	BackfillGeohash(ctx context.Context) (int64, error)
+
+	// SupportsPostGIS 是否启用 PostGIS
+	SupportsPostGIS() bool
+}
+
+// GeoRadiusQuery 半径查询参数
+type GeoRadiusQuery struct {
+	Lat             float64    `json:"lat"`
+	Lng             float64    `json:"lng"`
+	RadiusKm        float64    `json:"radius_km"`
+	MissingPersonID string     `json:"missing_person_id"`
+	Status          string     `json:"status"`
+	Since           *time.Time `json:"since"`
+	Limit           int        `json:"limit"`
+}
+
+// GeoBoundsQuery 包围盒查询参数
+type GeoBoundsQuery struct {
+	Bounds          geo.BBox   `json:"bounds"`
+	MissingPersonID string     `json:"missing_person_id"`
+	Status          string     `json:"status"`
+	ActiveOnly      bool       `json:"active_only"`
+	Since           *time.Time `json:"since"`
+	Limit           int        `json:"limit"`
+}
+
+// TrackDistance 带距离的线索
+type TrackDistance struct {
+	Track      entity.MissingPersonTrack `json:"track"`
+	DistanceKm float64                   `json:"distance_km"`
+}
+
+// CaseDistance 带距离的案件
+type CaseDistance struct {
+	Person     entity.MissingPerson `json:"person"`
+	DistanceKm float64              `json:"distance_km"`
+	// NearestTrackID 距离最近的线索ID，为空表示以走失地点计算
+	NearestTrackID string `json:"nearest_track_id,omitempty"`
+}
+
+// OrgDistance 带距离的组织
+type OrgDistance struct {
+	Org        entity.Organization `json:"org"`
+	DistanceKm float64             `json:"distance_km"`
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+)
+
+// ImportRepository 批量导入仓储接口
+type ImportRepository interface {
+	Repository[entity.ImportJob]
+
+	// List 导入任务列表，orgID 为空时不限组织
+	List(ctx context.Context, orgID string, pagination Pagination) (*PageResult[entity.ImportJob], error)
+
+	// UpdateProgress 更新执行进度（状态与计数，不含逐行结果）
+	UpdateProgress(ctx context.Context, job *entity.ImportJob) error
+
+	// FindRunning 查找未结束的任务（服务重启后标记为失败）
+	FindRunning(ctx context.Context) ([]entity.ImportJob, error)
+
+	// CreateTemplate 创建列映射模板
+	CreateTemplate(ctx context.Context, template *entity.ImportTemplate) error
+
+	// FindTemplateByID 查找列映射模板
+	FindTemplateByID(ctx context.Context, id string) (*entity.ImportTemplate, error)
+
+	// ListTemplates 组织的列映射模板
+	ListTemplates(ctx context.Context, orgID string) ([]entity.ImportTemplate, error)
+
+	// DeleteTemplate 删除列映射模板
+	DeleteTemplate(ctx context.Context, id string) error
+}
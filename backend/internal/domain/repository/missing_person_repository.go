@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
 )
@@ -25,18 +26,78 @@ type MissingPersonRepository interface {
 	// UpdateStatus 更新状态
 	UpdateStatus(ctx context.Context, id string, status entity.MissingStatus) error
 
+	// TransitionStatus 保存状态机转换后的状态及找到信息，并记录状态变更（同一事务）
+	TransitionStatus(ctx context.Context, mp *entity.MissingPerson, log *entity.MissingPersonStatusLog) error
+
+	// GetStatusLogs 获取案件状态变更记录
+	GetStatusLogs(ctx context.Context, personID string) ([]entity.MissingPersonStatusLog, error)
+
 	// AddTrack 添加轨迹
 	AddTrack(ctx context.Context, track *entity.MissingPersonTrack) error
 
 	// GetTracks 获取轨迹
 	GetTracks(ctx context.Context, personID string) ([]entity.MissingPersonTrack, error)
 
+	// FindTrackByID 根据ID查找轨迹
+	FindTrackByID(ctx context.Context, id string) (*entity.MissingPersonTrack, error)
+
+	// UpdateTrackStatus 更新线索核实状态并记录日志（同一事务）
+	UpdateTrackStatus(ctx context.Context, track *entity.MissingPersonTrack, log *entity.MissingPersonTrackLog) error
+
+	// GetTrackLogs 获取线索核实日志
+	GetTrackLogs(ctx context.Context, trackID string) ([]entity.MissingPersonTrackLog, error)
+
+	// UpdateUrgency 更新紧急程度
+	UpdateUrgency(ctx context.Context, id string, urgency entity.UrgencyLevel) error
+
+	// UpdateUrgencyScore 保存紧急程度评分结果（不更新 updated_at，避免触发检索索引同步）
+	UpdateUrgencyScore(ctx context.Context, mp *entity.MissingPerson) error
+
+	// FindActiveForScoring 按ID顺序分批读取进行中（待寻找、搜索中）且未合并的案件
+	FindActiveForScoring(ctx context.Context, afterID string, limit int) ([]entity.MissingPerson, error)
+
+	// CountRecentTracks 统计各案件指定时间后未驳回的线索数
+	CountRecentTracks(ctx context.Context, personIDs []string, since time.Time) (map[string]int, error)
+
+	// AddPhoto 添加照片
+	AddPhoto(ctx context.Context, photo *entity.MissingPhoto) error
+
+	// GetPhotos 获取案件照片
+	GetPhotos(ctx context.Context, personID string) ([]entity.MissingPhoto, error)
+
+	// FindPhotoByID 根据ID查找照片
+	FindPhotoByID(ctx context.Context, id string) (*entity.MissingPhoto, error)
+
+	// SetPrimaryPhoto 设为主照片并同步案件的 photo_url
+	SetPrimaryPhoto(ctx context.Context, photo *entity.MissingPhoto) error
+
+	// DeletePhoto 删除照片及其相似记录，删除主照片时清空案件的 photo_url
+	DeletePhoto(ctx context.Context, photo *entity.MissingPhoto) error
+
+	// FindPhotoHashes 获取其它案件已计算感知哈希的照片（仅加载 id、案件ID、哈希）
+	FindPhotoHashes(ctx context.Context, excludePersonID string) ([]entity.MissingPhoto, error)
+
+	// AddPhotoMatches 保存相似照片记录
+	AddPhotoMatches(ctx context.Context, matches []entity.MissingPhotoMatch) error
+
+	// GetPhotoMatches 获取与案件相关的相似照片记录（双向）
+	GetPhotoMatches(ctx context.Context, personID string) ([]entity.MissingPhotoMatch, error)
+
 	// GetStats 获取统计
 	GetStats(ctx context.Context) (*entity.MissingPersonStats, error)
 
 	// Search 全文搜索
 	Search(ctx context.Context, keyword string, pagination Pagination) (*PageResult[entity.MissingPerson], error)
 
+	// FindByIDs 根据ID批量查找（不保证顺序）
+	FindByIDs(ctx context.Context, ids []string) ([]entity.MissingPerson, error)
+
+	// FindForIndex 按ID顺序分批读取未合并的案件，用于重建检索索引
+	FindForIndex(ctx context.Context, afterID string, limit int) ([]entity.MissingPerson, error)
+
+	// FindChangedSince 查找指定时间后修改或删除的案件（含已软删除）
+	FindChangedSince(ctx context.Context, since time.Time) ([]entity.MissingPerson, error)
+
 	// CountByStatus 按状态统计
 	CountByStatus(ctx context.Context, status entity.MissingStatus) (int64, error)
 
@@ -45,6 +106,18 @@ type MissingPersonRepository interface {
 
 	// IncrementViews 增加浏览次数
 	IncrementViews(ctx context.Context, id string) error
+
+	// IncrementShareCount 增加分享次数
+	IncrementShareCount(ctx context.Context, id string) error
+
+	// FindDuplicateCandidates 查找可能重复的候选案件（同音姓名或同地区、走失时间相近）
+	FindDuplicateCandidates(ctx context.Context, mp *entity.MissingPerson, limit int) ([]entity.MissingPerson, error)
+
+	// Merge 合并重复案件：迁移轨迹、照片、任务、文件到保留案件，并记录合并日志及被合并案件的状态变更
+	Merge(ctx context.Context, survivor, merged *entity.MissingPerson, record *entity.MissingPersonMerge, statusLog *entity.MissingPersonStatusLog) error
+
+	// GetMergeHistory 获取案件的合并记录
+	GetMergeHistory(ctx context.Context, personID string) ([]entity.MissingPersonMerge, error)
 }
 
 // MissingPersonQuery 走失人员查询参数
@@ -62,6 +135,8 @@ type MissingPersonQuery struct {
 	UrgencyLevel string               `json:"urgency_level"`
 	SortField    string               `json:"sort_field"`
 	SortOrder    string               `json:"sort_order"`
+	// PublicOnly 公开查询：仅返回寻找中且未合并的案件，关键词不匹配联系人信息
+	PublicOnly bool `json:"public_only"`
 }
 
 // NewMissingPersonQuery 创建默认查询
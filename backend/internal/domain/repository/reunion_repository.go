@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+)
+
+// ReunionRepository 团聚记录仓储接口
+type ReunionRepository interface {
+	Repository[entity.ReunionRecord]
+
+	// CreateWithTransition 保存团聚状态转换、团聚记录及回访计划（同一事务）
+	CreateWithTransition(ctx context.Context, mp *entity.MissingPerson, log *entity.MissingPersonStatusLog, record *entity.ReunionRecord) error
+
+	// FindByMissingPersonID 查找案件的团聚记录（含回访计划），不存在时返回 nil
+	FindByMissingPersonID(ctx context.Context, personID string) (*entity.ReunionRecord, error)
+
+	// RescheduleFollowUps 团聚时间变更后顺延尚未生成任务的回访
+	RescheduleFollowUps(ctx context.Context, record *entity.ReunionRecord) error
+
+	// FindFollowUpByID 根据ID查找回访
+	FindFollowUpByID(ctx context.Context, id string) (*entity.ReunionFollowUp, error)
+
+	// UpdateFollowUp 更新回访
+	UpdateFollowUp(ctx context.Context, followUp *entity.ReunionFollowUp) error
+
+	// FindDueFollowUps 查找指定时间前到期、尚未生成任务的回访（含团聚记录）
+	FindDueFollowUps(ctx context.Context, before time.Time, limit int) ([]entity.ReunionFollowUp, error)
+
+	// FollowUpStats 回访统计，orgID 为空时统计全部
+	FollowUpStats(ctx context.Context, orgID string, now time.Time) ([]entity.FollowUpStat, error)
+}
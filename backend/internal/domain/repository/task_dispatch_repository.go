@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+)
+
+// TaskDispatchRepository 任务自动派单仓储接口
+type TaskDispatchRepository interface {
+	Repository[entity.TaskOffer]
+
+	// FindCandidates 获取组织内在职志愿者作为派单候选人，含在办任务数和最近一次任务地点
+	FindCandidates(ctx context.Context, orgID string) ([]entity.DispatchCandidate, error)
+
+	// OfferedUserIDs 获取任务已推送过的志愿者
+	OfferedUserIDs(ctx context.Context, taskID string) ([]string, error)
+
+	// CreateOffers 保存一轮派单邀请并更新任务的派单状态。仅当任务仍待分配且轮次仍为 task.DispatchRound-1 时保存，
+	// 避免并发推送同一轮；未保存时返回 false
+	CreateOffers(ctx context.Context, task *entity.Task, offers []*entity.TaskOffer) (bool, error)
+
+	// FindByIDWithTask 获取邀请（含任务），不存在时返回 nil
+	FindByIDWithTask(ctx context.Context, id string) (*entity.TaskOffer, error)
+
+	// ListByTask 获取任务的全部邀请（含志愿者），按轮次和排名排列
+	ListByTask(ctx context.Context, taskID string) ([]entity.TaskOffer, error)
+
+	// ListOpenByUser 获取志愿者待响应且任务仍待分配的邀请（含任务），按截止时间先后排列
+	ListOpenByUser(ctx context.Context, userID string, now time.Time) ([]entity.TaskOffer, error)
+
+	// Accept 接单：锁定任务，仅当任务仍待分配且邀请仍待响应时把任务分配给接单人，
+	// 同时撤回该任务其他待响应的邀请并记录任务日志。未能接单时返回 false
+	Accept(ctx context.Context, offer *entity.TaskOffer) (bool, []entity.TaskOffer, error)
+
+	// Respond 保存拒绝结果，仅当邀请仍待响应时成功
+	Respond(ctx context.Context, offer *entity.TaskOffer) (bool, error)
+
+	// ExpireOffers 将已超时的待响应邀请标记为超时，返回这些邀请
+	ExpireOffers(ctx context.Context, now time.Time) ([]entity.TaskOffer, error)
+
+	// WithdrawByTask 撤回任务全部待响应的邀请，返回这些邀请
+	WithdrawByTask(ctx context.Context, taskID string) ([]entity.TaskOffer, error)
+
+	// CountOpen 统计任务待响应的邀请数
+	CountOpen(ctx context.Context, taskID string) (int64, error)
+
+	// FindTasksToDispatch 获取需要推送下一轮的任务：待分配、未转人工且没有待响应的邀请，按创建时间先后排列
+	FindTasksToDispatch(ctx context.Context, limit int) ([]entity.Task, error)
+
+	// UpdateTaskDispatch 更新任务的派单状态和轮次
+	UpdateTaskDispatch(ctx context.Context, task *entity.Task) error
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+)
+
+// UrgencyRuleRepository 紧急程度评分规则仓储接口
+type UrgencyRuleRepository interface {
+	Repository[entity.UrgencyRule]
+
+	// FindByOrgID 查找组织自身配置的规则，未配置时返回 nil
+	FindByOrgID(ctx context.Context, orgID string) (*entity.UrgencyRule, error)
+
+	// ListAll 所有组织的规则
+	ListAll(ctx context.Context) ([]entity.UrgencyRule, error)
+
+	// DeleteByOrgID 删除组织的规则（恢复沿用上级规则）
+	DeleteByOrgID(ctx context.Context, orgID string) error
+}
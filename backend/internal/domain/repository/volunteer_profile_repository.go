@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/geo"
+)
+
+// VolunteerProfileRepository 志愿者档案仓储接口
+type VolunteerProfileRepository interface {
+	Repository[entity.VolunteerProfile]
+
+	// FindByUserID 获取志愿者档案（含证书、语言及方言分类、值班时段、休假），不存在时返回 nil
+	FindByUserID(ctx context.Context, userID string) (*entity.VolunteerProfile, error)
+
+	// FindByUserIDs 批量获取志愿者档案（含证书、语言及方言分类、值班时段、休假）
+	FindByUserIDs(ctx context.Context, userIDs []string) ([]entity.VolunteerProfile, error)
+
+	// SaveWithDetails 创建或更新档案，并整体替换证书、语言和值班时段（同一事务），休假不受影响
+	SaveWithDetails(ctx context.Context, profile *entity.VolunteerProfile) error
+
+	// AddVacation 添加休假
+	AddVacation(ctx context.Context, vacation *entity.VolunteerVacation) error
+
+	// DeleteVacation 删除休假，不存在时返回 false
+	DeleteVacation(ctx context.Context, profileID, vacationID string) (bool, error)
+
+	// Search 按条件初筛在职志愿者的档案（含明细），技能、语言为模糊匹配，需再用 VolunteerProfile.Match 精确筛选
+	Search(ctx context.Context, query *VolunteerProfileQuery) ([]entity.VolunteerProfile, error)
+
+	// FindExpiringCertifications 查找在 before 之前到期且尚未过期的证书（含档案及用户），按到期时间先后排列
+	FindExpiringCertifications(ctx context.Context, orgID string, now, before time.Time) ([]VolunteerCertificationExpiry, error)
+}
+
+// VolunteerProfileQuery 志愿者档案查询条件
+type VolunteerProfileQuery struct {
+	OrgID            string
+	Keyword          string // 匹配昵称、姓名
+	Province         string
+	City             string
+	Skills           []string
+	Languages        []string
+	DialectGroupPath string   // 会说该方言分类（含下级分类）
+	Bounds           geo.BBox // 常住地所在范围，无效时不限
+	Limit            int
+}
+
+// VolunteerCertificationExpiry 即将到期的证书及持有人
+type VolunteerCertificationExpiry struct {
+	Certification entity.VolunteerCertification
+	Profile       entity.VolunteerProfile
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/Snowitty-Re/CNtunyuan/internal/application/service/mocks"
 	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/valueobject"
 	pkgErrors "github.com/Snowitty-Re/CNtunyuan/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -18,34 +19,29 @@ type MockTokenService struct {
 	mock.Mock
 }
 
-func (m *MockTokenService) Generate(userID, orgID string, role string) (string, error) {
-	args := m.Called(userID, orgID, role)
-	return args.String(0), args.Error(1)
+func (m *MockTokenService) GenerateTokenPair(ctx context.Context, user *entity.User) (*TokenPair, error) {
+	args := m.Called(ctx, user)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*TokenPair), args.Error(1)
 }
 
-func (m *MockTokenService) Validate(token string) (*TokenClaims, error) {
-	args := m.Called(token)
+func (m *MockTokenService) ValidateToken(ctx context.Context, token string) (*TokenClaims, error) {
+	args := m.Called(ctx, token)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*TokenClaims), args.Error(1)
 }
 
-func (m *MockTokenService) Refresh(token string) (string, error) {
-	args := m.Called(token)
-	return args.String(0), args.Error(1)
-}
-
-func (m *MockTokenService) Revoke(token string) error {
-	args := m.Called(token)
+func (m *MockTokenService) RevokeToken(ctx context.Context, token string) error {
+	args := m.Called(ctx, token)
 	return args.Error(0)
 }
 
-// TokenClaims 令牌声明
-type TokenClaims struct {
-	UserID string
-	OrgID  string
-	Role   string
+func testTokenPair() *TokenPair {
+	return &TokenPair{AccessToken: "access-token", RefreshToken: "refresh-token", ExpiresIn: 3600}
 }
 
 func TestAuthService_Login(t *testing.T) {
@@ -54,9 +50,21 @@ func TestAuthService_Login(t *testing.T) {
 	authService := NewAuthService(userRepo, tokenService, nil, nil)
 	ctx := context.Background()
 
+	newUser := func(status entity.UserStatus) *entity.User {
+		user := &entity.User{
+			BaseEntity: entity.BaseEntity{ID: "user-001"},
+			Phone:      "13800138000",
+			Role:       "volunteer",
+			OrgID:      "org-001",
+			Status:     status,
+		}
+		user.SetPassword("password123")
+		return user
+	}
+
 	tests := []struct {
 		name     string
-		phone    string
+		username string
 		password string
 		mock     func()
 		wantErr  bool
@@ -64,64 +72,41 @@ func TestAuthService_Login(t *testing.T) {
 	}{
 		{
 			name:     "success - login with valid credentials",
-			phone:    "13800138000",
+			username: "13800138000",
 			password: "password123",
 			mock: func() {
-				user := &entity.User{
-					ID:       "user-001",
-					Phone:    "13800138000",
-					Password: "$2a$10$N9qo8uLOickgx2ZMRZoMy.MqrzM8mU7sJ7oJ0.9gHzb8N8b3r8G8a", // bcrypt hash
-					Role:     "volunteer",
-					OrgID:    "org-001",
-					Status:   entity.UserStatusActive,
-				}
-				user.SetPassword("password123")
-				userRepo.On("FindByPhone", ctx, "13800138000").Return(user, nil).Once()
-				userRepo.On("UpdateLastLogin", ctx, "user-001", mock.Anything).Return(nil).Once()
-				tokenService.On("Generate", "user-001", "org-001", "volunteer").Return("test-token", nil).Once()
+				userRepo.On("FindByPhoneOrNickname", ctx, "13800138000").Return(newUser(entity.UserStatusActive), nil).Once()
+				userRepo.On("Update", ctx, mock.AnythingOfType("*entity.User")).Return(nil).Once()
+				tokenService.On("GenerateTokenPair", ctx, mock.AnythingOfType("*entity.User")).Return(testTokenPair(), nil).Once()
 			},
 			wantErr: false,
 		},
 		{
 			name:     "fail - user not found",
-			phone:    "13800138000",
+			username: "13800138000",
 			password: "password123",
 			mock: func() {
-				userRepo.On("FindByPhone", ctx, "13800138000").Return(nil, errors.New("user not found")).Once()
+				userRepo.On("FindByPhoneOrNickname", ctx, "13800138000").Return(nil, errors.New("user not found")).Once()
 			},
 			wantErr: true,
-			errCode: pkgErrors.CodeUserNotFound,
+			errCode: pkgErrors.CodeInvalidPassword,
 		},
 		{
 			name:     "fail - invalid password",
-			phone:    "13800138000",
+			username: "13800138000",
 			password: "wrongpassword",
 			mock: func() {
-				user := &entity.User{
-					ID:       "user-001",
-					Phone:    "13800138000",
-					Password: "$2a$10$N9qo8uLOickgx2ZMRZoMy.MqrzM8mU7sJ7oJ0.9gHzb8N8b3r8G8a",
-					Status:   entity.UserStatusActive,
-				}
-				user.SetPassword("password123")
-				userRepo.On("FindByPhone", ctx, "13800138000").Return(user, nil).Once()
+				userRepo.On("FindByPhoneOrNickname", ctx, "13800138000").Return(newUser(entity.UserStatusActive), nil).Once()
 			},
 			wantErr: true,
 			errCode: pkgErrors.CodeInvalidPassword,
 		},
 		{
 			name:     "fail - account disabled",
-			phone:    "13800138000",
+			username: "13800138000",
 			password: "password123",
 			mock: func() {
-				user := &entity.User{
-					ID:       "user-001",
-					Phone:    "13800138000",
-					Password: "$2a$10$N9qo8uLOickgx2ZMRZoMy.MqrzM8mU7sJ7oJ0.9gHzb8N8b3r8G8a",
-					Status:   entity.UserStatusInactive,
-				}
-				user.SetPassword("password123")
-				userRepo.On("FindByPhone", ctx, "13800138000").Return(user, nil).Once()
+				userRepo.On("FindByPhoneOrNickname", ctx, "13800138000").Return(newUser(entity.UserStatusInactive), nil).Once()
 			},
 			wantErr: true,
 			errCode: pkgErrors.CodeAccountDisabled,
@@ -131,7 +116,7 @@ func TestAuthService_Login(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mock()
-			token, user, err := authService.Login(ctx, tt.phone, tt.password, "127.0.0.1")
+			result, user, err := authService.Login(ctx, valueobject.LoginCredentials{Username: tt.username, Password: tt.password}, "127.0.0.1")
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -140,8 +125,10 @@ func TestAuthService_Login(t *testing.T) {
 				}
 			} else {
 				assert.NoError(t, err)
-				assert.NotEmpty(t, token)
+				assert.Equal(t, "access-token", result.AccessToken)
+				assert.Equal(t, "Bearer", result.TokenType)
 				assert.NotNil(t, user)
+				assert.NotNil(t, user.LastLoginAt)
 			}
 
 			userRepo.AssertExpectations(t)
@@ -154,27 +141,36 @@ func TestAuthService_Logout(t *testing.T) {
 	userRepo := new(mocks.UserRepositoryMock)
 	tokenService := new(MockTokenService)
 	authService := NewAuthService(userRepo, tokenService, nil, nil)
+	ctx := context.Background()
 
 	tests := []struct {
-		name  string
-		token string
-		mock  func()
+		name    string
+		token   string
+		mock    func()
 		wantErr bool
 	}{
 		{
 			name:  "success - logout",
 			token: "valid-token",
 			mock: func() {
-				tokenService.On("Revoke", "valid-token").Return(nil).Once()
+				tokenService.On("RevokeToken", ctx, "valid-token").Return(nil).Once()
 			},
 			wantErr: false,
 		},
+		{
+			name:  "fail - revoke error",
+			token: "broken-token",
+			mock: func() {
+				tokenService.On("RevokeToken", ctx, "broken-token").Return(errors.New("cache unavailable")).Once()
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mock()
-			err := authService.Logout(tt.token)
+			err := authService.Logout(ctx, tt.token)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -191,18 +187,24 @@ func TestAuthService_RefreshToken(t *testing.T) {
 	userRepo := new(mocks.UserRepositoryMock)
 	tokenService := new(MockTokenService)
 	authService := NewAuthService(userRepo, tokenService, nil, nil)
+	ctx := context.Background()
+
+	claims := &TokenClaims{UserID: "user-001", OrgID: "org-001", Role: "volunteer"}
 
 	tests := []struct {
-		name  string
-		token string
-		mock  func()
+		name    string
+		token   string
+		mock    func()
 		wantErr bool
+		errCode pkgErrors.ErrorCode
 	}{
 		{
 			name:  "success - refresh token",
 			token: "old-token",
 			mock: func() {
-				tokenService.On("Refresh", "old-token").Return("new-token", nil).Once()
+				tokenService.On("ValidateToken", ctx, "old-token").Return(claims, nil).Once()
+				userRepo.On("FindByID", ctx, "user-001").Return(&entity.User{BaseEntity: entity.BaseEntity{ID: "user-001"}, Status: entity.UserStatusActive}, nil).Once()
+				tokenService.On("GenerateTokenPair", ctx, mock.AnythingOfType("*entity.User")).Return(testTokenPair(), nil).Once()
 			},
 			wantErr: false,
 		},
@@ -210,24 +212,49 @@ func TestAuthService_RefreshToken(t *testing.T) {
 			name:  "fail - invalid token",
 			token: "invalid-token",
 			mock: func() {
-				tokenService.On("Refresh", "invalid-token").Return("", errors.New("invalid token")).Once()
+				tokenService.On("ValidateToken", ctx, "invalid-token").Return(nil, errors.New("invalid token")).Once()
+			},
+			wantErr: true,
+			errCode: pkgErrors.CodeInvalidToken,
+		},
+		{
+			name:  "fail - expired token",
+			token: "expired-token",
+			mock: func() {
+				tokenService.On("ValidateToken", ctx, "expired-token").Return(nil, pkgErrors.ErrTokenExpired).Once()
+			},
+			wantErr: true,
+			errCode: pkgErrors.CodeTokenExpired,
+		},
+		{
+			name:  "fail - account disabled",
+			token: "disabled-token",
+			mock: func() {
+				tokenService.On("ValidateToken", ctx, "disabled-token").Return(claims, nil).Once()
+				userRepo.On("FindByID", ctx, "user-001").Return(&entity.User{BaseEntity: entity.BaseEntity{ID: "user-001"}, Status: entity.UserStatusInactive}, nil).Once()
 			},
 			wantErr: true,
+			errCode: pkgErrors.CodeAccountDisabled,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mock()
-			newToken, err := authService.RefreshToken(tt.token)
+			result, user, err := authService.RefreshToken(ctx, tt.token)
 
 			if tt.wantErr {
 				assert.Error(t, err)
+				if tt.errCode != 0 {
+					assert.Equal(t, tt.errCode, pkgErrors.GetCode(err))
+				}
 			} else {
 				assert.NoError(t, err)
-				assert.NotEmpty(t, newToken)
+				assert.NotEmpty(t, result.AccessToken)
+				assert.NotNil(t, user)
 			}
 
+			userRepo.AssertExpectations(t)
 			tokenService.AssertExpectations(t)
 		})
 	}
@@ -237,11 +264,12 @@ func TestAuthService_ValidateToken(t *testing.T) {
 	userRepo := new(mocks.UserRepositoryMock)
 	tokenService := new(MockTokenService)
 	authService := NewAuthService(userRepo, tokenService, nil, nil)
+	ctx := context.Background()
 
 	tests := []struct {
-		name  string
-		token string
-		mock  func()
+		name    string
+		token   string
+		mock    func()
 		wantErr bool
 	}{
 		{
@@ -253,7 +281,7 @@ func TestAuthService_ValidateToken(t *testing.T) {
 					OrgID:  "org-001",
 					Role:   "volunteer",
 				}
-				tokenService.On("Validate", "valid-token").Return(claims, nil).Once()
+				tokenService.On("ValidateToken", ctx, "valid-token").Return(claims, nil).Once()
 			},
 			wantErr: false,
 		},
@@ -261,7 +289,7 @@ func TestAuthService_ValidateToken(t *testing.T) {
 			name:  "fail - invalid token",
 			token: "invalid-token",
 			mock: func() {
-				tokenService.On("Validate", "invalid-token").Return(nil, errors.New("invalid token")).Once()
+				tokenService.On("ValidateToken", ctx, "invalid-token").Return(nil, errors.New("invalid token")).Once()
 			},
 			wantErr: true,
 		},
@@ -270,7 +298,7 @@ func TestAuthService_ValidateToken(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mock()
-			claims, err := authService.ValidateToken(tt.token)
+			claims, err := authService.ValidateToken(ctx, tt.token)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -291,9 +319,9 @@ func TestAuthService_GetCurrentUser(t *testing.T) {
 	ctx := context.Background()
 
 	tests := []struct {
-		name   string
-		userID string
-		mock   func()
+		name    string
+		userID  string
+		mock    func()
 		wantErr bool
 	}{
 		{
@@ -301,13 +329,12 @@ func TestAuthService_GetCurrentUser(t *testing.T) {
 			userID: "user-001",
 			mock: func() {
 				user := &entity.User{
-					ID:        "user-001",
-					Nickname:  "Test User",
-					Phone:     "13800138000",
-					Role:      "volunteer",
-					OrgID:     "org-001",
-					Status:    entity.UserStatusActive,
-					CreatedAt: time.Now(),
+					BaseEntity: entity.BaseEntity{ID: "user-001", CreatedAt: time.Now()},
+					Nickname:   "Test User",
+					Phone:      "13800138000",
+					Role:       "volunteer",
+					OrgID:      "org-001",
+					Status:     entity.UserStatusActive,
 				}
 				userRepo.On("FindByID", ctx, "user-001").Return(user, nil).Once()
 			},
@@ -340,61 +367,3 @@ func TestAuthService_GetCurrentUser(t *testing.T) {
 		})
 	}
 }
-
-func TestAuthService_UpdatePassword(t *testing.T) {
-	userRepo := new(mocks.UserRepositoryMock)
-	tokenService := new(MockTokenService)
-	authService := NewAuthService(userRepo, tokenService, nil, nil)
-	ctx := context.Background()
-
-	tests := []struct {
-		name        string
-		userID      string
-		oldPassword string
-		newPassword string
-		mock        func()
-		wantErr     bool
-	}{
-		{
-			name:        "success - update password",
-			userID:      "user-001",
-			oldPassword: "oldpassword123",
-			newPassword: "newpassword123",
-			mock: func() {
-				user := &entity.User{
-					ID:       "user-001",
-					Password: "$2a$10$N9qo8uLOickgx2ZMRZoMy.MqrzM8mU7sJ7oJ0.9gHzb8N8b3r8G8a",
-				}
-				user.SetPassword("oldpassword123")
-				userRepo.On("FindByID", ctx, "user-001").Return(user, nil).Once()
-				userRepo.On("Update", ctx, mock.AnythingOfType("*entity.User")).Return(nil).Once()
-			},
-			wantErr: false,
-		},
-		{
-			name:        "fail - user not found",
-			userID:      "user-notfound",
-			oldPassword: "oldpassword123",
-			newPassword: "newpassword123",
-			mock: func() {
-				userRepo.On("FindByID", ctx, "user-notfound").Return(nil, errors.New("user not found")).Once()
-			},
-			wantErr: true,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			tt.mock()
-			err := authService.UpdatePassword(ctx, tt.userID, tt.oldPassword, tt.newPassword)
-
-			if tt.wantErr {
-				assert.Error(t, err)
-			} else {
-				assert.NoError(t, err)
-			}
-
-			userRepo.AssertExpectations(t)
-		})
-	}
-}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+)
+
+// CaseSearchIndex 案件全文检索索引接口
+// 内置实现为纯 Go 内存倒排索引，也可替换为 Elasticsearch 等外部搜索引擎
+type CaseSearchIndex interface {
+	// Index 新增或更新案件索引
+	Index(ctx context.Context, mp *entity.MissingPerson) error
+
+	// Delete 删除案件索引
+	Delete(ctx context.Context, id string) error
+
+	// Search 检索，返回按相关度排序的案件ID及高亮摘要
+	Search(ctx context.Context, query CaseSearchQuery) (*CaseSearchResult, error)
+
+	// Rebuild 全量重建索引：反复调用 next 获取案件批次，返回空批次时结束
+	// 重建完成前旧索引继续提供查询，返回索引的案件数
+	Rebuild(ctx context.Context, next func() ([]entity.MissingPerson, error)) (int, error)
+
+	// Checkpoint 记录索引已与数据库同步到的时间点并持久化，下次启动从该时间点增量同步
+	Checkpoint(ctx context.Context, syncedAt time.Time) error
+
+	// Stats 索引状态
+	Stats() CaseSearchStats
+}
+
+// CaseSearchQuery 检索参数
+type CaseSearchQuery struct {
+	Keyword string
+	Offset  int
+	Limit   int
+}
+
+// CaseSearchHit 检索命中
+type CaseSearchHit struct {
+	ID         string
+	Score      float64
+	Highlights map[string]string // 字段 -> 高亮摘要，命中部分以 <em></em> 标记
+}
+
+// CaseSearchResult 检索结果
+type CaseSearchResult struct {
+	Total int
+	Hits  []CaseSearchHit
+}
+
+// CaseSearchStats 索引状态
+type CaseSearchStats struct {
+	Engine    string
+	Documents int
+	Terms     int
+	SyncedAt  time.Time // 零值表示尚未完成同步，需全量重建
+}
@@ -0,0 +1,21 @@
+package service
+
+import "context"
+
+// ContentFilter 用户发布内容的敏感词过滤接口
+// 内置实现为本地词典，也可替换为第三方内容审核服务
+type ContentFilter interface {
+	// Check 检查文本，返回命中的敏感词及替换后的文本
+	Check(ctx context.Context, text string) (*ContentCheckResult, error)
+}
+
+// ContentCheckResult 敏感词检查结果
+type ContentCheckResult struct {
+	Words  []string // 命中的敏感词
+	Masked string   // 敏感词替换为 * 后的文本，未命中时与原文相同
+}
+
+// Hit 是否命中敏感词
+func (r *ContentCheckResult) Hit() bool {
+	return len(r.Words) > 0
+}
@@ -0,0 +1,244 @@
+package service
+
+import (
+	"sort"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+)
+
+const (
+	// dialectAdvanceScore 进入下一级所需的最低累计得分（一次"家乡话"或两次"熟悉"）
+	dialectAdvanceScore = 4
+	// dialectAdvanceMargin 领先第二名的最小分差
+	dialectAdvanceMargin = 2
+	// DefaultDialectBatchSize 每次推荐播放的片段数
+	DefaultDialectBatchSize = 5
+)
+
+// RegionCandidate 推断的可能籍贯，City、Region 为空表示只能推断到上一级
+type RegionCandidate struct {
+	Province   string
+	City       string
+	Region     string
+	Score      int
+	Confidence float64
+	Evidence   int // 支持该地区的片段数
+}
+
+// dialectScores 按省、市、片区累计的反应得分
+type dialectScores struct {
+	province map[string]int
+	city     map[[2]string]int
+	region   map[[3]string]int
+	evidence map[[3]string]int
+}
+
+func scoreAnswers(answers []entity.DialectSessionAnswer) *dialectScores {
+	s := &dialectScores{
+		province: make(map[string]int),
+		city:     make(map[[2]string]int),
+		region:   make(map[[3]string]int),
+		evidence: make(map[[3]string]int),
+	}
+	for _, a := range answers {
+		if a.Province == "" {
+			continue
+		}
+		w := a.Recognition.Weight()
+		positive := w > 0
+		s.province[a.Province] += w
+		if positive {
+			s.evidence[[3]string{a.Province}]++
+		}
+		if a.City == "" {
+			continue
+		}
+		s.city[[2]string{a.Province, a.City}] += w
+		if positive {
+			s.evidence[[3]string{a.Province, a.City}]++
+		}
+		if a.Region == "" {
+			continue
+		}
+		s.region[[3]string{a.Province, a.City, a.Region}] += w
+		if positive {
+			s.evidence[[3]string{a.Province, a.City, a.Region}]++
+		}
+	}
+	return s
+}
+
+// DialectOriginInference 方言辨识籍贯推断
+type DialectOriginInference struct{}
+
+// NewDialectOriginInference 创建方言辨识籍贯推断器
+func NewDialectOriginInference() *DialectOriginInference {
+	return &DialectOriginInference{}
+}
+
+// Rank 根据受测者反应给出可能籍贯排名。片区得分叠加所属市、省得分，
+// 同一省市下已有更具体的候选时不再单独列出上一级
+func (i *DialectOriginInference) Rank(answers []entity.DialectSessionAnswer, limit int) []RegionCandidate {
+	s := scoreAnswers(answers)
+
+	var list []RegionCandidate
+	covered := make(map[[3]string]bool)
+	for key, score := range s.region {
+		total := s.province[key[0]] + s.city[[2]string{key[0], key[1]}] + score
+		if total <= 0 || score <= 0 {
+			continue
+		}
+		list = append(list, RegionCandidate{Province: key[0], City: key[1], Region: key[2], Score: total, Evidence: s.evidence[key]})
+		covered[[3]string{key[0], key[1]}] = true
+		covered[[3]string{key[0]}] = true
+	}
+	for key, score := range s.city {
+		k := [3]string{key[0], key[1]}
+		total := s.province[key[0]] + score
+		if covered[k] || total <= 0 || score <= 0 {
+			continue
+		}
+		list = append(list, RegionCandidate{Province: key[0], City: key[1], Score: total, Evidence: s.evidence[k]})
+		covered[[3]string{key[0]}] = true
+	}
+	for province, score := range s.province {
+		k := [3]string{province}
+		if covered[k] || score <= 0 {
+			continue
+		}
+		list = append(list, RegionCandidate{Province: province, Score: score, Evidence: s.evidence[k]})
+	}
+
+	sort.Slice(list, func(a, b int) bool {
+		if list[a].Score != list[b].Score {
+			return list[a].Score > list[b].Score
+		}
+		if list[a].Evidence != list[b].Evidence {
+			return list[a].Evidence > list[b].Evidence
+		}
+		return list[a].Province+list[a].City+list[a].Region < list[b].Province+list[b].City+list[b].Region
+	})
+
+	var total int
+	for _, c := range list {
+		total += c.Score
+	}
+	for k := range list {
+		list[k].Confidence = float64(list[k].Score) / float64(total)
+	}
+	if limit > 0 && len(list) > limit {
+		list = list[:limit]
+	}
+	return list
+}
+
+// Advance 根据累计反应决定是否进入下一级，或在当前范围被否定时退回上一级
+func (i *DialectOriginInference) Advance(session *entity.DialectSession, answers []entity.DialectSessionAnswer) (entity.DialectSessionStage, string, string) {
+	s := scoreAnswers(answers)
+
+	switch session.Stage {
+	case entity.DialectStageProvince:
+		if top, ok := leader(s.province); ok {
+			return entity.DialectStageCity, top, ""
+		}
+	case entity.DialectStageCity:
+		if s.province[session.Province] < 0 {
+			return entity.DialectStageProvince, "", ""
+		}
+		scores := make(map[string]int)
+		for k, v := range s.city {
+			if k[0] == session.Province {
+				scores[k[1]] = v
+			}
+		}
+		if top, ok := leader(scores); ok {
+			return entity.DialectStageRegion, session.Province, top
+		}
+	case entity.DialectStageRegion:
+		if s.city[[2]string{session.Province, session.City}] < 0 {
+			return entity.DialectStageCity, session.Province, ""
+		}
+	}
+	return session.Stage, session.Province, session.City
+}
+
+// leader 得分达到阈值且领先第二名足够多的候选
+func leader(scores map[string]int) (string, bool) {
+	var top, second string
+	for k, v := range scores {
+		switch {
+		case top == "" || v > scores[top] || (v == scores[top] && k < top):
+			second, top = top, k
+		case second == "" || v > scores[second]:
+			second = k
+		}
+	}
+	if top == "" || scores[top] < dialectAdvanceScore {
+		return "", false
+	}
+	if second != "" && scores[top]-scores[second] < dialectAdvanceMargin {
+		return "", false
+	}
+	return top, true
+}
+
+// PickClips 从候选片段中挑选下一批播放片段：每个尚未测试的省（市、片区）各取一段，
+// 不足时补充已有正面反应地区的其他片段用于确认。clips 应已按推荐优先级排序并排除已播放片段
+func (i *DialectOriginInference) PickClips(session *entity.DialectSession, clips []entity.Dialect, limit int) []entity.Dialect {
+	if limit <= 0 {
+		limit = DefaultDialectBatchSize
+	}
+	groupOf := func(province, city, region string) string {
+		switch session.Stage {
+		case entity.DialectStageCity:
+			return city
+		case entity.DialectStageRegion:
+			return region
+		default:
+			return province
+		}
+	}
+
+	tested := make(map[string]int)
+	for _, a := range session.Answers {
+		if session.Stage != entity.DialectStageProvince && a.Province != session.Province {
+			continue
+		}
+		if session.Stage == entity.DialectStageRegion && a.City != session.City {
+			continue
+		}
+		tested[groupOf(a.Province, a.City, a.Region)] += a.Recognition.Weight()
+	}
+
+	var picked, confirm []entity.Dialect
+	seen := make(map[string]bool)
+	for _, d := range clips {
+		g := groupOf(d.Province, d.City, d.Region)
+		if g == "" {
+			continue
+		}
+		if score, ok := tested[g]; ok {
+			if score > 0 && !seen[g] {
+				confirm = append(confirm, d)
+				seen[g] = true
+			}
+			continue
+		}
+		if seen[g] {
+			continue
+		}
+		seen[g] = true
+		picked = append(picked, d)
+		if len(picked) >= limit {
+			return picked
+		}
+	}
+
+	for _, d := range confirm {
+		if len(picked) >= limit {
+			break
+		}
+		picked = append(picked, d)
+	}
+	return picked
+}
@@ -0,0 +1,276 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func originAnswer(province, city, region string, r entity.DialectRecognition) entity.DialectSessionAnswer {
+	return entity.DialectSessionAnswer{Province: province, City: city, Region: region, Recognition: r}
+}
+
+func originClip(id, province, city, region string) entity.Dialect {
+	d := entity.Dialect{Province: province, City: city, Region: region}
+	d.ID = id
+	return d
+}
+
+func TestDialectOriginInference_Rank(t *testing.T) {
+	const (
+		native     = entity.DialectRecognitionNative
+		familiar   = entity.DialectRecognitionFamiliar
+		partial    = entity.DialectRecognitionPartial
+		unfamiliar = entity.DialectRecognitionUnfamiliar
+	)
+
+	tests := []struct {
+		name    string
+		answers []entity.DialectSessionAnswer
+		limit   int
+		want    []RegionCandidate
+	}{
+		{name: "no answers"},
+		{
+			name:    "answers without province ignored",
+			answers: []entity.DialectSessionAnswer{originAnswer("", "", "", native)},
+		},
+		{
+			// 片区得分叠加所属市、省得分，上一级不再单独列出
+			name:    "region includes city and province scores",
+			answers: []entity.DialectSessionAnswer{originAnswer("四川省", "成都市", "成渝片", native)},
+			want:    []RegionCandidate{{Province: "四川省", City: "成都市", Region: "成渝片", Score: 12, Confidence: 1, Evidence: 1}},
+		},
+		{
+			name: "negative region falls back to city",
+			answers: []entity.DialectSessionAnswer{
+				originAnswer("四川省", "成都市", "成渝片", unfamiliar),
+				originAnswer("四川省", "成都市", "", native),
+			},
+			want: []RegionCandidate{{Province: "四川省", City: "成都市", Score: 6, Confidence: 1, Evidence: 1}},
+		},
+		{
+			name: "negative province excluded",
+			answers: []entity.DialectSessionAnswer{
+				originAnswer("广东省", "", "", unfamiliar),
+				originAnswer("四川省", "", "", partial),
+			},
+			want: []RegionCandidate{{Province: "四川省", Score: 1, Confidence: 1, Evidence: 1}},
+		},
+		{
+			name: "ties broken by evidence",
+			answers: []entity.DialectSessionAnswer{
+				originAnswer("云南省", "", "", native),
+				originAnswer("四川省", "", "", familiar),
+				originAnswer("四川省", "", "", familiar),
+			},
+			want: []RegionCandidate{
+				{Province: "四川省", Score: 4, Confidence: 0.5, Evidence: 2},
+				{Province: "云南省", Score: 4, Confidence: 0.5, Evidence: 1},
+			},
+		},
+		{
+			name: "ties broken by name",
+			answers: []entity.DialectSessionAnswer{
+				originAnswer("四川省", "", "", native),
+				originAnswer("云南省", "", "", native),
+			},
+			want: []RegionCandidate{
+				{Province: "云南省", Score: 4, Confidence: 0.5, Evidence: 1},
+				{Province: "四川省", Score: 4, Confidence: 0.5, Evidence: 1},
+			},
+		},
+		{
+			// 置信度按全部候选计算，再截取前几位
+			name: "limit",
+			answers: []entity.DialectSessionAnswer{
+				originAnswer("四川省", "", "", native),
+				originAnswer("云南省", "", "", familiar),
+				originAnswer("贵州省", "", "", familiar),
+			},
+			limit: 1,
+			want:  []RegionCandidate{{Province: "四川省", Score: 4, Confidence: 0.5, Evidence: 1}},
+		},
+	}
+
+	inf := NewDialectOriginInference()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, inf.Rank(tt.answers, tt.limit))
+		})
+	}
+}
+
+func TestDialectOriginInference_Advance(t *testing.T) {
+	const (
+		native     = entity.DialectRecognitionNative
+		familiar   = entity.DialectRecognitionFamiliar
+		unfamiliar = entity.DialectRecognitionUnfamiliar
+	)
+
+	tests := []struct {
+		name         string
+		session      entity.DialectSession
+		answers      []entity.DialectSessionAnswer
+		wantStage    entity.DialectSessionStage
+		wantProvince string
+		wantCity     string
+	}{
+		{
+			name:         "province recognized",
+			session:      entity.DialectSession{Stage: entity.DialectStageProvince},
+			answers:      []entity.DialectSessionAnswer{originAnswer("四川省", "成都市", "", native)},
+			wantStage:    entity.DialectStageCity,
+			wantProvince: "四川省",
+		},
+		{
+			name:      "score below threshold",
+			session:   entity.DialectSession{Stage: entity.DialectStageProvince},
+			answers:   []entity.DialectSessionAnswer{originAnswer("四川省", "", "", familiar)},
+			wantStage: entity.DialectStageProvince,
+		},
+		{
+			name:    "lead too small",
+			session: entity.DialectSession{Stage: entity.DialectStageProvince},
+			answers: []entity.DialectSessionAnswer{
+				originAnswer("四川省", "", "", native),
+				originAnswer("重庆市", "", "", native),
+			},
+			wantStage: entity.DialectStageProvince,
+		},
+		{
+			name:    "lead exactly the margin",
+			session: entity.DialectSession{Stage: entity.DialectStageProvince},
+			answers: []entity.DialectSessionAnswer{
+				originAnswer("四川省", "", "", native),
+				originAnswer("重庆市", "", "", familiar),
+			},
+			wantStage:    entity.DialectStageCity,
+			wantProvince: "四川省",
+		},
+		{
+			name:    "city recognized within the province",
+			session: entity.DialectSession{Stage: entity.DialectStageCity, Province: "四川省"},
+			answers: []entity.DialectSessionAnswer{
+				originAnswer("重庆市", "重庆市", "", native),
+				originAnswer("四川省", "成都市", "", native),
+			},
+			wantStage:    entity.DialectStageRegion,
+			wantProvince: "四川省",
+			wantCity:     "成都市",
+		},
+		{
+			name:         "province rejected",
+			session:      entity.DialectSession{Stage: entity.DialectStageCity, Province: "四川省"},
+			answers:      []entity.DialectSessionAnswer{originAnswer("四川省", "成都市", "", unfamiliar)},
+			wantStage:    entity.DialectStageProvince,
+			wantProvince: "",
+		},
+		{
+			name:         "city rejected",
+			session:      entity.DialectSession{Stage: entity.DialectStageRegion, Province: "四川省", City: "成都市"},
+			answers:      []entity.DialectSessionAnswer{originAnswer("四川省", "成都市", "成渝片", unfamiliar)},
+			wantStage:    entity.DialectStageCity,
+			wantProvince: "四川省",
+		},
+		{
+			name:         "region stage stays",
+			session:      entity.DialectSession{Stage: entity.DialectStageRegion, Province: "四川省", City: "成都市"},
+			answers:      []entity.DialectSessionAnswer{originAnswer("四川省", "成都市", "成渝片", native)},
+			wantStage:    entity.DialectStageRegion,
+			wantProvince: "四川省",
+			wantCity:     "成都市",
+		},
+	}
+
+	inf := NewDialectOriginInference()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stage, province, city := inf.Advance(&tt.session, tt.answers)
+			assert.Equal(t, tt.wantStage, stage)
+			assert.Equal(t, tt.wantProvince, province)
+			assert.Equal(t, tt.wantCity, city)
+		})
+	}
+}
+
+func TestLeader(t *testing.T) {
+	tests := []struct {
+		name   string
+		scores map[string]int
+		want   string
+	}{
+		{"empty", map[string]int{}, ""},
+		{"single above threshold", map[string]int{"a": 4}, "a"},
+		{"single below threshold", map[string]int{"a": 3}, ""},
+		{"clear lead", map[string]int{"a": 2, "b": 6, "c": 4}, "b"},
+		{"tie", map[string]int{"a": 6, "b": 6}, ""},
+		{"lead below margin", map[string]int{"a": 5, "b": 4}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := leader(tt.scores)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.want != "", ok)
+		})
+	}
+}
+
+func TestDialectOriginInference_PickClips(t *testing.T) {
+	clips := []entity.Dialect{
+		originClip("sc1", "四川省", "成都市", "成渝片"),
+		originClip("sc2", "四川省", "绵阳市", "岷江片"),
+		originClip("gd1", "广东省", "广州市", ""),
+		originClip("yn1", "云南省", "昆明市", ""),
+		originClip("gz1", "贵州省", "贵阳市", ""),
+		originClip("gd2", "广东省", "深圳市", ""),
+		originClip("nx1", "", "", ""),
+	}
+	ids := func(list []entity.Dialect) []string {
+		out := make([]string, len(list))
+		for i, d := range list {
+			out[i] = d.ID
+		}
+		return out
+	}
+	inf := NewDialectOriginInference()
+
+	t.Run("one clip per untested province", func(t *testing.T) {
+		session := &entity.DialectSession{Stage: entity.DialectStageProvince}
+		assert.Equal(t, []string{"sc1", "gd1", "yn1", "gz1"}, ids(inf.PickClips(session, clips, 0)))
+		assert.Equal(t, []string{"sc1", "gd1"}, ids(inf.PickClips(session, clips, 2)))
+	})
+
+	t.Run("tested provinces used for confirmation", func(t *testing.T) {
+		session := &entity.DialectSession{
+			Stage: entity.DialectStageProvince,
+			Answers: []entity.DialectSessionAnswer{
+				originAnswer("四川省", "成都市", "", entity.DialectRecognitionFamiliar),
+				originAnswer("广东省", "广州市", "", entity.DialectRecognitionUnfamiliar),
+			},
+		}
+		// 已否定的广东不再播放，四川的片段排在未测试省份之后
+		assert.Equal(t, []string{"yn1", "gz1", "sc1"}, ids(inf.PickClips(session, clips, 5)))
+		assert.Equal(t, []string{"yn1", "gz1"}, ids(inf.PickClips(session, clips, 2)))
+	})
+
+	t.Run("city stage within the province", func(t *testing.T) {
+		session := &entity.DialectSession{
+			Stage:    entity.DialectStageCity,
+			Province: "四川省",
+			Answers: []entity.DialectSessionAnswer{
+				originAnswer("四川省", "成都市", "", entity.DialectRecognitionNative),
+				// 其他省份的反应不影响本省内的挑选
+				originAnswer("广东省", "绵阳市", "", entity.DialectRecognitionUnfamiliar),
+			},
+		}
+		province := []entity.Dialect{clips[0], clips[1], originClip("sc3", "四川省", "", "")}
+		assert.Equal(t, []string{"sc2", "sc1"}, ids(inf.PickClips(session, province, 5)))
+	})
+
+	t.Run("no clips", func(t *testing.T) {
+		assert.Empty(t, inf.PickClips(&entity.DialectSession{Stage: entity.DialectStageProvince}, nil, 5))
+	})
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+)
+
+// DialectPlayInterval 播放趋势统计粒度
+type DialectPlayInterval string
+
+const (
+	DialectPlayIntervalDay   DialectPlayInterval = "day"
+	DialectPlayIntervalWeek  DialectPlayInterval = "week"
+	DialectPlayIntervalMonth DialectPlayInterval = "month"
+)
+
+// IsValid 是否为支持的统计粒度
+func (i DialectPlayInterval) IsValid() bool {
+	switch i {
+	case DialectPlayIntervalDay, DialectPlayIntervalWeek, DialectPlayIntervalMonth:
+		return true
+	}
+	return false
+}
+
+// Start 时间所在统计区间的起点，周从周一开始
+func (i DialectPlayInterval) Start(t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	switch i {
+	case DialectPlayIntervalWeek:
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset)
+	case DialectPlayIntervalMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	}
+	return day
+}
+
+// Next 下一个统计区间的起点
+func (i DialectPlayInterval) Next(start time.Time) time.Time {
+	switch i {
+	case DialectPlayIntervalWeek:
+		return start.AddDate(0, 0, 7)
+	case DialectPlayIntervalMonth:
+		return start.AddDate(0, 1, 0)
+	}
+	return start.AddDate(0, 0, 1)
+}
+
+// DialectPlayBucket 一个统计区间的播放数据
+type DialectPlayBucket struct {
+	Start          time.Time
+	Plays          int64
+	Listeners      int64 // 区间内去重收听者数
+	Reported       int64
+	CompletionRate float64
+}
+
+// BucketDialectPlays 将按天、收听者汇总的播放归并为 [from, to) 内连续的统计区间，没有播放的区间补零。
+// 收听者在区间内去重，因此周、月的收听者数不等于各天之和。
+func BucketDialectPlays(days []entity.DialectListenerDay, from, to time.Time, interval DialectPlayInterval) []DialectPlayBucket {
+	if !interval.IsValid() {
+		interval = DialectPlayIntervalDay
+	}
+
+	var buckets []DialectPlayBucket
+	index := make(map[time.Time]int)
+	for start := interval.Start(from); start.Before(to); start = interval.Next(start) {
+		index[start] = len(buckets)
+		buckets = append(buckets, DialectPlayBucket{Start: start})
+	}
+
+	completion := make([]float64, len(buckets))
+	listeners := make([]map[string]struct{}, len(buckets))
+	for _, d := range days {
+		// 数据库返回的日期不带时区，按统计起点所在时区解释
+		day := time.Date(d.Day.Year(), d.Day.Month(), d.Day.Day(), 0, 0, 0, 0, from.Location())
+		i, ok := index[interval.Start(day)]
+		if !ok {
+			continue
+		}
+		buckets[i].Plays += d.Plays
+		buckets[i].Reported += d.Reported
+		completion[i] += d.CompletionSum
+		if listeners[i] == nil {
+			listeners[i] = make(map[string]struct{})
+		}
+		listeners[i][d.ListenerKey] = struct{}{}
+	}
+
+	for i := range buckets {
+		buckets[i].Listeners = int64(len(listeners[i]))
+		if buckets[i].Reported > 0 {
+			buckets[i].CompletionRate = completion[i] / float64(buckets[i].Reported)
+		}
+	}
+	return buckets
+}
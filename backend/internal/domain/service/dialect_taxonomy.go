@@ -0,0 +1,389 @@
+package service
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/hanzi"
+)
+
+// DialectTaxonomyNode 默认方言分类节点，Regions 为 "省" 或 "省/市"
+type DialectTaxonomyNode struct {
+	Name     string
+	Aliases  []string
+	Regions  []string
+	Children []DialectTaxonomyNode
+}
+
+// DefaultDialectTaxonomy 默认方言分类，参照《中国语言地图集》的大区、片划分，
+// 只收录寻亲中常见的地点方言，其余由管理员按需补充
+func DefaultDialectTaxonomy() []DialectTaxonomyNode {
+	return []DialectTaxonomyNode{
+		{Name: "官话", Aliases: []string{"北方话", "北方方言"}, Children: []DialectTaxonomyNode{
+			{Name: "北京官话", Regions: []string{"北京"}, Children: []DialectTaxonomyNode{
+				{Name: "北京话", Aliases: []string{"京片子"}, Regions: []string{"北京"}},
+			}},
+			{Name: "东北官话", Aliases: []string{"东北话"}, Regions: []string{"黑龙江", "吉林", "辽宁"}, Children: []DialectTaxonomyNode{
+				{Name: "哈尔滨话", Regions: []string{"黑龙江/哈尔滨"}},
+				{Name: "长春话", Regions: []string{"吉林/长春"}},
+				{Name: "沈阳话", Regions: []string{"辽宁/沈阳"}},
+			}},
+			{Name: "冀鲁官话", Regions: []string{"河北", "天津"}, Children: []DialectTaxonomyNode{
+				{Name: "天津话", Regions: []string{"天津"}},
+				{Name: "石家庄话", Regions: []string{"河北/石家庄"}},
+				{Name: "济南话", Regions: []string{"山东/济南"}},
+			}},
+			{Name: "胶辽官话", Regions: []string{"山东/青岛", "山东/烟台", "山东/威海", "辽宁/大连"}, Children: []DialectTaxonomyNode{
+				{Name: "青岛话", Regions: []string{"山东/青岛"}},
+				{Name: "烟台话", Regions: []string{"山东/烟台"}},
+				{Name: "大连话", Regions: []string{"辽宁/大连"}},
+			}},
+			{Name: "中原官话", Regions: []string{"河南", "陕西"}, Children: []DialectTaxonomyNode{
+				{Name: "郑州话", Regions: []string{"河南/郑州"}},
+				{Name: "洛阳话", Regions: []string{"河南/洛阳"}},
+				{Name: "西安话", Aliases: []string{"关中话", "陕西话"}, Regions: []string{"陕西/西安"}},
+				{Name: "徐州话", Regions: []string{"江苏/徐州"}},
+			}},
+			{Name: "兰银官话", Regions: []string{"甘肃", "宁夏", "新疆"}, Children: []DialectTaxonomyNode{
+				{Name: "兰州话", Regions: []string{"甘肃/兰州"}},
+				{Name: "银川话", Regions: []string{"宁夏/银川"}},
+			}},
+			{Name: "西南官话", Regions: []string{"四川", "重庆", "云南", "贵州", "湖北"}, Children: []DialectTaxonomyNode{
+				{Name: "成都话", Aliases: []string{"四川话"}, Regions: []string{"四川/成都"}},
+				{Name: "重庆话", Regions: []string{"重庆"}},
+				{Name: "昆明话", Aliases: []string{"云南话"}, Regions: []string{"云南/昆明"}},
+				{Name: "贵阳话", Aliases: []string{"贵州话"}, Regions: []string{"贵州/贵阳"}},
+				{Name: "武汉话", Regions: []string{"湖北/武汉"}},
+				{Name: "桂林话", Regions: []string{"广西/桂林"}},
+			}},
+			{Name: "江淮官话", Aliases: []string{"下江官话"}, Regions: []string{"江苏/南京", "江苏/扬州", "安徽/合肥"}, Children: []DialectTaxonomyNode{
+				{Name: "南京话", Regions: []string{"江苏/南京"}},
+				{Name: "扬州话", Regions: []string{"江苏/扬州"}},
+				{Name: "合肥话", Regions: []string{"安徽/合肥"}},
+			}},
+		}},
+		{Name: "晋语", Aliases: []string{"山西话"}, Regions: []string{"山西"}, Children: []DialectTaxonomyNode{
+			{Name: "并州片", Regions: []string{"山西/太原"}, Children: []DialectTaxonomyNode{
+				{Name: "太原话", Regions: []string{"山西/太原"}},
+			}},
+			{Name: "大包片", Regions: []string{"山西/大同", "内蒙古/包头"}, Children: []DialectTaxonomyNode{
+				{Name: "大同话", Regions: []string{"山西/大同"}},
+				{Name: "包头话", Regions: []string{"内蒙古/包头"}},
+			}},
+			{Name: "志延片", Regions: []string{"陕西/延安"}, Children: []DialectTaxonomyNode{
+				{Name: "延安话", Aliases: []string{"陕北话"}, Regions: []string{"陕西/延安"}},
+			}},
+		}},
+		{Name: "吴语", Aliases: []string{"江南话", "吴方言"}, Regions: []string{"上海", "浙江"}, Children: []DialectTaxonomyNode{
+			{Name: "太湖片", Regions: []string{"上海", "江苏/苏州", "江苏/无锡", "江苏/常州", "浙江/杭州", "浙江/嘉兴", "浙江/湖州", "浙江/绍兴", "浙江/宁波"}, Children: []DialectTaxonomyNode{
+				{Name: "上海话", Aliases: []string{"沪语"}, Regions: []string{"上海"}},
+				{Name: "苏州话", Aliases: []string{"吴侬软语"}, Regions: []string{"江苏/苏州"}},
+				{Name: "无锡话", Regions: []string{"江苏/无锡"}},
+				{Name: "常州话", Regions: []string{"江苏/常州"}},
+				{Name: "杭州话", Regions: []string{"浙江/杭州"}},
+				{Name: "绍兴话", Regions: []string{"浙江/绍兴"}},
+				{Name: "宁波话", Regions: []string{"浙江/宁波"}},
+			}},
+			{Name: "台州片", Regions: []string{"浙江/台州"}, Children: []DialectTaxonomyNode{
+				{Name: "台州话", Regions: []string{"浙江/台州"}},
+			}},
+			{Name: "瓯江片", Regions: []string{"浙江/温州"}, Children: []DialectTaxonomyNode{
+				{Name: "温州话", Aliases: []string{"瓯语"}, Regions: []string{"浙江/温州"}},
+			}},
+			{Name: "婺州片", Regions: []string{"浙江/金华"}, Children: []DialectTaxonomyNode{
+				{Name: "金华话", Regions: []string{"浙江/金华"}},
+			}},
+		}},
+		{Name: "徽语", Regions: []string{"安徽/黄山"}, Children: []DialectTaxonomyNode{
+			{Name: "绩歙片", Regions: []string{"安徽/黄山", "安徽/宣城"}, Children: []DialectTaxonomyNode{
+				{Name: "歙县话", Regions: []string{"安徽/黄山"}},
+				{Name: "绩溪话", Regions: []string{"安徽/宣城"}},
+			}},
+		}},
+		{Name: "赣语", Aliases: []string{"江西话"}, Regions: []string{"江西"}, Children: []DialectTaxonomyNode{
+			{Name: "昌都片", Regions: []string{"江西/南昌", "江西/九江"}, Children: []DialectTaxonomyNode{
+				{Name: "南昌话", Regions: []string{"江西/南昌"}},
+			}},
+			{Name: "宜浏片", Regions: []string{"江西/宜春", "湖南/长沙"}, Children: []DialectTaxonomyNode{
+				{Name: "宜春话", Regions: []string{"江西/宜春"}},
+			}},
+		}},
+		{Name: "湘语", Aliases: []string{"湖南话"}, Regions: []string{"湖南"}, Children: []DialectTaxonomyNode{
+			{Name: "长益片", Regions: []string{"湖南/长沙", "湖南/益阳", "湖南/株洲", "湖南/湘潭"}, Children: []DialectTaxonomyNode{
+				{Name: "长沙话", Regions: []string{"湖南/长沙"}},
+				{Name: "湘潭话", Regions: []string{"湖南/湘潭"}},
+			}},
+			{Name: "娄邵片", Regions: []string{"湖南/娄底", "湖南/邵阳"}, Children: []DialectTaxonomyNode{
+				{Name: "娄底话", Regions: []string{"湖南/娄底"}},
+				{Name: "邵阳话", Regions: []string{"湖南/邵阳"}},
+			}},
+		}},
+		{Name: "闽语", Aliases: []string{"福建话"}, Regions: []string{"福建", "海南"}, Children: []DialectTaxonomyNode{
+			{Name: "闽南片", Aliases: []string{"闽南话", "闽南语", "河洛话"}, Regions: []string{"福建/厦门", "福建/泉州", "福建/漳州", "台湾"}, Children: []DialectTaxonomyNode{
+				{Name: "厦门话", Regions: []string{"福建/厦门"}},
+				{Name: "泉州话", Regions: []string{"福建/泉州"}},
+				{Name: "漳州话", Regions: []string{"福建/漳州"}},
+				{Name: "台湾闽南话", Aliases: []string{"台语"}, Regions: []string{"台湾"}},
+			}},
+			{Name: "潮汕片", Aliases: []string{"潮汕话"}, Regions: []string{"广东/汕头", "广东/潮州", "广东/揭阳"}, Children: []DialectTaxonomyNode{
+				{Name: "汕头话", Regions: []string{"广东/汕头"}},
+				{Name: "潮州话", Regions: []string{"广东/潮州"}},
+			}},
+			{Name: "闽东片", Aliases: []string{"闽东话"}, Regions: []string{"福建/福州", "福建/宁德"}, Children: []DialectTaxonomyNode{
+				{Name: "福州话", Regions: []string{"福建/福州"}},
+			}},
+			{Name: "莆仙片", Aliases: []string{"莆仙话"}, Regions: []string{"福建/莆田"}, Children: []DialectTaxonomyNode{
+				{Name: "莆田话", Regions: []string{"福建/莆田"}},
+			}},
+			{Name: "琼文片", Aliases: []string{"海南话"}, Regions: []string{"海南"}, Children: []DialectTaxonomyNode{
+				{Name: "海口话", Regions: []string{"海南/海口"}},
+			}},
+		}},
+		{Name: "粤语", Aliases: []string{"广东话", "白话", "广府话"}, Regions: []string{"广东", "广西", "香港", "澳门"}, Children: []DialectTaxonomyNode{
+			{Name: "广府片", Regions: []string{"广东/广州", "广东/佛山", "香港", "澳门"}, Children: []DialectTaxonomyNode{
+				{Name: "广州话", Aliases: []string{"省城话"}, Regions: []string{"广东/广州"}},
+				{Name: "香港粤语", Aliases: []string{"香港话"}, Regions: []string{"香港"}},
+				{Name: "澳门粤语", Aliases: []string{"澳门话"}, Regions: []string{"澳门"}},
+			}},
+			{Name: "四邑片", Regions: []string{"广东/江门"}, Children: []DialectTaxonomyNode{
+				{Name: "台山话", Regions: []string{"广东/江门"}},
+			}},
+			{Name: "高阳片", Regions: []string{"广东/阳江", "广东/茂名"}, Children: []DialectTaxonomyNode{
+				{Name: "阳江话", Regions: []string{"广东/阳江"}},
+			}},
+			{Name: "邕浔片", Regions: []string{"广西/南宁"}, Children: []DialectTaxonomyNode{
+				{Name: "南宁白话", Regions: []string{"广西/南宁"}},
+			}},
+		}},
+		{Name: "客家话", Aliases: []string{"客家语", "客话", "涯话"}, Regions: []string{"广东/梅州", "福建/龙岩", "江西/赣州"}, Children: []DialectTaxonomyNode{
+			{Name: "粤台片", Regions: []string{"广东/梅州", "广东/河源", "广东/惠州"}, Children: []DialectTaxonomyNode{
+				{Name: "梅县话", Aliases: []string{"梅州话"}, Regions: []string{"广东/梅州"}},
+			}},
+			{Name: "汀州片", Regions: []string{"福建/龙岩", "福建/三明"}, Children: []DialectTaxonomyNode{
+				{Name: "长汀话", Regions: []string{"福建/龙岩"}},
+			}},
+			{Name: "宁龙片", Regions: []string{"江西/赣州"}, Children: []DialectTaxonomyNode{
+				{Name: "宁都话", Regions: []string{"江西/赣州"}},
+			}},
+		}},
+		{Name: "平话", Regions: []string{"广西"}, Children: []DialectTaxonomyNode{
+			{Name: "桂南片", Regions: []string{"广西/南宁"}, Children: []DialectTaxonomyNode{
+				{Name: "南宁平话", Regions: []string{"广西/南宁"}},
+			}},
+		}},
+	}
+}
+
+// ParseTaxonomyRegion 解析 "省" 或 "省/市" 形式的地区
+func ParseTaxonomyRegion(s string) entity.DialectGroupRegion {
+	province, city, _ := strings.Cut(s, "/")
+	return entity.DialectGroupRegion{Province: province, City: city}
+}
+
+// 方言分类匹配方式
+const (
+	DialectMatchName    = "name"    // 地区文本与分类名称或别称一致
+	DialectMatchKeyword = "keyword" // 地区文本中包含分类名称或别称
+	DialectMatchRegion  = "region"  // 按方言所在省市对应
+)
+
+// dialectNameSuffixes 比较名称时去掉的通称后缀，使"上海话"、"上海方言"、"上海"视为同一名称
+var dialectNameSuffixes = []string{"方言", "话", "语", "腔"}
+
+// NormalizeDialectName 规范化方言名称：繁转简、去除空白、去掉"话"、"方言"等通称后缀
+func NormalizeDialectName(name string) string {
+	s := hanzi.NormalizeName(name)
+	for _, suffix := range dialectNameSuffixes {
+		if trimmed := strings.TrimSuffix(s, suffix); trimmed != s && trimmed != "" {
+			return trimmed
+		}
+	}
+	return s
+}
+
+// DialectGroupMatch 匹配结果
+type DialectGroupMatch struct {
+	Group  *entity.DialectGroup
+	Method string
+}
+
+// DialectGroupMatcher 将历史方言的自由文本地区匹配到方言分类
+type DialectGroupMatcher struct {
+	groups   []*entity.DialectGroup
+	byName   map[string][]*entity.DialectGroup
+	keywords []dialectKeyword
+	regions  map[string][]entity.DialectGroupRegion // 分类自身未设置时沿用上级的对应地区
+}
+
+type dialectKeyword struct {
+	key   string
+	group *entity.DialectGroup
+}
+
+// NewDialectGroupMatcher 创建方言分类匹配器
+func NewDialectGroupMatcher(groups []entity.DialectGroup) *DialectGroupMatcher {
+	m := &DialectGroupMatcher{
+		byName:  make(map[string][]*entity.DialectGroup),
+		regions: make(map[string][]entity.DialectGroupRegion),
+	}
+	byID := make(map[string]*entity.DialectGroup, len(groups))
+	for i := range groups {
+		g := &groups[i]
+		m.groups = append(m.groups, g)
+		byID[g.ID] = g
+		for _, name := range g.Names() {
+			key := NormalizeDialectName(name)
+			m.byName[key] = appendUniqueGroup(m.byName[key], g)
+			// 单字（如"吴"、"粤"）在长文本中容易误中，只用于完全一致匹配
+			if utf8.RuneCountInString(key) >= 2 {
+				m.keywords = append(m.keywords, dialectKeyword{key: key, group: g})
+			}
+		}
+	}
+	// 长关键词优先，避免"南宁白话"被"白话"抢先匹配
+	sort.SliceStable(m.keywords, func(i, j int) bool {
+		return utf8.RuneCountInString(m.keywords[i].key) > utf8.RuneCountInString(m.keywords[j].key)
+	})
+
+	for _, g := range m.groups {
+		ids := append(g.AncestorIDs(), g.ID)
+		for i := len(ids) - 1; i >= 0; i-- {
+			if a := byID[ids[i]]; a != nil && len(a.Regions) > 0 {
+				m.regions[g.ID] = a.Regions
+				break
+			}
+		}
+	}
+	return m
+}
+
+// Match 依次按名称、关键词、省市匹配，存在歧义时返回 nil
+func (m *DialectGroupMatcher) Match(region, province, city string) *DialectGroupMatch {
+	if key := NormalizeDialectName(region); key != "" {
+		if g := m.pick(m.byName[key], province, city); g != nil {
+			return &DialectGroupMatch{Group: g, Method: DialectMatchName}
+		}
+	}
+
+	if text := hanzi.NormalizeName(region); text != "" {
+		var candidates []*entity.DialectGroup
+		keyLen := 0
+		for _, k := range m.keywords {
+			n := utf8.RuneCountInString(k.key)
+			if keyLen > 0 && n < keyLen {
+				break
+			}
+			if strings.Contains(text, k.key) {
+				candidates = appendUniqueGroup(candidates, k.group)
+				keyLen = n
+			}
+		}
+		if g := m.pick(candidates, province, city); g != nil {
+			return &DialectGroupMatch{Group: g, Method: DialectMatchKeyword}
+		}
+	}
+
+	if g := m.matchRegion(province, city); g != nil {
+		return &DialectGroupMatch{Group: g, Method: DialectMatchRegion}
+	}
+	return nil
+}
+
+// pick 从候选中选出唯一结果：多个候选时先按省市筛选，再取层级最深的一个
+func (m *DialectGroupMatcher) pick(candidates []*entity.DialectGroup, province, city string) *entity.DialectGroup {
+	if len(candidates) > 1 && province != "" {
+		var covered []*entity.DialectGroup
+		for _, g := range candidates {
+			if m.covers(g, province, city) {
+				covered = append(covered, g)
+			}
+		}
+		if len(covered) > 0 {
+			candidates = covered
+		}
+	}
+	if len(candidates) == 1 {
+		return candidates[0]
+	}
+
+	var best *entity.DialectGroup
+	tie := false
+	for _, g := range candidates {
+		switch {
+		case best == nil || g.Level.Depth() > best.Level.Depth():
+			best, tie = g, false
+		case g.Level.Depth() == best.Level.Depth():
+			tie = true
+		}
+	}
+	if tie {
+		return nil
+	}
+	return best
+}
+
+// covers 分类（或其上级）的对应地区是否覆盖省市
+func (m *DialectGroupMatcher) covers(g *entity.DialectGroup, province, city string) bool {
+	for i := range m.regions[g.ID] {
+		if m.regions[g.ID][i].Covers(province, city) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchRegion 按省市匹配：取对应地区精确包含该省市的最深层级分类，多个时取其共同上级
+func (m *DialectGroupMatcher) matchRegion(province, city string) *entity.DialectGroup {
+	if province == "" {
+		return nil
+	}
+	byDepth := make(map[int][]*entity.DialectGroup)
+	maxDepth := 0
+	for _, g := range m.groups {
+		for _, r := range g.Regions {
+			if r.Province == province && (r.City == "" || r.City == city) {
+				d := g.Level.Depth()
+				byDepth[d] = append(byDepth[d], g)
+				if d > maxDepth {
+					maxDepth = d
+				}
+				break
+			}
+		}
+	}
+	if maxDepth == 0 {
+		return nil
+	}
+	return m.commonAncestor(byDepth[maxDepth])
+}
+
+// commonAncestor 最近共同上级（含自身），不属于同一方言大区时返回 nil
+func (m *DialectGroupMatcher) commonAncestor(groups []*entity.DialectGroup) *entity.DialectGroup {
+	prefix := groups[0].Path
+	for _, g := range groups[1:] {
+		for !strings.HasPrefix(g.Path, prefix) {
+			i := strings.LastIndex(strings.TrimSuffix(prefix, "/"), "/")
+			if i <= 0 {
+				return nil
+			}
+			prefix = prefix[:i+1]
+		}
+	}
+	for _, g := range m.groups {
+		if g.Path == prefix {
+			return g
+		}
+	}
+	return nil
+}
+
+func appendUniqueGroup(list []*entity.DialectGroup, g *entity.DialectGroup) []*entity.DialectGroup {
+	for _, existing := range list {
+		if existing == g {
+			return list
+		}
+	}
+	return append(list, g)
+}
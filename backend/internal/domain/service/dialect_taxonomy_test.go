@@ -0,0 +1,179 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// taxonomyGroups 将分类树展开为方言分类，ID 按展开顺序编号
+func taxonomyGroups(t *testing.T, nodes []DialectTaxonomyNode) []entity.DialectGroup {
+	var groups []*entity.DialectGroup
+	var walk func(nodes []DialectTaxonomyNode, parent *entity.DialectGroup)
+	walk = func(nodes []DialectTaxonomyNode, parent *entity.DialectGroup) {
+		for _, n := range nodes {
+			g := &entity.DialectGroup{
+				BaseEntity: entity.BaseEntity{ID: fmt.Sprintf("g%d", len(groups)+1)},
+				Name:       n.Name,
+			}
+			g.SetAliases(n.Aliases)
+			for _, r := range n.Regions {
+				g.Regions = append(g.Regions, ParseTaxonomyRegion(r))
+			}
+			require.NoError(t, g.PlaceUnder(parent))
+			groups = append(groups, g)
+			walk(n.Children, g)
+		}
+	}
+	walk(nodes, nil)
+
+	list := make([]entity.DialectGroup, len(groups))
+	for i, g := range groups {
+		list[i] = *g
+	}
+	return list
+}
+
+func TestDefaultDialectTaxonomy(t *testing.T) {
+	groups := taxonomyGroups(t, DefaultDialectTaxonomy())
+	require.NotEmpty(t, groups)
+
+	fullNames := make(map[string]bool, len(groups))
+	for _, g := range groups {
+		assert.NoError(t, g.Validate(), g.FullName)
+		assert.False(t, fullNames[g.FullName], "duplicate group %s", g.FullName)
+		fullNames[g.FullName] = true
+		if g.Level == entity.DialectGroupVariety {
+			assert.NotEmpty(t, g.Regions, "variety %s has no region", g.FullName)
+		}
+	}
+}
+
+func TestParseTaxonomyRegion(t *testing.T) {
+	assert.Equal(t, entity.DialectGroupRegion{Province: "上海"}, ParseTaxonomyRegion("上海"))
+	assert.Equal(t, entity.DialectGroupRegion{Province: "江苏", City: "苏州"}, ParseTaxonomyRegion("江苏/苏州"))
+	assert.Equal(t, entity.DialectGroupRegion{}, ParseTaxonomyRegion(""))
+}
+
+func TestNormalizeDialectName(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"上海话", "上海"},
+		{"上海方言", "上海"},
+		{"上海", "上海"},
+		{"吴语", "吴"},
+		{"关中腔", "关中"},
+		{"廣東話", "广东"},
+		{" 上海 话 ", "上海"},
+		{"话", "话"}, // 只有后缀时保留
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, NormalizeDialectName(tt.in), "%q", tt.in)
+	}
+}
+
+func TestDialectGroupMatcher_Default(t *testing.T) {
+	m := NewDialectGroupMatcher(taxonomyGroups(t, DefaultDialectTaxonomy()))
+
+	tests := []struct {
+		name     string
+		region   string
+		province string
+		city     string
+		want     string // 分类全称，为空表示无法匹配
+		method   string
+	}{
+		{"name", "苏州话", "", "", "吴语/太湖片/苏州话", DialectMatchName},
+		{"alias", "沪语", "", "", "吴语/太湖片/上海话", DialectMatchName},
+		{"suffix ignored", "上海方言", "", "", "吴语/太湖片/上海话", DialectMatchName},
+		{"traditional characters", "廣東話", "", "", "粤语", DialectMatchName},
+		{"keyword in free text", "江苏苏州一带的吴侬软语", "", "", "吴语/太湖片/苏州话", DialectMatchKeyword},
+		{"longer keyword first", "广西南宁白话口音", "", "", "粤语/邕浔片/南宁白话", DialectMatchKeyword},
+		{"single character not a keyword", "带点吴味", "", "", "", ""},
+		{"region: deepest group", "", "浙江", "温州", "吴语/瓯江片/温州话", DialectMatchRegion},
+		{"region: province only", "", "四川", "绵阳", "官话/西南官话", DialectMatchRegion},
+		{"region: unknown text falls back", "老家土话", "山东", "济南", "官话/冀鲁官话/济南话", DialectMatchRegion},
+		{"region: different families", "", "广西", "南宁", "", ""},
+		{"region: not covered", "", "西藏", "拉萨", "", ""},
+		{"nothing", "", "", "", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := m.Match(tt.region, tt.province, tt.city)
+			if tt.want == "" {
+				assert.Nil(t, got)
+				return
+			}
+			require.NotNil(t, got)
+			assert.Equal(t, tt.want, got.Group.FullName)
+			assert.Equal(t, tt.method, got.Method)
+		})
+	}
+}
+
+func TestDialectGroupMatcher_Ambiguity(t *testing.T) {
+	groups := taxonomyGroups(t, []DialectTaxonomyNode{
+		{Name: "湘语", Regions: []string{"湖南"}, Children: []DialectTaxonomyNode{
+			{Name: "娄邵片", Children: []DialectTaxonomyNode{
+				{Name: "土话"},
+				{Name: "邵阳话", Regions: []string{"湖南/邵阳"}},
+			}},
+			{Name: "长益片", Regions: []string{"湖南/长沙"}, Children: []DialectTaxonomyNode{
+				{Name: "长沙话", Regions: []string{"湖南/长沙"}},
+			}},
+		}},
+		{Name: "平话", Regions: []string{"广西"}, Children: []DialectTaxonomyNode{
+			{Name: "土话", Regions: []string{"广西/桂林"}},
+			{Name: "桂北片", Regions: []string{"广西/桂林"}},
+		}},
+	})
+	m := NewDialectGroupMatcher(groups)
+
+	tests := []struct {
+		name     string
+		region   string
+		province string
+		city     string
+		want     string
+	}{
+		// 同名分类按省市筛选，上级的对应地区对下级同样有效
+		{"same name resolved by province", "土话", "广西", "", "平话/土话"},
+		{"same name resolved by inherited region", "土话", "湖南", "永州", "湘语/娄邵片/土话"},
+		// 无法按省市区分时取层级最深的一个
+		{"same name without region", "土话", "", "", "湘语/娄邵片/土话"},
+		{"same name outside both regions", "土话", "云南", "", "湘语/娄邵片/土话"},
+		{"whole province", "", "湖南", "", "湘语"},
+		// 多个同级分类对应同一省市时取共同上级
+		{"siblings share a parent", "", "广西", "桂林", "平话"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := m.Match(tt.region, tt.province, tt.city)
+			if tt.want == "" {
+				assert.Nil(t, got)
+				return
+			}
+			require.NotNil(t, got)
+			assert.Equal(t, tt.want, got.Group.FullName)
+		})
+	}
+
+	// 同一层级的同名分类无法区分时不匹配
+	tie := NewDialectGroupMatcher(taxonomyGroups(t, []DialectTaxonomyNode{
+		{Name: "土话", Regions: []string{"湖南"}},
+		{Name: "土語", Regions: []string{"广西"}},
+	}))
+	assert.Nil(t, tie.Match("土话", "", ""))
+	assert.Nil(t, tie.Match("土话", "云南", ""))
+	if got := tie.Match("土话", "广西", ""); assert.NotNil(t, got) {
+		assert.Equal(t, "土語", got.Group.FullName)
+	}
+}
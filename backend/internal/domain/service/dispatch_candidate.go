@@ -0,0 +1,15 @@
+package service
+
+import (
+	"context"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+)
+
+// DispatchCandidateProvider 派单候选人补充接口
+// 派单时先由任务仓储取出组织内的志愿者及其在办任务数、最近任务地点，再依次交给各 Provider
+// 补充常住地、技能、语言、服务半径和档期等信息；Provider 也可以移除不适合的候选人
+type DispatchCandidateProvider interface {
+	// Enrich 补充候选人信息，返回处理后的候选人列表
+	Enrich(ctx context.Context, task *entity.Task, candidates []entity.DispatchCandidate) ([]entity.DispatchCandidate, error)
+}
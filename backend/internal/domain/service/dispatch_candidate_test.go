@@ -0,0 +1,238 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// providerFunc 以函数实现 DispatchCandidateProvider
+type providerFunc func(ctx context.Context, task *entity.Task, candidates []entity.DispatchCandidate) ([]entity.DispatchCandidate, error)
+
+func (f providerFunc) Enrich(ctx context.Context, task *entity.Task, candidates []entity.DispatchCandidate) ([]entity.DispatchCandidate, error) {
+	return f(ctx, task, candidates)
+}
+
+// enrichCandidates 依次调用各 Provider，与派单服务的调用顺序一致
+func enrichCandidates(task *entity.Task, candidates []entity.DispatchCandidate, providers ...DispatchCandidateProvider) ([]entity.DispatchCandidate, error) {
+	var err error
+	for _, p := range providers {
+		if candidates, err = p.Enrich(context.Background(), task, candidates); err != nil {
+			return nil, err
+		}
+	}
+	return candidates, nil
+}
+
+func matchIDs(matches []entity.DispatchMatch) []string {
+	ids := make([]string, len(matches))
+	for i, m := range matches {
+		ids[i] = m.Candidate.UserID
+	}
+	return ids
+}
+
+func TestRankDispatchCandidates_Scores(t *testing.T) {
+	// 纬度 1° 约 111.2km，0.18° 约 20km，即距离得分减半处
+	tests := []struct {
+		name      string
+		task      func() *entity.Task
+		candidate entity.DispatchCandidate
+		want      float64
+	}{
+		{
+			name:      "same place, no requirements, idle",
+			task:      func() *entity.Task { return &entity.Task{Lat: 30, Lng: 104} },
+			candidate: entity.DispatchCandidate{UserID: "a", Lat: 30, Lng: 104},
+			want:      100,
+		},
+		{
+			name:      "distance score halves at 20km",
+			task:      func() *entity.Task { return &entity.Task{Lat: 30, Lng: 104} },
+			candidate: entity.DispatchCandidate{UserID: "a", Lat: 30.18, Lng: 104},
+			want:      80,
+		},
+		{
+			name:      "candidate location unknown",
+			task:      func() *entity.Task { return &entity.Task{Lat: 30, Lng: 104} },
+			candidate: entity.DispatchCandidate{UserID: "a"},
+			want:      70,
+		},
+		{
+			name:      "task location unknown",
+			task:      func() *entity.Task { return &entity.Task{} },
+			candidate: entity.DispatchCandidate{UserID: "a", Lat: 30, Lng: 104},
+			want:      70,
+		},
+		{
+			name:      "one open task",
+			task:      func() *entity.Task { return &entity.Task{Lat: 30, Lng: 104} },
+			candidate: entity.DispatchCandidate{UserID: "a", Lat: 30, Lng: 104, OpenTasks: 1},
+			want:      90,
+		},
+		{
+			name: "half the skills, no language",
+			task: func() *entity.Task {
+				task := &entity.Task{Lat: 30, Lng: 104}
+				task.SetSkills([]string{"驾驶", "急救"})
+				task.SetLanguages([]string{"粤语"})
+				return task
+			},
+			candidate: entity.DispatchCandidate{UserID: "a", Lat: 30, Lng: 104, Skills: []string{" 急救 "}},
+			want:      70,
+		},
+		{
+			name: "tags compared case-insensitively",
+			task: func() *entity.Task {
+				task := &entity.Task{Lat: 30, Lng: 104}
+				task.SetSkills([]string{"Drone"})
+				return task
+			},
+			candidate: entity.DispatchCandidate{UserID: "a", Lat: 30, Lng: 104, Skills: []string{"drone"}},
+			want:      100,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches := entity.RankDispatchCandidates(tt.task(), []entity.DispatchCandidate{tt.candidate}, entity.DispatchOptions{})
+			require.Len(t, matches, 1)
+			assert.InDelta(t, tt.want, matches[0].Score, 0.2)
+		})
+	}
+}
+
+func TestRankDispatchCandidates_Filters(t *testing.T) {
+	task := &entity.Task{Lat: 30, Lng: 104}
+
+	tests := []struct {
+		name      string
+		candidate entity.DispatchCandidate
+		opts      entity.DispatchOptions
+		kept      bool
+	}{
+		{"unavailable", entity.DispatchCandidate{Lat: 30, Lng: 104, Unavailable: "休假中"}, entity.DispatchOptions{}, false},
+		{"open tasks at limit", entity.DispatchCandidate{Lat: 30, Lng: 104, OpenTasks: 2}, entity.DispatchOptions{MaxOpenTasks: 2}, false},
+		{"open tasks unlimited", entity.DispatchCandidate{Lat: 30, Lng: 104, OpenTasks: 9}, entity.DispatchOptions{}, true},
+		{"beyond max distance", entity.DispatchCandidate{Lat: 31, Lng: 104}, entity.DispatchOptions{MaxDistanceKm: 50}, false},
+		{"beyond service radius", entity.DispatchCandidate{Lat: 30.1, Lng: 104, ServiceRadius: 5}, entity.DispatchOptions{}, false},
+		{"within service radius", entity.DispatchCandidate{Lat: 30.01, Lng: 104, ServiceRadius: 5}, entity.DispatchOptions{}, true},
+		// 位置未知时无法判断距离，保留
+		{"unknown location ignores distance limits", entity.DispatchCandidate{ServiceRadius: 5}, entity.DispatchOptions{MaxDistanceKm: 50}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches := entity.RankDispatchCandidates(task, []entity.DispatchCandidate{tt.candidate}, tt.opts)
+			assert.Equal(t, tt.kept, len(matches) == 1)
+		})
+	}
+
+	assert.Empty(t, entity.RankDispatchCandidates(task, nil, entity.DispatchOptions{}))
+}
+
+func TestRankDispatchCandidates_Ties(t *testing.T) {
+	task := &entity.Task{Lat: 30, Lng: 104}
+	task.SetSkills([]string{"驾驶", "急救"})
+
+	// busy 技能齐全但有一件在办任务，与只会一项技能的空闲志愿者同分；同分时在办任务少的在前，再按 ID
+	candidates := []entity.DispatchCandidate{
+		{UserID: "busy", Lat: 30, Lng: 104, OpenTasks: 1, Skills: []string{"驾驶", "急救"}},
+		{UserID: "b", Lat: 30, Lng: 104, Skills: []string{"急救"}},
+		{UserID: "a", Lat: 30, Lng: 104, Skills: []string{"驾驶"}},
+	}
+	matches := entity.RankDispatchCandidates(task, candidates, entity.DispatchOptions{})
+	assert.Equal(t, []string{"a", "b", "busy"}, matchIDs(matches))
+	assert.Equal(t, matches[0].Score, matches[2].Score)
+}
+
+func TestDispatchCandidateProvider_Chain(t *testing.T) {
+	task := &entity.Task{Lat: 30, Lng: 104}
+	task.SetLanguages([]string{"四川话"})
+
+	// 常住地和语言来自志愿者档案，档期来自排班
+	profiles := providerFunc(func(_ context.Context, _ *entity.Task, candidates []entity.DispatchCandidate) ([]entity.DispatchCandidate, error) {
+		for i := range candidates {
+			switch candidates[i].UserID {
+			case "local":
+				candidates[i].Lat, candidates[i].Lng = 30.01, 104
+				candidates[i].LocationSource = "home"
+				candidates[i].Languages = []string{"四川话"}
+			case "remote":
+				candidates[i].Lat, candidates[i].Lng = 31, 104
+				candidates[i].ServiceRadius = 10
+			}
+		}
+		return candidates, nil
+	})
+	schedule := providerFunc(func(_ context.Context, _ *entity.Task, candidates []entity.DispatchCandidate) ([]entity.DispatchCandidate, error) {
+		kept := candidates[:0]
+		for _, c := range candidates {
+			if c.UserID == "offline" {
+				continue
+			}
+			if c.UserID == "night" {
+				c.Unavailable = "不在值班时段"
+			}
+			kept = append(kept, c)
+		}
+		return kept, nil
+	})
+
+	tests := []struct {
+		name       string
+		providers  []DispatchCandidateProvider
+		candidates []entity.DispatchCandidate
+		want       []string
+	}{
+		{
+			name:      "no candidates",
+			providers: []DispatchCandidateProvider{profiles, schedule},
+		},
+		{
+			// 仅有仓储数据时按在办任务数排序，位置未知的候选人不被排除
+			name: "without providers",
+			candidates: []entity.DispatchCandidate{
+				{UserID: "remote", OpenTasks: 1},
+				{UserID: "local", OpenTasks: 2},
+				{UserID: "night"},
+				{UserID: "offline"},
+			},
+			want: []string{"night", "offline", "remote", "local"},
+		},
+		{
+			name:      "enriched and filtered",
+			providers: []DispatchCandidateProvider{profiles, schedule},
+			candidates: []entity.DispatchCandidate{
+				{UserID: "remote", OpenTasks: 1},
+				{UserID: "local", OpenTasks: 2},
+				{UserID: "night"},
+				{UserID: "offline"},
+			},
+			want: []string{"local"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			candidates, err := enrichCandidates(task, tt.candidates, tt.providers...)
+			require.NoError(t, err)
+			matches := entity.RankDispatchCandidates(task, candidates, entity.DispatchOptions{})
+			if len(tt.want) == 0 {
+				assert.Empty(t, matches)
+				return
+			}
+			assert.Equal(t, tt.want, matchIDs(matches))
+		})
+	}
+
+	failing := providerFunc(func(context.Context, *entity.Task, []entity.DispatchCandidate) ([]entity.DispatchCandidate, error) {
+		return nil, errors.New("profile store unavailable")
+	})
+	_, err := enrichCandidates(task, []entity.DispatchCandidate{{UserID: "local"}}, failing, profiles)
+	assert.Error(t, err)
+}
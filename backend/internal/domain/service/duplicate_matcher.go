@@ -0,0 +1,237 @@
+package service
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/hanzi"
+)
+
+// 查重评分权重（满分100）
+const (
+	dupWeightName        = 40.0
+	dupWeightGender      = 10.0
+	dupWeightAge         = 15.0
+	dupWeightRegion      = 20.0
+	dupWeightMissingTime = 15.0
+
+	// DefaultDuplicateThreshold 默认疑似重复阈值
+	DefaultDuplicateThreshold = 60.0
+)
+
+// DuplicateCandidate 疑似重复案件
+type DuplicateCandidate struct {
+	Person  *entity.MissingPerson
+	Score   float64
+	Reasons []string
+}
+
+// DuplicateMatcher 重复案件匹配器
+type DuplicateMatcher struct {
+	threshold float64
+}
+
+// NewDuplicateMatcher 创建重复案件匹配器
+func NewDuplicateMatcher(threshold float64) *DuplicateMatcher {
+	if threshold <= 0 {
+		threshold = DefaultDuplicateThreshold
+	}
+	return &DuplicateMatcher{threshold: threshold}
+}
+
+// Threshold 疑似重复阈值
+func (m *DuplicateMatcher) Threshold() float64 {
+	return m.threshold
+}
+
+// Match 从候选案件中找出疑似重复并按得分降序排列
+func (m *DuplicateMatcher) Match(target *entity.MissingPerson, candidates []entity.MissingPerson) []DuplicateCandidate {
+	var result []DuplicateCandidate
+	for i := range candidates {
+		c := &candidates[i]
+		if c.ID == target.ID || c.IsMerged() {
+			continue
+		}
+		score, reasons := m.Score(target, c)
+		if score >= m.threshold {
+			result = append(result, DuplicateCandidate{Person: c, Score: score, Reasons: reasons})
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Score > result[j].Score
+	})
+	return result
+}
+
+// Score 计算两个案件的相似度得分及命中原因
+func (m *DuplicateMatcher) Score(a, b *entity.MissingPerson) (float64, []string) {
+	var score float64
+	var reasons []string
+
+	if s, reason := nameScore(a.Name, b.Name); s > 0 {
+		score += s
+		reasons = append(reasons, reason)
+	}
+
+	if a.Gender != "" && a.Gender == b.Gender {
+		score += dupWeightGender
+		reasons = append(reasons, "性别相同")
+	}
+
+	if s := ageScore(a.GetAgeAtMissing(), b.GetAgeAtMissing()); s > 0 {
+		score += s
+		reasons = append(reasons, "走失年龄相近")
+	}
+
+	if s := regionScore(a, b); s > 0 {
+		score += s
+		reasons = append(reasons, "走失地区相同")
+	}
+
+	if s := missingTimeScore(a.MissingTime, b.MissingTime); s > 0 {
+		score += s
+		reasons = append(reasons, "走失时间相近")
+	}
+
+	return score, reasons
+}
+
+// nameScore 姓名相似度：规范化后相同 > 拼音相同 > 拼音编辑距离相近 > 首字母相同
+func nameScore(a, b string) (float64, string) {
+	na, nb := hanzi.NormalizeName(a), hanzi.NormalizeName(b)
+	if na == "" || nb == "" {
+		return 0, ""
+	}
+	if na == nb {
+		return dupWeightName, "姓名相同（含繁简）"
+	}
+
+	pa, pb := hanzi.Syllables(na), hanzi.Syllables(nb)
+	if equalStrings(pa, pb) {
+		return dupWeightName * 0.85, "姓名同音"
+	}
+
+	if sim := syllableSimilarity(pa, pb); sim >= 0.6 {
+		return dupWeightName * 0.75 * sim, "姓名拼音相近"
+	}
+
+	if hanzi.Initials(na) == hanzi.Initials(nb) && len(pa) > 0 && len(pb) > 0 && pa[0] == pb[0] {
+		return dupWeightName * 0.4, "姓名首字母相同"
+	}
+	return 0, ""
+}
+
+// ageScore 走失年龄得分
+func ageScore(a, b int) float64 {
+	if a <= 0 || b <= 0 {
+		return 0
+	}
+	diff := a - b
+	if diff < 0 {
+		diff = -diff
+	}
+	switch diff {
+	case 0:
+		return dupWeightAge
+	case 1:
+		return dupWeightAge * 0.8
+	case 2:
+		return dupWeightAge * 0.5
+	case 3:
+		return dupWeightAge * 0.25
+	default:
+		return 0
+	}
+}
+
+// regionScore 地区得分，逐级匹配
+func regionScore(a, b *entity.MissingPerson) float64 {
+	return dupWeightRegion * placeSimilarity(a.Province, a.City, a.District, b.Province, b.City, b.District)
+}
+
+// placeSimilarity 省、市、区逐级匹配的相似度（0~1）
+func placeSimilarity(aProvince, aCity, aDistrict, bProvince, bCity, bDistrict string) float64 {
+	if aProvince == "" || aProvince != bProvince {
+		return 0
+	}
+	sim := 0.4
+	if aCity == "" || aCity != bCity {
+		return sim
+	}
+	sim += 0.35
+	if aDistrict != "" && aDistrict == bDistrict {
+		sim += 0.25
+	}
+	return sim
+}
+
+// missingTimeScore 走失时间得分
+func missingTimeScore(a, b time.Time) float64 {
+	if a.IsZero() || b.IsZero() {
+		return 0
+	}
+	diff := a.Sub(b)
+	if diff < 0 {
+		diff = -diff
+	}
+	day := 24 * time.Hour
+	switch {
+	case diff <= day:
+		return dupWeightMissingTime
+	case diff <= 7*day:
+		return dupWeightMissingTime * 0.8
+	case diff <= 30*day:
+		return dupWeightMissingTime * 0.5
+	case diff <= 365*day:
+		return dupWeightMissingTime * 0.2
+	default:
+		return 0
+	}
+}
+
+// syllableSimilarity 基于音节编辑距离的相似度（0~1）
+func syllableSimilarity(a, b []string) float64 {
+	sa, sb := []rune(strings.Join(a, "")), []rune(strings.Join(b, ""))
+	maxLen := len(sa)
+	if len(sb) > maxLen {
+		maxLen = len(sb)
+	}
+	if maxLen == 0 {
+		return 0
+	}
+	return 1 - float64(levenshtein(sa, sb))/float64(maxLen)
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) || len(a) == 0 {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
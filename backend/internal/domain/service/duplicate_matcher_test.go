@@ -0,0 +1,194 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func dupCase(id, name string) entity.MissingPerson {
+	mp := entity.MissingPerson{
+		Name:        name,
+		Gender:      "male",
+		Age:         8,
+		Province:    "四川省",
+		City:        "成都市",
+		District:    "武侯区",
+		MissingTime: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+	}
+	mp.ID = id
+	return mp
+}
+
+func TestNameScore(t *testing.T) {
+	tests := []struct {
+		name   string
+		a, b   string
+		want   float64
+		reason string
+	}{
+		{"identical", "张伟", "张伟", dupWeightName, "姓名相同（含繁简）"},
+		{"traditional and simplified", "张国华", "張國華", dupWeightName, "姓名相同（含繁简）"},
+		{"separators ignored", "张国华", "张 国华", dupWeightName, "姓名相同（含繁简）"},
+		{"homophone", "张伟", "章伟", dupWeightName * 0.85, "姓名同音"},
+		// zhangwei / zhaowei 编辑距离 2，相似度 0.75
+		{"similar pinyin", "张伟", "赵伟", dupWeightName * 0.75 * 0.75, "姓名拼音相近"},
+		// liwei / liwang 编辑距离 3，相似度 0.5，仅首字母相同
+		{"same initials", "李伟", "李旺", dupWeightName * 0.4, "姓名首字母相同"},
+		{"unrelated", "李明", "王芳", 0, ""},
+		{"empty", "", "张伟", 0, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, reason := nameScore(tt.a, tt.b)
+			assert.InDelta(t, tt.want, got, 1e-9)
+			assert.Equal(t, tt.reason, reason)
+		})
+	}
+}
+
+func TestAgeScore(t *testing.T) {
+	tests := []struct {
+		a, b int
+		want float64
+	}{
+		{8, 8, dupWeightAge},
+		{8, 9, dupWeightAge * 0.8},
+		{9, 8, dupWeightAge * 0.8},
+		{8, 10, dupWeightAge * 0.5},
+		{8, 11, dupWeightAge * 0.25},
+		{8, 12, 0},
+		{0, 8, 0}, // 年龄未知
+	}
+
+	for _, tt := range tests {
+		assert.InDelta(t, tt.want, ageScore(tt.a, tt.b), 1e-9, "age %d vs %d", tt.a, tt.b)
+	}
+}
+
+func TestPlaceSimilarity(t *testing.T) {
+	tests := []struct {
+		name string
+		b    [3]string
+		want float64
+	}{
+		{"same district", [3]string{"四川省", "成都市", "武侯区"}, 1},
+		{"same city", [3]string{"四川省", "成都市", "锦江区"}, 0.75},
+		{"same province", [3]string{"四川省", "绵阳市", "涪城区"}, 0.4},
+		{"different province", [3]string{"云南省", "成都市", "武侯区"}, 0},
+		{"missing province", [3]string{"", "成都市", "武侯区"}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := placeSimilarity("四川省", "成都市", "武侯区", tt.b[0], tt.b[1], tt.b[2])
+			assert.InDelta(t, tt.want, got, 1e-9)
+		})
+	}
+
+	// 双方均未填写区县时只按省市计分
+	assert.InDelta(t, 0.75, placeSimilarity("四川省", "成都市", "", "四川省", "成都市", ""), 1e-9)
+}
+
+func TestMissingTimeScore(t *testing.T) {
+	base := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	tests := []struct {
+		name string
+		b    time.Time
+		want float64
+	}{
+		{"same day", base.Add(-6 * time.Hour), dupWeightMissingTime},
+		{"within a week", base.Add(3 * day), dupWeightMissingTime * 0.8},
+		{"within a month", base.Add(-20 * day), dupWeightMissingTime * 0.5},
+		{"within a year", base.Add(200 * day), dupWeightMissingTime * 0.2},
+		{"over a year", base.Add(400 * day), 0},
+		{"unknown", time.Time{}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, missingTimeScore(base, tt.b), 1e-9)
+		})
+	}
+}
+
+func TestDuplicateMatcher_Score(t *testing.T) {
+	m := NewDuplicateMatcher(0)
+	a := dupCase("a", "张伟")
+
+	t.Run("all fields match", func(t *testing.T) {
+		b := dupCase("b", "張偉")
+		score, reasons := m.Score(&a, &b)
+		assert.InDelta(t, 100, score, 1e-9)
+		assert.Len(t, reasons, 5)
+	})
+
+	t.Run("birth date takes precedence over age", func(t *testing.T) {
+		b := dupCase("b", "张伟")
+		b.Age = 30
+		birth := time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)
+		b.BirthDate = &birth
+		score, _ := m.Score(&a, &b)
+		assert.InDelta(t, 100, score, 1e-9)
+	})
+
+	t.Run("weights add up", func(t *testing.T) {
+		b := dupCase("b", "章伟")
+		b.Gender = "female"
+		b.Age = 9
+		b.District = "锦江区"
+		b.MissingTime = a.MissingTime.Add(3 * 24 * time.Hour)
+		score, reasons := m.Score(&a, &b)
+		want := dupWeightName*0.85 + dupWeightAge*0.8 + dupWeightRegion*0.75 + dupWeightMissingTime*0.8
+		assert.InDelta(t, want, score, 1e-9)
+		assert.Equal(t, []string{"姓名同音", "走失年龄相近", "走失地区相同", "走失时间相近"}, reasons)
+	})
+
+	t.Run("unknown fields score nothing", func(t *testing.T) {
+		x, y := dupCase("x", "李明"), dupCase("y", "王芳")
+		x.Gender, y.Gender = "", ""
+		x.Age, y.Age = 0, 0
+		x.Province, y.Province = "", ""
+		x.MissingTime, y.MissingTime = time.Time{}, time.Time{}
+		score, reasons := m.Score(&x, &y)
+		assert.Zero(t, score)
+		assert.Empty(t, reasons)
+	})
+}
+
+func TestDuplicateMatcher_Match(t *testing.T) {
+	m := NewDuplicateMatcher(0)
+	assert.Equal(t, DefaultDuplicateThreshold, m.Threshold())
+
+	target := dupCase("target", "张伟")
+	merged := dupCase("merged", "张伟")
+	survivorID := "target"
+	merged.MergedIntoID = &survivorID
+
+	homophone := dupCase("homophone", "章伟")
+	exact := dupCase("exact", "张伟")
+	exact.District = "锦江区"
+	unrelated := dupCase("unrelated", "李明")
+	unrelated.Province, unrelated.City, unrelated.District = "云南省", "昆明市", "五华区"
+	unrelated.Age = 60
+	unrelated.MissingTime = time.Time{}
+
+	candidates := []entity.MissingPerson{target, merged, unrelated, homophone, exact}
+	result := m.Match(&target, candidates)
+
+	// 排除自身、已合并案件和低于阈值的案件，按得分降序
+	if assert.Len(t, result, 2) {
+		assert.Equal(t, "exact", result[0].Person.ID)
+		assert.Equal(t, "homophone", result[1].Person.ID)
+		assert.Greater(t, result[0].Score, result[1].Score)
+	}
+
+	strict := NewDuplicateMatcher(99)
+	result = strict.Match(&target, candidates)
+	assert.Empty(t, result)
+}
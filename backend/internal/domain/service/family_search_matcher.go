@@ -0,0 +1,212 @@
+package service
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/hanzi"
+)
+
+// 寻亲比对评分权重（满分100）。亲寻家的登记人往往记不清姓名和确切时间，
+// 因此相比案件查重降低姓名、时间权重，提高出生年份和体貌特征权重
+const (
+	famWeightName      = 25.0
+	famWeightGender    = 10.0
+	famWeightBirthYear = 15.0
+	famWeightLostAge   = 10.0
+	famWeightRegion    = 20.0
+	famWeightLostTime  = 10.0
+	famWeightFeatures  = 10.0
+
+	// DefaultFamilyMatchThreshold 默认寻亲比对阈值
+	DefaultFamilyMatchThreshold = 45.0
+)
+
+// featureKeywords 可用于辨认身份的体貌特征关键词（简体）
+var featureKeywords = []string{
+	"胎记", "痣", "疤", "伤疤", "烫伤", "烧伤", "六指", "兔唇", "唇裂", "斜视", "对眼",
+	"酒窝", "虎牙", "豁牙", "耳洞", "招风耳", "双旋", "秃斑", "白癜风", "左撇子", "跛",
+	"聋哑", "口吃", "近视", "纹身", "卷发", "黄头发",
+}
+
+// SearchProfile 比对用的走失者画像，由案件或寻亲者档案转换而来
+type SearchProfile struct {
+	Name      string
+	Gender    string
+	BirthYear int
+	LostAge   int
+	LostTime  time.Time
+	Province  string
+	City      string
+	District  string
+	Features  string
+}
+
+// ProfileFromMissingPerson 由案件生成画像
+func ProfileFromMissingPerson(mp *entity.MissingPerson) SearchProfile {
+	p := SearchProfile{
+		Name:     mp.Name,
+		Gender:   mp.Gender,
+		LostAge:  mp.GetAgeAtMissing(),
+		LostTime: mp.MissingTime,
+		Province: mp.Province,
+		City:     mp.City,
+		District: mp.District,
+		Features: strings.Join([]string{mp.Features, mp.Description}, " "),
+	}
+	switch {
+	case mp.BirthDate != nil:
+		p.BirthYear = mp.BirthDate.Year()
+	case mp.Age > 0 && !mp.MissingTime.IsZero():
+		p.BirthYear = mp.MissingTime.Year() - mp.Age
+	}
+	return p
+}
+
+// ProfileFromSearcher 由寻亲者档案生成被寻找人的画像
+func ProfileFromSearcher(s *entity.FamilySearcher) SearchProfile {
+	p := SearchProfile{
+		Name:      s.LostName,
+		Gender:    s.LostGender,
+		BirthYear: s.LostBirthYear,
+		LostAge:   s.LostAge,
+		Province:  s.LostProvince,
+		City:      s.LostCity,
+		District:  s.LostDistrict,
+		Features:  strings.Join([]string{s.Features, s.Memories, s.Description}, " "),
+	}
+	if s.LostTime != nil {
+		p.LostTime = *s.LostTime
+	}
+	if p.BirthYear == 0 && p.LostAge > 0 && !p.LostTime.IsZero() {
+		p.BirthYear = p.LostTime.Year() - p.LostAge
+	}
+	if p.LostAge == 0 && p.BirthYear > 0 && !p.LostTime.IsZero() {
+		p.LostAge = p.LostTime.Year() - p.BirthYear
+	}
+	return p
+}
+
+// FamilyMatch 寻亲比对结果
+type FamilyMatch struct {
+	Index   int // 候选在输入切片中的下标
+	Score   float64
+	Reasons []string
+}
+
+// FamilySearchMatcher 寻亲者与案件、寻亲者之间的比对器
+type FamilySearchMatcher struct {
+	threshold float64
+}
+
+// NewFamilySearchMatcher 创建寻亲比对器
+func NewFamilySearchMatcher(threshold float64) *FamilySearchMatcher {
+	if threshold <= 0 {
+		threshold = DefaultFamilyMatchThreshold
+	}
+	return &FamilySearchMatcher{threshold: threshold}
+}
+
+// Threshold 比对阈值
+func (m *FamilySearchMatcher) Threshold() float64 {
+	return m.threshold
+}
+
+// Match 从候选画像中找出达到阈值的比对结果并按得分降序排列
+func (m *FamilySearchMatcher) Match(target SearchProfile, candidates []SearchProfile) []FamilyMatch {
+	var result []FamilyMatch
+	for i := range candidates {
+		score, reasons := m.Score(target, candidates[i])
+		if score >= m.threshold {
+			result = append(result, FamilyMatch{Index: i, Score: score, Reasons: reasons})
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Score > result[j].Score
+	})
+	return result
+}
+
+// Score 计算两个画像的相似度得分及命中原因，性别明确不同时直接判定不匹配
+func (m *FamilySearchMatcher) Score(a, b SearchProfile) (float64, []string) {
+	if a.Gender != "" && b.Gender != "" && a.Gender != b.Gender {
+		return 0, nil
+	}
+
+	var score float64
+	var reasons []string
+
+	if s, reason := nameScore(a.Name, b.Name); s > 0 {
+		score += s / dupWeightName * famWeightName
+		reasons = append(reasons, reason)
+	}
+
+	if a.Gender != "" && a.Gender == b.Gender {
+		score += famWeightGender
+		reasons = append(reasons, "性别相同")
+	}
+
+	if s := birthYearScore(a.BirthYear, b.BirthYear); s > 0 {
+		score += s
+		reasons = append(reasons, "出生年份相近")
+	}
+
+	if s := ageScore(a.LostAge, b.LostAge); s > 0 {
+		score += s / dupWeightAge * famWeightLostAge
+		reasons = append(reasons, "走失年龄相近")
+	}
+
+	if sim := placeSimilarity(a.Province, a.City, a.District, b.Province, b.City, b.District); sim > 0 {
+		score += famWeightRegion * sim
+		reasons = append(reasons, "走失地区相同")
+	}
+
+	if s := missingTimeScore(a.LostTime, b.LostTime); s > 0 {
+		score += s / dupWeightMissingTime * famWeightLostTime
+		reasons = append(reasons, "走失时间相近")
+	}
+
+	if shared := sharedFeatures(a.Features, b.Features); len(shared) > 0 {
+		score += min(famWeightFeatures, famWeightFeatures*0.5*float64(len(shared)))
+		reasons = append(reasons, "共同特征："+strings.Join(shared, "、"))
+	}
+
+	return score, reasons
+}
+
+// birthYearScore 出生年份得分，亲寻家登记的出生年份多为估计值，允许较大误差
+func birthYearScore(a, b int) float64 {
+	if a <= 0 || b <= 0 {
+		return 0
+	}
+	diff := a - b
+	if diff < 0 {
+		diff = -diff
+	}
+	switch diff {
+	case 0:
+		return famWeightBirthYear
+	case 1:
+		return famWeightBirthYear * 0.8
+	case 2:
+		return famWeightBirthYear * 0.5
+	case 3, 4:
+		return famWeightBirthYear * 0.25
+	default:
+		return 0
+	}
+}
+
+// sharedFeatures 两段描述中共同出现的体貌特征关键词
+func sharedFeatures(a, b string) []string {
+	a, b = hanzi.ToSimplified(a), hanzi.ToSimplified(b)
+	var shared []string
+	for _, kw := range featureKeywords {
+		if strings.Contains(a, kw) && strings.Contains(b, kw) {
+			shared = append(shared, kw)
+		}
+	}
+	return shared
+}
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+var famLostTime = time.Date(1995, 3, 1, 0, 0, 0, 0, time.UTC)
+
+func famProfile(name string) SearchProfile {
+	return SearchProfile{
+		Name:      name,
+		Gender:    "male",
+		BirthYear: 1990,
+		LostAge:   5,
+		LostTime:  famLostTime,
+		Province:  "四川省",
+		City:      "成都市",
+		District:  "武侯区",
+		Features:  "左脸有胎记，耳后有痣",
+	}
+}
+
+func TestProfileFromMissingPerson(t *testing.T) {
+	mp := &entity.MissingPerson{
+		Name:        "张伟",
+		Gender:      "male",
+		Age:         5,
+		MissingTime: famLostTime,
+		Features:    "胎记",
+		Description: "穿红色外套",
+	}
+
+	p := ProfileFromMissingPerson(mp)
+	assert.Equal(t, 1990, p.BirthYear, "出生年份由走失年龄推算")
+	assert.Equal(t, 5, p.LostAge)
+	assert.Equal(t, "胎记 穿红色外套", p.Features)
+
+	birth := time.Date(1989, 6, 1, 0, 0, 0, 0, time.UTC)
+	mp.BirthDate = &birth
+	assert.Equal(t, 1989, ProfileFromMissingPerson(mp).BirthYear)
+
+	// 走失时间未知时无法推算
+	assert.Zero(t, ProfileFromMissingPerson(&entity.MissingPerson{Age: 5}).BirthYear)
+}
+
+func TestProfileFromSearcher(t *testing.T) {
+	lost := famLostTime
+
+	tests := []struct {
+		name          string
+		searcher      entity.FamilySearcher
+		wantBirthYear int
+		wantLostAge   int
+	}{
+		{"birth year from lost age", entity.FamilySearcher{LostAge: 5, LostTime: &lost}, 1990, 5},
+		{"lost age from birth year", entity.FamilySearcher{LostBirthYear: 1988, LostTime: &lost}, 1988, 7},
+		{"both given", entity.FamilySearcher{LostBirthYear: 1988, LostAge: 5, LostTime: &lost}, 1988, 5},
+		{"lost time unknown", entity.FamilySearcher{LostAge: 5}, 0, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := ProfileFromSearcher(&tt.searcher)
+			assert.Equal(t, tt.wantBirthYear, p.BirthYear)
+			assert.Equal(t, tt.wantLostAge, p.LostAge)
+		})
+	}
+
+	s := &entity.FamilySearcher{Features: "胎记", Memories: "家门口有条河", Description: "小名狗蛋"}
+	assert.Equal(t, "胎记 家门口有条河 小名狗蛋", ProfileFromSearcher(s).Features)
+}
+
+func TestBirthYearScore(t *testing.T) {
+	tests := []struct {
+		a, b int
+		want float64
+	}{
+		{1990, 1990, famWeightBirthYear},
+		{1990, 1991, famWeightBirthYear * 0.8},
+		{1992, 1990, famWeightBirthYear * 0.5},
+		{1990, 1993, famWeightBirthYear * 0.25},
+		{1994, 1990, famWeightBirthYear * 0.25},
+		{1990, 1995, 0},
+		{0, 1990, 0}, // 未知
+	}
+
+	for _, tt := range tests {
+		assert.InDelta(t, tt.want, birthYearScore(tt.a, tt.b), 1e-9, "%d vs %d", tt.a, tt.b)
+	}
+}
+
+func TestSharedFeatures(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want []string
+	}{
+		{"empty", "", "胎记", nil},
+		{"no overlap", "有胎记", "有酒窝", nil},
+		{"traditional characters", "左撇子，有胎記", "胎记", []string{"胎记"}},
+		{"keyword order", "有痣也有胎记", "胎记和痣", []string{"胎记", "痣"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, sharedFeatures(tt.a, tt.b))
+		})
+	}
+}
+
+func TestFamilySearchMatcher_Score(t *testing.T) {
+	m := NewFamilySearchMatcher(0)
+	a := famProfile("张伟")
+
+	tests := []struct {
+		name    string
+		b       func() SearchProfile
+		want    float64
+		reasons int
+	}{
+		{
+			name:    "all fields match",
+			b:       func() SearchProfile { return famProfile("張偉") },
+			want:    100,
+			reasons: 7,
+		},
+		{
+			name: "gender differs",
+			b: func() SearchProfile {
+				b := famProfile("张伟")
+				b.Gender = "female"
+				return b
+			},
+			want: 0,
+		},
+		{
+			name: "single shared feature counts half",
+			b: func() SearchProfile {
+				b := famProfile("张伟")
+				b.Features = "有胎记"
+				return b
+			},
+			want:    100 - famWeightFeatures*0.5,
+			reasons: 7,
+		},
+		{
+			// 同音姓名，出生年份差一年，只记得省份
+			name: "partial memories",
+			b: func() SearchProfile {
+				return SearchProfile{Name: "章伟", Gender: "male", BirthYear: 1991, Province: "四川省"}
+			},
+			want:    famWeightName*0.85 + famWeightGender + famWeightBirthYear*0.8 + famWeightRegion*0.4,
+			reasons: 4,
+		},
+		{
+			name:    "nothing known",
+			b:       func() SearchProfile { return SearchProfile{} },
+			want:    0,
+			reasons: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, reasons := m.Score(a, tt.b())
+			assert.InDelta(t, tt.want, score, 1e-9)
+			assert.Len(t, reasons, tt.reasons)
+		})
+	}
+}
+
+func TestFamilySearchMatcher_Match(t *testing.T) {
+	m := NewFamilySearchMatcher(0)
+	assert.Equal(t, DefaultFamilyMatchThreshold, m.Threshold())
+
+	target := famProfile("张伟")
+	weak := SearchProfile{Name: "李明", Gender: "male"}
+	partial := famProfile("章伟")
+	partial.Features = ""
+	tieA := famProfile("张伟")
+	tieB := famProfile("张伟")
+
+	candidates := []SearchProfile{weak, partial, tieA, tieB}
+	result := m.Match(target, candidates)
+
+	// 低于阈值的候选被过滤，同分时保持输入顺序
+	if assert.Len(t, result, 3) {
+		assert.Equal(t, []int{2, 3, 1}, []int{result[0].Index, result[1].Index, result[2].Index})
+		assert.Equal(t, result[0].Score, result[1].Score)
+		assert.Greater(t, result[1].Score, result[2].Score)
+	}
+
+	assert.Empty(t, m.Match(target, nil))
+	assert.Empty(t, NewFamilySearchMatcher(100.5).Match(target, candidates))
+}
@@ -0,0 +1,250 @@
+package service
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/geo"
+)
+
+const (
+	// DefaultMaxSpeedKmh 默认合理移动速度上限，超过视为不合理跳跃（误报或定位错误）
+	DefaultMaxSpeedKmh = 120.0
+	// DefaultClusterRadiusKm 默认线索聚类半径
+	DefaultClusterRadiusKm = 1.0
+
+	// timelineSamePlaceKm 定位误差容忍距离，低于该距离不判定为跳跃
+	timelineSamePlaceKm = 0.2
+	// timelineWalkingSpeedKmh 缺少观测速度时使用的步行速度
+	timelineWalkingSpeedKmh = 4.0
+	// timelineActivityFactor 走失人员并非持续移动，按该比例折算有效移动时间
+	timelineActivityFactor = 0.25
+	// timelineMinSearchRadiusKm 搜索区域最小半径
+	timelineMinSearchRadiusKm = 1.0
+	// timelineMaxSearchRadiusKm 搜索区域最大半径
+	timelineMaxSearchRadiusKm = 50.0
+	// timelineCircleSegments 搜索区域多边形顶点数
+	timelineCircleSegments = 32
+)
+
+// TimelinePoint 时间线上的定位点
+type TimelinePoint struct {
+	TrackID     string // 为空表示走失地点
+	Origin      bool
+	Point       geo.Point
+	Time        time.Time
+	Location    string
+	Status      string
+	IsKeyPoint  bool
+	Implausible bool    // 相对上一个可信点的移动速度不合理
+	DistanceKm  float64 // 距上一个可信点的距离
+	SpeedKmh    float64 // 距上一个可信点的平均速度
+	ClusterID   int
+}
+
+// TimelineCluster 邻近线索聚类
+type TimelineCluster struct {
+	ID          int
+	Center      geo.Point
+	Points      []geo.Point
+	Count       int
+	Start       time.Time
+	End         time.Time
+	HasKeyPoint bool
+}
+
+// SearchArea 推测的当前搜索区域
+type SearchArea struct {
+	Center        geo.Point
+	RadiusKm      float64
+	AnchorTrackID string
+	AnchorTime    time.Time
+	SpeedKmh      float64
+	Polygon       []geo.Point
+}
+
+// Timeline 案件时间线
+type Timeline struct {
+	Points           []TimelinePoint
+	Path             []geo.Point // 剔除不合理跳跃后的移动路径
+	TotalDistanceKm  float64
+	ImplausibleCount int
+	UnlocatedCount   int // 无坐标的线索数
+	Clusters         []TimelineCluster
+	SearchArea       *SearchArea // 已找到或无定位点时为空
+}
+
+// TimelineAnalyzer 时间线分析器
+type TimelineAnalyzer struct {
+	maxSpeedKmh     float64
+	clusterRadiusKm float64
+}
+
+// NewTimelineAnalyzer 创建时间线分析器
+func NewTimelineAnalyzer(maxSpeedKmh, clusterRadiusKm float64) *TimelineAnalyzer {
+	if maxSpeedKmh <= 0 {
+		maxSpeedKmh = DefaultMaxSpeedKmh
+	}
+	if clusterRadiusKm <= 0 {
+		clusterRadiusKm = DefaultClusterRadiusKm
+	}
+	return &TimelineAnalyzer{maxSpeedKmh: maxSpeedKmh, clusterRadiusKm: clusterRadiusKm}
+}
+
+// Analyze 根据走失地点和线索重建时间线
+func (a *TimelineAnalyzer) Analyze(person *entity.MissingPerson, tracks []entity.MissingPersonTrack, now time.Time) *Timeline {
+	tl := &Timeline{}
+
+	points := make([]TimelinePoint, 0, len(tracks)+1)
+	if geo.ValidCoordinate(person.Lat, person.Lng) {
+		points = append(points, TimelinePoint{
+			Origin:     true,
+			Point:      geo.Point{Lat: person.Lat, Lng: person.Lng},
+			Time:       person.MissingTime,
+			Location:   person.Address,
+			IsKeyPoint: true,
+		})
+	}
+	for _, t := range tracks {
+		if t.Status == entity.TrackStatusRejected {
+			continue
+		}
+		if !geo.ValidCoordinate(t.Lat, t.Lng) {
+			tl.UnlocatedCount++
+			continue
+		}
+		points = append(points, TimelinePoint{
+			TrackID:    t.ID,
+			Point:      geo.Point{Lat: t.Lat, Lng: t.Lng},
+			Time:       t.Time,
+			Location:   t.Location,
+			Status:     t.Status,
+			IsKeyPoint: t.IsKeyPoint,
+		})
+	}
+	sort.SliceStable(points, func(i, j int) bool {
+		return points[i].Time.Before(points[j].Time)
+	})
+
+	a.flagJumps(tl, points)
+	tl.Points = points
+	tl.Clusters = a.cluster(points)
+
+	if person.Status != entity.MissingStatusFound && person.Status != entity.MissingStatusReunited {
+		tl.SearchArea = a.estimateSearchArea(points, now)
+	}
+	return tl
+}
+
+// flagJumps 标记不合理跳跃：与上一个可信点比较，速度超过上限视为不合理
+// 关键节点由工作人员确认，始终视为可信
+func (a *TimelineAnalyzer) flagJumps(tl *Timeline, points []TimelinePoint) {
+	last := -1
+	for i := range points {
+		p := &points[i]
+		if last >= 0 {
+			prev := points[last]
+			p.DistanceKm = geo.Distance(prev.Point.Lat, prev.Point.Lng, p.Point.Lat, p.Point.Lng)
+			hours := p.Time.Sub(prev.Time).Hours()
+			if hours > 0 {
+				p.SpeedKmh = p.DistanceKm / hours
+			}
+
+			jump := p.DistanceKm > timelineSamePlaceKm && (hours <= 0 || p.SpeedKmh > a.maxSpeedKmh)
+			if jump && !p.IsKeyPoint {
+				p.Implausible = true
+				tl.ImplausibleCount++
+				continue
+			}
+			tl.TotalDistanceKm += p.DistanceKm
+		}
+		tl.Path = append(tl.Path, p.Point)
+		last = i
+	}
+}
+
+// cluster 按时间顺序将可信点贪心归入半径内最近的聚类
+func (a *TimelineAnalyzer) cluster(points []TimelinePoint) []TimelineCluster {
+	var clusters []TimelineCluster
+	for i := range points {
+		p := &points[i]
+		if p.Implausible {
+			continue
+		}
+
+		best, bestDist := -1, math.MaxFloat64
+		for j := range clusters {
+			c := clusters[j].Center
+			d := geo.Distance(c.Lat, c.Lng, p.Point.Lat, p.Point.Lng)
+			if d <= a.clusterRadiusKm && d < bestDist {
+				best, bestDist = j, d
+			}
+		}
+
+		if best < 0 {
+			clusters = append(clusters, TimelineCluster{ID: len(clusters) + 1, Start: p.Time})
+			best = len(clusters) - 1
+		}
+		c := &clusters[best]
+		c.Points = append(c.Points, p.Point)
+		c.Count++
+		c.Center = geo.Centroid(c.Points)
+		c.End = p.Time
+		c.HasKeyPoint = c.HasKeyPoint || p.IsKeyPoint
+		p.ClusterID = c.ID
+	}
+	return clusters
+}
+
+// estimateSearchArea 以最后一个可信点为中心，按观测移动速度和经过时间推算搜索半径
+func (a *TimelineAnalyzer) estimateSearchArea(points []TimelinePoint, now time.Time) *SearchArea {
+	anchor := -1
+	var speeds []float64
+	for i := range points {
+		if points[i].Implausible {
+			continue
+		}
+		if anchor >= 0 && points[i].SpeedKmh > 0 {
+			speeds = append(speeds, points[i].SpeedKmh)
+		}
+		anchor = i
+	}
+	if anchor < 0 {
+		return nil
+	}
+
+	speed := timelineWalkingSpeedKmh
+	if len(speeds) > 0 {
+		speed = median(speeds)
+	}
+
+	hours := now.Sub(points[anchor].Time).Hours()
+	if hours < 0 {
+		hours = 0
+	}
+	radius := speed * hours * timelineActivityFactor
+	radius = math.Max(timelineMinSearchRadiusKm, math.Min(timelineMaxSearchRadiusKm, radius))
+
+	center := points[anchor].Point
+	return &SearchArea{
+		Center:        center,
+		RadiusKm:      radius,
+		AnchorTrackID: points[anchor].TrackID,
+		AnchorTime:    points[anchor].Time,
+		SpeedKmh:      speed,
+		Polygon:       geo.Circle(center, radius, timelineCircleSegments),
+	}
+}
+
+func median(values []float64) float64 {
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
@@ -0,0 +1,254 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var timelineStart = time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+
+func timelineCase(lat, lng float64) *entity.MissingPerson {
+	return &entity.MissingPerson{
+		Lat:         lat,
+		Lng:         lng,
+		MissingTime: timelineStart,
+		Status:      entity.MissingStatusSearching,
+	}
+}
+
+func timelineTrack(id string, lat, lng float64, after time.Duration) entity.MissingPersonTrack {
+	t := entity.MissingPersonTrack{
+		Lat:    lat,
+		Lng:    lng,
+		Time:   timelineStart.Add(after),
+		Status: entity.TrackStatusConfirmed,
+	}
+	t.ID = id
+	return t
+}
+
+func TestNewTimelineAnalyzer_Defaults(t *testing.T) {
+	a := NewTimelineAnalyzer(0, -1)
+	assert.Equal(t, DefaultMaxSpeedKmh, a.maxSpeedKmh)
+	assert.Equal(t, DefaultClusterRadiusKm, a.clusterRadiusKm)
+}
+
+func TestTimelineAnalyzer_Points(t *testing.T) {
+	a := NewTimelineAnalyzer(0, 0)
+
+	t.Run("empty", func(t *testing.T) {
+		tl := a.Analyze(timelineCase(0, 0), nil, timelineStart)
+		assert.Empty(t, tl.Points)
+		assert.Empty(t, tl.Path)
+		assert.Empty(t, tl.Clusters)
+		assert.Nil(t, tl.SearchArea)
+	})
+
+	t.Run("missing coordinates and rejected tracks", func(t *testing.T) {
+		rejected := timelineTrack("rejected", 30.01, 104, time.Hour)
+		rejected.Status = entity.TrackStatusRejected
+		tracks := []entity.MissingPersonTrack{
+			timelineTrack("unlocated", 0, 0, time.Hour),
+			rejected,
+			timelineTrack("located", 30.01, 104, 2*time.Hour),
+		}
+
+		tl := a.Analyze(timelineCase(0, 0), tracks, timelineStart)
+		assert.Equal(t, 1, tl.UnlocatedCount)
+		require.Len(t, tl.Points, 1)
+		assert.Equal(t, "located", tl.Points[0].TrackID)
+	})
+
+	t.Run("sorted by time after the origin", func(t *testing.T) {
+		tracks := []entity.MissingPersonTrack{
+			timelineTrack("later", 30.02, 104, 2*time.Hour),
+			timelineTrack("earlier", 30.01, 104, time.Hour),
+		}
+
+		tl := a.Analyze(timelineCase(30, 104), tracks, timelineStart)
+		require.Len(t, tl.Points, 3)
+		assert.True(t, tl.Points[0].Origin)
+		assert.True(t, tl.Points[0].IsKeyPoint)
+		assert.Equal(t, "earlier", tl.Points[1].TrackID)
+		assert.Equal(t, "later", tl.Points[2].TrackID)
+	})
+}
+
+func TestTimelineAnalyzer_Jumps(t *testing.T) {
+	// 纬度 0.01° 约 1.11km，1° 约 111km
+	tests := []struct {
+		name        string
+		track       entity.MissingPersonTrack
+		keyPoint    bool
+		implausible bool
+	}{
+		{"walking pace", timelineTrack("t", 30.01, 104, time.Hour), false, false},
+		{"too fast", timelineTrack("t", 32, 104, time.Hour), false, true},
+		{"too fast but confirmed key point", timelineTrack("t", 32, 104, time.Hour), true, false},
+		{"same time elsewhere", timelineTrack("t", 30.01, 104, 0), false, true},
+		{"same time within location error", timelineTrack("t", 30.001, 104, 0), false, false},
+	}
+
+	a := NewTimelineAnalyzer(0, 0)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			track := tt.track
+			track.IsKeyPoint = tt.keyPoint
+			tl := a.Analyze(timelineCase(30, 104), []entity.MissingPersonTrack{track}, timelineStart)
+
+			require.Len(t, tl.Points, 2)
+			assert.Equal(t, tt.implausible, tl.Points[1].Implausible)
+			if tt.implausible {
+				assert.Equal(t, 1, tl.ImplausibleCount)
+				assert.Len(t, tl.Path, 1)
+				assert.Zero(t, tl.TotalDistanceKm)
+			} else {
+				assert.Zero(t, tl.ImplausibleCount)
+				assert.Len(t, tl.Path, 2)
+				assert.InDelta(t, tl.Points[1].DistanceKm, tl.TotalDistanceKm, 1e-9)
+			}
+		})
+	}
+
+	// 跳跃点之后的线索与最后一个可信点比较
+	tracks := []entity.MissingPersonTrack{
+		timelineTrack("jump", 32, 104, time.Hour),
+		timelineTrack("back", 30.01, 104, 2*time.Hour),
+	}
+	tl := a.Analyze(timelineCase(30, 104), tracks, timelineStart)
+	assert.True(t, tl.Points[1].Implausible)
+	assert.False(t, tl.Points[2].Implausible)
+	assert.InDelta(t, 0.55, tl.Points[2].SpeedKmh, 0.01)
+}
+
+func TestTimelineAnalyzer_Clusters(t *testing.T) {
+	tracks := []entity.MissingPersonTrack{
+		timelineTrack("near", 30.005, 104, time.Hour),
+		timelineTrack("far", 30.05, 104, 3*time.Hour),
+		timelineTrack("jump", 35, 104, 4*time.Hour),
+	}
+
+	tl := NewTimelineAnalyzer(0, 0).Analyze(timelineCase(30, 104), tracks, timelineStart)
+	require.Len(t, tl.Clusters, 2)
+
+	first := tl.Clusters[0]
+	assert.Equal(t, 2, first.Count)
+	assert.True(t, first.HasKeyPoint)
+	assert.Equal(t, timelineStart, first.Start)
+	assert.Equal(t, timelineStart.Add(time.Hour), first.End)
+	assert.InDelta(t, 30.0025, first.Center.Lat, 1e-9)
+
+	assert.Equal(t, 1, tl.Clusters[1].Count)
+	assert.False(t, tl.Clusters[1].HasKeyPoint)
+
+	// 不合理跳跃不归入聚类
+	assert.Equal(t, []int{1, 1, 2, 0}, []int{
+		tl.Points[0].ClusterID, tl.Points[1].ClusterID, tl.Points[2].ClusterID, tl.Points[3].ClusterID,
+	})
+}
+
+func TestTimelineAnalyzer_SearchArea(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     entity.MissingStatus
+		tracks     []entity.MissingPersonTrack
+		elapsed    time.Duration
+		wantNil    bool
+		wantAnchor string
+		wantSpeed  float64
+		wantRadius float64
+	}{
+		{
+			// 步行速度 4km/h × 10h × 0.25
+			name:       "walking speed without observations",
+			elapsed:    10 * time.Hour,
+			wantSpeed:  timelineWalkingSpeedKmh,
+			wantRadius: 10,
+		},
+		{
+			name:       "minimum radius",
+			elapsed:    30 * time.Minute,
+			wantSpeed:  timelineWalkingSpeedKmh,
+			wantRadius: timelineMinSearchRadiusKm,
+		},
+		{
+			name:       "anchor later than now",
+			elapsed:    -time.Hour,
+			wantSpeed:  timelineWalkingSpeedKmh,
+			wantRadius: timelineMinSearchRadiusKm,
+		},
+		{
+			name:       "maximum radius",
+			elapsed:    30 * 24 * time.Hour,
+			wantSpeed:  timelineWalkingSpeedKmh,
+			wantRadius: timelineMaxSearchRadiusKm,
+		},
+		{
+			// 两段观测速度取中位数，从最后一个线索起算
+			name: "median observed speed",
+			tracks: []entity.MissingPersonTrack{
+				timelineTrack("a", 30.1, 104, time.Hour),
+				timelineTrack("b", 30.3, 104, 2*time.Hour),
+			},
+			elapsed:    12 * time.Hour,
+			wantAnchor: "b",
+			wantSpeed:  16.68,
+			wantRadius: 16.68 * 10 * timelineActivityFactor,
+		},
+		{
+			name: "implausible anchor ignored",
+			tracks: []entity.MissingPersonTrack{
+				timelineTrack("jump", 40, 104, time.Hour),
+			},
+			elapsed:    10 * time.Hour,
+			wantSpeed:  timelineWalkingSpeedKmh,
+			wantRadius: 10,
+		},
+		{name: "found", status: entity.MissingStatusFound, elapsed: time.Hour, wantNil: true},
+		{name: "reunited", status: entity.MissingStatusReunited, elapsed: time.Hour, wantNil: true},
+	}
+
+	a := NewTimelineAnalyzer(0, 0)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			person := timelineCase(30, 104)
+			if tt.status != "" {
+				person.Status = tt.status
+			}
+			tl := a.Analyze(person, tt.tracks, timelineStart.Add(tt.elapsed))
+
+			if tt.wantNil {
+				assert.Nil(t, tl.SearchArea)
+				return
+			}
+			require.NotNil(t, tl.SearchArea)
+			area := tl.SearchArea
+			assert.Equal(t, tt.wantAnchor, area.AnchorTrackID)
+			assert.InDelta(t, tt.wantSpeed, area.SpeedKmh, 0.05)
+			assert.InDelta(t, tt.wantRadius, area.RadiusKm, 0.2)
+			assert.Len(t, area.Polygon, timelineCircleSegments)
+		})
+	}
+}
+
+func TestMedian(t *testing.T) {
+	tests := []struct {
+		values []float64
+		want   float64
+	}{
+		{[]float64{5}, 5},
+		{[]float64{3, 1, 2}, 2},
+		{[]float64{4, 1, 3, 2}, 2.5},
+		{[]float64{2, 2}, 2},
+	}
+
+	for _, tt := range tests {
+		values := append([]float64(nil), tt.values...)
+		assert.Equal(t, tt.want, median(values))
+		assert.Equal(t, tt.values, values, "input must not be reordered")
+	}
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/hanzi"
+)
+
+// medicalKeywords 内置疾病、认知障碍关键词（简体）
+var medicalKeywords = []string{
+	"阿尔茨海默", "老年痴呆", "痴呆", "失智", "认知障碍", "记忆力差", "记忆力减退",
+	"智力障碍", "智障", "自闭症", "孤独症", "唐氏",
+	"精神分裂", "精神病", "精神障碍", "抑郁", "躁郁", "双相",
+	"癫痫", "糖尿病", "心脏病", "高血压", "哮喘", "需服药", "长期服药",
+	"聋哑", "失明", "行动不便", "残疾",
+}
+
+// 评分因素
+const (
+	UrgencyFactorChild    = "child"
+	UrgencyFactorElder    = "elder"
+	UrgencyFactorMedical  = "medical"
+	UrgencyFactorElapsed  = "elapsed"
+	UrgencyFactorSeason   = "season"
+	UrgencyFactorSighting = "sighting"
+)
+
+// UrgencySignals 评分所需的案件外部信号
+type UrgencySignals struct {
+	RecentSightings int       // 统计窗口内未驳回的线索数
+	Now             time.Time // 评分时间
+}
+
+// UrgencyFactor 评分因素
+type UrgencyFactor struct {
+	Code   string
+	Points int
+	Detail string
+}
+
+// UrgencyAssessment 紧急程度评估结果
+type UrgencyAssessment struct {
+	Score   int
+	Level   entity.UrgencyLevel
+	Factors []UrgencyFactor
+}
+
+// UrgencyScorer 紧急程度评分器
+type UrgencyScorer struct {
+	rule     *entity.UrgencyRule
+	keywords []string
+}
+
+// NewUrgencyScorer 按规则创建评分器，rule 为空时使用默认规则
+func NewUrgencyScorer(rule *entity.UrgencyRule) *UrgencyScorer {
+	if rule == nil {
+		rule = entity.DefaultUrgencyRule()
+	}
+	keywords := append([]string(nil), medicalKeywords...)
+	for _, kw := range rule.ExtraKeywords() {
+		keywords = append(keywords, hanzi.ToSimplified(kw))
+	}
+	return &UrgencyScorer{rule: rule, keywords: keywords}
+}
+
+// Score 计算案件紧急程度
+func (s *UrgencyScorer) Score(mp *entity.MissingPerson, signals UrgencySignals) UrgencyAssessment {
+	r := s.rule
+	now := signals.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	var factors []UrgencyFactor
+	add := func(code string, points int, detail string) {
+		if points > 0 {
+			factors = append(factors, UrgencyFactor{Code: code, Points: points, Detail: detail})
+		}
+	}
+
+	// 年龄未知（0）时不计入
+	age := mp.GetAgeAtMissing()
+	vulnerable := false
+	switch {
+	case age > 0 && age <= r.ChildMaxAge:
+		add(UrgencyFactorChild, r.ChildWeight, fmt.Sprintf("走失时 %d 岁，属儿童", age))
+		vulnerable = true
+	case r.ElderMinAge > 0 && age >= r.ElderMinAge:
+		add(UrgencyFactorElder, r.ElderWeight, fmt.Sprintf("走失时 %d 岁，属老人", age))
+		vulnerable = true
+	}
+
+	if hits := s.matchMedical(mp); len(hits) > 0 {
+		add(UrgencyFactorMedical, r.MedicalWeight, "提及："+strings.Join(hits, "、"))
+		vulnerable = true
+	}
+
+	if !mp.MissingTime.IsZero() && r.GoldenHours > 0 {
+		elapsed := now.Sub(mp.MissingTime)
+		golden := time.Duration(r.GoldenHours) * time.Hour
+		switch {
+		case elapsed < 0:
+		case elapsed <= golden:
+			add(UrgencyFactorElapsed, r.GoldenWeight, fmt.Sprintf("走失 %.0f 小时，处于黄金搜救时间内", elapsed.Hours()))
+		case elapsed <= 3*golden:
+			add(UrgencyFactorElapsed, r.GoldenWeight/2, fmt.Sprintf("走失 %.0f 小时", elapsed.Hours()))
+		}
+	}
+
+	if name, harsh := harshSeason(now, mp.Lat); harsh {
+		points := r.SeasonWeight
+		if !vulnerable {
+			points /= 2
+		}
+		add(UrgencyFactorSeason, points, name)
+	}
+
+	if signals.RecentSightings > 0 {
+		points := signals.RecentSightings * r.SightingWeight
+		if points > r.SightingMaxWeight {
+			points = r.SightingMaxWeight
+		}
+		add(UrgencyFactorSighting, points, fmt.Sprintf("近 %d 小时 %d 条线索", r.SightingHours, signals.RecentSightings))
+	}
+
+	score := 0
+	for _, f := range factors {
+		score += f.Points
+	}
+	return UrgencyAssessment{Score: score, Level: r.Level(score), Factors: factors}
+}
+
+// matchMedical 体貌特征、描述中命中的疾病或认知障碍关键词
+func (s *UrgencyScorer) matchMedical(mp *entity.MissingPerson) []string {
+	text := hanzi.ToSimplified(mp.Features + "\n" + mp.Description)
+	if strings.TrimSpace(text) == "" {
+		return nil
+	}
+
+	var hits []string
+	for _, kw := range s.keywords {
+		if kw == "" || !strings.Contains(text, kw) {
+			continue
+		}
+		// 已命中更长的词时跳过其子串（如命中“老年痴呆”时不再列出“痴呆”）
+		covered := false
+		for _, h := range hits {
+			if strings.Contains(h, kw) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			hits = append(hits, kw)
+		}
+	}
+	return hits
+}
+
+// harshSeason 是否处于严寒或酷暑季节，按纬度区分南北半球
+func harshSeason(now time.Time, lat float64) (string, bool) {
+	month := now.Month()
+	if lat < 0 {
+		month = (month+5)%12 + 1
+	}
+	switch month {
+	case time.December, time.January, time.February:
+		return "严寒季节", true
+	case time.June, time.July, time.August:
+		return "酷暑季节", true
+	}
+	return "", false
+}
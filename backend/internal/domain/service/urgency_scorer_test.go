@@ -0,0 +1,229 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+// urgencyNow 四月，不处于严寒酷暑季节
+var urgencyNow = time.Date(2024, 4, 10, 12, 0, 0, 0, time.UTC)
+
+func urgencyCase(age int, missingAgo time.Duration) *entity.MissingPerson {
+	mp := &entity.MissingPerson{Age: age, Lat: 30}
+	if missingAgo > 0 {
+		mp.MissingTime = urgencyNow.Add(-missingAgo)
+	}
+	return mp
+}
+
+func factorPoints(a UrgencyAssessment) map[string]int {
+	points := make(map[string]int, len(a.Factors))
+	for _, f := range a.Factors {
+		points[f.Code] = f.Points
+	}
+	return points
+}
+
+func TestUrgencyScorer_Score(t *testing.T) {
+	r := entity.DefaultUrgencyRule()
+	day := 24 * time.Hour
+
+	tests := []struct {
+		name      string
+		mp        func() *entity.MissingPerson
+		sightings int
+		now       time.Time
+		want      map[string]int
+		level     entity.UrgencyLevel
+	}{
+		{
+			name:  "no signals",
+			mp:    func() *entity.MissingPerson { return urgencyCase(0, 0) },
+			want:  map[string]int{},
+			level: entity.UrgencyLevelLow,
+		},
+		{
+			name:  "child in golden hours",
+			mp:    func() *entity.MissingPerson { return urgencyCase(6, 5*time.Hour) },
+			want:  map[string]int{UrgencyFactorChild: r.ChildWeight, UrgencyFactorElapsed: r.GoldenWeight},
+			level: entity.UrgencyLevelHigh,
+		},
+		{
+			name:  "child age boundary",
+			mp:    func() *entity.MissingPerson { return urgencyCase(r.ChildMaxAge, 0) },
+			want:  map[string]int{UrgencyFactorChild: r.ChildWeight},
+			level: entity.UrgencyLevelMedium,
+		},
+		{
+			name:  "adult",
+			mp:    func() *entity.MissingPerson { return urgencyCase(r.ChildMaxAge+1, 0) },
+			want:  map[string]int{},
+			level: entity.UrgencyLevelLow,
+		},
+		{
+			name:  "elder age boundary",
+			mp:    func() *entity.MissingPerson { return urgencyCase(r.ElderMinAge, 0) },
+			want:  map[string]int{UrgencyFactorElder: r.ElderWeight},
+			level: entity.UrgencyLevelMedium,
+		},
+		{
+			name: "birth date takes precedence over age",
+			mp: func() *entity.MissingPerson {
+				mp := urgencyCase(30, 0)
+				mp.MissingTime = urgencyNow
+				birth := urgencyNow.AddDate(-8, 0, 0)
+				mp.BirthDate = &birth
+				return mp
+			},
+			want:  map[string]int{UrgencyFactorChild: r.ChildWeight, UrgencyFactorElapsed: r.GoldenWeight},
+			level: entity.UrgencyLevelHigh,
+		},
+		{
+			name:  "elapsed within three golden periods",
+			mp:    func() *entity.MissingPerson { return urgencyCase(30, 5*day) },
+			want:  map[string]int{UrgencyFactorElapsed: r.GoldenWeight / 2},
+			level: entity.UrgencyLevelLow,
+		},
+		{
+			name:  "elapsed beyond three golden periods",
+			mp:    func() *entity.MissingPerson { return urgencyCase(30, 10*day) },
+			want:  map[string]int{},
+			level: entity.UrgencyLevelLow,
+		},
+		{
+			name: "missing time in the future",
+			mp: func() *entity.MissingPerson {
+				mp := urgencyCase(30, 0)
+				mp.MissingTime = urgencyNow.Add(time.Hour)
+				return mp
+			},
+			want:  map[string]int{},
+			level: entity.UrgencyLevelLow,
+		},
+		{
+			name: "medical keyword in traditional characters",
+			mp: func() *entity.MissingPerson {
+				mp := urgencyCase(30, 0)
+				mp.Description = "患有阿爾茨海默症"
+				return mp
+			},
+			want:  map[string]int{UrgencyFactorMedical: r.MedicalWeight},
+			level: entity.UrgencyLevelMedium,
+		},
+		{
+			name:  "winter for a vulnerable person",
+			mp:    func() *entity.MissingPerson { return urgencyCase(70, 0) },
+			now:   time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC),
+			want:  map[string]int{UrgencyFactorElder: r.ElderWeight, UrgencyFactorSeason: r.SeasonWeight},
+			level: entity.UrgencyLevelMedium,
+		},
+		{
+			name:  "summer for an adult counts half",
+			mp:    func() *entity.MissingPerson { return urgencyCase(30, 0) },
+			now:   time.Date(2024, 7, 10, 12, 0, 0, 0, time.UTC),
+			want:  map[string]int{UrgencyFactorSeason: r.SeasonWeight / 2},
+			level: entity.UrgencyLevelLow,
+		},
+		{
+			name:      "sightings",
+			mp:        func() *entity.MissingPerson { return urgencyCase(30, 0) },
+			sightings: 2,
+			want:      map[string]int{UrgencyFactorSighting: 2 * r.SightingWeight},
+			level:     entity.UrgencyLevelLow,
+		},
+		{
+			name:      "sightings capped",
+			mp:        func() *entity.MissingPerson { return urgencyCase(30, 0) },
+			sightings: 10,
+			want:      map[string]int{UrgencyFactorSighting: r.SightingMaxWeight},
+			level:     entity.UrgencyLevelLow,
+		},
+		{
+			name: "critical",
+			mp: func() *entity.MissingPerson {
+				mp := urgencyCase(75, time.Hour)
+				mp.Features = "老年痴呆，需服药"
+				return mp
+			},
+			sightings: 1,
+			want: map[string]int{
+				UrgencyFactorElder:    r.ElderWeight,
+				UrgencyFactorMedical:  r.MedicalWeight,
+				UrgencyFactorElapsed:  r.GoldenWeight,
+				UrgencyFactorSighting: r.SightingWeight,
+			},
+			level: entity.UrgencyLevelCritical,
+		},
+	}
+
+	scorer := NewUrgencyScorer(nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := tt.now
+			if now.IsZero() {
+				now = urgencyNow
+			}
+			got := scorer.Score(tt.mp(), UrgencySignals{RecentSightings: tt.sightings, Now: now})
+
+			assert.Equal(t, tt.want, factorPoints(got))
+			total := 0
+			for _, p := range tt.want {
+				total += p
+			}
+			assert.Equal(t, total, got.Score)
+			assert.Equal(t, tt.level, got.Level)
+		})
+	}
+}
+
+func TestUrgencyScorer_MatchMedical(t *testing.T) {
+	rule := entity.DefaultUrgencyRule()
+	rule.MedicalKeywords = "腦梗，帕金森"
+	scorer := NewUrgencyScorer(rule)
+
+	tests := []struct {
+		name        string
+		features    string
+		description string
+		want        []string
+	}{
+		{"empty", "", "  ", nil},
+		{"none", "身穿蓝色外套", "", nil},
+		{"longer keyword covers substring", "老年痴呆", "", []string{"老年痴呆"}},
+		{"features and description", "有癫痫病史", "患有糖尿病", []string{"癫痫", "糖尿病"}},
+		{"extra keywords normalized to simplified", "", "曾脑梗，患帕金森", []string{"脑梗", "帕金森"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mp := &entity.MissingPerson{Features: tt.features, Description: tt.description}
+			assert.Equal(t, tt.want, scorer.matchMedical(mp))
+		})
+	}
+}
+
+func TestHarshSeason(t *testing.T) {
+	tests := []struct {
+		month time.Month
+		lat   float64
+		want  string
+	}{
+		{time.January, 30, "严寒季节"},
+		{time.July, 30, "酷暑季节"},
+		{time.April, 30, ""},
+		{time.October, 30, ""},
+		// 南半球季节相反
+		{time.January, -33, "酷暑季节"},
+		{time.July, -33, "严寒季节"},
+		{time.December, -33, "酷暑季节"},
+	}
+
+	for _, tt := range tests {
+		name, harsh := harshSeason(time.Date(2024, tt.month, 15, 0, 0, 0, 0, time.UTC), tt.lat)
+		assert.Equal(t, tt.want, name, "%s at %.0f", tt.month, tt.lat)
+		assert.Equal(t, tt.want != "", harsh)
+	}
+}
@@ -108,7 +108,12 @@ func (p *DefaultDataPermissionProvider) GetDataPermissionContext(ctx context.Con
 	
 	orgID, _ := ctx.Value("org_id").(string)
 	role, _ := ctx.Value("user_role").(string)
-	
+
+	return BuildDataPermissionContext(ctx, p, userID, orgID, role)
+}
+
+// BuildDataPermissionContext 按用户所在组织和角色构建数据权限上下文
+func BuildDataPermissionContext(ctx context.Context, p DataPermissionProvider, userID, orgID, role string) (*DataPermissionContext, error) {
 	// 超级管理员有全部权限
 	isSuperAdmin := role == string(entity.RoleSuperAdmin)
 	
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	// dialectPlayReportedExpr 播放记录是否可计算完成度
+	dialectPlayReportedExpr = "CASE WHEN l.duration > 0 AND d.duration > 0 THEN 1 ELSE 0 END"
+	// dialectPlayCompletionExpr 播放完成度，与 entity.DialectPlayLog.Completion 一致，不可计算时为 NULL
+	dialectPlayCompletionExpr = "CASE WHEN l.duration > 0 AND d.duration > 0 THEN " +
+		"(CASE WHEN l.duration >= d.duration THEN 1.0 ELSE l.duration * 1.0 / d.duration END) END"
+)
+
+// DialectAnalyticsRepositoryImpl 方言播放统计仓储实现
+type DialectAnalyticsRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewDialectAnalyticsRepository 创建方言播放统计仓储
+func NewDialectAnalyticsRepository(db *gorm.DB) repository.DialectAnalyticsRepository {
+	return &DialectAnalyticsRepositoryImpl{db: db}
+}
+
+// plays 按条件筛选播放记录（关联方言，已删除的方言不计）
+func (r *DialectAnalyticsRepositoryImpl) plays(ctx context.Context, query *repository.DialectAnalyticsQuery) *gorm.DB {
+	db := r.db.WithContext(ctx).
+		Table("ty_dialect_play_logs AS l").
+		Joins("JOIN ty_dialects d ON d.id = l.dialect_id AND d.deleted_at IS NULL").
+		Where("l.created_at >= ? AND l.created_at < ?", query.From, query.To)
+	if query.OrgID != "" {
+		db = db.Where("d.org_id = ?", query.OrgID)
+	}
+	if query.DialectID != "" {
+		db = db.Where("l.dialect_id = ?", query.DialectID)
+	}
+	return db
+}
+
+// Summary 播放汇总
+func (r *DialectAnalyticsRepositoryImpl) Summary(ctx context.Context, query *repository.DialectAnalyticsQuery) (*entity.DialectPlaySummary, error) {
+	var summary entity.DialectPlaySummary
+	err := r.plays(ctx, query).
+		Select("COUNT(*) AS plays, " +
+			"COUNT(DISTINCT l.listener_key) AS listeners, " +
+			"COALESCE(SUM(" + dialectPlayReportedExpr + "), 0) AS reported, " +
+			"COALESCE(AVG(" + dialectPlayCompletionExpr + "), 0) AS completion_rate").
+		Scan(&summary).Error
+	if err != nil {
+		return nil, err
+	}
+	return &summary, nil
+}
+
+// ListenerDays 按天、收听者汇总播放
+func (r *DialectAnalyticsRepositoryImpl) ListenerDays(ctx context.Context, query *repository.DialectAnalyticsQuery) ([]entity.DialectListenerDay, error) {
+	var days []entity.DialectListenerDay
+	err := r.plays(ctx, query).
+		Select("DATE(l.created_at) AS day, l.listener_key, COUNT(*) AS plays, " +
+			"COALESCE(SUM(" + dialectPlayReportedExpr + "), 0) AS reported, " +
+			"COALESCE(SUM(" + dialectPlayCompletionExpr + "), 0) AS completion_sum").
+		Group("DATE(l.created_at), l.listener_key").
+		Order("day ASC").
+		Scan(&days).Error
+	return days, err
+}
+
+// TopRegions 播放最多的方言地区
+func (r *DialectAnalyticsRepositoryImpl) TopRegions(ctx context.Context, query *repository.DialectAnalyticsQuery, limit int) ([]entity.DialectRegionPlays, error) {
+	var regions []entity.DialectRegionPlays
+	err := r.plays(ctx, query).
+		Select("d.region, COUNT(*) AS plays, COUNT(DISTINCT l.listener_key) AS listeners, COUNT(DISTINCT d.id) AS clips").
+		Group("d.region").
+		Order("plays DESC").
+		Limit(limit).
+		Scan(&regions).Error
+	return regions, err
+}
+
+// TopClips 播放最多的方言
+func (r *DialectAnalyticsRepositoryImpl) TopClips(ctx context.Context, query *repository.DialectAnalyticsQuery, limit int) ([]entity.DialectClipPlays, error) {
+	var clips []entity.DialectClipPlays
+	err := r.plays(ctx, query).
+		Select("d.id AS dialect_id, d.title, d.region, d.duration, COUNT(*) AS plays, " +
+			"COUNT(DISTINCT l.listener_key) AS listeners, " +
+			"COALESCE(AVG(" + dialectPlayCompletionExpr + "), 0) AS completion_rate").
+		Group("d.id, d.title, d.region, d.duration").
+		Order("plays DESC").
+		Limit(limit).
+		Scan(&clips).Error
+	return clips, err
+}
@@ -0,0 +1,287 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// dialectCommentPendingReports 评论存在待处理举报
+const dialectCommentPendingReports = "EXISTS (SELECT 1 FROM ty_dialect_comment_reports r " +
+	"WHERE r.comment_id = ty_dialect_comments.id AND r.status = 'pending' AND r.deleted_at IS NULL)"
+
+// DialectCommentRepositoryImpl 方言评论仓储实现
+type DialectCommentRepositoryImpl struct {
+	*BaseRepository[entity.DialectComment]
+}
+
+// NewDialectCommentRepository 创建方言评论仓储
+func NewDialectCommentRepository(db *gorm.DB) repository.DialectCommentRepository {
+	return &DialectCommentRepositoryImpl{
+		BaseRepository: NewBaseRepository[entity.DialectComment](db),
+	}
+}
+
+// FindByIDWithUser 获取评论（含方言、作者和被回复用户）
+func (r *DialectCommentRepositoryImpl) FindByIDWithUser(ctx context.Context, id string) (*entity.DialectComment, error) {
+	var comment entity.DialectComment
+	err := r.db.WithContext(ctx).
+		Preload("Dialect").
+		Preload("User").
+		Preload("ReplyToUser").
+		First(&comment, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// Add 添加评论
+func (r *DialectCommentRepositoryImpl) Add(ctx context.Context, comment *entity.DialectComment) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Dialect", "User", "ReplyToUser").Create(comment).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&entity.Dialect{}).Where("id = ?", comment.DialectID).
+			UpdateColumn("comment_count", gorm.Expr("comment_count + 1")).Error; err != nil {
+			return err
+		}
+		if comment.ParentID == nil {
+			return nil
+		}
+		return tx.Model(&entity.DialectComment{}).Where("id = ?", *comment.ParentID).
+			UpdateColumn("reply_count", gorm.Expr("reply_count + 1")).Error
+	})
+}
+
+// ListThreads 分页查询主评论
+func (r *DialectCommentRepositoryImpl) ListThreads(ctx context.Context, dialectID string, pagination repository.Pagination) (*repository.PageResult[entity.DialectComment], error) {
+	db := r.db.WithContext(ctx).Model(&entity.DialectComment{}).
+		Where("dialect_id = ? AND parent_id IS NULL", dialectID)
+	return r.page(db, "created_at DESC", pagination)
+}
+
+// ListReplies 分页查询回复
+func (r *DialectCommentRepositoryImpl) ListReplies(ctx context.Context, parentID string, pagination repository.Pagination) (*repository.PageResult[entity.DialectComment], error) {
+	db := r.db.WithContext(ctx).Model(&entity.DialectComment{}).
+		Where("parent_id = ?", parentID)
+	return r.page(db, "created_at ASC", pagination)
+}
+
+// page 分页查询评论（含作者和被回复用户）
+func (r *DialectCommentRepositoryImpl) page(db *gorm.DB, order string, pagination repository.Pagination) (*repository.PageResult[entity.DialectComment], error) {
+	var comments []entity.DialectComment
+	var total int64
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	err := r.Paginate(db.Order(order), pagination).
+		Preload("User").
+		Preload("ReplyToUser").
+		Find(&comments).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return repository.NewPageResult(comments, total, pagination.Page, pagination.PageSize), nil
+}
+
+// PreviewReplies 每条主评论最早的 limit 条回复
+func (r *DialectCommentRepositoryImpl) PreviewReplies(ctx context.Context, parentIDs []string, limit int) (map[string][]entity.DialectComment, error) {
+	previews := make(map[string][]entity.DialectComment, len(parentIDs))
+	if len(parentIDs) == 0 || limit <= 0 {
+		return previews, nil
+	}
+
+	ranked := r.db.Model(&entity.DialectComment{}).
+		Select("id, ROW_NUMBER() OVER (PARTITION BY parent_id ORDER BY created_at ASC) AS rn").
+		Where("parent_id IN ?", parentIDs)
+	var ids []string
+	err := r.db.WithContext(ctx).Table("(?) AS ranked", ranked).
+		Where("ranked.rn <= ?", limit).
+		Pluck("ranked.id", &ids).Error
+	if err != nil || len(ids) == 0 {
+		return previews, err
+	}
+
+	var replies []entity.DialectComment
+	err = r.db.WithContext(ctx).
+		Where("id IN ?", ids).
+		Order("created_at ASC").
+		Preload("User").
+		Preload("ReplyToUser").
+		Find(&replies).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, reply := range replies {
+		previews[*reply.ParentID] = append(previews[*reply.ParentID], reply)
+	}
+	return previews, nil
+}
+
+// Like 点赞评论
+func (r *DialectCommentRepositoryImpl) Like(ctx context.Context, like *entity.DialectCommentLike) (bool, error) {
+	liked := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&entity.DialectCommentLike{}).
+			Where("comment_id = ? AND user_id = ?", like.CommentID, like.UserID).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return nil
+		}
+		if err := tx.Create(like).Error; err != nil {
+			return err
+		}
+		liked = true
+		return tx.Model(&entity.DialectComment{}).Where("id = ?", like.CommentID).
+			UpdateColumn("like_count", gorm.Expr("like_count + 1")).Error
+	})
+	return liked, err
+}
+
+// Unlike 取消点赞
+func (r *DialectCommentRepositoryImpl) Unlike(ctx context.Context, commentID, userID string) (bool, error) {
+	removed := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("comment_id = ? AND user_id = ?", commentID, userID).Delete(&entity.DialectCommentLike{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		removed = true
+		return tx.Model(&entity.DialectComment{}).Where("id = ?", commentID).
+			UpdateColumn("like_count", gorm.Expr("CASE WHEN like_count > 0 THEN like_count - 1 ELSE 0 END")).Error
+	})
+	return removed, err
+}
+
+// LikedIDs 用户点赞过的评论
+func (r *DialectCommentRepositoryImpl) LikedIDs(ctx context.Context, userID string, commentIDs []string) (map[string]bool, error) {
+	liked := make(map[string]bool)
+	if userID == "" || len(commentIDs) == 0 {
+		return liked, nil
+	}
+
+	var ids []string
+	err := r.db.WithContext(ctx).Model(&entity.DialectCommentLike{}).
+		Where("user_id = ? AND comment_id IN ?", userID, commentIDs).
+		Pluck("comment_id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		liked[id] = true
+	}
+	return liked, nil
+}
+
+// AddReport 举报评论
+func (r *DialectCommentRepositoryImpl) AddReport(ctx context.Context, report *entity.DialectCommentReport) (bool, error) {
+	added := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&entity.DialectCommentReport{}).
+			Where("comment_id = ? AND reporter_id = ?", report.CommentID, report.ReporterID).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return nil
+		}
+		if err := tx.Omit("Reporter").Create(report).Error; err != nil {
+			return err
+		}
+		added = true
+		return tx.Model(&entity.DialectComment{}).Where("id = ?", report.CommentID).
+			UpdateColumn("report_count", gorm.Expr("report_count + 1")).Error
+	})
+	return added, err
+}
+
+// ListReports 评论的举报记录
+func (r *DialectCommentRepositoryImpl) ListReports(ctx context.Context, commentID string) ([]entity.DialectCommentReport, error) {
+	var reports []entity.DialectCommentReport
+	err := r.db.WithContext(ctx).
+		Where("comment_id = ?", commentID).
+		Order("created_at DESC").
+		Preload("Reporter").
+		Find(&reports).Error
+	return reports, err
+}
+
+// SaveModeration 保存管理员对评论的处理
+func (r *DialectCommentRepositoryImpl) SaveModeration(ctx context.Context, comment *entity.DialectComment, reportStatus entity.DialectCommentReportStatus, handledBy string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&entity.DialectComment{}).Where("id = ?", comment.ID).
+			Updates(map[string]interface{}{
+				"status":        comment.Status,
+				"flagged":       comment.Flagged,
+				"hidden_by":     comment.HiddenBy,
+				"hidden_reason": comment.HiddenReason,
+				"hidden_at":     comment.HiddenAt,
+			}).Error
+		if err != nil || reportStatus == "" {
+			return err
+		}
+		return tx.Model(&entity.DialectCommentReport{}).
+			Where("comment_id = ? AND status = ?", comment.ID, entity.CommentReportPending).
+			Updates(map[string]interface{}{
+				"status":     reportStatus,
+				"handled_by": handledBy,
+				"handled_at": time.Now(),
+			}).Error
+	})
+}
+
+// ListModeration 分页查询需要管理的评论，已删除方言的评论不列出
+func (r *DialectCommentRepositoryImpl) ListModeration(ctx context.Context, query *repository.DialectCommentQuery) (*repository.PageResult[entity.DialectComment], error) {
+	var comments []entity.DialectComment
+	var total int64
+
+	db := r.db.WithContext(ctx).Model(&entity.DialectComment{}).
+		Joins("JOIN ty_dialects ON ty_dialects.id = ty_dialect_comments.dialect_id AND ty_dialects.deleted_at IS NULL").
+		Where("ty_dialects.org_id = ?", query.OrgID)
+	if query.DialectID != "" {
+		db = db.Where("ty_dialect_comments.dialect_id = ?", query.DialectID)
+	}
+	switch query.Filter {
+	case repository.DialectCommentFilterFlagged:
+		db = db.Where("ty_dialect_comments.flagged = ?", true)
+	case repository.DialectCommentFilterReported:
+		db = db.Where(dialectCommentPendingReports)
+	case repository.DialectCommentFilterHidden:
+		db = db.Where("ty_dialect_comments.status = ?", entity.DialectCommentHidden)
+	default:
+		db = db.Where("ty_dialect_comments.flagged = ? OR "+dialectCommentPendingReports, true)
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	err := r.Paginate(db.Order("ty_dialect_comments.report_count DESC, ty_dialect_comments.created_at DESC"), query.Pagination).
+		Preload("Dialect").
+		Preload("User").
+		Preload("ReplyToUser").
+		Find(&comments).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return repository.NewPageResult(comments, total, query.Page, query.PageSize), nil
+}
@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DialectGroupRepositoryImpl 方言分类仓储实现
+type DialectGroupRepositoryImpl struct {
+	*BaseRepository[entity.DialectGroup]
+}
+
+// NewDialectGroupRepository 创建方言分类仓储
+func NewDialectGroupRepository(db *gorm.DB) repository.DialectGroupRepository {
+	return &DialectGroupRepositoryImpl{
+		BaseRepository: NewBaseRepository[entity.DialectGroup](db),
+	}
+}
+
+// FindByIDWithRegions 获取分类及其对应地区
+func (r *DialectGroupRepositoryImpl) FindByIDWithRegions(ctx context.Context, id string) (*entity.DialectGroup, error) {
+	var group entity.DialectGroup
+	err := r.db.WithContext(ctx).Preload("Regions").First(&group, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &group, nil
+}
+
+// FindAllWithRegions 获取全部分类
+func (r *DialectGroupRepositoryImpl) FindAllWithRegions(ctx context.Context) ([]entity.DialectGroup, error) {
+	var groups []entity.DialectGroup
+	err := r.db.WithContext(ctx).Preload("Regions").Order("sort ASC, name ASC").Find(&groups).Error
+	return groups, err
+}
+
+// FindSubtree 获取 path 下的全部分类
+func (r *DialectGroupRepositoryImpl) FindSubtree(ctx context.Context, path string) ([]entity.DialectGroup, error) {
+	var groups []entity.DialectGroup
+	err := r.db.WithContext(ctx).Where("path LIKE ?", path+"%").Order("path ASC").Find(&groups).Error
+	return groups, err
+}
+
+// FindByRegion 查找对应地区覆盖指定省市的分类
+func (r *DialectGroupRepositoryImpl) FindByRegion(ctx context.Context, province, city string) ([]entity.DialectGroup, error) {
+	regions := r.db.Model(&entity.DialectGroupRegion{}).Select("group_id").Where("province = ?", province)
+	if city != "" {
+		regions = regions.Where("city = ? OR city = '' OR city IS NULL", city)
+	}
+
+	var groups []entity.DialectGroup
+	err := r.db.WithContext(ctx).
+		Preload("Regions").
+		Where("id IN (?)", regions).
+		Order("sort ASC, name ASC").
+		Find(&groups).Error
+	return groups, err
+}
+
+// ExistsName 同一上级下是否已有同名分类
+func (r *DialectGroupRepositoryImpl) ExistsName(ctx context.Context, parentID *string, name string, excludeID string) (bool, error) {
+	db := r.db.WithContext(ctx).Model(&entity.DialectGroup{}).Where("name = ?", name)
+	if parentID == nil {
+		db = db.Where("parent_id IS NULL")
+	} else {
+		db = db.Where("parent_id = ?", *parentID)
+	}
+	if excludeID != "" {
+		db = db.Where("id <> ?", excludeID)
+	}
+
+	var count int64
+	if err := db.Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// CreateWithRegions 创建分类及其对应地区
+func (r *DialectGroupRepositoryImpl) CreateWithRegions(ctx context.Context, group *entity.DialectGroup) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return createDialectGroup(tx, group)
+	})
+}
+
+// CreateTree 批量创建分类
+func (r *DialectGroupRepositoryImpl) CreateTree(ctx context.Context, groups []*entity.DialectGroup) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, g := range groups {
+			if err := createDialectGroup(tx, g); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// UpdateWithRegions 更新分类并替换对应地区，同时更新下级分类的层级、路径和全称，
+// 已归入该分类的方言地区名称随分类改名
+func (r *DialectGroupRepositoryImpl) UpdateWithRegions(ctx context.Context, group *entity.DialectGroup, descendants []entity.DialectGroup) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(group).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("group_id = ?", group.ID).Delete(&entity.DialectGroupRegion{}).Error; err != nil {
+			return err
+		}
+		if err := createDialectGroupRegions(tx, group); err != nil {
+			return err
+		}
+		if err := tx.Model(&entity.Dialect{}).Where("group_id = ?", group.ID).Update("region", group.Name).Error; err != nil {
+			return err
+		}
+		for _, d := range descendants {
+			if err := tx.Model(&entity.DialectGroup{}).Where("id = ?", d.ID).Updates(map[string]interface{}{
+				"level":     d.Level,
+				"path":      d.Path,
+				"full_name": d.FullName,
+			}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// CountChildren 统计直接下级数
+func (r *DialectGroupRepositoryImpl) CountChildren(ctx context.Context, id string) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&entity.DialectGroup{}).Where("parent_id = ?", id).Count(&count).Error
+	return count, err
+}
+
+// CountDialects 统计 path 子树下已归类的方言数
+func (r *DialectGroupRepositoryImpl) CountDialects(ctx context.Context, path string) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&entity.Dialect{}).
+		Where("group_id IN (?)", r.db.Model(&entity.DialectGroup{}).Select("id").Where("path LIKE ?", path+"%")).
+		Count(&count).Error
+	return count, err
+}
+
+// createDialectGroup 写入分类及其对应地区
+func createDialectGroup(tx *gorm.DB, group *entity.DialectGroup) error {
+	if err := tx.Omit(clause.Associations).Create(group).Error; err != nil {
+		return err
+	}
+	return createDialectGroupRegions(tx, group)
+}
+
+// createDialectGroupRegions 写入对应地区
+func createDialectGroupRegions(tx *gorm.DB, group *entity.DialectGroup) error {
+	if len(group.Regions) == 0 {
+		return nil
+	}
+	for i := range group.Regions {
+		group.Regions[i].ID = ""
+		group.Regions[i].GroupID = group.ID
+	}
+	return tx.Create(&group.Regions).Error
+}
@@ -0,0 +1,311 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// dialectPlaylistCollaboratorOf 用户是歌单的协作者
+const dialectPlaylistCollaboratorOf = "EXISTS (SELECT 1 FROM ty_dialect_playlist_collaborators c " +
+	"WHERE c.playlist_id = ty_dialect_playlists.id AND c.user_id = ?)"
+
+// DialectPlaylistRepositoryImpl 方言歌单仓储实现
+type DialectPlaylistRepositoryImpl struct {
+	*BaseRepository[entity.DialectPlaylist]
+}
+
+// NewDialectPlaylistRepository 创建方言歌单仓储
+func NewDialectPlaylistRepository(db *gorm.DB) repository.DialectPlaylistRepository {
+	return &DialectPlaylistRepositoryImpl{
+		BaseRepository: NewBaseRepository[entity.DialectPlaylist](db),
+	}
+}
+
+// FindByIDWithItems 获取歌单（含创建者、片段及协作者）
+func (r *DialectPlaylistRepositoryImpl) FindByIDWithItems(ctx context.Context, id string) (*entity.DialectPlaylist, error) {
+	var playlist entity.DialectPlaylist
+	err := r.db.WithContext(ctx).
+		Preload("Owner").
+		Preload("Items", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position ASC, created_at ASC")
+		}).
+		Preload("Items.Dialect").
+		Preload("Collaborators", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		}).
+		Preload("Collaborators.User").
+		First(&playlist, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &playlist, nil
+}
+
+// List 分页查询歌单
+func (r *DialectPlaylistRepositoryImpl) List(ctx context.Context, query *repository.DialectPlaylistQuery) (*repository.PageResult[entity.DialectPlaylist], error) {
+	var playlists []entity.DialectPlaylist
+	var total int64
+
+	db := r.db.WithContext(ctx).Model(&entity.DialectPlaylist{})
+	switch query.Scope {
+	case repository.DialectPlaylistScopeMine:
+		db = db.Where("owner_id = ?", query.UserID)
+	case repository.DialectPlaylistScopeShared:
+		db = db.Where(dialectPlaylistCollaboratorOf, query.UserID)
+	case repository.DialectPlaylistScopeOrg:
+		db = db.Where("org_id = ? AND visibility = ?", query.OrgID, entity.DialectPlaylistOrg)
+	case repository.DialectPlaylistScopeAuto:
+		db = db.Where("org_id = ? AND kind = ?", query.OrgID, entity.DialectPlaylistAuto)
+	default:
+		db = db.Where("owner_id = ? OR "+dialectPlaylistCollaboratorOf+" OR (org_id = ? AND visibility = ?)",
+			query.UserID, query.UserID, query.OrgID, entity.DialectPlaylistOrg)
+	}
+	if query.Province != "" {
+		db = db.Where("province = ?", query.Province)
+	}
+	if query.Keyword != "" {
+		db = db.Where("title LIKE ? OR description LIKE ?", "%"+query.Keyword+"%", "%"+query.Keyword+"%")
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	err := r.Paginate(db.Order("updated_at DESC"), query.Pagination).
+		Preload("Owner").
+		Find(&playlists).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return repository.NewPageResult(playlists, total, query.Page, query.PageSize), nil
+}
+
+// SaveInfo 保存歌单信息
+func (r *DialectPlaylistRepositoryImpl) SaveInfo(ctx context.Context, playlist *entity.DialectPlaylist) error {
+	return r.db.WithContext(ctx).Model(&entity.DialectPlaylist{}).Where("id = ?", playlist.ID).
+		Updates(map[string]interface{}{
+			"title":       playlist.Title,
+			"description": playlist.Description,
+			"visibility":  playlist.Visibility,
+			"updated_at":  time.Now(),
+		}).Error
+}
+
+// AddItem 添加片段
+func (r *DialectPlaylistRepositoryImpl) AddItem(ctx context.Context, item *entity.DialectPlaylistItem) (bool, error) {
+	added := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 锁定歌单，避免协作者同时添加时位置冲突
+		if err := r.lock(tx, item.PlaylistID); err != nil {
+			return err
+		}
+
+		var stats struct {
+			Items      int64
+			Duplicates int64
+			Position   int
+		}
+		err := tx.Model(&entity.DialectPlaylistItem{}).
+			Select("COUNT(*) AS items, "+
+				"COALESCE(SUM(CASE WHEN dialect_id = ? THEN 1 ELSE 0 END), 0) AS duplicates, "+
+				"COALESCE(MAX(position), 0) AS position", item.DialectID).
+			Where("playlist_id = ?", item.PlaylistID).
+			Scan(&stats).Error
+		if err != nil {
+			return err
+		}
+		if stats.Duplicates > 0 {
+			return nil
+		}
+		if stats.Items >= entity.DialectPlaylistMaxItems {
+			return entity.ErrDialectPlaylistFull
+		}
+
+		if item.Position <= 0 || item.Position > stats.Position {
+			item.Position = stats.Position + 1
+		} else if err := tx.Model(&entity.DialectPlaylistItem{}).
+			Where("playlist_id = ? AND position >= ?", item.PlaylistID, item.Position).
+			UpdateColumn("position", gorm.Expr("position + 1")).Error; err != nil {
+			return err
+		}
+		if err := tx.Omit("Dialect").Create(item).Error; err != nil {
+			return err
+		}
+		added = true
+		return r.summarize(tx, item.PlaylistID, nil)
+	})
+	return added, err
+}
+
+// RemoveItem 移除片段
+func (r *DialectPlaylistRepositoryImpl) RemoveItem(ctx context.Context, playlistID, itemID string) (bool, error) {
+	removed := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := r.lock(tx, playlistID); err != nil {
+			return err
+		}
+
+		var item entity.DialectPlaylistItem
+		err := tx.Where("id = ? AND playlist_id = ?", itemID, playlistID).First(&item).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := tx.Delete(&item).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&entity.DialectPlaylistItem{}).
+			Where("playlist_id = ? AND position > ?", playlistID, item.Position).
+			UpdateColumn("position", gorm.Expr("position - 1")).Error; err != nil {
+			return err
+		}
+		removed = true
+		return r.summarize(tx, playlistID, nil)
+	})
+	return removed, err
+}
+
+// Reorder 更新片段顺序
+func (r *DialectPlaylistRepositoryImpl) Reorder(ctx context.Context, playlistID string, positions map[string]int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := r.lock(tx, playlistID); err != nil {
+			return err
+		}
+
+		// 排序期间其他协作者增删过片段时放弃本次排序
+		var ids []string
+		if err := tx.Model(&entity.DialectPlaylistItem{}).
+			Where("playlist_id = ?", playlistID).
+			Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) != len(positions) {
+			return entity.ErrDialectPlaylistStale
+		}
+		for _, id := range ids {
+			if _, ok := positions[id]; !ok {
+				return entity.ErrDialectPlaylistStale
+			}
+		}
+
+		for id, position := range positions {
+			if err := tx.Model(&entity.DialectPlaylistItem{}).
+				Where("id = ?", id).
+				UpdateColumn("position", position).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Model(&entity.DialectPlaylist{}).Where("id = ?", playlistID).
+			UpdateColumn("updated_at", time.Now()).Error
+	})
+}
+
+// ReplaceItems 替换全部片段
+func (r *DialectPlaylistRepositoryImpl) ReplaceItems(ctx context.Context, playlistID string, items []entity.DialectPlaylistItem, refreshedAt time.Time) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := r.lock(tx, playlistID); err != nil {
+			return err
+		}
+		if err := tx.Where("playlist_id = ?", playlistID).Delete(&entity.DialectPlaylistItem{}).Error; err != nil {
+			return err
+		}
+		if len(items) > 0 {
+			if err := tx.Omit("Dialect").Create(&items).Error; err != nil {
+				return err
+			}
+		}
+		return r.summarize(tx, playlistID, &refreshedAt)
+	})
+}
+
+// AddCollaborator 添加协作者
+func (r *DialectPlaylistRepositoryImpl) AddCollaborator(ctx context.Context, collaborator *entity.DialectPlaylistCollaborator) (bool, error) {
+	added := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&entity.DialectPlaylistCollaborator{}).
+			Where("playlist_id = ? AND user_id = ?", collaborator.PlaylistID, collaborator.UserID).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return nil
+		}
+		if err := tx.Omit("User").Create(collaborator).Error; err != nil {
+			return err
+		}
+		added = true
+		return nil
+	})
+	return added, err
+}
+
+// RemoveCollaborator 移除协作者
+func (r *DialectPlaylistRepositoryImpl) RemoveCollaborator(ctx context.Context, playlistID, userID string) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Where("playlist_id = ? AND user_id = ?", playlistID, userID).
+		Delete(&entity.DialectPlaylistCollaborator{})
+	return result.RowsAffected > 0, result.Error
+}
+
+// FindCollectionCandidates 省份内的候选片段，先按精选和点赞数粗选，播放完成度来自播放记录
+func (r *DialectPlaylistRepositoryImpl) FindCollectionCandidates(ctx context.Context, province string, limit int) ([]entity.DialectClipCandidate, error) {
+	var candidates []entity.DialectClipCandidate
+
+	completion := r.db.Table("ty_dialect_play_logs AS l").
+		Select("l.dialect_id, "+
+			"COALESCE(SUM("+dialectPlayReportedExpr+"), 0) AS reported, "+
+			"COALESCE(SUM("+dialectPlayCompletionExpr+"), 0) AS completion_sum").
+		Joins("JOIN ty_dialects d ON d.id = l.dialect_id").
+		Where("d.province = ?", province).
+		Group("l.dialect_id")
+
+	err := r.db.WithContext(ctx).
+		Table("ty_dialects AS d").
+		Select("d.id AS dialect_id, d.is_featured, d.like_count, d.created_at, "+
+			"COALESCE(p.reported, 0) AS reported, COALESCE(p.completion_sum, 0) AS completion_sum").
+		Joins("LEFT JOIN (?) AS p ON p.dialect_id = d.id", completion).
+		Where("d.province = ? AND d.status = ? AND d.deleted_at IS NULL", province, entity.DialectStatusActive).
+		Order("d.is_featured DESC, d.like_count DESC, d.created_at DESC").
+		Limit(limit).
+		Scan(&candidates).Error
+	return candidates, err
+}
+
+// lock 锁定歌单行，串行化同一歌单的片段修改
+func (r *DialectPlaylistRepositoryImpl) lock(tx *gorm.DB, playlistID string) error {
+	var playlist entity.DialectPlaylist
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		First(&playlist, "id = ?", playlistID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	return err
+}
+
+// summarize 重新计算歌单的片段数和总时长
+func (r *DialectPlaylistRepositoryImpl) summarize(tx *gorm.DB, playlistID string, refreshedAt *time.Time) error {
+	updates := map[string]interface{}{
+		"item_count": gorm.Expr("(SELECT COUNT(*) FROM ty_dialect_playlist_items i WHERE i.playlist_id = ?)", playlistID),
+		"total_duration": gorm.Expr("(SELECT COALESCE(SUM(d.duration), 0) FROM ty_dialect_playlist_items i "+
+			"JOIN ty_dialects d ON d.id = i.dialect_id AND d.deleted_at IS NULL WHERE i.playlist_id = ?)", playlistID),
+		"updated_at": time.Now(),
+	}
+	if refreshedAt != nil {
+		updates["refreshed_at"] = *refreshedAt
+	}
+	return tx.Model(&entity.DialectPlaylist{}).Where("id = ?", playlistID).Updates(updates).Error
+}
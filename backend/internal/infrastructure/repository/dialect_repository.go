@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
 	"github.com/Snowitty-Re/CNtunyuan/internal/domain/repository"
@@ -39,6 +40,9 @@ func (r *DialectRepositoryImpl) List(ctx context.Context, query *repository.Dial
 	if query.Region != "" {
 		db = db.Where("region = ?", query.Region)
 	}
+	if query.GroupPath != "" {
+		db = db.Where("group_id IN (?)", r.db.Model(&entity.DialectGroup{}).Select("id").Where("path LIKE ?", query.GroupPath+"%"))
+	}
 	if query.Province != "" {
 		db = db.Where("province = ?", query.Province)
 	}
@@ -80,6 +84,7 @@ func (r *DialectRepositoryImpl) List(ctx context.Context, query *repository.Dial
 	// 分页查询
 	if err := db.Order(order).
 		Preload("Uploader").
+		Preload("Group").
 		Offset((query.Page - 1) * query.PageSize).
 		Limit(query.PageSize).
 		Find(&dialects).Error; err != nil {
@@ -203,38 +208,6 @@ func (r *DialectRepositoryImpl) DecrementLikeCount(ctx context.Context, id strin
 	return r.db.WithContext(ctx).Model(&entity.Dialect{}).Where("id = ?", id).UpdateColumn("like_count", gorm.Expr("CASE WHEN like_count > 0 THEN like_count - 1 ELSE 0 END")).Error
 }
 
-// AddComment 添加评论
-func (r *DialectRepositoryImpl) AddComment(ctx context.Context, comment *entity.DialectComment) error {
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		if err := tx.Create(comment).Error; err != nil {
-			return err
-		}
-		// 更新评论数
-		return tx.Model(&entity.Dialect{}).Where("id = ?", comment.DialectID).UpdateColumn("comment_count", gorm.Expr("comment_count + 1")).Error
-	})
-}
-
-// GetComments 获取评论
-func (r *DialectRepositoryImpl) GetComments(ctx context.Context, dialectID string, pagination repository.Pagination) (*repository.PageResult[entity.DialectComment], error) {
-	var comments []entity.DialectComment
-	var total int64
-
-	db := r.db.WithContext(ctx).Where("dialect_id = ?", dialectID)
-
-	if err := db.Model(&entity.DialectComment{}).Count(&total).Error; err != nil {
-		return nil, err
-	}
-
-	if err := db.Order("created_at DESC").Preload("User").
-		Offset((pagination.Page - 1) * pagination.PageSize).
-		Limit(pagination.PageSize).
-		Find(&comments).Error; err != nil {
-		return nil, err
-	}
-
-	return repository.NewPageResult(comments, total, pagination.Page, pagination.PageSize), nil
-}
-
 // AddLike 添加点赞
 func (r *DialectRepositoryImpl) AddLike(ctx context.Context, like *entity.DialectLike) error {
 	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
@@ -273,6 +246,33 @@ func (r *DialectRepositoryImpl) AddPlayLog(ctx context.Context, log *entity.Dial
 	return r.db.WithContext(ctx).Create(log).Error
 }
 
+// RecordPlay 记录播放，窗口内的重复播放合并到已有记录
+func (r *DialectRepositoryImpl) RecordPlay(ctx context.Context, log *entity.DialectPlayLog, since time.Time) (bool, error) {
+	counted := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var recent entity.DialectPlayLog
+		err := tx.Where("dialect_id = ? AND listener_key = ? AND created_at >= ?", log.DialectID, log.ListenerKey, since).
+			Order("created_at DESC").
+			First(&recent).Error
+		if err == nil {
+			if log.Duration <= recent.Duration {
+				return nil
+			}
+			return tx.Model(&entity.DialectPlayLog{}).Where("id = ?", recent.ID).Update("duration", log.Duration).Error
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		if err := tx.Create(log).Error; err != nil {
+			return err
+		}
+		counted = true
+		return tx.Model(&entity.Dialect{}).Where("id = ?", log.DialectID).UpdateColumn("play_count", gorm.Expr("play_count + 1")).Error
+	})
+	return counted, err
+}
+
 // GetStats 获取统计
 func (r *DialectRepositoryImpl) GetStats(ctx context.Context) (*entity.DialectStats, error) {
 	stats := &entity.DialectStats{}
@@ -328,3 +328,68 @@ func (r *DialectRepositoryImpl) FindByID(ctx context.Context, id string) (*entit
 	}
 	return &dialect, nil
 }
+
+// FindUngrouped 查找尚未归入方言分类的方言
+func (r *DialectRepositoryImpl) FindUngrouped(ctx context.Context) ([]entity.Dialect, error) {
+	var dialects []entity.Dialect
+	err := r.db.WithContext(ctx).
+		Select("id", "title", "region", "province", "city").
+		Where("group_id IS NULL").
+		Order("created_at ASC").
+		Find(&dialects).Error
+	return dialects, err
+}
+
+// AssignGroup 设置方言分类
+func (r *DialectRepositoryImpl) AssignGroup(ctx context.Context, id, groupID, region string) error {
+	return r.db.WithContext(ctx).Model(&entity.Dialect{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{"group_id": groupID, "region": region}).Error
+}
+
+// FindIdentificationClips 查找可用于方言辨识的片段
+func (r *DialectRepositoryImpl) FindIdentificationClips(ctx context.Context, query *repository.DialectClipQuery) ([]entity.Dialect, error) {
+	var dialects []entity.Dialect
+
+	db := r.db.WithContext(ctx).
+		Where("status = ?", entity.DialectStatusActive).
+		Where("province <> ''")
+	if query.Province != "" {
+		db = db.Where("province = ?", query.Province)
+	}
+	if query.City != "" {
+		db = db.Where("city = ?", query.City)
+	}
+	if len(query.ExcludeIDs) > 0 {
+		db = db.Where("id NOT IN ?", query.ExcludeIDs)
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = 500
+	}
+
+	err := db.Order("is_featured DESC, play_count DESC, created_at DESC").Limit(limit).Find(&dialects).Error
+	return dialects, err
+}
+
+// FindFingerprints 获取已计算声纹指纹的其它方言
+func (r *DialectRepositoryImpl) FindFingerprints(ctx context.Context, excludeID string, minDuration, maxDuration int) ([]entity.Dialect, error) {
+	var dialects []entity.Dialect
+	err := r.db.WithContext(ctx).
+		Select("id", "duration", "fingerprint").
+		Where("fingerprint IS NOT NULL AND fingerprint <> '' AND id <> ?", excludeID).
+		Where("duration BETWEEN ? AND ?", minDuration, maxDuration).
+		Find(&dialects).Error
+	return dialects, err
+}
+
+// FindByIDs 批量查找
+func (r *DialectRepositoryImpl) FindByIDs(ctx context.Context, ids []string) ([]entity.Dialect, error) {
+	var dialects []entity.Dialect
+	if len(ids) == 0 {
+		return dialects, nil
+	}
+	err := r.db.WithContext(ctx).Preload("Uploader").Where("id IN ?", ids).Find(&dialects).Error
+	return dialects, err
+}
@@ -0,0 +1,227 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// DialectReviewRepositoryImpl 方言审核仓储实现
+type DialectReviewRepositoryImpl struct {
+	*BaseRepository[entity.DialectReview]
+}
+
+// NewDialectReviewRepository 创建方言审核仓储
+func NewDialectReviewRepository(db *gorm.DB) repository.DialectReviewRepository {
+	return &DialectReviewRepositoryImpl{
+		BaseRepository: NewBaseRepository[entity.DialectReview](db),
+	}
+}
+
+// FindByIDWithDecisions 获取审核（含方言、当前认领人和审核历史）
+func (r *DialectReviewRepositoryImpl) FindByIDWithDecisions(ctx context.Context, id string) (*entity.DialectReview, error) {
+	var review entity.DialectReview
+	err := r.db.WithContext(ctx).
+		Preload("Dialect").
+		Preload("Reviewer").
+		Preload("DuplicateOf").
+		Preload("Decisions", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		}).
+		Preload("Decisions.Reviewer").
+		First(&review, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &review, nil
+}
+
+// FindOpenByDialect 获取方言未结束的审核
+func (r *DialectReviewRepositoryImpl) FindOpenByDialect(ctx context.Context, dialectID string) (*entity.DialectReview, error) {
+	var review entity.DialectReview
+	err := r.db.WithContext(ctx).
+		Where("dialect_id = ? AND status IN ?", dialectID, []entity.DialectReviewStatus{entity.DialectReviewPending, entity.DialectReviewClaimed}).
+		First(&review).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &review, nil
+}
+
+// List 分页查询审核队列，已删除方言的审核不列出
+func (r *DialectReviewRepositoryImpl) List(ctx context.Context, query *repository.DialectReviewQuery) (*repository.PageResult[entity.DialectReview], error) {
+	var reviews []entity.DialectReview
+	var total int64
+
+	db := r.db.WithContext(ctx).Model(&entity.DialectReview{}).
+		Joins("JOIN ty_dialects ON ty_dialects.id = ty_dialect_reviews.dialect_id AND ty_dialects.deleted_at IS NULL")
+	if query.OrgID != "" {
+		db = db.Where("ty_dialect_reviews.org_id = ?", query.OrgID)
+	}
+	if query.Status != "" {
+		db = db.Where("ty_dialect_reviews.status = ?", query.Status)
+	}
+	if query.ReviewerID != "" {
+		db = db.Where("ty_dialect_reviews.reviewer_id = ?", query.ReviewerID)
+	}
+	if query.DialectID != "" {
+		db = db.Where("ty_dialect_reviews.dialect_id = ?", query.DialectID)
+	}
+	if query.Duplicate {
+		db = db.Where("ty_dialect_reviews.duplicate_of_id IS NOT NULL")
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	err := r.Paginate(db.Order("ty_dialect_reviews.created_at ASC"), query.Pagination).
+		Preload("Dialect").
+		Preload("Reviewer").
+		Preload("DuplicateOf").
+		Find(&reviews).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return repository.NewPageResult(reviews, total, query.Page, query.PageSize), nil
+}
+
+// FindClaimable 获取可由该审核员认领的审核
+func (r *DialectReviewRepositoryImpl) FindClaimable(ctx context.Context, orgID, reviewerID string, staleBefore time.Time, limit int) ([]entity.DialectReview, error) {
+	var reviews []entity.DialectReview
+	err := r.db.WithContext(ctx).
+		Joins("JOIN ty_dialects ON ty_dialects.id = ty_dialect_reviews.dialect_id AND ty_dialects.deleted_at IS NULL").
+		Where("ty_dialect_reviews.org_id = ?", orgID).
+		Where("ty_dialect_reviews.status = ? OR (ty_dialect_reviews.status = ? AND ty_dialect_reviews.claimed_at < ?)",
+			entity.DialectReviewPending, entity.DialectReviewClaimed, staleBefore).
+		Where("NOT EXISTS (?)", r.db.Model(&entity.DialectReviewDecision{}).
+			Select("1").
+			Where("ty_dialect_review_decisions.review_id = ty_dialect_reviews.id AND ty_dialect_review_decisions.reviewer_id = ?", reviewerID)).
+		Order("ty_dialect_reviews.created_at ASC").
+		Limit(limit).
+		Find(&reviews).Error
+	return reviews, err
+}
+
+// Claim 认领审核，以条件更新避免两名审核员同时认领
+func (r *DialectReviewRepositoryImpl) Claim(ctx context.Context, review *entity.DialectReview, staleBefore time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&entity.DialectReview{}).
+		Where("id = ?", review.ID).
+		Where("status = ? OR (status = ? AND (claimed_at < ? OR reviewer_id = ?))",
+			entity.DialectReviewPending, entity.DialectReviewClaimed, staleBefore, review.ReviewerID).
+		Updates(map[string]interface{}{
+			"status":      review.Status,
+			"reviewer_id": review.ReviewerID,
+			"claimed_at":  review.ClaimedAt,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Release 释放认领
+func (r *DialectReviewRepositoryImpl) Release(ctx context.Context, review *entity.DialectReview, claimedBy string) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&entity.DialectReview{}).
+		Where("id = ? AND status = ? AND reviewer_id = ?", review.ID, entity.DialectReviewClaimed, claimedBy).
+		Updates(map[string]interface{}{
+			"status":      review.Status,
+			"reviewer_id": nil,
+			"claimed_at":  nil,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// SaveDecision 保存审核员结论并更新审核，审核结束时同时更新方言状态
+func (r *DialectReviewRepositoryImpl) SaveDecision(ctx context.Context, review *entity.DialectReview, decision *entity.DialectReviewDecision) (bool, error) {
+	saved := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entity.DialectReview{}).
+			Where("id = ? AND status = ? AND reviewer_id = ?", review.ID, entity.DialectReviewClaimed, decision.ReviewerID).
+			Updates(map[string]interface{}{
+				"status":      review.Status,
+				"approvals":   review.Approvals,
+				"reviewer_id": review.ReviewerID,
+				"claimed_at":  review.ClaimedAt,
+				"reason_code": review.ReasonCode,
+				"comment":     review.Comment,
+				"decided_at":  review.DecidedAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		if err := tx.Omit("Reviewer").Create(decision).Error; err != nil {
+			return err
+		}
+		if status := review.DialectStatus(); status != "" {
+			if err := tx.Model(&entity.Dialect{}).Where("id = ?", review.DialectID).Update("status", status).Error; err != nil {
+				return err
+			}
+		}
+		saved = true
+		return nil
+	})
+	return saved, err
+}
+
+// CountByStatus 统计组织中各状态的审核数
+func (r *DialectReviewRepositoryImpl) CountByStatus(ctx context.Context, orgID string) (map[entity.DialectReviewStatus]int64, error) {
+	var rows []struct {
+		Status entity.DialectReviewStatus
+		Count  int64
+	}
+	err := r.db.WithContext(ctx).Model(&entity.DialectReview{}).
+		Select("status, COUNT(*) AS count").
+		Where("org_id = ?", orgID).
+		Group("status").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[entity.DialectReviewStatus]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
+
+// ReviewerStats 统计各审核员的审核结论，按审核总数降序
+func (r *DialectReviewRepositoryImpl) ReviewerStats(ctx context.Context, orgID string, since *time.Time) ([]entity.DialectReviewerStats, error) {
+	db := r.db.WithContext(ctx).Model(&entity.DialectReviewDecision{}).
+		Select(`ty_dialect_review_decisions.reviewer_id,
+			MAX(ty_users.nickname) AS nickname,
+			SUM(CASE WHEN ty_dialect_review_decisions.action = ? THEN 1 ELSE 0 END) AS approved,
+			SUM(CASE WHEN ty_dialect_review_decisions.action = ? THEN 1 ELSE 0 END) AS rejected,
+			AVG(ty_dialect_review_decisions.handle_seconds) AS avg_seconds,
+			MAX(ty_dialect_review_decisions.created_at) AS last_decided_at`,
+			entity.DialectReviewActionApprove, entity.DialectReviewActionReject).
+		Joins("LEFT JOIN ty_users ON ty_users.id = ty_dialect_review_decisions.reviewer_id").
+		Where("ty_dialect_review_decisions.org_id = ?", orgID)
+	if since != nil {
+		db = db.Where("ty_dialect_review_decisions.created_at >= ?", *since)
+	}
+
+	var stats []entity.DialectReviewerStats
+	err := db.Group("ty_dialect_review_decisions.reviewer_id").
+		Order("COUNT(*) DESC").
+		Scan(&stats).Error
+	return stats, err
+}
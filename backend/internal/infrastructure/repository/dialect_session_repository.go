@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DialectSessionRepositoryImpl 方言辨识会话仓储实现
+type DialectSessionRepositoryImpl struct {
+	*BaseRepository[entity.DialectSession]
+}
+
+// NewDialectSessionRepository 创建方言辨识会话仓储
+func NewDialectSessionRepository(db *gorm.DB) repository.DialectSessionRepository {
+	return &DialectSessionRepositoryImpl{
+		BaseRepository: NewBaseRepository[entity.DialectSession](db),
+	}
+}
+
+// FindByIDWithAnswers 查找会话（含受测者反应）
+func (r *DialectSessionRepositoryImpl) FindByIDWithAnswers(ctx context.Context, id string) (*entity.DialectSession, error) {
+	var session entity.DialectSession
+	err := r.db.WithContext(ctx).
+		Preload("Answers", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		}).
+		Preload("Answers.Dialect").
+		First(&session, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &session, nil
+}
+
+// AddAnswer 保存受测者反应及会话当前阶段
+func (r *DialectSessionRepositoryImpl) AddAnswer(ctx context.Context, session *entity.DialectSession, answer *entity.DialectSessionAnswer) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Dialect").Create(answer).Error; err != nil {
+			return err
+		}
+		return tx.Model(&entity.DialectSession{}).
+			Where("id = ?", session.ID).
+			Updates(map[string]interface{}{
+				"stage":    session.Stage,
+				"province": session.Province,
+				"city":     session.City,
+			}).Error
+	})
+}
+
+// UpdateSession 更新会话
+func (r *DialectSessionRepositoryImpl) UpdateSession(ctx context.Context, session *entity.DialectSession) error {
+	return r.db.WithContext(ctx).Omit(clause.Associations).Save(session).Error
+}
+
+// AttachTrack 保存线索并关联会话
+func (r *DialectSessionRepositoryImpl) AttachTrack(ctx context.Context, session *entity.DialectSession, track *entity.MissingPersonTrack) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(track).Error; err != nil {
+			return err
+		}
+		session.TrackID = &track.ID
+		return tx.Omit(clause.Associations).Save(session).Error
+	})
+}
+
+// List 分页查询
+func (r *DialectSessionRepositoryImpl) List(ctx context.Context, query *repository.DialectSessionQuery) (*repository.PageResult[entity.DialectSession], error) {
+	var sessions []entity.DialectSession
+	var total int64
+
+	db := r.db.WithContext(ctx).Model(&entity.DialectSession{})
+	if query.MissingPersonID != "" {
+		db = db.Where("missing_person_id = ?", query.MissingPersonID)
+	}
+	if query.OperatorID != "" {
+		db = db.Where("operator_id = ?", query.OperatorID)
+	}
+	if query.OrgID != "" {
+		db = db.Where("org_id = ?", query.OrgID)
+	}
+	if query.Status != "" {
+		db = db.Where("status = ?", query.Status)
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	if err := r.Paginate(db.Order("created_at DESC"), query.Pagination).Find(&sessions).Error; err != nil {
+		return nil, err
+	}
+
+	return repository.NewPageResult(sessions, total, query.Page, query.PageSize), nil
+}
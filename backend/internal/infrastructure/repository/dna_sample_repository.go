@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// DNASampleRepositoryImpl DNA 样本仓储实现
+type DNASampleRepositoryImpl struct {
+	*BaseRepository[entity.DNASample]
+}
+
+// NewDNASampleRepository 创建 DNA 样本仓储
+func NewDNASampleRepository(db *gorm.DB) repository.DNASampleRepository {
+	return &DNASampleRepositoryImpl{
+		BaseRepository: NewBaseRepository[entity.DNASample](db),
+	}
+}
+
+// CreateWithLog 保存样本及采集记录
+func (r *DNASampleRepositoryImpl) CreateWithLog(ctx context.Context, sample *entity.DNASample, log *entity.DNASampleCustodyLog) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Collector").Create(sample).Error; err != nil {
+			return err
+		}
+		log.SampleID = sample.ID
+		return tx.Create(log).Error
+	})
+}
+
+// UpdateWithLog 保存样本状态及保管链记录
+func (r *DNASampleRepositoryImpl) UpdateWithLog(ctx context.Context, sample *entity.DNASample, log *entity.DNASampleCustodyLog) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&entity.DNASample{}).
+			Where("id = ? AND status = ?", sample.ID, log.FromStatus).
+			Updates(map[string]interface{}{
+				"status":       sample.Status,
+				"holder":       sample.Holder,
+				"lab_name":     sample.LabName,
+				"lab_case_no":  sample.LabCaseNo,
+				"submitted_at": sample.SubmittedAt,
+				"result":       sample.Result,
+				"result_ref":   sample.ResultRef,
+				"result_at":    sample.ResultAt,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return fmt.Errorf("%w: 样本状态已被修改", entity.ErrDNASampleTransitionNotAllowed)
+		}
+		return tx.Create(log).Error
+	})
+}
+
+// UpdateInfo 更新采集信息
+func (r *DNASampleRepositoryImpl) UpdateInfo(ctx context.Context, sample *entity.DNASample) error {
+	return r.db.WithContext(ctx).Model(&entity.DNASample{}).
+		Where("id = ?", sample.ID).
+		Updates(map[string]interface{}{
+			"sample_type":     sample.SampleType,
+			"donor_name":      sample.DonorName,
+			"donor_relation":  sample.DonorRelation,
+			"donor_phone":     sample.DonorPhone,
+			"collected_at":    sample.CollectedAt,
+			"collection_site": sample.CollectionSite,
+			"note":            sample.Note,
+		}).Error
+}
+
+// FindBySampleNo 根据样本编号查找
+func (r *DNASampleRepositoryImpl) FindBySampleNo(ctx context.Context, sampleNo string) (*entity.DNASample, error) {
+	var sample entity.DNASample
+	err := r.db.WithContext(ctx).First(&sample, "sample_no = ?", sampleNo).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &sample, nil
+}
+
+// List 分页查询
+func (r *DNASampleRepositoryImpl) List(ctx context.Context, query *repository.DNASampleQuery) (*repository.PageResult[entity.DNASample], error) {
+	var samples []entity.DNASample
+	var total int64
+
+	db := r.db.WithContext(ctx).Model(&entity.DNASample{})
+	if query.MissingPersonID != "" {
+		db = db.Where("missing_person_id = ?", query.MissingPersonID)
+	}
+	if query.CollectorID != "" {
+		db = db.Where("collector_id = ?", query.CollectorID)
+	}
+	if query.OrgID != "" {
+		db = db.Where("org_id = ?", query.OrgID)
+	}
+	if len(query.OrgIDs) > 0 {
+		db = db.Where("org_id IN ?", query.OrgIDs)
+	}
+	if query.Status != "" {
+		db = db.Where("status = ?", query.Status)
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	if err := r.Paginate(db.Order("collected_at DESC"), query.Pagination).Preload("Collector").Find(&samples).Error; err != nil {
+		return nil, err
+	}
+
+	return repository.NewPageResult(samples, total, query.Page, query.PageSize), nil
+}
+
+// GetCustodyLogs 获取样本保管链记录
+func (r *DNASampleRepositoryImpl) GetCustodyLogs(ctx context.Context, sampleID string) ([]entity.DNASampleCustodyLog, error) {
+	var logs []entity.DNASampleCustodyLog
+	err := r.db.WithContext(ctx).
+		Where("sample_id = ?", sampleID).
+		Order("occurred_at ASC, created_at ASC").
+		Preload("Operator").
+		Find(&logs).Error
+	return logs, err
+}
@@ -0,0 +1,230 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// familyCandidateLimit 比对候选默认上限
+const familyCandidateLimit = 500
+
+// FamilySearcherRepositoryImpl 寻亲者仓储实现
+type FamilySearcherRepositoryImpl struct {
+	*BaseRepository[entity.FamilySearcher]
+}
+
+// NewFamilySearcherRepository 创建寻亲者仓储
+func NewFamilySearcherRepository(db *gorm.DB) repository.FamilySearcherRepository {
+	return &FamilySearcherRepositoryImpl{
+		BaseRepository: NewBaseRepository[entity.FamilySearcher](db),
+	}
+}
+
+// CreateWithDetails 保存寻亲者及联系人、亲属
+func (r *FamilySearcherRepositoryImpl) CreateWithDetails(ctx context.Context, searcher *entity.FamilySearcher) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(searcher).Error; err != nil {
+			return err
+		}
+		return replaceSearcherDetails(tx, searcher)
+	})
+}
+
+// UpdateWithDetails 更新寻亲者并整体替换联系人、亲属
+func (r *FamilySearcherRepositoryImpl) UpdateWithDetails(ctx context.Context, searcher *entity.FamilySearcher) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(searcher).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("searcher_id = ?", searcher.ID).Delete(&entity.FamilySearcherContact{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("searcher_id = ?", searcher.ID).Delete(&entity.FamilySearcherRelative{}).Error; err != nil {
+			return err
+		}
+		return replaceSearcherDetails(tx, searcher)
+	})
+}
+
+// replaceSearcherDetails 写入联系人、亲属
+func replaceSearcherDetails(tx *gorm.DB, searcher *entity.FamilySearcher) error {
+	for i := range searcher.Contacts {
+		searcher.Contacts[i].ID = ""
+		searcher.Contacts[i].SearcherID = searcher.ID
+	}
+	for i := range searcher.Relatives {
+		searcher.Relatives[i].ID = ""
+		searcher.Relatives[i].SearcherID = searcher.ID
+	}
+	if len(searcher.Contacts) > 0 {
+		if err := tx.Create(&searcher.Contacts).Error; err != nil {
+			return err
+		}
+	}
+	if len(searcher.Relatives) > 0 {
+		if err := tx.Create(&searcher.Relatives).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FindByIDWithDetails 查找寻亲者（含联系人、亲属、关联案件）
+func (r *FamilySearcherRepositoryImpl) FindByIDWithDetails(ctx context.Context, id string) (*entity.FamilySearcher, error) {
+	var searcher entity.FamilySearcher
+	err := r.db.WithContext(ctx).
+		Preload("Contacts", func(db *gorm.DB) *gorm.DB {
+			return db.Order("is_primary DESC, created_at ASC")
+		}).
+		Preload("Relatives", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		}).
+		Preload("Cases", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		}).
+		Preload("Cases.MissingPerson").
+		First(&searcher, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &searcher, nil
+}
+
+// FindByIDs 批量查找寻亲者
+func (r *FamilySearcherRepositoryImpl) FindByIDs(ctx context.Context, ids []string) ([]entity.FamilySearcher, error) {
+	var searchers []entity.FamilySearcher
+	if len(ids) == 0 {
+		return searchers, nil
+	}
+	err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&searchers).Error
+	return searchers, err
+}
+
+// List 分页查询
+func (r *FamilySearcherRepositoryImpl) List(ctx context.Context, query *repository.FamilySearcherQuery) (*repository.PageResult[entity.FamilySearcher], error) {
+	var searchers []entity.FamilySearcher
+	var total int64
+
+	db := r.db.WithContext(ctx).Model(&entity.FamilySearcher{})
+	if query.Kind != "" {
+		db = db.Where("kind = ?", query.Kind)
+	}
+	if query.Status != "" {
+		db = db.Where("status = ?", query.Status)
+	}
+	if query.OrgID != "" {
+		db = db.Where("org_id = ?", query.OrgID)
+	}
+	if query.Keyword != "" {
+		like := "%" + query.Keyword + "%"
+		db = db.Where("name LIKE ? OR lost_name LIKE ? OR id IN (?)", like, like,
+			r.db.Model(&entity.FamilySearcherContact{}).Select("searcher_id").Where("phone LIKE ? OR name LIKE ?", like, like))
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	if err := r.Paginate(db.Order("created_at DESC"), query.Pagination).
+		Preload("Contacts", func(db *gorm.DB) *gorm.DB {
+			return db.Order("is_primary DESC, created_at ASC")
+		}).
+		Find(&searchers).Error; err != nil {
+		return nil, err
+	}
+
+	return repository.NewPageResult(searchers, total, query.Page, query.PageSize), nil
+}
+
+// LinkCase 关联案件
+func (r *FamilySearcherRepositoryImpl) LinkCase(ctx context.Context, link *entity.FamilySearcherCase) error {
+	return r.db.WithContext(ctx).Omit("MissingPerson").Create(link).Error
+}
+
+// UnlinkCase 取消关联案件
+func (r *FamilySearcherRepositoryImpl) UnlinkCase(ctx context.Context, searcherID, personID string) (bool, error) {
+	res := r.db.WithContext(ctx).Unscoped().
+		Where("searcher_id = ? AND missing_person_id = ?", searcherID, personID).
+		Delete(&entity.FamilySearcherCase{})
+	return res.RowsAffected > 0, res.Error
+}
+
+// FindByCase 查找关联到案件的寻亲者
+func (r *FamilySearcherRepositoryImpl) FindByCase(ctx context.Context, personID string) ([]entity.FamilySearcher, error) {
+	var searchers []entity.FamilySearcher
+	err := r.db.WithContext(ctx).
+		Where("id IN (?)", r.db.Model(&entity.FamilySearcherCase{}).Select("searcher_id").Where("missing_person_id = ?", personID)).
+		Preload("Contacts", func(db *gorm.DB) *gorm.DB {
+			return db.Order("is_primary DESC, created_at ASC")
+		}).
+		Preload("Cases", "missing_person_id = ?", personID).
+		Order("created_at ASC").
+		Find(&searchers).Error
+	return searchers, err
+}
+
+// FindRelativesLinkedTo 查找指向该寻亲者的亲属关系
+func (r *FamilySearcherRepositoryImpl) FindRelativesLinkedTo(ctx context.Context, searcherID string) ([]entity.FamilySearcherRelative, error) {
+	var relatives []entity.FamilySearcherRelative
+	err := r.db.WithContext(ctx).
+		Where("linked_searcher_id = ?", searcherID).
+		Order("created_at ASC").
+		Find(&relatives).Error
+	return relatives, err
+}
+
+// FindMatchCandidates 查找可比对的寻亲中档案
+func (r *FamilySearcherRepositoryImpl) FindMatchCandidates(ctx context.Context, query *repository.FamilyMatchCandidateQuery) ([]entity.FamilySearcher, error) {
+	var searchers []entity.FamilySearcher
+
+	db := r.db.WithContext(ctx).
+		Where("kind = ? AND status = ?", query.Kind, entity.FamilySearcherStatusActive)
+	if query.ExcludeID != "" {
+		db = db.Where("id <> ?", query.ExcludeID)
+	}
+	// 未填写性别、地区的档案仍作为候选
+	if query.Gender != "" {
+		db = db.Where("lost_gender = ? OR lost_gender = '' OR lost_gender IS NULL", query.Gender)
+	}
+	if query.Province != "" {
+		db = db.Where("lost_province = ? OR lost_province = '' OR lost_province IS NULL OR (lost_name_pinyin <> '' AND lost_name_pinyin = ?)",
+			query.Province, query.NamePinyin)
+	}
+
+	err := db.Order("created_at DESC").Limit(candidateLimit(query.Limit)).Find(&searchers).Error
+	return searchers, err
+}
+
+// FindCaseCandidates 查找可比对的寻找中案件
+func (r *FamilySearcherRepositoryImpl) FindCaseCandidates(ctx context.Context, query *repository.FamilyMatchCandidateQuery) ([]entity.MissingPerson, error) {
+	var persons []entity.MissingPerson
+
+	db := r.db.WithContext(ctx).
+		Where("merged_into_id IS NULL").
+		Where("status IN ?", []entity.MissingStatus{entity.MissingStatusMissing, entity.MissingStatusSearching})
+	if query.Gender != "" {
+		db = db.Where("gender = ?", query.Gender)
+	}
+	if query.Province != "" {
+		db = db.Where("province = ? OR province = '' OR province IS NULL OR (name_pinyin <> '' AND name_pinyin = ?)",
+			query.Province, query.NamePinyin)
+	}
+
+	err := db.Order("missing_time DESC").Limit(candidateLimit(query.Limit)).Find(&persons).Error
+	return persons, err
+}
+
+func candidateLimit(limit int) int {
+	if limit <= 0 {
+		return familyCandidateLimit
+	}
+	return limit
+}
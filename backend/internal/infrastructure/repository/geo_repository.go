@@ -0,0 +1,356 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/repository"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/geo"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/logger"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// geoDefaultLimit 默认返回数量
+	geoDefaultLimit = 100
+	// geoMaxLimit 最大返回数量
+	geoMaxLimit = 1000
+	// geoScanLimit 回退模式下单次扫描的最大候选数量
+	geoScanLimit = 5000
+	// geoCoverCells geohash 前缀过滤的最大单元数
+	geoCoverCells = 16
+	// geoBackfillBatch 回填 geohash 的批大小
+	geoBackfillBatch = 500
+)
+
+// GeoRepositoryImpl 地理空间查询仓储实现
+type GeoRepositoryImpl struct {
+	db      *gorm.DB
+	postgis bool
+}
+
+// NewGeoRepository 创建地理空间查询仓储
+func NewGeoRepository(db *gorm.DB) repository.GeoRepository {
+	postgis := detectPostGIS(db)
+	logger.Info("Geo repository initialized", logger.String("mode", geoMode(postgis)))
+	return &GeoRepositoryImpl{db: db, postgis: postgis}
+}
+
+// detectPostGIS 检测 PostGIS 扩展是否可用
+func detectPostGIS(db *gorm.DB) bool {
+	if db == nil || db.Dialector.Name() != "postgres" {
+		return false
+	}
+	var count int64
+	if err := db.Raw("SELECT COUNT(*) FROM pg_extension WHERE extname = 'postgis'").Scan(&count).Error; err != nil {
+		return false
+	}
+	return count > 0
+}
+
+func geoMode(postgis bool) string {
+	if postgis {
+		return "postgis"
+	}
+	return "geohash"
+}
+
+// SupportsPostGIS 是否启用 PostGIS
+func (r *GeoRepositoryImpl) SupportsPostGIS() bool {
+	return r.postgis
+}
+
+// FindTracksWithinRadius 查找某点半径范围内的线索
+func (r *GeoRepositoryImpl) FindTracksWithinRadius(ctx context.Context, query *repository.GeoRadiusQuery) ([]repository.TrackDistance, error) {
+	table := entity.MissingPersonTrack{}.TableName()
+	db := r.db.WithContext(ctx).Model(&entity.MissingPersonTrack{})
+	db = r.trackFilter(db, table, query.MissingPersonID, query.Status, query.Since)
+	db = r.withinRadius(db, table, query.Lat, query.Lng, query.RadiusKm)
+
+	var tracks []entity.MissingPersonTrack
+	if err := db.Limit(r.scanLimit(query.Limit)).Preload("Reporter").Find(&tracks).Error; err != nil {
+		return nil, err
+	}
+
+	result := make([]repository.TrackDistance, 0, len(tracks))
+	for _, t := range tracks {
+		d := geo.Distance(query.Lat, query.Lng, t.Lat, t.Lng)
+		if d > query.RadiusKm {
+			continue
+		}
+		result = append(result, repository.TrackDistance{Track: t, DistanceKm: d})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].DistanceKm < result[j].DistanceKm
+	})
+
+	return truncate(result, normalizeGeoLimit(query.Limit)), nil
+}
+
+// FindTracksInBounds 查找包围盒内的线索
+func (r *GeoRepositoryImpl) FindTracksInBounds(ctx context.Context, query *repository.GeoBoundsQuery) ([]entity.MissingPersonTrack, error) {
+	table := entity.MissingPersonTrack{}.TableName()
+	db := r.db.WithContext(ctx).Model(&entity.MissingPersonTrack{})
+	db = r.trackFilter(db, table, query.MissingPersonID, query.Status, query.Since)
+	db = r.withinBounds(db, table, query.Bounds)
+
+	var tracks []entity.MissingPersonTrack
+	err := db.Order("time DESC").Limit(normalizeGeoLimit(query.Limit)).Find(&tracks).Error
+	return tracks, err
+}
+
+// FindCasesInBounds 查找包围盒内的案件
+func (r *GeoRepositoryImpl) FindCasesInBounds(ctx context.Context, query *repository.GeoBoundsQuery) ([]entity.MissingPerson, error) {
+	table := entity.MissingPerson{}.TableName()
+	db := r.db.WithContext(ctx).Model(&entity.MissingPerson{}).Where("merged_into_id IS NULL")
+	if query.ActiveOnly {
+		db = db.Where("status IN ?", activeMissingStatuses())
+	} else if query.Status != "" {
+		db = db.Where("status = ?", query.Status)
+	}
+	if query.Since != nil {
+		db = db.Where("missing_time >= ?", *query.Since)
+	}
+	db = r.withinBounds(db, table, query.Bounds)
+
+	var persons []entity.MissingPerson
+	err := db.Order("missing_time DESC").Limit(normalizeGeoLimit(query.Limit)).Find(&persons).Error
+	return persons, err
+}
+
+// FindNearestActiveCases 查找距离某点最近的活跃案件
+func (r *GeoRepositoryImpl) FindNearestActiveCases(ctx context.Context, query *repository.GeoRadiusQuery) ([]repository.CaseDistance, error) {
+	nearest := make(map[string]*repository.CaseDistance)
+
+	// 1. 按走失地点
+	caseTable := entity.MissingPerson{}.TableName()
+	caseDB := r.db.WithContext(ctx).Model(&entity.MissingPerson{}).
+		Where("merged_into_id IS NULL AND status IN ?", activeMissingStatuses())
+	caseDB = r.withinRadius(caseDB, caseTable, query.Lat, query.Lng, query.RadiusKm)
+
+	var persons []entity.MissingPerson
+	if err := caseDB.Limit(geoScanLimit).Find(&persons).Error; err != nil {
+		return nil, err
+	}
+	for _, p := range persons {
+		d := geo.Distance(query.Lat, query.Lng, p.Lat, p.Lng)
+		if d > query.RadiusKm {
+			continue
+		}
+		nearest[p.ID] = &repository.CaseDistance{Person: p, DistanceKm: d}
+	}
+
+	// 2. 按线索位置（仅统计活跃案件的非驳回线索）
+	trackTable := entity.MissingPersonTrack{}.TableName()
+	trackDB := r.db.WithContext(ctx).Model(&entity.MissingPersonTrack{}).
+		Joins("JOIN "+caseTable+" ON "+caseTable+".id = "+trackTable+".missing_person_id").
+		Where(caseTable+".deleted_at IS NULL AND "+caseTable+".merged_into_id IS NULL").
+		Where(caseTable+".status IN ?", activeMissingStatuses()).
+		Where(trackTable + ".status <> 'rejected'")
+	trackDB = r.withinRadius(trackDB, trackTable, query.Lat, query.Lng, query.RadiusKm)
+
+	var tracks []entity.MissingPersonTrack
+	if err := trackDB.Select(trackTable + ".*").Limit(geoScanLimit).Find(&tracks).Error; err != nil {
+		return nil, err
+	}
+
+	var missingIDs []string
+	trackDistances := make(map[string]repository.CaseDistance)
+	for _, t := range tracks {
+		d := geo.Distance(query.Lat, query.Lng, t.Lat, t.Lng)
+		if d > query.RadiusKm {
+			continue
+		}
+		if cd, ok := nearest[t.MissingPersonID]; ok {
+			if d < cd.DistanceKm {
+				cd.DistanceKm = d
+				cd.NearestTrackID = t.ID
+			}
+			continue
+		}
+		if cd, ok := trackDistances[t.MissingPersonID]; !ok || d < cd.DistanceKm {
+			if !ok {
+				missingIDs = append(missingIDs, t.MissingPersonID)
+			}
+			trackDistances[t.MissingPersonID] = repository.CaseDistance{DistanceKm: d, NearestTrackID: t.ID}
+		}
+	}
+
+	// 3. 加载仅通过线索命中的案件
+	if len(missingIDs) > 0 {
+		var extra []entity.MissingPerson
+		if err := r.db.WithContext(ctx).Where("id IN ?", missingIDs).Find(&extra).Error; err != nil {
+			return nil, err
+		}
+		for _, p := range extra {
+			cd := trackDistances[p.ID]
+			cd.Person = p
+			nearest[p.ID] = &cd
+		}
+	}
+
+	result := make([]repository.CaseDistance, 0, len(nearest))
+	for _, cd := range nearest {
+		result = append(result, *cd)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].DistanceKm < result[j].DistanceKm
+	})
+
+	return truncate(result, normalizeGeoLimit(query.Limit)), nil
+}
+
+// FindNearestOrg 查找距离某点最近的活跃组织
+// 组织数量有限，直接加载已设置坐标的组织并在内存中计算距离
+func (r *GeoRepositoryImpl) FindNearestOrg(ctx context.Context, lat, lng float64) (*repository.OrgDistance, error) {
+	var orgs []entity.Organization
+	err := r.db.WithContext(ctx).
+		Where("status = ?", entity.OrgStatusActive).
+		Where("lat <> 0 OR lng <> 0").
+		Find(&orgs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var nearest *repository.OrgDistance
+	for _, org := range orgs {
+		if !geo.ValidCoordinate(org.Lat, org.Lng) {
+			continue
+		}
+		d := geo.Distance(lat, lng, org.Lat, org.Lng)
+		if nearest == nil || d < nearest.DistanceKm {
+			nearest = &repository.OrgDistance{Org: org, DistanceKm: d}
+		}
+	}
+	return nearest, nil
+}
+
+// BackfillGeohash 为历史数据补齐 geohash 列
+func (r *GeoRepositoryImpl) BackfillGeohash(ctx context.Context) (int64, error) {
+	var total int64
+	for _, table := range []string{entity.MissingPerson{}.TableName(), entity.MissingPersonTrack{}.TableName()} {
+		n, err := r.backfillTable(ctx, table)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	return total, nil
+}
+
+func (r *GeoRepositoryImpl) backfillTable(ctx context.Context, table string) (int64, error) {
+	type row struct {
+		ID  string
+		Lat float64
+		Lng float64
+	}
+
+	var total int64
+	for {
+		var rows []row
+		err := r.db.WithContext(ctx).Table(table).
+			Select("id, lat, lng").
+			Where("(geohash IS NULL OR geohash = '')").
+			Where("NOT (lat = 0 AND lng = 0)").
+			Where("lat BETWEEN -90 AND 90 AND lng BETWEEN -180 AND 180").
+			Limit(geoBackfillBatch).
+			Find(&rows).Error
+		if err != nil {
+			return total, err
+		}
+		if len(rows) == 0 {
+			return total, nil
+		}
+
+		for _, item := range rows {
+			hash := geo.Encode(item.Lat, item.Lng, geo.DefaultGeohashPrecision)
+			if err := r.db.WithContext(ctx).Table(table).
+				Where("id = ?", item.ID).
+				UpdateColumn("geohash", hash).Error; err != nil {
+				return total, err
+			}
+			total++
+		}
+	}
+}
+
+// trackFilter 线索通用过滤条件
+func (r *GeoRepositoryImpl) trackFilter(db *gorm.DB, table, missingPersonID, status string, since *time.Time) *gorm.DB {
+	if missingPersonID != "" {
+		db = db.Where(table+".missing_person_id = ?", missingPersonID)
+	}
+	if status != "" {
+		db = db.Where(table+".status = ?", status)
+	}
+	if since != nil {
+		db = db.Where(table+".time >= ?", *since)
+	}
+	return db
+}
+
+// withinRadius 半径过滤：PostGIS 使用 ST_DWithin，否则使用 geohash 前缀 + 经纬度范围粗筛（精确距离由调用方计算）
+func (r *GeoRepositoryImpl) withinRadius(db *gorm.DB, table string, lat, lng, radiusKm float64) *gorm.DB {
+	if r.postgis {
+		point := "ST_SetSRID(ST_MakePoint(" + table + ".lng, " + table + ".lat), 4326)::geography"
+		target := "ST_SetSRID(ST_MakePoint(?, ?), 4326)::geography"
+		return db.
+			Where("ST_DWithin("+point+", "+target+", ?)", lng, lat, radiusKm*1000).
+			Clauses(clause.OrderBy{Expression: clause.Expr{
+				SQL:  "ST_Distance(" + point + ", " + target + ")",
+				Vars: []interface{}{lng, lat},
+			}})
+	}
+	return r.withinBounds(db, table, geo.BBoxAround(lat, lng, radiusKm))
+}
+
+// withinBounds 包围盒过滤：geohash 前缀命中索引，经纬度范围保证精确
+func (r *GeoRepositoryImpl) withinBounds(db *gorm.DB, table string, bounds geo.BBox) *gorm.DB {
+	db = db.Where(table+".lat BETWEEN ? AND ? AND "+table+".lng BETWEEN ? AND ?",
+		bounds.MinLat, bounds.MaxLat, bounds.MinLng, bounds.MaxLng)
+
+	cells := geo.CoverBBox(bounds, geoCoverCells)
+	if len(cells) == 0 || len(cells[0]) < 2 {
+		// 范围过大时前缀过滤没有意义
+		return db
+	}
+
+	conds := make([]string, len(cells))
+	args := make([]interface{}, len(cells))
+	for i, cell := range cells {
+		conds[i] = table + ".geohash LIKE ?"
+		args[i] = cell + "%"
+	}
+	return db.Where("("+strings.Join(conds, " OR ")+")", args...)
+}
+
+func (r *GeoRepositoryImpl) scanLimit(limit int) int {
+	if r.postgis {
+		return normalizeGeoLimit(limit)
+	}
+	return geoScanLimit
+}
+
+func normalizeGeoLimit(limit int) int {
+	if limit <= 0 {
+		return geoDefaultLimit
+	}
+	if limit > geoMaxLimit {
+		return geoMaxLimit
+	}
+	return limit
+}
+
+func truncate[T any](list []T, limit int) []T {
+	if len(list) > limit {
+		return list[:limit]
+	}
+	return list
+}
+
+func activeMissingStatuses() []entity.MissingStatus {
+	return []entity.MissingStatus{entity.MissingStatusMissing, entity.MissingStatusSearching}
+}
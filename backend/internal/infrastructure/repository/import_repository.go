@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// ImportRepositoryImpl 批量导入仓储实现
+type ImportRepositoryImpl struct {
+	*BaseRepository[entity.ImportJob]
+}
+
+// NewImportRepository 创建批量导入仓储
+func NewImportRepository(db *gorm.DB) repository.ImportRepository {
+	return &ImportRepositoryImpl{
+		BaseRepository: NewBaseRepository[entity.ImportJob](db),
+	}
+}
+
+// List 导入任务列表，不加载逐行结果
+func (r *ImportRepositoryImpl) List(ctx context.Context, orgID string, pagination repository.Pagination) (*repository.PageResult[entity.ImportJob], error) {
+	var jobs []entity.ImportJob
+	var total int64
+
+	db := r.db.WithContext(ctx).Model(&entity.ImportJob{})
+	if orgID != "" {
+		db = db.Where("org_id = ?", orgID)
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	if err := r.Paginate(db.Omit("results").Order("created_at DESC"), pagination).Find(&jobs).Error; err != nil {
+		return nil, err
+	}
+
+	return repository.NewPageResult(jobs, total, pagination.Page, pagination.PageSize), nil
+}
+
+// UpdateProgress 更新执行进度
+func (r *ImportRepositoryImpl) UpdateProgress(ctx context.Context, job *entity.ImportJob) error {
+	return r.db.WithContext(ctx).Model(&entity.ImportJob{}).Where("id = ?", job.ID).Updates(map[string]interface{}{
+		"status":         job.Status,
+		"total_rows":     job.TotalRows,
+		"processed_rows": job.ProcessedRows,
+		"created_rows":   job.CreatedRows,
+		"valid_rows":     job.ValidRows,
+		"failed_rows":    job.FailedRows,
+		"duplicate_rows": job.DuplicateRows,
+		"started_at":     job.StartedAt,
+	}).Error
+}
+
+// FindRunning 查找未结束的任务
+func (r *ImportRepositoryImpl) FindRunning(ctx context.Context) ([]entity.ImportJob, error) {
+	var jobs []entity.ImportJob
+	err := r.db.WithContext(ctx).Omit("results").
+		Where("status IN ?", []entity.ImportStatus{entity.ImportStatusPending, entity.ImportStatusRunning}).
+		Find(&jobs).Error
+	return jobs, err
+}
+
+// CreateTemplate 创建列映射模板
+func (r *ImportRepositoryImpl) CreateTemplate(ctx context.Context, template *entity.ImportTemplate) error {
+	return r.db.WithContext(ctx).Create(template).Error
+}
+
+// FindTemplateByID 查找列映射模板
+func (r *ImportRepositoryImpl) FindTemplateByID(ctx context.Context, id string) (*entity.ImportTemplate, error) {
+	var template entity.ImportTemplate
+	if err := r.db.WithContext(ctx).First(&template, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("记录不存在")
+		}
+		return nil, err
+	}
+	return &template, nil
+}
+
+// ListTemplates 组织的列映射模板
+func (r *ImportRepositoryImpl) ListTemplates(ctx context.Context, orgID string) ([]entity.ImportTemplate, error) {
+	var templates []entity.ImportTemplate
+	err := r.db.WithContext(ctx).Where("org_id = ?", orgID).Order("name ASC").Find(&templates).Error
+	return templates, err
+}
+
+// DeleteTemplate 删除列映射模板
+func (r *ImportRepositoryImpl) DeleteTemplate(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).Delete(&entity.ImportTemplate{}, "id = ?", id).Error
+}
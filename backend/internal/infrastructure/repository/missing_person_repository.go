@@ -30,8 +30,16 @@ func (r *MissingPersonRepositoryImpl) List(ctx context.Context, query *repositor
 
 	db := r.db.WithContext(ctx).Model(&entity.MissingPerson{})
 
+	// 公开查询
+	if query.PublicOnly {
+		db = db.Where("merged_into_id IS NULL AND status IN ?",
+			[]entity.MissingStatus{entity.MissingStatusMissing, entity.MissingStatusSearching})
+	}
+
 	// 关键词搜索
-	if query.Keyword != "" {
+	if query.Keyword != "" && query.PublicOnly {
+		db = db.Where("name LIKE ? OR case_no = ?", "%"+query.Keyword+"%", query.Keyword)
+	} else if query.Keyword != "" {
 		db = db.Where("name LIKE ? OR contact_name LIKE ? OR contact_phone LIKE ?",
 			"%"+query.Keyword+"%", "%"+query.Keyword+"%", "%"+query.Keyword+"%")
 	}
@@ -183,6 +191,248 @@ func (r *MissingPersonRepositoryImpl) GetTracks(ctx context.Context, personID st
 	return tracks, err
 }
 
+// FindTrackByID 根据ID查找轨迹
+func (r *MissingPersonRepositoryImpl) FindTrackByID(ctx context.Context, id string) (*entity.MissingPersonTrack, error) {
+	var track entity.MissingPersonTrack
+	err := r.db.WithContext(ctx).First(&track, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("track not found")
+		}
+		return nil, err
+	}
+	return &track, nil
+}
+
+// UpdateTrackStatus 更新线索核实状态并记录日志
+func (r *MissingPersonRepositoryImpl) UpdateTrackStatus(ctx context.Context, track *entity.MissingPersonTrack, log *entity.MissingPersonTrackLog) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&entity.MissingPersonTrack{}).
+			Where("id = ?", track.ID).
+			Updates(map[string]interface{}{
+				"status":        track.Status,
+				"verifier_id":   track.VerifierID,
+				"verified_at":   track.VerifiedAt,
+				"status_reason": track.StatusReason,
+				"is_key_point":  track.IsKeyPoint,
+			}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(log).Error
+	})
+}
+
+// TransitionStatus 保存状态机转换结果并记录状态变更
+func (r *MissingPersonRepositoryImpl) TransitionStatus(ctx context.Context, mp *entity.MissingPerson, log *entity.MissingPersonStatusLog) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return transitionStatus(tx, mp, log)
+	})
+}
+
+// transitionStatus 在事务中保存状态转换及变更记录
+func transitionStatus(tx *gorm.DB, mp *entity.MissingPerson, log *entity.MissingPersonStatusLog) error {
+	// 以转换前状态为条件，防止并发操作基于过期状态覆盖
+	res := tx.Model(&entity.MissingPerson{}).
+		Where("id = ? AND status = ?", mp.ID, log.FromStatus).
+		Updates(map[string]interface{}{
+			"status":         mp.Status,
+			"status_reason":  mp.StatusReason,
+			"found_time":     mp.FoundTime,
+			"found_location": mp.FoundLocation,
+			"found_note":     mp.FoundNote,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("%w: 案件状态已被修改", entity.ErrCaseTransitionNotAllowed)
+	}
+	return tx.Create(log).Error
+}
+
+// GetStatusLogs 获取案件状态变更记录
+func (r *MissingPersonRepositoryImpl) GetStatusLogs(ctx context.Context, personID string) ([]entity.MissingPersonStatusLog, error) {
+	var logs []entity.MissingPersonStatusLog
+	err := r.db.WithContext(ctx).
+		Where("missing_person_id = ?", personID).
+		Order("created_at ASC").
+		Preload("User").
+		Find(&logs).Error
+	return logs, err
+}
+
+// GetTrackLogs 获取线索核实日志
+func (r *MissingPersonRepositoryImpl) GetTrackLogs(ctx context.Context, trackID string) ([]entity.MissingPersonTrackLog, error) {
+	var logs []entity.MissingPersonTrackLog
+	err := r.db.WithContext(ctx).
+		Where("track_id = ?", trackID).
+		Order("created_at ASC").
+		Preload("User").
+		Find(&logs).Error
+	return logs, err
+}
+
+// UpdateUrgency 更新紧急程度
+func (r *MissingPersonRepositoryImpl) UpdateUrgency(ctx context.Context, id string, urgency entity.UrgencyLevel) error {
+	return r.db.WithContext(ctx).
+		Model(&entity.MissingPerson{}).
+		Where("id = ?", id).
+		Update("urgency", urgency).
+		Error
+}
+
+// UpdateUrgencyScore 保存紧急程度评分结果
+func (r *MissingPersonRepositoryImpl) UpdateUrgencyScore(ctx context.Context, mp *entity.MissingPerson) error {
+	return r.db.WithContext(ctx).
+		Model(&entity.MissingPerson{}).
+		Where("id = ?", mp.ID).
+		UpdateColumns(map[string]interface{}{
+			"urgency":              mp.Urgency,
+			"urgency_score":        mp.UrgencyScore,
+			"urgency_manual":       mp.UrgencyManual,
+			"urgency_scored_at":    mp.UrgencyScoredAt,
+			"urgency_escalated_at": mp.UrgencyEscalatedAt,
+		}).Error
+}
+
+// FindActiveForScoring 按ID顺序分批读取进行中且未合并的案件
+func (r *MissingPersonRepositoryImpl) FindActiveForScoring(ctx context.Context, afterID string, limit int) ([]entity.MissingPerson, error) {
+	var persons []entity.MissingPerson
+	db := r.db.WithContext(ctx).
+		Where("status IN ?", []entity.MissingStatus{entity.MissingStatusMissing, entity.MissingStatusSearching}).
+		Where("merged_into_id IS NULL")
+	if afterID != "" {
+		db = db.Where("id > ?", afterID)
+	}
+	err := db.Order("id ASC").Limit(limit).Find(&persons).Error
+	return persons, err
+}
+
+// CountRecentTracks 统计各案件指定时间后未驳回的线索数
+func (r *MissingPersonRepositoryImpl) CountRecentTracks(ctx context.Context, personIDs []string, since time.Time) (map[string]int, error) {
+	counts := make(map[string]int, len(personIDs))
+	if len(personIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		MissingPersonID string
+		Count           int
+	}
+	err := r.db.WithContext(ctx).
+		Model(&entity.MissingPersonTrack{}).
+		Select("missing_person_id, COUNT(*) AS count").
+		Where("missing_person_id IN ?", personIDs).
+		Where("time >= ? AND status <> ?", since, entity.TrackStatusRejected).
+		Group("missing_person_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		counts[row.MissingPersonID] = row.Count
+	}
+	return counts, nil
+}
+
+// AddPhoto 添加照片
+func (r *MissingPersonRepositoryImpl) AddPhoto(ctx context.Context, photo *entity.MissingPhoto) error {
+	return r.db.WithContext(ctx).Create(photo).Error
+}
+
+// GetPhotos 获取案件照片
+func (r *MissingPersonRepositoryImpl) GetPhotos(ctx context.Context, personID string) ([]entity.MissingPhoto, error) {
+	var photos []entity.MissingPhoto
+	err := r.db.WithContext(ctx).
+		Where("missing_person_id = ?", personID).
+		Order("is_primary DESC, created_at ASC").
+		Find(&photos).Error
+	return photos, err
+}
+
+// FindPhotoByID 根据ID查找照片
+func (r *MissingPersonRepositoryImpl) FindPhotoByID(ctx context.Context, id string) (*entity.MissingPhoto, error) {
+	var photo entity.MissingPhoto
+	err := r.db.WithContext(ctx).First(&photo, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("photo not found")
+		}
+		return nil, err
+	}
+	return &photo, nil
+}
+
+// SetPrimaryPhoto 设为主照片并同步案件的 photo_url
+func (r *MissingPersonRepositoryImpl) SetPrimaryPhoto(ctx context.Context, photo *entity.MissingPhoto) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&entity.MissingPhoto{}).
+			Where("missing_person_id = ? AND id <> ?", photo.MissingPersonID, photo.ID).
+			Update("is_primary", false).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&entity.MissingPhoto{}).
+			Where("id = ?", photo.ID).
+			Update("is_primary", true).Error; err != nil {
+			return err
+		}
+		photo.IsPrimary = true
+		return tx.Model(&entity.MissingPerson{}).
+			Where("id = ?", photo.MissingPersonID).
+			Update("photo_url", photo.URL).Error
+	})
+}
+
+// DeletePhoto 删除照片及其相似记录
+func (r *MissingPersonRepositoryImpl) DeletePhoto(ctx context.Context, photo *entity.MissingPhoto) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("photo_id = ? OR matched_photo_id = ?", photo.ID, photo.ID).
+			Delete(&entity.MissingPhotoMatch{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&entity.MissingPhoto{}, "id = ?", photo.ID).Error; err != nil {
+			return err
+		}
+		if !photo.IsPrimary {
+			return nil
+		}
+		return tx.Model(&entity.MissingPerson{}).
+			Where("id = ? AND photo_url = ?", photo.MissingPersonID, photo.URL).
+			Update("photo_url", "").Error
+	})
+}
+
+// FindPhotoHashes 获取其它案件已计算感知哈希的照片
+func (r *MissingPersonRepositoryImpl) FindPhotoHashes(ctx context.Context, excludePersonID string) ([]entity.MissingPhoto, error) {
+	var photos []entity.MissingPhoto
+	err := r.db.WithContext(ctx).
+		Select("id", "missing_person_id", "phash").
+		Where("phash IS NOT NULL AND phash <> '' AND missing_person_id <> ?", excludePersonID).
+		Find(&photos).Error
+	return photos, err
+}
+
+// AddPhotoMatches 保存相似照片记录
+func (r *MissingPersonRepositoryImpl) AddPhotoMatches(ctx context.Context, matches []entity.MissingPhotoMatch) error {
+	if len(matches) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Create(&matches).Error
+}
+
+// GetPhotoMatches 获取与案件相关的相似照片记录
+func (r *MissingPersonRepositoryImpl) GetPhotoMatches(ctx context.Context, personID string) ([]entity.MissingPhotoMatch, error) {
+	var matches []entity.MissingPhotoMatch
+	err := r.db.WithContext(ctx).
+		Where("missing_person_id = ? OR matched_person_id = ?", personID, personID).
+		Order("distance ASC, created_at DESC").
+		Preload("Photo").
+		Preload("MatchedPhoto").
+		Find(&matches).Error
+	return matches, err
+}
+
 // GetStats 获取统计
 func (r *MissingPersonRepositoryImpl) GetStats(ctx context.Context) (*entity.MissingPersonStats, error) {
 	stats := &entity.MissingPersonStats{}
@@ -262,6 +512,37 @@ func (r *MissingPersonRepositoryImpl) Search(ctx context.Context, keyword string
 	return repository.NewPageResult(persons, total, pagination.Page, pagination.PageSize), nil
 }
 
+// FindByIDs 根据ID批量查找
+func (r *MissingPersonRepositoryImpl) FindByIDs(ctx context.Context, ids []string) ([]entity.MissingPerson, error) {
+	var persons []entity.MissingPerson
+	if len(ids) == 0 {
+		return persons, nil
+	}
+	err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&persons).Error
+	return persons, err
+}
+
+// FindForIndex 按ID顺序分批读取未合并的案件
+func (r *MissingPersonRepositoryImpl) FindForIndex(ctx context.Context, afterID string, limit int) ([]entity.MissingPerson, error) {
+	var persons []entity.MissingPerson
+	db := r.db.WithContext(ctx).Where("merged_into_id IS NULL")
+	if afterID != "" {
+		db = db.Where("id > ?", afterID)
+	}
+	err := db.Order("id ASC").Limit(limit).Find(&persons).Error
+	return persons, err
+}
+
+// FindChangedSince 查找指定时间后修改或删除的案件
+func (r *MissingPersonRepositoryImpl) FindChangedSince(ctx context.Context, since time.Time) ([]entity.MissingPerson, error) {
+	var persons []entity.MissingPerson
+	err := r.db.WithContext(ctx).Unscoped().
+		Where("updated_at > ? OR deleted_at > ?", since, since).
+		Order("updated_at ASC").
+		Find(&persons).Error
+	return persons, err
+}
+
 // CountByStatus 按状态统计
 func (r *MissingPersonRepositoryImpl) CountByStatus(ctx context.Context, status entity.MissingStatus) (int64, error) {
 	var count int64
@@ -283,6 +564,161 @@ func (r *MissingPersonRepositoryImpl) IncrementViews(ctx context.Context, id str
 	return r.db.WithContext(ctx).Model(&entity.MissingPerson{}).Where("id = ?", id).UpdateColumn("views", gorm.Expr("views + 1")).Error
 }
 
+// IncrementShareCount 增加分享次数
+func (r *MissingPersonRepositoryImpl) IncrementShareCount(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).Model(&entity.MissingPerson{}).Where("id = ?", id).UpdateColumn("share_count", gorm.Expr("share_count + 1")).Error
+}
+
+// FindDuplicateCandidates 查找可能重复的候选案件
+func (r *MissingPersonRepositoryImpl) FindDuplicateCandidates(ctx context.Context, mp *entity.MissingPerson, limit int) ([]entity.MissingPerson, error) {
+	var persons []entity.MissingPerson
+
+	db := r.db.WithContext(ctx).Where("merged_into_id IS NULL")
+	if mp.ID != "" {
+		db = db.Where("id <> ?", mp.ID)
+	}
+
+	// 同音姓名或同省份，且走失时间在一年以内
+	switch {
+	case mp.NamePinyin != "" && mp.Province != "":
+		db = db.Where("name_pinyin = ? OR name = ? OR province = ?", mp.NamePinyin, mp.Name, mp.Province)
+	case mp.NamePinyin != "":
+		db = db.Where("name_pinyin = ? OR name = ?", mp.NamePinyin, mp.Name)
+	default:
+		db = db.Where("name = ?", mp.Name)
+	}
+	if !mp.MissingTime.IsZero() {
+		db = db.Where("missing_time BETWEEN ? AND ?", mp.MissingTime.AddDate(-1, 0, 0), mp.MissingTime.AddDate(1, 0, 0))
+	}
+
+	if limit <= 0 {
+		limit = 200
+	}
+
+	err := db.Order("missing_time DESC").Limit(limit).Find(&persons).Error
+	return persons, err
+}
+
+// Merge 合并重复案件
+func (r *MissingPersonRepositoryImpl) Merge(ctx context.Context, survivor, merged *entity.MissingPerson, record *entity.MissingPersonMerge, statusLog *entity.MissingPersonStatusLog) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 迁移轨迹
+		res := tx.Model(&entity.MissingPersonTrack{}).
+			Where("missing_person_id = ?", merged.ID).
+			Update("missing_person_id", survivor.ID)
+		if res.Error != nil {
+			return res.Error
+		}
+		record.TrackCount = res.RowsAffected
+
+		// 迁移照片（保留案件已有主图时，迁入的照片不再作为主图）
+		res = tx.Model(&entity.MissingPhoto{}).
+			Where("missing_person_id = ?", merged.ID).
+			Updates(map[string]interface{}{
+				"missing_person_id": survivor.ID,
+				"is_primary":        false,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		record.PhotoCount = res.RowsAffected
+
+		// 迁移关联任务
+		res = tx.Model(&entity.Task{}).
+			Where("missing_person_id = ?", merged.ID).
+			Update("missing_person_id", survivor.ID)
+		if res.Error != nil {
+			return res.Error
+		}
+		record.TaskCount = res.RowsAffected
+
+		// 迁移文件绑定
+		res = tx.Model(&entity.File{}).
+			Where("entity_type = ? AND entity_id = ?", string(entity.ResourceMissingPerson), merged.ID).
+			Update("entity_id", survivor.ID)
+		if res.Error != nil {
+			return res.Error
+		}
+		record.FileCount = res.RowsAffected
+
+		if err := mergeCaseLinks(tx, survivor.ID, merged.ID); err != nil {
+			return err
+		}
+
+		// 关闭被合并案件
+		if err := tx.Model(&entity.MissingPerson{}).
+			Where("id = ?", merged.ID).
+			Updates(map[string]interface{}{
+				"merged_into_id": survivor.ID,
+				"status":         merged.Status,
+				"found_note":     merged.FoundNote,
+			}).Error; err != nil {
+			return err
+		}
+		if statusLog != nil {
+			if err := tx.Create(statusLog).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Create(record).Error
+	})
+}
+
+// mergeCaseLinks 迁移其它模块中关联到被合并案件的记录：DNA 样本、寻亲者关联、团聚记录及回访、方言辨识会话
+func mergeCaseLinks(tx *gorm.DB, survivorID, mergedID string) error {
+	if err := tx.Model(&entity.DNASample{}).
+		Where("missing_person_id = ?", mergedID).
+		Update("missing_person_id", survivorID).Error; err != nil {
+		return err
+	}
+
+	// 同一寻亲者已关联保留案件时，去掉被合并案件上的重复关联
+	if err := tx.Where("missing_person_id = ? AND searcher_id IN (?)", mergedID,
+		tx.Model(&entity.FamilySearcherCase{}).Select("searcher_id").Where("missing_person_id = ?", survivorID)).
+		Delete(&entity.FamilySearcherCase{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Model(&entity.FamilySearcherCase{}).
+		Where("missing_person_id = ?", mergedID).
+		Update("missing_person_id", survivorID).Error; err != nil {
+		return err
+	}
+
+	// 每个案件只有一条团聚记录，保留案件已有记录时被合并案件的记录不迁移
+	var reunions int64
+	if err := tx.Model(&entity.ReunionRecord{}).Where("missing_person_id = ?", survivorID).Count(&reunions).Error; err != nil {
+		return err
+	}
+	if reunions == 0 {
+		if err := tx.Model(&entity.ReunionRecord{}).
+			Where("missing_person_id = ?", mergedID).
+			Update("missing_person_id", survivorID).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&entity.ReunionFollowUp{}).
+			Where("missing_person_id = ?", mergedID).
+			Update("missing_person_id", survivorID).Error; err != nil {
+			return err
+		}
+	}
+
+	return tx.Model(&entity.DialectSession{}).
+		Where("missing_person_id = ?", mergedID).
+		Update("missing_person_id", survivorID).Error
+}
+
+// GetMergeHistory 获取案件的合并记录
+func (r *MissingPersonRepositoryImpl) GetMergeHistory(ctx context.Context, personID string) ([]entity.MissingPersonMerge, error) {
+	var records []entity.MissingPersonMerge
+	err := r.db.WithContext(ctx).
+		Where("survivor_id = ? OR merged_id = ?", personID, personID).
+		Order("created_at DESC").
+		Preload("Operator").
+		Find(&records).Error
+	return records, err
+}
+
 // FindByID 根据ID查找
 func (r *MissingPersonRepositoryImpl) FindByID(ctx context.Context, id string) (*entity.MissingPerson, error) {
 	var person entity.MissingPerson
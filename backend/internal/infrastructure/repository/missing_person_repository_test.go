@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// openTestDB 内存 SQLite 数据库，只建立测试用到的表
+func openTestDB(t *testing.T, models ...interface{}) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{
+		Logger:                                   logger.Default.LogMode(logger.Silent),
+		DisableForeignKeyConstraintWhenMigrating: true,
+	})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	// 内存数据库按连接隔离，只保留一个连接
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	require.NoError(t, db.AutoMigrate(models...))
+	return db
+}
+
+// caseIDOf 读取记录当前关联的案件
+func caseIDOf(t *testing.T, db *gorm.DB, model interface{}, id string) string {
+	var caseID string
+	require.NoError(t, db.Model(model).Where("id = ?", id).Pluck("missing_person_id", &caseID).Error)
+	return caseID
+}
+
+func TestMissingPersonRepository_MergeMovesCaseLinks(t *testing.T) {
+	db := openTestDB(t,
+		&entity.MissingPerson{}, &entity.MissingPersonTrack{}, &entity.MissingPhoto{}, &entity.Task{}, &entity.File{},
+		&entity.MissingPersonStatusLog{}, &entity.MissingPersonMerge{},
+		&entity.DNASample{}, &entity.FamilySearcherCase{}, &entity.ReunionRecord{}, &entity.ReunionFollowUp{}, &entity.DialectSession{},
+	)
+	ctx := context.Background()
+
+	survivor := &entity.MissingPerson{BaseEntity: entity.BaseEntity{ID: "survivor"}, CaseNo: "MP001", Name: "张三", Status: entity.MissingStatusSearching}
+	merged := &entity.MissingPerson{BaseEntity: entity.BaseEntity{ID: "merged"}, CaseNo: "MP002", Name: "张三", Status: entity.MissingStatusMissing}
+	require.NoError(t, db.Omit("Reporter", "Assignee", "Org").Create([]*entity.MissingPerson{survivor, merged}).Error)
+
+	mergedID := merged.ID
+	require.NoError(t, db.Create(&entity.DNASample{BaseEntity: entity.BaseEntity{ID: "sample"}, MissingPersonID: mergedID, SampleNo: "S1"}).Error)
+	require.NoError(t, db.Create([]*entity.FamilySearcherCase{
+		{BaseEntity: entity.BaseEntity{ID: "link-shared-survivor"}, SearcherID: "searcher-a", MissingPersonID: survivor.ID},
+		{BaseEntity: entity.BaseEntity{ID: "link-shared-merged"}, SearcherID: "searcher-a", MissingPersonID: mergedID},
+		{BaseEntity: entity.BaseEntity{ID: "link-merged"}, SearcherID: "searcher-b", MissingPersonID: mergedID},
+	}).Error)
+	require.NoError(t, db.Create(&entity.ReunionRecord{BaseEntity: entity.BaseEntity{ID: "reunion"}, MissingPersonID: mergedID}).Error)
+	require.NoError(t, db.Create(&entity.ReunionFollowUp{BaseEntity: entity.BaseEntity{ID: "follow-up"}, ReunionID: "reunion", MissingPersonID: mergedID, Month: 1}).Error)
+	require.NoError(t, db.Create(&entity.DialectSession{BaseEntity: entity.BaseEntity{ID: "session"}, MissingPersonID: &mergedID}).Error)
+
+	from := merged.Status
+	require.NoError(t, merged.MergeInto(survivor))
+	record := entity.NewMissingPersonMerge(survivor, merged, "operator", "org", "重复登记")
+	repo := NewMissingPersonRepository(db)
+	require.NoError(t, repo.Merge(ctx, survivor, merged, record, entity.NewMergeStatusLog(merged, from, "operator", "重复登记")))
+
+	assert.Equal(t, survivor.ID, caseIDOf(t, db, &entity.DNASample{}, "sample"))
+	assert.Equal(t, survivor.ID, caseIDOf(t, db, &entity.FamilySearcherCase{}, "link-merged"))
+	assert.Equal(t, survivor.ID, caseIDOf(t, db, &entity.ReunionRecord{}, "reunion"))
+	assert.Equal(t, survivor.ID, caseIDOf(t, db, &entity.ReunionFollowUp{}, "follow-up"))
+	assert.Equal(t, survivor.ID, caseIDOf(t, db, &entity.DialectSession{}, "session"))
+
+	// 同一寻亲者的重复关联去重
+	var links int64
+	require.NoError(t, db.Model(&entity.FamilySearcherCase{}).Where("searcher_id = ?", "searcher-a").Count(&links).Error)
+	assert.Equal(t, int64(1), links)
+	assert.Empty(t, caseIDOf(t, db, &entity.FamilySearcherCase{}, "link-shared-merged"))
+
+	var closed entity.MissingPerson
+	require.NoError(t, db.First(&closed, "id = ?", mergedID).Error)
+	require.NotNil(t, closed.MergedIntoID)
+	assert.Equal(t, survivor.ID, *closed.MergedIntoID)
+}
+
+func TestMissingPersonRepository_MergeKeepsSurvivorReunion(t *testing.T) {
+	db := openTestDB(t,
+		&entity.MissingPerson{}, &entity.MissingPersonTrack{}, &entity.MissingPhoto{}, &entity.Task{}, &entity.File{},
+		&entity.MissingPersonStatusLog{}, &entity.MissingPersonMerge{},
+		&entity.DNASample{}, &entity.FamilySearcherCase{}, &entity.ReunionRecord{}, &entity.ReunionFollowUp{}, &entity.DialectSession{},
+	)
+
+	survivor := &entity.MissingPerson{BaseEntity: entity.BaseEntity{ID: "survivor"}, CaseNo: "MP001", Status: entity.MissingStatusSearching}
+	merged := &entity.MissingPerson{BaseEntity: entity.BaseEntity{ID: "merged"}, CaseNo: "MP002", Status: entity.MissingStatusMissing}
+	require.NoError(t, db.Omit("Reporter", "Assignee", "Org").Create([]*entity.MissingPerson{survivor, merged}).Error)
+	require.NoError(t, db.Create([]*entity.ReunionRecord{
+		{BaseEntity: entity.BaseEntity{ID: "reunion-survivor"}, MissingPersonID: survivor.ID},
+		{BaseEntity: entity.BaseEntity{ID: "reunion-merged"}, MissingPersonID: merged.ID},
+	}).Error)
+
+	require.NoError(t, merged.MergeInto(survivor))
+	record := entity.NewMissingPersonMerge(survivor, merged, "operator", "org", "")
+	require.NoError(t, NewMissingPersonRepository(db).Merge(context.Background(), survivor, merged, record, nil))
+
+	// 每个案件只有一条团聚记录，不覆盖保留案件已有的记录
+	assert.Equal(t, survivor.ID, caseIDOf(t, db, &entity.ReunionRecord{}, "reunion-survivor"))
+	assert.Equal(t, merged.ID, caseIDOf(t, db, &entity.ReunionRecord{}, "reunion-merged"))
+}
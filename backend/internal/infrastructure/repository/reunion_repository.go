@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// ReunionRepositoryImpl 团聚记录仓储实现
+type ReunionRepositoryImpl struct {
+	*BaseRepository[entity.ReunionRecord]
+}
+
+// NewReunionRepository 创建团聚记录仓储
+func NewReunionRepository(db *gorm.DB) repository.ReunionRepository {
+	return &ReunionRepositoryImpl{
+		BaseRepository: NewBaseRepository[entity.ReunionRecord](db),
+	}
+}
+
+// CreateWithTransition 保存团聚状态转换、团聚记录及回访计划
+func (r *ReunionRepositoryImpl) CreateWithTransition(ctx context.Context, mp *entity.MissingPerson, log *entity.MissingPersonStatusLog, record *entity.ReunionRecord) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := transitionStatus(tx, mp, log); err != nil {
+			return err
+		}
+		// 回访计划随团聚记录一并创建
+		return tx.Create(record).Error
+	})
+}
+
+// FindByMissingPersonID 查找案件的团聚记录
+func (r *ReunionRepositoryImpl) FindByMissingPersonID(ctx context.Context, personID string) (*entity.ReunionRecord, error) {
+	var record entity.ReunionRecord
+	err := r.db.WithContext(ctx).
+		Preload("ConfirmedBy").
+		Preload("FollowUps", func(db *gorm.DB) *gorm.DB {
+			return db.Order("month ASC")
+		}).
+		First(&record, "missing_person_id = ?", personID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &record, nil
+}
+
+// RescheduleFollowUps 团聚时间变更后顺延尚未生成任务的回访
+func (r *ReunionRepositoryImpl) RescheduleFollowUps(ctx context.Context, record *entity.ReunionRecord) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, f := range record.FollowUps {
+			if f.Status != entity.FollowUpStatusScheduled {
+				continue
+			}
+			if err := tx.Model(&entity.ReunionFollowUp{}).
+				Where("id = ? AND status = ?", f.ID, entity.FollowUpStatusScheduled).
+				Update("due_at", record.ReunionTime.AddDate(0, f.Month, 0)).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// FindFollowUpByID 根据ID查找回访
+func (r *ReunionRepositoryImpl) FindFollowUpByID(ctx context.Context, id string) (*entity.ReunionFollowUp, error) {
+	var followUp entity.ReunionFollowUp
+	if err := r.db.WithContext(ctx).First(&followUp, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &followUp, nil
+}
+
+// UpdateFollowUp 更新回访
+func (r *ReunionRepositoryImpl) UpdateFollowUp(ctx context.Context, followUp *entity.ReunionFollowUp) error {
+	return r.db.WithContext(ctx).Save(followUp).Error
+}
+
+// FindDueFollowUps 查找指定时间前到期、尚未生成任务的回访
+func (r *ReunionRepositoryImpl) FindDueFollowUps(ctx context.Context, before time.Time, limit int) ([]entity.ReunionFollowUp, error) {
+	var list []entity.ReunionFollowUp
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND due_at <= ?", entity.FollowUpStatusScheduled, before).
+		Order("due_at ASC").
+		Limit(limit).
+		Preload("Reunion").
+		Find(&list).Error
+	return list, err
+}
+
+// FollowUpStats 回访统计
+func (r *ReunionRepositoryImpl) FollowUpStats(ctx context.Context, orgID string, now time.Time) ([]entity.FollowUpStat, error) {
+	var stats []entity.FollowUpStat
+	db := r.db.WithContext(ctx).
+		Table("ty_reunion_follow_ups AS f").
+		Select("f.month, f.status, f.outcome, "+
+			"CASE WHEN f.status <> ? AND f.due_at < ? THEN 1 ELSE 0 END AS overdue, COUNT(*) AS count",
+			entity.FollowUpStatusCompleted, now).
+		Joins("JOIN ty_missing_persons mp ON mp.id = f.missing_person_id").
+		Where("f.deleted_at IS NULL AND mp.deleted_at IS NULL")
+	if orgID != "" {
+		db = db.Where("mp.org_id = ?", orgID)
+	}
+	err := db.Group("f.month, f.status, f.outcome, overdue").
+		Order("f.month ASC").
+		Scan(&stats).Error
+	return stats, err
+}
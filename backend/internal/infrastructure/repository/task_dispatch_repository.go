@@ -0,0 +1,341 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// openTaskStatuses 计入志愿者在办任务数的任务状态
+var openTaskStatuses = []entity.TaskStatus{entity.TaskStatusAssigned, entity.TaskStatusProcessing}
+
+// TaskDispatchRepositoryImpl 任务自动派单仓储实现
+type TaskDispatchRepositoryImpl struct {
+	*BaseRepository[entity.TaskOffer]
+}
+
+// NewTaskDispatchRepository 创建任务自动派单仓储
+func NewTaskDispatchRepository(db *gorm.DB) repository.TaskDispatchRepository {
+	return &TaskDispatchRepositoryImpl{
+		BaseRepository: NewBaseRepository[entity.TaskOffer](db),
+	}
+}
+
+// FindCandidates 获取组织内在职志愿者，含在办任务数和最近一次任务地点
+func (r *TaskDispatchRepositoryImpl) FindCandidates(ctx context.Context, orgID string) ([]entity.DispatchCandidate, error) {
+	var users []entity.User
+	err := r.db.WithContext(ctx).
+		Select("id", "nickname").
+		Where("org_id = ? AND role = ? AND status = ?", orgID, entity.RoleVolunteer, entity.UserStatusActive).
+		Find(&users).Error
+	if err != nil || len(users) == 0 {
+		return nil, err
+	}
+	userIDs := make([]string, len(users))
+	for i, u := range users {
+		userIDs[i] = u.ID
+	}
+
+	var loads []struct {
+		AssigneeID string
+		Count      int
+	}
+	err = r.db.WithContext(ctx).Model(&entity.Task{}).
+		Select("assignee_id, COUNT(*) AS count").
+		Where("assignee_id IN ? AND status IN ?", userIDs, openTaskStatuses).
+		Group("assignee_id").
+		Scan(&loads).Error
+	if err != nil {
+		return nil, err
+	}
+	openTasks := make(map[string]int, len(loads))
+	for _, l := range loads {
+		openTasks[l.AssigneeID] = l.Count
+	}
+
+	// 最近一次有坐标的任务地点作为志愿者的已知位置
+	var locations []struct {
+		AssigneeID string
+		Lat        float64
+		Lng        float64
+		UpdatedAt  time.Time
+	}
+	err = r.db.WithContext(ctx).Model(&entity.Task{}).
+		Select("assignee_id, lat, lng, updated_at").
+		Where("assignee_id IN ? AND NOT (lat = 0 AND lng = 0)", userIDs).
+		Where(`updated_at = (SELECT MAX(t.updated_at) FROM ty_tasks t
+			WHERE t.assignee_id = ty_tasks.assignee_id AND NOT (t.lat = 0 AND t.lng = 0) AND t.deleted_at IS NULL)`).
+		Scan(&locations).Error
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]entity.DispatchCandidate, len(users))
+	for i, u := range users {
+		candidates[i] = entity.DispatchCandidate{
+			UserID:    u.ID,
+			Nickname:  u.Nickname,
+			OpenTasks: openTasks[u.ID],
+		}
+	}
+	index := make(map[string]int, len(candidates))
+	for i := range candidates {
+		index[candidates[i].UserID] = i
+	}
+	for _, loc := range locations {
+		c := &candidates[index[loc.AssigneeID]]
+		if c.LocationSource != "" {
+			continue
+		}
+		locatedAt := loc.UpdatedAt
+		c.Lat, c.Lng = loc.Lat, loc.Lng
+		c.LocationSource = entity.DispatchLocationLastKnown
+		c.LocatedAt = &locatedAt
+	}
+	return candidates, nil
+}
+
+// OfferedUserIDs 获取任务已推送过的志愿者
+func (r *TaskDispatchRepositoryImpl) OfferedUserIDs(ctx context.Context, taskID string) ([]string, error) {
+	var userIDs []string
+	err := r.db.WithContext(ctx).Model(&entity.TaskOffer{}).
+		Where("task_id = ?", taskID).
+		Distinct().
+		Pluck("user_id", &userIDs).Error
+	return userIDs, err
+}
+
+// CreateOffers 保存一轮派单邀请并更新任务的派单状态，以轮次条件更新避免并发推送同一轮
+func (r *TaskDispatchRepositoryImpl) CreateOffers(ctx context.Context, task *entity.Task, offers []*entity.TaskOffer) (bool, error) {
+	saved := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entity.Task{}).
+			Where("id = ? AND status = ? AND dispatch_round = ?", task.ID, entity.TaskStatusPending, task.DispatchRound-1).
+			Updates(map[string]interface{}{
+				"dispatch_status": task.DispatchStatus,
+				"dispatch_round":  task.DispatchRound,
+				"dispatched_at":   task.DispatchedAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		if len(offers) > 0 {
+			if err := tx.Omit("Task", "User").Create(&offers).Error; err != nil {
+				return err
+			}
+		}
+		saved = true
+		return nil
+	})
+	return saved, err
+}
+
+// FindByIDWithTask 获取邀请（含任务）
+func (r *TaskDispatchRepositoryImpl) FindByIDWithTask(ctx context.Context, id string) (*entity.TaskOffer, error) {
+	var offer entity.TaskOffer
+	err := r.db.WithContext(ctx).Preload("Task").First(&offer, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &offer, nil
+}
+
+// ListByTask 获取任务的全部邀请
+func (r *TaskDispatchRepositoryImpl) ListByTask(ctx context.Context, taskID string) ([]entity.TaskOffer, error) {
+	var offers []entity.TaskOffer
+	err := r.db.WithContext(ctx).
+		Preload("User").
+		Where("task_id = ?", taskID).
+		Order("round ASC, position ASC").
+		Find(&offers).Error
+	return offers, err
+}
+
+// ListOpenByUser 获取志愿者待响应的邀请
+func (r *TaskDispatchRepositoryImpl) ListOpenByUser(ctx context.Context, userID string, now time.Time) ([]entity.TaskOffer, error) {
+	var offers []entity.TaskOffer
+	err := r.db.WithContext(ctx).
+		Preload("Task").
+		Joins("JOIN ty_tasks ON ty_tasks.id = ty_task_offers.task_id AND ty_tasks.status = ? AND ty_tasks.deleted_at IS NULL",
+			entity.TaskStatusPending).
+		Where("ty_task_offers.user_id = ? AND ty_task_offers.status = ? AND ty_task_offers.expires_at > ?",
+			userID, entity.TaskOfferPending, now).
+		Order("ty_task_offers.expires_at ASC").
+		Find(&offers).Error
+	return offers, err
+}
+
+// Accept 接单，锁定任务行避免两名志愿者同时接单
+func (r *TaskDispatchRepositoryImpl) Accept(ctx context.Context, offer *entity.TaskOffer) (bool, []entity.TaskOffer, error) {
+	accepted := false
+	var withdrawn []entity.TaskOffer
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var task entity.Task
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&task, "id = ?", offer.TaskID).Error; err != nil {
+			return err
+		}
+		if !task.CanDispatch() {
+			return nil
+		}
+
+		result := tx.Model(&entity.TaskOffer{}).
+			Where("id = ? AND status = ?", offer.ID, entity.TaskOfferPending).
+			Updates(map[string]interface{}{
+				"status":       offer.Status,
+				"responded_at": offer.RespondedAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		oldStatus := task.Status
+		if err := task.Assign(offer.UserID); err != nil {
+			return err
+		}
+		err := tx.Model(&entity.Task{}).Where("id = ?", task.ID).Updates(map[string]interface{}{
+			"assignee_id":     task.AssigneeID,
+			"status":          task.Status,
+			"dispatch_status": entity.TaskDispatchAccepted,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		withdrawn, err = withdrawOffers(tx, task.ID)
+		if err != nil {
+			return err
+		}
+
+		log := &entity.TaskLog{
+			TaskID:    task.ID,
+			UserID:    offer.UserID,
+			Action:    "assign",
+			OldStatus: string(oldStatus),
+			NewStatus: string(task.Status),
+			Content:   fmt.Sprintf("Accepted dispatch offer (round %d, position %d)", offer.Round, offer.Position),
+		}
+		if err := tx.Create(log).Error; err != nil {
+			return err
+		}
+		accepted = true
+		return nil
+	})
+	if !accepted {
+		withdrawn = nil
+	}
+	return accepted, withdrawn, err
+}
+
+// Respond 保存拒绝结果
+func (r *TaskDispatchRepositoryImpl) Respond(ctx context.Context, offer *entity.TaskOffer) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&entity.TaskOffer{}).
+		Where("id = ? AND status = ?", offer.ID, entity.TaskOfferPending).
+		Updates(map[string]interface{}{
+			"status":       offer.Status,
+			"reason":       offer.Reason,
+			"responded_at": offer.RespondedAt,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// ExpireOffers 将已超时的待响应邀请标记为超时
+func (r *TaskDispatchRepositoryImpl) ExpireOffers(ctx context.Context, now time.Time) ([]entity.TaskOffer, error) {
+	var offers []entity.TaskOffer
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("status = ? AND expires_at <= ?", entity.TaskOfferPending, now).
+			Find(&offers).Error
+		if err != nil || len(offers) == 0 {
+			return err
+		}
+		ids := make([]string, len(offers))
+		for i := range offers {
+			ids[i] = offers[i].ID
+			offers[i].Status = entity.TaskOfferExpired
+		}
+		return tx.Model(&entity.TaskOffer{}).
+			Where("id IN ?", ids).
+			Update("status", entity.TaskOfferExpired).Error
+	})
+	return offers, err
+}
+
+// WithdrawByTask 撤回任务全部待响应的邀请
+func (r *TaskDispatchRepositoryImpl) WithdrawByTask(ctx context.Context, taskID string) ([]entity.TaskOffer, error) {
+	var offers []entity.TaskOffer
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		offers, err = withdrawOffers(tx, taskID)
+		return err
+	})
+	return offers, err
+}
+
+// withdrawOffers 在事务中撤回任务全部待响应的邀请
+func withdrawOffers(tx *gorm.DB, taskID string) ([]entity.TaskOffer, error) {
+	var offers []entity.TaskOffer
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("task_id = ? AND status = ?", taskID, entity.TaskOfferPending).
+		Find(&offers).Error
+	if err != nil || len(offers) == 0 {
+		return nil, err
+	}
+	for i := range offers {
+		offers[i].Status = entity.TaskOfferWithdrawn
+	}
+	err = tx.Model(&entity.TaskOffer{}).
+		Where("task_id = ? AND status = ?", taskID, entity.TaskOfferPending).
+		Update("status", entity.TaskOfferWithdrawn).Error
+	return offers, err
+}
+
+// CountOpen 统计任务待响应的邀请数
+func (r *TaskDispatchRepositoryImpl) CountOpen(ctx context.Context, taskID string) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&entity.TaskOffer{}).
+		Where("task_id = ? AND status = ?", taskID, entity.TaskOfferPending).
+		Count(&count).Error
+	return count, err
+}
+
+// FindTasksToDispatch 获取需要推送下一轮的任务
+func (r *TaskDispatchRepositoryImpl) FindTasksToDispatch(ctx context.Context, limit int) ([]entity.Task, error) {
+	var tasks []entity.Task
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND dispatch_status IN ?", entity.TaskStatusPending,
+			[]entity.TaskDispatchStatus{entity.TaskDispatchNone, entity.TaskDispatchOffering}).
+		Where("NOT EXISTS (?)", r.db.Model(&entity.TaskOffer{}).
+			Select("1").
+			Where("ty_task_offers.task_id = ty_tasks.id AND ty_task_offers.status = ?", entity.TaskOfferPending)).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&tasks).Error
+	return tasks, err
+}
+
+// UpdateTaskDispatch 更新任务的派单状态和轮次
+func (r *TaskDispatchRepositoryImpl) UpdateTaskDispatch(ctx context.Context, task *entity.Task) error {
+	return r.db.WithContext(ctx).Model(&entity.Task{}).Where("id = ?", task.ID).Updates(map[string]interface{}{
+		"dispatch_status": task.DispatchStatus,
+		"dispatch_round":  task.DispatchRound,
+		"dispatched_at":   task.DispatchedAt,
+	}).Error
+}
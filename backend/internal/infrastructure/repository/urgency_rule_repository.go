@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// UrgencyRuleRepositoryImpl 紧急程度评分规则仓储实现
+type UrgencyRuleRepositoryImpl struct {
+	*BaseRepository[entity.UrgencyRule]
+}
+
+// NewUrgencyRuleRepository 创建紧急程度评分规则仓储
+func NewUrgencyRuleRepository(db *gorm.DB) repository.UrgencyRuleRepository {
+	return &UrgencyRuleRepositoryImpl{
+		BaseRepository: NewBaseRepository[entity.UrgencyRule](db),
+	}
+}
+
+// FindByOrgID 查找组织自身配置的规则
+func (r *UrgencyRuleRepositoryImpl) FindByOrgID(ctx context.Context, orgID string) (*entity.UrgencyRule, error) {
+	var rule entity.UrgencyRule
+	err := r.db.WithContext(ctx).First(&rule, "org_id = ?", orgID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// ListAll 所有组织的规则
+func (r *UrgencyRuleRepositoryImpl) ListAll(ctx context.Context) ([]entity.UrgencyRule, error) {
+	var rules []entity.UrgencyRule
+	err := r.db.WithContext(ctx).Order("created_at ASC").Find(&rules).Error
+	return rules, err
+}
+
+// DeleteByOrgID 删除组织的规则（硬删除，便于重新配置）
+func (r *UrgencyRuleRepositoryImpl) DeleteByOrgID(ctx context.Context, orgID string) error {
+	return r.db.WithContext(ctx).Unscoped().Where("org_id = ?", orgID).Delete(&entity.UrgencyRule{}).Error
+}
@@ -0,0 +1,226 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// volunteerSearchLimit 志愿者初筛默认上限
+const volunteerSearchLimit = 1000
+
+// VolunteerProfileRepositoryImpl 志愿者档案仓储实现
+type VolunteerProfileRepositoryImpl struct {
+	*BaseRepository[entity.VolunteerProfile]
+}
+
+// NewVolunteerProfileRepository 创建志愿者档案仓储
+func NewVolunteerProfileRepository(db *gorm.DB) repository.VolunteerProfileRepository {
+	return &VolunteerProfileRepositoryImpl{
+		BaseRepository: NewBaseRepository[entity.VolunteerProfile](db),
+	}
+}
+
+// preloadVolunteerDetails 预加载证书、语言及方言分类、值班时段和休假
+func preloadVolunteerDetails(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Certifications", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		}).
+		Preload("Languages", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		}).
+		Preload("Languages.DialectGroup").
+		Preload("Availability", func(db *gorm.DB) *gorm.DB {
+			return db.Order("weekday ASC, start_minute ASC")
+		}).
+		Preload("Vacations", func(db *gorm.DB) *gorm.DB {
+			return db.Order("start_at ASC")
+		})
+}
+
+// FindByUserID 获取志愿者档案
+func (r *VolunteerProfileRepositoryImpl) FindByUserID(ctx context.Context, userID string) (*entity.VolunteerProfile, error) {
+	var profile entity.VolunteerProfile
+	err := preloadVolunteerDetails(r.db.WithContext(ctx)).
+		Preload("User").
+		First(&profile, "user_id = ?", userID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// FindByUserIDs 批量获取志愿者档案
+func (r *VolunteerProfileRepositoryImpl) FindByUserIDs(ctx context.Context, userIDs []string) ([]entity.VolunteerProfile, error) {
+	var profiles []entity.VolunteerProfile
+	if len(userIDs) == 0 {
+		return profiles, nil
+	}
+	err := preloadVolunteerDetails(r.db.WithContext(ctx)).Where("user_id IN ?", userIDs).Find(&profiles).Error
+	return profiles, err
+}
+
+// SaveWithDetails 创建或更新档案，并整体替换证书、语言和值班时段
+func (r *VolunteerProfileRepositoryImpl) SaveWithDetails(ctx context.Context, profile *entity.VolunteerProfile) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if profile.ID == "" {
+			if err := tx.Omit(clause.Associations).Create(profile).Error; err != nil {
+				return err
+			}
+		} else {
+			if err := tx.Omit(clause.Associations).Save(profile).Error; err != nil {
+				return err
+			}
+			for _, model := range []interface{}{
+				&entity.VolunteerCertification{},
+				&entity.VolunteerLanguage{},
+				&entity.VolunteerAvailability{},
+			} {
+				if err := tx.Unscoped().Where("profile_id = ?", profile.ID).Delete(model).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		for i := range profile.Certifications {
+			profile.Certifications[i].ID = ""
+			profile.Certifications[i].ProfileID = profile.ID
+		}
+		for i := range profile.Languages {
+			profile.Languages[i].ID = ""
+			profile.Languages[i].ProfileID = profile.ID
+		}
+		for i := range profile.Availability {
+			profile.Availability[i].ID = ""
+			profile.Availability[i].ProfileID = profile.ID
+		}
+		if len(profile.Certifications) > 0 {
+			if err := tx.Create(&profile.Certifications).Error; err != nil {
+				return err
+			}
+		}
+		if len(profile.Languages) > 0 {
+			if err := tx.Omit("DialectGroup").Create(&profile.Languages).Error; err != nil {
+				return err
+			}
+		}
+		if len(profile.Availability) > 0 {
+			if err := tx.Create(&profile.Availability).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// AddVacation 添加休假
+func (r *VolunteerProfileRepositoryImpl) AddVacation(ctx context.Context, vacation *entity.VolunteerVacation) error {
+	return r.db.WithContext(ctx).Create(vacation).Error
+}
+
+// DeleteVacation 删除休假
+func (r *VolunteerProfileRepositoryImpl) DeleteVacation(ctx context.Context, profileID, vacationID string) (bool, error) {
+	res := r.db.WithContext(ctx).Unscoped().
+		Where("id = ? AND profile_id = ?", vacationID, profileID).
+		Delete(&entity.VolunteerVacation{})
+	return res.RowsAffected > 0, res.Error
+}
+
+// Search 按条件初筛在职志愿者的档案
+func (r *VolunteerProfileRepositoryImpl) Search(ctx context.Context, query *repository.VolunteerProfileQuery) ([]entity.VolunteerProfile, error) {
+	db := r.db.WithContext(ctx).Model(&entity.VolunteerProfile{}).
+		Where("user_id IN (?)", r.db.Model(&entity.User{}).Select("id").Where("status = ?", entity.UserStatusActive))
+	if query.OrgID != "" {
+		db = db.Where("org_id = ?", query.OrgID)
+	}
+	if query.Keyword != "" {
+		like := "%" + query.Keyword + "%"
+		db = db.Where("user_id IN (?)", r.db.Model(&entity.User{}).Select("id").
+			Where("nickname LIKE ? OR real_name LIKE ?", like, like))
+	}
+	if query.Province != "" {
+		db = db.Where("province = ?", query.Province)
+	}
+	if query.City != "" {
+		db = db.Where("city = ?", query.City)
+	}
+	for _, skill := range query.Skills {
+		like := "%" + skill + "%"
+		db = db.Where("skills LIKE ? OR id IN (?)", like,
+			r.db.Model(&entity.VolunteerCertification{}).Select("profile_id").Where("name LIKE ?", like))
+	}
+	for _, language := range query.Languages {
+		like := "%" + language + "%"
+		db = db.Where("id IN (?)", r.db.Model(&entity.VolunteerLanguage{}).
+			Select("ty_volunteer_languages.profile_id").
+			Joins("LEFT JOIN ty_dialect_groups ON ty_dialect_groups.id = ty_volunteer_languages.dialect_group_id").
+			Where("ty_volunteer_languages.name LIKE ? OR ty_dialect_groups.full_name LIKE ? OR ty_dialect_groups.aliases LIKE ?",
+				like, like, like))
+	}
+	if query.DialectGroupPath != "" {
+		db = db.Where("id IN (?)", r.db.Model(&entity.VolunteerLanguage{}).
+			Select("profile_id").
+			Where("dialect_group_id IN (?)", r.db.Model(&entity.DialectGroup{}).Select("id").
+				Where("path LIKE ?", query.DialectGroupPath+"%")))
+	}
+	if query.Bounds.Valid() {
+		db = db.Where("home_lat BETWEEN ? AND ? AND home_lng BETWEEN ? AND ?",
+			query.Bounds.MinLat, query.Bounds.MaxLat, query.Bounds.MinLng, query.Bounds.MaxLng)
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = volunteerSearchLimit
+	}
+	var profiles []entity.VolunteerProfile
+	err := preloadVolunteerDetails(db).Preload("User").Order("updated_at DESC").Limit(limit).Find(&profiles).Error
+	return profiles, err
+}
+
+// FindExpiringCertifications 查找即将到期的证书
+func (r *VolunteerProfileRepositoryImpl) FindExpiringCertifications(ctx context.Context, orgID string, now, before time.Time) ([]repository.VolunteerCertificationExpiry, error) {
+	var certs []entity.VolunteerCertification
+	db := r.db.WithContext(ctx).
+		Where("expires_at > ? AND expires_at <= ?", now, before)
+	if orgID != "" {
+		db = db.Where("profile_id IN (?)", r.db.Model(&entity.VolunteerProfile{}).Select("id").Where("org_id = ?", orgID))
+	}
+	if err := db.Order("expires_at ASC").Find(&certs).Error; err != nil {
+		return nil, err
+	}
+	if len(certs) == 0 {
+		return nil, nil
+	}
+
+	profileIDs := make([]string, len(certs))
+	for i, c := range certs {
+		profileIDs[i] = c.ProfileID
+	}
+	var profiles []entity.VolunteerProfile
+	if err := r.db.WithContext(ctx).Preload("User").Where("id IN ?", profileIDs).Find(&profiles).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[string]entity.VolunteerProfile, len(profiles))
+	for _, p := range profiles {
+		byID[p.ID] = p
+	}
+
+	list := make([]repository.VolunteerCertificationExpiry, 0, len(certs))
+	for _, c := range certs {
+		p, ok := byID[c.ProfileID]
+		if !ok {
+			continue
+		}
+		list = append(list, repository.VolunteerCertificationExpiry{Certification: c, Profile: p})
+	}
+	return list, nil
+}
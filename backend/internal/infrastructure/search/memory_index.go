@@ -0,0 +1,225 @@
+package search
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/service"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/logger"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/search"
+)
+
+// snapshotVersion 快照格式版本，分词规则变化时递增使旧快照失效
+const snapshotVersion = 1
+
+// caseFields 案件检索字段及权重
+var caseFields = []search.Field{
+	{Name: "name", Boost: 3, Pinyin: true},
+	{Name: "features", Boost: 1.5},
+	{Name: "clothes", Boost: 1.2},
+	{Name: "description", Boost: 1},
+	{Name: "address", Boost: 1},
+}
+
+// snapshot 索引快照
+type snapshot struct {
+	Version  int
+	SyncedAt time.Time
+	Docs     []search.Document
+}
+
+// MemoryCaseIndex 内置的内存案件索引，定期快照到本地文件
+type MemoryCaseIndex struct {
+	mu       sync.RWMutex
+	index    *search.Index
+	path     string
+	syncedAt time.Time
+	saveMu   sync.Mutex
+}
+
+// NewMemoryCaseIndex 创建内存案件索引，path 不为空时从快照恢复，快照无效时从空索引开始
+func NewMemoryCaseIndex(path string) *MemoryCaseIndex {
+	m := &MemoryCaseIndex{index: search.New(caseFields...), path: path}
+	if path == "" {
+		return m
+	}
+	if err := m.load(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		logger.Warn("Failed to load search index snapshot, index will be rebuilt",
+			logger.String("path", path), logger.Err(err))
+	}
+	return m
+}
+
+// Index 新增或更新案件索引
+func (m *MemoryCaseIndex) Index(ctx context.Context, mp *entity.MissingPerson) error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	m.index.Put(caseDocument(mp))
+	return nil
+}
+
+// Delete 删除案件索引
+func (m *MemoryCaseIndex) Delete(ctx context.Context, id string) error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	m.index.Delete(id)
+	return nil
+}
+
+// Search 检索
+func (m *MemoryCaseIndex) Search(ctx context.Context, query service.CaseSearchQuery) (*service.CaseSearchResult, error) {
+	m.mu.RLock()
+	res := m.index.Search(query.Keyword, search.Options{Offset: query.Offset, Limit: query.Limit})
+	m.mu.RUnlock()
+
+	result := &service.CaseSearchResult{Total: res.Total, Hits: make([]service.CaseSearchHit, len(res.Hits))}
+	for i, h := range res.Hits {
+		result.Hits[i] = service.CaseSearchHit{ID: h.ID, Score: h.Score, Highlights: h.Highlights}
+	}
+	return result, nil
+}
+
+// Rebuild 在新索引上全量构建，完成后替换当前索引
+func (m *MemoryCaseIndex) Rebuild(ctx context.Context, next func() ([]entity.MissingPerson, error)) (int, error) {
+	index := search.New(caseFields...)
+	for {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		batch, err := next()
+		if err != nil {
+			return 0, err
+		}
+		if len(batch) == 0 {
+			break
+		}
+		for i := range batch {
+			index.Put(caseDocument(&batch[i]))
+		}
+	}
+
+	m.mu.Lock()
+	m.index = index
+	m.mu.Unlock()
+	return index.Len(), nil
+}
+
+// Checkpoint 记录同步时间点并写入快照
+func (m *MemoryCaseIndex) Checkpoint(ctx context.Context, syncedAt time.Time) error {
+	m.mu.Lock()
+	m.syncedAt = syncedAt
+	index := m.index
+	m.mu.Unlock()
+
+	if m.path == "" {
+		return nil
+	}
+	return m.save(&snapshot{Version: snapshotVersion, SyncedAt: syncedAt, Docs: index.Documents()})
+}
+
+// Stats 索引状态
+func (m *MemoryCaseIndex) Stats() service.CaseSearchStats {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return service.CaseSearchStats{
+		Engine:    "memory",
+		Documents: m.index.Len(),
+		Terms:     m.index.Terms(),
+		SyncedAt:  m.syncedAt,
+	}
+}
+
+// load 从快照恢复
+func (m *MemoryCaseIndex) load() error {
+	f, err := os.Open(m.path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	var snap snapshot
+	if err := gob.NewDecoder(zr).Decode(&snap); err != nil {
+		return err
+	}
+	if snap.Version != snapshotVersion {
+		return fmt.Errorf("snapshot version %d, want %d", snap.Version, snapshotVersion)
+	}
+
+	for _, doc := range snap.Docs {
+		m.index.Put(doc)
+	}
+	m.syncedAt = snap.SyncedAt
+	logger.Info("Search index snapshot loaded",
+		logger.String("path", m.path),
+		logger.Int("documents", len(snap.Docs)),
+	)
+	return nil
+}
+
+// save 写入快照（先写临时文件再替换，避免中途失败损坏旧快照）
+func (m *MemoryCaseIndex) save(snap *snapshot) error {
+	m.saveMu.Lock()
+	defer m.saveMu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(m.path), 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(m.path), filepath.Base(m.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	zw := gzip.NewWriter(tmp)
+	if err := gob.NewEncoder(zw).Encode(snap); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), m.path)
+}
+
+// caseDocument 案件转为索引文档
+func caseDocument(mp *entity.MissingPerson) search.Document {
+	return search.Document{
+		ID:   mp.ID,
+		Time: mp.CreatedAt,
+		Fields: map[string]string{
+			"name":        mp.Name,
+			"features":    mp.Features,
+			"clothes":     mp.Clothes,
+			"description": mp.Description,
+			"address":     caseAddress(mp),
+		},
+	}
+}
+
+// caseAddress 省市区与详细地址
+func caseAddress(mp *entity.MissingPerson) string {
+	var parts []string
+	for _, p := range []string{mp.Province, mp.City, mp.District, mp.Address} {
+		if p = strings.TrimSpace(p); p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, " ")
+}
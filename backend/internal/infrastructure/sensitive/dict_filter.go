@@ -0,0 +1,36 @@
+package sensitive
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/service"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/logger"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/sensitive"
+)
+
+// DictFilter 基于本地词典的敏感词过滤
+type DictFilter struct {
+	matcher *sensitive.Matcher
+}
+
+// NewDictFilter 创建词典过滤器：内置词典加上 dictPath 指定的本地词表（每行一个词），
+// 词表不存在或读取失败时只使用内置词典
+func NewDictFilter(dictPath string) *DictFilter {
+	matcher := sensitive.Builtin()
+	if dictPath != "" {
+		if err := matcher.LoadFile(dictPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			logger.Warn("Failed to load sensitive word dictionary, using builtin words only",
+				logger.String("path", dictPath), logger.Err(err))
+		}
+	}
+	logger.Info("Sensitive word filter loaded", logger.Int("words", matcher.Len()))
+	return &DictFilter{matcher: matcher}
+}
+
+// Check 检查文本
+func (f *DictFilter) Check(_ context.Context, text string) (*service.ContentCheckResult, error) {
+	masked, words := f.matcher.Replace(text, '*')
+	return &service.ContentCheckResult{Words: words, Masked: masked}, nil
+}
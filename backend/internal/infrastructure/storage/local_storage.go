@@ -22,6 +22,7 @@ type LocalStorage struct {
 	baseURL      string
 	maxSize      int64
 	allowedTypes []string
+	private      bool // 不对外提供访问，文件没有URL
 }
 
 // NewLocalStorage 创建本地存储
@@ -34,6 +35,16 @@ func NewLocalStorage(cfg *config.StorageConfig) service.StorageService {
 	}
 }
 
+// NewPrivateLocalStorage 创建不对外提供访问的本地存储，basePath 不能位于公开的上传目录下
+// 存入的文件没有访问URL，只能通过 Download 读取
+func NewPrivateLocalStorage(basePath string, maxSize int64) service.StorageService {
+	return &LocalStorage{
+		basePath: basePath,
+		maxSize:  maxSize,
+		private:  true,
+	}
+}
+
 // Upload 上传文件
 func (s *LocalStorage) Upload(ctx context.Context, reader io.Reader, filename string, size int64, contentType string) (*entity.File, error) {
 	logger.Info("LocalStorage Upload called",
@@ -99,7 +110,7 @@ func (s *LocalStorage) Upload(ctx context.Context, reader io.Reader, filename st
 		MimeType:     contentType,
 		Size:         written,
 		Path:         storagePath,
-		URL:          s.baseURL + "/" + strings.ReplaceAll(storagePath, "\\", "/"),
+		URL:          s.GetURL(ctx, storagePath),
 		StorageType:  entity.StorageTypeLocal,
 	}
 
@@ -138,6 +149,9 @@ func (s *LocalStorage) Delete(ctx context.Context, path string) error {
 
 // GetURL 获取文件访问URL
 func (s *LocalStorage) GetURL(ctx context.Context, path string) string {
+	if s.private {
+		return ""
+	}
 	return s.baseURL + "/" + strings.ReplaceAll(path, "\\", "/")
 }
 
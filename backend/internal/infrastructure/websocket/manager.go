@@ -43,8 +43,15 @@ type Manager struct {
 	
 	// 统计
 	stats Stats
+
+	// 客户端上行消息处理器
+	handlers    map[string]MessageHandler
+	handlersMux sync.RWMutex
 }
 
+// MessageHandler 客户端上行消息处理器，data 为消息的 data 字段；返回的消息回复给发送者，返回 nil 时不回复
+type MessageHandler func(userID, orgID string, data json.RawMessage) *entity.WebSocketMessage
+
 // BroadcastMessage 广播消息
 type BroadcastMessage struct {
 	Message *entity.WebSocketMessage
@@ -68,6 +75,7 @@ func NewManager() *Manager {
 		register:   make(chan *Client, 100),
 		unregister: make(chan *Client, 100),
 		broadcast:  make(chan *BroadcastMessage, 100),
+		handlers:   make(map[string]MessageHandler),
 		upgrader: websocket.Upgrader{
 			ReadBufferSize:  1024,
 			WriteBufferSize: 1024,
@@ -287,6 +295,13 @@ func (m *Manager) BroadcastToOrg(orgID string, msg *entity.WebSocketMessage) err
 	return nil
 }
 
+// HandleFunc 注册客户端上行消息的处理器，内置的 ping/pong/read 类型不可覆盖
+func (m *Manager) HandleFunc(msgType string, handler MessageHandler) {
+	m.handlersMux.Lock()
+	defer m.handlersMux.Unlock()
+	m.handlers[msgType] = handler
+}
+
 // IsUserOnline 检查用户是否在线
 func (m *Manager) IsUserOnline(userID string) bool {
 	m.clientsMux.RLock()
@@ -451,7 +466,20 @@ func (c *Client) handleMessage(message []byte) {
 			logger.Debug("Mark notification as read", zap.String("notification_id", notifID), zap.String("user_id", c.UserID))
 		}
 	default:
-		logger.Debug("Unknown message type", zap.String("type", msgType), zap.String("user_id", c.UserID))
+		c.Manager.handlersMux.RLock()
+		handler, ok := c.Manager.handlers[msgType]
+		c.Manager.handlersMux.RUnlock()
+		if !ok {
+			logger.Debug("Unknown message type", zap.String("type", msgType), zap.String("user_id", c.UserID))
+			return
+		}
+		var payload struct {
+			Data json.RawMessage `json:"data"`
+		}
+		json.Unmarshal(message, &payload)
+		if reply := handler(c.UserID, c.OrgID, payload.Data); reply != nil {
+			c.Manager.SendToUser(c.UserID, reply)
+		}
 	}
 }
 
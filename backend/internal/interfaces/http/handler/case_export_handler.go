@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/dto"
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/service"
+	"github.com/Snowitty-Re/CNtunyuan/internal/interfaces/http/middleware"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/logger"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// CaseExportHandler 案件移交导出处理器
+type CaseExportHandler struct {
+	exportService *service.CaseExportAppService
+}
+
+// NewCaseExportHandler 创建案件移交导出处理器
+func NewCaseExportHandler(exportService *service.CaseExportAppService) *CaseExportHandler {
+	return &CaseExportHandler{exportService: exportService}
+}
+
+// RegisterRoutes 注册路由
+func (h *CaseExportHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware *middleware.AuthMiddleware) {
+	router.POST("/missing-persons/:id/export", authMiddleware.Required(), h.Export)
+
+	exports := router.Group("/case-exports")
+	exports.Use(authMiddleware.Required())
+	{
+		exports.GET("/public-key", h.PublicKey)
+		exports.POST("/verify", h.Verify)
+	}
+}
+
+// Export 导出案件移交包（签名 ZIP）
+func (h *CaseExportHandler) Export(c *gin.Context) {
+	var req dto.CaseExportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	file, err := h.exportService.Export(c.Request.Context(), c.Param("id"), &req, middleware.GetUserID(c), c.ClientIP())
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrMissingPersonNotFound):
+			response.NotFound(c, "missing person not found")
+		case errors.Is(err, service.ErrUserNotFound):
+			response.Unauthorized(c, "user not found")
+		case errors.Is(err, service.ErrExportAuditFailed):
+			response.ErrorCodeWithMessage(c, http.StatusServiceUnavailable, "export is unavailable while audit logging fails")
+		default:
+			logger.Error("Failed to export case", logger.String("id", c.Param("id")), logger.Err(err))
+			response.InternalServerError(c, "failed to export case")
+		}
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", file.FileName))
+	c.Header("X-Content-SHA256", file.SHA256)
+	c.Data(http.StatusOK, "application/zip", file.Data)
+}
+
+// PublicKey 导出包签名公钥
+func (h *CaseExportHandler) PublicKey(c *gin.Context) {
+	response.Success(c, h.exportService.PublicKey())
+}
+
+// Verify 校验导出包（multipart 文件字段为 file）
+func (h *CaseExportHandler) Verify(c *gin.Context) {
+	file, _, err := c.Request.FormFile("file")
+	if err != nil {
+		response.BadRequest(c, "file is required")
+		return
+	}
+	defer file.Close()
+
+	result, err := h.exportService.Verify(file)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrExportBundle):
+			response.BadRequest(c, "not a case export bundle")
+		case errors.Is(err, service.ErrFileTooLarge):
+			response.BadRequest(c, "file too large")
+		default:
+			logger.Error("Failed to verify export bundle", logger.Err(err))
+			response.InternalServerError(c, "failed to verify export bundle")
+		}
+		return
+	}
+
+	response.Success(c, result)
+}
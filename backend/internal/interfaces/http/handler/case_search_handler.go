@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/service"
+	"github.com/Snowitty-Re/CNtunyuan/internal/interfaces/http/middleware"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// CaseSearchHandler 案件检索索引管理处理器
+type CaseSearchHandler struct {
+	searchService *service.CaseSearchAppService
+}
+
+// NewCaseSearchHandler 创建案件检索索引管理处理器
+func NewCaseSearchHandler(searchService *service.CaseSearchAppService) *CaseSearchHandler {
+	return &CaseSearchHandler{searchService: searchService}
+}
+
+// RegisterRoutes 注册路由
+func (h *CaseSearchHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware *middleware.AuthMiddleware) {
+	index := router.Group("/search-index")
+	index.Use(authMiddleware.Required())
+	{
+		index.GET("/status", middleware.RequireManager(), h.Status)
+		index.POST("/rebuild", middleware.RequireAdmin(), h.Rebuild)
+	}
+}
+
+// Status 索引状态
+func (h *CaseSearchHandler) Status(c *gin.Context) {
+	response.Success(c, h.searchService.Status())
+}
+
+// Rebuild 后台全量重建索引
+func (h *CaseSearchHandler) Rebuild(c *gin.Context) {
+	if err := h.searchService.StartReindex(); err != nil {
+		switch {
+		case errors.Is(err, service.ErrSearchIndexDisabled):
+			response.BadRequest(c, "search index is disabled")
+		case errors.Is(err, service.ErrSearchRebuilding):
+			response.Conflict(c, "search index rebuild already in progress")
+		default:
+			response.ErrorCodeWithMessage(c, http.StatusServiceUnavailable, err.Error())
+		}
+		return
+	}
+
+	response.Success(c, h.searchService.Status())
+}
@@ -0,0 +1,234 @@
+package handler
+
+import (
+	"errors"
+	"io"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/dto"
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/service"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/Snowitty-Re/CNtunyuan/internal/interfaces/http/middleware"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/logger"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// DialectCommentHandler 方言评论处理器
+type DialectCommentHandler struct {
+	commentService *service.DialectCommentAppService
+}
+
+// NewDialectCommentHandler 创建方言评论处理器
+func NewDialectCommentHandler(commentService *service.DialectCommentAppService) *DialectCommentHandler {
+	return &DialectCommentHandler{commentService: commentService}
+}
+
+// RegisterRoutes 注册路由
+func (h *DialectCommentHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware *middleware.AuthMiddleware) {
+	dialects := router.Group("/dialects")
+	dialects.Use(authMiddleware.Required())
+	{
+		dialects.GET("/:id/comments", h.ListThreads)
+		dialects.POST("/:id/comments", h.Create)
+	}
+
+	comments := router.Group("/dialect-comments")
+	comments.Use(authMiddleware.Required())
+	{
+		comments.GET("/report-reasons", h.Reasons)
+		comments.GET("/:id/replies", h.ListReplies)
+		comments.POST("/:id/like", h.Like)
+		comments.DELETE("/:id/like", h.Unlike)
+		comments.POST("/:id/report", h.Report)
+
+		// 评论管理只对组织管理员开放
+		comments.GET("/moderation", middleware.RequireManager(), h.ListModeration)
+		comments.GET("/:id/reports", middleware.RequireManager(), h.ListReports)
+		comments.POST("/:id/hide", middleware.RequireManager(), h.Hide)
+		comments.POST("/:id/unhide", middleware.RequireManager(), h.Unhide)
+		comments.POST("/:id/dismiss", middleware.RequireManager(), h.Dismiss)
+	}
+}
+
+// Create 发表评论或回复
+func (h *DialectCommentHandler) Create(c *gin.Context) {
+	var req dto.CreateDialectCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	resp, err := h.commentService.Create(c.Request.Context(), c.Param("id"), &req, middleware.GetUserID(c))
+	if err != nil {
+		h.handleError(c, err, "failed to add comment")
+		return
+	}
+
+	response.Created(c, resp)
+}
+
+// ListThreads 分页获取主评论（含最早的几条回复）
+func (h *DialectCommentHandler) ListThreads(c *gin.Context) {
+	var req dto.DialectCommentListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	resp, err := h.commentService.ListThreads(c.Request.Context(), c.Param("id"), &req, middleware.GetUserID(c), middleware.IsAdmin(c))
+	if err != nil {
+		h.handleError(c, err, "failed to get comments")
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// ListReplies 分页获取回复
+func (h *DialectCommentHandler) ListReplies(c *gin.Context) {
+	var req dto.DialectCommentListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	resp, err := h.commentService.ListReplies(c.Request.Context(), c.Param("id"), &req, middleware.GetUserID(c), middleware.IsAdmin(c))
+	if err != nil {
+		h.handleError(c, err, "failed to get replies")
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// Like 点赞评论
+func (h *DialectCommentHandler) Like(c *gin.Context) {
+	if err := h.commentService.Like(c.Request.Context(), c.Param("id"), middleware.GetUserID(c)); err != nil {
+		h.handleError(c, err, "failed to like comment")
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+// Unlike 取消点赞
+func (h *DialectCommentHandler) Unlike(c *gin.Context) {
+	if err := h.commentService.Unlike(c.Request.Context(), c.Param("id"), middleware.GetUserID(c)); err != nil {
+		h.handleError(c, err, "failed to unlike comment")
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+// Reasons 举报原因列表
+func (h *DialectCommentHandler) Reasons(c *gin.Context) {
+	response.Success(c, h.commentService.Reasons())
+}
+
+// Report 举报评论
+func (h *DialectCommentHandler) Report(c *gin.Context) {
+	var req dto.ReportDialectCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.commentService.Report(c.Request.Context(), c.Param("id"), &req, middleware.GetUserID(c)); err != nil {
+		h.handleError(c, err, "failed to report comment")
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+// ListModeration 评论管理列表
+func (h *DialectCommentHandler) ListModeration(c *gin.Context) {
+	var req dto.DialectCommentModerationRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	resp, err := h.commentService.ListModeration(c.Request.Context(), &req, middleware.GetOrgID(c))
+	if err != nil {
+		h.handleError(c, err, "failed to list comments")
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// ListReports 评论的举报记录
+func (h *DialectCommentHandler) ListReports(c *gin.Context) {
+	resp, err := h.commentService.ListReports(c.Request.Context(), c.Param("id"), middleware.GetOrgID(c))
+	if err != nil {
+		h.handleError(c, err, "failed to list comment reports")
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// Hide 隐藏评论
+func (h *DialectCommentHandler) Hide(c *gin.Context) {
+	var req dto.HideDialectCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	resp, err := h.commentService.Hide(c.Request.Context(), c.Param("id"), &req, middleware.GetUserID(c), middleware.GetOrgID(c))
+	if err != nil {
+		h.handleError(c, err, "failed to hide comment")
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// Unhide 恢复显示评论
+func (h *DialectCommentHandler) Unhide(c *gin.Context) {
+	resp, err := h.commentService.Unhide(c.Request.Context(), c.Param("id"), middleware.GetUserID(c), middleware.GetOrgID(c))
+	if err != nil {
+		h.handleError(c, err, "failed to unhide comment")
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// Dismiss 复核保留评论并驳回举报
+func (h *DialectCommentHandler) Dismiss(c *gin.Context) {
+	resp, err := h.commentService.Dismiss(c.Request.Context(), c.Param("id"), middleware.GetUserID(c), middleware.GetOrgID(c))
+	if err != nil {
+		h.handleError(c, err, "failed to dismiss comment reports")
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// handleError 统一处理方言评论错误
+func (h *DialectCommentHandler) handleError(c *gin.Context, err error, msg string) {
+	switch {
+	case errors.Is(err, service.ErrDialectNotFound):
+		response.NotFound(c, "dialect not found")
+	case errors.Is(err, service.ErrDialectCommentNotFound):
+		response.NotFound(c, "comment not found")
+	case errors.Is(err, service.ErrDialectCommentInvalid),
+		errors.Is(err, service.ErrDialectCommentSensitive):
+		response.BadRequest(c, err.Error())
+	case errors.Is(err, service.ErrAlreadyLiked):
+		response.BadRequest(c, "already liked")
+	case errors.Is(err, service.ErrNotLiked):
+		response.BadRequest(c, "not liked")
+	case errors.Is(err, service.ErrDialectCommentReported),
+		errors.Is(err, entity.ErrDialectCommentHidden),
+		errors.Is(err, entity.ErrDialectCommentVisible):
+		response.Conflict(c, err.Error())
+	default:
+		logger.Error("Dialect comment operation failed", logger.Err(err))
+		response.InternalServerError(c, msg)
+	}
+}
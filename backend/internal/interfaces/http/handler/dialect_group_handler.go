@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"errors"
+	"io"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/dto"
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/service"
+	"github.com/Snowitty-Re/CNtunyuan/internal/interfaces/http/middleware"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/logger"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// DialectGroupHandler 方言分类处理器
+type DialectGroupHandler struct {
+	groupService *service.DialectGroupAppService
+}
+
+// NewDialectGroupHandler 创建方言分类处理器
+func NewDialectGroupHandler(groupService *service.DialectGroupAppService) *DialectGroupHandler {
+	return &DialectGroupHandler{groupService: groupService}
+}
+
+// RegisterRoutes 注册路由
+func (h *DialectGroupHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware *middleware.AuthMiddleware) {
+	groups := router.Group("/dialect-groups")
+	groups.Use(authMiddleware.Required())
+	{
+		groups.GET("", h.Tree)
+		groups.GET("/by-region", h.FindByRegion)
+		groups.GET("/:id", h.GetByID)
+
+		// 分类由管理员维护
+		groups.POST("", middleware.RequireAdmin(), h.Create)
+		groups.PUT("/:id", middleware.RequireAdmin(), h.Update)
+		groups.DELETE("/:id", middleware.RequireAdmin(), h.Delete)
+		groups.POST("/migrate", middleware.RequireAdmin(), h.Migrate)
+	}
+}
+
+// Tree 获取方言分类树
+func (h *DialectGroupHandler) Tree(c *gin.Context) {
+	resp, err := h.groupService.Tree(c.Request.Context())
+	if err != nil {
+		logger.Error("Failed to get dialect groups", logger.Err(err))
+		response.InternalServerError(c, "failed to get dialect groups")
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// FindByRegion 查找对应省市的方言分类
+func (h *DialectGroupHandler) FindByRegion(c *gin.Context) {
+	province := c.Query("province")
+	if province == "" {
+		response.BadRequest(c, "province is required")
+		return
+	}
+
+	resp, err := h.groupService.FindByRegion(c.Request.Context(), province, c.Query("city"))
+	if err != nil {
+		logger.Error("Failed to find dialect groups by region", logger.Err(err))
+		response.InternalServerError(c, "failed to get dialect groups")
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// GetByID 获取方言分类详情
+func (h *DialectGroupHandler) GetByID(c *gin.Context) {
+	resp, err := h.groupService.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.handleError(c, err, "failed to get dialect group")
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// Create 创建方言分类
+func (h *DialectGroupHandler) Create(c *gin.Context) {
+	var req dto.DialectGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	resp, err := h.groupService.Create(c.Request.Context(), &req, middleware.GetUserID(c), middleware.GetOrgID(c))
+	if err != nil {
+		h.handleError(c, err, "failed to create dialect group")
+		return
+	}
+
+	response.Created(c, resp)
+}
+
+// Update 修改方言分类
+func (h *DialectGroupHandler) Update(c *gin.Context) {
+	var req dto.DialectGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	resp, err := h.groupService.Update(c.Request.Context(), c.Param("id"), &req, middleware.GetUserID(c), middleware.GetOrgID(c))
+	if err != nil {
+		h.handleError(c, err, "failed to update dialect group")
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// Delete 删除方言分类
+func (h *DialectGroupHandler) Delete(c *gin.Context) {
+	if err := h.groupService.Delete(c.Request.Context(), c.Param("id"), middleware.GetUserID(c), middleware.GetOrgID(c)); err != nil {
+		h.handleError(c, err, "failed to delete dialect group")
+		return
+	}
+
+	response.NoContent(c)
+}
+
+// Migrate 将历史方言按地区文本归入方言分类，dry_run 时只预览匹配结果
+func (h *DialectGroupHandler) Migrate(c *gin.Context) {
+	var req dto.MigrateDialectGroupsRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	resp, err := h.groupService.MigrateLegacy(c.Request.Context(), req.DryRun, middleware.GetUserID(c), middleware.GetOrgID(c))
+	if err != nil {
+		logger.Error("Failed to migrate legacy dialects", logger.Err(err))
+		response.InternalServerError(c, "failed to migrate legacy dialects")
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// handleError 统一处理方言分类错误
+func (h *DialectGroupHandler) handleError(c *gin.Context, err error, msg string) {
+	switch {
+	case errors.Is(err, service.ErrDialectGroupNotFound):
+		response.NotFound(c, "dialect group not found")
+	case errors.Is(err, service.ErrDialectGroupInvalid):
+		response.BadRequest(c, err.Error())
+	case errors.Is(err, service.ErrDialectGroupExists), errors.Is(err, service.ErrDialectGroupInUse):
+		response.Conflict(c, err.Error())
+	default:
+		logger.Error("Dialect group operation failed", logger.Err(err))
+		response.InternalServerError(c, msg)
+	}
+}
@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"errors"
+	"io"
 	"strconv"
 
 	"github.com/Snowitty-Re/CNtunyuan/internal/application/dto"
@@ -13,12 +15,13 @@ import (
 
 // DialectHandler 方言处理器
 type DialectHandler struct {
-	dialectService *service.DialectAppService
+	dialectService   *service.DialectAppService
+	analyticsService *service.DialectAnalyticsAppService
 }
 
 // NewDialectHandler 创建方言处理器
-func NewDialectHandler(dialectService *service.DialectAppService) *DialectHandler {
-	return &DialectHandler{dialectService: dialectService}
+func NewDialectHandler(dialectService *service.DialectAppService, analyticsService *service.DialectAnalyticsAppService) *DialectHandler {
+	return &DialectHandler{dialectService: dialectService, analyticsService: analyticsService}
 }
 
 // RegisterRoutes 注册路由
@@ -30,18 +33,19 @@ func (h *DialectHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware
 		dialects.GET("/featured", authMiddleware.Required(), h.GetFeatured)
 		dialects.GET("/stats", authMiddleware.Required(), h.GetStats)
 		dialects.GET("/:id", authMiddleware.Required(), h.GetByID)
-		dialects.GET("/:id/comments", authMiddleware.Required(), h.GetComments)
+		dialects.GET("/:id/similar", authMiddleware.Required(), h.FindSimilar)
 		dialects.POST("/:id/play", authMiddleware.Required(), h.Play)
 		dialects.POST("/:id/like", authMiddleware.Required(), h.Like)
 		dialects.DELETE("/:id/like", authMiddleware.Required(), h.Unlike)
-		dialects.POST("/:id/comments", authMiddleware.Required(), h.AddComment)
 
 		// 需要上传权限
+		dialects.POST("/audio", authMiddleware.Required(), h.UploadAudio)
 		dialects.POST("", authMiddleware.Required(), h.Create)
 		dialects.PUT("/:id", authMiddleware.Required(), h.Update)
 		dialects.DELETE("/:id", authMiddleware.Required(), h.Delete)
 
 		// 需要管理员权限
+		dialects.GET("/analytics", authMiddleware.Required(), middleware.RequireManager(), h.Analytics)
 		dialects.PUT("/:id/status", middleware.RequireManager(), h.UpdateStatus)
 		dialects.POST("/:id/feature", middleware.RequireAdmin(), h.Feature)
 		dialects.DELETE("/:id/feature", middleware.RequireAdmin(), h.Unfeature)
@@ -61,14 +65,51 @@ func (h *DialectHandler) Create(c *gin.Context) {
 
 	dialect, err := h.dialectService.Create(c.Request.Context(), &req, userID, orgID)
 	if err != nil {
-		logger.Error("Failed to create dialect", logger.Err(err))
-		response.InternalServerError(c, "failed to create dialect")
+		switch {
+		case errors.Is(err, service.ErrFileNotFound):
+			response.NotFound(c, "audio file not found")
+		case errors.Is(err, service.ErrDialectGroupNotFound):
+			response.BadRequest(c, "dialect group not found")
+		case errors.Is(err, service.ErrInvalidAudio), errors.Is(err, service.ErrDialectInvalid):
+			response.BadRequest(c, err.Error())
+		default:
+			logger.Error("Failed to create dialect", logger.Err(err))
+			response.InternalServerError(c, "failed to create dialect")
+		}
 		return
 	}
 
 	response.Created(c, dialect)
 }
 
+// UploadAudio 上传方言音频，返回服务端探测的时长、格式及播放地址、波形地址
+func (h *DialectHandler) UploadAudio(c *gin.Context) {
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		response.BadRequest(c, "file is required")
+		return
+	}
+	defer file.Close()
+
+	resp, err := h.dialectService.UploadAudio(c.Request.Context(), file, header, middleware.GetUserID(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrFileTooLarge):
+			response.BadRequest(c, "file too large")
+		case errors.Is(err, service.ErrInvalidAudio):
+			response.BadRequest(c, "unrecognized audio, supported formats: wav, mp3, aac, m4a, amr")
+		case errors.Is(err, service.ErrAudioTooLong), errors.Is(err, service.ErrDialectInvalid):
+			response.BadRequest(c, err.Error())
+		default:
+			logger.Error("Failed to upload dialect audio", logger.Err(err))
+			response.InternalServerError(c, "failed to upload audio")
+		}
+		return
+	}
+
+	response.Created(c, resp)
+}
+
 // GetByID 获取详情
 func (h *DialectHandler) GetByID(c *gin.Context) {
 	id := c.Param("id")
@@ -90,6 +131,28 @@ func (h *DialectHandler) GetByID(c *gin.Context) {
 	response.Success(c, dialect)
 }
 
+// FindSimilar 查找声纹相似（疑似同一段录音）的方言
+func (h *DialectHandler) FindSimilar(c *gin.Context) {
+	var req dto.SimilarDialectRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	list, err := h.dialectService.FindSimilar(c.Request.Context(), c.Param("id"), &req)
+	if err != nil {
+		if errors.Is(err, service.ErrDialectNotFound) {
+			response.NotFound(c, "dialect not found")
+			return
+		}
+		logger.Error("Failed to find similar dialects", logger.Err(err))
+		response.InternalServerError(c, "failed to find similar dialects")
+		return
+	}
+
+	response.Success(c, list)
+}
+
 // List 列表查询
 func (h *DialectHandler) List(c *gin.Context) {
 	var req dto.DialectListRequest
@@ -100,7 +163,12 @@ func (h *DialectHandler) List(c *gin.Context) {
 
 	dialects, err := h.dialectService.List(c.Request.Context(), &req)
 	if err != nil {
-		response.InternalServerError(c, "failed to get list")
+		switch {
+		case errors.Is(err, service.ErrDialectGroupNotFound):
+			response.BadRequest(c, "dialect group not found")
+		default:
+			response.InternalServerError(c, "failed to get list")
+		}
 		return
 	}
 
@@ -123,9 +191,11 @@ func (h *DialectHandler) Update(c *gin.Context) {
 
 	dialect, err := h.dialectService.Update(c.Request.Context(), id, &req)
 	if err != nil {
-		switch err {
-		case service.ErrDialectNotFound:
+		switch {
+		case errors.Is(err, service.ErrDialectNotFound):
 			response.NotFound(c, "dialect not found")
+		case errors.Is(err, service.ErrDialectGroupNotFound):
+			response.BadRequest(c, "dialect group not found")
 		default:
 			logger.Error("Failed to update dialect", logger.Err(err))
 			response.InternalServerError(c, "failed to update")
@@ -230,12 +300,25 @@ func (h *DialectHandler) Play(c *gin.Context) {
 		return
 	}
 
-	if err := h.dialectService.IncrementPlayCount(c.Request.Context(), id); err != nil {
+	// 播放时长可选，未上报时只计播放次数
+	var req dto.DialectPlayRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	resp, err := h.dialectService.RecordPlay(c.Request.Context(), id, &req, middleware.GetUserID(c), c.ClientIP(), c.Request.UserAgent())
+	if err != nil {
+		if errors.Is(err, service.ErrDialectNotFound) {
+			response.NotFound(c, "dialect not found")
+			return
+		}
+		logger.Error("Failed to record dialect play", logger.String("dialect_id", id), logger.Err(err))
 		response.InternalServerError(c, "failed to record play")
 		return
 	}
 
-	response.Success(c, nil)
+	response.Success(c, resp)
 }
 
 // Like 点赞
@@ -288,56 +371,6 @@ func (h *DialectHandler) Unlike(c *gin.Context) {
 	response.Success(c, nil)
 }
 
-// AddComment 添加评论
-func (h *DialectHandler) AddComment(c *gin.Context) {
-	id := c.Param("id")
-	if id == "" {
-		response.BadRequest(c, "dialect id is required")
-		return
-	}
-
-	var req dto.CreateDialectCommentRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, err.Error())
-		return
-	}
-
-	userID := middleware.GetUserID(c)
-
-	comment, err := h.dialectService.AddComment(c.Request.Context(), id, &req, userID)
-	if err != nil {
-		switch err {
-		case service.ErrDialectNotFound:
-			response.NotFound(c, "dialect not found")
-		default:
-			response.InternalServerError(c, "failed to add comment")
-		}
-		return
-	}
-
-	response.Created(c, comment)
-}
-
-// GetComments 获取评论
-func (h *DialectHandler) GetComments(c *gin.Context) {
-	id := c.Param("id")
-	if id == "" {
-		response.BadRequest(c, "dialect id is required")
-		return
-	}
-
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
-
-	comments, err := h.dialectService.GetComments(c.Request.Context(), id, page, pageSize)
-	if err != nil {
-		response.InternalServerError(c, "failed to get comments")
-		return
-	}
-
-	response.Success(c, comments)
-}
-
 // GetFeatured 获取精选
 func (h *DialectHandler) GetFeatured(c *gin.Context) {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
@@ -362,3 +395,30 @@ func (h *DialectHandler) GetStats(c *gin.Context) {
 
 	response.Success(c, stats)
 }
+
+// Analytics 方言播放统计，超级管理员可查看其他组织
+func (h *DialectHandler) Analytics(c *gin.Context) {
+	var req dto.DialectAnalyticsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	orgID := middleware.GetOrgID(c)
+	if req.OrgID != "" && middleware.IsSuperAdmin(c) {
+		orgID = req.OrgID
+	}
+
+	resp, err := h.analyticsService.Analytics(c.Request.Context(), &req, orgID)
+	if err != nil {
+		if errors.Is(err, service.ErrDialectAnalyticsRange) {
+			response.BadRequest(c, err.Error())
+			return
+		}
+		logger.Error("Failed to get dialect analytics", logger.Err(err))
+		response.InternalServerError(c, "failed to get dialect analytics")
+		return
+	}
+
+	response.Success(c, resp)
+}
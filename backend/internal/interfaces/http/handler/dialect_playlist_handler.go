@@ -0,0 +1,264 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/dto"
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/service"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/Snowitty-Re/CNtunyuan/internal/interfaces/http/middleware"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/logger"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// DialectPlaylistHandler 方言歌单处理器
+type DialectPlaylistHandler struct {
+	playlistService *service.DialectPlaylistAppService
+}
+
+// NewDialectPlaylistHandler 创建方言歌单处理器
+func NewDialectPlaylistHandler(playlistService *service.DialectPlaylistAppService) *DialectPlaylistHandler {
+	return &DialectPlaylistHandler{playlistService: playlistService}
+}
+
+// RegisterRoutes 注册路由
+func (h *DialectPlaylistHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware *middleware.AuthMiddleware) {
+	playlists := router.Group("/dialect-playlists")
+	playlists.Use(authMiddleware.Required())
+	{
+		playlists.GET("", h.List)
+		playlists.POST("", h.Create)
+		playlists.GET("/:id", h.Get)
+		playlists.PUT("/:id", h.Update)
+		playlists.DELETE("/:id", h.Delete)
+		playlists.GET("/:id/export", h.Export)
+
+		playlists.POST("/:id/items", h.AddItem)
+		playlists.DELETE("/:id/items/:itemId", h.RemoveItem)
+		playlists.PUT("/:id/items/order", h.Reorder)
+
+		playlists.POST("/:id/collaborators", h.AddCollaborator)
+		playlists.DELETE("/:id/collaborators/:userId", h.RemoveCollaborator)
+
+		// 省份精选合集由组织管理员创建
+		playlists.POST("/collections", middleware.RequireManager(), h.CreateCollection)
+		playlists.POST("/:id/refresh", h.Refresh)
+	}
+}
+
+// Create 创建歌单
+func (h *DialectPlaylistHandler) Create(c *gin.Context) {
+	var req dto.CreateDialectPlaylistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	resp, err := h.playlistService.Create(c.Request.Context(), &req, middleware.GetUserID(c))
+	if err != nil {
+		h.handleError(c, err, "failed to create playlist")
+		return
+	}
+
+	response.Created(c, resp)
+}
+
+// CreateCollection 创建省份精选合集
+func (h *DialectPlaylistHandler) CreateCollection(c *gin.Context) {
+	var req dto.CreateDialectCollectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	resp, err := h.playlistService.CreateCollection(c.Request.Context(), &req, middleware.GetUserID(c))
+	if err != nil {
+		h.handleError(c, err, "failed to create collection")
+		return
+	}
+
+	response.Created(c, resp)
+}
+
+// List 歌单列表
+func (h *DialectPlaylistHandler) List(c *gin.Context) {
+	var req dto.DialectPlaylistListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	resp, err := h.playlistService.List(c.Request.Context(), &req, middleware.GetUserID(c))
+	if err != nil {
+		h.handleError(c, err, "failed to list playlists")
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// Get 歌单详情
+func (h *DialectPlaylistHandler) Get(c *gin.Context) {
+	resp, err := h.playlistService.Get(c.Request.Context(), c.Param("id"), middleware.GetUserID(c))
+	if err != nil {
+		h.handleError(c, err, "failed to get playlist")
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// Update 修改歌单
+func (h *DialectPlaylistHandler) Update(c *gin.Context) {
+	var req dto.UpdateDialectPlaylistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	resp, err := h.playlistService.Update(c.Request.Context(), c.Param("id"), &req, middleware.GetUserID(c))
+	if err != nil {
+		h.handleError(c, err, "failed to update playlist")
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// Delete 删除歌单
+func (h *DialectPlaylistHandler) Delete(c *gin.Context) {
+	if err := h.playlistService.Delete(c.Request.Context(), c.Param("id"), middleware.GetUserID(c)); err != nil {
+		h.handleError(c, err, "failed to delete playlist")
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+// AddItem 添加片段
+func (h *DialectPlaylistHandler) AddItem(c *gin.Context) {
+	var req dto.AddDialectPlaylistItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	resp, err := h.playlistService.AddItem(c.Request.Context(), c.Param("id"), &req, middleware.GetUserID(c))
+	if err != nil {
+		h.handleError(c, err, "failed to add playlist item")
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// RemoveItem 移除片段
+func (h *DialectPlaylistHandler) RemoveItem(c *gin.Context) {
+	resp, err := h.playlistService.RemoveItem(c.Request.Context(), c.Param("id"), c.Param("itemId"), middleware.GetUserID(c))
+	if err != nil {
+		h.handleError(c, err, "failed to remove playlist item")
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// Reorder 调整片段顺序
+func (h *DialectPlaylistHandler) Reorder(c *gin.Context) {
+	var req dto.ReorderDialectPlaylistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	resp, err := h.playlistService.Reorder(c.Request.Context(), c.Param("id"), &req, middleware.GetUserID(c))
+	if err != nil {
+		h.handleError(c, err, "failed to reorder playlist")
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// AddCollaborator 添加协作者
+func (h *DialectPlaylistHandler) AddCollaborator(c *gin.Context) {
+	var req dto.AddDialectPlaylistCollaboratorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	resp, err := h.playlistService.AddCollaborator(c.Request.Context(), c.Param("id"), &req, middleware.GetUserID(c))
+	if err != nil {
+		h.handleError(c, err, "failed to add collaborator")
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// RemoveCollaborator 移除协作者或退出协作
+func (h *DialectPlaylistHandler) RemoveCollaborator(c *gin.Context) {
+	resp, err := h.playlistService.RemoveCollaborator(c.Request.Context(), c.Param("id"), c.Param("userId"), middleware.GetUserID(c))
+	if err != nil {
+		h.handleError(c, err, "failed to remove collaborator")
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// Refresh 重新生成自动合集
+func (h *DialectPlaylistHandler) Refresh(c *gin.Context) {
+	resp, err := h.playlistService.Refresh(c.Request.Context(), c.Param("id"), middleware.GetUserID(c))
+	if err != nil {
+		h.handleError(c, err, "failed to refresh collection")
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// Export 下载离线包（签名 ZIP）
+func (h *DialectPlaylistHandler) Export(c *gin.Context) {
+	file, err := h.playlistService.Export(c.Request.Context(), c.Param("id"), middleware.GetUserID(c), c.ClientIP())
+	if err != nil {
+		h.handleError(c, err, "failed to export playlist")
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", file.FileName))
+	c.Header("X-Content-SHA256", file.SHA256)
+	c.Data(http.StatusOK, "application/zip", file.Data)
+}
+
+// handleError 统一处理方言歌单错误
+func (h *DialectPlaylistHandler) handleError(c *gin.Context, err error, msg string) {
+	switch {
+	case errors.Is(err, service.ErrDialectPlaylistNotFound):
+		response.NotFound(c, "playlist not found")
+	case errors.Is(err, service.ErrDialectPlaylistItemNotFound):
+		response.NotFound(c, "playlist item not found")
+	case errors.Is(err, service.ErrDialectNotFound):
+		response.NotFound(c, "dialect not found")
+	case errors.Is(err, service.ErrUserNotFound):
+		response.NotFound(c, "user not found")
+	case errors.Is(err, service.ErrDialectPlaylistForbidden):
+		response.Forbidden(c, "no permission on this playlist")
+	case errors.Is(err, service.ErrDialectPlaylistInvalid),
+		errors.Is(err, service.ErrDialectPlaylistCollaborator),
+		errors.Is(err, service.ErrDialectPlaylistEmpty),
+		errors.Is(err, entity.ErrDialectPlaylistAuto),
+		errors.Is(err, entity.ErrDialectPlaylistFull):
+		response.BadRequest(c, err.Error())
+	case errors.Is(err, service.ErrDialectPlaylistItemExists),
+		errors.Is(err, entity.ErrDialectPlaylistStale):
+		response.Conflict(c, err.Error())
+	default:
+		logger.Error("Dialect playlist operation failed", logger.Err(err))
+		response.InternalServerError(c, msg)
+	}
+}
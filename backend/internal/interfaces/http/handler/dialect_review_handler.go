@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"errors"
+	"io"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/dto"
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/service"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/Snowitty-Re/CNtunyuan/internal/interfaces/http/middleware"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/logger"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// DialectReviewHandler 方言审核处理器
+type DialectReviewHandler struct {
+	reviewService *service.DialectReviewAppService
+}
+
+// NewDialectReviewHandler 创建方言审核处理器
+func NewDialectReviewHandler(reviewService *service.DialectReviewAppService) *DialectReviewHandler {
+	return &DialectReviewHandler{reviewService: reviewService}
+}
+
+// RegisterRoutes 注册路由，审核队列只对组织管理员开放
+func (h *DialectReviewHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware *middleware.AuthMiddleware) {
+	reviews := router.Group("/dialect-reviews")
+	reviews.Use(authMiddleware.Required(), middleware.RequireManager())
+	{
+		reviews.GET("", h.List)
+		reviews.GET("/reasons", h.Reasons)
+		reviews.GET("/stats", h.Stats)
+		reviews.POST("/claim-next", h.ClaimNext)
+		reviews.GET("/:id", h.GetByID)
+		reviews.POST("/:id/claim", h.Claim)
+		reviews.POST("/:id/release", h.Release)
+		reviews.POST("/:id/approve", h.Approve)
+		reviews.POST("/:id/reject", h.Reject)
+	}
+}
+
+// List 查询组织的审核队列
+func (h *DialectReviewHandler) List(c *gin.Context) {
+	var req dto.DialectReviewListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	resp, err := h.reviewService.List(c.Request.Context(), &req, middleware.GetUserID(c), middleware.GetOrgID(c))
+	if err != nil {
+		h.handleError(c, err, "failed to list dialect reviews")
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// Reasons 驳回原因列表
+func (h *DialectReviewHandler) Reasons(c *gin.Context) {
+	response.Success(c, h.reviewService.Reasons())
+}
+
+// Stats 审核队列及审核员统计
+func (h *DialectReviewHandler) Stats(c *gin.Context) {
+	var req dto.DialectReviewStatsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	resp, err := h.reviewService.Stats(c.Request.Context(), &req, middleware.GetOrgID(c))
+	if err != nil {
+		h.handleError(c, err, "failed to get dialect review stats")
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// GetByID 获取审核详情
+func (h *DialectReviewHandler) GetByID(c *gin.Context) {
+	resp, err := h.reviewService.GetByID(c.Request.Context(), c.Param("id"), middleware.GetOrgID(c))
+	if err != nil {
+		h.handleError(c, err, "failed to get dialect review")
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// ClaimNext 认领队列中的下一条
+func (h *DialectReviewHandler) ClaimNext(c *gin.Context) {
+	resp, err := h.reviewService.ClaimNext(c.Request.Context(), middleware.GetUserID(c), middleware.GetOrgID(c))
+	if err != nil {
+		h.handleError(c, err, "failed to claim dialect review")
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// Claim 认领审核
+func (h *DialectReviewHandler) Claim(c *gin.Context) {
+	resp, err := h.reviewService.Claim(c.Request.Context(), c.Param("id"), middleware.GetUserID(c), middleware.GetOrgID(c))
+	if err != nil {
+		h.handleError(c, err, "failed to claim dialect review")
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// Release 放弃认领，管理员可释放其他审核员的认领
+func (h *DialectReviewHandler) Release(c *gin.Context) {
+	resp, err := h.reviewService.Release(c.Request.Context(), c.Param("id"), middleware.GetUserID(c), middleware.GetOrgID(c), middleware.IsAdmin(c))
+	if err != nil {
+		h.handleError(c, err, "failed to release dialect review")
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// Approve 审核通过
+func (h *DialectReviewHandler) Approve(c *gin.Context) {
+	var req dto.ApproveDialectReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	resp, err := h.reviewService.Approve(c.Request.Context(), c.Param("id"), &req, middleware.GetUserID(c), middleware.GetOrgID(c))
+	if err != nil {
+		h.handleError(c, err, "failed to approve dialect")
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// Reject 审核驳回
+func (h *DialectReviewHandler) Reject(c *gin.Context) {
+	var req dto.RejectDialectReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	resp, err := h.reviewService.Reject(c.Request.Context(), c.Param("id"), &req, middleware.GetUserID(c), middleware.GetOrgID(c))
+	if err != nil {
+		h.handleError(c, err, "failed to reject dialect")
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// handleError 统一处理方言审核错误
+func (h *DialectReviewHandler) handleError(c *gin.Context, err error, msg string) {
+	switch {
+	case errors.Is(err, service.ErrDialectReviewNotFound):
+		response.NotFound(c, "dialect review not found")
+	case errors.Is(err, service.ErrDialectReviewQueueEmpty):
+		response.NotFound(c, "no dialect review to claim")
+	case errors.Is(err, entity.ErrDialectReviewReasonNeeded):
+		response.BadRequest(c, err.Error())
+	case errors.Is(err, entity.ErrDialectReviewClosed),
+		errors.Is(err, entity.ErrDialectReviewClaimed),
+		errors.Is(err, entity.ErrDialectReviewNotClaimed),
+		errors.Is(err, entity.ErrDialectReviewDuplicate):
+		response.Conflict(c, err.Error())
+	default:
+		logger.Error("Dialect review operation failed", logger.Err(err))
+		response.InternalServerError(c, msg)
+	}
+}
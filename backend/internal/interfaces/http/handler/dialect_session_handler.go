@@ -0,0 +1,201 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/dto"
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/service"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/Snowitty-Re/CNtunyuan/internal/interfaces/http/middleware"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/logger"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// DialectSessionHandler 方言辨识处理器
+type DialectSessionHandler struct {
+	sessionService *service.DialectSessionAppService
+}
+
+// NewDialectSessionHandler 创建方言辨识处理器
+func NewDialectSessionHandler(sessionService *service.DialectSessionAppService) *DialectSessionHandler {
+	return &DialectSessionHandler{sessionService: sessionService}
+}
+
+// RegisterRoutes 注册路由
+func (h *DialectSessionHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware *middleware.AuthMiddleware) {
+	router.GET("/missing-persons/:id/dialect-sessions", authMiddleware.Required(), h.ListByCase)
+
+	sessions := router.Group("/dialect-sessions")
+	sessions.Use(authMiddleware.Required())
+	{
+		sessions.POST("", h.Start)
+		sessions.GET("", h.List)
+		sessions.GET("/:id", h.GetByID)
+		sessions.POST("/:id/answers", h.Answer)
+		sessions.PUT("/:id/scope", h.Narrow)
+		sessions.POST("/:id/complete", h.Complete)
+		sessions.POST("/:id/cancel", h.Cancel)
+		sessions.POST("/:id/attach", h.Attach)
+	}
+}
+
+// Start 开始方言辨识
+func (h *DialectSessionHandler) Start(c *gin.Context) {
+	var req dto.StartDialectSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	resp, err := h.sessionService.Start(c.Request.Context(), &req, middleware.GetUserID(c), middleware.GetOrgID(c))
+	if err != nil {
+		respondDialectSessionError(c, err)
+		return
+	}
+
+	response.Created(c, resp)
+}
+
+// List 查询方言辨识会话
+func (h *DialectSessionHandler) List(c *gin.Context) {
+	var req dto.DialectSessionListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	resp, err := h.sessionService.List(c.Request.Context(), &req, middleware.GetUserID(c))
+	if err != nil {
+		respondDialectSessionError(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// GetByID 获取辨识会话详情及下一批推荐片段
+func (h *DialectSessionHandler) GetByID(c *gin.Context) {
+	resp, err := h.sessionService.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondDialectSessionError(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// Answer 记录受测者反应
+func (h *DialectSessionHandler) Answer(c *gin.Context) {
+	var req dto.DialectAnswerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	resp, err := h.sessionService.Answer(c.Request.Context(), c.Param("id"), &req)
+	if err != nil {
+		respondDialectSessionError(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// Narrow 手动调整辨识范围
+func (h *DialectSessionHandler) Narrow(c *gin.Context) {
+	var req dto.NarrowDialectSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	resp, err := h.sessionService.Narrow(c.Request.Context(), c.Param("id"), &req)
+	if err != nil {
+		respondDialectSessionError(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// Complete 完成辨识
+func (h *DialectSessionHandler) Complete(c *gin.Context) {
+	var req dto.CompleteDialectSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	resp, err := h.sessionService.Complete(c.Request.Context(), c.Param("id"), &req, middleware.GetUserID(c), middleware.GetOrgID(c))
+	if err != nil {
+		respondDialectSessionError(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// Cancel 取消辨识
+func (h *DialectSessionHandler) Cancel(c *gin.Context) {
+	resp, err := h.sessionService.Cancel(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondDialectSessionError(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// Attach 将辨识结果关联到案件
+func (h *DialectSessionHandler) Attach(c *gin.Context) {
+	var req dto.AttachDialectSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	resp, err := h.sessionService.Attach(c.Request.Context(), c.Param("id"), &req, middleware.GetUserID(c), middleware.GetOrgID(c))
+	if err != nil {
+		respondDialectSessionError(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// ListByCase 获取关联到案件的辨识会话
+func (h *DialectSessionHandler) ListByCase(c *gin.Context) {
+	var req dto.DialectSessionListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	resp, err := h.sessionService.ListByCase(c.Request.Context(), c.Param("id"), &req)
+	if err != nil {
+		respondDialectSessionError(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// respondDialectSessionError 方言辨识操作错误响应
+func respondDialectSessionError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrDialectSessionNotFound):
+		response.NotFound(c, "dialect session not found")
+	case errors.Is(err, service.ErrDialectNotFound):
+		response.NotFound(c, "dialect not found")
+	case errors.Is(err, service.ErrMissingPersonNotFound):
+		response.NotFound(c, "missing person not found")
+	case errors.Is(err, entity.ErrDialectSessionClosed), errors.Is(err, entity.ErrDialectSessionAttached):
+		response.Conflict(c, err.Error())
+	case errors.Is(err, service.ErrDialectSessionInvalid):
+		response.BadRequest(c, err.Error())
+	default:
+		logger.Error("Dialect session operation failed", logger.Err(err))
+		response.InternalServerError(c, "dialect session operation failed")
+	}
+}
@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/dto"
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/service"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/Snowitty-Re/CNtunyuan/internal/interfaces/http/middleware"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/logger"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// DNASampleHandler DNA / 血样登记处理器
+type DNASampleHandler struct {
+	sampleService *service.DNASampleAppService
+}
+
+// NewDNASampleHandler 创建 DNA 样本处理器
+func NewDNASampleHandler(sampleService *service.DNASampleAppService) *DNASampleHandler {
+	return &DNASampleHandler{sampleService: sampleService}
+}
+
+// RegisterRoutes 注册路由
+func (h *DNASampleHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware *middleware.AuthMiddleware) {
+	router.POST("/missing-persons/:id/dna-samples", authMiddleware.Required(), h.Create)
+	router.GET("/missing-persons/:id/dna-samples", authMiddleware.Required(), h.ListByCase)
+
+	samples := router.Group("/dna-samples")
+	samples.Use(authMiddleware.Required())
+	{
+		samples.GET("", h.List)
+		samples.GET("/:id", h.GetByID)
+		samples.PUT("/:id", middleware.RequireManager(), h.Update)
+		samples.POST("/:id/custody", h.RecordCustody)
+		samples.GET("/:id/custody-logs", h.GetCustodyLogs)
+	}
+}
+
+// Create 登记样本
+func (h *DNASampleHandler) Create(c *gin.Context) {
+	var req dto.CreateDNASampleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	resp, err := h.sampleService.Create(c.Request.Context(), c.Param("id"), &req, middleware.GetUserID(c))
+	if err != nil {
+		respondDNASampleError(c, err)
+		return
+	}
+
+	response.Created(c, resp)
+}
+
+// ListByCase 查询案件的样本
+func (h *DNASampleHandler) ListByCase(c *gin.Context) {
+	h.list(c, c.Param("id"))
+}
+
+// List 查询样本
+func (h *DNASampleHandler) List(c *gin.Context) {
+	h.list(c, "")
+}
+
+// list 查询样本，personID 为空时不限案件
+func (h *DNASampleHandler) list(c *gin.Context, personID string) {
+	var req dto.DNASampleListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	resp, err := h.sampleService.List(c.Request.Context(), personID, &req, middleware.GetUserID(c))
+	if err != nil {
+		respondDNASampleError(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// GetByID 查看样本
+func (h *DNASampleHandler) GetByID(c *gin.Context) {
+	resp, err := h.sampleService.GetByID(c.Request.Context(), c.Param("id"), middleware.GetUserID(c))
+	if err != nil {
+		respondDNASampleError(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// Update 修改样本采集信息
+func (h *DNASampleHandler) Update(c *gin.Context) {
+	var req dto.UpdateDNASampleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	resp, err := h.sampleService.Update(c.Request.Context(), c.Param("id"), &req, middleware.GetUserID(c))
+	if err != nil {
+		respondDNASampleError(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// RecordCustody 登记保管链事件
+func (h *DNASampleHandler) RecordCustody(c *gin.Context) {
+	var req dto.DNASampleCustodyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	resp, err := h.sampleService.RecordCustody(c.Request.Context(), c.Param("id"), &req, middleware.GetUserID(c))
+	if err != nil {
+		respondDNASampleError(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// GetCustodyLogs 获取样本保管链记录
+func (h *DNASampleHandler) GetCustodyLogs(c *gin.Context) {
+	resp, err := h.sampleService.GetCustodyLogs(c.Request.Context(), c.Param("id"), middleware.GetUserID(c))
+	if err != nil {
+		respondDNASampleError(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// respondDNASampleError 样本操作错误响应
+func respondDNASampleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrDNASampleNotFound):
+		response.NotFound(c, "dna sample not found")
+	case errors.Is(err, service.ErrMissingPersonNotFound):
+		response.NotFound(c, "missing person not found")
+	case errors.Is(err, service.ErrUserNotFound):
+		response.Unauthorized(c, "user not found")
+	case errors.Is(err, service.ErrDNASampleForbidden), errors.Is(err, service.ErrDNASampleFieldForbidden):
+		response.Forbidden(c, err.Error())
+	case errors.Is(err, service.ErrDNASampleExists), errors.Is(err, entity.ErrDNASampleTransitionNotAllowed):
+		response.Conflict(c, err.Error())
+	case errors.Is(err, service.ErrDNASampleInvalid):
+		response.BadRequest(c, err.Error())
+	case errors.Is(err, entity.ErrGeneticDataNotAllowed):
+		response.BadRequest(c, "raw genetic data (STR profiles) must not be stored; record only sample and document references")
+	default:
+		logger.Error("DNA sample operation failed", logger.Err(err))
+		response.InternalServerError(c, "dna sample operation failed")
+	}
+}
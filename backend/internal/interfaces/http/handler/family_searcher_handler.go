@@ -0,0 +1,206 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/dto"
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/service"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/Snowitty-Re/CNtunyuan/internal/interfaces/http/middleware"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/logger"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// FamilySearcherHandler 寻亲者处理器
+type FamilySearcherHandler struct {
+	searcherService *service.FamilySearcherAppService
+}
+
+// NewFamilySearcherHandler 创建寻亲者处理器
+func NewFamilySearcherHandler(searcherService *service.FamilySearcherAppService) *FamilySearcherHandler {
+	return &FamilySearcherHandler{searcherService: searcherService}
+}
+
+// RegisterRoutes 注册路由
+func (h *FamilySearcherHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware *middleware.AuthMiddleware) {
+	router.GET("/missing-persons/:id/searchers", authMiddleware.Required(), h.ListByCase)
+	router.GET("/missing-persons/:id/searcher-matches", authMiddleware.Required(), h.FindCaseMatches)
+
+	searchers := router.Group("/family-searchers")
+	searchers.Use(authMiddleware.Required())
+	{
+		searchers.POST("", h.Create)
+		searchers.GET("", h.List)
+		searchers.GET("/:id", h.GetByID)
+		searchers.PUT("/:id", h.Update)
+		searchers.DELETE("/:id", middleware.RequireManager(), h.Delete)
+		searchers.GET("/:id/graph", h.Graph)
+		searchers.GET("/:id/matches", h.FindMatches)
+		searchers.POST("/:id/cases", middleware.RequireManager(), h.LinkCase)
+		searchers.DELETE("/:id/cases/:caseId", middleware.RequireManager(), h.UnlinkCase)
+	}
+}
+
+// Create 登记寻亲者
+func (h *FamilySearcherHandler) Create(c *gin.Context) {
+	var req dto.FamilySearcherRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	resp, err := h.searcherService.Create(c.Request.Context(), &req, middleware.GetUserID(c), middleware.GetOrgID(c))
+	if err != nil {
+		respondFamilySearcherError(c, err)
+		return
+	}
+
+	response.Created(c, resp)
+}
+
+// List 查询寻亲者
+func (h *FamilySearcherHandler) List(c *gin.Context) {
+	var req dto.FamilySearcherListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	resp, err := h.searcherService.List(c.Request.Context(), &req)
+	if err != nil {
+		respondFamilySearcherError(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// GetByID 获取寻亲者详情
+func (h *FamilySearcherHandler) GetByID(c *gin.Context) {
+	resp, err := h.searcherService.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondFamilySearcherError(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// Update 修改寻亲者
+func (h *FamilySearcherHandler) Update(c *gin.Context) {
+	var req dto.FamilySearcherRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	resp, err := h.searcherService.Update(c.Request.Context(), c.Param("id"), &req, middleware.GetUserID(c), middleware.GetOrgID(c))
+	if err != nil {
+		respondFamilySearcherError(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// Delete 删除寻亲者
+func (h *FamilySearcherHandler) Delete(c *gin.Context) {
+	if err := h.searcherService.Delete(c.Request.Context(), c.Param("id"), middleware.GetUserID(c), middleware.GetOrgID(c)); err != nil {
+		respondFamilySearcherError(c, err)
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+// Graph 获取亲属关系图
+func (h *FamilySearcherHandler) Graph(c *gin.Context) {
+	resp, err := h.searcherService.Graph(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondFamilySearcherError(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// FindMatches 为寻亲者比对可能的案件及寻亲者
+func (h *FamilySearcherHandler) FindMatches(c *gin.Context) {
+	resp, err := h.searcherService.FindMatches(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondFamilySearcherError(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// LinkCase 关联案件
+func (h *FamilySearcherHandler) LinkCase(c *gin.Context) {
+	var req dto.LinkSearcherCaseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	resp, err := h.searcherService.LinkCase(c.Request.Context(), c.Param("id"), &req, middleware.GetUserID(c), middleware.GetOrgID(c))
+	if err != nil {
+		respondFamilySearcherError(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// UnlinkCase 取消关联案件
+func (h *FamilySearcherHandler) UnlinkCase(c *gin.Context) {
+	err := h.searcherService.UnlinkCase(c.Request.Context(), c.Param("id"), c.Param("caseId"), middleware.GetUserID(c), middleware.GetOrgID(c))
+	if err != nil {
+		respondFamilySearcherError(c, err)
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+// ListByCase 获取关联到案件的寻亲者
+func (h *FamilySearcherHandler) ListByCase(c *gin.Context) {
+	resp, err := h.searcherService.ListByCase(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondFamilySearcherError(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// FindCaseMatches 为案件比对可能是走失者本人的亲寻家档案
+func (h *FamilySearcherHandler) FindCaseMatches(c *gin.Context) {
+	resp, err := h.searcherService.FindCaseMatches(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondFamilySearcherError(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// respondFamilySearcherError 寻亲者操作错误响应
+func respondFamilySearcherError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrFamilySearcherNotFound):
+		response.NotFound(c, "family searcher not found")
+	case errors.Is(err, service.ErrMissingPersonNotFound):
+		response.NotFound(c, "missing person not found")
+	case errors.Is(err, service.ErrSearcherCaseNotLinked):
+		response.NotFound(c, err.Error())
+	case errors.Is(err, entity.ErrSearcherCaseAlreadyLinked):
+		response.Conflict(c, err.Error())
+	case errors.Is(err, service.ErrFamilySearcherInvalid):
+		response.BadRequest(c, err.Error())
+	default:
+		logger.Error("Family searcher operation failed", logger.Err(err))
+		response.InternalServerError(c, "family searcher operation failed")
+	}
+}
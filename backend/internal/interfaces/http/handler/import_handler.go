@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/dto"
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/service"
+	"github.com/Snowitty-Re/CNtunyuan/internal/interfaces/http/middleware"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/logger"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/response"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/spreadsheet"
+	"github.com/gin-gonic/gin"
+)
+
+// ImportHandler 案件批量导入处理器
+type ImportHandler struct {
+	importService *service.ImportAppService
+}
+
+// NewImportHandler 创建案件批量导入处理器
+func NewImportHandler(importService *service.ImportAppService) *ImportHandler {
+	return &ImportHandler{importService: importService}
+}
+
+// RegisterRoutes 注册路由
+func (h *ImportHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware *middleware.AuthMiddleware) {
+	imports := router.Group("/imports")
+	imports.Use(authMiddleware.Required(), middleware.RequireManager())
+	{
+		imports.GET("/fields", h.Fields)
+		imports.GET("/template", h.Template)
+		imports.GET("/templates", h.ListTemplates)
+		imports.POST("/templates", h.CreateTemplate)
+		imports.DELETE("/templates/:id", h.DeleteTemplate)
+		imports.POST("", h.Create)
+		imports.GET("", h.List)
+		imports.GET("/:id", h.Get)
+		imports.POST("/:id/confirm", h.Confirm)
+		imports.GET("/:id/errors", h.ErrorReport)
+	}
+}
+
+// Fields 可导入字段及可识别的列名
+func (h *ImportHandler) Fields(c *gin.Context) {
+	response.Success(c, h.importService.Fields())
+}
+
+// Template 下载导入模板
+func (h *ImportHandler) Template(c *gin.Context) {
+	c.Header("Content-Disposition", "attachment; filename=\"case_import_template.csv\"")
+	c.Data(http.StatusOK, "text/csv; charset=utf-8", h.importService.Template())
+}
+
+// Create 上传表格创建导入任务（dry_run=true 时只预检）
+func (h *ImportHandler) Create(c *gin.Context) {
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		response.BadRequest(c, "file is required")
+		return
+	}
+	defer file.Close()
+
+	var req dto.CreateImportRequest
+	if err := c.ShouldBind(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	job, err := h.importService.Create(c.Request.Context(), file, header, &req, middleware.GetUserID(c), middleware.GetOrgID(c))
+	if err != nil {
+		h.handleError(c, err, "failed to create import job")
+		return
+	}
+
+	response.Created(c, job)
+}
+
+// List 导入任务列表
+func (h *ImportHandler) List(c *gin.Context) {
+	var req dto.ImportJobListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.importService.List(c.Request.Context(), &req, middleware.GetOrgID(c))
+	if err != nil {
+		logger.Error("Failed to list import jobs", logger.Err(err))
+		response.InternalServerError(c, "failed to list import jobs")
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Get 导入任务详情及逐行结果
+func (h *ImportHandler) Get(c *gin.Context) {
+	job, err := h.importService.Get(c.Request.Context(), c.Param("id"), middleware.GetOrgID(c))
+	if err != nil {
+		h.handleError(c, err, "failed to get import job")
+		return
+	}
+
+	response.Success(c, job)
+}
+
+// Confirm 确认预检结果并正式导入
+func (h *ImportHandler) Confirm(c *gin.Context) {
+	job, err := h.importService.Confirm(c.Request.Context(), c.Param("id"), middleware.GetUserID(c), middleware.GetOrgID(c))
+	if err != nil {
+		h.handleError(c, err, "failed to confirm import job")
+		return
+	}
+
+	response.Created(c, job)
+}
+
+// ErrorReport 下载错误报告
+func (h *ImportHandler) ErrorReport(c *gin.Context) {
+	data, name, err := h.importService.ErrorReport(c.Request.Context(), c.Param("id"), middleware.GetOrgID(c))
+	if err != nil {
+		h.handleError(c, err, "failed to build error report")
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", name))
+	c.Data(http.StatusOK, "text/csv; charset=utf-8", data)
+}
+
+// ListTemplates 列映射模板列表
+func (h *ImportHandler) ListTemplates(c *gin.Context) {
+	list, err := h.importService.ListTemplates(c.Request.Context(), middleware.GetOrgID(c))
+	if err != nil {
+		logger.Error("Failed to list import templates", logger.Err(err))
+		response.InternalServerError(c, "failed to list import templates")
+		return
+	}
+
+	response.Success(c, list)
+}
+
+// CreateTemplate 保存列映射模板
+func (h *ImportHandler) CreateTemplate(c *gin.Context) {
+	var req dto.CreateImportTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	template, err := h.importService.CreateTemplate(c.Request.Context(), &req, middleware.GetUserID(c), middleware.GetOrgID(c))
+	if err != nil {
+		h.handleError(c, err, "failed to create import template")
+		return
+	}
+
+	response.Created(c, template)
+}
+
+// DeleteTemplate 删除列映射模板
+func (h *ImportHandler) DeleteTemplate(c *gin.Context) {
+	if err := h.importService.DeleteTemplate(c.Request.Context(), c.Param("id"), middleware.GetOrgID(c)); err != nil {
+		h.handleError(c, err, "failed to delete import template")
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+// handleError 错误映射
+func (h *ImportHandler) handleError(c *gin.Context, err error, msg string) {
+	switch {
+	case errors.Is(err, service.ErrImportNotFound):
+		response.NotFound(c, "import job not found")
+	case errors.Is(err, service.ErrImportTemplateNotFound):
+		response.NotFound(c, "import template not found")
+	case errors.Is(err, service.ErrFileTooLarge):
+		response.BadRequest(c, fmt.Sprintf("file too large, max %d MB", service.MaxImportFileSize>>20))
+	case errors.Is(err, spreadsheet.ErrUnsupportedFormat):
+		response.BadRequest(c, "only .csv and .xlsx files are supported")
+	case errors.Is(err, service.ErrImportMapping),
+		errors.Is(err, service.ErrImportFile),
+		errors.Is(err, service.ErrImportTooManyRows):
+		response.BadRequest(c, err.Error())
+	case errors.Is(err, service.ErrImportNotConfirmable):
+		response.Conflict(c, "only completed dry runs with valid rows can be confirmed")
+	case errors.Is(err, service.ErrImportNotFinished):
+		response.Conflict(c, "import job is still running")
+	default:
+		logger.Error(msg, logger.Err(err))
+		response.InternalServerError(c, msg)
+	}
+}
@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/dto"
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/service"
+	"github.com/Snowitty-Re/CNtunyuan/internal/interfaces/http/middleware"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// MissingPersonGeoHandler 走失人员地理查询处理器
+type MissingPersonGeoHandler struct {
+	geoService      *service.GeoAppService
+	timelineService *service.TimelineAppService
+}
+
+// NewMissingPersonGeoHandler 创建走失人员地理查询处理器
+func NewMissingPersonGeoHandler(geoService *service.GeoAppService, timelineService *service.TimelineAppService) *MissingPersonGeoHandler {
+	return &MissingPersonGeoHandler{geoService: geoService, timelineService: timelineService}
+}
+
+// RegisterRoutes 注册路由
+func (h *MissingPersonGeoHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware *middleware.AuthMiddleware) {
+	geo := router.Group("/missing-persons/geo")
+	geo.Use(authMiddleware.Required())
+	{
+		geo.GET("/tracks/nearby", h.NearbyTracks)
+		geo.GET("/map", h.MapMarkers)
+		geo.GET("/nearest", h.NearestCases)
+	}
+
+	mps := router.Group("/missing-persons")
+	mps.Use(authMiddleware.Required())
+	{
+		mps.GET("/:id/timeline", h.GetTimeline)
+	}
+}
+
+// NearbyTracks 查询半径范围内的线索
+func (h *MissingPersonGeoHandler) NearbyTracks(c *gin.Context) {
+	var req dto.NearbyTracksRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	list, err := h.geoService.NearbyTracks(c.Request.Context(), &req)
+	if err != nil {
+		if err == service.ErrInvalidCoordinate {
+			response.BadRequest(c, "invalid coordinate")
+			return
+		}
+		response.InternalServerError(c, "failed to query nearby tracks")
+		return
+	}
+
+	response.Success(c, list)
+}
+
+// MapMarkers 查询地图视野内的案件与线索
+func (h *MissingPersonGeoHandler) MapMarkers(c *gin.Context) {
+	var req dto.MapBoundsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	markers, err := h.geoService.MapMarkers(c.Request.Context(), &req)
+	if err != nil {
+		if err == service.ErrInvalidBounds {
+			response.BadRequest(c, "invalid bounds")
+			return
+		}
+		response.InternalServerError(c, "failed to query map markers")
+		return
+	}
+
+	response.Success(c, markers)
+}
+
+// NearestCases 查询最近的活跃案件
+func (h *MissingPersonGeoHandler) NearestCases(c *gin.Context) {
+	var req dto.NearestCasesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	list, err := h.geoService.NearestActiveCases(c.Request.Context(), &req)
+	if err != nil {
+		if err == service.ErrInvalidCoordinate {
+			response.BadRequest(c, "invalid coordinate")
+			return
+		}
+		response.InternalServerError(c, "failed to query nearest cases")
+		return
+	}
+
+	response.Success(c, list)
+}
+
+// GetTimeline 获取案件时间线（GeoJSON）
+func (h *MissingPersonGeoHandler) GetTimeline(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		response.BadRequest(c, "missing person id is required")
+		return
+	}
+
+	var req dto.TimelineRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	fc, err := h.timelineService.GetTimeline(c.Request.Context(), id, &req)
+	if err != nil {
+		if err == service.ErrMissingPersonNotFound {
+			response.NotFound(c, "missing person not found")
+			return
+		}
+		response.InternalServerError(c, "failed to build timeline")
+		return
+	}
+
+	response.Success(c, fc)
+}
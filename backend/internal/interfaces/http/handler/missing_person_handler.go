@@ -1,24 +1,30 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
+	"net/http"
 	"strconv"
 
 	"github.com/Snowitty-Re/CNtunyuan/internal/application/dto"
 	"github.com/Snowitty-Re/CNtunyuan/internal/application/service"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
 	"github.com/Snowitty-Re/CNtunyuan/internal/interfaces/http/middleware"
 	"github.com/Snowitty-Re/CNtunyuan/pkg/logger"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/poster"
 	"github.com/Snowitty-Re/CNtunyuan/pkg/response"
 	"github.com/gin-gonic/gin"
 )
 
 // MissingPersonHandler 走失人员处理器
 type MissingPersonHandler struct {
-	mpService *service.MissingPersonAppService
+	mpService     *service.MissingPersonAppService
+	posterService *service.PosterAppService
 }
 
 // NewMissingPersonHandler 创建走失人员处理器
-func NewMissingPersonHandler(mpService *service.MissingPersonAppService) *MissingPersonHandler {
-	return &MissingPersonHandler{mpService: mpService}
+func NewMissingPersonHandler(mpService *service.MissingPersonAppService, posterService *service.PosterAppService) *MissingPersonHandler {
+	return &MissingPersonHandler{mpService: mpService, posterService: posterService}
 }
 
 // RegisterRoutes 注册路由
@@ -36,8 +42,15 @@ func (h *MissingPersonHandler) RegisterRoutes(router *gin.RouterGroup, authMiddl
 		mps.DELETE("/:id", middleware.RequireManager(), h.Delete)
 		mps.PUT("/:id/status", middleware.RequireManager(), h.UpdateStatus)
 		mps.POST("/:id/found", middleware.RequireManager(), h.MarkFound)
-		mps.POST("/:id/reunited", middleware.RequireManager(), h.MarkReunited)
+		mps.POST("/:id/close", middleware.RequireManager(), h.Close)
+		mps.POST("/:id/reopen", middleware.RequireManager(), h.Reopen)
+		mps.GET("/:id/status-logs", h.GetStatusLogs)
+		mps.GET("/:id/transitions", h.GetTransitions)
 		mps.POST("/:id/tracks", h.AddTrack)
+		mps.GET("/:id/duplicates", h.FindDuplicates)
+		mps.GET("/:id/merges", h.GetMergeHistory)
+		mps.POST("/:id/merge", middleware.RequireManager(), h.Merge)
+		mps.GET("/:id/poster", h.Poster)
 	}
 }
 
@@ -160,13 +173,11 @@ func (h *MissingPersonHandler) UpdateStatus(c *gin.Context) {
 		return
 	}
 
-	if err := h.mpService.UpdateStatus(c.Request.Context(), id, req.Status); err != nil {
-		switch err {
-		case service.ErrMissingPersonNotFound:
-			response.NotFound(c, "missing person not found")
-		default:
-			response.BadRequest(c, err.Error())
-		}
+	userID := middleware.GetUserID(c)
+	orgID := middleware.GetOrgID(c)
+
+	if err := h.mpService.UpdateStatus(c.Request.Context(), id, req.Status, req.Reason, userID, orgID); err != nil {
+		respondTransitionError(c, err)
 		return
 	}
 
@@ -187,38 +198,122 @@ func (h *MissingPersonHandler) MarkFound(c *gin.Context) {
 		return
 	}
 
-	if err := h.mpService.MarkFound(c.Request.Context(), id, &req); err != nil {
-		switch err {
-		case service.ErrMissingPersonNotFound:
-			response.NotFound(c, "missing person not found")
-		default:
-			response.BadRequest(c, err.Error())
-		}
+	userID := middleware.GetUserID(c)
+	orgID := middleware.GetOrgID(c)
+
+	if err := h.mpService.MarkFound(c.Request.Context(), id, &req, userID, orgID); err != nil {
+		respondTransitionError(c, err)
 		return
 	}
 
 	response.Success(c, nil)
 }
 
-// MarkReunited 标记团聚
-func (h *MissingPersonHandler) MarkReunited(c *gin.Context) {
+// Close 关闭案件
+func (h *MissingPersonHandler) Close(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
 		response.BadRequest(c, "missing person id is required")
 		return
 	}
 
-	if err := h.mpService.MarkReunited(c.Request.Context(), id); err != nil {
-		switch err {
-		case service.ErrMissingPersonNotFound:
+	var req dto.CaseStatusReasonRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	userID := middleware.GetUserID(c)
+	orgID := middleware.GetOrgID(c)
+
+	if err := h.mpService.Close(c.Request.Context(), id, req.Reason, userID, orgID); err != nil {
+		respondTransitionError(c, err)
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+// Reopen 重新打开案件
+func (h *MissingPersonHandler) Reopen(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		response.BadRequest(c, "missing person id is required")
+		return
+	}
+
+	var req dto.CaseStatusReasonRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	userID := middleware.GetUserID(c)
+	orgID := middleware.GetOrgID(c)
+
+	if err := h.mpService.Reopen(c.Request.Context(), id, req.Reason, userID, orgID); err != nil {
+		respondTransitionError(c, err)
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+// GetStatusLogs 获取状态变更记录
+func (h *MissingPersonHandler) GetStatusLogs(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		response.BadRequest(c, "missing person id is required")
+		return
+	}
+
+	list, err := h.mpService.GetStatusLogs(c.Request.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrMissingPersonNotFound):
 			response.NotFound(c, "missing person not found")
 		default:
-			response.BadRequest(c, err.Error())
+			logger.Error("Failed to get status logs", logger.Err(err))
+			response.InternalServerError(c, "failed to get status logs")
 		}
 		return
 	}
 
-	response.Success(c, nil)
+	response.Success(c, list)
+}
+
+// GetTransitions 获取当前可执行的状态转换
+func (h *MissingPersonHandler) GetTransitions(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		response.BadRequest(c, "missing person id is required")
+		return
+	}
+
+	list, err := h.mpService.GetTransitions(c.Request.Context(), id)
+	if err != nil {
+		response.NotFound(c, "missing person not found")
+		return
+	}
+
+	response.Success(c, list)
+}
+
+// respondTransitionError 状态转换错误响应
+func respondTransitionError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrMissingPersonNotFound):
+		response.NotFound(c, "missing person not found")
+	case errors.Is(err, entity.ErrCaseTransitionNotAllowed), errors.Is(err, entity.ErrCaseMerged):
+		response.Conflict(c, err.Error())
+	case errors.Is(err, entity.ErrCaseTransitionReasonRequired):
+		response.BadRequest(c, err.Error())
+	case errors.Is(err, service.ErrReunionRecordRequired):
+		response.BadRequest(c, "use POST /missing-persons/:id/reunited with a reunion record")
+	default:
+		logger.Error("Failed to transition missing person status", logger.Err(err))
+		response.InternalServerError(c, "failed to update status")
+	}
 }
 
 // AddTrack 添加轨迹
@@ -298,3 +393,115 @@ func (h *MissingPersonHandler) Search(c *gin.Context) {
 
 	response.Success(c, result)
 }
+
+// FindDuplicates 查找疑似重复案件
+func (h *MissingPersonHandler) FindDuplicates(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		response.BadRequest(c, "missing person id is required")
+		return
+	}
+
+	list, err := h.mpService.FindDuplicates(c.Request.Context(), id)
+	if err != nil {
+		switch err {
+		case service.ErrMissingPersonNotFound:
+			response.NotFound(c, "missing person not found")
+		default:
+			logger.Error("Failed to find duplicates", logger.Err(err))
+			response.InternalServerError(c, "failed to find duplicates")
+		}
+		return
+	}
+
+	response.Success(c, list)
+}
+
+// Merge 合并重复案件到当前案件
+func (h *MissingPersonHandler) Merge(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		response.BadRequest(c, "missing person id is required")
+		return
+	}
+
+	var req dto.MergeMissingPersonRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	userID := middleware.GetUserID(c)
+	orgID := middleware.GetOrgID(c)
+
+	record, err := h.mpService.Merge(c.Request.Context(), id, &req, userID, orgID, middleware.GetUserRole(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrMissingPersonNotFound):
+			response.NotFound(c, "missing person not found")
+		case errors.Is(err, service.ErrMissingPersonForbidden):
+			response.Forbidden(c, "no access to missing person")
+		case errors.Is(err, service.ErrInvalidMerge):
+			response.BadRequest(c, err.Error())
+		default:
+			logger.Error("Failed to merge missing person", logger.Err(err))
+			response.InternalServerError(c, "failed to merge")
+		}
+		return
+	}
+
+	response.Success(c, record)
+}
+
+// GetMergeHistory 获取合并记录
+func (h *MissingPersonHandler) GetMergeHistory(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		response.BadRequest(c, "missing person id is required")
+		return
+	}
+
+	list, err := h.mpService.GetMergeHistory(c.Request.Context(), id)
+	if err != nil {
+		response.InternalServerError(c, "failed to get merge history")
+		return
+	}
+
+	response.Success(c, list)
+}
+
+// Poster 生成寻人海报（format=pdf 打印版，format=png 分享卡片）
+func (h *MissingPersonHandler) Poster(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		response.BadRequest(c, "missing person id is required")
+		return
+	}
+
+	var req dto.PosterRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	file, err := h.posterService.Render(c.Request.Context(), id, req.Format)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrMissingPersonNotFound):
+			response.NotFound(c, "missing person not found")
+		case errors.Is(err, service.ErrPosterFormat):
+			response.BadRequest(c, "format must be pdf or png")
+		case errors.Is(err, service.ErrCaseNotPostable):
+			response.Conflict(c, "poster is only available for active cases")
+		case errors.Is(err, poster.ErrFontUnavailable):
+			response.ErrorCodeWithMessage(c, http.StatusServiceUnavailable, "poster requires a CJK TrueType font, see poster.font_path")
+		default:
+			logger.Error("Failed to render poster", logger.String("id", id), logger.Err(err))
+			response.InternalServerError(c, "failed to render poster")
+		}
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("inline; filename=\"%s\"", file.FileName))
+	c.Data(http.StatusOK, file.ContentType, file.Data)
+}
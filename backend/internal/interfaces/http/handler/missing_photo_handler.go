@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/dto"
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/service"
+	"github.com/Snowitty-Re/CNtunyuan/internal/interfaces/http/middleware"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/logger"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// MissingPhotoHandler 走失人员照片处理器
+type MissingPhotoHandler struct {
+	photoService *service.MissingPhotoAppService
+}
+
+// NewMissingPhotoHandler 创建走失人员照片处理器
+func NewMissingPhotoHandler(photoService *service.MissingPhotoAppService) *MissingPhotoHandler {
+	return &MissingPhotoHandler{photoService: photoService}
+}
+
+// RegisterRoutes 注册路由
+func (h *MissingPhotoHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware *middleware.AuthMiddleware) {
+	mp := router.Group("/missing-persons/:id")
+	mp.Use(authMiddleware.Required())
+	{
+		mp.GET("/photos", h.ListPhotos)
+		mp.POST("/photos", h.AddPhoto)
+		mp.PUT("/photos/:photoId/primary", h.SetPrimary)
+		mp.DELETE("/photos/:photoId", middleware.RequireManager(), h.DeletePhoto)
+		mp.GET("/photo-matches", h.GetMatches)
+	}
+}
+
+// AddPhoto 上传照片
+func (h *MissingPhotoHandler) AddPhoto(c *gin.Context) {
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		response.BadRequest(c, "file is required")
+		return
+	}
+	defer file.Close()
+
+	var req dto.AddMissingPhotoRequest
+	if err := c.ShouldBind(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.photoService.AddPhoto(c.Request.Context(), c.Param("id"), file, header, &req, middleware.GetUserID(c))
+	if err != nil {
+		h.handleError(c, err, "failed to upload photo")
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// ListPhotos 获取案件照片
+func (h *MissingPhotoHandler) ListPhotos(c *gin.Context) {
+	photos, err := h.photoService.ListPhotos(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.handleError(c, err, "failed to get photos")
+		return
+	}
+
+	response.Success(c, photos)
+}
+
+// SetPrimary 设为主照片
+func (h *MissingPhotoHandler) SetPrimary(c *gin.Context) {
+	photo, err := h.photoService.SetPrimary(c.Request.Context(), c.Param("id"), c.Param("photoId"))
+	if err != nil {
+		h.handleError(c, err, "failed to set primary photo")
+		return
+	}
+
+	response.Success(c, photo)
+}
+
+// DeletePhoto 删除照片
+func (h *MissingPhotoHandler) DeletePhoto(c *gin.Context) {
+	if err := h.photoService.DeletePhoto(c.Request.Context(), c.Param("id"), c.Param("photoId")); err != nil {
+		h.handleError(c, err, "failed to delete photo")
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+// GetMatches 获取其它案件中的相似照片
+func (h *MissingPhotoHandler) GetMatches(c *gin.Context) {
+	matches, err := h.photoService.GetMatches(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.handleError(c, err, "failed to get photo matches")
+		return
+	}
+
+	response.Success(c, matches)
+}
+
+// handleError 统一处理照片错误
+func (h *MissingPhotoHandler) handleError(c *gin.Context, err error, fallback string) {
+	switch err {
+	case service.ErrMissingPersonNotFound:
+		response.NotFound(c, "missing person not found")
+	case service.ErrPhotoNotFound:
+		response.NotFound(c, "photo not found")
+	case service.ErrInvalidPhotoType:
+		response.BadRequest(c, "invalid photo type")
+	case service.ErrInvalidImage:
+		response.BadRequest(c, "unsupported or corrupted image")
+	case service.ErrFileTooLarge:
+		response.BadRequest(c, "file too large")
+	default:
+		logger.Error(fallback, logger.Err(err))
+		response.InternalServerError(c, fallback)
+	}
+}
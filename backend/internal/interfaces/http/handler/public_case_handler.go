@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/dto"
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/service"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/logger"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// PublicCaseHandler 公开案件公示板处理器（无需登录）
+type PublicCaseHandler struct {
+	publicService *service.PublicCaseAppService
+	boardLimit    gin.HandlerFunc
+	leadLimit     gin.HandlerFunc
+}
+
+// NewPublicCaseHandler 创建公开案件处理器
+// boardLimit 作用于所有公开接口，leadLimit 额外作用于线索提交
+func NewPublicCaseHandler(publicService *service.PublicCaseAppService, boardLimit, leadLimit gin.HandlerFunc) *PublicCaseHandler {
+	return &PublicCaseHandler{
+		publicService: publicService,
+		boardLimit:    boardLimit,
+		leadLimit:     leadLimit,
+	}
+}
+
+// RegisterRoutes 注册路由
+func (h *PublicCaseHandler) RegisterRoutes(router *gin.RouterGroup) {
+	public := router.Group("/public")
+	if h.boardLimit != nil {
+		public.Use(h.boardLimit)
+	}
+	{
+		public.GET("/cases", h.List)
+		public.GET("/cases/:id", h.GetByID)
+		public.GET("/captcha", h.Captcha)
+
+		leads := []gin.HandlerFunc{h.SubmitLead}
+		if h.leadLimit != nil {
+			leads = append([]gin.HandlerFunc{h.leadLimit}, leads...)
+		}
+		public.POST("/cases/:id/leads", leads...)
+	}
+}
+
+// List 公开案件列表
+func (h *PublicCaseHandler) List(c *gin.Context) {
+	var req dto.PublicCaseListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	list, err := h.publicService.List(c.Request.Context(), &req)
+	if err != nil {
+		response.InternalServerError(c, "failed to get list")
+		return
+	}
+
+	response.Success(c, list)
+}
+
+// GetByID 公开案件详情
+func (h *PublicCaseHandler) GetByID(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		response.BadRequest(c, "case id is required")
+		return
+	}
+
+	mp, err := h.publicService.GetByID(c.Request.Context(), id)
+	if err != nil {
+		if err == service.ErrMissingPersonNotFound {
+			response.NotFound(c, "case not found")
+			return
+		}
+		response.InternalServerError(c, "failed to get case")
+		return
+	}
+
+	response.Success(c, mp)
+}
+
+// Captcha 获取验证码
+func (h *PublicCaseHandler) Captcha(c *gin.Context) {
+	resp, err := h.publicService.NewCaptcha()
+	if err != nil {
+		logger.Error("Failed to generate captcha", logger.Err(err))
+		response.InternalServerError(c, "failed to generate captcha")
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// SubmitLead 提交线索
+func (h *PublicCaseHandler) SubmitLead(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		response.BadRequest(c, "case id is required")
+		return
+	}
+
+	var req dto.SubmitLeadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	lead, err := h.publicService.SubmitLead(c.Request.Context(), id, &req, c.ClientIP())
+	if err != nil {
+		switch err {
+		case service.ErrMissingPersonNotFound:
+			response.NotFound(c, "case not found")
+		case service.ErrCaptchaRequired:
+			response.BadRequest(c, "captcha required")
+		case service.ErrCaptchaInvalid:
+			response.BadRequest(c, "captcha invalid or expired")
+		case service.ErrInvalidLeadPhone:
+			response.BadRequest(c, "invalid contact phone")
+		case service.ErrInvalidLeadTime:
+			response.BadRequest(c, "sighting time cannot be in the future")
+		default:
+			response.InternalServerError(c, "failed to submit lead")
+		}
+		return
+	}
+
+	response.Created(c, lead)
+}
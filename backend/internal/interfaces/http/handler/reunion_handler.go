@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/dto"
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/service"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/Snowitty-Re/CNtunyuan/internal/interfaces/http/middleware"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/logger"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// ReunionHandler 团聚记录与回访处理器
+type ReunionHandler struct {
+	reunionService *service.ReunionAppService
+}
+
+// NewReunionHandler 创建团聚记录与回访处理器
+func NewReunionHandler(reunionService *service.ReunionAppService) *ReunionHandler {
+	return &ReunionHandler{reunionService: reunionService}
+}
+
+// RegisterRoutes 注册路由
+func (h *ReunionHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware *middleware.AuthMiddleware) {
+	router.POST("/missing-persons/:id/reunited", authMiddleware.Required(), middleware.RequireManager(), h.Reunite)
+	router.GET("/missing-persons/:id/reunion", authMiddleware.Required(), h.GetRecord)
+	router.PUT("/missing-persons/:id/reunion", authMiddleware.Required(), middleware.RequireManager(), h.UpdateRecord)
+
+	reunions := router.Group("/reunions")
+	reunions.Use(authMiddleware.Required())
+	{
+		reunions.GET("/follow-ups/report", middleware.RequireManager(), h.GetFollowUpReport)
+		reunions.POST("/follow-ups/:id/complete", h.CompleteFollowUp)
+		reunions.POST("/follow-ups/dispatch", middleware.RequireAdmin(), h.DispatchFollowUps)
+	}
+}
+
+// Reunite 标记团聚并登记团聚记录
+func (h *ReunionHandler) Reunite(c *gin.Context) {
+	var req dto.ReunionRecordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	userID := middleware.GetUserID(c)
+	orgID := middleware.GetOrgID(c)
+
+	resp, err := h.reunionService.Reunite(c.Request.Context(), c.Param("id"), &req, userID, orgID)
+	if err != nil {
+		if errors.Is(err, service.ErrReunionInvalid) {
+			response.BadRequest(c, err.Error())
+			return
+		}
+		respondTransitionError(c, err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// GetRecord 获取团聚记录
+func (h *ReunionHandler) GetRecord(c *gin.Context) {
+	resp, err := h.reunionService.GetRecord(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrReunionNotFound):
+			response.NotFound(c, "reunion record not found")
+		default:
+			logger.Error("Failed to get reunion record", logger.Err(err))
+			response.InternalServerError(c, "failed to get reunion record")
+		}
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// UpdateRecord 修改团聚记录
+func (h *ReunionHandler) UpdateRecord(c *gin.Context) {
+	var req dto.ReunionRecordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	userID := middleware.GetUserID(c)
+	orgID := middleware.GetOrgID(c)
+
+	resp, err := h.reunionService.UpdateRecord(c.Request.Context(), c.Param("id"), &req, userID, orgID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrReunionNotFound):
+			response.NotFound(c, "reunion record not found")
+		case errors.Is(err, service.ErrReunionInvalid):
+			response.BadRequest(c, err.Error())
+		default:
+			response.InternalServerError(c, "failed to update reunion record")
+		}
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// CompleteFollowUp 记录回访结果
+func (h *ReunionHandler) CompleteFollowUp(c *gin.Context) {
+	var req dto.CompleteFollowUpRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	resp, err := h.reunionService.CompleteFollowUp(c.Request.Context(), c.Param("id"), &req, middleware.GetUserID(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrReunionFollowUpNotFound):
+			response.NotFound(c, "follow-up not found")
+		case errors.Is(err, entity.ErrFollowUpAlreadyCompleted):
+			response.Conflict(c, "follow-up already completed")
+		case errors.Is(err, entity.ErrFollowUpInvalidOutcome):
+			response.BadRequest(c, err.Error())
+		default:
+			response.InternalServerError(c, "failed to complete follow-up")
+		}
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// GetFollowUpReport 团聚回访长期结果报告
+func (h *ReunionHandler) GetFollowUpReport(c *gin.Context) {
+	resp, err := h.reunionService.GetFollowUpReport(c.Request.Context(), c.Query("org_id"))
+	if err != nil {
+		logger.Error("Failed to get follow-up report", logger.Err(err))
+		response.InternalServerError(c, "failed to get follow-up report")
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// DispatchFollowUps 立即为到期的回访生成任务
+func (h *ReunionHandler) DispatchFollowUps(c *gin.Context) {
+	created, err := h.reunionService.DispatchDueFollowUps(c.Request.Context())
+	if err != nil {
+		logger.Error("Failed to dispatch follow-ups", logger.Err(err))
+		response.InternalServerError(c, "failed to dispatch follow-ups")
+		return
+	}
+
+	response.Success(c, gin.H{"created": created})
+}
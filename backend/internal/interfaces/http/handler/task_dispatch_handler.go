@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"errors"
+	"io"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/dto"
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/service"
+	"github.com/Snowitty-Re/CNtunyuan/internal/domain/entity"
+	"github.com/Snowitty-Re/CNtunyuan/internal/interfaces/http/middleware"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/logger"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// TaskDispatchHandler 任务自动派单处理器
+type TaskDispatchHandler struct {
+	dispatchService *service.TaskDispatchAppService
+}
+
+// NewTaskDispatchHandler 创建任务自动派单处理器
+func NewTaskDispatchHandler(dispatchService *service.TaskDispatchAppService) *TaskDispatchHandler {
+	return &TaskDispatchHandler{dispatchService: dispatchService}
+}
+
+// RegisterRoutes 注册路由
+func (h *TaskDispatchHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware *middleware.AuthMiddleware) {
+	tasks := router.Group("/tasks")
+	tasks.Use(authMiddleware.Required())
+	{
+		// 志愿者查看和响应派单邀请，也可通过 WebSocket 的 task_offer_accept / task_offer_decline 消息响应
+		tasks.GET("/offers", h.MyOffers)
+		tasks.POST("/offers/:offerId/accept", h.Accept)
+		tasks.POST("/offers/:offerId/decline", h.Decline)
+
+		tasks.GET("/:id/dispatch/candidates", middleware.RequireManager(), h.Candidates)
+		tasks.POST("/:id/dispatch", middleware.RequireManager(), h.Dispatch)
+		tasks.GET("/:id/offers", middleware.RequireManager(), h.TaskOffers)
+	}
+}
+
+// MyOffers 我的待响应派单邀请
+func (h *TaskDispatchHandler) MyOffers(c *gin.Context) {
+	resp, err := h.dispatchService.MyOffers(c.Request.Context(), middleware.GetUserID(c))
+	if err != nil {
+		h.handleError(c, err, "failed to list task offers")
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// Accept 接单
+func (h *TaskDispatchHandler) Accept(c *gin.Context) {
+	resp, err := h.dispatchService.Accept(c.Request.Context(), c.Param("offerId"), middleware.GetUserID(c))
+	if err != nil {
+		h.handleError(c, err, "failed to accept task offer")
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// Decline 拒绝派单
+func (h *TaskDispatchHandler) Decline(c *gin.Context) {
+	var req dto.DeclineTaskOfferRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	resp, err := h.dispatchService.Decline(c.Request.Context(), c.Param("offerId"), &req, middleware.GetUserID(c))
+	if err != nil {
+		h.handleError(c, err, "failed to decline task offer")
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// Candidates 预览候选志愿者及评分
+func (h *TaskDispatchHandler) Candidates(c *gin.Context) {
+	var req dto.DispatchCandidateRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	resp, err := h.dispatchService.Candidates(c.Request.Context(), c.Param("id"), &req, middleware.GetOrgID(c))
+	if err != nil {
+		h.handleError(c, err, "failed to rank dispatch candidates")
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// Dispatch 手动推送一轮派单
+func (h *TaskDispatchHandler) Dispatch(c *gin.Context) {
+	var req dto.DispatchTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	resp, err := h.dispatchService.Dispatch(c.Request.Context(), c.Param("id"), &req, middleware.GetOrgID(c))
+	if err != nil {
+		h.handleError(c, err, "failed to dispatch task")
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// TaskOffers 任务的派单记录
+func (h *TaskDispatchHandler) TaskOffers(c *gin.Context) {
+	resp, err := h.dispatchService.TaskOffers(c.Request.Context(), c.Param("id"), middleware.GetOrgID(c))
+	if err != nil {
+		h.handleError(c, err, "failed to list task offers")
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// handleError 统一处理派单错误
+func (h *TaskDispatchHandler) handleError(c *gin.Context, err error, msg string) {
+	switch {
+	case errors.Is(err, service.ErrTaskNotFound):
+		response.NotFound(c, "task not found")
+	case errors.Is(err, service.ErrTaskOfferNotFound):
+		response.NotFound(c, "task offer not found")
+	case errors.Is(err, service.ErrTaskNotDispatchable),
+		errors.Is(err, service.ErrNoDispatchCandidate):
+		response.BadRequest(c, err.Error())
+	case errors.Is(err, service.ErrTaskOfferTaken),
+		errors.Is(err, entity.ErrTaskOfferClosed):
+		response.Conflict(c, err.Error())
+	default:
+		logger.Error("Task dispatch operation failed", logger.Err(err))
+		response.InternalServerError(c, msg)
+	}
+}
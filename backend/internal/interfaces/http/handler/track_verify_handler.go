@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/dto"
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/service"
+	"github.com/Snowitty-Re/CNtunyuan/internal/interfaces/http/middleware"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// TrackVerifyHandler 线索核实处理器
+type TrackVerifyHandler struct {
+	verifyService *service.TrackVerifyAppService
+}
+
+// NewTrackVerifyHandler 创建线索核实处理器
+func NewTrackVerifyHandler(verifyService *service.TrackVerifyAppService) *TrackVerifyHandler {
+	return &TrackVerifyHandler{verifyService: verifyService}
+}
+
+// RegisterRoutes 注册路由
+func (h *TrackVerifyHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware *middleware.AuthMiddleware) {
+	tracks := router.Group("/missing-persons/:id/tracks/:trackId")
+	tracks.Use(authMiddleware.Required())
+	{
+		tracks.GET("/logs", h.GetLogs)
+		tracks.POST("/verify", h.StartVerify)
+		tracks.POST("/confirm", middleware.RequireManager(), h.Confirm)
+		tracks.POST("/reject", middleware.RequireManager(), h.Reject)
+	}
+}
+
+// StartVerify 开始核实线索
+func (h *TrackVerifyHandler) StartVerify(c *gin.Context) {
+	track, err := h.verifyService.StartVerify(c.Request.Context(), c.Param("id"), c.Param("trackId"), middleware.GetUserID(c))
+	if err != nil {
+		h.handleError(c, err, "failed to start verification")
+		return
+	}
+
+	response.Success(c, track)
+}
+
+// Confirm 确认线索
+func (h *TrackVerifyHandler) Confirm(c *gin.Context) {
+	var req dto.ConfirmTrackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.verifyService.Confirm(c.Request.Context(), c.Param("id"), c.Param("trackId"), &req, middleware.GetUserID(c))
+	if err != nil {
+		h.handleError(c, err, "failed to confirm track")
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Reject 驳回线索
+func (h *TrackVerifyHandler) Reject(c *gin.Context) {
+	var req dto.RejectTrackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	track, err := h.verifyService.Reject(c.Request.Context(), c.Param("id"), c.Param("trackId"), &req, middleware.GetUserID(c))
+	if err != nil {
+		h.handleError(c, err, "failed to reject track")
+		return
+	}
+
+	response.Success(c, track)
+}
+
+// GetLogs 获取线索核实日志
+func (h *TrackVerifyHandler) GetLogs(c *gin.Context) {
+	logs, err := h.verifyService.GetLogs(c.Request.Context(), c.Param("id"), c.Param("trackId"))
+	if err != nil {
+		h.handleError(c, err, "failed to get track logs")
+		return
+	}
+
+	response.Success(c, logs)
+}
+
+// handleError 统一处理线索核实错误
+func (h *TrackVerifyHandler) handleError(c *gin.Context, err error, fallback string) {
+	switch err {
+	case service.ErrMissingPersonNotFound:
+		response.NotFound(c, "missing person not found")
+	case service.ErrTrackNotFound:
+		response.NotFound(c, "track not found")
+	case service.ErrTrackInvalidStatus:
+		response.BadRequest(c, "invalid track status transition")
+	case service.ErrTrackReasonRequired:
+		response.BadRequest(c, "reason is required")
+	case service.ErrCaseNotActiveForTrack:
+		response.BadRequest(c, "case is no longer being searched")
+	default:
+		response.InternalServerError(c, fallback)
+	}
+}
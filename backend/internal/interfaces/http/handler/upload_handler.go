@@ -65,6 +65,8 @@ func (h *UploadHandler) Upload(c *gin.Context) {
 		switch err {
 		case service.ErrFileTooLarge:
 			response.BadRequest(c, "file too large")
+		case service.ErrAudioTooLong:
+			response.BadRequest(c, "audio too long")
 		default:
 			logger.Error("Failed to upload file", logger.Err(err))
 			response.InternalServerError(c, "failed to upload file: "+err.Error())
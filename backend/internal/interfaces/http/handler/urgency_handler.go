@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/dto"
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/service"
+	"github.com/Snowitty-Re/CNtunyuan/internal/interfaces/http/middleware"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/logger"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// UrgencyHandler 案件紧急程度评分处理器
+type UrgencyHandler struct {
+	urgencyService *service.UrgencyAppService
+}
+
+// NewUrgencyHandler 创建案件紧急程度评分处理器
+func NewUrgencyHandler(urgencyService *service.UrgencyAppService) *UrgencyHandler {
+	return &UrgencyHandler{urgencyService: urgencyService}
+}
+
+// RegisterRoutes 注册路由
+func (h *UrgencyHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware *middleware.AuthMiddleware) {
+	router.GET("/missing-persons/:id/urgency", authMiddleware.Required(), h.Assess)
+	router.POST("/missing-persons/:id/urgency/rescore", authMiddleware.Required(), middleware.RequireManager(), h.Rescore)
+
+	urgency := router.Group("/urgency")
+	urgency.Use(authMiddleware.Required())
+	{
+		urgency.GET("/rules/:orgId", middleware.RequireManager(), h.GetRule)
+		urgency.PUT("/rules/:orgId", middleware.RequireAdmin(), h.SaveRule)
+		urgency.DELETE("/rules/:orgId", middleware.RequireAdmin(), h.DeleteRule)
+		urgency.POST("/rescore", middleware.RequireAdmin(), h.RescoreAll)
+	}
+}
+
+// Assess 评估案件紧急程度
+func (h *UrgencyHandler) Assess(c *gin.Context) {
+	resp, err := h.urgencyService.Assess(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrMissingPersonNotFound):
+			response.NotFound(c, "missing person not found")
+		default:
+			logger.Error("Failed to assess urgency", logger.Err(err))
+			response.InternalServerError(c, "failed to assess urgency")
+		}
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// Rescore 立即重新评分案件并恢复自动评级
+func (h *UrgencyHandler) Rescore(c *gin.Context) {
+	resp, err := h.urgencyService.Rescore(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrMissingPersonNotFound):
+			response.NotFound(c, "missing person not found")
+		case errors.Is(err, service.ErrUrgencyCaseNotActive):
+			response.Conflict(c, "urgency is only scored for active cases")
+		default:
+			logger.Error("Failed to rescore urgency", logger.Err(err))
+			response.InternalServerError(c, "failed to rescore urgency")
+		}
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// RescoreAll 立即重新评分所有进行中的案件
+func (h *UrgencyHandler) RescoreAll(c *gin.Context) {
+	resp, err := h.urgencyService.RescoreAll(c.Request.Context())
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrUrgencyRescoreRunning):
+			response.Conflict(c, "urgency rescore already in progress")
+		default:
+			logger.Error("Failed to rescore urgency", logger.Err(err))
+			response.InternalServerError(c, "failed to rescore urgency")
+		}
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// GetRule 获取组织生效的评分规则
+func (h *UrgencyHandler) GetRule(c *gin.Context) {
+	resp, err := h.urgencyService.GetRule(c.Request.Context(), c.Param("orgId"))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrOrganizationNotFound):
+			response.NotFound(c, "organization not found")
+		default:
+			logger.Error("Failed to get urgency rule", logger.Err(err))
+			response.InternalServerError(c, "failed to get urgency rule")
+		}
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// SaveRule 配置组织的评分规则
+func (h *UrgencyHandler) SaveRule(c *gin.Context) {
+	var req dto.UrgencyRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	resp, err := h.urgencyService.SaveRule(c.Request.Context(), c.Param("orgId"), &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrOrganizationNotFound):
+			response.NotFound(c, "organization not found")
+		case errors.Is(err, service.ErrUrgencyRuleInvalid):
+			response.BadRequest(c, err.Error())
+		default:
+			response.InternalServerError(c, "failed to save urgency rule")
+		}
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// DeleteRule 删除组织的评分规则，恢复沿用上级规则
+func (h *UrgencyHandler) DeleteRule(c *gin.Context) {
+	if err := h.urgencyService.DeleteRule(c.Request.Context(), c.Param("orgId")); err != nil {
+		logger.Error("Failed to delete urgency rule", logger.Err(err))
+		response.InternalServerError(c, "failed to delete urgency rule")
+		return
+	}
+
+	response.NoContent(c)
+}
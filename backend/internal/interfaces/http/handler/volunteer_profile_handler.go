@@ -0,0 +1,177 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/dto"
+	"github.com/Snowitty-Re/CNtunyuan/internal/application/service"
+	"github.com/Snowitty-Re/CNtunyuan/internal/interfaces/http/middleware"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/logger"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// VolunteerProfileHandler 志愿者档案处理器
+type VolunteerProfileHandler struct {
+	profileService *service.VolunteerProfileAppService
+}
+
+// NewVolunteerProfileHandler 创建志愿者档案处理器
+func NewVolunteerProfileHandler(profileService *service.VolunteerProfileAppService) *VolunteerProfileHandler {
+	return &VolunteerProfileHandler{profileService: profileService}
+}
+
+// RegisterRoutes 注册路由
+func (h *VolunteerProfileHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware *middleware.AuthMiddleware) {
+	volunteers := router.Group("/volunteers")
+	volunteers.Use(authMiddleware.Required())
+	{
+		// 志愿者维护自己的档案和休假
+		volunteers.GET("/me/profile", h.GetMine)
+		volunteers.PUT("/me/profile", h.SaveMine)
+		volunteers.POST("/me/vacations", h.AddMyVacation)
+		volunteers.DELETE("/me/vacations/:vacationId", h.DeleteMyVacation)
+
+		volunteers.GET("/search", middleware.RequireManager(), h.Search)
+		volunteers.GET("/certifications/expiring", middleware.RequireManager(), h.ExpiringCertifications)
+		volunteers.GET("/:userId/profile", middleware.RequireManager(), h.Get)
+		volunteers.PUT("/:userId/profile", middleware.RequireManager(), h.Save)
+	}
+}
+
+// GetMine 我的志愿者档案
+func (h *VolunteerProfileHandler) GetMine(c *gin.Context) {
+	resp, err := h.profileService.Get(c.Request.Context(), middleware.GetUserID(c), "")
+	if err != nil {
+		h.handleError(c, err, "failed to get volunteer profile")
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// SaveMine 保存我的志愿者档案
+func (h *VolunteerProfileHandler) SaveMine(c *gin.Context) {
+	var req dto.VolunteerProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	userID := middleware.GetUserID(c)
+	resp, err := h.profileService.Save(c.Request.Context(), userID, &req, userID, "")
+	if err != nil {
+		h.handleError(c, err, "failed to save volunteer profile")
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// AddMyVacation 添加休假
+func (h *VolunteerProfileHandler) AddMyVacation(c *gin.Context) {
+	var req dto.VolunteerVacationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	resp, err := h.profileService.AddVacation(c.Request.Context(), middleware.GetUserID(c), &req)
+	if err != nil {
+		h.handleError(c, err, "failed to add vacation")
+		return
+	}
+
+	response.Created(c, resp)
+}
+
+// DeleteMyVacation 删除休假
+func (h *VolunteerProfileHandler) DeleteMyVacation(c *gin.Context) {
+	if err := h.profileService.DeleteVacation(c.Request.Context(), middleware.GetUserID(c), c.Param("vacationId")); err != nil {
+		h.handleError(c, err, "failed to delete vacation")
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+// Search 按技能、语言方言、服务地点和档期搜索本组织志愿者
+func (h *VolunteerProfileHandler) Search(c *gin.Context) {
+	var req dto.VolunteerSearchRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	resp, err := h.profileService.Search(c.Request.Context(), &req, middleware.GetOrgID(c))
+	if err != nil {
+		h.handleError(c, err, "failed to search volunteers")
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// ExpiringCertifications 本组织即将到期的志愿者证书
+func (h *VolunteerProfileHandler) ExpiringCertifications(c *gin.Context) {
+	var req dto.ExpiringCertificationRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	resp, err := h.profileService.ExpiringCertifications(c.Request.Context(), &req, middleware.GetOrgID(c))
+	if err != nil {
+		h.handleError(c, err, "failed to list expiring certifications")
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// Get 查看本组织志愿者的档案
+func (h *VolunteerProfileHandler) Get(c *gin.Context) {
+	resp, err := h.profileService.Get(c.Request.Context(), c.Param("userId"), middleware.GetOrgID(c))
+	if err != nil {
+		h.handleError(c, err, "failed to get volunteer profile")
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// Save 主管维护本组织志愿者的档案（如登记证书）
+func (h *VolunteerProfileHandler) Save(c *gin.Context) {
+	var req dto.VolunteerProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	resp, err := h.profileService.Save(c.Request.Context(), c.Param("userId"), &req, middleware.GetUserID(c), middleware.GetOrgID(c))
+	if err != nil {
+		h.handleError(c, err, "failed to save volunteer profile")
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// handleError 统一处理志愿者档案错误
+func (h *VolunteerProfileHandler) handleError(c *gin.Context, err error, msg string) {
+	switch {
+	case errors.Is(err, service.ErrVolunteerProfileNotFound):
+		response.NotFound(c, "volunteer profile not found")
+	case errors.Is(err, service.ErrVolunteerVacationNotFound):
+		response.NotFound(c, "vacation not found")
+	case errors.Is(err, service.ErrUserNotFound):
+		response.NotFound(c, "user not found")
+	case errors.Is(err, service.ErrDialectGroupNotFound):
+		response.BadRequest(c, "dialect group not found")
+	case errors.Is(err, service.ErrVolunteerProfileInvalid):
+		response.BadRequest(c, err.Error())
+	default:
+		logger.Error("Volunteer profile operation failed", logger.Err(err))
+		response.InternalServerError(c, msg)
+	}
+}
@@ -189,7 +189,7 @@ func ResourceFromPath(path string) entity.PermissionResource {
 	resource := parts[0]
 	
 	switch resource {
-	case "users":
+	case "users", "volunteers":
 		return entity.ResourceUser
 	case "organizations":
 		return entity.ResourceOrganization
@@ -197,7 +197,7 @@ func ResourceFromPath(path string) entity.PermissionResource {
 		return entity.ResourceTask
 	case "missing-persons":
 		return entity.ResourceMissingPerson
-	case "dialects":
+	case "dialects", "dialect-sessions", "dialect-groups", "dialect-reviews", "dialect-comments", "dialect-playlists":
 		return entity.ResourceDialect
 	case "files":
 		return entity.ResourceFile
@@ -207,6 +207,8 @@ func ResourceFromPath(path string) entity.PermissionResource {
 		return entity.ResourceAuditLog
 	case "dashboard":
 		return entity.ResourceDashboard
+	case "family-searchers":
+		return entity.ResourceFamilySearcher
 	default:
 		return entity.ResourceSystem
 	}
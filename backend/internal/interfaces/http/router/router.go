@@ -18,8 +18,26 @@ type Router struct {
 	userHandler              *handler.UserHandler
 	organizationHandler      *handler.OrganizationHandler
 	missingPersonHandler     *handler.MissingPersonHandler
+	missingPersonGeoHandler  *handler.MissingPersonGeoHandler
+	publicCaseHandler        *handler.PublicCaseHandler
+	trackVerifyHandler       *handler.TrackVerifyHandler
+	missingPhotoHandler      *handler.MissingPhotoHandler
+	importHandler            *handler.ImportHandler
+	caseExportHandler        *handler.CaseExportHandler
+	caseSearchHandler        *handler.CaseSearchHandler
+	urgencyHandler           *handler.UrgencyHandler
+	reunionHandler           *handler.ReunionHandler
+	dnaSampleHandler         *handler.DNASampleHandler
+	familySearcherHandler    *handler.FamilySearcherHandler
+	dialectSessionHandler    *handler.DialectSessionHandler
+	dialectGroupHandler      *handler.DialectGroupHandler
+	dialectReviewHandler     *handler.DialectReviewHandler
+	dialectCommentHandler    *handler.DialectCommentHandler
+	dialectPlaylistHandler   *handler.DialectPlaylistHandler
 	dialectHandler           *handler.DialectHandler
 	taskHandler              *handler.TaskHandler
+	taskDispatchHandler      *handler.TaskDispatchHandler
+	volunteerProfileHandler  *handler.VolunteerProfileHandler
 	uploadHandler            *handler.UploadHandler
 	dashboardHandler         *handler.DashboardHandler
 	auditHandler             *handler.AuditHandler
@@ -38,8 +56,26 @@ func NewRouter(
 	userHandler *handler.UserHandler,
 	organizationHandler *handler.OrganizationHandler,
 	missingPersonHandler *handler.MissingPersonHandler,
+	missingPersonGeoHandler *handler.MissingPersonGeoHandler,
+	publicCaseHandler *handler.PublicCaseHandler,
+	trackVerifyHandler *handler.TrackVerifyHandler,
+	missingPhotoHandler *handler.MissingPhotoHandler,
+	importHandler *handler.ImportHandler,
+	caseExportHandler *handler.CaseExportHandler,
+	caseSearchHandler *handler.CaseSearchHandler,
+	urgencyHandler *handler.UrgencyHandler,
+	reunionHandler *handler.ReunionHandler,
+	dnaSampleHandler *handler.DNASampleHandler,
+	familySearcherHandler *handler.FamilySearcherHandler,
+	dialectSessionHandler *handler.DialectSessionHandler,
+	dialectGroupHandler *handler.DialectGroupHandler,
+	dialectReviewHandler *handler.DialectReviewHandler,
+	dialectCommentHandler *handler.DialectCommentHandler,
+	dialectPlaylistHandler *handler.DialectPlaylistHandler,
 	dialectHandler *handler.DialectHandler,
 	taskHandler *handler.TaskHandler,
+	taskDispatchHandler *handler.TaskDispatchHandler,
+	volunteerProfileHandler *handler.VolunteerProfileHandler,
 	uploadHandler *handler.UploadHandler,
 	dashboardHandler *handler.DashboardHandler,
 	auditHandler *handler.AuditHandler,
@@ -84,8 +120,26 @@ func NewRouter(
 		userHandler:              userHandler,
 		organizationHandler:      organizationHandler,
 		missingPersonHandler:     missingPersonHandler,
+		missingPersonGeoHandler:  missingPersonGeoHandler,
+		publicCaseHandler:        publicCaseHandler,
+		trackVerifyHandler:       trackVerifyHandler,
+		missingPhotoHandler:      missingPhotoHandler,
+		importHandler:            importHandler,
+		caseExportHandler:        caseExportHandler,
+		caseSearchHandler:        caseSearchHandler,
+		urgencyHandler:           urgencyHandler,
+		reunionHandler:           reunionHandler,
+		dnaSampleHandler:         dnaSampleHandler,
+		familySearcherHandler:    familySearcherHandler,
+		dialectSessionHandler:    dialectSessionHandler,
+		dialectGroupHandler:      dialectGroupHandler,
+		dialectReviewHandler:     dialectReviewHandler,
+		dialectCommentHandler:    dialectCommentHandler,
+		dialectPlaylistHandler:   dialectPlaylistHandler,
 		dialectHandler:           dialectHandler,
 		taskHandler:              taskHandler,
+		taskDispatchHandler:      taskDispatchHandler,
+		volunteerProfileHandler:  volunteerProfileHandler,
 		uploadHandler:            uploadHandler,
 		dashboardHandler:         dashboardHandler,
 		auditHandler:             auditHandler,
@@ -125,8 +179,26 @@ func (r *Router) Setup() {
 	r.userHandler.RegisterRoutes(api, r.authMiddleware)
 	r.organizationHandler.RegisterRoutes(api, r.authMiddleware)
 	r.missingPersonHandler.RegisterRoutes(api, r.authMiddleware)
+	r.missingPersonGeoHandler.RegisterRoutes(api, r.authMiddleware)
+	r.trackVerifyHandler.RegisterRoutes(api, r.authMiddleware)
+	r.missingPhotoHandler.RegisterRoutes(api, r.authMiddleware)
+	r.importHandler.RegisterRoutes(api, r.authMiddleware)
+	r.caseExportHandler.RegisterRoutes(api, r.authMiddleware)
+	r.caseSearchHandler.RegisterRoutes(api, r.authMiddleware)
+	r.urgencyHandler.RegisterRoutes(api, r.authMiddleware)
+	r.reunionHandler.RegisterRoutes(api, r.authMiddleware)
+	r.dnaSampleHandler.RegisterRoutes(api, r.authMiddleware)
+	r.familySearcherHandler.RegisterRoutes(api, r.authMiddleware)
+	r.publicCaseHandler.RegisterRoutes(api)
 	r.dialectHandler.RegisterRoutes(api, r.authMiddleware)
+	r.dialectSessionHandler.RegisterRoutes(api, r.authMiddleware)
+	r.dialectGroupHandler.RegisterRoutes(api, r.authMiddleware)
+	r.dialectReviewHandler.RegisterRoutes(api, r.authMiddleware)
+	r.dialectCommentHandler.RegisterRoutes(api, r.authMiddleware)
+	r.dialectPlaylistHandler.RegisterRoutes(api, r.authMiddleware)
 	r.taskHandler.RegisterRoutes(api, r.authMiddleware)
+	r.taskDispatchHandler.RegisterRoutes(api, r.authMiddleware)
+	r.volunteerProfileHandler.RegisterRoutes(api, r.authMiddleware)
 	r.uploadHandler.RegisterRoutes(api, r.authMiddleware)
 	r.dashboardHandler.RegisterRoutes(api, r.authMiddleware)
 	r.auditHandler.RegisterRoutes(api, r.authMiddleware)
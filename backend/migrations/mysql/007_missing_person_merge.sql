@@ -0,0 +1,41 @@
+-- Migration: Missing Person Duplicate Detection & Merge
+-- Date: 2026-10-16
+-- Description: Add name pinyin for duplicate detection, merge pointer and merge history
+
+ALTER TABLE ty_missing_persons
+    ADD COLUMN name_pinyin VARCHAR(100) NULL COMMENT '姓名全拼（繁简归一），用于重复案件检测',
+    ADD COLUMN merged_into_id CHAR(36) NULL DEFAULT NULL COMMENT '被合并到的保留案件ID',
+    ADD INDEX idx_missing_persons_name_pinyin (name_pinyin),
+    ADD INDEX idx_missing_persons_merged_into (merged_into_id),
+    ADD CONSTRAINT fk_missing_person_merged_into FOREIGN KEY (merged_into_id) REFERENCES ty_missing_persons(id) ON DELETE SET NULL ON UPDATE CASCADE;
+
+CREATE TABLE IF NOT EXISTS ty_missing_person_merges (
+    id CHAR(36) PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL DEFAULT NULL,
+
+    survivor_id CHAR(36) NOT NULL COMMENT '保留的案件',
+    survivor_case_no VARCHAR(50) NULL COMMENT '保留案件编号',
+    merged_id CHAR(36) NOT NULL COMMENT '被合并的案件',
+    merged_case_no VARCHAR(50) NULL COMMENT '被合并案件编号',
+    operator_id CHAR(36) NOT NULL COMMENT '操作人',
+    org_id CHAR(36) NULL COMMENT '所属组织',
+    score DOUBLE NOT NULL DEFAULT 0 COMMENT '重复评分',
+    reason TEXT NULL COMMENT '合并原因',
+
+    -- 迁移的关联数据数量
+    track_count BIGINT NOT NULL DEFAULT 0 COMMENT '迁移的线索数',
+    photo_count BIGINT NOT NULL DEFAULT 0 COMMENT '迁移的照片数',
+    task_count BIGINT NOT NULL DEFAULT 0 COMMENT '迁移的任务数',
+    file_count BIGINT NOT NULL DEFAULT 0 COMMENT '迁移的文件数',
+
+    INDEX idx_mp_merges_survivor (survivor_id),
+    INDEX idx_mp_merges_merged (merged_id),
+    INDEX idx_mp_merges_org (org_id),
+    INDEX idx_mp_merges_deleted_at (deleted_at),
+    CONSTRAINT fk_mp_merge_survivor FOREIGN KEY (survivor_id) REFERENCES ty_missing_persons(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_mp_merge_merged FOREIGN KEY (merged_id) REFERENCES ty_missing_persons(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_mp_merge_operator FOREIGN KEY (operator_id) REFERENCES ty_users(id) ON DELETE RESTRICT ON UPDATE CASCADE,
+    CONSTRAINT fk_mp_merge_org FOREIGN KEY (org_id) REFERENCES ty_organizations(id) ON DELETE SET NULL ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='重复案件合并记录表';
@@ -0,0 +1,15 @@
+-- Migration: Geospatial Search
+-- Date: 2026-10-16
+-- Description: Add coordinates and geohash columns for radius / bounding-box queries.
+--              MySQL 不使用 PostGIS，空间查询依赖 geohash 前缀索引 + 经纬度范围过滤；
+--              历史数据执行 `go run cmd/app/main.go -backfill-geohash` 回填
+
+ALTER TABLE ty_missing_persons
+    ADD COLUMN lat DOUBLE COMMENT '走失地点纬度',
+    ADD COLUMN lng DOUBLE COMMENT '走失地点经度',
+    ADD COLUMN geohash VARCHAR(12) COMMENT '走失地点 geohash（9位）',
+    ADD INDEX idx_missing_persons_geohash (geohash);
+
+ALTER TABLE ty_missing_person_tracks
+    ADD COLUMN geohash VARCHAR(12) COMMENT '线索位置 geohash（9位）',
+    ADD INDEX idx_mp_tracks_geohash (geohash);
@@ -0,0 +1,11 @@
+-- Migration: Public Case Board & Lead Submission
+-- Date: 2026-10-16
+-- Description: Allow anonymous leads from the public case board
+
+ALTER TABLE ty_missing_person_tracks
+    MODIFY COLUMN reporter_id CHAR(36) NULL COMMENT '报告人ID（公众提交时为空）',
+    ADD COLUMN source VARCHAR(20) NOT NULL DEFAULT 'volunteer' COMMENT '线索来源: volunteer-志愿者录入, public-公众提交',
+    ADD COLUMN submitter_name VARCHAR(50) COMMENT '公众提交人姓名（仅内部可见）',
+    ADD COLUMN submitter_phone VARCHAR(20) COMMENT '公众提交人电话（仅内部可见）',
+    ADD COLUMN submitter_ip VARCHAR(64) COMMENT '公众提交人IP',
+    ADD INDEX idx_tracks_source_pending (source, status);
@@ -0,0 +1,37 @@
+-- Migration: Sighting Verification Workflow
+-- Date: 2026-10-16
+-- Description: Track verification lifecycle (pending -> verifying -> confirmed/rejected),
+--              verification history and organization coordinates for verify-task dispatch
+
+ALTER TABLE ty_missing_person_tracks DROP CHECK chk_mpt_status;
+
+ALTER TABLE ty_missing_person_tracks
+    MODIFY COLUMN status VARCHAR(20) NOT NULL DEFAULT 'pending' COMMENT '状态: pending-待核实, verifying-核实中, confirmed-已确认, rejected-已驳回',
+    ADD COLUMN verifier_id CHAR(36) NULL COMMENT '核实人ID',
+    ADD COLUMN verified_at TIMESTAMP NULL DEFAULT NULL COMMENT '核实完成时间',
+    ADD COLUMN status_reason TEXT COMMENT '确认/驳回原因',
+    ADD INDEX idx_tracks_verifier (verifier_id),
+    ADD CONSTRAINT fk_mpt_verifier FOREIGN KEY (verifier_id) REFERENCES ty_users(id) ON DELETE SET NULL ON UPDATE CASCADE,
+    ADD CONSTRAINT chk_mpt_status CHECK (status IN ('pending', 'verifying', 'confirmed', 'rejected'));
+
+CREATE TABLE IF NOT EXISTS ty_missing_person_track_logs (
+    id CHAR(36) PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL DEFAULT NULL,
+
+    track_id CHAR(36) NOT NULL COMMENT '线索ID',
+    user_id CHAR(36) NOT NULL COMMENT '操作人ID',
+    action VARCHAR(50) NOT NULL COMMENT '动作: verify-开始核实, confirm-确认, reject-驳回',
+    old_status VARCHAR(20) COMMENT '原状态',
+    new_status VARCHAR(20) COMMENT '新状态',
+    reason TEXT COMMENT '原因',
+
+    INDEX idx_track_logs_track (track_id),
+    CONSTRAINT fk_tlog_track FOREIGN KEY (track_id) REFERENCES ty_missing_person_tracks(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_tlog_user FOREIGN KEY (user_id) REFERENCES ty_users(id) ON DELETE RESTRICT ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='线索核实日志表';
+
+ALTER TABLE ty_organizations
+    ADD COLUMN lat DOUBLE COMMENT '组织所在地纬度',
+    ADD COLUMN lng DOUBLE COMMENT '组织所在地经度';
@@ -0,0 +1,51 @@
+-- Migration: Photo Metadata Pipeline
+-- Date: 2026-10-16
+-- Description: Image dimensions, perceptual hash, EXIF capture hints and derived variants
+--              (public/thumbnail/share) for uploaded files and missing person photos,
+--              plus cross-case similar photo records
+
+ALTER TABLE ty_files
+    ADD COLUMN width INT NOT NULL DEFAULT 0 COMMENT '图片宽度',
+    ADD COLUMN height INT NOT NULL DEFAULT 0 COMMENT '图片高度',
+    ADD COLUMN phash VARCHAR(16) COMMENT '感知哈希（16位十六进制）',
+    ADD COLUMN taken_at TIMESTAMP NULL DEFAULT NULL COMMENT 'EXIF 拍摄时间',
+    ADD COLUMN lat DOUBLE COMMENT 'EXIF 拍摄位置纬度',
+    ADD COLUMN lng DOUBLE COMMENT 'EXIF 拍摄位置经度',
+    ADD COLUMN variants JSON COMMENT '衍生版本: public-去除元数据, thumbnail-缩略图, share-水印分享图',
+    ADD INDEX idx_files_phash (phash);
+
+ALTER TABLE ty_missing_photos
+    ADD COLUMN file_id CHAR(36) NULL COMMENT '文件ID',
+    ADD COLUMN thumbnail_url VARCHAR(500) COMMENT '缩略图URL',
+    ADD COLUMN share_url VARCHAR(500) COMMENT '带水印的分享版本URL',
+    ADD COLUMN phash VARCHAR(16) COMMENT '感知哈希，用于发现不同案件中的相同照片',
+    ADD COLUMN width INT NOT NULL DEFAULT 0 COMMENT '图片宽度',
+    ADD COLUMN height INT NOT NULL DEFAULT 0 COMMENT '图片高度',
+    ADD COLUMN taken_at TIMESTAMP NULL DEFAULT NULL COMMENT 'EXIF 拍摄时间（线索参考）',
+    ADD COLUMN lat DOUBLE COMMENT 'EXIF 拍摄位置纬度',
+    ADD COLUMN lng DOUBLE COMMENT 'EXIF 拍摄位置经度',
+    ADD INDEX idx_photos_file (file_id),
+    ADD INDEX idx_photos_phash (phash),
+    ADD CONSTRAINT fk_mp_photos_file FOREIGN KEY (file_id) REFERENCES ty_files(id) ON DELETE SET NULL ON UPDATE CASCADE;
+
+CREATE TABLE IF NOT EXISTS ty_missing_photo_matches (
+    id CHAR(36) PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL DEFAULT NULL,
+
+    photo_id CHAR(36) NOT NULL COMMENT '照片ID',
+    missing_person_id CHAR(36) NOT NULL COMMENT '案件ID',
+    matched_photo_id CHAR(36) NOT NULL COMMENT '相似照片ID',
+    matched_person_id CHAR(36) NOT NULL COMMENT '相似照片所属案件ID',
+    distance INT NOT NULL COMMENT '感知哈希汉明距离',
+
+    INDEX idx_photo_matches_photo (photo_id),
+    INDEX idx_photo_matches_person (missing_person_id),
+    INDEX idx_photo_matches_matched_photo (matched_photo_id),
+    INDEX idx_photo_matches_matched_person (matched_person_id),
+    CONSTRAINT fk_pm_photo FOREIGN KEY (photo_id) REFERENCES ty_missing_photos(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_pm_person FOREIGN KEY (missing_person_id) REFERENCES ty_missing_persons(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_pm_matched_photo FOREIGN KEY (matched_photo_id) REFERENCES ty_missing_photos(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_pm_matched_person FOREIGN KEY (matched_person_id) REFERENCES ty_missing_persons(id) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='跨案件相似照片表';
@@ -0,0 +1,58 @@
+-- Migration: Bulk Case Import
+-- Date: 2026-10-16
+-- Description: Background import jobs for partner spreadsheets (CSV/XLSX) with dry-run preview,
+--              row-level results for the error report, and saved column mapping templates
+
+CREATE TABLE IF NOT EXISTS ty_import_jobs (
+    id CHAR(36) PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL DEFAULT NULL,
+
+    org_id CHAR(36) NOT NULL COMMENT '组织ID',
+    creator_id CHAR(36) NOT NULL COMMENT '发起人ID',
+    file_name VARCHAR(255) NOT NULL COMMENT '文件名',
+    file_path VARCHAR(500) NOT NULL COMMENT '原文件存储路径',
+    format VARCHAR(10) NOT NULL COMMENT '格式: csv, xlsx',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' COMMENT '状态: pending-等待, running-执行中, completed-完成, failed-失败',
+    dry_run TINYINT(1) NOT NULL DEFAULT 0 COMMENT '预检：只校验和查重，不创建案件',
+    skip_duplicates TINYINT(1) NOT NULL DEFAULT 1 COMMENT '跳过疑似重复的行',
+    source_job_id CHAR(36) NULL COMMENT '确认导入时对应的预检任务',
+
+    mapping TEXT COMMENT '列映射 JSON: 字段 -> 列名',
+    header TEXT COMMENT '原表头 JSON',
+    results LONGTEXT COMMENT '逐行结果 JSON',
+
+    total_rows INT NOT NULL DEFAULT 0 COMMENT '总行数',
+    processed_rows INT NOT NULL DEFAULT 0 COMMENT '已处理行数',
+    created_rows INT NOT NULL DEFAULT 0 COMMENT '已创建案件数',
+    valid_rows INT NOT NULL DEFAULT 0 COMMENT '预检通过行数',
+    failed_rows INT NOT NULL DEFAULT 0 COMMENT '校验失败行数',
+    duplicate_rows INT NOT NULL DEFAULT 0 COMMENT '疑似重复跳过行数',
+    error TEXT COMMENT '任务失败原因',
+    started_at TIMESTAMP NULL DEFAULT NULL COMMENT '开始时间',
+    finished_at TIMESTAMP NULL DEFAULT NULL COMMENT '结束时间',
+
+    INDEX idx_import_jobs_org (org_id, created_at),
+    INDEX idx_import_jobs_creator (creator_id),
+    INDEX idx_import_jobs_status (status),
+    CONSTRAINT fk_ij_org FOREIGN KEY (org_id) REFERENCES ty_organizations(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_ij_creator FOREIGN KEY (creator_id) REFERENCES ty_users(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_ij_source FOREIGN KEY (source_job_id) REFERENCES ty_import_jobs(id) ON DELETE SET NULL ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='案件批量导入任务表';
+
+CREATE TABLE IF NOT EXISTS ty_import_templates (
+    id CHAR(36) PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL DEFAULT NULL,
+
+    org_id CHAR(36) NOT NULL COMMENT '组织ID',
+    creator_id CHAR(36) NOT NULL COMMENT '创建人ID',
+    name VARCHAR(100) NOT NULL COMMENT '模板名称',
+    mapping TEXT NOT NULL COMMENT '列映射 JSON: 字段 -> 列名',
+
+    INDEX idx_import_templates_org (org_id),
+    CONSTRAINT fk_it_org FOREIGN KEY (org_id) REFERENCES ty_organizations(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_it_creator FOREIGN KEY (creator_id) REFERENCES ty_users(id) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='导入列映射模板表';
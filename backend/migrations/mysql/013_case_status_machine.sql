@@ -0,0 +1,25 @@
+-- Migration: Case Status State Machine
+-- Date: 2026-10-16
+-- Description: Guarded case status transitions (start_search/mark_found/reunite/close/reopen),
+--              latest transition reason and full status history
+
+ALTER TABLE ty_missing_persons
+    ADD COLUMN status_reason TEXT COMMENT '最近一次状态变更原因（关闭、重新打开等）';
+
+CREATE TABLE IF NOT EXISTS ty_missing_person_status_logs (
+    id CHAR(36) PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL DEFAULT NULL,
+
+    missing_person_id CHAR(36) NOT NULL COMMENT '案件ID',
+    user_id CHAR(36) NOT NULL COMMENT '操作人ID',
+    event VARCHAR(30) NOT NULL COMMENT '事件: start_search-开始搜索, mark_found-标记找到, reunite-团聚, close-关闭, reopen-重新打开, merge-合并',
+    from_status VARCHAR(20) NOT NULL COMMENT '原状态',
+    to_status VARCHAR(20) NOT NULL COMMENT '新状态',
+    reason TEXT COMMENT '原因',
+
+    INDEX idx_mp_status_logs_person (missing_person_id, created_at),
+    CONSTRAINT fk_mpslog_person FOREIGN KEY (missing_person_id) REFERENCES ty_missing_persons(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_mpslog_user FOREIGN KEY (user_id) REFERENCES ty_users(id) ON DELETE RESTRICT ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='案件状态变更记录表';
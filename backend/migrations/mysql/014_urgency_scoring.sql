@@ -0,0 +1,38 @@
+-- Migration: Automatic Urgency Scoring
+-- Date: 2026-10-16
+-- Description: Case urgency score with manual override flag and escalation time,
+--              per-organization scoring rules (inherited from parent organizations)
+
+ALTER TABLE ty_missing_persons
+    ADD COLUMN urgency_score INT NOT NULL DEFAULT 0 COMMENT '紧急程度评分',
+    ADD COLUMN urgency_manual TINYINT(1) NOT NULL DEFAULT 0 COMMENT '紧急程度为人工设置，评分不覆盖等级',
+    ADD COLUMN urgency_scored_at TIMESTAMP NULL DEFAULT NULL COMMENT '最近评分时间',
+    ADD COLUMN urgency_escalated_at TIMESTAMP NULL DEFAULT NULL COMMENT '升级为紧急并通知的时间，降级后清空';
+
+CREATE TABLE IF NOT EXISTS ty_urgency_rules (
+    id CHAR(36) PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL DEFAULT NULL,
+
+    org_id CHAR(36) NOT NULL COMMENT '组织ID',
+    child_max_age INT NOT NULL COMMENT '儿童年龄上限',
+    child_weight INT NOT NULL COMMENT '儿童加分',
+    elder_min_age INT NOT NULL COMMENT '老人年龄下限',
+    elder_weight INT NOT NULL COMMENT '老人加分',
+    medical_weight INT NOT NULL COMMENT '疾病、认知障碍加分',
+    medical_keywords TEXT COMMENT '补充的疾病、认知障碍关键词，逗号分隔',
+    golden_hours INT NOT NULL COMMENT '黄金搜救时间（小时）',
+    golden_weight INT NOT NULL COMMENT '黄金搜救时间内加分',
+    season_weight INT NOT NULL COMMENT '严寒、酷暑季节加分',
+    sighting_hours INT NOT NULL COMMENT '近期线索统计窗口（小时）',
+    sighting_weight INT NOT NULL COMMENT '每条近期线索加分',
+    sighting_max_weight INT NOT NULL COMMENT '近期线索加分上限',
+    critical_score INT NOT NULL COMMENT '紧急阈值',
+    high_score INT NOT NULL COMMENT '高阈值',
+    medium_score INT NOT NULL COMMENT '中阈值',
+    escalate TINYINT(1) NOT NULL DEFAULT 1 COMMENT '升级为紧急时是否通知组织管理员',
+
+    UNIQUE KEY uk_urgency_rules_org (org_id),
+    CONSTRAINT fk_urgency_rule_org FOREIGN KEY (org_id) REFERENCES ty_organizations(id) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='组织紧急程度评分规则表';
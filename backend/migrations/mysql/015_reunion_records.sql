@@ -0,0 +1,62 @@
+-- Migration: Reunion Records and Follow-up Visits
+-- Date: 2026-10-17
+-- Description: Structured reunion record (identity confirmation, verification methods, location,
+--              publicity consent, photos) and follow-up visits at 1, 3 and 12 months
+
+CREATE TABLE IF NOT EXISTS ty_reunion_records (
+    id CHAR(36) PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL DEFAULT NULL,
+
+    missing_person_id CHAR(36) NOT NULL COMMENT '案件ID',
+    recorded_by_id CHAR(36) NOT NULL COMMENT '登记人',
+    confirmed_by_id CHAR(36) NOT NULL COMMENT '确认身份的用户',
+    confirmer_name VARCHAR(100) COMMENT '外部确认人（如派出所民警）',
+    verification_methods VARCHAR(100) NOT NULL COMMENT '身份确认方式，逗号分隔: dna, documents, family_recognition, other',
+    verification_note TEXT COMMENT '确认说明',
+    reunion_time TIMESTAMP NOT NULL COMMENT '团聚时间',
+    location VARCHAR(200) COMMENT '团聚地点',
+    province VARCHAR(50),
+    city VARCHAR(50),
+    district VARCHAR(50),
+    address VARCHAR(200),
+    lat DECIMAL(10, 8),
+    lng DECIMAL(11, 8),
+    publicity_consent TINYINT(1) NOT NULL DEFAULT 0 COMMENT '是否同意用于宣传报道',
+    consent_by VARCHAR(100) COMMENT '宣传授权人',
+    photos TEXT COMMENT '团聚照片 URL 列表（JSON 数组）',
+    note TEXT COMMENT '备注',
+
+    UNIQUE KEY uk_reunion_records_person (missing_person_id),
+    INDEX idx_reunion_records_confirmed_by (confirmed_by_id),
+    CONSTRAINT fk_reunion_record_person FOREIGN KEY (missing_person_id) REFERENCES ty_missing_persons(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_reunion_record_recorder FOREIGN KEY (recorded_by_id) REFERENCES ty_users(id) ON DELETE RESTRICT ON UPDATE CASCADE,
+    CONSTRAINT fk_reunion_record_confirmer FOREIGN KEY (confirmed_by_id) REFERENCES ty_users(id) ON DELETE RESTRICT ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='团聚记录表';
+
+CREATE TABLE IF NOT EXISTS ty_reunion_follow_ups (
+    id CHAR(36) PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL DEFAULT NULL,
+
+    reunion_id CHAR(36) NOT NULL COMMENT '团聚记录ID',
+    missing_person_id CHAR(36) NOT NULL COMMENT '案件ID',
+    month INT NOT NULL COMMENT '回访节点（团聚后月数）: 1, 3, 12',
+    due_at TIMESTAMP NOT NULL COMMENT '回访到期日',
+    status VARCHAR(20) NOT NULL DEFAULT 'scheduled' COMMENT '状态: scheduled-待生成任务, tasked-已生成回访任务, completed-已回访',
+    task_id CHAR(36) NULL COMMENT '回访任务ID',
+    outcome VARCHAR(20) COMMENT '回访结果: stable, needs_support, missing_again, unreachable',
+    note TEXT COMMENT '回访记录',
+    visitor_id CHAR(36) NULL COMMENT '回访人',
+    visited_at TIMESTAMP NULL DEFAULT NULL COMMENT '回访时间',
+
+    INDEX idx_reunion_follow_ups_reunion (reunion_id),
+    INDEX idx_reunion_follow_ups_person (missing_person_id),
+    INDEX idx_reunion_follow_ups_due (status, due_at),
+    CONSTRAINT fk_follow_up_reunion FOREIGN KEY (reunion_id) REFERENCES ty_reunion_records(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_follow_up_person FOREIGN KEY (missing_person_id) REFERENCES ty_missing_persons(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_follow_up_task FOREIGN KEY (task_id) REFERENCES ty_tasks(id) ON DELETE SET NULL ON UPDATE CASCADE,
+    CONSTRAINT fk_follow_up_visitor FOREIGN KEY (visitor_id) REFERENCES ty_users(id) ON DELETE SET NULL ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='团聚回访表';
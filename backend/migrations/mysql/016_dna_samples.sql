@@ -0,0 +1,62 @@
+-- Migration: DNA / Blood Sample Registry
+-- Date: 2026-10-17
+-- Description: Chain-of-custody and lab submission metadata for DNA samples collected from
+--              missing persons and family members. No raw genetic data is stored.
+
+CREATE TABLE IF NOT EXISTS ty_dna_samples (
+    id CHAR(36) PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL DEFAULT NULL,
+
+    missing_person_id CHAR(36) NOT NULL COMMENT '案件ID',
+    org_id CHAR(36) NOT NULL COMMENT '组织ID',
+    sample_no VARCHAR(50) NOT NULL COMMENT '样本编号',
+    subject_type VARCHAR(20) NOT NULL COMMENT '采样对象: missing_person, family_member',
+    sample_type VARCHAR(20) NOT NULL COMMENT '样本类型: blood, saliva, hair, other',
+    donor_name VARCHAR(50) COMMENT '采样家属姓名',
+    donor_relation VARCHAR(20) COMMENT '与走失者关系',
+    donor_phone VARCHAR(20) COMMENT '采样家属电话',
+    collected_at TIMESTAMP NOT NULL COMMENT '采集时间',
+    collection_site VARCHAR(200) NOT NULL COMMENT '采集地点',
+    collector_id CHAR(36) NOT NULL COMMENT '采集人',
+    holder VARCHAR(100) COMMENT '当前保管人或保管机构',
+    status VARCHAR(20) NOT NULL DEFAULT 'collected' COMMENT '状态: collected, submitted, resulted, rejected, destroyed',
+    lab_name VARCHAR(100) COMMENT '送检实验室',
+    lab_case_no VARCHAR(100) COMMENT '实验室受理编号',
+    submitted_at TIMESTAMP NULL DEFAULT NULL COMMENT '送检时间',
+    result VARCHAR(20) COMMENT '比对结果: match, no_match, inconclusive',
+    result_ref VARCHAR(100) COMMENT '比对结果文书编号',
+    result_at TIMESTAMP NULL DEFAULT NULL COMMENT '结果时间',
+    note TEXT COMMENT '备注',
+
+    UNIQUE KEY uk_dna_samples_no (sample_no),
+    INDEX idx_dna_samples_person (missing_person_id),
+    INDEX idx_dna_samples_org (org_id),
+    INDEX idx_dna_samples_collector (collector_id),
+    INDEX idx_dna_samples_status (status),
+    CONSTRAINT fk_dna_sample_person FOREIGN KEY (missing_person_id) REFERENCES ty_missing_persons(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_dna_sample_org FOREIGN KEY (org_id) REFERENCES ty_organizations(id) ON DELETE RESTRICT ON UPDATE CASCADE,
+    CONSTRAINT fk_dna_sample_collector FOREIGN KEY (collector_id) REFERENCES ty_users(id) ON DELETE RESTRICT ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='DNA / 血样登记表（仅保管链及送检元数据）';
+
+CREATE TABLE IF NOT EXISTS ty_dna_sample_custody_logs (
+    id CHAR(36) PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL DEFAULT NULL,
+
+    sample_id CHAR(36) NOT NULL COMMENT '样本ID',
+    action VARCHAR(20) NOT NULL COMMENT '事件: collect, transfer, submit, result, reject, destroy',
+    from_status VARCHAR(20) COMMENT '事件前状态',
+    to_status VARCHAR(20) NOT NULL COMMENT '事件后状态',
+    operator_id CHAR(36) NOT NULL COMMENT '操作人',
+    holder VARCHAR(100) COMMENT '接收人或保管机构',
+    location VARCHAR(200) COMMENT '地点',
+    note TEXT COMMENT '说明',
+    occurred_at TIMESTAMP NOT NULL COMMENT '发生时间',
+
+    INDEX idx_dna_custody_logs_sample (sample_id, occurred_at),
+    CONSTRAINT fk_dna_custody_sample FOREIGN KEY (sample_id) REFERENCES ty_dna_samples(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_dna_custody_operator FOREIGN KEY (operator_id) REFERENCES ty_users(id) ON DELETE RESTRICT ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='DNA 样本保管链记录表';
@@ -0,0 +1,107 @@
+-- Migration: Family Searcher Profiles
+-- Date: 2026-10-17
+-- Description: Profiles for families searching for lost relatives (家寻亲) and adults searching
+--              for their birth families (亲寻家), with contacts, relatives graph and case links
+
+CREATE TABLE IF NOT EXISTS ty_family_searchers (
+    id CHAR(36) PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL DEFAULT NULL,
+
+    kind VARCHAR(20) NOT NULL COMMENT '寻亲类型: seeking_relative-家寻亲, seeking_family-亲寻家',
+    status VARCHAR(20) NOT NULL DEFAULT 'active' COMMENT '状态: active, found, closed',
+
+    name VARCHAR(50) NOT NULL COMMENT '登记人姓名',
+    gender VARCHAR(10) COMMENT '登记人性别',
+    birth_year INT COMMENT '登记人出生年份',
+    province VARCHAR(50) COMMENT '现居省份',
+    city VARCHAR(50) COMMENT '现居城市',
+
+    lost_name VARCHAR(50) COMMENT '被寻找人姓名或乳名',
+    lost_name_pinyin VARCHAR(100) COMMENT '被寻找人姓名全拼',
+    lost_gender VARCHAR(10) COMMENT '被寻找人性别',
+    lost_birth_year INT COMMENT '被寻找人出生年份',
+    lost_age INT COMMENT '走失时年龄',
+    lost_time TIMESTAMP NULL DEFAULT NULL COMMENT '走失时间',
+    lost_province VARCHAR(50) COMMENT '走失省份',
+    lost_city VARCHAR(50) COMMENT '走失城市',
+    lost_district VARCHAR(50) COMMENT '走失区县',
+    lost_address VARCHAR(255) COMMENT '走失地址',
+    dialect VARCHAR(50) COMMENT '口音或方言',
+    features TEXT COMMENT '体貌特征',
+    memories TEXT COMMENT '记忆片段',
+    description TEXT COMMENT '其他描述',
+
+    org_id CHAR(36) NOT NULL COMMENT '组织ID',
+    registered_by_id CHAR(36) NOT NULL COMMENT '登记人（志愿者）',
+
+    INDEX idx_family_searchers_kind (kind, status),
+    INDEX idx_family_searchers_org (org_id),
+    INDEX idx_family_searchers_lost_pinyin (lost_name_pinyin),
+    INDEX idx_family_searchers_lost_gender (lost_gender),
+    INDEX idx_family_searchers_lost_province (lost_province),
+    CONSTRAINT fk_family_searcher_org FOREIGN KEY (org_id) REFERENCES ty_organizations(id) ON DELETE RESTRICT ON UPDATE CASCADE,
+    CONSTRAINT fk_family_searcher_registrar FOREIGN KEY (registered_by_id) REFERENCES ty_users(id) ON DELETE RESTRICT ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='寻亲者档案表';
+
+CREATE TABLE IF NOT EXISTS ty_family_searcher_contacts (
+    id CHAR(36) PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL DEFAULT NULL,
+
+    searcher_id CHAR(36) NOT NULL COMMENT '寻亲者ID',
+    name VARCHAR(50) NOT NULL COMMENT '联系人姓名',
+    relation VARCHAR(20) COMMENT '与登记人关系',
+    phone VARCHAR(20) NOT NULL COMMENT '电话',
+    wechat VARCHAR(50) COMMENT '微信',
+    is_primary TINYINT(1) NOT NULL DEFAULT 0 COMMENT '是否主要联系人',
+    note VARCHAR(255) COMMENT '备注',
+
+    INDEX idx_family_searcher_contacts_searcher (searcher_id),
+    INDEX idx_family_searcher_contacts_phone (phone),
+    CONSTRAINT fk_family_contact_searcher FOREIGN KEY (searcher_id) REFERENCES ty_family_searchers(id) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='寻亲者联系人表';
+
+CREATE TABLE IF NOT EXISTS ty_family_searcher_relatives (
+    id CHAR(36) PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL DEFAULT NULL,
+
+    searcher_id CHAR(36) NOT NULL COMMENT '寻亲者ID',
+    name VARCHAR(50) COMMENT '亲属姓名',
+    relation VARCHAR(20) NOT NULL COMMENT '与被寻找人的关系',
+    gender VARCHAR(10) COMMENT '性别',
+    birth_year INT COMMENT '出生年份',
+    hometown VARCHAR(255) COMMENT '籍贯',
+    deceased TINYINT(1) NOT NULL DEFAULT 0 COMMENT '是否已故',
+    linked_searcher_id CHAR(36) NULL COMMENT '亲属本人也登记为寻亲者时指向其档案',
+    note VARCHAR(255) COMMENT '备注',
+
+    INDEX idx_family_searcher_relatives_searcher (searcher_id),
+    INDEX idx_family_searcher_relatives_linked (linked_searcher_id),
+    CONSTRAINT fk_family_relative_searcher FOREIGN KEY (searcher_id) REFERENCES ty_family_searchers(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_family_relative_linked FOREIGN KEY (linked_searcher_id) REFERENCES ty_family_searchers(id) ON DELETE SET NULL ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='寻亲者亲属关系表';
+
+CREATE TABLE IF NOT EXISTS ty_family_searcher_cases (
+    id CHAR(36) PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL DEFAULT NULL,
+
+    searcher_id CHAR(36) NOT NULL COMMENT '寻亲者ID',
+    missing_person_id CHAR(36) NOT NULL COMMENT '案件ID',
+    relation VARCHAR(20) COMMENT '案件走失者与登记人的关系',
+    match_score DOUBLE COMMENT '关联时的比对得分',
+    linked_by_id CHAR(36) NOT NULL COMMENT '关联人',
+    note VARCHAR(255) COMMENT '备注',
+
+    UNIQUE KEY idx_searcher_case (searcher_id, missing_person_id),
+    INDEX idx_family_searcher_cases_person (missing_person_id),
+    CONSTRAINT fk_family_case_searcher FOREIGN KEY (searcher_id) REFERENCES ty_family_searchers(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_family_case_person FOREIGN KEY (missing_person_id) REFERENCES ty_missing_persons(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_family_case_linker FOREIGN KEY (linked_by_id) REFERENCES ty_users(id) ON DELETE RESTRICT ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='寻亲者与案件关联表';
@@ -0,0 +1,58 @@
+-- Migration: Dialect Identification Sessions
+-- Date: 2026-10-17
+-- Description: Guided sessions that play dialect clips to a found person, narrowing from
+--              province to city to region, and rank likely places of origin
+
+CREATE TABLE IF NOT EXISTS ty_dialect_sessions (
+    id CHAR(36) PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL DEFAULT NULL,
+
+    missing_person_id CHAR(36) NULL COMMENT '关联案件',
+    subject_name VARCHAR(50) COMMENT '受测者称呼',
+    status VARCHAR(20) NOT NULL DEFAULT 'in_progress' COMMENT '状态: in_progress, completed, cancelled',
+    stage VARCHAR(20) NOT NULL DEFAULT 'province' COMMENT '辨识阶段: province, city, region',
+    province VARCHAR(50) COMMENT '已缩小到的省份',
+    city VARCHAR(50) COMMENT '已缩小到的城市',
+    note TEXT COMMENT '备注',
+    result TEXT COMMENT '籍贯推断结果（JSON）',
+    completed_at TIMESTAMP NULL DEFAULT NULL COMMENT '完成时间',
+
+    attach_mode VARCHAR(20) COMMENT '关联案件方式: track, note',
+    track_id CHAR(36) NULL COMMENT '生成的线索ID',
+
+    operator_id CHAR(36) NOT NULL COMMENT '操作志愿者',
+    org_id CHAR(36) NOT NULL COMMENT '组织ID',
+
+    INDEX idx_dialect_sessions_person (missing_person_id),
+    INDEX idx_dialect_sessions_status (status),
+    INDEX idx_dialect_sessions_operator (operator_id),
+    INDEX idx_dialect_sessions_org (org_id),
+    CONSTRAINT fk_dialect_session_person FOREIGN KEY (missing_person_id) REFERENCES ty_missing_persons(id) ON DELETE SET NULL ON UPDATE CASCADE,
+    CONSTRAINT fk_dialect_session_track FOREIGN KEY (track_id) REFERENCES ty_missing_person_tracks(id) ON DELETE SET NULL ON UPDATE CASCADE,
+    CONSTRAINT fk_dialect_session_operator FOREIGN KEY (operator_id) REFERENCES ty_users(id) ON DELETE RESTRICT ON UPDATE CASCADE,
+    CONSTRAINT fk_dialect_session_org FOREIGN KEY (org_id) REFERENCES ty_organizations(id) ON DELETE RESTRICT ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='方言辨识会话表';
+
+CREATE TABLE IF NOT EXISTS ty_dialect_session_answers (
+    id CHAR(36) PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL DEFAULT NULL,
+
+    session_id CHAR(36) NOT NULL COMMENT '会话ID',
+    dialect_id CHAR(36) NOT NULL COMMENT '方言片段ID',
+    stage VARCHAR(20) NOT NULL COMMENT '播放时的辨识阶段',
+    province VARCHAR(50) COMMENT '片段所属省份（快照）',
+    city VARCHAR(50) COMMENT '片段所属城市（快照）',
+    region VARCHAR(100) COMMENT '片段所属片区（快照）',
+    recognition VARCHAR(20) NOT NULL COMMENT '反应: native, familiar, partial, unfamiliar',
+    note VARCHAR(500) COMMENT '备注',
+
+    INDEX idx_dialect_session_answers_session (session_id),
+    CONSTRAINT fk_dialect_answer_session FOREIGN KEY (session_id) REFERENCES ty_dialect_sessions(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_dialect_answer_dialect FOREIGN KEY (dialect_id) REFERENCES ty_dialects(id) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='方言辨识反应记录表';
+
+CREATE INDEX idx_dialects_identification ON ty_dialects(province, city);
@@ -0,0 +1,15 @@
+-- Migration: Audio Processing
+-- Date: 2026-10-17
+-- Description: Server-probed duration/format, loudness and derived variants (normalized MP3 stream,
+--              waveform JSON) for uploaded audio, and the processed audio file behind each dialect
+
+ALTER TABLE ty_files
+    ADD COLUMN duration_ms INT NOT NULL DEFAULT 0 COMMENT '音频时长（毫秒），服务端探测',
+    ADD COLUMN audio_format VARCHAR(10) COMMENT '音频实际格式: wav, mp3, aac, m4a, amr, amr-wb',
+    ADD COLUMN loudness DOUBLE COMMENT '原始积分响度（LUFS）';
+
+ALTER TABLE ty_dialects
+    ADD COLUMN audio_file_id CHAR(36) NULL COMMENT '处理后的音频文件',
+    ADD COLUMN waveform_url VARCHAR(255) COMMENT '播放器波形数据URL',
+    ADD INDEX idx_dialects_audio_file (audio_file_id),
+    ADD CONSTRAINT fk_dialects_audio_file FOREIGN KEY (audio_file_id) REFERENCES ty_files(id) ON DELETE SET NULL ON UPDATE CASCADE;
@@ -0,0 +1,48 @@
+-- Migration: Dialect Taxonomy
+-- Date: 2026-10-17
+-- Description: Managed dialect groups (family → subgroup → local variety) mapped to administrative
+--              regions, replacing free-text ty_dialects.region. Default groups are imported and
+--              existing dialects are grouped afterwards by
+--                  go run cmd/app/main.go -migrate-dialect-groups [-dry-run]
+--              or POST /api/v1/dialect-groups/migrate
+
+CREATE TABLE IF NOT EXISTS ty_dialect_groups (
+    id CHAR(36) PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL DEFAULT NULL,
+
+    parent_id CHAR(36) NULL COMMENT '上级分类',
+    level VARCHAR(20) NOT NULL COMMENT '层级: family, subgroup, variety',
+    name VARCHAR(50) NOT NULL COMMENT '名称',
+    aliases JSON COMMENT '别称（JSON 数组）',
+    path VARCHAR(255) NOT NULL COMMENT '从方言大区到自身的ID路径，如 /a/b/c/',
+    full_name VARCHAR(200) COMMENT '全称，如 吴语/太湖片/苏州话',
+    sort INT NOT NULL DEFAULT 0 COMMENT '排序',
+    description TEXT COMMENT '说明',
+
+    INDEX idx_dialect_groups_parent (parent_id),
+    INDEX idx_dialect_groups_path (path),
+    INDEX idx_dialect_groups_deleted_at (deleted_at),
+    CONSTRAINT fk_dialect_group_parent FOREIGN KEY (parent_id) REFERENCES ty_dialect_groups(id) ON DELETE RESTRICT ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='方言分类表';
+
+CREATE TABLE IF NOT EXISTS ty_dialect_group_regions (
+    id CHAR(36) PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL DEFAULT NULL,
+
+    group_id CHAR(36) NOT NULL COMMENT '方言分类',
+    province VARCHAR(50) NOT NULL COMMENT '省份',
+    city VARCHAR(50) COMMENT '城市，为空表示整个省',
+
+    INDEX idx_dialect_group_regions_group (group_id),
+    INDEX idx_dialect_group_region (province, city),
+    CONSTRAINT fk_dialect_group_region_group FOREIGN KEY (group_id) REFERENCES ty_dialect_groups(id) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='方言分类对应行政区表';
+
+ALTER TABLE ty_dialects
+    ADD COLUMN group_id CHAR(36) NULL COMMENT '方言分类（任意层级），设置后 region 取分类名称',
+    ADD INDEX idx_dialects_group (group_id),
+    ADD CONSTRAINT fk_dialects_group FOREIGN KEY (group_id) REFERENCES ty_dialect_groups(id) ON DELETE SET NULL ON UPDATE CASCADE;
@@ -0,0 +1,61 @@
+-- Migration: Dialect Moderation Queue
+-- Date: 2026-10-17
+-- Description: Per-organization review queue for uploaded dialects. Reviewers claim items,
+--              approve or reject them with a reason code, and the uploader is notified.
+--              Organizations that publish a workflow definition with category 'dialect_review'
+--              need one distinct reviewer per approval node. Dialects that are already pending
+--              are queued here.
+
+CREATE TABLE IF NOT EXISTS ty_dialect_reviews (
+    id CHAR(36) PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL DEFAULT NULL,
+
+    dialect_id CHAR(36) NOT NULL COMMENT '方言',
+    org_id CHAR(36) NOT NULL COMMENT '所属组织',
+    uploader_id CHAR(36) NOT NULL COMMENT '上传者',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' COMMENT '状态: pending, claimed, approved, rejected',
+    required_approvals INT NOT NULL DEFAULT 1 COMMENT '需要通过的审核员人数',
+    approvals INT NOT NULL DEFAULT 0 COMMENT '已通过人数',
+    reviewer_id CHAR(36) NULL COMMENT '当前认领人，认领超过30分钟未处理可被重新认领',
+    claimed_at TIMESTAMP NULL DEFAULT NULL COMMENT '认领时间',
+    workflow_instance_id CHAR(36) NULL COMMENT '方言审核流程实例',
+    reason_code VARCHAR(30) COMMENT '驳回原因',
+    comment TEXT COMMENT '审核意见',
+    decided_at TIMESTAMP NULL DEFAULT NULL COMMENT '审核结束时间',
+
+    INDEX idx_dialect_reviews_dialect (dialect_id),
+    INDEX idx_dialect_review_queue (org_id, status),
+    INDEX idx_dialect_reviews_reviewer (reviewer_id),
+    INDEX idx_dialect_reviews_deleted_at (deleted_at),
+    CONSTRAINT fk_dialect_review_dialect FOREIGN KEY (dialect_id) REFERENCES ty_dialects(id) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='方言审核表';
+
+CREATE TABLE IF NOT EXISTS ty_dialect_review_decisions (
+    id CHAR(36) PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL DEFAULT NULL,
+
+    review_id CHAR(36) NOT NULL COMMENT '方言审核',
+    org_id CHAR(36) NOT NULL COMMENT '所属组织',
+    reviewer_id CHAR(36) NOT NULL COMMENT '审核员',
+    action VARCHAR(20) NOT NULL COMMENT '结论: approve, reject',
+    reason_code VARCHAR(30) COMMENT '驳回原因',
+    comment TEXT COMMENT '审核意见',
+    stage INT NOT NULL COMMENT '第几位审核员，从1开始',
+    handle_seconds INT NOT NULL DEFAULT 0 COMMENT '认领到给出结论的用时（秒）',
+
+    INDEX idx_dialect_review_decisions_review (review_id),
+    INDEX idx_dialect_decision_reviewer (org_id, reviewer_id),
+    INDEX idx_dialect_review_decisions_deleted_at (deleted_at),
+    CONSTRAINT fk_dialect_review_decision_review FOREIGN KEY (review_id) REFERENCES ty_dialect_reviews(id) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='方言审核结论表';
+
+INSERT INTO ty_dialect_reviews (id, dialect_id, org_id, uploader_id, status, required_approvals, created_at, updated_at)
+SELECT UUID(), d.id, d.org_id, d.uploader_id, 'pending', 1, d.created_at, NOW()
+FROM ty_dialects d
+WHERE d.status = 'pending'
+  AND d.deleted_at IS NULL
+  AND NOT EXISTS (SELECT 1 FROM ty_dialect_reviews r WHERE r.dialect_id = d.id);
@@ -0,0 +1,18 @@
+-- Migration: Dialect Playback Analytics
+-- Date: 2026-10-17
+-- Description: Listener key on play logs so repeat plays can be de-duplicated and unique
+--              listeners counted. A play by the same listener within 30 minutes updates the
+--              existing log instead of adding one, so refreshes no longer inflate play_count.
+--              Existing logs are keyed by user; anonymous logs by an MD5 of IP and user agent
+--              (new anonymous keys use SHA-1, so such listeners spanning the migration count twice).
+
+ALTER TABLE ty_dialect_play_logs
+    ADD COLUMN listener_key VARCHAR(64) NOT NULL DEFAULT '' COMMENT '收听者标识：登录用户为 user:<id>，匿名为 IP 与 User-Agent 的摘要',
+    ADD INDEX idx_dialect_play_listener (dialect_id, listener_key, created_at);
+
+UPDATE ty_dialect_play_logs
+SET listener_key = CASE
+    WHEN user_id IS NOT NULL THEN CONCAT('user:', user_id)
+    ELSE CONCAT('anon:', LEFT(MD5(CONCAT(COALESCE(ip, ''), '|', COALESCE(user_agent, ''))), 24))
+END
+WHERE listener_key = '';
@@ -0,0 +1,65 @@
+-- Migration: Dialect Comment Threads and Moderation
+-- Date: 2026-10-17
+-- Description: Two-level comment threads (replies hang off the top-level comment and record the
+--              user being replied to), comment likes, abuse reports and soft-hide by moderators.
+--              Comments matching the sensitive-word dictionary are masked and flagged for review.
+--              Existing replies to replies are moved under their top-level comment and reply
+--              counts are recomputed.
+
+ALTER TABLE ty_dialect_comments
+    ADD COLUMN reply_to_user_id CHAR(36) NULL COMMENT '被回复的用户（回复楼中楼时）',
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'visible' COMMENT '状态: visible, hidden',
+    ADD COLUMN flagged TINYINT(1) NOT NULL DEFAULT 0 COMMENT '命中敏感词，等待管理员复核',
+    ADD COLUMN report_count INT NOT NULL DEFAULT 0 COMMENT '举报数',
+    ADD COLUMN hidden_by CHAR(36) NULL COMMENT '隐藏评论的管理员',
+    ADD COLUMN hidden_reason VARCHAR(200) NULL COMMENT '隐藏原因',
+    ADD COLUMN hidden_at TIMESTAMP NULL DEFAULT NULL COMMENT '隐藏时间',
+    ADD INDEX idx_dialect_comments_status (status);
+
+UPDATE ty_dialect_comments c
+JOIN ty_dialect_comments p ON c.parent_id = p.id
+SET c.reply_to_user_id = p.user_id, c.parent_id = p.parent_id
+WHERE p.parent_id IS NOT NULL;
+
+UPDATE ty_dialect_comments c
+LEFT JOIN (
+    SELECT parent_id, COUNT(*) AS replies
+    FROM ty_dialect_comments
+    WHERE parent_id IS NOT NULL AND deleted_at IS NULL
+    GROUP BY parent_id
+) r ON r.parent_id = c.id
+SET c.reply_count = COALESCE(r.replies, 0)
+WHERE c.parent_id IS NULL;
+
+CREATE TABLE IF NOT EXISTS ty_dialect_comment_likes (
+    id CHAR(36) PRIMARY KEY,
+    comment_id CHAR(36) NOT NULL COMMENT '评论',
+    user_id CHAR(36) NOT NULL COMMENT '点赞用户',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE INDEX idx_dialect_comment_like (comment_id, user_id),
+    CONSTRAINT fk_dialect_comment_like_comment FOREIGN KEY (comment_id) REFERENCES ty_dialect_comments(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_dialect_comment_like_user FOREIGN KEY (user_id) REFERENCES ty_users(id) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='方言评论点赞表';
+
+CREATE TABLE IF NOT EXISTS ty_dialect_comment_reports (
+    id CHAR(36) PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL DEFAULT NULL,
+
+    comment_id CHAR(36) NOT NULL COMMENT '评论',
+    dialect_id CHAR(36) NOT NULL COMMENT '方言',
+    reporter_id CHAR(36) NOT NULL COMMENT '举报人',
+    reason VARCHAR(30) NOT NULL COMMENT '举报原因: spam, abuse, fraud, privacy, other',
+    detail VARCHAR(500) NULL COMMENT '举报说明',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' COMMENT '状态: pending, upheld, dismissed',
+    handled_by CHAR(36) NULL COMMENT '处理人',
+    handled_at TIMESTAMP NULL DEFAULT NULL COMMENT '处理时间',
+
+    UNIQUE INDEX idx_dialect_comment_report (comment_id, reporter_id),
+    INDEX idx_dialect_comment_reports_dialect (dialect_id),
+    INDEX idx_dialect_comment_reports_status (status),
+    INDEX idx_dialect_comment_reports_deleted_at (deleted_at),
+    CONSTRAINT fk_dialect_comment_report_comment FOREIGN KEY (comment_id) REFERENCES ty_dialect_comments(id) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='方言评论举报表';
@@ -0,0 +1,62 @@
+-- Migration: Dialect Playlists and Collections
+-- Date: 2026-10-17
+-- Description: Ordered dialect playlists for hometown identification field visits. Playlists are
+--              private or shared within the org, and collaborators may add, remove and reorder
+--              clips. Auto collections ("best of province") are regenerated from featured flag,
+--              like count and play completion. Playlists can be exported as a signed offline ZIP.
+
+CREATE TABLE IF NOT EXISTS ty_dialect_playlists (
+    id CHAR(36) PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL DEFAULT NULL,
+
+    title VARCHAR(100) NOT NULL COMMENT '歌单名称',
+    description VARCHAR(500) NULL COMMENT '说明',
+    visibility VARCHAR(20) NOT NULL DEFAULT 'private' COMMENT '可见范围: private, org',
+    kind VARCHAR(20) NOT NULL DEFAULT 'manual' COMMENT '类型: manual, auto',
+    province VARCHAR(50) NULL COMMENT '自动合集的省份',
+    item_limit INT NOT NULL DEFAULT 0 COMMENT '自动合集的片段数',
+    item_count INT NOT NULL DEFAULT 0 COMMENT '片段数',
+    total_duration INT NOT NULL DEFAULT 0 COMMENT '总时长（秒）',
+    refreshed_at TIMESTAMP NULL DEFAULT NULL COMMENT '自动合集最近一次生成时间',
+    owner_id CHAR(36) NOT NULL COMMENT '创建者',
+    org_id CHAR(36) NOT NULL COMMENT '所属组织',
+
+    INDEX idx_dialect_playlists_owner (owner_id),
+    INDEX idx_dialect_playlists_org (org_id, visibility),
+    INDEX idx_dialect_playlists_kind (kind),
+    INDEX idx_dialect_playlists_province (province),
+    INDEX idx_dialect_playlists_deleted_at (deleted_at),
+    CONSTRAINT fk_dialect_playlist_owner FOREIGN KEY (owner_id) REFERENCES ty_users(id) ON DELETE RESTRICT ON UPDATE CASCADE,
+    CONSTRAINT fk_dialect_playlist_org FOREIGN KEY (org_id) REFERENCES ty_organizations(id) ON DELETE RESTRICT ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='方言歌单表';
+
+CREATE TABLE IF NOT EXISTS ty_dialect_playlist_items (
+    id CHAR(36) PRIMARY KEY,
+    playlist_id CHAR(36) NOT NULL COMMENT '歌单',
+    dialect_id CHAR(36) NOT NULL COMMENT '方言',
+    position INT NOT NULL DEFAULT 0 COMMENT '顺序，从 1 开始',
+    note VARCHAR(200) NULL COMMENT '现场播放提示',
+    score DOUBLE NOT NULL DEFAULT 0 COMMENT '自动合集的排序得分',
+    added_by CHAR(36) NOT NULL COMMENT '添加人',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE INDEX idx_dialect_playlist_item (playlist_id, dialect_id),
+    INDEX idx_dialect_playlist_items_position (playlist_id, position),
+    CONSTRAINT fk_dialect_playlist_item_playlist FOREIGN KEY (playlist_id) REFERENCES ty_dialect_playlists(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_dialect_playlist_item_dialect FOREIGN KEY (dialect_id) REFERENCES ty_dialects(id) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='方言歌单片段表';
+
+CREATE TABLE IF NOT EXISTS ty_dialect_playlist_collaborators (
+    id CHAR(36) PRIMARY KEY,
+    playlist_id CHAR(36) NOT NULL COMMENT '歌单',
+    user_id CHAR(36) NOT NULL COMMENT '协作者',
+    added_by CHAR(36) NOT NULL COMMENT '邀请人',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE INDEX idx_dialect_playlist_collaborator (playlist_id, user_id),
+    INDEX idx_dialect_playlist_collaborators_user (user_id),
+    CONSTRAINT fk_dialect_playlist_collaborator_playlist FOREIGN KEY (playlist_id) REFERENCES ty_dialect_playlists(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_dialect_playlist_collaborator_user FOREIGN KEY (user_id) REFERENCES ty_users(id) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='方言歌单协作者表';
@@ -0,0 +1,18 @@
+-- Migration: Audio Fingerprints
+-- Date: 2026-10-17
+-- Description: Acoustic fingerprint (spectral sub-fingerprints, base64) computed when audio is
+--              uploaded and copied onto the dialect record. New dialects whose fingerprint is close
+--              to an existing one are flagged as suspected duplicates in the moderation queue.
+--              Audio uploaded before this migration has no fingerprint and is not compared.
+
+ALTER TABLE ty_files
+    ADD COLUMN audio_fingerprint TEXT NULL COMMENT '声纹指纹（仅音频）';
+
+ALTER TABLE ty_dialects
+    ADD COLUMN fingerprint TEXT NULL COMMENT '声纹指纹，用于发现重复上传';
+
+ALTER TABLE ty_dialect_reviews
+    ADD COLUMN duplicate_of_id CHAR(36) NULL COMMENT '疑似重复的已有方言',
+    ADD COLUMN duplicate_similarity DOUBLE NOT NULL DEFAULT 0 COMMENT '与该方言的声纹相似度（0~1）',
+    ADD INDEX idx_dialect_reviews_duplicate_of (duplicate_of_id),
+    ADD CONSTRAINT fk_dialect_review_duplicate FOREIGN KEY (duplicate_of_id) REFERENCES ty_dialects(id) ON DELETE SET NULL;
@@ -0,0 +1,43 @@
+-- Migration: Task Auto-Dispatch
+-- Date: 2026-10-17
+-- Description: Dispatch engine for pending tasks. Candidate volunteers are ranked by distance to
+--              their home or last-known location, required skills and languages (including
+--              dialects) and current open-task count. The top N receive an offer over WebSocket;
+--              the first to accept gets the task. Offers that time out or are declined roll over
+--              to the next round, and after the last round the task falls back to manual assignment.
+
+ALTER TABLE ty_tasks
+    ADD COLUMN skills VARCHAR(255) NULL COMMENT '所需技能，逗号分隔',
+    ADD COLUMN languages VARCHAR(255) NULL COMMENT '所需语言或方言，逗号分隔',
+    ADD COLUMN dispatch_status VARCHAR(20) NOT NULL DEFAULT '' COMMENT '自动派单状态: 空, offering, accepted, exhausted',
+    ADD COLUMN dispatch_round INT NOT NULL DEFAULT 0 COMMENT '已推送轮数',
+    ADD COLUMN dispatched_at TIMESTAMP NULL DEFAULT NULL COMMENT '最近一轮推送时间',
+    ADD INDEX idx_tasks_dispatch (status, dispatch_status);
+
+CREATE TABLE IF NOT EXISTS ty_task_offers (
+    id CHAR(36) PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL DEFAULT NULL,
+
+    task_id CHAR(36) NOT NULL COMMENT '任务',
+    org_id CHAR(36) NOT NULL COMMENT '所属组织',
+    user_id CHAR(36) NOT NULL COMMENT '志愿者',
+    round INT NOT NULL DEFAULT 1 COMMENT '派单轮次',
+    position INT NOT NULL DEFAULT 1 COMMENT '本轮排名',
+    score DOUBLE NOT NULL DEFAULT 0 COMMENT '派单评分（0~100）',
+    distance_km DOUBLE NULL COMMENT '到任务地点的距离（公里）',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' COMMENT '状态: pending, accepted, declined, expired, withdrawn',
+    expires_at TIMESTAMP NOT NULL COMMENT '截止时间',
+    responded_at TIMESTAMP NULL DEFAULT NULL COMMENT '响应时间',
+    reason VARCHAR(200) NULL COMMENT '拒绝原因',
+
+    INDEX idx_task_offers_task (task_id, status),
+    INDEX idx_task_offers_org (org_id),
+    INDEX idx_task_offer_user (user_id, status),
+    INDEX idx_task_offers_expires_at (expires_at),
+    INDEX idx_task_offers_deleted_at (deleted_at),
+    CONSTRAINT fk_task_offer_task FOREIGN KEY (task_id) REFERENCES ty_tasks(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_task_offer_org FOREIGN KEY (org_id) REFERENCES ty_organizations(id) ON DELETE RESTRICT ON UPDATE CASCADE,
+    CONSTRAINT fk_task_offer_user FOREIGN KEY (user_id) REFERENCES ty_users(id) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='任务派单邀请表';
@@ -0,0 +1,104 @@
+-- Migration: Volunteer Profiles
+-- Date: 2026-10-17
+-- Description: Volunteer profile with home location, service radius and skills, plus certifications
+--              with expiry, spoken languages linked to dialect groups, a weekly availability
+--              schedule and vacation blocks. Profiles back the volunteer search API and enrich
+--              task dispatch candidates (home location, skills, languages, availability).
+
+CREATE TABLE IF NOT EXISTS ty_volunteer_profiles (
+    id CHAR(36) PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL DEFAULT NULL,
+
+    user_id CHAR(36) NOT NULL COMMENT '志愿者',
+    org_id CHAR(36) NOT NULL COMMENT '所属组织',
+    province VARCHAR(50) NULL COMMENT '常住省份',
+    city VARCHAR(50) NULL COMMENT '常住城市',
+    district VARCHAR(50) NULL COMMENT '常住区县',
+    address VARCHAR(255) NULL COMMENT '常住地址',
+    home_lat DECIMAL(10, 8) NULL COMMENT '常住地纬度',
+    home_lng DECIMAL(11, 8) NULL COMMENT '常住地经度',
+    service_radius_km DECIMAL(8, 2) NOT NULL DEFAULT 0 COMMENT '服务半径（公里），0 表示不限',
+    skills VARCHAR(500) NULL COMMENT '技能，逗号分隔',
+    bio TEXT NULL COMMENT '个人简介',
+
+    UNIQUE INDEX idx_volunteer_profiles_user (user_id),
+    INDEX idx_volunteer_profiles_org (org_id),
+    INDEX idx_volunteer_profiles_province (province),
+    INDEX idx_volunteer_profiles_deleted_at (deleted_at),
+    CONSTRAINT fk_volunteer_profile_user FOREIGN KEY (user_id) REFERENCES ty_users(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_volunteer_profile_org FOREIGN KEY (org_id) REFERENCES ty_organizations(id) ON DELETE RESTRICT ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='志愿者档案表';
+
+CREATE TABLE IF NOT EXISTS ty_volunteer_certifications (
+    id CHAR(36) PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL DEFAULT NULL,
+
+    profile_id CHAR(36) NOT NULL COMMENT '志愿者档案',
+    name VARCHAR(100) NOT NULL COMMENT '证书名称',
+    issuer VARCHAR(100) NULL COMMENT '发证机构',
+    cert_no VARCHAR(100) NULL COMMENT '证书编号',
+    issued_at TIMESTAMP NULL DEFAULT NULL COMMENT '发证日期',
+    expires_at TIMESTAMP NULL DEFAULT NULL COMMENT '有效期至，为空表示长期有效',
+
+    INDEX idx_volunteer_certifications_profile (profile_id),
+    INDEX idx_volunteer_certifications_name (name),
+    INDEX idx_volunteer_certifications_expires_at (expires_at),
+    INDEX idx_volunteer_certifications_deleted_at (deleted_at),
+    CONSTRAINT fk_volunteer_certification_profile FOREIGN KEY (profile_id) REFERENCES ty_volunteer_profiles(id) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='志愿者证书表';
+
+CREATE TABLE IF NOT EXISTS ty_volunteer_languages (
+    id CHAR(36) PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL DEFAULT NULL,
+
+    profile_id CHAR(36) NOT NULL COMMENT '志愿者档案',
+    name VARCHAR(50) NOT NULL COMMENT '语言或方言名称',
+    dialect_group_id CHAR(36) NULL COMMENT '关联的方言分类',
+    level VARCHAR(20) NOT NULL DEFAULT 'fluent' COMMENT '熟练程度: native, fluent, basic',
+
+    INDEX idx_volunteer_languages_profile (profile_id),
+    INDEX idx_volunteer_languages_name (name),
+    INDEX idx_volunteer_languages_group (dialect_group_id),
+    INDEX idx_volunteer_languages_deleted_at (deleted_at),
+    CONSTRAINT fk_volunteer_language_profile FOREIGN KEY (profile_id) REFERENCES ty_volunteer_profiles(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_volunteer_language_group FOREIGN KEY (dialect_group_id) REFERENCES ty_dialect_groups(id) ON DELETE SET NULL ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='志愿者语言方言表';
+
+CREATE TABLE IF NOT EXISTS ty_volunteer_availability (
+    id CHAR(36) PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL DEFAULT NULL,
+
+    profile_id CHAR(36) NOT NULL COMMENT '志愿者档案',
+    weekday TINYINT NOT NULL COMMENT '星期，0 为周日',
+    start_minute INT NOT NULL COMMENT '开始时刻（当天零点起的分钟数）',
+    end_minute INT NOT NULL COMMENT '结束时刻（不含），1440 表示当天结束',
+
+    INDEX idx_volunteer_availability_profile (profile_id),
+    INDEX idx_volunteer_availability_deleted_at (deleted_at),
+    CONSTRAINT fk_volunteer_availability_profile FOREIGN KEY (profile_id) REFERENCES ty_volunteer_profiles(id) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='志愿者每周值班时段表';
+
+CREATE TABLE IF NOT EXISTS ty_volunteer_vacations (
+    id CHAR(36) PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL DEFAULT NULL,
+
+    profile_id CHAR(36) NOT NULL COMMENT '志愿者档案',
+    start_at TIMESTAMP NOT NULL COMMENT '休假开始时间',
+    end_at TIMESTAMP NOT NULL COMMENT '休假结束时间（不含）',
+    reason VARCHAR(200) NULL COMMENT '休假原因',
+
+    INDEX idx_volunteer_vacations_profile (profile_id),
+    INDEX idx_volunteer_vacations_end_at (end_at),
+    INDEX idx_volunteer_vacations_deleted_at (deleted_at),
+    CONSTRAINT fk_volunteer_vacation_profile FOREIGN KEY (profile_id) REFERENCES ty_volunteer_profiles(id) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='志愿者休假表';
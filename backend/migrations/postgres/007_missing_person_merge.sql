@@ -0,0 +1,51 @@
+-- Migration: Missing Person Duplicate Detection & Merge
+-- Date: 2026-10-16
+-- Description: Add name pinyin for duplicate detection, merge pointer and merge history
+
+-- ============================================
+-- 1. Missing Persons Columns
+-- ============================================
+ALTER TABLE ty_missing_persons ADD COLUMN IF NOT EXISTS name_pinyin VARCHAR(100);
+ALTER TABLE ty_missing_persons ADD COLUMN IF NOT EXISTS merged_into_id UUID REFERENCES ty_missing_persons(id) ON DELETE SET NULL;
+
+COMMENT ON COLUMN ty_missing_persons.name_pinyin IS '姓名全拼（繁简归一），用于重复案件检测';
+COMMENT ON COLUMN ty_missing_persons.merged_into_id IS '被合并到的保留案件ID';
+
+CREATE INDEX IF NOT EXISTS idx_missing_persons_name_pinyin ON ty_missing_persons(name_pinyin) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_missing_persons_merged_into ON ty_missing_persons(merged_into_id) WHERE merged_into_id IS NOT NULL;
+
+-- ============================================
+-- 2. Missing Person Merges Table
+-- ============================================
+CREATE TABLE IF NOT EXISTS ty_missing_person_merges (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    survivor_id UUID NOT NULL REFERENCES ty_missing_persons(id) ON DELETE CASCADE,
+    survivor_case_no VARCHAR(50),
+    merged_id UUID NOT NULL REFERENCES ty_missing_persons(id) ON DELETE CASCADE,
+    merged_case_no VARCHAR(50),
+    operator_id UUID NOT NULL REFERENCES ty_users(id) ON DELETE RESTRICT,
+    org_id UUID REFERENCES ty_organizations(id) ON DELETE SET NULL,
+    score DOUBLE PRECISION NOT NULL DEFAULT 0,
+    reason TEXT,
+
+    -- 迁移的关联数据数量
+    track_count BIGINT NOT NULL DEFAULT 0,
+    photo_count BIGINT NOT NULL DEFAULT 0,
+    task_count BIGINT NOT NULL DEFAULT 0,
+    file_count BIGINT NOT NULL DEFAULT 0,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+COMMENT ON TABLE ty_missing_person_merges IS '重复案件合并记录表';
+
+CREATE INDEX IF NOT EXISTS idx_mp_merges_survivor ON ty_missing_person_merges(survivor_id);
+CREATE INDEX IF NOT EXISTS idx_mp_merges_merged ON ty_missing_person_merges(merged_id);
+CREATE INDEX IF NOT EXISTS idx_mp_merges_org ON ty_missing_person_merges(org_id);
+CREATE INDEX IF NOT EXISTS idx_mp_merges_deleted_at ON ty_missing_person_merges(deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- ============================================
+-- Migration Complete
+-- ============================================
@@ -0,0 +1,50 @@
+-- Migration: Geospatial Search
+-- Date: 2026-10-16
+-- Description: Add coordinates and geohash columns for radius / bounding-box queries,
+--              with optional PostGIS expression indexes
+
+-- ============================================
+-- 1. Missing Persons Columns
+-- ============================================
+ALTER TABLE ty_missing_persons ADD COLUMN IF NOT EXISTS lat DOUBLE PRECISION;
+ALTER TABLE ty_missing_persons ADD COLUMN IF NOT EXISTS lng DOUBLE PRECISION;
+ALTER TABLE ty_missing_persons ADD COLUMN IF NOT EXISTS geohash VARCHAR(12);
+
+COMMENT ON COLUMN ty_missing_persons.lat IS '走失地点纬度';
+COMMENT ON COLUMN ty_missing_persons.lng IS '走失地点经度';
+COMMENT ON COLUMN ty_missing_persons.geohash IS '走失地点 geohash（9位），用于无 PostGIS 时的空间索引';
+
+CREATE INDEX IF NOT EXISTS idx_missing_persons_geohash ON ty_missing_persons(geohash varchar_pattern_ops) WHERE deleted_at IS NULL;
+
+-- ============================================
+-- 2. Missing Person Tracks Columns
+-- ============================================
+ALTER TABLE ty_missing_person_tracks ADD COLUMN IF NOT EXISTS geohash VARCHAR(12);
+
+COMMENT ON COLUMN ty_missing_person_tracks.geohash IS '线索位置 geohash（9位），用于无 PostGIS 时的空间索引';
+
+CREATE INDEX IF NOT EXISTS idx_mp_tracks_geohash ON ty_missing_person_tracks(geohash varchar_pattern_ops) WHERE deleted_at IS NULL;
+
+-- ============================================
+-- 3. PostGIS (optional)
+-- ============================================
+-- 已安装 PostGIS 时创建 geography 表达式索引，并直接用 ST_GeoHash 回填历史数据；
+-- 未安装时可执行 `go run cmd/app/main.go -backfill-geohash` 回填
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM pg_extension WHERE extname = 'postgis') THEN
+        EXECUTE 'CREATE INDEX IF NOT EXISTS idx_missing_persons_geog ON ty_missing_persons
+                 USING GIST ((ST_SetSRID(ST_MakePoint(lng, lat), 4326)::geography))
+                 WHERE deleted_at IS NULL AND lat IS NOT NULL AND lng IS NOT NULL';
+        EXECUTE 'CREATE INDEX IF NOT EXISTS idx_mp_tracks_geog ON ty_missing_person_tracks
+                 USING GIST ((ST_SetSRID(ST_MakePoint(lng, lat), 4326)::geography))
+                 WHERE deleted_at IS NULL AND lat IS NOT NULL AND lng IS NOT NULL';
+
+        EXECUTE 'UPDATE ty_missing_persons SET geohash = ST_GeoHash(ST_SetSRID(ST_MakePoint(lng, lat), 4326), 9)
+                 WHERE (geohash IS NULL OR geohash = '''') AND lat IS NOT NULL AND lng IS NOT NULL
+                   AND NOT (lat = 0 AND lng = 0)';
+        EXECUTE 'UPDATE ty_missing_person_tracks SET geohash = ST_GeoHash(ST_SetSRID(ST_MakePoint(lng, lat), 4326), 9)
+                 WHERE (geohash IS NULL OR geohash = '''') AND lat IS NOT NULL AND lng IS NOT NULL
+                   AND NOT (lat = 0 AND lng = 0)';
+    END IF;
+END $$;
@@ -0,0 +1,21 @@
+-- Migration: Public Case Board & Lead Submission
+-- Date: 2026-10-16
+-- Description: Allow anonymous leads from the public case board
+
+-- ============================================
+-- 1. Missing Person Tracks Columns
+-- ============================================
+-- 公众提交的线索没有登录用户
+ALTER TABLE ty_missing_person_tracks ALTER COLUMN reporter_id DROP NOT NULL;
+
+ALTER TABLE ty_missing_person_tracks ADD COLUMN IF NOT EXISTS source VARCHAR(20) NOT NULL DEFAULT 'volunteer';
+ALTER TABLE ty_missing_person_tracks ADD COLUMN IF NOT EXISTS submitter_name VARCHAR(50);
+ALTER TABLE ty_missing_person_tracks ADD COLUMN IF NOT EXISTS submitter_phone VARCHAR(20);
+ALTER TABLE ty_missing_person_tracks ADD COLUMN IF NOT EXISTS submitter_ip VARCHAR(64);
+
+COMMENT ON COLUMN ty_missing_person_tracks.source IS '线索来源: volunteer-志愿者录入, public-公众提交';
+COMMENT ON COLUMN ty_missing_person_tracks.submitter_name IS '公众提交人姓名（仅内部可见）';
+COMMENT ON COLUMN ty_missing_person_tracks.submitter_phone IS '公众提交人电话（仅内部可见）';
+COMMENT ON COLUMN ty_missing_person_tracks.submitter_ip IS '公众提交人IP';
+
+CREATE INDEX IF NOT EXISTS idx_tracks_source_pending ON ty_missing_person_tracks(source, status) WHERE deleted_at IS NULL;
@@ -0,0 +1,57 @@
+-- Migration: Sighting Verification Workflow
+-- Date: 2026-10-16
+-- Description: Track verification lifecycle (pending -> verifying -> confirmed/rejected),
+--              verification history and organization coordinates for verify-task dispatch
+
+-- ============================================
+-- 1. Missing Person Tracks Columns
+-- ============================================
+ALTER TABLE ty_missing_person_tracks DROP CONSTRAINT IF EXISTS ty_missing_person_tracks_status_check;
+ALTER TABLE ty_missing_person_tracks ADD CONSTRAINT ty_missing_person_tracks_status_check
+    CHECK (status IN ('pending', 'verifying', 'confirmed', 'rejected'));
+
+ALTER TABLE ty_missing_person_tracks ADD COLUMN IF NOT EXISTS verifier_id UUID REFERENCES ty_users(id) ON DELETE SET NULL;
+ALTER TABLE ty_missing_person_tracks ADD COLUMN IF NOT EXISTS verified_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE ty_missing_person_tracks ADD COLUMN IF NOT EXISTS status_reason TEXT;
+
+COMMENT ON COLUMN ty_missing_person_tracks.status IS '状态: pending-待核实, verifying-核实中, confirmed-已确认, rejected-已驳回';
+COMMENT ON COLUMN ty_missing_person_tracks.verifier_id IS '核实人ID';
+COMMENT ON COLUMN ty_missing_person_tracks.verified_at IS '核实完成时间';
+COMMENT ON COLUMN ty_missing_person_tracks.status_reason IS '确认/驳回原因';
+
+CREATE INDEX IF NOT EXISTS idx_tracks_verifier ON ty_missing_person_tracks(verifier_id) WHERE verifier_id IS NOT NULL;
+
+-- ============================================
+-- 2. Track Logs Table
+-- ============================================
+CREATE TABLE IF NOT EXISTS ty_missing_person_track_logs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    track_id UUID NOT NULL REFERENCES ty_missing_person_tracks(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES ty_users(id) ON DELETE RESTRICT,
+    action VARCHAR(50) NOT NULL,
+    old_status VARCHAR(20),
+    new_status VARCHAR(20),
+    reason TEXT,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+COMMENT ON TABLE ty_missing_person_track_logs IS '线索核实日志表';
+
+CREATE INDEX IF NOT EXISTS idx_track_logs_track ON ty_missing_person_track_logs(track_id);
+
+-- ============================================
+-- 3. Organization Coordinates
+-- ============================================
+-- 用于为已确认线索选择最近的组织派发核实任务
+ALTER TABLE ty_organizations ADD COLUMN IF NOT EXISTS lat DOUBLE PRECISION;
+ALTER TABLE ty_organizations ADD COLUMN IF NOT EXISTS lng DOUBLE PRECISION;
+
+COMMENT ON COLUMN ty_organizations.lat IS '组织所在地纬度';
+COMMENT ON COLUMN ty_organizations.lng IS '组织所在地经度';
+
+-- ============================================
+-- Migration Complete
+-- ============================================
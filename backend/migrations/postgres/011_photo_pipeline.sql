@@ -0,0 +1,73 @@
+-- Migration: Photo Metadata Pipeline
+-- Date: 2026-10-16
+-- Description: Image dimensions, perceptual hash, EXIF capture hints and derived variants
+--              (public/thumbnail/share) for uploaded files and missing person photos,
+--              plus cross-case similar photo records
+
+-- ============================================
+-- 1. Files Columns
+-- ============================================
+ALTER TABLE ty_files ADD COLUMN IF NOT EXISTS width INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE ty_files ADD COLUMN IF NOT EXISTS height INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE ty_files ADD COLUMN IF NOT EXISTS phash VARCHAR(16);
+ALTER TABLE ty_files ADD COLUMN IF NOT EXISTS taken_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE ty_files ADD COLUMN IF NOT EXISTS lat DOUBLE PRECISION;
+ALTER TABLE ty_files ADD COLUMN IF NOT EXISTS lng DOUBLE PRECISION;
+ALTER TABLE ty_files ADD COLUMN IF NOT EXISTS variants JSONB;
+
+COMMENT ON COLUMN ty_files.phash IS '感知哈希（16位十六进制）';
+COMMENT ON COLUMN ty_files.taken_at IS 'EXIF 拍摄时间';
+COMMENT ON COLUMN ty_files.lat IS 'EXIF 拍摄位置纬度';
+COMMENT ON COLUMN ty_files.lng IS 'EXIF 拍摄位置经度';
+COMMENT ON COLUMN ty_files.variants IS '衍生版本: public-去除元数据, thumbnail-缩略图, share-水印分享图';
+
+CREATE INDEX IF NOT EXISTS idx_files_phash ON ty_files(phash) WHERE phash IS NOT NULL;
+
+-- ============================================
+-- 2. Missing Photos Columns
+-- ============================================
+ALTER TABLE ty_missing_photos ADD COLUMN IF NOT EXISTS file_id UUID REFERENCES ty_files(id) ON DELETE SET NULL;
+ALTER TABLE ty_missing_photos ADD COLUMN IF NOT EXISTS thumbnail_url VARCHAR(500);
+ALTER TABLE ty_missing_photos ADD COLUMN IF NOT EXISTS share_url VARCHAR(500);
+ALTER TABLE ty_missing_photos ADD COLUMN IF NOT EXISTS phash VARCHAR(16);
+ALTER TABLE ty_missing_photos ADD COLUMN IF NOT EXISTS width INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE ty_missing_photos ADD COLUMN IF NOT EXISTS height INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE ty_missing_photos ADD COLUMN IF NOT EXISTS taken_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE ty_missing_photos ADD COLUMN IF NOT EXISTS lat DOUBLE PRECISION;
+ALTER TABLE ty_missing_photos ADD COLUMN IF NOT EXISTS lng DOUBLE PRECISION;
+
+COMMENT ON COLUMN ty_missing_photos.url IS '去除元数据的公开版本URL';
+COMMENT ON COLUMN ty_missing_photos.share_url IS '带水印的分享版本URL';
+COMMENT ON COLUMN ty_missing_photos.phash IS '感知哈希，用于发现不同案件中的相同照片';
+COMMENT ON COLUMN ty_missing_photos.taken_at IS 'EXIF 拍摄时间（线索参考）';
+
+CREATE INDEX IF NOT EXISTS idx_photos_file ON ty_missing_photos(file_id) WHERE file_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_photos_phash ON ty_missing_photos(phash) WHERE phash IS NOT NULL;
+
+-- ============================================
+-- 3. Photo Matches Table
+-- ============================================
+CREATE TABLE IF NOT EXISTS ty_missing_photo_matches (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    photo_id UUID NOT NULL REFERENCES ty_missing_photos(id) ON DELETE CASCADE,
+    missing_person_id UUID NOT NULL REFERENCES ty_missing_persons(id) ON DELETE CASCADE,
+    matched_photo_id UUID NOT NULL REFERENCES ty_missing_photos(id) ON DELETE CASCADE,
+    matched_person_id UUID NOT NULL REFERENCES ty_missing_persons(id) ON DELETE CASCADE,
+    distance INTEGER NOT NULL,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+COMMENT ON TABLE ty_missing_photo_matches IS '跨案件相似照片表';
+COMMENT ON COLUMN ty_missing_photo_matches.distance IS '感知哈希汉明距离';
+
+CREATE INDEX IF NOT EXISTS idx_photo_matches_photo ON ty_missing_photo_matches(photo_id);
+CREATE INDEX IF NOT EXISTS idx_photo_matches_person ON ty_missing_photo_matches(missing_person_id);
+CREATE INDEX IF NOT EXISTS idx_photo_matches_matched_photo ON ty_missing_photo_matches(matched_photo_id);
+CREATE INDEX IF NOT EXISTS idx_photo_matches_matched_person ON ty_missing_photo_matches(matched_person_id);
+
+-- ============================================
+-- Migration Complete
+-- ============================================
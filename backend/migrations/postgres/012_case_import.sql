@@ -0,0 +1,74 @@
+-- Migration: Bulk Case Import
+-- Date: 2026-10-16
+-- Description: Background import jobs for partner spreadsheets (CSV/XLSX) with dry-run preview,
+--              row-level results for the error report, and saved column mapping templates
+
+-- ============================================
+-- 1. Import Jobs Table
+-- ============================================
+CREATE TABLE IF NOT EXISTS ty_import_jobs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    org_id UUID NOT NULL REFERENCES ty_organizations(id) ON DELETE CASCADE,
+    creator_id UUID NOT NULL REFERENCES ty_users(id) ON DELETE CASCADE,
+    file_name VARCHAR(255) NOT NULL,
+    file_path VARCHAR(500) NOT NULL,
+    format VARCHAR(10) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    dry_run BOOLEAN NOT NULL DEFAULT FALSE,
+    skip_duplicates BOOLEAN NOT NULL DEFAULT TRUE,
+    source_job_id UUID REFERENCES ty_import_jobs(id) ON DELETE SET NULL,
+
+    mapping TEXT,
+    header TEXT,
+    results TEXT,
+
+    total_rows INTEGER NOT NULL DEFAULT 0,
+    processed_rows INTEGER NOT NULL DEFAULT 0,
+    created_rows INTEGER NOT NULL DEFAULT 0,
+    valid_rows INTEGER NOT NULL DEFAULT 0,
+    failed_rows INTEGER NOT NULL DEFAULT 0,
+    duplicate_rows INTEGER NOT NULL DEFAULT 0,
+    error TEXT,
+    started_at TIMESTAMP WITH TIME ZONE,
+    finished_at TIMESTAMP WITH TIME ZONE,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+COMMENT ON TABLE ty_import_jobs IS '案件批量导入任务表';
+COMMENT ON COLUMN ty_import_jobs.status IS '状态: pending-等待, running-执行中, completed-完成, failed-失败';
+COMMENT ON COLUMN ty_import_jobs.dry_run IS '预检：只校验和查重，不创建案件';
+COMMENT ON COLUMN ty_import_jobs.source_job_id IS '确认导入时对应的预检任务';
+COMMENT ON COLUMN ty_import_jobs.mapping IS '列映射 JSON: 字段 -> 列名';
+COMMENT ON COLUMN ty_import_jobs.header IS '原表头 JSON';
+COMMENT ON COLUMN ty_import_jobs.results IS '逐行结果 JSON';
+
+CREATE INDEX IF NOT EXISTS idx_import_jobs_org ON ty_import_jobs(org_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_import_jobs_creator ON ty_import_jobs(creator_id);
+CREATE INDEX IF NOT EXISTS idx_import_jobs_status ON ty_import_jobs(status);
+
+-- ============================================
+-- 2. Import Templates Table
+-- ============================================
+CREATE TABLE IF NOT EXISTS ty_import_templates (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    org_id UUID NOT NULL REFERENCES ty_organizations(id) ON DELETE CASCADE,
+    creator_id UUID NOT NULL REFERENCES ty_users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    mapping TEXT NOT NULL,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+COMMENT ON TABLE ty_import_templates IS '导入列映射模板表';
+COMMENT ON COLUMN ty_import_templates.mapping IS '列映射 JSON: 字段 -> 列名';
+
+CREATE INDEX IF NOT EXISTS idx_import_templates_org ON ty_import_templates(org_id);
+
+-- ============================================
+-- Migration Complete
+-- ============================================
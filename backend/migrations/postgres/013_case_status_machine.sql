@@ -0,0 +1,33 @@
+-- Migration: Case Status State Machine
+-- Date: 2026-10-16
+-- Description: Guarded case status transitions (start_search/mark_found/reunite/close/reopen),
+--              latest transition reason and full status history
+
+-- ============================================
+-- 1. Missing Persons Columns
+-- ============================================
+ALTER TABLE ty_missing_persons ADD COLUMN IF NOT EXISTS status_reason TEXT;
+
+COMMENT ON COLUMN ty_missing_persons.status_reason IS '最近一次状态变更原因（关闭、重新打开等）';
+
+-- ============================================
+-- 2. Status Logs Table
+-- ============================================
+CREATE TABLE IF NOT EXISTS ty_missing_person_status_logs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    missing_person_id UUID NOT NULL REFERENCES ty_missing_persons(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES ty_users(id) ON DELETE RESTRICT,
+    event VARCHAR(30) NOT NULL,
+    from_status VARCHAR(20) NOT NULL,
+    to_status VARCHAR(20) NOT NULL,
+    reason TEXT,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+COMMENT ON TABLE ty_missing_person_status_logs IS '案件状态变更记录表';
+COMMENT ON COLUMN ty_missing_person_status_logs.event IS '事件: start_search-开始搜索, mark_found-标记找到, reunite-团聚, close-关闭, reopen-重新打开, merge-合并';
+
+CREATE INDEX IF NOT EXISTS idx_mp_status_logs_person ON ty_missing_person_status_logs(missing_person_id, created_at);
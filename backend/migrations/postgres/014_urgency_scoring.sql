@@ -0,0 +1,49 @@
+-- Migration: Automatic Urgency Scoring
+-- Date: 2026-10-16
+-- Description: Case urgency score with manual override flag and escalation time,
+--              per-organization scoring rules (inherited from parent organizations)
+
+-- ============================================
+-- 1. Missing Persons Columns
+-- ============================================
+ALTER TABLE ty_missing_persons ADD COLUMN IF NOT EXISTS urgency_score INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE ty_missing_persons ADD COLUMN IF NOT EXISTS urgency_manual BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE ty_missing_persons ADD COLUMN IF NOT EXISTS urgency_scored_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE ty_missing_persons ADD COLUMN IF NOT EXISTS urgency_escalated_at TIMESTAMP WITH TIME ZONE;
+
+COMMENT ON COLUMN ty_missing_persons.urgency_score IS '紧急程度评分';
+COMMENT ON COLUMN ty_missing_persons.urgency_manual IS '紧急程度为人工设置，评分不覆盖等级';
+COMMENT ON COLUMN ty_missing_persons.urgency_scored_at IS '最近评分时间';
+COMMENT ON COLUMN ty_missing_persons.urgency_escalated_at IS '升级为紧急并通知的时间，降级后清空';
+
+-- ============================================
+-- 2. Urgency Rules Table
+-- ============================================
+CREATE TABLE IF NOT EXISTS ty_urgency_rules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    org_id UUID NOT NULL UNIQUE REFERENCES ty_organizations(id) ON DELETE CASCADE,
+    child_max_age INTEGER NOT NULL,
+    child_weight INTEGER NOT NULL,
+    elder_min_age INTEGER NOT NULL,
+    elder_weight INTEGER NOT NULL,
+    medical_weight INTEGER NOT NULL,
+    medical_keywords TEXT,
+    golden_hours INTEGER NOT NULL,
+    golden_weight INTEGER NOT NULL,
+    season_weight INTEGER NOT NULL,
+    sighting_hours INTEGER NOT NULL,
+    sighting_weight INTEGER NOT NULL,
+    sighting_max_weight INTEGER NOT NULL,
+    critical_score INTEGER NOT NULL,
+    high_score INTEGER NOT NULL,
+    medium_score INTEGER NOT NULL,
+    escalate BOOLEAN NOT NULL DEFAULT TRUE,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+COMMENT ON TABLE ty_urgency_rules IS '组织紧急程度评分规则表，未配置的组织沿用上级组织规则';
+COMMENT ON COLUMN ty_urgency_rules.medical_keywords IS '补充的疾病、认知障碍关键词，逗号分隔';
+COMMENT ON COLUMN ty_urgency_rules.escalate IS '升级为紧急时是否通知组织管理员';
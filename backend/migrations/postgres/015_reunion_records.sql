@@ -0,0 +1,73 @@
+-- Migration: Reunion Records and Follow-up Visits
+-- Date: 2026-10-17
+-- Description: Structured reunion record (identity confirmation, verification methods, location,
+--              publicity consent, photos) and follow-up visits at 1, 3 and 12 months
+
+-- ============================================
+-- 1. Reunion Records Table
+-- ============================================
+CREATE TABLE IF NOT EXISTS ty_reunion_records (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    missing_person_id UUID NOT NULL UNIQUE REFERENCES ty_missing_persons(id) ON DELETE CASCADE,
+    recorded_by_id UUID NOT NULL REFERENCES ty_users(id) ON DELETE RESTRICT,
+    confirmed_by_id UUID NOT NULL REFERENCES ty_users(id) ON DELETE RESTRICT,
+    confirmer_name VARCHAR(100),
+    verification_methods VARCHAR(100) NOT NULL,
+    verification_note TEXT,
+    reunion_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    location VARCHAR(200),
+    province VARCHAR(50),
+    city VARCHAR(50),
+    district VARCHAR(50),
+    address VARCHAR(200),
+    lat DECIMAL(10, 8),
+    lng DECIMAL(11, 8),
+    publicity_consent BOOLEAN NOT NULL DEFAULT FALSE,
+    consent_by VARCHAR(100),
+    photos TEXT,
+    note TEXT,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+COMMENT ON TABLE ty_reunion_records IS '团聚记录表';
+COMMENT ON COLUMN ty_reunion_records.confirmed_by_id IS '确认身份的用户';
+COMMENT ON COLUMN ty_reunion_records.confirmer_name IS '外部确认人（如派出所民警）';
+COMMENT ON COLUMN ty_reunion_records.verification_methods IS '身份确认方式，逗号分隔: dna-DNA比对, documents-证件资料, family_recognition-家属辨认, other-其他';
+COMMENT ON COLUMN ty_reunion_records.publicity_consent IS '是否同意用于宣传报道';
+COMMENT ON COLUMN ty_reunion_records.consent_by IS '宣传授权人';
+COMMENT ON COLUMN ty_reunion_records.photos IS '团聚照片 URL 列表（JSON 数组）';
+
+CREATE INDEX IF NOT EXISTS idx_reunion_records_confirmed_by ON ty_reunion_records(confirmed_by_id);
+
+-- ============================================
+-- 2. Follow-up Visits Table
+-- ============================================
+CREATE TABLE IF NOT EXISTS ty_reunion_follow_ups (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    reunion_id UUID NOT NULL REFERENCES ty_reunion_records(id) ON DELETE CASCADE,
+    missing_person_id UUID NOT NULL REFERENCES ty_missing_persons(id) ON DELETE CASCADE,
+    month INTEGER NOT NULL,
+    due_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'scheduled',
+    task_id UUID REFERENCES ty_tasks(id) ON DELETE SET NULL,
+    outcome VARCHAR(20),
+    note TEXT,
+    visitor_id UUID REFERENCES ty_users(id) ON DELETE SET NULL,
+    visited_at TIMESTAMP WITH TIME ZONE,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+COMMENT ON TABLE ty_reunion_follow_ups IS '团聚回访表';
+COMMENT ON COLUMN ty_reunion_follow_ups.month IS '回访节点（团聚后月数）: 1, 3, 12';
+COMMENT ON COLUMN ty_reunion_follow_ups.status IS '状态: scheduled-待生成任务, tasked-已生成回访任务, completed-已回访';
+COMMENT ON COLUMN ty_reunion_follow_ups.outcome IS '回访结果: stable-生活稳定, needs_support-需要帮扶, missing_again-再次走失, unreachable-无法联系';
+
+CREATE INDEX IF NOT EXISTS idx_reunion_follow_ups_reunion ON ty_reunion_follow_ups(reunion_id);
+CREATE INDEX IF NOT EXISTS idx_reunion_follow_ups_person ON ty_reunion_follow_ups(missing_person_id);
+CREATE INDEX IF NOT EXISTS idx_reunion_follow_ups_due ON ty_reunion_follow_ups(status, due_at);
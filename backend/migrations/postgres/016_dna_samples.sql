@@ -0,0 +1,75 @@
+-- Migration: DNA / Blood Sample Registry
+-- Date: 2026-10-17
+-- Description: Chain-of-custody and lab submission metadata for DNA samples collected from
+--              missing persons and family members. No raw genetic data is stored.
+
+-- ============================================
+-- 1. DNA Samples Table
+-- ============================================
+CREATE TABLE IF NOT EXISTS ty_dna_samples (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    missing_person_id UUID NOT NULL REFERENCES ty_missing_persons(id) ON DELETE CASCADE,
+    org_id UUID NOT NULL REFERENCES ty_organizations(id) ON DELETE RESTRICT,
+    sample_no VARCHAR(50) NOT NULL UNIQUE,
+    subject_type VARCHAR(20) NOT NULL,
+    sample_type VARCHAR(20) NOT NULL,
+    donor_name VARCHAR(50),
+    donor_relation VARCHAR(20),
+    donor_phone VARCHAR(20),
+    collected_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    collection_site VARCHAR(200) NOT NULL,
+    collector_id UUID NOT NULL REFERENCES ty_users(id) ON DELETE RESTRICT,
+    holder VARCHAR(100),
+    status VARCHAR(20) NOT NULL DEFAULT 'collected',
+    lab_name VARCHAR(100),
+    lab_case_no VARCHAR(100),
+    submitted_at TIMESTAMP WITH TIME ZONE,
+    result VARCHAR(20),
+    result_ref VARCHAR(100),
+    result_at TIMESTAMP WITH TIME ZONE,
+    note TEXT,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+COMMENT ON TABLE ty_dna_samples IS 'DNA / 血样登记表（仅保管链及送检元数据，不保存原始遗传数据）';
+COMMENT ON COLUMN ty_dna_samples.sample_no IS '样本编号';
+COMMENT ON COLUMN ty_dna_samples.subject_type IS '采样对象: missing_person-走失者本人, family_member-寻亲家属';
+COMMENT ON COLUMN ty_dna_samples.sample_type IS '样本类型: blood-血样, saliva-口腔拭子, hair-毛发, other-其他';
+COMMENT ON COLUMN ty_dna_samples.holder IS '当前保管人或保管机构';
+COMMENT ON COLUMN ty_dna_samples.status IS '状态: collected-已采集, submitted-已送检, resulted-已出结果, rejected-实验室退回, destroyed-已销毁';
+COMMENT ON COLUMN ty_dna_samples.lab_case_no IS '实验室受理编号';
+COMMENT ON COLUMN ty_dna_samples.result IS '比对结果: match-比中, no_match-未比中, inconclusive-无法判定';
+COMMENT ON COLUMN ty_dna_samples.result_ref IS '比对结果文书编号';
+
+CREATE INDEX IF NOT EXISTS idx_dna_samples_person ON ty_dna_samples(missing_person_id);
+CREATE INDEX IF NOT EXISTS idx_dna_samples_org ON ty_dna_samples(org_id);
+CREATE INDEX IF NOT EXISTS idx_dna_samples_collector ON ty_dna_samples(collector_id);
+CREATE INDEX IF NOT EXISTS idx_dna_samples_status ON ty_dna_samples(status);
+
+-- ============================================
+-- 2. Custody Logs Table
+-- ============================================
+CREATE TABLE IF NOT EXISTS ty_dna_sample_custody_logs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    sample_id UUID NOT NULL REFERENCES ty_dna_samples(id) ON DELETE CASCADE,
+    action VARCHAR(20) NOT NULL,
+    from_status VARCHAR(20),
+    to_status VARCHAR(20) NOT NULL,
+    operator_id UUID NOT NULL REFERENCES ty_users(id) ON DELETE RESTRICT,
+    holder VARCHAR(100),
+    location VARCHAR(200),
+    note TEXT,
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+COMMENT ON TABLE ty_dna_sample_custody_logs IS 'DNA 样本保管链记录表';
+COMMENT ON COLUMN ty_dna_sample_custody_logs.action IS '事件: collect-采集, transfer-移交保管, submit-送检, result-登记结果, reject-实验室退回, destroy-销毁';
+
+CREATE INDEX IF NOT EXISTS idx_dna_custody_logs_sample ON ty_dna_sample_custody_logs(sample_id, occurred_at);
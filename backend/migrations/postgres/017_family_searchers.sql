@@ -0,0 +1,126 @@
+-- Migration: Family Searcher Profiles
+-- Date: 2026-10-17
+-- Description: Profiles for families searching for lost relatives (家寻亲) and adults searching
+--              for their birth families (亲寻家), with contacts, relatives graph and case links
+
+-- ============================================
+-- 1. Family Searchers Table
+-- ============================================
+CREATE TABLE IF NOT EXISTS ty_family_searchers (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    kind VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+
+    name VARCHAR(50) NOT NULL,
+    gender VARCHAR(10),
+    birth_year INTEGER,
+    province VARCHAR(50),
+    city VARCHAR(50),
+
+    lost_name VARCHAR(50),
+    lost_name_pinyin VARCHAR(100),
+    lost_gender VARCHAR(10),
+    lost_birth_year INTEGER,
+    lost_age INTEGER,
+    lost_time TIMESTAMP WITH TIME ZONE,
+    lost_province VARCHAR(50),
+    lost_city VARCHAR(50),
+    lost_district VARCHAR(50),
+    lost_address VARCHAR(255),
+    dialect VARCHAR(50),
+    features TEXT,
+    memories TEXT,
+    description TEXT,
+
+    org_id UUID NOT NULL REFERENCES ty_organizations(id) ON DELETE RESTRICT,
+    registered_by_id UUID NOT NULL REFERENCES ty_users(id) ON DELETE RESTRICT,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+COMMENT ON TABLE ty_family_searchers IS '寻亲者档案表';
+COMMENT ON COLUMN ty_family_searchers.kind IS '寻亲类型: seeking_relative-家寻亲, seeking_family-亲寻家';
+COMMENT ON COLUMN ty_family_searchers.status IS '状态: active-寻找中, found-已找到, closed-已关闭';
+COMMENT ON COLUMN ty_family_searchers.lost_name IS '被寻找人姓名或乳名（亲寻家时为本人记得的小名）';
+COMMENT ON COLUMN ty_family_searchers.lost_age IS '走失时年龄';
+COMMENT ON COLUMN ty_family_searchers.memories IS '记忆片段';
+
+CREATE INDEX IF NOT EXISTS idx_family_searchers_kind ON ty_family_searchers(kind, status);
+CREATE INDEX IF NOT EXISTS idx_family_searchers_org ON ty_family_searchers(org_id);
+CREATE INDEX IF NOT EXISTS idx_family_searchers_lost_pinyin ON ty_family_searchers(lost_name_pinyin);
+CREATE INDEX IF NOT EXISTS idx_family_searchers_lost_gender ON ty_family_searchers(lost_gender);
+CREATE INDEX IF NOT EXISTS idx_family_searchers_lost_province ON ty_family_searchers(lost_province);
+
+-- ============================================
+-- 2. Contacts Table
+-- ============================================
+CREATE TABLE IF NOT EXISTS ty_family_searcher_contacts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    searcher_id UUID NOT NULL REFERENCES ty_family_searchers(id) ON DELETE CASCADE,
+    name VARCHAR(50) NOT NULL,
+    relation VARCHAR(20),
+    phone VARCHAR(20) NOT NULL,
+    wechat VARCHAR(50),
+    is_primary BOOLEAN NOT NULL DEFAULT FALSE,
+    note VARCHAR(255),
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+COMMENT ON TABLE ty_family_searcher_contacts IS '寻亲者联系人表';
+
+CREATE INDEX IF NOT EXISTS idx_family_searcher_contacts_searcher ON ty_family_searcher_contacts(searcher_id);
+CREATE INDEX IF NOT EXISTS idx_family_searcher_contacts_phone ON ty_family_searcher_contacts(phone);
+
+-- ============================================
+-- 3. Relatives Table
+-- ============================================
+CREATE TABLE IF NOT EXISTS ty_family_searcher_relatives (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    searcher_id UUID NOT NULL REFERENCES ty_family_searchers(id) ON DELETE CASCADE,
+    name VARCHAR(50),
+    relation VARCHAR(20) NOT NULL,
+    gender VARCHAR(10),
+    birth_year INTEGER,
+    hometown VARCHAR(255),
+    deceased BOOLEAN NOT NULL DEFAULT FALSE,
+    linked_searcher_id UUID REFERENCES ty_family_searchers(id) ON DELETE SET NULL,
+    note VARCHAR(255),
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+COMMENT ON TABLE ty_family_searcher_relatives IS '寻亲者亲属关系表';
+COMMENT ON COLUMN ty_family_searcher_relatives.relation IS '与被寻找人的关系';
+COMMENT ON COLUMN ty_family_searcher_relatives.linked_searcher_id IS '亲属本人也登记为寻亲者时指向其档案';
+
+CREATE INDEX IF NOT EXISTS idx_family_searcher_relatives_searcher ON ty_family_searcher_relatives(searcher_id);
+CREATE INDEX IF NOT EXISTS idx_family_searcher_relatives_linked ON ty_family_searcher_relatives(linked_searcher_id);
+
+-- ============================================
+-- 4. Case Links Table
+-- ============================================
+CREATE TABLE IF NOT EXISTS ty_family_searcher_cases (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    searcher_id UUID NOT NULL REFERENCES ty_family_searchers(id) ON DELETE CASCADE,
+    missing_person_id UUID NOT NULL REFERENCES ty_missing_persons(id) ON DELETE CASCADE,
+    relation VARCHAR(20),
+    match_score DOUBLE PRECISION,
+    linked_by_id UUID NOT NULL REFERENCES ty_users(id) ON DELETE RESTRICT,
+    note VARCHAR(255),
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+COMMENT ON TABLE ty_family_searcher_cases IS '寻亲者与案件关联表';
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_searcher_case ON ty_family_searcher_cases(searcher_id, missing_person_id);
+CREATE INDEX IF NOT EXISTS idx_family_searcher_cases_person ON ty_family_searcher_cases(missing_person_id);
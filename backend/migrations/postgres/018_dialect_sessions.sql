@@ -0,0 +1,71 @@
+-- Migration: Dialect Identification Sessions
+-- Date: 2026-10-17
+-- Description: Guided sessions that play dialect clips to a found person, narrowing from
+--              province to city to region, and rank likely places of origin
+
+-- ============================================
+-- 1. Dialect Sessions Table
+-- ============================================
+CREATE TABLE IF NOT EXISTS ty_dialect_sessions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    missing_person_id UUID REFERENCES ty_missing_persons(id) ON DELETE SET NULL,
+    subject_name VARCHAR(50),
+    status VARCHAR(20) NOT NULL DEFAULT 'in_progress',
+    stage VARCHAR(20) NOT NULL DEFAULT 'province',
+    province VARCHAR(50),
+    city VARCHAR(50),
+    note TEXT,
+    result TEXT,
+    completed_at TIMESTAMP WITH TIME ZONE,
+
+    attach_mode VARCHAR(20),
+    track_id UUID REFERENCES ty_missing_person_tracks(id) ON DELETE SET NULL,
+
+    operator_id UUID NOT NULL REFERENCES ty_users(id) ON DELETE RESTRICT,
+    org_id UUID NOT NULL REFERENCES ty_organizations(id) ON DELETE RESTRICT,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+COMMENT ON TABLE ty_dialect_sessions IS '方言辨识会话表';
+COMMENT ON COLUMN ty_dialect_sessions.status IS '状态: in_progress-辨识中, completed-已完成, cancelled-已取消';
+COMMENT ON COLUMN ty_dialect_sessions.stage IS '辨识阶段: province-按省, city-按市, region-按片区';
+COMMENT ON COLUMN ty_dialect_sessions.result IS '完成时的籍贯推断结果（JSON）';
+COMMENT ON COLUMN ty_dialect_sessions.attach_mode IS '关联案件方式: track-生成线索, note-备注';
+
+CREATE INDEX IF NOT EXISTS idx_dialect_sessions_person ON ty_dialect_sessions(missing_person_id);
+CREATE INDEX IF NOT EXISTS idx_dialect_sessions_status ON ty_dialect_sessions(status);
+CREATE INDEX IF NOT EXISTS idx_dialect_sessions_operator ON ty_dialect_sessions(operator_id);
+CREATE INDEX IF NOT EXISTS idx_dialect_sessions_org ON ty_dialect_sessions(org_id);
+
+-- ============================================
+-- 2. Session Answers Table
+-- ============================================
+CREATE TABLE IF NOT EXISTS ty_dialect_session_answers (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    session_id UUID NOT NULL REFERENCES ty_dialect_sessions(id) ON DELETE CASCADE,
+    dialect_id UUID NOT NULL REFERENCES ty_dialects(id) ON DELETE CASCADE,
+    stage VARCHAR(20) NOT NULL,
+    province VARCHAR(50),
+    city VARCHAR(50),
+    region VARCHAR(100),
+    recognition VARCHAR(20) NOT NULL,
+    note VARCHAR(500),
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+COMMENT ON TABLE ty_dialect_session_answers IS '方言辨识反应记录表';
+COMMENT ON COLUMN ty_dialect_session_answers.recognition IS '反应: native-家乡话, familiar-熟悉, partial-部分听懂, unfamiliar-听不懂';
+COMMENT ON COLUMN ty_dialect_session_answers.province IS '片段所属省份（播放时快照）';
+
+CREATE INDEX IF NOT EXISTS idx_dialect_session_answers_session ON ty_dialect_session_answers(session_id);
+
+-- ============================================
+-- 3. Identification Clip Lookup
+-- ============================================
+CREATE INDEX IF NOT EXISTS idx_dialects_identification ON ty_dialects(province, city) WHERE status = 'active';
@@ -0,0 +1,27 @@
+-- Migration: Audio Processing
+-- Date: 2026-10-17
+-- Description: Server-probed duration/format, loudness and derived variants (normalized MP3 stream,
+--              waveform JSON) for uploaded audio, and the processed audio file behind each dialect
+
+-- ============================================
+-- 1. Files Columns
+-- ============================================
+ALTER TABLE ty_files ADD COLUMN IF NOT EXISTS duration_ms INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE ty_files ADD COLUMN IF NOT EXISTS audio_format VARCHAR(10);
+ALTER TABLE ty_files ADD COLUMN IF NOT EXISTS loudness DOUBLE PRECISION;
+
+COMMENT ON COLUMN ty_files.duration_ms IS '音频时长（毫秒），服务端探测';
+COMMENT ON COLUMN ty_files.audio_format IS '音频实际格式: wav, mp3, aac, m4a, amr, amr-wb';
+COMMENT ON COLUMN ty_files.loudness IS '原始积分响度（LUFS）';
+COMMENT ON COLUMN ty_files.variants IS '衍生版本: public-去除元数据, thumbnail-缩略图, share-水印分享图, stream-归一化MP3, waveform-波形';
+
+-- ============================================
+-- 2. Dialects Columns
+-- ============================================
+ALTER TABLE ty_dialects ADD COLUMN IF NOT EXISTS audio_file_id UUID REFERENCES ty_files(id) ON DELETE SET NULL;
+ALTER TABLE ty_dialects ADD COLUMN IF NOT EXISTS waveform_url VARCHAR(255);
+
+COMMENT ON COLUMN ty_dialects.audio_file_id IS '处理后的音频文件';
+COMMENT ON COLUMN ty_dialects.waveform_url IS '播放器波形数据URL';
+
+CREATE INDEX IF NOT EXISTS idx_dialects_audio_file ON ty_dialects(audio_file_id);
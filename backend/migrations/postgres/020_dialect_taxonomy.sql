@@ -0,0 +1,65 @@
+-- Migration: Dialect Taxonomy
+-- Date: 2026-10-17
+-- Description: Managed dialect groups (family → subgroup → local variety) mapped to administrative
+--              regions, replacing free-text ty_dialects.region. Default groups are imported and
+--              existing dialects are grouped afterwards by
+--                  go run cmd/app/main.go -migrate-dialect-groups [-dry-run]
+--              or POST /api/v1/dialect-groups/migrate
+
+-- ============================================
+-- 1. Dialect Groups Table
+-- ============================================
+CREATE TABLE IF NOT EXISTS ty_dialect_groups (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    parent_id UUID REFERENCES ty_dialect_groups(id) ON DELETE RESTRICT,
+    level VARCHAR(20) NOT NULL,
+    name VARCHAR(50) NOT NULL,
+    aliases JSON,
+    path VARCHAR(255) NOT NULL,
+    full_name VARCHAR(200),
+    sort INTEGER NOT NULL DEFAULT 0,
+    description TEXT,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+COMMENT ON TABLE ty_dialect_groups IS '方言分类表';
+COMMENT ON COLUMN ty_dialect_groups.level IS '层级: family-方言大区, subgroup-方言片, variety-地点方言';
+COMMENT ON COLUMN ty_dialect_groups.aliases IS '别称（JSON 数组），如粤语的广东话、白话';
+COMMENT ON COLUMN ty_dialect_groups.path IS '从方言大区到自身的ID路径，如 /a/b/c/，按前缀查询子树';
+COMMENT ON COLUMN ty_dialect_groups.full_name IS '全称，如 吴语/太湖片/苏州话';
+
+CREATE INDEX IF NOT EXISTS idx_dialect_groups_parent ON ty_dialect_groups(parent_id);
+CREATE INDEX IF NOT EXISTS idx_dialect_groups_path ON ty_dialect_groups(path varchar_pattern_ops);
+CREATE INDEX IF NOT EXISTS idx_dialect_groups_deleted_at ON ty_dialect_groups(deleted_at);
+
+-- ============================================
+-- 2. Dialect Group Regions Table
+-- ============================================
+CREATE TABLE IF NOT EXISTS ty_dialect_group_regions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    group_id UUID NOT NULL REFERENCES ty_dialect_groups(id) ON DELETE CASCADE,
+    province VARCHAR(50) NOT NULL,
+    city VARCHAR(50),
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+COMMENT ON TABLE ty_dialect_group_regions IS '方言分类对应行政区表';
+COMMENT ON COLUMN ty_dialect_group_regions.city IS '城市，为空表示整个省';
+
+CREATE INDEX IF NOT EXISTS idx_dialect_group_regions_group ON ty_dialect_group_regions(group_id);
+CREATE INDEX IF NOT EXISTS idx_dialect_group_region ON ty_dialect_group_regions(province, city);
+
+-- ============================================
+-- 3. Dialects Column
+-- ============================================
+ALTER TABLE ty_dialects ADD COLUMN IF NOT EXISTS group_id UUID REFERENCES ty_dialect_groups(id) ON DELETE SET NULL;
+
+COMMENT ON COLUMN ty_dialects.group_id IS '方言分类（任意层级），设置后 region 取分类名称';
+
+CREATE INDEX IF NOT EXISTS idx_dialects_group ON ty_dialects(group_id);
@@ -0,0 +1,79 @@
+-- Migration: Dialect Moderation Queue
+-- Date: 2026-10-17
+-- Description: Per-organization review queue for uploaded dialects. Reviewers claim items,
+--              approve or reject them with a reason code, and the uploader is notified.
+--              Organizations that publish a workflow definition with category 'dialect_review'
+--              need one distinct reviewer per approval node. Dialects that are already pending
+--              are queued here.
+
+-- ============================================
+-- 1. Dialect Reviews Table
+-- ============================================
+CREATE TABLE IF NOT EXISTS ty_dialect_reviews (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    dialect_id UUID NOT NULL REFERENCES ty_dialects(id) ON DELETE CASCADE,
+    org_id UUID NOT NULL,
+    uploader_id UUID NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    required_approvals INTEGER NOT NULL DEFAULT 1,
+    approvals INTEGER NOT NULL DEFAULT 0,
+    reviewer_id UUID,
+    claimed_at TIMESTAMP WITH TIME ZONE,
+    workflow_instance_id UUID,
+    reason_code VARCHAR(30),
+    comment TEXT,
+    decided_at TIMESTAMP WITH TIME ZONE,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+COMMENT ON TABLE ty_dialect_reviews IS '方言审核表';
+COMMENT ON COLUMN ty_dialect_reviews.status IS '状态: pending-待认领, claimed-审核中, approved-已通过, rejected-已驳回';
+COMMENT ON COLUMN ty_dialect_reviews.required_approvals IS '需要通过的审核员人数，按方言审核流程的审批节点数';
+COMMENT ON COLUMN ty_dialect_reviews.reviewer_id IS '当前认领人，认领超过30分钟未处理可被重新认领';
+COMMENT ON COLUMN ty_dialect_reviews.reason_code IS '驳回原因';
+
+CREATE INDEX IF NOT EXISTS idx_dialect_reviews_dialect ON ty_dialect_reviews(dialect_id);
+CREATE INDEX IF NOT EXISTS idx_dialect_review_queue ON ty_dialect_reviews(org_id, status);
+CREATE INDEX IF NOT EXISTS idx_dialect_reviews_reviewer ON ty_dialect_reviews(reviewer_id);
+CREATE INDEX IF NOT EXISTS idx_dialect_reviews_deleted_at ON ty_dialect_reviews(deleted_at);
+
+-- ============================================
+-- 2. Dialect Review Decisions Table
+-- ============================================
+CREATE TABLE IF NOT EXISTS ty_dialect_review_decisions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    review_id UUID NOT NULL REFERENCES ty_dialect_reviews(id) ON DELETE CASCADE,
+    org_id UUID NOT NULL,
+    reviewer_id UUID NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    reason_code VARCHAR(30),
+    comment TEXT,
+    stage INTEGER NOT NULL,
+    handle_seconds INTEGER NOT NULL DEFAULT 0,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+COMMENT ON TABLE ty_dialect_review_decisions IS '方言审核结论表';
+COMMENT ON COLUMN ty_dialect_review_decisions.action IS '结论: approve-通过, reject-驳回';
+COMMENT ON COLUMN ty_dialect_review_decisions.stage IS '第几位审核员，从1开始';
+COMMENT ON COLUMN ty_dialect_review_decisions.handle_seconds IS '认领到给出结论的用时（秒）';
+
+CREATE INDEX IF NOT EXISTS idx_dialect_review_decisions_review ON ty_dialect_review_decisions(review_id);
+CREATE INDEX IF NOT EXISTS idx_dialect_decision_reviewer ON ty_dialect_review_decisions(org_id, reviewer_id);
+CREATE INDEX IF NOT EXISTS idx_dialect_review_decisions_deleted_at ON ty_dialect_review_decisions(deleted_at);
+
+-- ============================================
+-- 3. Queue Existing Pending Dialects
+-- ============================================
+INSERT INTO ty_dialect_reviews (dialect_id, org_id, uploader_id, status, required_approvals, created_at, updated_at)
+SELECT d.id, d.org_id, d.uploader_id, 'pending', 1, d.created_at, NOW()
+FROM ty_dialects d
+WHERE d.status = 'pending'
+  AND d.deleted_at IS NULL
+  AND NOT EXISTS (SELECT 1 FROM ty_dialect_reviews r WHERE r.dialect_id = d.id);
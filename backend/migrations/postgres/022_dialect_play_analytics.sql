@@ -0,0 +1,20 @@
+-- Migration: Dialect Playback Analytics
+-- Date: 2026-10-17
+-- Description: Listener key on play logs so repeat plays can be de-duplicated and unique
+--              listeners counted. A play by the same listener within 30 minutes updates the
+--              existing log instead of adding one, so refreshes no longer inflate play_count.
+--              Existing logs are keyed by user; anonymous logs by an MD5 of IP and user agent
+--              (new anonymous keys use SHA-1, so such listeners spanning the migration count twice).
+
+ALTER TABLE ty_dialect_play_logs ADD COLUMN IF NOT EXISTS listener_key VARCHAR(64) NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN ty_dialect_play_logs.listener_key IS '收听者标识：登录用户为 user:<id>，匿名为 IP 与 User-Agent 的摘要';
+
+UPDATE ty_dialect_play_logs
+SET listener_key = CASE
+    WHEN user_id IS NOT NULL THEN 'user:' || user_id::text
+    ELSE 'anon:' || LEFT(MD5(COALESCE(ip, '') || '|' || COALESCE(user_agent, '')), 24)
+END
+WHERE listener_key = '';
+
+CREATE INDEX IF NOT EXISTS idx_dialect_play_listener ON ty_dialect_play_logs(dialect_id, listener_key, created_at);
@@ -0,0 +1,77 @@
+-- Migration: Dialect Comment Threads and Moderation
+-- Date: 2026-10-17
+-- Description: Two-level comment threads (replies hang off the top-level comment and record the
+--              user being replied to), comment likes, abuse reports and soft-hide by moderators.
+--              Comments matching the sensitive-word dictionary are masked and flagged for review.
+--              Existing replies to replies are moved under their top-level comment and reply
+--              counts are recomputed.
+
+-- ============================================
+-- 1. Comment moderation columns
+-- ============================================
+ALTER TABLE ty_dialect_comments ADD COLUMN IF NOT EXISTS reply_to_user_id UUID;
+ALTER TABLE ty_dialect_comments ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'visible';
+ALTER TABLE ty_dialect_comments ADD COLUMN IF NOT EXISTS flagged BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE ty_dialect_comments ADD COLUMN IF NOT EXISTS report_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE ty_dialect_comments ADD COLUMN IF NOT EXISTS hidden_by UUID;
+ALTER TABLE ty_dialect_comments ADD COLUMN IF NOT EXISTS hidden_reason VARCHAR(200);
+ALTER TABLE ty_dialect_comments ADD COLUMN IF NOT EXISTS hidden_at TIMESTAMP WITH TIME ZONE;
+
+COMMENT ON COLUMN ty_dialect_comments.reply_to_user_id IS '被回复的用户（回复楼中楼时）';
+COMMENT ON COLUMN ty_dialect_comments.status IS '状态: visible-正常, hidden-已被管理员隐藏';
+COMMENT ON COLUMN ty_dialect_comments.flagged IS '命中敏感词，等待管理员复核';
+
+CREATE INDEX IF NOT EXISTS idx_dialect_comments_status ON ty_dialect_comments(status);
+
+UPDATE ty_dialect_comments c
+SET reply_to_user_id = p.user_id, parent_id = p.parent_id
+FROM ty_dialect_comments p
+WHERE c.parent_id = p.id AND p.parent_id IS NOT NULL;
+
+UPDATE ty_dialect_comments c
+SET reply_count = (
+    SELECT COUNT(*) FROM ty_dialect_comments r WHERE r.parent_id = c.id AND r.deleted_at IS NULL
+)
+WHERE c.parent_id IS NULL;
+
+-- ============================================
+-- 2. Comment Likes Table
+-- ============================================
+CREATE TABLE IF NOT EXISTS ty_dialect_comment_likes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    comment_id UUID NOT NULL REFERENCES ty_dialect_comments(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES ty_users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE ty_dialect_comment_likes IS '方言评论点赞表';
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_dialect_comment_like ON ty_dialect_comment_likes(comment_id, user_id);
+
+-- ============================================
+-- 3. Comment Reports Table
+-- ============================================
+CREATE TABLE IF NOT EXISTS ty_dialect_comment_reports (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    comment_id UUID NOT NULL REFERENCES ty_dialect_comments(id) ON DELETE CASCADE,
+    dialect_id UUID NOT NULL,
+    reporter_id UUID NOT NULL,
+    reason VARCHAR(30) NOT NULL,
+    detail VARCHAR(500),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    handled_by UUID,
+    handled_at TIMESTAMP WITH TIME ZONE,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+COMMENT ON TABLE ty_dialect_comment_reports IS '方言评论举报表';
+COMMENT ON COLUMN ty_dialect_comment_reports.reason IS '举报原因: spam, abuse, fraud, privacy, other';
+COMMENT ON COLUMN ty_dialect_comment_reports.status IS '状态: pending-待处理, upheld-成立（评论已隐藏）, dismissed-不成立';
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_dialect_comment_report ON ty_dialect_comment_reports(comment_id, reporter_id);
+CREATE INDEX IF NOT EXISTS idx_dialect_comment_reports_dialect ON ty_dialect_comment_reports(dialect_id);
+CREATE INDEX IF NOT EXISTS idx_dialect_comment_reports_status ON ty_dialect_comment_reports(status);
+CREATE INDEX IF NOT EXISTS idx_dialect_comment_reports_deleted_at ON ty_dialect_comment_reports(deleted_at);
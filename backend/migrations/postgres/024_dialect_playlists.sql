@@ -0,0 +1,79 @@
+-- Migration: Dialect Playlists and Collections
+-- Date: 2026-10-17
+-- Description: Ordered dialect playlists for hometown identification field visits. Playlists are
+--              private or shared within the org, and collaborators may add, remove and reorder
+--              clips. Auto collections ("best of province") are regenerated from featured flag,
+--              like count and play completion. Playlists can be exported as a signed offline ZIP.
+
+-- ============================================
+-- 1. Playlists Table
+-- ============================================
+CREATE TABLE IF NOT EXISTS ty_dialect_playlists (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    title VARCHAR(100) NOT NULL,
+    description VARCHAR(500),
+    visibility VARCHAR(20) NOT NULL DEFAULT 'private',
+    kind VARCHAR(20) NOT NULL DEFAULT 'manual',
+    province VARCHAR(50),
+    item_limit INTEGER NOT NULL DEFAULT 0,
+    item_count INTEGER NOT NULL DEFAULT 0,
+    total_duration INTEGER NOT NULL DEFAULT 0,
+    refreshed_at TIMESTAMP WITH TIME ZONE,
+    owner_id UUID NOT NULL REFERENCES ty_users(id),
+    org_id UUID NOT NULL REFERENCES ty_organizations(id),
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+COMMENT ON TABLE ty_dialect_playlists IS '方言歌单表';
+COMMENT ON COLUMN ty_dialect_playlists.visibility IS '可见范围: private-仅创建者和协作者, org-组织内共享';
+COMMENT ON COLUMN ty_dialect_playlists.kind IS '类型: manual-手动编排, auto-按省份自动生成的精选合集';
+COMMENT ON COLUMN ty_dialect_playlists.item_limit IS '自动合集的片段数';
+COMMENT ON COLUMN ty_dialect_playlists.total_duration IS '总时长（秒）';
+COMMENT ON COLUMN ty_dialect_playlists.refreshed_at IS '自动合集最近一次生成时间';
+
+CREATE INDEX IF NOT EXISTS idx_dialect_playlists_owner ON ty_dialect_playlists(owner_id);
+CREATE INDEX IF NOT EXISTS idx_dialect_playlists_org ON ty_dialect_playlists(org_id, visibility);
+CREATE INDEX IF NOT EXISTS idx_dialect_playlists_kind ON ty_dialect_playlists(kind);
+CREATE INDEX IF NOT EXISTS idx_dialect_playlists_province ON ty_dialect_playlists(province);
+CREATE INDEX IF NOT EXISTS idx_dialect_playlists_deleted_at ON ty_dialect_playlists(deleted_at);
+
+-- ============================================
+-- 2. Playlist Items Table
+-- ============================================
+CREATE TABLE IF NOT EXISTS ty_dialect_playlist_items (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    playlist_id UUID NOT NULL REFERENCES ty_dialect_playlists(id) ON DELETE CASCADE,
+    dialect_id UUID NOT NULL REFERENCES ty_dialects(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL DEFAULT 0,
+    note VARCHAR(200),
+    score DOUBLE PRECISION NOT NULL DEFAULT 0,
+    added_by UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE ty_dialect_playlist_items IS '方言歌单片段表';
+COMMENT ON COLUMN ty_dialect_playlist_items.position IS '顺序，从 1 开始';
+COMMENT ON COLUMN ty_dialect_playlist_items.note IS '现场播放提示';
+COMMENT ON COLUMN ty_dialect_playlist_items.score IS '自动合集的排序得分';
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_dialect_playlist_item ON ty_dialect_playlist_items(playlist_id, dialect_id);
+CREATE INDEX IF NOT EXISTS idx_dialect_playlist_items_position ON ty_dialect_playlist_items(playlist_id, position);
+
+-- ============================================
+-- 3. Playlist Collaborators Table
+-- ============================================
+CREATE TABLE IF NOT EXISTS ty_dialect_playlist_collaborators (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    playlist_id UUID NOT NULL REFERENCES ty_dialect_playlists(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES ty_users(id) ON DELETE CASCADE,
+    added_by UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE ty_dialect_playlist_collaborators IS '方言歌单协作者表';
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_dialect_playlist_collaborator ON ty_dialect_playlist_collaborators(playlist_id, user_id);
+CREATE INDEX IF NOT EXISTS idx_dialect_playlist_collaborators_user ON ty_dialect_playlist_collaborators(user_id);
@@ -0,0 +1,20 @@
+-- Migration: Audio Fingerprints
+-- Date: 2026-10-17
+-- Description: Acoustic fingerprint (spectral sub-fingerprints, base64) computed when audio is
+--              uploaded and copied onto the dialect record. New dialects whose fingerprint is close
+--              to an existing one are flagged as suspected duplicates in the moderation queue.
+--              Audio uploaded before this migration has no fingerprint and is not compared.
+
+ALTER TABLE ty_files ADD COLUMN IF NOT EXISTS audio_fingerprint TEXT;
+ALTER TABLE ty_dialects ADD COLUMN IF NOT EXISTS fingerprint TEXT;
+
+COMMENT ON COLUMN ty_files.audio_fingerprint IS '声纹指纹（仅音频）';
+COMMENT ON COLUMN ty_dialects.fingerprint IS '声纹指纹，用于发现重复上传';
+
+ALTER TABLE ty_dialect_reviews ADD COLUMN IF NOT EXISTS duplicate_of_id UUID REFERENCES ty_dialects(id) ON DELETE SET NULL;
+ALTER TABLE ty_dialect_reviews ADD COLUMN IF NOT EXISTS duplicate_similarity DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN ty_dialect_reviews.duplicate_of_id IS '疑似重复的已有方言';
+COMMENT ON COLUMN ty_dialect_reviews.duplicate_similarity IS '与该方言的声纹相似度（0~1）';
+
+CREATE INDEX IF NOT EXISTS idx_dialect_reviews_duplicate_of ON ty_dialect_reviews(duplicate_of_id);
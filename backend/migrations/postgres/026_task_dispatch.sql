@@ -0,0 +1,60 @@
+-- Migration: Task Auto-Dispatch
+-- Date: 2026-10-17
+-- Description: Dispatch engine for pending tasks. Candidate volunteers are ranked by distance to
+--              their home or last-known location, required skills and languages (including
+--              dialects) and current open-task count. The top N receive an offer over WebSocket;
+--              the first to accept gets the task. Offers that time out or are declined roll over
+--              to the next round, and after the last round the task falls back to manual assignment.
+
+-- ============================================
+-- 1. Task Dispatch Columns
+-- ============================================
+ALTER TABLE ty_tasks ADD COLUMN IF NOT EXISTS skills VARCHAR(255);
+ALTER TABLE ty_tasks ADD COLUMN IF NOT EXISTS languages VARCHAR(255);
+ALTER TABLE ty_tasks ADD COLUMN IF NOT EXISTS dispatch_status VARCHAR(20) NOT NULL DEFAULT '';
+ALTER TABLE ty_tasks ADD COLUMN IF NOT EXISTS dispatch_round INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE ty_tasks ADD COLUMN IF NOT EXISTS dispatched_at TIMESTAMP WITH TIME ZONE;
+
+COMMENT ON COLUMN ty_tasks.skills IS '所需技能，逗号分隔';
+COMMENT ON COLUMN ty_tasks.languages IS '所需语言或方言，逗号分隔';
+COMMENT ON COLUMN ty_tasks.dispatch_status IS '自动派单状态: 空-未派单, offering-推送中, accepted-已接单, exhausted-无人接单转人工';
+COMMENT ON COLUMN ty_tasks.dispatch_round IS '已推送轮数';
+COMMENT ON COLUMN ty_tasks.dispatched_at IS '最近一轮推送时间';
+
+CREATE INDEX IF NOT EXISTS idx_tasks_dispatch ON ty_tasks(status, dispatch_status);
+
+-- ============================================
+-- 2. Task Offers Table
+-- ============================================
+CREATE TABLE IF NOT EXISTS ty_task_offers (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    task_id UUID NOT NULL REFERENCES ty_tasks(id) ON DELETE CASCADE,
+    org_id UUID NOT NULL REFERENCES ty_organizations(id),
+    user_id UUID NOT NULL REFERENCES ty_users(id) ON DELETE CASCADE,
+    round INTEGER NOT NULL DEFAULT 1,
+    position INTEGER NOT NULL DEFAULT 1,
+    score DOUBLE PRECISION NOT NULL DEFAULT 0,
+    distance_km DOUBLE PRECISION,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    responded_at TIMESTAMP WITH TIME ZONE,
+    reason VARCHAR(200),
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+COMMENT ON TABLE ty_task_offers IS '任务派单邀请表';
+COMMENT ON COLUMN ty_task_offers.round IS '派单轮次';
+COMMENT ON COLUMN ty_task_offers.position IS '本轮排名';
+COMMENT ON COLUMN ty_task_offers.score IS '派单评分（0~100）';
+COMMENT ON COLUMN ty_task_offers.distance_km IS '志愿者到任务地点的距离（公里），位置未知时为空';
+COMMENT ON COLUMN ty_task_offers.status IS '状态: pending-待响应, accepted-已接单, declined-已拒绝, expired-已超时, withdrawn-已撤回';
+COMMENT ON COLUMN ty_task_offers.reason IS '拒绝原因';
+
+CREATE INDEX IF NOT EXISTS idx_task_offers_task ON ty_task_offers(task_id, status);
+CREATE INDEX IF NOT EXISTS idx_task_offers_org ON ty_task_offers(org_id);
+CREATE INDEX IF NOT EXISTS idx_task_offer_user ON ty_task_offers(user_id, status);
+CREATE INDEX IF NOT EXISTS idx_task_offers_expires_at ON ty_task_offers(expires_at);
+CREATE INDEX IF NOT EXISTS idx_task_offers_deleted_at ON ty_task_offers(deleted_at);
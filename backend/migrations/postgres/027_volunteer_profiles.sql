@@ -0,0 +1,132 @@
+-- Migration: Volunteer Profiles
+-- Date: 2026-10-17
+-- Description: Volunteer profile with home location, service radius and skills, plus certifications
+--              with expiry, spoken languages linked to dialect groups, a weekly availability
+--              schedule and vacation blocks. Profiles back the volunteer search API and enrich
+--              task dispatch candidates (home location, skills, languages, availability).
+
+-- ============================================
+-- 1. Volunteer Profiles Table
+-- ============================================
+CREATE TABLE IF NOT EXISTS ty_volunteer_profiles (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES ty_users(id) ON DELETE CASCADE,
+    org_id UUID NOT NULL REFERENCES ty_organizations(id),
+    province VARCHAR(50),
+    city VARCHAR(50),
+    district VARCHAR(50),
+    address VARCHAR(255),
+    home_lat DECIMAL(10, 8),
+    home_lng DECIMAL(11, 8),
+    service_radius_km DECIMAL(8, 2) NOT NULL DEFAULT 0,
+    skills VARCHAR(500),
+    bio TEXT,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+COMMENT ON TABLE ty_volunteer_profiles IS '志愿者档案表';
+COMMENT ON COLUMN ty_volunteer_profiles.home_lat IS '常住地纬度';
+COMMENT ON COLUMN ty_volunteer_profiles.home_lng IS '常住地经度';
+COMMENT ON COLUMN ty_volunteer_profiles.service_radius_km IS '服务半径（公里），0 表示不限';
+COMMENT ON COLUMN ty_volunteer_profiles.skills IS '技能，逗号分隔';
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_volunteer_profiles_user ON ty_volunteer_profiles(user_id);
+CREATE INDEX IF NOT EXISTS idx_volunteer_profiles_org ON ty_volunteer_profiles(org_id);
+CREATE INDEX IF NOT EXISTS idx_volunteer_profiles_province ON ty_volunteer_profiles(province);
+CREATE INDEX IF NOT EXISTS idx_volunteer_profiles_deleted_at ON ty_volunteer_profiles(deleted_at);
+
+-- ============================================
+-- 2. Volunteer Certifications Table
+-- ============================================
+CREATE TABLE IF NOT EXISTS ty_volunteer_certifications (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    profile_id UUID NOT NULL REFERENCES ty_volunteer_profiles(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    issuer VARCHAR(100),
+    cert_no VARCHAR(100),
+    issued_at TIMESTAMP WITH TIME ZONE,
+    expires_at TIMESTAMP WITH TIME ZONE,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+COMMENT ON TABLE ty_volunteer_certifications IS '志愿者证书表';
+COMMENT ON COLUMN ty_volunteer_certifications.expires_at IS '有效期至，为空表示长期有效';
+
+CREATE INDEX IF NOT EXISTS idx_volunteer_certifications_profile ON ty_volunteer_certifications(profile_id);
+CREATE INDEX IF NOT EXISTS idx_volunteer_certifications_name ON ty_volunteer_certifications(name);
+CREATE INDEX IF NOT EXISTS idx_volunteer_certifications_expires_at ON ty_volunteer_certifications(expires_at);
+CREATE INDEX IF NOT EXISTS idx_volunteer_certifications_deleted_at ON ty_volunteer_certifications(deleted_at);
+
+-- ============================================
+-- 3. Volunteer Languages Table
+-- ============================================
+CREATE TABLE IF NOT EXISTS ty_volunteer_languages (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    profile_id UUID NOT NULL REFERENCES ty_volunteer_profiles(id) ON DELETE CASCADE,
+    name VARCHAR(50) NOT NULL,
+    dialect_group_id UUID REFERENCES ty_dialect_groups(id) ON DELETE SET NULL,
+    level VARCHAR(20) NOT NULL DEFAULT 'fluent',
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+COMMENT ON TABLE ty_volunteer_languages IS '志愿者语言方言表';
+COMMENT ON COLUMN ty_volunteer_languages.dialect_group_id IS '关联的方言分类';
+COMMENT ON COLUMN ty_volunteer_languages.level IS '熟练程度: native-母语, fluent-流利, basic-简单交流';
+
+CREATE INDEX IF NOT EXISTS idx_volunteer_languages_profile ON ty_volunteer_languages(profile_id);
+CREATE INDEX IF NOT EXISTS idx_volunteer_languages_name ON ty_volunteer_languages(name);
+CREATE INDEX IF NOT EXISTS idx_volunteer_languages_group ON ty_volunteer_languages(dialect_group_id);
+CREATE INDEX IF NOT EXISTS idx_volunteer_languages_deleted_at ON ty_volunteer_languages(deleted_at);
+
+-- ============================================
+-- 4. Volunteer Availability Table
+-- ============================================
+CREATE TABLE IF NOT EXISTS ty_volunteer_availability (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    profile_id UUID NOT NULL REFERENCES ty_volunteer_profiles(id) ON DELETE CASCADE,
+    weekday SMALLINT NOT NULL,
+    start_minute INTEGER NOT NULL,
+    end_minute INTEGER NOT NULL,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+COMMENT ON TABLE ty_volunteer_availability IS '志愿者每周值班时段表';
+COMMENT ON COLUMN ty_volunteer_availability.weekday IS '星期，0 为周日';
+COMMENT ON COLUMN ty_volunteer_availability.start_minute IS '开始时刻（当天零点起的分钟数）';
+COMMENT ON COLUMN ty_volunteer_availability.end_minute IS '结束时刻（不含），1440 表示当天结束';
+
+CREATE INDEX IF NOT EXISTS idx_volunteer_availability_profile ON ty_volunteer_availability(profile_id);
+CREATE INDEX IF NOT EXISTS idx_volunteer_availability_deleted_at ON ty_volunteer_availability(deleted_at);
+
+-- ============================================
+-- 5. Volunteer Vacations Table
+-- ============================================
+CREATE TABLE IF NOT EXISTS ty_volunteer_vacations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    profile_id UUID NOT NULL REFERENCES ty_volunteer_profiles(id) ON DELETE CASCADE,
+    start_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    end_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    reason VARCHAR(200),
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+COMMENT ON TABLE ty_volunteer_vacations IS '志愿者休假表，休假期间不参与派单';
+
+CREATE INDEX IF NOT EXISTS idx_volunteer_vacations_profile ON ty_volunteer_vacations(profile_id);
+CREATE INDEX IF NOT EXISTS idx_volunteer_vacations_end_at ON ty_volunteer_vacations(end_at);
+CREATE INDEX IF NOT EXISTS idx_volunteer_vacations_deleted_at ON ty_volunteer_vacations(deleted_at);
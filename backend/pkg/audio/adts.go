@@ -0,0 +1,69 @@
+package audio
+
+var adtsSampleRates = [13]int{96000, 88200, 64000, 48000, 44100, 32000, 24000, 22050, 16000, 12000, 11025, 8000, 7350}
+
+// adtsHeader ADTS 帧头
+type adtsHeader struct {
+	sampleRate int
+	channels   int
+	samples    int // 每帧采样数
+	size       int // 帧长（含帧头）
+}
+
+// parseADTSHeader 解析 ADTS 帧头
+func parseADTSHeader(b []byte) (adtsHeader, bool) {
+	var h adtsHeader
+	if len(b) < 7 || b[0] != 0xFF || b[1]&0xF6 != 0xF0 {
+		return h, false
+	}
+	srIndex := int(b[2]>>2) & 0x0F
+	if srIndex >= len(adtsSampleRates) {
+		return h, false
+	}
+	h.sampleRate = adtsSampleRates[srIndex]
+	h.channels = int(b[2]&0x01)<<2 | int(b[3]>>6)
+	h.size = int(b[3]&0x03)<<11 | int(b[4])<<3 | int(b[5]>>5)
+	h.samples = 1024 * (int(b[6]&0x03) + 1)
+
+	headerSize := 7
+	if b[1]&0x01 == 0 {
+		headerSize = 9 // 含 CRC
+	}
+	return h, h.size > headerSize
+}
+
+// probeADTS 逐帧统计 ADTS AAC 时长
+func probeADTS(data []byte) (*Info, error) {
+	offset := skipID3v2(data)
+
+	var (
+		first   *adtsHeader
+		samples int64
+	)
+	for offset+7 <= len(data) {
+		h, ok := parseADTSHeader(data[offset:])
+		if !ok || offset+h.size > len(data) {
+			break
+		}
+		if first == nil {
+			hh := h
+			first = &hh
+		}
+		samples += int64(h.samples)
+		offset += h.size
+	}
+
+	if first == nil {
+		return nil, ErrCorrupt
+	}
+	channels := first.channels
+	if channels == 0 {
+		channels = 2 // 声道配置在 AOT 特定配置中，常见为立体声
+	}
+	return &Info{
+		Format:     FormatAAC,
+		Duration:   samplesDuration(samples, first.sampleRate),
+		SampleRate: first.sampleRate,
+		Channels:   channels,
+	}, nil
+}
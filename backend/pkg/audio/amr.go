@@ -0,0 +1,45 @@
+package audio
+
+import "time"
+
+var (
+	amrNBMagic = []byte("#!AMR\n")
+	amrWBMagic = []byte("#!AMR-WB\n")
+)
+
+// amr 帧长（含 1 字节帧头），按帧类型索引；每帧固定 20ms
+var (
+	amrNBFrameSizes = [16]int{13, 14, 16, 18, 20, 21, 27, 32, 6, 1, 1, 1, 1, 1, 1, 1}
+	amrWBFrameSizes = [16]int{18, 24, 33, 37, 41, 47, 51, 59, 61, 6, 1, 1, 1, 1, 1, 1}
+)
+
+const amrFrameDuration = 20 * time.Millisecond
+
+// probeAMR 逐帧统计 AMR 时长（微信语音为 AMR-NB 单声道 8kHz）
+func probeAMR(data []byte) (*Info, error) {
+	info := &Info{Format: FormatAMR, SampleRate: 8000, Channels: 1}
+	sizes := amrNBFrameSizes
+	offset := len(amrNBMagic)
+	if Detect(data) == FormatAMRWB {
+		info.Format = FormatAMRWB
+		info.SampleRate = 16000
+		sizes = amrWBFrameSizes
+		offset = len(amrWBMagic)
+	}
+
+	frames := 0
+	for offset < len(data) {
+		size := sizes[(data[offset]>>3)&0x0F]
+		if offset+size > len(data) {
+			break
+		}
+		frames++
+		offset += size
+	}
+
+	if frames == 0 {
+		return nil, ErrCorrupt
+	}
+	info.Duration = time.Duration(frames) * amrFrameDuration
+	return info, nil
+}
@@ -0,0 +1,143 @@
+// Package audio 音频探测与处理：从文件内容解析真实格式和时长，计算响度并归一化，
+// 转码为统一的流媒体格式并生成播放器使用的波形数据
+package audio
+
+import (
+	"bytes"
+	"errors"
+	"time"
+)
+
+// Format 音频格式
+type Format string
+
+const (
+	FormatWAV   Format = "wav"
+	FormatMP3   Format = "mp3"
+	FormatAAC   Format = "aac"    // ADTS 封装的 AAC
+	FormatM4A   Format = "m4a"    // MP4 封装的 AAC
+	FormatAMR   Format = "amr"    // AMR-NB（微信语音）
+	FormatAMRWB Format = "amr-wb" // AMR-WB
+)
+
+// StreamFormat 转码后的统一流媒体格式
+const StreamFormat = FormatMP3
+
+var (
+	ErrUnknownFormat    = errors.New("audio: unknown format")
+	ErrCorrupt          = errors.New("audio: corrupt or truncated data")
+	ErrUnsupported      = errors.New("audio: unsupported encoding")
+	ErrCodecUnavailable = errors.New("audio: codec unavailable")
+	ErrTooLong          = errors.New("audio: duration exceeds limit")
+)
+
+// Info 音频探测结果
+type Info struct {
+	Format     Format
+	Duration   time.Duration
+	SampleRate int
+	Channels   int
+	Bitrate    int // 平均码率（bps）
+}
+
+// Seconds 时长（秒，向上取整）
+func (i *Info) Seconds() int {
+	return int((i.Duration + time.Second - 1) / time.Second)
+}
+
+// MimeType 格式对应的 MIME 类型
+func (f Format) MimeType() string {
+	switch f {
+	case FormatWAV:
+		return "audio/wav"
+	case FormatMP3:
+		return "audio/mpeg"
+	case FormatAAC:
+		return "audio/aac"
+	case FormatM4A:
+		return "audio/mp4"
+	case FormatAMR:
+		return "audio/amr"
+	case FormatAMRWB:
+		return "audio/amr-wb"
+	}
+	return "application/octet-stream"
+}
+
+// Ext 格式对应的文件扩展名
+func (f Format) Ext() string {
+	if f == FormatAMRWB {
+		return ".awb"
+	}
+	return "." + string(f)
+}
+
+// Detect 根据文件头识别格式，无法识别时返回空
+func Detect(data []byte) Format {
+	switch {
+	case len(data) >= 12 && string(data[:4]) == "RIFF" && string(data[8:12]) == "WAVE":
+		return FormatWAV
+	case bytes.HasPrefix(data, amrWBMagic):
+		return FormatAMRWB
+	case bytes.HasPrefix(data, amrNBMagic):
+		return FormatAMR
+	case len(data) >= 8 && string(data[4:8]) == "ftyp":
+		return FormatM4A
+	}
+
+	data = data[skipID3v2(data):]
+	if len(data) < 4 || data[0] != 0xFF || data[1]&0xF0 != 0xF0 {
+		return ""
+	}
+	// ADTS 的 layer 固定为 0，MPEG 音频的 layer 不能为 0
+	if data[1]&0x06 == 0 {
+		if _, ok := parseADTSHeader(data); ok {
+			return FormatAAC
+		}
+		return ""
+	}
+	if _, ok := parseMPEGHeader(data); ok {
+		return FormatMP3
+	}
+	return ""
+}
+
+// Probe 解析音频真实格式、时长、采样率和声道数，不信任文件扩展名和客户端声明
+func Probe(data []byte) (*Info, error) {
+	var (
+		info *Info
+		err  error
+	)
+	switch Detect(data) {
+	case FormatWAV:
+		info, err = probeWAV(data)
+	case FormatMP3:
+		info, err = probeMPEG(data)
+	case FormatAAC:
+		info, err = probeADTS(data)
+	case FormatM4A:
+		info, err = probeMP4(data)
+	case FormatAMR, FormatAMRWB:
+		info, err = probeAMR(data)
+	default:
+		return nil, ErrUnknownFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+	if info.Duration <= 0 {
+		return nil, ErrCorrupt
+	}
+	if info.Bitrate == 0 {
+		info.Bitrate = int(float64(len(data)*8) / info.Duration.Seconds())
+	}
+	return info, nil
+}
+
+// samplesDuration 采样数换算为时长
+func samplesDuration(samples int64, sampleRate int) time.Duration {
+	if sampleRate <= 0 {
+		return 0
+	}
+	return time.Duration(samples * int64(time.Second) / int64(sampleRate))
+}
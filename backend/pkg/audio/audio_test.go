@@ -0,0 +1,282 @@
+package audio
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sine 生成正弦波，amplitude 为峰值
+func sine(sampleRate int, freq, amplitude float64, d time.Duration) *PCM {
+	n := int(d.Seconds() * float64(sampleRate))
+	pcm := &PCM{SampleRate: sampleRate, Samples: make([]float32, n)}
+	for i := range pcm.Samples {
+		pcm.Samples[i] = float32(amplitude * math.Sin(2*math.Pi*freq*float64(i)/float64(sampleRate)))
+	}
+	return pcm
+}
+
+// wav16 编码为 16 位 PCM WAV，channels 个声道写入相同数据
+func wav16(pcm *PCM, channels int) []byte {
+	var body bytes.Buffer
+	for _, s := range pcm.Samples {
+		for ch := 0; ch < channels; ch++ {
+			binary.Write(&body, binary.LittleEndian, int16(s*32767))
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("RIFF")
+	binary.Write(&buf, binary.LittleEndian, uint32(36+body.Len()))
+	buf.WriteString("WAVEfmt ")
+	for _, v := range []any{
+		uint32(16), uint16(wavFormatPCM), uint16(channels), uint32(pcm.SampleRate),
+		uint32(pcm.SampleRate * 2 * channels), uint16(2 * channels), uint16(16),
+	} {
+		binary.Write(&buf, binary.LittleEndian, v)
+	}
+	// 非音频块应被跳过
+	buf.WriteString("LIST")
+	binary.Write(&buf, binary.LittleEndian, uint32(3))
+	buf.Write([]byte{'a', 'b', 'c', 0})
+	buf.WriteString("data")
+	binary.Write(&buf, binary.LittleEndian, uint32(body.Len()))
+	buf.Write(body.Bytes())
+	return buf.Bytes()
+}
+
+// mp3Frames 生成 MPEG1 Layer III 128kbps 44.1kHz 单声道帧（内容为空）
+func mp3Frames(n int) []byte {
+	frame := make([]byte, 417)
+	copy(frame, []byte{0xFF, 0xFB, 0x90, 0xC0})
+	var buf bytes.Buffer
+	// ID3v2 标签：长度 20
+	buf.Write([]byte{'I', 'D', '3', 4, 0, 0, 0, 0, 0, 20})
+	buf.Write(make([]byte, 20))
+	for i := 0; i < n; i++ {
+		buf.Write(frame)
+	}
+	return buf.Bytes()
+}
+
+func TestProbe_WAV(t *testing.T) {
+	data := wav16(sine(16000, 440, 0.5, 1500*time.Millisecond), 2)
+
+	info, err := Probe(data)
+	require.NoError(t, err)
+	assert.Equal(t, FormatWAV, info.Format)
+	assert.Equal(t, 16000, info.SampleRate)
+	assert.Equal(t, 2, info.Channels)
+	assert.Equal(t, 1500*time.Millisecond, info.Duration)
+	assert.Equal(t, 2, info.Seconds())
+
+	pcm, err := DecodeWAV(data)
+	require.NoError(t, err)
+	assert.Len(t, pcm.Samples, 24000)
+	assert.InDelta(t, -6.02, pcm.Peak(), 0.05)
+}
+
+func TestProbe_MP3(t *testing.T) {
+	info, err := Probe(mp3Frames(100))
+	require.NoError(t, err)
+	assert.Equal(t, FormatMP3, info.Format)
+	assert.Equal(t, 44100, info.SampleRate)
+	assert.Equal(t, 1, info.Channels)
+	assert.InDelta(t, 100*1152.0/44100, info.Duration.Seconds(), 0.001)
+
+	// 截断的最后一帧不计入
+	data := mp3Frames(10)
+	_, err = Probe(data[:len(data)-100])
+	require.NoError(t, err)
+}
+
+func TestProbe_ADTS(t *testing.T) {
+	const size = 100
+	header := []byte{0xFF, 0xF1, 1<<6 | 8<<2, 1<<6 | size>>11, byte(size >> 3), byte(size&7)<<5 | 0x1F, 0xFC}
+	frame := append(header, make([]byte, size-len(header))...)
+	data := bytes.Repeat(frame, 50)
+
+	info, err := Probe(data)
+	require.NoError(t, err)
+	assert.Equal(t, FormatAAC, info.Format)
+	assert.Equal(t, 16000, info.SampleRate)
+	assert.Equal(t, 1, info.Channels)
+	assert.Equal(t, 3200*time.Millisecond, info.Duration)
+}
+
+func TestProbe_AMR(t *testing.T) {
+	// 微信语音：AMR-NB 12.2kbps，每帧 32 字节、20ms
+	frame := append([]byte{7<<3 | 0x04}, make([]byte, 31)...)
+	data := append([]byte("#!AMR\n"), bytes.Repeat(frame, 150)...)
+
+	info, err := Probe(data)
+	require.NoError(t, err)
+	assert.Equal(t, FormatAMR, info.Format)
+	assert.Equal(t, 8000, info.SampleRate)
+	assert.Equal(t, 3*time.Second, info.Duration)
+	assert.InDelta(t, 12800, info.Bitrate, 100)
+
+	wb := append([]byte("#!AMR-WB\n"), bytes.Repeat(append([]byte{2 << 3}, make([]byte, 32)...), 50)...)
+	info, err = Probe(wb)
+	require.NoError(t, err)
+	assert.Equal(t, FormatAMRWB, info.Format)
+	assert.Equal(t, time.Second, info.Duration)
+}
+
+func TestProbe_Unknown(t *testing.T) {
+	_, err := Probe([]byte("not audio at all"))
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+
+	_, err = Probe([]byte("#!AMR\n"))
+	assert.ErrorIs(t, err, ErrCorrupt)
+}
+
+func TestLoudness(t *testing.T) {
+	// BS.1770：-20 dBFS 的 1kHz 正弦波响度约为 -23 LUFS
+	pcm := sine(48000, 1000, 0.1, 5*time.Second)
+	loudness := pcm.Loudness()
+	assert.InDelta(t, -23.0, loudness, 0.2)
+
+	gain := NormalizeGain(loudness, pcm.Peak(), DefaultTargetLoudness, DefaultPeakCeiling, DefaultMaxGain)
+	assert.InDelta(t, 7.0, gain, 0.2)
+	pcm.ApplyGain(gain)
+	assert.InDelta(t, DefaultTargetLoudness, pcm.Loudness(), 0.2)
+
+	// 峰值限制：响度很低但有尖峰时不能把尖峰推到削波
+	assert.InDelta(t, 5.0, NormalizeGain(-40, -6, -16, -1, 30), 0.001)
+	assert.Equal(t, 20.0, NormalizeGain(-60, -50, -16, -1, 20))
+	assert.Equal(t, 0.0, NormalizeGain(math.Inf(-1), math.Inf(-1), -16, -1, 20))
+
+	silent := &PCM{SampleRate: 16000, Samples: make([]float32, 16000)}
+	assert.True(t, math.IsInf(silent.Loudness(), -1))
+}
+
+func TestNewWaveform(t *testing.T) {
+	pcm := sine(8000, 100, 0.5, time.Second)
+	w := NewWaveform(pcm, 100)
+	assert.Equal(t, 2, w.Version)
+	assert.Equal(t, 80, w.SamplesPerPixel)
+	assert.Equal(t, 100, w.Length)
+	assert.Len(t, w.Data, 200)
+	// 每个点覆盖完整周期，最小/最大值接近 ±0.5
+	assert.InDelta(t, -64, int(w.Data[0]), 1)
+	assert.InDelta(t, 64, int(w.Data[1]), 1)
+
+	short := NewWaveform(&PCM{SampleRate: 8000, Samples: []float32{0.1, -0.2, 0.3}}, 800)
+	assert.Equal(t, 3, short.Length)
+}
+
+// fakeCodec 测试用编解码器，编码输出固定时长的 MP3 帧
+type fakeCodec struct {
+	decoded bool
+	peak    float64
+}
+
+func (c *fakeCodec) Decode(ctx context.Context, data []byte, sampleRate int) (*PCM, error) {
+	c.decoded = true
+	return sine(sampleRate, 440, 0.3, time.Second), nil
+}
+
+func (c *fakeCodec) EncodeMP3(ctx context.Context, pcm *PCM, sampleRate, bitrate int) ([]byte, error) {
+	c.peak = pcm.Peak()
+	return mp3Frames(int(pcm.Duration().Seconds() * 44100 / 1152)), nil
+}
+
+func TestPipeline_Process(t *testing.T) {
+	codec := &fakeCodec{}
+	p := NewPipeline(codec, Options{})
+
+	res, err := p.Process(context.Background(), wav16(sine(16000, 440, 0.05, 2*time.Second), 1))
+	require.NoError(t, err)
+	assert.False(t, codec.decoded, "PCM WAV is decoded natively")
+	assert.Equal(t, FormatWAV, res.Source.Format)
+	assert.Equal(t, StreamFormat, res.Format)
+	assert.Greater(t, res.Gain, 0.0)
+	assert.LessOrEqual(t, codec.peak, DefaultPeakCeiling+0.01)
+	assert.InDelta(t, 2, res.Duration.Seconds(), 0.05)
+	assert.Equal(t, DefaultWaveformPoints, res.Waveform.Length)
+
+	_, err = p.Process(context.Background(), mp3Frames(40))
+	require.NoError(t, err)
+	assert.True(t, codec.decoded)
+
+	short := NewPipeline(codec, Options{MaxDuration: time.Second})
+	_, err = short.Process(context.Background(), mp3Frames(100))
+	assert.ErrorIs(t, err, ErrTooLong)
+
+	_, err = NewPipeline(nil, Options{}).Process(context.Background(), mp3Frames(10))
+	assert.ErrorIs(t, err, ErrCodecUnavailable)
+}
+
+func TestFFmpeg(t *testing.T) {
+	codec, err := NewFFmpeg("")
+	if err != nil {
+		t.Skip("ffmpeg not installed")
+	}
+	res, err := NewPipeline(codec, Options{}).Process(context.Background(), wav16(sine(44100, 440, 0.2, 2*time.Second), 2))
+	require.NoError(t, err)
+	assert.Equal(t, FormatMP3, Detect(res.Audio))
+	assert.InDelta(t, 2, res.Duration.Seconds(), 0.1)
+}
+
+// speech 生成类似语音的信号：每 120ms 切换一组随机频率和音量，offset 为起始时间
+func speech(seed int64, sampleRate int, offset, d time.Duration) *PCM {
+	const segment = 0.12
+	rng := rand.New(rand.NewSource(seed))
+	segments := int((offset+d).Seconds()/segment) + 1
+	freqs := make([][3]float64, segments)
+	amps := make([]float64, segments)
+	for i := range freqs {
+		for k := range freqs[i] {
+			freqs[i][k] = 200 + rng.Float64()*2300
+		}
+		amps[i] = 0.05 + rng.Float64()*0.25
+	}
+
+	n := int(d.Seconds() * float64(sampleRate))
+	pcm := &PCM{SampleRate: sampleRate, Samples: make([]float32, n)}
+	for i := range pcm.Samples {
+		t := offset.Seconds() + float64(i)/float64(sampleRate)
+		seg := int(t / segment)
+		var v float64
+		for _, f := range freqs[seg] {
+			v += math.Sin(2 * math.Pi * f * t)
+		}
+		pcm.Samples[i] = float32(amps[seg] * v / 3)
+	}
+	return pcm
+}
+
+func TestFingerprint(t *testing.T) {
+	original := NewFingerprint(speech(1, 24000, 0, 20*time.Second))
+	require.NotEmpty(t, original)
+
+	// 不同采样率、音量、轻微噪声并剪掉开头 0.5 秒，仍应判定为同一段录音
+	noisy := speech(1, 16000, 500*time.Millisecond, 15*time.Second)
+	rng := rand.New(rand.NewSource(7))
+	for i, s := range noisy.Samples {
+		noisy.Samples[i] = s*0.4 + float32(rng.NormFloat64()*0.003)
+	}
+	assert.GreaterOrEqual(t, Similarity(original, NewFingerprint(noisy)), DefaultDuplicateSimilarity)
+	assert.GreaterOrEqual(t, Similarity(NewFingerprint(noisy), original), DefaultDuplicateSimilarity)
+
+	// 不同录音
+	other := NewFingerprint(speech(2, 24000, 0, 20*time.Second))
+	assert.Less(t, Similarity(original, other), DefaultDuplicateSimilarity)
+
+	parsed, err := ParseFingerprint(original.String())
+	require.NoError(t, err)
+	assert.Equal(t, original, parsed)
+	_, err = ParseFingerprint("abc")
+	assert.ErrorIs(t, err, ErrInvalidFingerprint)
+
+	assert.Nil(t, NewFingerprint(sine(8000, 440, 0.3, 100*time.Millisecond)))
+	assert.Zero(t, Similarity(original, nil))
+}
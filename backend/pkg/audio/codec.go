@@ -0,0 +1,102 @@
+package audio
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// Codec 压缩格式编解码器
+type Codec interface {
+	// Decode 解码任意支持的格式为单声道 PCM，并重采样到 sampleRate
+	Decode(ctx context.Context, data []byte, sampleRate int) (*PCM, error)
+	// EncodeMP3 将 PCM 编码为 MP3（CBR，bitrate 单位 kbps）
+	EncodeMP3(ctx context.Context, pcm *PCM, sampleRate, bitrate int) ([]byte, error)
+}
+
+// FFmpeg 调用本地 ffmpeg 可执行文件编解码（随部署镜像打包，离线可用）
+type FFmpeg struct {
+	path string
+}
+
+// NewFFmpeg 查找 ffmpeg 可执行文件，path 为空时在 PATH 中查找
+func NewFFmpeg(path string) (*FFmpeg, error) {
+	if path == "" {
+		path = "ffmpeg"
+	}
+	resolved, err := exec.LookPath(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCodecUnavailable, err)
+	}
+	return &FFmpeg{path: resolved}, nil
+}
+
+// Decode 解码为 32 位浮点单声道 PCM。输入写入临时文件，以便 MP4 等需要随机读取的格式正确解析
+func (f *FFmpeg) Decode(ctx context.Context, data []byte, sampleRate int) (*PCM, error) {
+	tmp, err := os.CreateTemp("", "audio-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return nil, err
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, err
+	}
+
+	out, err := f.run(ctx, nil,
+		"-i", tmp.Name(),
+		"-vn", "-ac", "1", "-ar", strconv.Itoa(sampleRate),
+		"-f", "f32le", "pipe:1",
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	pcm := &PCM{SampleRate: sampleRate, Samples: make([]float32, len(out)/4)}
+	for i := range pcm.Samples {
+		pcm.Samples[i] = math.Float32frombits(binary.LittleEndian.Uint32(out[i*4:]))
+	}
+	return pcm, nil
+}
+
+// EncodeMP3 编码为不带 ID3 标签的 CBR MP3
+func (f *FFmpeg) EncodeMP3(ctx context.Context, pcm *PCM, sampleRate, bitrate int) ([]byte, error) {
+	in := make([]byte, len(pcm.Samples)*4)
+	for i, s := range pcm.Samples {
+		binary.LittleEndian.PutUint32(in[i*4:], math.Float32bits(s))
+	}
+
+	return f.run(ctx, bytes.NewReader(in),
+		"-f", "f32le", "-ar", strconv.Itoa(pcm.SampleRate), "-ac", "1", "-i", "pipe:0",
+		"-ar", strconv.Itoa(sampleRate), "-c:a", "libmp3lame", "-b:a", strconv.Itoa(bitrate)+"k",
+		"-id3v2_version", "0", "-write_xing", "0",
+		"-f", "mp3", "pipe:1",
+	)
+}
+
+// run 执行 ffmpeg 并返回标准输出
+func (f *FFmpeg) run(ctx context.Context, stdin io.Reader, args ...string) ([]byte, error) {
+	base := []string{"-hide_banner", "-loglevel", "error"}
+	if stdin == nil {
+		base = append(base, "-nostdin")
+	}
+	cmd := exec.CommandContext(ctx, f.path, append(base, args...)...)
+	cmd.Stdin = stdin
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("audio: ffmpeg: %v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
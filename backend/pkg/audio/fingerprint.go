@@ -0,0 +1,230 @@
+package audio
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/bits"
+	"math/cmplx"
+	"sync"
+)
+
+// 声纹指纹参数，参考 Haitsma & Kalker 的子指纹算法：重采样到 8kHz 后逐帧计算
+// 33 个对数分布频带的能量，相邻频带能量差在相邻帧之间的变化方向构成每帧 32 位子指纹。
+// 只取变化方向，与音量无关，对转码、响度归一化和轻微噪声都比较稳定
+const (
+	fingerprintSampleRate = 8000
+	fingerprintFrameSize  = 2048 // 256ms
+	fingerprintHopSize    = 512  // 64ms
+	fingerprintBands      = 33
+	fingerprintMinFreq    = 300.0
+	fingerprintMaxFreq    = 2000.0
+	fingerprintMaxFrames  = 1875 // 只取前 2 分钟，足以判断是否为同一段录音
+	fingerprintMaxShift   = 160  // 比对时允许的最大错位（约 10 秒），覆盖剪掉开头或结尾静音的情况
+	fingerprintMinOverlap = 32   // 比对时至少重叠的帧数（约 2 秒）
+)
+
+// DefaultDuplicateSimilarity 指纹相似度不低于该值视为同一段录音，不相关的录音约为 0.5
+const DefaultDuplicateSimilarity = 0.75
+
+// ErrInvalidFingerprint 无法解析的指纹
+var ErrInvalidFingerprint = errors.New("audio: invalid fingerprint")
+
+// Fingerprint 声纹指纹，每个元素为一帧的 32 位子指纹
+type Fingerprint []uint32
+
+// NewFingerprint 计算声纹指纹，不足两帧（约 0.3 秒）的音频返回 nil
+func NewFingerprint(pcm *PCM) Fingerprint {
+	if pcm == nil || pcm.SampleRate <= 0 {
+		return nil
+	}
+	maxSamples := int(float64((fingerprintMaxFrames-1)*fingerprintHopSize+fingerprintFrameSize) *
+		float64(pcm.SampleRate) / fingerprintSampleRate)
+	samples := pcm.Samples
+	if len(samples) > maxSamples {
+		samples = samples[:maxSamples]
+	}
+	signal := resample(samples, pcm.SampleRate, fingerprintSampleRate)
+	if len(signal) < fingerprintFrameSize+fingerprintHopSize {
+		return nil
+	}
+
+	window, edges := fingerprintTables()
+	buf := make([]complex128, fingerprintFrameSize)
+	var prev, cur [fingerprintBands]float64
+	fp := make(Fingerprint, 0, (len(signal)-fingerprintFrameSize)/fingerprintHopSize)
+	for start := 0; start+fingerprintFrameSize <= len(signal); start += fingerprintHopSize {
+		for i := range buf {
+			buf[i] = complex(signal[start+i]*window[i], 0)
+		}
+		fft(buf)
+		for b := 0; b < fingerprintBands; b++ {
+			var energy float64
+			for k := edges[b]; k < edges[b+1]; k++ {
+				energy += real(buf[k])*real(buf[k]) + imag(buf[k])*imag(buf[k])
+			}
+			cur[b] = energy
+		}
+		if start > 0 {
+			var sub uint32
+			for b := 0; b < fingerprintBands-1; b++ {
+				if (cur[b]-cur[b+1])-(prev[b]-prev[b+1]) > 0 {
+					sub |= 1 << uint(b)
+				}
+			}
+			fp = append(fp, sub)
+		}
+		prev = cur
+	}
+	return fp
+}
+
+// String 编码为 base64（小端序），用于存储
+func (f Fingerprint) String() string {
+	if len(f) == 0 {
+		return ""
+	}
+	buf := make([]byte, 4*len(f))
+	for i, v := range f {
+		binary.LittleEndian.PutUint32(buf[4*i:], v)
+	}
+	return base64.StdEncoding.EncodeToString(buf)
+}
+
+// ParseFingerprint 解析 String 输出的指纹
+func ParseFingerprint(s string) (Fingerprint, error) {
+	buf, err := base64.StdEncoding.DecodeString(s)
+	if err != nil || len(buf) == 0 || len(buf)%4 != 0 {
+		return nil, ErrInvalidFingerprint
+	}
+	f := make(Fingerprint, len(buf)/4)
+	for i := range f {
+		f[i] = binary.LittleEndian.Uint32(buf[4*i:])
+	}
+	return f, nil
+}
+
+// Similarity 两个指纹的相似度（0~1）：在允许的错位范围内取重叠部分相同位数占比的最大值。
+// 同一录音转码、加噪后通常在 0.8 以上
+func Similarity(a, b Fingerprint) float64 {
+	minOverlap := fingerprintMinOverlap
+	if len(a) < minOverlap {
+		minOverlap = len(a)
+	}
+	if len(b) < minOverlap {
+		minOverlap = len(b)
+	}
+	if minOverlap == 0 {
+		return 0
+	}
+
+	best := 0.0
+	for shift := -fingerprintMaxShift; shift <= fingerprintMaxShift; shift++ {
+		// a[i] 与 b[i+shift] 对齐
+		from, to := 0, len(a)
+		if shift < 0 {
+			from = -shift
+		}
+		if len(b)-shift < to {
+			to = len(b) - shift
+		}
+		n := to - from
+		if n < minOverlap {
+			continue
+		}
+		diff := 0
+		for i := from; i < to; i++ {
+			diff += bits.OnesCount32(a[i] ^ b[i+shift])
+		}
+		if sim := 1 - float64(diff)/float64(32*n); sim > best {
+			best = sim
+		}
+	}
+	return best
+}
+
+var (
+	fingerprintOnce   sync.Once
+	fingerprintWindow []float64
+	fingerprintEdges  []int
+)
+
+// fingerprintTables Hann 窗和各频带的 FFT 下标边界
+func fingerprintTables() ([]float64, []int) {
+	fingerprintOnce.Do(func() {
+		fingerprintWindow = make([]float64, fingerprintFrameSize)
+		for i := range fingerprintWindow {
+			fingerprintWindow[i] = 0.5 - 0.5*math.Cos(2*math.Pi*float64(i)/float64(fingerprintFrameSize-1))
+		}
+		fingerprintEdges = make([]int, fingerprintBands+1)
+		ratio := fingerprintMaxFreq / fingerprintMinFreq
+		for b := range fingerprintEdges {
+			freq := fingerprintMinFreq * math.Pow(ratio, float64(b)/fingerprintBands)
+			fingerprintEdges[b] = int(math.Round(freq * fingerprintFrameSize / fingerprintSampleRate))
+		}
+	})
+	return fingerprintWindow, fingerprintEdges
+}
+
+// resample 重采样：降采样取区间平均（兼作低通滤波），升采样线性插值
+func resample(samples []float32, from, to int) []float64 {
+	if from == to {
+		out := make([]float64, len(samples))
+		for i, s := range samples {
+			out[i] = float64(s)
+		}
+		return out
+	}
+
+	ratio := float64(from) / float64(to)
+	out := make([]float64, int(float64(len(samples))/ratio))
+	for i := range out {
+		pos := float64(i) * ratio
+		if ratio < 1 {
+			j := int(pos)
+			a, b := float64(samples[j]), float64(samples[j])
+			if j+1 < len(samples) {
+				b = float64(samples[j+1])
+			}
+			out[i] = a + (b-a)*(pos-float64(j))
+			continue
+		}
+		start, end := int(pos), int(pos+ratio)
+		if end > len(samples) {
+			end = len(samples)
+		}
+		var sum float64
+		for _, s := range samples[start:end] {
+			sum += float64(s)
+		}
+		out[i] = sum / float64(end-start)
+	}
+	return out
+}
+
+// fft 原地计算基 2 快速傅里叶变换，长度须为 2 的幂
+func fft(x []complex128) {
+	n := len(x)
+	for i, j := 1, 0; i < n; i++ {
+		bit := n >> 1
+		for ; j&bit != 0; bit >>= 1 {
+			j ^= bit
+		}
+		j ^= bit
+		if i < j {
+			x[i], x[j] = x[j], x[i]
+		}
+	}
+	for size := 2; size <= n; size <<= 1 {
+		step := cmplx.Exp(complex(0, -2*math.Pi/float64(size)))
+		for start := 0; start < n; start += size {
+			w := complex(1, 0)
+			for k := 0; k < size/2; k++ {
+				u, v := x[start+k], x[start+k+size/2]*w
+				x[start+k], x[start+k+size/2] = u+v, u-v
+				w *= step
+			}
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package audio
+
+import (
+	"math"
+	"time"
+)
+
+// PCM 单声道浮点采样，取值 [-1, 1]
+type PCM struct {
+	SampleRate int
+	Samples    []float32
+}
+
+// Duration 时长
+func (p *PCM) Duration() time.Duration {
+	return samplesDuration(int64(len(p.Samples)), p.SampleRate)
+}
+
+// Peak 采样峰值（dBFS），静音返回负无穷
+func (p *PCM) Peak() float64 {
+	var peak float64
+	for _, s := range p.Samples {
+		if v := math.Abs(float64(s)); v > peak {
+			peak = v
+		}
+	}
+	return toDB(peak)
+}
+
+// ApplyGain 施加增益（dB），超出范围的采样截断
+func (p *PCM) ApplyGain(db float64) {
+	if db == 0 {
+		return
+	}
+	g := float32(math.Pow(10, db/20))
+	for i, s := range p.Samples {
+		s *= g
+		if s > 1 {
+			s = 1
+		} else if s < -1 {
+			s = -1
+		}
+		p.Samples[i] = s
+	}
+}
+
+// biquad 二阶 IIR 滤波器
+type biquad struct {
+	b0, b1, b2, a1, a2 float64
+	z1, z2             float64
+}
+
+func (f *biquad) process(x float64) float64 {
+	y := f.b0*x + f.z1
+	f.z1 = f.b1*x - f.a1*y + f.z2
+	f.z2 = f.b2*x - f.a2*y
+	return y
+}
+
+// kWeighting ITU-R BS.1770 K 加权滤波器（高架 + RLB 高通），按采样率计算系数
+func kWeighting(sampleRate int) [2]*biquad {
+	fs := float64(sampleRate)
+
+	f0, gain, q := 1681.974450955533, 3.999843853973347, 0.7071752369554196
+	k := math.Tan(math.Pi * f0 / fs)
+	vh := math.Pow(10, gain/20)
+	vb := math.Pow(vh, 0.4996667741545416)
+	a0 := 1 + k/q + k*k
+	shelf := &biquad{
+		b0: (vh + vb*k/q + k*k) / a0,
+		b1: 2 * (k*k - vh) / a0,
+		b2: (vh - vb*k/q + k*k) / a0,
+		a1: 2 * (k*k - 1) / a0,
+		a2: (1 - k/q + k*k) / a0,
+	}
+
+	f0, q = 38.13547087602444, 0.5003270373238773
+	k = math.Tan(math.Pi * f0 / fs)
+	a0 = 1 + k/q + k*k
+	highpass := &biquad{
+		b0: 1, b1: -2, b2: 1,
+		a1: 2 * (k*k - 1) / a0,
+		a2: (1 - k/q + k*k) / a0,
+	}
+	return [2]*biquad{shelf, highpass}
+}
+
+// Loudness 按 ITU-R BS.1770 / EBU R128 计算积分响度（LUFS）：K 加权后以 400ms 块、
+// 75% 重叠计算块响度，经 -70 LUFS 绝对门限和 -10 LU 相对门限后取平均。静音返回负无穷
+func (p *PCM) Loudness() float64 {
+	if p.SampleRate <= 0 || len(p.Samples) == 0 {
+		return math.Inf(-1)
+	}
+	filters := kWeighting(p.SampleRate)
+	squared := make([]float64, len(p.Samples))
+	for i, s := range p.Samples {
+		y := filters[1].process(filters[0].process(float64(s)))
+		squared[i] = y * y
+	}
+
+	block := p.SampleRate * 4 / 10
+	step := block / 4
+	if len(squared) < block {
+		// 不足一个门限块的短片段直接取整体均方
+		return blockLoudness(mean(squared))
+	}
+
+	prefix := make([]float64, len(squared)+1)
+	for i, v := range squared {
+		prefix[i+1] = prefix[i] + v
+	}
+	var powers []float64
+	for start := 0; start+block <= len(squared); start += step {
+		powers = append(powers, (prefix[start+block]-prefix[start])/float64(block))
+	}
+
+	gated := gate(powers, -70)
+	if len(gated) == 0 {
+		return math.Inf(-1)
+	}
+	gated = gate(gated, blockLoudness(mean(gated))-10)
+	if len(gated) == 0 {
+		return math.Inf(-1)
+	}
+	return blockLoudness(mean(gated))
+}
+
+// gate 保留响度高于门限的块
+func gate(powers []float64, threshold float64) []float64 {
+	var kept []float64
+	for _, p := range powers {
+		if blockLoudness(p) > threshold {
+			kept = append(kept, p)
+		}
+	}
+	return kept
+}
+
+func blockLoudness(power float64) float64 {
+	if power <= 0 {
+		return math.Inf(-1)
+	}
+	return -0.691 + 10*math.Log10(power)
+}
+
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+func toDB(amplitude float64) float64 {
+	if amplitude <= 0 {
+		return math.Inf(-1)
+	}
+	return 20 * math.Log10(amplitude)
+}
+
+// NormalizeGain 计算将响度调整到目标值所需的增益（dB），受峰值上限和最大增益约束；
+// 静音或无法测量时返回 0
+func NormalizeGain(loudness, peak, target, ceiling, maxGain float64) float64 {
+	if math.IsInf(loudness, 0) || math.IsNaN(loudness) {
+		return 0
+	}
+	gain := target - loudness
+	if !math.IsInf(peak, 0) && peak+gain > ceiling {
+		gain = ceiling - peak
+	}
+	if maxGain > 0 && gain > maxGain {
+		gain = maxGain
+	}
+	return math.Round(gain*100) / 100
+}
@@ -0,0 +1,101 @@
+package audio
+
+import (
+	"encoding/binary"
+	"time"
+)
+
+// mp4Box 在 data 中查找指定类型的子 box，返回其内容
+func mp4Box(data []byte, typ string) []byte {
+	for offset := 0; offset+8 <= len(data); {
+		size := int(be32(data[offset:]))
+		header := 8
+		switch size {
+		case 0:
+			size = len(data) - offset
+		case 1:
+			if offset+16 > len(data) {
+				return nil
+			}
+			size = int(binary.BigEndian.Uint64(data[offset+8:]))
+			header = 16
+		}
+		if size < header || offset+size > len(data) {
+			return nil
+		}
+		if string(data[offset+4:offset+8]) == typ {
+			return data[offset+header : offset+size]
+		}
+		offset += size
+	}
+	return nil
+}
+
+// mp4Path 按路径逐层查找 box
+func mp4Path(data []byte, path ...string) []byte {
+	for _, typ := range path {
+		if data = mp4Box(data, typ); data == nil {
+			return nil
+		}
+	}
+	return data
+}
+
+// probeMP4 从第一个音轨的 mdhd 读取时长，从 mp4a 样本描述读取声道和采样率
+func probeMP4(data []byte) (*Info, error) {
+	moov := mp4Box(data, "moov")
+	if moov == nil {
+		return nil, ErrCorrupt
+	}
+
+	info := &Info{Format: FormatM4A}
+	for offset := 0; offset+8 <= len(moov); {
+		size := int(be32(moov[offset:]))
+		if size < 8 || offset+size > len(moov) {
+			break
+		}
+		if string(moov[offset+4:offset+8]) == "trak" {
+			trak := moov[offset+8 : offset+size]
+			if hdlr := mp4Path(trak, "mdia", "hdlr"); len(hdlr) >= 12 && string(hdlr[8:12]) == "soun" {
+				if mdhd := mp4Path(trak, "mdia", "mdhd"); mdhd != nil {
+					info.Duration = mp4Duration(mdhd)
+				}
+				if stsd := mp4Path(trak, "mdia", "minf", "stbl", "stsd"); len(stsd) >= 8 {
+					if entry := stsd[8:]; len(entry) >= 36 {
+						info.Channels = int(binary.BigEndian.Uint16(entry[24:26]))
+						info.SampleRate = int(be32(entry[32:36]) >> 16)
+					}
+				}
+				break
+			}
+		}
+		offset += size
+	}
+
+	if info.Duration == 0 {
+		if mvhd := mp4Box(moov, "mvhd"); mvhd != nil {
+			info.Duration = mp4Duration(mvhd)
+		}
+	}
+	if info.Duration == 0 {
+		return nil, ErrCorrupt
+	}
+	return info, nil
+}
+
+// mp4Duration 解析 mvhd/mdhd 中的时间刻度和时长
+func mp4Duration(b []byte) time.Duration {
+	var timescale, duration uint64
+	switch {
+	case len(b) >= 32 && b[0] == 1:
+		timescale = uint64(be32(b[20:24]))
+		duration = binary.BigEndian.Uint64(b[24:32])
+	case len(b) >= 20:
+		timescale = uint64(be32(b[12:16]))
+		duration = uint64(be32(b[16:20]))
+	}
+	if timescale == 0 {
+		return 0
+	}
+	return samplesDuration(int64(duration), int(timescale))
+}
@@ -0,0 +1,195 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// MPEG 音频版本
+const (
+	mpeg25 = 0
+	mpeg2  = 2
+	mpeg1  = 3
+)
+
+// mpegBitrates 码率表（kbps），按 [MPEG1/MPEG2(.5)][layer 1-3][索引]
+var mpegBitrates = [2][3][15]int{
+	{
+		{0, 32, 64, 96, 128, 160, 192, 224, 256, 288, 320, 352, 384, 416, 448},
+		{0, 32, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 384},
+		{0, 32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320},
+	},
+	{
+		{0, 32, 48, 56, 64, 80, 96, 112, 128, 144, 160, 176, 192, 224, 256},
+		{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160},
+		{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160},
+	},
+}
+
+var mpegSampleRates = map[int][3]int{
+	mpeg1:  {44100, 48000, 32000},
+	mpeg2:  {22050, 24000, 16000},
+	mpeg25: {11025, 12000, 8000},
+}
+
+// mpegHeader MPEG 音频帧头
+type mpegHeader struct {
+	version    int
+	layer      int // 1-3
+	sampleRate int
+	channels   int
+	samples    int // 每帧采样数
+	size       int // 帧长（含帧头）
+}
+
+// parseMPEGHeader 解析 MPEG 音频帧头，不支持自由码率
+func parseMPEGHeader(b []byte) (mpegHeader, bool) {
+	var h mpegHeader
+	if len(b) < 4 || b[0] != 0xFF || b[1]&0xE0 != 0xE0 {
+		return h, false
+	}
+	h.version = int(b[1]>>3) & 3
+	layerBits := int(b[1]>>1) & 3
+	brIndex := int(b[2] >> 4)
+	srIndex := int(b[2]>>2) & 3
+	padding := int(b[2]>>1) & 1
+	if h.version == 1 || layerBits == 0 || brIndex == 0 || brIndex == 15 || srIndex == 3 {
+		return h, false
+	}
+	h.layer = 4 - layerBits
+	h.sampleRate = mpegSampleRates[h.version][srIndex]
+	h.channels = 2
+	if b[3]>>6 == 3 {
+		h.channels = 1
+	}
+
+	table := 0
+	if h.version != mpeg1 {
+		table = 1
+	}
+	bitrate := mpegBitrates[table][h.layer-1][brIndex] * 1000
+
+	switch {
+	case h.layer == 1:
+		h.samples = 384
+		h.size = (12*bitrate/h.sampleRate + padding) * 4
+	case h.layer == 3 && h.version != mpeg1:
+		h.samples = 576
+		h.size = 72*bitrate/h.sampleRate + padding
+	default:
+		h.samples = 1152
+		h.size = 144*bitrate/h.sampleRate + padding
+	}
+	return h, h.size > 4
+}
+
+// skipID3v2 返回 ID3v2 标签之后的偏移
+func skipID3v2(data []byte) int {
+	offset := 0
+	for len(data)-offset >= 10 && string(data[offset:offset+3]) == "ID3" {
+		b := data[offset:]
+		size := int(b[6]&0x7F)<<21 | int(b[7]&0x7F)<<14 | int(b[8]&0x7F)<<7 | int(b[9]&0x7F)
+		offset += 10 + size
+		if b[5]&0x10 != 0 {
+			offset += 10
+		}
+	}
+	if offset > len(data) {
+		return len(data)
+	}
+	return offset
+}
+
+// isXingFrame 是否为 LAME/Xing 或 VBRI 信息帧（不含音频数据）
+func isXingFrame(frame []byte, h mpegHeader) bool {
+	side := 32
+	switch {
+	case h.version == mpeg1 && h.channels == 1:
+		side = 17
+	case h.version != mpeg1 && h.channels == 1:
+		side = 9
+	case h.version != mpeg1:
+		side = 17
+	}
+	if off := 4 + side; len(frame) >= off+4 {
+		tag := string(frame[off : off+4])
+		if tag == "Xing" || tag == "Info" {
+			return true
+		}
+	}
+	return len(frame) >= 40 && string(frame[36:40]) == "VBRI"
+}
+
+// probeMPEG 逐帧统计 MP3 时长，兼容 VBR 且不依赖 Xing 头中可能错误的帧数
+func probeMPEG(data []byte) (*Info, error) {
+	offset := skipID3v2(data)
+	end := len(data)
+	if end-offset >= 128 && bytes.Equal(data[end-128:end-125], []byte("TAG")) {
+		end -= 128
+	}
+
+	var (
+		first   *mpegHeader
+		samples int64
+		frames  int
+	)
+	for offset+4 <= end {
+		h, ok := parseMPEGHeader(data[offset:end])
+		if !ok || (first != nil && (h.sampleRate != first.sampleRate || h.layer != first.layer)) {
+			if frames == 0 {
+				// 文件开头可能有垃圾数据，需要连续两帧才算找到同步
+				offset = resyncMPEG(data[:end], offset+1)
+				if offset < 0 {
+					break
+				}
+				continue
+			}
+			break
+		}
+		if offset+h.size > end {
+			break
+		}
+		if first == nil {
+			if next := offset + h.size; next+4 <= end {
+				if _, ok := parseMPEGHeader(data[next:end]); !ok {
+					offset++
+					continue
+				}
+			}
+			hh := h
+			first = &hh
+			if isXingFrame(data[offset:offset+h.size], h) {
+				offset += h.size
+				continue
+			}
+		}
+		samples += int64(h.samples)
+		frames++
+		offset += h.size
+	}
+
+	if first == nil || frames == 0 {
+		return nil, ErrCorrupt
+	}
+	return &Info{
+		Format:     FormatMP3,
+		Duration:   samplesDuration(samples, first.sampleRate),
+		SampleRate: first.sampleRate,
+		Channels:   first.channels,
+	}, nil
+}
+
+// resyncMPEG 查找下一个可能的帧同步位置，找不到返回 -1
+func resyncMPEG(data []byte, from int) int {
+	for i := from; i+4 <= len(data); i++ {
+		if data[i] == 0xFF && data[i+1]&0xE0 == 0xE0 {
+			return i
+		}
+	}
+	return -1
+}
+
+// be32 读取大端 uint32
+func be32(b []byte) uint32 {
+	return binary.BigEndian.Uint32(b)
+}
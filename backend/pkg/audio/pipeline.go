@@ -0,0 +1,120 @@
+package audio
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// 默认处理参数
+const (
+	DefaultTargetLoudness = -16.0 // 移动端语音常用响度（LUFS）
+	DefaultPeakCeiling    = -1.0  // 归一化后峰值上限（dBFS）
+	DefaultMaxGain        = 20.0  // 最大提升增益（dB），避免把底噪放大
+	DefaultSampleRate     = 24000
+	DefaultBitrate        = 64 // kbps，单声道语音足够清晰
+	DefaultMaxDuration    = 10 * time.Minute
+)
+
+// Options 音频处理参数
+type Options struct {
+	TargetLoudness float64
+	PeakCeiling    float64
+	MaxGain        float64
+	SampleRate     int // 输出采样率
+	Bitrate        int // 输出码率（kbps）
+	WaveformPoints int
+	MaxDuration    time.Duration // 超过该时长不解码，防止占用过多内存
+}
+
+// Result 音频处理结果
+type Result struct {
+	Source *Info // 原始文件探测结果
+
+	Format   Format // 输出格式，固定为 StreamFormat
+	Audio    []byte
+	Duration time.Duration // 输出音频时长
+
+	Loudness float64 // 原始积分响度（LUFS），静音为负无穷
+	Gain     float64 // 归一化施加的增益（dB）
+	Waveform *Waveform
+
+	Fingerprint Fingerprint // 声纹指纹，用于发现重复上传的录音
+}
+
+// Pipeline 音频处理流水线：探测 → 解码 → 声纹指纹 → 响度归一化 → 波形 → 转码
+type Pipeline struct {
+	opts  Options
+	codec Codec
+}
+
+// NewPipeline 创建音频处理流水线，未设置的参数使用默认值
+// codec 为空时只能探测，Process 返回 ErrCodecUnavailable
+func NewPipeline(codec Codec, opts Options) *Pipeline {
+	if opts.TargetLoudness == 0 {
+		opts.TargetLoudness = DefaultTargetLoudness
+	}
+	if opts.PeakCeiling == 0 {
+		opts.PeakCeiling = DefaultPeakCeiling
+	}
+	if opts.MaxGain <= 0 {
+		opts.MaxGain = DefaultMaxGain
+	}
+	if opts.SampleRate <= 0 {
+		opts.SampleRate = DefaultSampleRate
+	}
+	if opts.Bitrate <= 0 {
+		opts.Bitrate = DefaultBitrate
+	}
+	if opts.WaveformPoints <= 0 {
+		opts.WaveformPoints = DefaultWaveformPoints
+	}
+	if opts.MaxDuration <= 0 {
+		opts.MaxDuration = DefaultMaxDuration
+	}
+	return &Pipeline{opts: opts, codec: codec}
+}
+
+// Process 探测真实格式和时长，响度归一化后转码为 MP3 并生成波形
+// PCM 编码的 WAV 直接在 Go 中解码，其它格式由编解码器解码
+func (p *Pipeline) Process(ctx context.Context, data []byte) (*Result, error) {
+	info, err := Probe(data)
+	if err != nil {
+		return nil, err
+	}
+	if info.Duration > p.opts.MaxDuration {
+		return nil, ErrTooLong
+	}
+	if p.codec == nil {
+		return nil, ErrCodecUnavailable
+	}
+
+	var pcm *PCM
+	if info.Format == FormatWAV {
+		pcm, err = DecodeWAV(data)
+	}
+	if pcm == nil {
+		pcm, err = p.codec.Decode(ctx, data, p.opts.SampleRate)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(pcm.Samples) == 0 {
+		return nil, ErrCorrupt
+	}
+
+	res := &Result{Source: info, Format: StreamFormat, Loudness: pcm.Loudness(), Fingerprint: NewFingerprint(pcm)}
+	res.Gain = NormalizeGain(res.Loudness, pcm.Peak(), p.opts.TargetLoudness, p.opts.PeakCeiling, p.opts.MaxGain)
+	pcm.ApplyGain(res.Gain)
+	res.Waveform = NewWaveform(pcm, p.opts.WaveformPoints)
+
+	if res.Audio, err = p.codec.EncodeMP3(ctx, pcm, p.opts.SampleRate, p.opts.Bitrate); err != nil {
+		return nil, err
+	}
+	out, err := Probe(res.Audio)
+	if err != nil || out.Format != StreamFormat {
+		return nil, fmt.Errorf("audio: invalid encoder output: %v", err)
+	}
+	res.Duration = out.Duration
+	return res, nil
+}
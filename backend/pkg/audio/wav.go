@@ -0,0 +1,134 @@
+package audio
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// WAV 编码类型
+const (
+	wavFormatPCM        = 0x0001
+	wavFormatFloat      = 0x0003
+	wavFormatExtensible = 0xFFFE
+)
+
+// wavFormat WAV fmt 块
+type wavFormat struct {
+	encoding      int
+	channels      int
+	sampleRate    int
+	byteRate      int
+	blockAlign    int
+	bitsPerSample int
+}
+
+// parseWAV 解析 fmt 块及数据区，data 块长度缺失（流式写入）时取到文件末尾
+func parseWAV(data []byte) (*wavFormat, []byte, error) {
+	var (
+		format  *wavFormat
+		samples []byte
+	)
+	offset := 12
+	for offset+8 <= len(data) {
+		id := string(data[offset : offset+4])
+		size := int(binary.LittleEndian.Uint32(data[offset+4 : offset+8]))
+		body := offset + 8
+		if size < 0 || body+size > len(data) {
+			size = len(data) - body
+		}
+
+		switch id {
+		case "fmt ":
+			if size < 16 {
+				return nil, nil, ErrCorrupt
+			}
+			b := data[body:]
+			format = &wavFormat{
+				encoding:      int(binary.LittleEndian.Uint16(b[0:2])),
+				channels:      int(binary.LittleEndian.Uint16(b[2:4])),
+				sampleRate:    int(binary.LittleEndian.Uint32(b[4:8])),
+				byteRate:      int(binary.LittleEndian.Uint32(b[8:12])),
+				blockAlign:    int(binary.LittleEndian.Uint16(b[12:14])),
+				bitsPerSample: int(binary.LittleEndian.Uint16(b[14:16])),
+			}
+			if format.encoding == wavFormatExtensible && size >= 26 {
+				format.encoding = int(binary.LittleEndian.Uint16(b[24:26]))
+			}
+		case "data":
+			samples = data[body : body+size]
+		}
+		if samples != nil && format != nil {
+			break
+		}
+		offset = body + size + size&1
+	}
+
+	if format == nil || samples == nil || format.channels <= 0 || format.sampleRate <= 0 {
+		return nil, nil, ErrCorrupt
+	}
+	return format, samples, nil
+}
+
+// probeWAV 由数据区长度计算时长，压缩编码（如 ADPCM）按平均字节率计算
+func probeWAV(data []byte) (*Info, error) {
+	format, samples, err := parseWAV(data)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &Info{
+		Format:     FormatWAV,
+		SampleRate: format.sampleRate,
+		Channels:   format.channels,
+		Bitrate:    format.byteRate * 8,
+	}
+	if format.blockAlign > 0 && (format.encoding == wavFormatPCM || format.encoding == wavFormatFloat) {
+		info.Duration = samplesDuration(int64(len(samples)/format.blockAlign), format.sampleRate)
+	} else if format.byteRate > 0 {
+		info.Duration = samplesDuration(int64(len(samples))*int64(format.sampleRate)/int64(format.byteRate), format.sampleRate)
+	}
+	return info, nil
+}
+
+// DecodeWAV 解码 PCM（8/16/24/32 位整数及 32 位浮点）WAV 并混合为单声道
+func DecodeWAV(data []byte) (*PCM, error) {
+	format, raw, err := parseWAV(data)
+	if err != nil {
+		return nil, err
+	}
+
+	bytesPerSample := format.bitsPerSample / 8
+	var sample func(b []byte) float32
+	switch {
+	case format.encoding == wavFormatPCM && bytesPerSample == 1:
+		sample = func(b []byte) float32 { return (float32(b[0]) - 128) / 128 }
+	case format.encoding == wavFormatPCM && bytesPerSample == 2:
+		sample = func(b []byte) float32 { return float32(int16(binary.LittleEndian.Uint16(b))) / 32768 }
+	case format.encoding == wavFormatPCM && bytesPerSample == 3:
+		sample = func(b []byte) float32 {
+			v := int32(uint32(b[0])<<8|uint32(b[1])<<16|uint32(b[2])<<24) >> 8
+			return float32(v) / 8388608
+		}
+	case format.encoding == wavFormatPCM && bytesPerSample == 4:
+		sample = func(b []byte) float32 { return float32(int32(binary.LittleEndian.Uint32(b))) / 2147483648 }
+	case format.encoding == wavFormatFloat && bytesPerSample == 4:
+		sample = func(b []byte) float32 { return math.Float32frombits(binary.LittleEndian.Uint32(b)) }
+	default:
+		return nil, ErrUnsupported
+	}
+
+	frameSize := bytesPerSample * format.channels
+	if format.blockAlign > frameSize {
+		frameSize = format.blockAlign
+	}
+	pcm := &PCM{SampleRate: format.sampleRate, Samples: make([]float32, len(raw)/frameSize)}
+	for i := range pcm.Samples {
+		frame := raw[i*frameSize:]
+		var sum float32
+		for ch := 0; ch < format.channels; ch++ {
+			sum += sample(frame[ch*bytesPerSample:])
+		}
+		pcm.Samples[i] = sum / float32(format.channels)
+	}
+	return pcm, nil
+}
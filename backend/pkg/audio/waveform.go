@@ -0,0 +1,66 @@
+package audio
+
+import "math"
+
+// DefaultWaveformPoints 默认波形点数
+const DefaultWaveformPoints = 800
+
+// Waveform 播放器波形数据，兼容 audiowaveform JSON 格式（version 2），
+// Data 依次为每个点的最小值、最大值，8 位有符号
+type Waveform struct {
+	Version         int    `json:"version"`
+	Channels        int    `json:"channels"`
+	SampleRate      int    `json:"sample_rate"`
+	SamplesPerPixel int    `json:"samples_per_pixel"`
+	Bits            int    `json:"bits"`
+	Length          int    `json:"length"`
+	Data            []int8 `json:"data"`
+}
+
+// NewWaveform 按指定点数生成波形，短音频点数不超过采样数
+func NewWaveform(pcm *PCM, points int) *Waveform {
+	if points <= 0 {
+		points = DefaultWaveformPoints
+	}
+	spp := int(math.Ceil(float64(len(pcm.Samples)) / float64(points)))
+	if spp < 1 {
+		spp = 1
+	}
+
+	w := &Waveform{
+		Version:         2,
+		Channels:        1,
+		SampleRate:      pcm.SampleRate,
+		SamplesPerPixel: spp,
+		Bits:            8,
+	}
+	for start := 0; start < len(pcm.Samples); start += spp {
+		end := start + spp
+		if end > len(pcm.Samples) {
+			end = len(pcm.Samples)
+		}
+		lo, hi := pcm.Samples[start], pcm.Samples[start]
+		for _, s := range pcm.Samples[start+1 : end] {
+			if s < lo {
+				lo = s
+			}
+			if s > hi {
+				hi = s
+			}
+		}
+		w.Data = append(w.Data, quantize(lo), quantize(hi))
+		w.Length++
+	}
+	return w
+}
+
+// quantize 将 [-1, 1] 映射为 int8
+func quantize(s float32) int8 {
+	v := math.Round(float64(s) * 127)
+	if v > 127 {
+		v = 127
+	} else if v < -128 {
+		v = -128
+	}
+	return int8(v)
+}
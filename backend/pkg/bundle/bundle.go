@@ -0,0 +1,369 @@
+// Package bundle 生成和校验带签名清单的 ZIP 包
+// 包内每个文件的 SHA-256 记录在 manifest.json 中，manifest.json 使用 Ed25519 签名（manifest.sig），
+// 接收方持有公钥即可离线校验包内容未被篡改
+package bundle
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// 清单和签名文件名
+const (
+	ManifestName  = "manifest.json"
+	SignatureName = "manifest.sig"
+)
+
+// ManifestVersion 清单格式版本
+const ManifestVersion = 1
+
+var (
+	ErrInvalidBundle = errors.New("bundle: invalid bundle")
+	ErrBadSignature  = errors.New("bundle: signature mismatch")
+	ErrTampered      = errors.New("bundle: content does not match manifest")
+	ErrInvalidKey    = errors.New("bundle: invalid signing key")
+	ErrDuplicatePath = errors.New("bundle: duplicate path")
+)
+
+// maxManifestSize 校验时清单大小上限
+const maxManifestSize = 8 << 20
+
+// Entry 包内文件
+type Entry struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// Manifest 清单
+type Manifest struct {
+	Version   int             `json:"version"`
+	CreatedAt time.Time       `json:"created_at"`
+	KeyID     string          `json:"key_id"`
+	PublicKey string          `json:"public_key"` // Base64，仅供参考，校验应使用事先取得的公钥
+	Meta      json.RawMessage `json:"meta,omitempty"`
+	Files     []Entry         `json:"files"`
+}
+
+// Signer Ed25519 签名密钥
+type Signer struct {
+	key ed25519.PrivateKey
+}
+
+// NewSigner 由 32 字节种子创建签名密钥
+func NewSigner(seed []byte) (*Signer, error) {
+	if len(seed) != ed25519.SeedSize {
+		return nil, ErrInvalidKey
+	}
+	return &Signer{key: ed25519.NewKeyFromSeed(seed)}, nil
+}
+
+// GenerateSigner 生成随机签名密钥
+func GenerateSigner() (*Signer, []byte, error) {
+	seed := make([]byte, ed25519.SeedSize)
+	if _, err := rand.Read(seed); err != nil {
+		return nil, nil, err
+	}
+	signer, err := NewSigner(seed)
+	return signer, seed, err
+}
+
+// LoadOrCreateSigner 从密钥文件加载签名密钥（32 字节种子的 Base64），
+// 文件不存在时生成随机密钥并写入，文件仅所有者可读写
+func LoadOrCreateSigner(file string) (*Signer, error) {
+	data, err := os.ReadFile(file)
+	if err == nil {
+		seed, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidKey, err.Error())
+		}
+		return NewSigner(seed)
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	signer, seed, err := GenerateSigner()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0o700); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		if errors.Is(err, os.ErrExist) {
+			// 其它实例刚刚生成了密钥
+			return LoadOrCreateSigner(file)
+		}
+		return nil, err
+	}
+	if _, err := f.WriteString(base64.StdEncoding.EncodeToString(seed) + "\n"); err != nil {
+		f.Close()
+		os.Remove(file)
+		return nil, err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(file)
+		return nil, err
+	}
+	return signer, nil
+}
+
+// PublicKey 公钥
+func (s *Signer) PublicKey() ed25519.PublicKey {
+	return s.key.Public().(ed25519.PublicKey)
+}
+
+// KeyID 公钥指纹
+func (s *Signer) KeyID() string {
+	return KeyID(s.PublicKey())
+}
+
+// KeyID 公钥指纹：SHA-256 前 8 字节的十六进制
+func KeyID(pub ed25519.PublicKey) string {
+	sum := sha256.Sum256(pub)
+	return hex.EncodeToString(sum[:8])
+}
+
+// Writer 签名 ZIP 包写入器，Close 时写入清单和签名
+type Writer struct {
+	zw      *zip.Writer
+	signer  *Signer
+	entries []Entry
+	paths   map[string]bool
+	current *entryWriter
+	now     func() time.Time
+}
+
+// NewWriter 创建写入器
+func NewWriter(w io.Writer, signer *Signer) *Writer {
+	return &Writer{
+		zw:     zip.NewWriter(w),
+		signer: signer,
+		paths:  make(map[string]bool),
+		now:    time.Now,
+	}
+}
+
+// Create 添加文件，返回的 Writer 在下一次 Create、Add 或 Close 前有效
+func (w *Writer) Create(name string) (io.Writer, error) {
+	w.flush()
+
+	name, err := cleanPath(name)
+	if err != nil {
+		return nil, err
+	}
+	if w.paths[name] {
+		return nil, fmt.Errorf("%w: %s", ErrDuplicatePath, name)
+	}
+	w.paths[name] = true
+
+	fw, err := w.zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: w.now()})
+	if err != nil {
+		return nil, err
+	}
+	w.current = &entryWriter{w: fw, hash: sha256.New(), path: name}
+	return w.current, nil
+}
+
+// Add 添加文件
+func (w *Writer) Add(name string, data []byte) error {
+	fw, err := w.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = fw.Write(data)
+	return err
+}
+
+// AddJSON 以缩进 JSON 添加文件
+func (w *Writer) AddJSON(name string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return w.Add(name, data)
+}
+
+// Close 写入清单和签名并结束 ZIP，meta 为调用方的附加信息
+func (w *Writer) Close(meta any) (*Manifest, error) {
+	w.flush()
+
+	manifest := &Manifest{
+		Version:   ManifestVersion,
+		CreatedAt: w.now().UTC().Truncate(time.Second),
+		KeyID:     w.signer.KeyID(),
+		PublicKey: base64.StdEncoding.EncodeToString(w.signer.PublicKey()),
+		Files:     w.entries,
+	}
+	if meta != nil {
+		raw, err := json.Marshal(meta)
+		if err != nil {
+			return nil, err
+		}
+		manifest.Meta = raw
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := w.writeRaw(ManifestName, data); err != nil {
+		return nil, err
+	}
+	sig := base64.StdEncoding.EncodeToString(ed25519.Sign(w.signer.key, data)) + "\n"
+	if err := w.writeRaw(SignatureName, []byte(sig)); err != nil {
+		return nil, err
+	}
+	return manifest, w.zw.Close()
+}
+
+func (w *Writer) writeRaw(name string, data []byte) error {
+	fw, err := w.zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: w.now()})
+	if err != nil {
+		return err
+	}
+	_, err = fw.Write(data)
+	return err
+}
+
+// flush 记录当前文件的大小和摘要
+func (w *Writer) flush() {
+	if w.current == nil {
+		return
+	}
+	w.entries = append(w.entries, Entry{
+		Path:   w.current.path,
+		Size:   w.current.size,
+		SHA256: hex.EncodeToString(w.current.hash.Sum(nil)),
+	})
+	w.current = nil
+}
+
+// entryWriter 写入时同时计算摘要
+type entryWriter struct {
+	w    io.Writer
+	hash hash.Hash
+	path string
+	size int64
+}
+
+func (e *entryWriter) Write(p []byte) (int, error) {
+	n, err := e.w.Write(p)
+	e.hash.Write(p[:n])
+	e.size += int64(n)
+	return n, err
+}
+
+// Verify 校验签名和包内每个文件的摘要；包内不允许出现清单以外的文件
+func Verify(data []byte, pub ed25519.PublicKey) (*Manifest, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, ErrInvalidBundle
+	}
+
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		if _, ok := files[f.Name]; ok {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicatePath, f.Name)
+		}
+		files[f.Name] = f
+	}
+
+	manifestData, err := readFile(files[ManifestName], maxManifestSize)
+	if err != nil {
+		return nil, fmt.Errorf("%w: missing manifest", ErrInvalidBundle)
+	}
+	sigData, err := readFile(files[SignatureName], 1024)
+	if err != nil {
+		return nil, fmt.Errorf("%w: missing signature", ErrInvalidBundle)
+	}
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(sigData)))
+	if err != nil || !ed25519.Verify(pub, manifestData, sig) {
+		return nil, ErrBadSignature
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(manifestData, &manifest); err != nil {
+		return nil, fmt.Errorf("%w: malformed manifest", ErrInvalidBundle)
+	}
+
+	listed := map[string]bool{ManifestName: true, SignatureName: true}
+	for _, e := range manifest.Files {
+		listed[e.Path] = true
+		f := files[e.Path]
+		if f == nil {
+			return nil, fmt.Errorf("%w: %s is missing", ErrTampered, e.Path)
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidBundle, e.Path)
+		}
+		h := sha256.New()
+		n, err := io.Copy(h, io.LimitReader(rc, e.Size+1))
+		rc.Close()
+		if err != nil || n != e.Size || hex.EncodeToString(h.Sum(nil)) != e.SHA256 {
+			return nil, fmt.Errorf("%w: %s", ErrTampered, e.Path)
+		}
+	}
+
+	var extra []string
+	for name := range files {
+		if !listed[name] {
+			extra = append(extra, name)
+		}
+	}
+	if len(extra) > 0 {
+		sort.Strings(extra)
+		return nil, fmt.Errorf("%w: unexpected %s", ErrTampered, strings.Join(extra, ", "))
+	}
+	return &manifest, nil
+}
+
+func readFile(f *zip.File, limit int64) ([]byte, error) {
+	if f == nil {
+		return nil, ErrInvalidBundle
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(io.LimitReader(rc, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, ErrInvalidBundle
+	}
+	return data, nil
+}
+
+// cleanPath 包内路径只允许相对路径，且不能与清单、签名重名
+func cleanPath(name string) (string, error) {
+	name = path.Clean(strings.ReplaceAll(name, "\\", "/"))
+	if name == "." || strings.HasPrefix(name, "/") || strings.HasPrefix(name, "../") || name == ".." {
+		return "", fmt.Errorf("%w: invalid path %q", ErrInvalidBundle, name)
+	}
+	if name == ManifestName || name == SignatureName {
+		return "", fmt.Errorf("%w: %s is reserved", ErrDuplicatePath, name)
+	}
+	return name, nil
+}
@@ -0,0 +1,137 @@
+package bundle
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func build(t *testing.T, signer *Signer) []byte {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, signer)
+	require.NoError(t, w.AddJSON("case.json", map[string]string{"name": "张三"}))
+	fw, err := w.Create("photos/1.jpg")
+	require.NoError(t, err)
+	_, err = io.WriteString(fw, "jpeg-bytes")
+	require.NoError(t, err)
+
+	_, err = w.Create("case.json")
+	assert.ErrorIs(t, err, ErrDuplicatePath)
+	_, err = w.Create("../escape")
+	assert.ErrorIs(t, err, ErrInvalidBundle)
+	_, err = w.Create(ManifestName)
+	assert.ErrorIs(t, err, ErrDuplicatePath)
+
+	manifest, err := w.Close(map[string]string{"recipient": "派出所"})
+	require.NoError(t, err)
+	require.Len(t, manifest.Files, 2)
+	assert.Equal(t, int64(10), manifest.Files[1].Size)
+	return buf.Bytes()
+}
+
+func newSigner(t *testing.T) *Signer {
+	signer, _, err := GenerateSigner()
+	require.NoError(t, err)
+	return signer
+}
+
+func TestVerify(t *testing.T) {
+	signer := newSigner(t)
+	data := build(t, signer)
+
+	manifest, err := Verify(data, signer.PublicKey())
+	require.NoError(t, err)
+	assert.Equal(t, signer.KeyID(), manifest.KeyID)
+	var meta map[string]string
+	require.NoError(t, json.Unmarshal(manifest.Meta, &meta))
+	assert.Equal(t, "派出所", meta["recipient"])
+
+	_, err = Verify(data, newSigner(t).PublicKey())
+	assert.ErrorIs(t, err, ErrBadSignature)
+
+	_, err = Verify([]byte("not a zip"), signer.PublicKey())
+	assert.ErrorIs(t, err, ErrInvalidBundle)
+}
+
+func TestVerify_Tampered(t *testing.T) {
+	signer := newSigner(t)
+	data := build(t, signer)
+
+	// 重新打包：替换照片内容、保留原清单和签名
+	rewrite := func(modify func(name string, content []byte) []byte, extra string) []byte {
+		zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		require.NoError(t, err)
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		for _, f := range zr.File {
+			rc, err := f.Open()
+			require.NoError(t, err)
+			content, err := io.ReadAll(rc)
+			require.NoError(t, err)
+			rc.Close()
+			w, err := zw.Create(f.Name)
+			require.NoError(t, err)
+			_, err = w.Write(modify(f.Name, content))
+			require.NoError(t, err)
+		}
+		if extra != "" {
+			w, err := zw.Create(extra)
+			require.NoError(t, err)
+			_, err = w.Write([]byte("x"))
+			require.NoError(t, err)
+		}
+		require.NoError(t, zw.Close())
+		return buf.Bytes()
+	}
+
+	changed := rewrite(func(name string, content []byte) []byte {
+		if name == "photos/1.jpg" {
+			return []byte("jpeg-byteZ")
+		}
+		return content
+	}, "")
+	_, err := Verify(changed, signer.PublicKey())
+	assert.ErrorIs(t, err, ErrTampered)
+
+	added := rewrite(func(_ string, content []byte) []byte { return content }, "notes.txt")
+	_, err = Verify(added, signer.PublicKey())
+	assert.ErrorIs(t, err, ErrTampered)
+}
+
+func TestNewSigner(t *testing.T) {
+	_, err := NewSigner([]byte("short"))
+	assert.ErrorIs(t, err, ErrInvalidKey)
+
+	a, err := NewSigner(make([]byte, 32))
+	require.NoError(t, err)
+	b, err := NewSigner(make([]byte, 32))
+	require.NoError(t, err)
+	assert.Equal(t, a.KeyID(), b.KeyID())
+	assert.Len(t, a.KeyID(), 16)
+}
+
+func TestLoadOrCreateSigner(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "keys", "export.key")
+
+	created, err := LoadOrCreateSigner(file)
+	require.NoError(t, err)
+	info, err := os.Stat(file)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	// 再次加载得到同一密钥
+	loaded, err := LoadOrCreateSigner(file)
+	require.NoError(t, err)
+	assert.Equal(t, created.KeyID(), loaded.KeyID())
+
+	require.NoError(t, os.WriteFile(file, []byte("not base64!"), 0o600))
+	_, err = LoadOrCreateSigner(file)
+	assert.ErrorIs(t, err, ErrInvalidKey)
+}
@@ -0,0 +1,50 @@
+package captcha
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func solve(t *testing.T, question string) string {
+	var a, b int
+	var op string
+	_, err := fmt.Sscanf(question, "%d %s %d = ?", &a, &op, &b)
+	require.NoError(t, err)
+	if op == "-" {
+		return fmt.Sprint(a - b)
+	}
+	return fmt.Sprint(a + b)
+}
+
+func TestGenerateAndVerify(t *testing.T) {
+	g := NewGenerator("secret", time.Minute)
+	c, err := g.Generate()
+	require.NoError(t, err)
+
+	nonce, err := g.Verify(c.Token, " "+solve(t, c.Question)+" ")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, nonce)
+
+	_, err = g.Verify(c.Token, "-1000")
+	assert.ErrorIs(t, err, ErrInvalid)
+
+	_, err = NewGenerator("other", time.Minute).Verify(c.Token, solve(t, c.Question))
+	assert.ErrorIs(t, err, ErrInvalid)
+
+	_, err = g.Verify("garbage", "1")
+	assert.ErrorIs(t, err, ErrInvalid)
+}
+
+func TestVerifyExpired(t *testing.T) {
+	g := NewGenerator("secret", time.Minute)
+	c, err := g.Generate()
+	require.NoError(t, err)
+
+	g.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+	_, err = g.Verify(c.Token, solve(t, c.Question))
+	assert.ErrorIs(t, err, ErrExpired)
+}
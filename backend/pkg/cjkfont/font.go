@@ -0,0 +1,121 @@
+// Package cjkfont 加载中文字体，供海报 PNG 光栅化（x/image opentype）与 PDF 嵌入（fpdf）共用
+package cjkfont
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/go-pdf/fpdf"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/font/sfnt"
+)
+
+// Family 在 PDF 中注册的字体名
+const Family = "cjk"
+
+var (
+	// ErrUnavailable 未找到可用的中文字体
+	ErrUnavailable = errors.New("cjkfont: font unavailable")
+	// ErrUnsupported 字体格式不支持：PDF 只能嵌入单个 TrueType 轮廓字体，.ttc 字体集合和 CFF 轮廓的 .otf 不支持
+	ErrUnsupported = errors.New("cjkfont: only single TrueType (.ttf) fonts are supported")
+)
+
+// fontDirs 系统字体目录
+var fontDirs = []string{
+	"/usr/share/fonts",
+	"/usr/local/share/fonts",
+	`C:\Windows\Fonts`,
+	"/System/Library/Fonts",
+}
+
+// fontCandidates 常见的 TrueType 轮廓中文字体
+var fontCandidates = []string{
+	"DroidSansFallbackFull.ttf",
+	"DroidSansFallback.ttf",
+	"simhei.ttf",
+	"NotoSansSC-Regular.ttf",
+}
+
+// Font 中文字体
+type Font struct {
+	data []byte
+	sfnt *opentype.Font
+}
+
+// Parse 解析 TrueType 字体文件
+func Parse(data []byte) (*Font, error) {
+	if len(data) < 4 || !(bytes.Equal(data[:4], []byte{0, 1, 0, 0}) || bytes.Equal(data[:4], []byte("true"))) {
+		return nil, ErrUnsupported
+	}
+	f, err := opentype.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// fpdf 解析失败时不返回错误，只在设置字体时报未定义，这里先试注册一次
+	doc := fpdf.New("P", "pt", "A4", "")
+	doc.AddUTF8FontFromBytes(Family, "", data)
+	doc.SetFont(Family, "", 10)
+	if err := doc.Error(); err != nil {
+		return nil, ErrUnsupported
+	}
+	return &Font{data: data, sfnt: f}, nil
+}
+
+// Load 加载中文字体：优先使用指定路径，为空时在系统字体目录中查找
+func Load(path string) (*Font, error) {
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		return Parse(data)
+	}
+
+	found := make(map[string]string)
+	for _, dir := range fontDirs {
+		filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+			if err == nil && !d.IsDir() {
+				if _, ok := found[d.Name()]; !ok {
+					found[d.Name()] = p
+				}
+			}
+			return nil
+		})
+	}
+	for _, name := range fontCandidates {
+		p, ok := found[name]
+		if !ok {
+			continue
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			continue
+		}
+		if f, err := Parse(data); err == nil && f.HasGlyph('寻') {
+			return f, nil
+		}
+	}
+	return nil, ErrUnavailable
+}
+
+// HasGlyph 字体是否包含该字符
+func (f *Font) HasGlyph(r rune) bool {
+	var buf sfnt.Buffer
+	idx, err := f.sfnt.GlyphIndex(&buf, r)
+	return err == nil && idx != 0
+}
+
+// Face 指定字号（像素）的字形，用于光栅化
+func (f *Font) Face(size float64) (font.Face, error) {
+	return opentype.NewFace(f.sfnt, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingNone})
+}
+
+// Register 在 PDF 文档中注册字体，之后以 Family 设置字体；输出时只嵌入用到的字符
+func (f *Font) Register(doc *fpdf.Fpdf) {
+	doc.AddUTF8FontFromBytes(Family, "", f.data)
+}
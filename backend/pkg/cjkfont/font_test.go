@@ -0,0 +1,64 @@
+package cjkfont
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+func TestParse(t *testing.T) {
+	f, err := Parse(goregular.TTF)
+	require.NoError(t, err)
+	assert.True(t, f.HasGlyph('A'))
+	assert.False(t, f.HasGlyph('寻'))
+
+	face, err := f.Face(20)
+	require.NoError(t, err)
+	assert.Positive(t, font.MeasureString(face, "ABC").Round())
+}
+
+func TestParse_Unsupported(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", nil},
+		{"collection", append([]byte("ttcf"), goregular.TTF...)},
+		{"cff outlines", append([]byte("OTTO"), goregular.TTF[4:]...)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.data)
+			assert.ErrorIs(t, err, ErrUnsupported)
+		})
+	}
+
+	_, err := Parse(append([]byte{0, 1, 0, 0}, make([]byte, 64)...))
+	assert.Error(t, err)
+}
+
+func TestLoad_Path(t *testing.T) {
+	_, err := Load("testdata/missing.ttf")
+	assert.Error(t, err)
+}
+
+func TestFont_Register(t *testing.T) {
+	f, err := Parse(goregular.TTF)
+	require.NoError(t, err)
+
+	doc := fpdf.New("P", "pt", "A4", "")
+	f.Register(doc)
+	doc.SetFont(Family, "", 12)
+	doc.AddPage()
+	doc.Text(50, 50, "Hello")
+
+	var buf bytes.Buffer
+	require.NoError(t, doc.Output(&buf))
+	assert.Contains(t, buf.String(), "/FontFile2")
+}
@@ -0,0 +1,63 @@
+// Package geo 提供经纬度距离计算、包围盒与 geohash 编码等地理工具
+package geo
+
+import "math"
+
+// EarthRadiusKm 地球平均半径（公里）
+const EarthRadiusKm = 6371.0088
+
+// BBox 经纬度包围盒
+type BBox struct {
+	MinLat float64 `json:"min_lat"`
+	MinLng float64 `json:"min_lng"`
+	MaxLat float64 `json:"max_lat"`
+	MaxLng float64 `json:"max_lng"`
+}
+
+// Valid 包围盒是否有效
+func (b BBox) Valid() bool {
+	return b.MinLat < b.MaxLat && b.MinLng < b.MaxLng &&
+		b.MinLat >= -90 && b.MaxLat <= 90 && b.MinLng >= -180 && b.MaxLng <= 180
+}
+
+// Contains 点是否在包围盒内
+func (b BBox) Contains(lat, lng float64) bool {
+	return lat >= b.MinLat && lat <= b.MaxLat && lng >= b.MinLng && lng <= b.MaxLng
+}
+
+// ValidCoordinate 坐标是否有效（0,0 视为未填写）
+func ValidCoordinate(lat, lng float64) bool {
+	if lat == 0 && lng == 0 {
+		return false
+	}
+	return lat >= -90 && lat <= 90 && lng >= -180 && lng <= 180
+}
+
+// Distance 使用 Haversine 公式计算两点间球面距离（公里）
+func Distance(lat1, lng1, lat2, lng2 float64) float64 {
+	dLat := toRadians(lat2 - lat1)
+	dLng := toRadians(lng2 - lng1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRadians(lat1))*math.Cos(toRadians(lat2))*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * EarthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// BBoxAround 计算以某点为中心、指定半径（公里）的外接包围盒
+func BBoxAround(lat, lng, radiusKm float64) BBox {
+	dLat := radiusKm / EarthRadiusKm * 180 / math.Pi
+	cosLat := math.Cos(toRadians(lat))
+	dLng := 180.0
+	if cosLat > 1e-6 {
+		dLng = math.Min(180, dLat/cosLat)
+	}
+	return BBox{
+		MinLat: math.Max(-90, lat-dLat),
+		MinLng: math.Max(-180, lng-dLng),
+		MaxLat: math.Min(90, lat+dLat),
+		MaxLng: math.Min(180, lng+dLng),
+	}
+}
+
+func toRadians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
@@ -0,0 +1,73 @@
+package geo
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistance(t *testing.T) {
+	// 北京天安门 -> 上海人民广场 约 1067 公里
+	d := Distance(39.9087, 116.3975, 31.2304, 121.4737)
+	assert.InDelta(t, 1067, d, 10)
+	assert.InDelta(t, 0, Distance(30, 120, 30, 120), 1e-9)
+}
+
+func TestEncode(t *testing.T) {
+	assert.Equal(t, "wx4g0", Encode(39.9087, 116.3975, 5))
+	assert.Equal(t, "ezs42", Encode(42.6, -5.6, 5))
+
+	b := DecodeBBox("wx4g0")
+	assert.True(t, b.Contains(39.9087, 116.3975))
+}
+
+func TestCoverBBox(t *testing.T) {
+	box := BBoxAround(39.9087, 116.3975, 5)
+	assert.True(t, box.Contains(39.9087, 116.3975))
+
+	cells := CoverBBox(box, 16)
+	assert.NotEmpty(t, cells)
+	assert.LessOrEqual(t, len(cells), 16)
+
+	// 包围盒内任意点的 geohash 都应以某个覆盖单元为前缀
+	for _, p := range [][2]float64{
+		{box.MinLat, box.MinLng}, {box.MaxLat, box.MaxLng},
+		{box.MinLat, box.MaxLng}, {box.MaxLat, box.MinLng}, {39.9087, 116.3975},
+	} {
+		hash := Encode(p[0], p[1], 12)
+		covered := false
+		for _, c := range cells {
+			if strings.HasPrefix(hash, c) {
+				covered = true
+				break
+			}
+		}
+		assert.True(t, covered, "point %v not covered", p)
+	}
+}
+
+func TestCircle(t *testing.T) {
+	center := Point{Lat: 30.5728, Lng: 104.0668}
+	ring := Circle(center, 2, 32)
+	assert.Len(t, ring, 32)
+	for _, p := range ring {
+		assert.InDelta(t, 2, Distance(center.Lat, center.Lng, p.Lat, p.Lng), 1e-6)
+	}
+}
+
+func TestConvexHull(t *testing.T) {
+	hull := ConvexHull([]Point{
+		{Lat: 0, Lng: 0}, {Lat: 1, Lng: 0}, {Lat: 1, Lng: 1}, {Lat: 0, Lng: 1},
+		{Lat: 0.5, Lng: 0.5}, {Lat: 0, Lng: 0},
+	})
+	assert.Len(t, hull, 4)
+	assert.NotContains(t, hull, Point{Lat: 0.5, Lng: 0.5})
+}
+
+func TestPolygonGeometryClosed(t *testing.T) {
+	g := NewPolygonGeometry([]Point{{Lat: 0, Lng: 0}, {Lat: 1, Lng: 0}, {Lat: 1, Lng: 1}})
+	rings := g.Coordinates.([][][2]float64)
+	assert.Len(t, rings[0], 4)
+	assert.Equal(t, rings[0][0], rings[0][3])
+}
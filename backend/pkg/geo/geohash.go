@@ -0,0 +1,125 @@
+package geo
+
+import (
+	"math"
+	"strings"
+)
+
+const geohashBase32 = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// DefaultGeohashPrecision 默认 geohash 精度（9 位约 5 米）
+const DefaultGeohashPrecision = 9
+
+// Encode 计算 geohash
+func Encode(lat, lng float64, precision int) string {
+	if precision <= 0 || precision > 12 {
+		precision = DefaultGeohashPrecision
+	}
+
+	latRange := [2]float64{-90, 90}
+	lngRange := [2]float64{-180, 180}
+
+	var b strings.Builder
+	b.Grow(precision)
+
+	bit, ch := 0, 0
+	even := true
+	for b.Len() < precision {
+		if even {
+			mid := (lngRange[0] + lngRange[1]) / 2
+			if lng >= mid {
+				ch = ch<<1 | 1
+				lngRange[0] = mid
+			} else {
+				ch <<= 1
+				lngRange[1] = mid
+			}
+		} else {
+			mid := (latRange[0] + latRange[1]) / 2
+			if lat >= mid {
+				ch = ch<<1 | 1
+				latRange[0] = mid
+			} else {
+				ch <<= 1
+				latRange[1] = mid
+			}
+		}
+		even = !even
+
+		bit++
+		if bit == 5 {
+			b.WriteByte(geohashBase32[ch])
+			bit, ch = 0, 0
+		}
+	}
+	return b.String()
+}
+
+// DecodeBBox 解码 geohash 对应的包围盒
+func DecodeBBox(hash string) BBox {
+	latRange := [2]float64{-90, 90}
+	lngRange := [2]float64{-180, 180}
+
+	even := true
+	for i := 0; i < len(hash); i++ {
+		idx := strings.IndexByte(geohashBase32, hash[i])
+		if idx < 0 {
+			break
+		}
+		for mask := 16; mask > 0; mask >>= 1 {
+			r := &latRange
+			if even {
+				r = &lngRange
+			}
+			mid := (r[0] + r[1]) / 2
+			if idx&mask != 0 {
+				r[0] = mid
+			} else {
+				r[1] = mid
+			}
+			even = !even
+		}
+	}
+	return BBox{MinLat: latRange[0], MinLng: lngRange[0], MaxLat: latRange[1], MaxLng: lngRange[1]}
+}
+
+// cellSize 指定精度下单个 geohash 单元的纬度、经度跨度
+func cellSize(precision int) (float64, float64) {
+	bits := precision * 5
+	lngBits := (bits + 1) / 2
+	latBits := bits / 2
+	return 180 / math.Pow(2, float64(latBits)), 360 / math.Pow(2, float64(lngBits))
+}
+
+// CoverBBox 计算覆盖包围盒的 geohash 前缀集合
+// 在不超过 maxCells 个单元的前提下选择尽可能高的精度，用于 geohash 索引列的前缀过滤
+func CoverBBox(b BBox, maxCells int) []string {
+	if maxCells <= 0 {
+		maxCells = 16
+	}
+
+	for precision := 12; precision >= 1; precision-- {
+		dLat, dLng := cellSize(precision)
+		rows := int(math.Floor(b.MaxLat/dLat)-math.Floor(b.MinLat/dLat)) + 1
+		cols := int(math.Floor(b.MaxLng/dLng)-math.Floor(b.MinLng/dLng)) + 1
+		if rows*cols > maxCells {
+			continue
+		}
+
+		seen := make(map[string]struct{}, rows*cols)
+		cells := make([]string, 0, rows*cols)
+		for i := 0; i < rows; i++ {
+			lat := math.Min(b.MinLat+float64(i)*dLat, b.MaxLat)
+			for j := 0; j < cols; j++ {
+				lng := math.Min(b.MinLng+float64(j)*dLng, b.MaxLng)
+				hash := Encode(lat, lng, precision)
+				if _, ok := seen[hash]; !ok {
+					seen[hash] = struct{}{}
+					cells = append(cells, hash)
+				}
+			}
+		}
+		return cells
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package geo
+
+// GeoJSON 几何类型
+const (
+	GeometryPoint      = "Point"
+	GeometryLineString = "LineString"
+	GeometryPolygon    = "Polygon"
+)
+
+// Geometry GeoJSON 几何对象，坐标顺序为 [经度, 纬度]
+type Geometry struct {
+	Type        string      `json:"type"`
+	Coordinates interface{} `json:"coordinates"`
+}
+
+// Feature GeoJSON 要素
+type Feature struct {
+	Type       string                 `json:"type"`
+	Geometry   *Geometry              `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// FeatureCollection GeoJSON 要素集合
+type FeatureCollection struct {
+	Type     string    `json:"type"`
+	Features []Feature `json:"features"`
+}
+
+// NewPointGeometry 创建点几何
+func NewPointGeometry(p Point) *Geometry {
+	return &Geometry{Type: GeometryPoint, Coordinates: position(p)}
+}
+
+// NewLineStringGeometry 创建线几何
+func NewLineStringGeometry(points []Point) *Geometry {
+	coords := make([][2]float64, len(points))
+	for i, p := range points {
+		coords[i] = position(p)
+	}
+	return &Geometry{Type: GeometryLineString, Coordinates: coords}
+}
+
+// NewPolygonGeometry 创建多边形几何（单个外环），自动闭合
+func NewPolygonGeometry(ring []Point) *Geometry {
+	coords := make([][2]float64, 0, len(ring)+1)
+	for _, p := range ring {
+		coords = append(coords, position(p))
+	}
+	if len(ring) > 0 && ring[0] != ring[len(ring)-1] {
+		coords = append(coords, position(ring[0]))
+	}
+	return &Geometry{Type: GeometryPolygon, Coordinates: [][][2]float64{coords}}
+}
+
+// NewFeature 创建要素
+func NewFeature(geometry *Geometry, properties map[string]interface{}) Feature {
+	if properties == nil {
+		properties = map[string]interface{}{}
+	}
+	return Feature{Type: "Feature", Geometry: geometry, Properties: properties}
+}
+
+// NewFeatureCollection 创建要素集合
+func NewFeatureCollection(features ...Feature) *FeatureCollection {
+	if features == nil {
+		features = []Feature{}
+	}
+	return &FeatureCollection{Type: "FeatureCollection", Features: features}
+}
+
+// Add 追加要素
+func (fc *FeatureCollection) Add(f Feature) {
+	fc.Features = append(fc.Features, f)
+}
+
+func position(p Point) [2]float64 {
+	return [2]float64{p.Lng, p.Lat}
+}
@@ -0,0 +1,100 @@
+package geo
+
+import (
+	"math"
+	"sort"
+)
+
+// Point 经纬度坐标点
+type Point struct {
+	Lat float64 `json:"lat"`
+	Lng float64 `json:"lng"`
+}
+
+// Destination 从起点沿方位角（度，正北为 0）行进指定距离（公里）后的坐标
+func Destination(p Point, bearingDeg, distanceKm float64) Point {
+	delta := distanceKm / EarthRadiusKm
+	theta := toRadians(bearingDeg)
+	lat1 := toRadians(p.Lat)
+	lng1 := toRadians(p.Lng)
+
+	lat2 := math.Asin(math.Sin(lat1)*math.Cos(delta) + math.Cos(lat1)*math.Sin(delta)*math.Cos(theta))
+	lng2 := lng1 + math.Atan2(math.Sin(theta)*math.Sin(delta)*math.Cos(lat1), math.Cos(delta)-math.Sin(lat1)*math.Sin(lat2))
+
+	lng := math.Mod(toDegrees(lng2)+540, 360) - 180
+	return Point{Lat: toDegrees(lat2), Lng: lng}
+}
+
+// Circle 以多边形近似表示圆形区域，segments 为顶点数
+func Circle(center Point, radiusKm float64, segments int) []Point {
+	if segments < 8 {
+		segments = 8
+	}
+	ring := make([]Point, segments)
+	for i := 0; i < segments; i++ {
+		ring[i] = Destination(center, float64(i)*360/float64(segments), radiusKm)
+	}
+	return ring
+}
+
+// Centroid 计算点集的平均中心（适用于小范围点集）
+func Centroid(points []Point) Point {
+	if len(points) == 0 {
+		return Point{}
+	}
+	var lat, lng float64
+	for _, p := range points {
+		lat += p.Lat
+		lng += p.Lng
+	}
+	n := float64(len(points))
+	return Point{Lat: lat / n, Lng: lng / n}
+}
+
+// ConvexHull 计算点集的凸包（Andrew 单调链算法），按逆时针顺序返回
+// 点数少于 3 或全部共线时返回去重后的点
+func ConvexHull(points []Point) []Point {
+	pts := make([]Point, len(points))
+	copy(pts, points)
+	sort.Slice(pts, func(i, j int) bool {
+		if pts[i].Lng != pts[j].Lng {
+			return pts[i].Lng < pts[j].Lng
+		}
+		return pts[i].Lat < pts[j].Lat
+	})
+
+	uniq := pts[:0]
+	for i, p := range pts {
+		if i == 0 || p != pts[i-1] {
+			uniq = append(uniq, p)
+		}
+	}
+	if len(uniq) < 3 {
+		return uniq
+	}
+
+	cross := func(o, a, b Point) float64 {
+		return (a.Lng-o.Lng)*(b.Lat-o.Lat) - (a.Lat-o.Lat)*(b.Lng-o.Lng)
+	}
+
+	hull := make([]Point, 0, 2*len(uniq))
+	for _, p := range uniq {
+		for len(hull) >= 2 && cross(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+	lower := len(hull) + 1
+	for i := len(uniq) - 2; i >= 0; i-- {
+		p := uniq[i]
+		for len(hull) >= lower && cross(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+	return hull[:len(hull)-1]
+}
+
+func toDegrees(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
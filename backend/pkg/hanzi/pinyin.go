@@ -0,0 +1,150 @@
+// Package hanzi 提供汉字拼音转换、繁简转换等文本处理工具（纯 Go 实现，无外部服务依赖）
+package hanzi
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+
+	"golang.org/x/text/encoding/simplifiedchinese"
+)
+
+var (
+	pinyinOnce  sync.Once
+	pinyinTable map[rune]string
+)
+
+// gb2312Initials GB2312 一级汉字按拼音排序，记录每个声母首字的编码区间起点
+// 用于常用字表未覆盖的一级汉字的首字母兜底
+var gb2312Initials = []struct {
+	code    uint16
+	initial byte
+}{
+	{0xB0A1, 'a'}, {0xB0C5, 'b'}, {0xB2C1, 'c'}, {0xB4EE, 'd'}, {0xB6EA, 'e'},
+	{0xB7A2, 'f'}, {0xB8C1, 'g'}, {0xB9FE, 'h'}, {0xBBF7, 'j'}, {0xBFA6, 'k'},
+	{0xC0AC, 'l'}, {0xC2E8, 'm'}, {0xC4C3, 'n'}, {0xC5B6, 'o'}, {0xC5BE, 'p'},
+	{0xC6DA, 'q'}, {0xC8BB, 'r'}, {0xC8F6, 's'}, {0xCBFA, 't'}, {0xCDDA, 'w'},
+	{0xCEF4, 'x'}, {0xD1B9, 'y'}, {0xD4D1, 'z'},
+}
+
+const gb2312Level1End = 0xD7F9
+
+func loadPinyinTable() {
+	pinyinTable = make(map[rune]string, 4096)
+	for _, line := range strings.Split(pinyinDict, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		for _, r := range fields[1] {
+			// 多音字以先出现的读音为准
+			if _, ok := pinyinTable[r]; !ok {
+				pinyinTable[r] = fields[0]
+			}
+		}
+	}
+}
+
+// IsHan 是否为汉字
+func IsHan(r rune) bool {
+	return unicode.Is(unicode.Han, r)
+}
+
+// RunePinyin 获取单个汉字的拼音，ok 为 false 表示未收录
+func RunePinyin(r rune) (string, bool) {
+	pinyinOnce.Do(loadPinyinTable)
+	r = simplifyRune(r)
+	py, ok := pinyinTable[r]
+	return py, ok
+}
+
+// RuneInitial 获取单个汉字的拼音首字母，未知时返回 0
+func RuneInitial(r rune) byte {
+	if py, ok := RunePinyin(r); ok {
+		return py[0]
+	}
+	return gb2312Initial(simplifyRune(r))
+}
+
+// Syllables 将字符串转换为拼音音节序列
+// 汉字转为拼音（未收录的常用字退化为首字母），连续的字母数字作为一个整体保留，其它字符忽略
+func Syllables(s string) []string {
+	var result []string
+	var word strings.Builder
+	flush := func() {
+		if word.Len() > 0 {
+			result = append(result, word.String())
+			word.Reset()
+		}
+	}
+
+	for _, r := range s {
+		switch {
+		case IsHan(r):
+			flush()
+			if py, ok := RunePinyin(r); ok {
+				result = append(result, py)
+			} else if initial := gb2312Initial(simplifyRune(r)); initial != 0 {
+				result = append(result, string(initial))
+			} else {
+				result = append(result, string(r))
+			}
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			word.WriteRune(unicode.ToLower(r))
+		default:
+			flush()
+		}
+	}
+	flush()
+	return result
+}
+
+// ToPinyin 转换为不带分隔符的全拼，如 "张伟" -> "zhangwei"
+func ToPinyin(s string) string {
+	return strings.Join(Syllables(s), "")
+}
+
+// Initials 获取拼音首字母，如 "张伟" -> "zw"
+func Initials(s string) string {
+	var b strings.Builder
+	for _, syllable := range Syllables(s) {
+		// 无法转换的汉字保留原字
+		for _, r := range syllable {
+			b.WriteRune(r)
+			break
+		}
+	}
+	return b.String()
+}
+
+// NormalizeName 规范化姓名：繁转简、去除空白及间隔号、字母转小写
+func NormalizeName(name string) string {
+	var b strings.Builder
+	for _, r := range ToSimplified(name) {
+		if unicode.IsSpace(r) || r == '·' || r == '•' || r == '.' || r == '-' {
+			continue
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+// gb2312Initial 根据 GB2312 一级汉字编码区间推断拼音首字母
+func gb2312Initial(r rune) byte {
+	encoded, err := simplifiedchinese.GBK.NewEncoder().String(string(r))
+	if err != nil || len(encoded) != 2 {
+		return 0
+	}
+	code := uint16(encoded[0])<<8 | uint16(encoded[1])
+	if code < gb2312Initials[0].code || code > gb2312Level1End {
+		return 0
+	}
+	initial := gb2312Initials[0].initial
+	for _, item := range gb2312Initials {
+		if code < item.code {
+			break
+		}
+		initial = item.initial
+	}
+	return initial
+}
@@ -0,0 +1,391 @@
+package hanzi
+
+// pinyinDict 常用汉字拼音表（不带声调）
+// 每行格式: 拼音 汉字列表，多音字取姓名、地名中最常见的读音
+const pinyinDict = `
+a 阿啊
+ai 爱艾哀挨矮碍埃癌蔼
+an 安按案暗岸俺庵鞍
+ang 昂肮
+ao 奥澳傲熬袄凹敖
+ba 八把爸吧巴拔霸坝芭疤捌靶
+bai 白百摆败拜柏佰
+ban 办半班般板版搬伴扮斑颁瓣
+bang 帮邦棒榜膀绑磅
+bao 包保报宝抱暴薄饱豹爆堡胞褒
+bei 北被备背杯悲贝辈倍碑卑
+ben 本奔笨
+beng 崩蹦绷甭
+bi 比笔必毕闭币碧壁避鼻彼逼弊臂璧毙
+bian 边变便遍编辨辩扁鞭卞
+biao 表标彪膘
+bie 别憋
+bin 宾彬斌滨缤濒
+bing 兵冰病并饼丙秉炳
+bo 波博播伯剥玻薄勃驳泊柏帛铂渤
+bu 不部步布补捕卜埠簿
+ca 擦
+cai 才材财菜采彩猜蔡裁踩
+can 参餐残蚕惨灿
+cang 藏仓苍沧舱
+cao 草操曹槽糙
+ce 策测册侧厕
+cen 岑
+ceng 层蹭
+cha 查茶差插察叉岔
+chai 柴拆
+chan 产铲缠馋蝉禅阐颤
+chang 长常场厂唱昌肠尝偿畅倡
+chao 朝超潮抄吵巢钞炒
+che 车彻撤扯澈
+chen 陈晨沉臣尘辰衬趁琛谌
+cheng 成城程承称乘诚呈橙秤澄惩逞
+chi 吃持迟池尺齿赤翅驰耻痴弛
+chong 冲充虫崇宠
+chou 抽愁仇丑臭筹酬
+chu 出处初除楚础触储厨锄雏矗
+chuan 传船穿川串喘
+chuang 窗床创闯
+chui 吹垂锤炊
+chun 春纯唇淳醇
+ci 次此词辞瓷慈磁刺雌
+cong 从丛聪葱匆
+cou 凑
+cu 粗促醋簇
+cui 崔催脆翠
+cun 村存寸
+cuo 错措挫
+da 大打达答搭
+dai 代带待戴袋呆贷逮歹
+dan 单但担蛋淡胆弹旦丹耽
+dang 当党档荡挡
+dao 到道导倒岛刀盗稻蹈悼
+de 的得德
+deng 等灯登邓瞪凳
+di 地第低底帝弟敌滴递笛迪抵堤狄
+dian 点电店典殿垫甸淀颠
+diao 调掉吊钓雕刁
+die 跌叠蝶爹
+ding 定顶丁订钉盯鼎
+diu 丢
+dong 东动冬懂洞董栋冻
+dou 斗豆抖陡窦逗
+du 度读独毒杜督渡肚堵赌都
+duan 段断短端锻
+dui 对队堆兑
+dun 顿吨蹲敦盾
+duo 多夺朵躲舵堕
+e 额恶饿俄鹅峨娥鄂
+en 恩
+er 而二儿耳尔
+fa 发法罚伐乏
+fan 反饭范犯翻凡烦繁返帆番樊
+fang 方放房防访芳仿纺坊
+fei 非飞费肥废肺菲妃
+fen 分份粉奋纷芬坟愤
+feng 风封丰峰锋疯冯奉凤枫逢蜂
+fo 佛
+fou 否
+fu 夫父付府富服福复副附负扶浮符傅抚腹妇伏辅赴肤甫
+ga 嘎
+gai 该改概盖钙
+gan 干感赶敢甘杆肝
+gang 刚港钢岗纲缸
+gao 高告搞稿糕膏
+ge 个各歌哥格革隔割戈葛阁鸽
+gei 给
+gen 根跟
+geng 更耕耿庚
+gong 工公功共供宫攻贡弓恭龚巩
+gou 够构狗购沟钩勾
+gu 古故顾股骨鼓谷固姑孤雇
+gua 挂瓜刮寡
+guai 怪拐乖
+guan 关观官管馆惯冠贯灌罐
+guang 光广逛
+gui 规贵鬼归桂跪柜轨圭
+gun 滚棍
+guo 国过果锅郭裹
+ha 哈
+hai 还海害孩亥
+han 汉含寒汗韩喊函涵罕翰
+hang 航杭
+hao 好号毫豪耗浩郝
+he 和合河何喝核贺荷盒赫禾鹤
+hei 黑嘿
+hen 很恨狠痕
+heng 横衡恒亨
+hong 红洪宏鸿虹哄烘弘
+hou 后候厚侯猴吼
+hu 和湖户虎护呼胡忽互糊壶狐葫沪
+hua 化话花华画划滑
+huai 坏怀淮槐
+huan 换还欢环缓患幻焕桓
+huang 黄皇荒慌晃煌凰
+hui 会回灰汇辉挥毁悔惠慧徽绘
+hun 婚混魂昏浑
+huo 活火获或货伙霍
+ji 己机几及级记极基急积计即际技集济继击纪寄季吉籍迹鸡激既疾姬嵇冀辑祭
+jia 家加价假架甲佳嘉夹贾驾稼
+jian 间见建件简渐坚健检减剑键监鉴兼尖肩艰荐箭舰
+jiang 将江讲奖降蒋疆酱姜浆僵
+jiao 叫教交较角脚觉焦胶骄郊娇椒
+jie 结界解接节街介借姐阶届杰洁截劫揭戒
+jin 进金今近尽紧仅斤津锦劲晋禁筋
+jing 经精京静境竟景警井净敬镜径晶惊荆靖
+jiong 窘炯
+jiu 就九酒旧久救究纠
+ju 局具据举句剧居巨聚拒菊鞠
+juan 卷捐娟倦
+jue 决绝觉掘爵
+jun 军君均俊菌郡
+ka 卡咖
+kai 开凯慨楷
+kan 看刊砍堪
+kang 康抗扛慷
+kao 考靠烤
+ke 可科克客课刻渴颗棵柯
+ken 肯恳
+keng 坑
+kong 空控孔恐
+kou 口扣寇
+ku 苦库哭酷裤
+kua 夸跨垮
+kuai 快块筷
+kuan 宽款
+kuang 况矿狂框旷匡
+kui 亏愧葵魁奎
+kun 困昆坤
+kuo 扩阔括廓
+la 拉啦喇辣蜡
+lai 来赖莱
+lan 兰蓝烂栏拦篮览懒岚澜
+lang 浪郎朗狼廊琅
+lao 老劳牢涝
+le 了乐勒
+lei 类泪雷累垒蕾
+leng 冷棱
+li 里理力利立李历离例礼丽粒励厉璃黎梨莉犁
+lian 联连脸练炼恋莲怜廉链
+liang 两量良亮粮梁凉辆谅
+liao 了料疗辽聊廖
+lie 列烈裂猎
+lin 林临邻淋琳霖鳞
+ling 领令另零灵岭龄铃玲凌陵
+liu 六流留刘柳溜
+long 龙隆笼拢陇
+lou 楼漏娄
+lu 路陆录露鲁炉卢庐鹿芦
+lv 绿律率旅虑吕铝屡履
+luan 乱卵
+lue 略掠
+lun 论轮伦
+luo 落罗络逻洛骆萝锣
+ma 马妈吗麻码骂
+mai 买卖麦迈埋脉
+man 满慢漫曼蛮
+mang 忙茫盲芒
+mao 毛冒猫帽貌茂矛
+me 么
+mei 没美每煤妹眉梅媒枚玫
+men 们门闷
+meng 梦孟猛蒙盟萌
+mi 米密迷秘蜜弥
+mian 面免棉眠绵勉
+miao 妙苗秒庙描
+mie 灭
+min 民敏闽岷
+ming 明名命鸣铭
+mo 么模莫末默磨摸魔墨漠
+mou 某谋
+mu 目母木幕牧墓慕穆
+na 那拿哪纳娜
+nai 乃奶耐
+nan 南男难楠
+nao 脑闹恼
+ne 呢
+nei 内
+nen 嫩
+neng 能
+ni 你尼泥妮拟逆
+nian 年念
+niang 娘
+niao 鸟
+nie 聂
+nin 您
+ning 宁凝
+niu 牛扭纽
+nong 农浓弄
+nu 女努怒奴
+nuan 暖
+nuo 诺挪
+ou 欧偶
+pa 怕爬帕
+pai 派排拍牌
+pan 判盘盼潘攀
+pang 旁胖庞
+pao 跑炮泡
+pei 配培陪佩裴
+pen 盆喷
+peng 朋鹏蓬彭碰
+pi 皮批匹疲啤脾
+pian 片篇偏骗
+piao 票漂飘
+pin 品贫拼频
+ping 平评凭瓶萍屏
+po 破婆迫颇坡
+pu 普铺扑朴蒲浦谱
+qi 起其气期七齐器奇企启汽旗骑棋妻弃戚祁琪
+qia 恰洽
+qian 前千钱浅签迁欠谦潜牵乾倩
+qiang 强墙枪抢腔
+qiao 桥巧乔敲悄侨
+qie 且切窃
+qin 亲秦琴勤侵芹钦
+qing 情青清请轻庆晴卿倾
+qiong 穷琼
+qiu 求球秋丘邱
+qu 去取区曲趣渠屈瞿
+quan 全权泉劝圈拳
+que 却确缺雀
+qun 群裙
+ran 然燃染冉
+rang 让
+rao 绕饶
+re 热
+ren 人认任仁忍
+reng 仍扔
+ri 日
+rong 容荣融溶蓉戎
+rou 肉柔
+ru 如入乳儒汝
+ruan 软阮
+rui 瑞锐睿蕊
+run 润闰
+ruo 若弱
+sa 撒洒萨
+sai 赛塞
+san 三散伞
+sang 桑丧
+sao 扫嫂
+se 色
+sen 森
+sha 沙杀啥傻纱莎
+shai 晒
+shan 山善闪衫珊杉陕单扇
+shang 上商伤尚赏
+shao 少绍烧邵韶哨
+she 社设舍射蛇摄涉
+shen 身深神什申审甚沈伸慎绅
+sheng 生声省胜升圣盛绳剩
+shi 是时事市实使十世式石师史始施识示士室视试适失诗湿拾狮
+shou 手收受首守寿授瘦兽
+shu 数书树属术输熟述束叔舒殊鼠署蜀淑
+shua 刷
+shuai 帅衰摔
+shuan 拴
+shuang 双爽霜
+shui 水谁睡税
+shun 顺舜
+shuo 说硕朔
+si 四思死司丝私斯寺似
+song 送松宋颂
+sou 搜艘
+su 苏速素诉宿俗塑肃
+suan 算酸蒜
+sui 随虽岁碎隋遂穗
+sun 孙损笋
+suo 所索锁缩
+ta 他她它塔踏
+tai 太台态泰抬胎
+tan 谈探坦叹谭碳滩
+tang 堂唐汤糖躺塘
+tao 套讨逃桃陶涛
+te 特
+teng 腾疼藤
+ti 体提题替梯踢
+tian 天田填甜添
+tiao 条调跳挑
+tie 铁贴
+ting 听停庭厅亭婷
+tong 同通统痛童铜桐彤
+tou 头投透偷
+tu 图土突途涂徒屠
+tuan 团
+tui 推退腿
+tun 吞屯
+tuo 脱托拖妥拓
+wa 挖哇娃瓦袜
+wai 外歪
+wan 万完晚湾玩碗弯婉宛
+wang 王往望网忘亡汪旺
+wei 为位委未维卫围威微伟尾味危魏韦薇巍蔚
+wen 文问温闻稳纹吻
+weng 翁
+wo 我握卧沃
+wu 无五物务武午舞雾误屋吴伍乌悟巫
+xi 西系习细希喜洗席息戏稀析吸锡熙溪夕曦
+xia 下夏吓峡虾霞侠
+xian 先现线县显险鲜限献闲仙贤咸
+xiang 想向相象香乡响项详箱湘翔祥
+xiao 小笑校效消晓肖萧孝销
+xie 写些谢协鞋斜
+xin 心新信欣辛鑫馨
+xing 行性形星兴型刑醒姓幸邢杏
+xiong 雄兄凶熊胸
+xiu 修秀休绣袖
+xu 需许续虚须序徐旭叙绪
+xuan 选宣悬旋玄轩萱
+xue 学雪血穴薛
+xun 寻训迅讯询巡循勋
+ya 亚压牙雅呀鸭芽崖
+yan 严研言眼验烟颜演盐延燕岩沿炎艳阎晏彦闫
+yang 样阳养羊洋杨仰央扬
+yao 要药摇腰咬耀姚遥瑶尧
+ye 也业夜叶野爷页
+yi 一以已意义议易医依益衣移亦艺异疑忆伊仪宜怡逸毅翼
+yin 因音引银印饮阴隐尹殷寅
+ying 应影英营迎硬映赢鹰婴盈莹颖樱
+yo 哟
+yong 用永勇拥涌泳庸咏
+you 有又由友油游优尤右邮幼犹悠佑
+yu 于与语育预雨余遇鱼玉域欲宇羽愉御郁渔愚裕喻虞俞禹瑜
+yuan 员元原院远源愿园圆援缘袁苑渊媛
+yue 月越约跃岳阅悦
+yun 运云允孕韵匀芸
+za 杂砸
+zai 在再载灾宰
+zan 赞暂咱
+zang 脏葬臧
+zao 早造遭燥枣灶
+ze 则责择泽
+zei 贼
+zen 怎
+zeng 增曾赠
+zha 查扎炸闸诈渣
+zhai 宅摘窄债翟
+zhan 战站展占沾詹斩
+zhang 张长章掌涨丈账障彰
+zhao 找照招赵召兆昭朝
+zhe 这者着折哲浙
+zhen 真镇阵针珍震振贞祯
+zheng 正政证整争征郑症蒸
+zhi 之只知至制直治指支志质值职止纸致智置植枝执芝织
+zhong 中种重众终钟忠仲
+zhou 周州洲舟昼皱宙
+zhu 主住注助竹朱祝诸逐猪珠筑株
+zhua 抓
+zhuan 转专传砖
+zhuang 装状壮庄撞
+zhui 追坠
+zhun 准
+zhuo 桌捉卓着
+zi 子自字资紫姿滋
+zong 总宗纵踪
+zou 走邹奏
+zu 组族足祖阻租
+zuan 钻
+zui 最嘴罪醉
+zun 尊遵
+zuo 作做坐左座昨佐
+`
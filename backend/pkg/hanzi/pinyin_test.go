@@ -0,0 +1,31 @@
+package hanzi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToPinyin(t *testing.T) {
+	assert.Equal(t, "zhangwei", ToPinyin("张伟"))
+	assert.Equal(t, "chengdu", ToPinyin("成都"))
+	assert.Equal(t, "lixiaoming", ToPinyin("李 小明"))
+	assert.Equal(t, "wang2", ToPinyin("王2"))
+}
+
+func TestInitials(t *testing.T) {
+	assert.Equal(t, "zw", Initials("张伟"))
+	// 常用字表未收录的一级汉字按 GB2312 区间取首字母
+	assert.Equal(t, "j", Initials("饺"))
+	assert.Equal(t, "z玮", Initials("张玮"))
+}
+
+func TestToSimplified(t *testing.T) {
+	assert.Equal(t, "张国华", ToSimplified("張國華"))
+	assert.Equal(t, "abc", ToSimplified("abc"))
+}
+
+func TestNormalizeName(t *testing.T) {
+	assert.Equal(t, NormalizeName("张国华"), NormalizeName("張 國華"))
+	assert.Equal(t, "阿依古丽", NormalizeName("阿依·古丽"))
+}
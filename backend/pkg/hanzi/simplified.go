@@ -0,0 +1,89 @@
+package hanzi
+
+import (
+	"strings"
+	"sync"
+)
+
+// tradSimpPairs 常用繁体字与简体字对照表，每两个字符为一组（繁, 简）
+const tradSimpPairs = "" +
+	"愛爱礙碍襖袄壩坝罷罢擺摆敗败頒颁闆板辦办絆绊幫帮綁绑鎊镑寶宝飽饱報报鮑鲍輩辈貝贝" +
+	"備备筆笔畢毕閉闭幣币邊边編编變变標标錶表別别賓宾濱滨餅饼撥拨駁驳補补財财採采參参" +
+	"蠶蚕殘残慘惨燦灿倉仓滄沧艙舱廁厕測测層层產产鏟铲纏缠饞馋蟬蝉闡阐顫颤長长場场廠厂" +
+	"暢畅鈔钞車车徹彻陳陈塵尘襯衬稱称誠诚懲惩馳驰齒齿恥耻衝冲蟲虫寵宠籌筹處处礎础觸触" +
+	"儲储廚厨傳传創创闖闯錘锤純纯詞词辭辞從从叢丛聰聪蔥葱湊凑竄窜錯错達达帶带貸贷單单" +
+	"擔担膽胆彈弹當当黨党檔档盪荡擋挡導导島岛盜盗燈灯鄧邓敵敌遞递點点電电澱淀墊垫釣钓" +
+	"調调疊叠頂顶訂订釘钉東东動动凍冻棟栋鬥斗讀读獨独賭赌鍍镀斷断鍛锻隊队對对噸吨頓顿" +
+	"奪夺墮堕鵝鹅惡恶餓饿兒儿爾尔餌饵發发罰罚閥阀礬矾範范飯饭販贩紡纺訪访飛飞廢废費费" +
+	"墳坟紛纷憤愤糞粪豐丰風风楓枫瘋疯鋒锋馮冯鳳凤婦妇膚肤輔辅撫抚復复負负該该蓋盖幹干" +
+	"趕赶岡冈剛刚鋼钢崗岗綱纲閣阁個个給给鞏巩貢贡溝沟構构購购夠够顧顾穀谷僱雇颳刮掛挂" +
+	"關关觀观館馆慣惯貫贯廣广歸归龜龟規规軌轨貴贵櫃柜滾滚鍋锅國国過过還还漢汉號号禍祸" +
+	"閤合賀贺轟轰紅红鴻鸿後后護护滬沪華华劃划畫画話话懷怀壞坏歡欢環环緩缓換换喚唤黃黄" +
+	"揮挥輝辉匯汇會会諱讳毀毁彙汇燴烩葷荤渾浑夥伙貨货獲获擊击機机積积極极雞鸡跡迹饑饥" +
+	"級级紀纪記记際际劑剂濟济計计繼继績绩夾夹價价駕驾監监堅坚殲歼間间艱艰揀拣繭茧減减" +
+	"檢检簡简見见艦舰薦荐鑒鉴劍剑將将漿浆獎奖講讲醬酱膠胶驕骄嬌娇腳脚攪搅絞绞較较轎轿" +
+	"階阶節节潔洁結结屆届僅仅緊紧錦锦盡尽勁劲進进晉晋驚惊經经頸颈靜静鏡镜競竞糾纠舊旧" +
+	"舉举劇剧據据懼惧捲卷覺觉絕绝軍军開开凱凯顆颗殼壳課课墾垦懇恳褲裤誇夸塊块寬宽礦矿" +
+	"虧亏闊阔臘腊蠟蜡來来賴赖蘭兰欄栏攔拦藍蓝籃篮覽览懶懒爛烂濫滥撈捞勞劳樂乐淚泪類类" +
+	"離离裡里禮礼歷历曆历麗丽勵励厲厉倆俩聯联連连簾帘憐怜臉脸練练煉炼糧粮兩两輛辆諒谅" +
+	"遼辽療疗獵猎鄰邻臨临鱗鳞靈灵齡龄領领劉刘龍龙樓楼蘆芦爐炉陸陆錄录滷卤虜虏魯鲁驢驴" +
+	"綠绿亂乱輪轮論论羅罗蘿萝鑼锣邏逻絡络駱骆媽妈瑪玛碼码螞蚂馬马罵骂嗎吗買买麥麦賣卖" +
+	"邁迈滿满貓猫貿贸麼么沒没門门們们夢梦彌弥謎谜綿绵麵面廟庙滅灭憫悯閩闽鳴鸣銘铭謀谋" +
+	"畝亩納纳難难腦脑惱恼鬧闹內内擬拟膩腻釀酿鳥鸟聶聂寧宁濃浓農农諾诺歐欧毆殴盤盘龐庞" +
+	"賠赔噴喷鵬鹏騙骗頻频憑凭蘋苹評评潑泼撲扑鋪铺樸朴譜谱齊齐騎骑豈岂啟启氣气棄弃牽牵" +
+	"鉛铅謙谦錢钱淺浅強强牆墙槍枪搶抢橋桥喬乔僑侨竊窃親亲輕轻傾倾請请慶庆窮穷瓊琼區区" +
+	"軀躯趨趋權权勸劝確确讓让饒饶擾扰繞绕熱热認认榮荣軟软銳锐閏闰潤润灑洒薩萨賽赛傘伞" +
+	"喪丧掃扫澀涩殺杀紗纱曬晒閃闪陝陕傷伤賞赏燒烧紹绍設设攝摄審审紳绅腎肾聲声勝胜聖圣" +
+	"繩绳濕湿詩诗師师獅狮時时實实識识勢势視视試试適适飾饰壽寿獸兽書书屬属樹树術术數数" +
+	"帥帅雙双誰谁稅税順顺說说碩硕絲丝飼饲鬆松頌颂訴诉肅肃雖虽隨随歲岁孫孙損损筍笋縮缩" +
+	"瑣琐鎖锁態态臺台檯台颱台攤摊灘滩譚谭談谈歎叹湯汤濤涛討讨騰腾題题體体條条鐵铁聽听" +
+	"廳厅銅铜統统頭头圖图塗涂團团頹颓脫脱駝驼襪袜灣湾萬万網网衛卫偉伟圍围緯纬違违為为" +
+	"維维韋韦溫温聞闻紋纹穩稳問问渦涡臥卧烏乌無无誤误霧雾務务習习係系戲戏細细蝦虾峽峡" +
+	"俠侠廈厦鮮鲜閑闲賢贤顯显險险縣县現现線线憲宪獻献鄉乡詳详響响項项蕭萧曉晓銷销嘯啸" +
+	"協协脅胁寫写謝谢興兴洶汹兇凶繡绣須须許许緒绪續续選选懸悬學学尋寻詢询訓训訊讯遜逊" +
+	"壓压鴉鸦啞哑亞亚煙烟嚴严鹽盐顏颜豔艳驗验陽阳楊杨揚扬養养樣样藥药搖摇堯尧遙遥葉叶" +
+	"頁页業业醫医儀仪億亿憶忆藝艺譯译義义議议異异陰阴銀银隱隐飲饮應应營营嬰婴鷹鹰櫻樱" +
+	"贏赢穎颖擁拥傭佣優优憂忧郵邮猶犹遊游誘诱於于魚鱼漁渔與与語语預预譽誉獄狱園园圓圆" +
+	"員员遠远願愿約约躍跃閱阅嶽岳雲云運运韻韵雜杂災灾載载暫暂讚赞贊赞髒脏鑿凿棗枣竈灶" +
+	"責责擇择賊贼澤泽贈赠紮扎閘闸詐诈齋斋債债戰战佔占張张漲涨帳帐賬账趙赵這这針针貞贞" +
+	"鎮镇陣阵爭争徵征證证鄭郑隻只織织職职執执紙纸誌志製制質质鐘钟鍾钟種种眾众週周軸轴" +
+	"晝昼皺皱豬猪諸诸燭烛囑嘱築筑註注鑄铸駐驻專专磚砖轉转莊庄裝装壯壮狀状樁桩錐锥準准" +
+	"濁浊資资綜综總总縱纵鄒邹組组鑽钻傑杰蘇苏"
+
+var (
+	simpOnce  sync.Once
+	simpTable map[rune]rune
+)
+
+func loadSimpTable() {
+	simpTable = make(map[rune]rune, 1024)
+	runes := []rune(tradSimpPairs)
+	for i := 0; i+1 < len(runes); i += 2 {
+		if runes[i] != runes[i+1] {
+			simpTable[runes[i]] = runes[i+1]
+		}
+	}
+}
+
+func simplifyRune(r rune) rune {
+	simpOnce.Do(loadSimpTable)
+	if s, ok := simpTable[r]; ok {
+		return s
+	}
+	return r
+}
+
+// SimplifyRune 单字繁体转简体，未收录的字符原样返回
+func SimplifyRune(r rune) rune {
+	return simplifyRune(r)
+}
+
+// ToSimplified 繁体转简体（仅覆盖常用字，未收录的字符保持不变）
+func ToSimplified(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		b.WriteRune(simplifyRune(r))
+	}
+	return b.String()
+}
@@ -0,0 +1,248 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"strings"
+	"time"
+)
+
+// ErrNoEXIF 图片不含 EXIF 信息
+var ErrNoEXIF = errors.New("no exif data")
+
+// Metadata 从 EXIF 中提取的拍摄信息
+type Metadata struct {
+	TakenAt     *time.Time
+	Lat         float64
+	Lng         float64
+	HasGPS      bool
+	Orientation int
+}
+
+const (
+	tagOrientation       = 0x0112
+	tagDateTime          = 0x0132
+	tagExifIFD           = 0x8769
+	tagGPSIFD            = 0x8825
+	tagDateTimeOriginal  = 0x9003
+	tagOffsetTimeOrig    = 0x9011
+	tagGPSLatitudeRef    = 0x0001
+	tagGPSLatitude       = 0x0002
+	tagGPSLongitudeRef   = 0x0003
+	tagGPSLongitude      = 0x0004
+	exifTypeASCII        = 2
+	exifTypeShort        = 3
+	exifTypeLong         = 4
+	exifTypeRational     = 5
+	exifDateTimeLayout   = "2006:01:02 15:04:05"
+	exifMaxIFDEntries    = 512
+	jpegMarkerAPP1       = 0xE1
+	jpegMarkerStartOfImg = 0xD8
+	jpegMarkerStartScan  = 0xDA
+)
+
+// ReadEXIF 解析 JPEG 中的 EXIF，提取拍摄时间、GPS 坐标和方向
+func ReadEXIF(data []byte) (*Metadata, error) {
+	tiff := findEXIF(data)
+	if tiff == nil {
+		return nil, ErrNoEXIF
+	}
+
+	r, err := newTIFFReader(tiff)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := &Metadata{}
+	ifd0 := r.readIFD(r.order.Uint32(tiff[4:8]))
+
+	if e, ok := ifd0[tagOrientation]; ok {
+		meta.Orientation = int(r.uint(e))
+	}
+
+	var taken, offset string
+	if e, ok := ifd0[tagDateTime]; ok {
+		taken = r.ascii(e)
+	}
+	if e, ok := ifd0[tagExifIFD]; ok {
+		exif := r.readIFD(r.uint(e))
+		if e, ok := exif[tagDateTimeOriginal]; ok {
+			taken = r.ascii(e)
+		}
+		if e, ok := exif[tagOffsetTimeOrig]; ok {
+			offset = r.ascii(e)
+		}
+	}
+	if t, ok := parseEXIFTime(taken, offset); ok {
+		meta.TakenAt = &t
+	}
+
+	if e, ok := ifd0[tagGPSIFD]; ok {
+		gps := r.readIFD(r.uint(e))
+		lat, latOK := r.degrees(gps[tagGPSLatitude])
+		lng, lngOK := r.degrees(gps[tagGPSLongitude])
+		if latOK && lngOK && (lat != 0 || lng != 0) {
+			if strings.EqualFold(r.ascii(gps[tagGPSLatitudeRef]), "S") {
+				lat = -lat
+			}
+			if strings.EqualFold(r.ascii(gps[tagGPSLongitudeRef]), "W") {
+				lng = -lng
+			}
+			if lat >= -90 && lat <= 90 && lng >= -180 && lng <= 180 {
+				meta.Lat, meta.Lng, meta.HasGPS = lat, lng, true
+			}
+		}
+	}
+
+	return meta, nil
+}
+
+// findEXIF 在 JPEG 段中查找 APP1 Exif 段，返回 TIFF 数据
+func findEXIF(data []byte) []byte {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != jpegMarkerStartOfImg {
+		return nil
+	}
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return nil
+		}
+		marker := data[i+1]
+		if marker == jpegMarkerStartScan {
+			return nil
+		}
+		size := int(binary.BigEndian.Uint16(data[i+2 : i+4]))
+		if size < 2 || i+2+size > len(data) {
+			return nil
+		}
+		seg := data[i+4 : i+2+size]
+		if marker == jpegMarkerAPP1 && bytes.HasPrefix(seg, []byte("Exif\x00\x00")) {
+			return seg[6:]
+		}
+		i += 2 + size
+	}
+	return nil
+}
+
+// ifdEntry IFD 条目
+type ifdEntry struct {
+	typ   uint16
+	count uint32
+	value []byte // 4 字节值或偏移
+}
+
+// tiffReader TIFF 结构读取器，所有越界访问均安全返回零值
+type tiffReader struct {
+	data  []byte
+	order binary.ByteOrder
+}
+
+func newTIFFReader(data []byte) (*tiffReader, error) {
+	if len(data) < 8 {
+		return nil, ErrNoEXIF
+	}
+	var order binary.ByteOrder
+	switch string(data[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return nil, ErrNoEXIF
+	}
+	if order.Uint16(data[2:4]) != 42 {
+		return nil, ErrNoEXIF
+	}
+	return &tiffReader{data: data, order: order}, nil
+}
+
+func (r *tiffReader) readIFD(offset uint32) map[uint16]ifdEntry {
+	entries := make(map[uint16]ifdEntry)
+	if uint64(offset)+2 > uint64(len(r.data)) {
+		return entries
+	}
+	n := int(r.order.Uint16(r.data[offset:]))
+	if n > exifMaxIFDEntries {
+		return entries
+	}
+	pos := int(offset) + 2
+	for i := 0; i < n && pos+12 <= len(r.data); i++ {
+		e := r.data[pos : pos+12]
+		entries[r.order.Uint16(e[0:2])] = ifdEntry{
+			typ:   r.order.Uint16(e[2:4]),
+			count: r.order.Uint32(e[4:8]),
+			value: e[8:12],
+		}
+		pos += 12
+	}
+	return entries
+}
+
+// raw 返回条目数据，超过 4 字节时按偏移读取
+func (r *tiffReader) raw(e ifdEntry, size int) []byte {
+	total := uint64(e.count) * uint64(size)
+	if total <= 4 {
+		return e.value[:total]
+	}
+	off := uint64(r.order.Uint32(e.value))
+	if off+total > uint64(len(r.data)) {
+		return nil
+	}
+	return r.data[off : off+total]
+}
+
+func (r *tiffReader) uint(e ifdEntry) uint32 {
+	switch e.typ {
+	case exifTypeShort:
+		return uint32(r.order.Uint16(e.value))
+	case exifTypeLong:
+		return r.order.Uint32(e.value)
+	}
+	return 0
+}
+
+func (r *tiffReader) ascii(e ifdEntry) string {
+	if e.typ != exifTypeASCII {
+		return ""
+	}
+	b := r.raw(e, 1)
+	return strings.TrimSpace(strings.TrimRight(string(b), "\x00"))
+}
+
+// degrees 将度/分/秒三个有理数转换为十进制度数
+func (r *tiffReader) degrees(e ifdEntry) (float64, bool) {
+	if e.typ != exifTypeRational || e.count < 3 {
+		return 0, false
+	}
+	b := r.raw(ifdEntry{typ: e.typ, count: 3, value: e.value}, 8)
+	if b == nil {
+		return 0, false
+	}
+	var parts [3]float64
+	for i := range parts {
+		num := r.order.Uint32(b[i*8:])
+		den := r.order.Uint32(b[i*8+4:])
+		if den == 0 {
+			return 0, false
+		}
+		parts[i] = float64(num) / float64(den)
+	}
+	return parts[0] + parts[1]/60 + parts[2]/3600, true
+}
+
+// parseEXIFTime 解析 EXIF 时间，无时区信息时按本地时区处理
+func parseEXIFTime(value, offset string) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, false
+	}
+	if offset != "" {
+		if t, err := time.Parse(exifDateTimeLayout+"-07:00", value+offset); err == nil {
+			return t, true
+		}
+	}
+	t, err := time.ParseInLocation(exifDateTimeLayout, value, time.Local)
+	if err != nil || t.Year() < 1900 {
+		return time.Time{}, false
+	}
+	return t, true
+}
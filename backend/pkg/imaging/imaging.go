@@ -0,0 +1,201 @@
+// Package imaging 提供不依赖外部服务的图片处理：解码、缩放、方向校正、水印、感知哈希及 EXIF 解析
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"math"
+
+	// 注册解码器
+	_ "image/gif"
+	_ "image/png"
+)
+
+// MaxPixels 允许解码的最大像素数，防止解压炸弹
+const MaxPixels = 50_000_000
+
+var (
+	ErrUnsupported = errors.New("unsupported image format")
+	ErrTooLarge    = errors.New("image dimensions too large")
+)
+
+// Decode 解码图片，返回图片及格式（jpeg/png/gif）
+func Decode(data []byte) (image.Image, string, error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", ErrUnsupported
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > MaxPixels {
+		return nil, "", ErrTooLarge
+	}
+
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", err
+	}
+	return img, format, nil
+}
+
+// EncodeJPEG 编码为 JPEG；重新编码不会携带任何原始元数据
+func EncodeJPEG(img image.Image, quality int) ([]byte, error) {
+	if quality <= 0 || quality > 100 {
+		quality = jpeg.DefaultQuality
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, flatten(img), &jpeg.Options{Quality: quality}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Fit 等比缩小到最长边不超过 maxSide，不放大
+func Fit(img image.Image, maxSide int) image.Image {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if maxSide <= 0 || (w <= maxSide && h <= maxSide) {
+		return img
+	}
+	if w >= h {
+		h = max(1, h*maxSide/w)
+		w = maxSide
+	} else {
+		w = max(1, w*maxSide/h)
+		h = maxSide
+	}
+	return Resize(img, w, h)
+}
+
+// Resize 使用区域平均缩放到指定尺寸，按源像素覆盖比例加权，缩小时不会产生明显锯齿
+func Resize(img image.Image, width, height int) *image.RGBA {
+	src := toRGBA(img)
+	sb := src.Bounds()
+	sw, sh := sb.Dx(), sb.Dy()
+	xw := boxWeights(sw, width)
+	yw := boxWeights(sh, height)
+
+	// 先水平后垂直的可分离滤波
+	tmp := make([]float64, width*sh*4)
+	for y := 0; y < sh; y++ {
+		row := src.Pix[src.PixOffset(sb.Min.X, sb.Min.Y+y):]
+		for x, ws := range xw {
+			t := tmp[(y*width+x)*4:]
+			for _, w := range ws {
+				p := row[w.index*4:]
+				t[0] += float64(p[0]) * w.weight
+				t[1] += float64(p[1]) * w.weight
+				t[2] += float64(p[2]) * w.weight
+				t[3] += float64(p[3]) * w.weight
+			}
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y, ws := range yw {
+		for x := 0; x < width; x++ {
+			var c [4]float64
+			for _, w := range ws {
+				t := tmp[(w.index*width+x)*4:]
+				c[0] += t[0] * w.weight
+				c[1] += t[1] * w.weight
+				c[2] += t[2] * w.weight
+				c[3] += t[3] * w.weight
+			}
+			o := dst.PixOffset(x, y)
+			for i := range c {
+				dst.Pix[o+i] = uint8(math.Min(255, c[i]+0.5))
+			}
+		}
+	}
+	return dst
+}
+
+// sampleWeight 源像素及其权重
+type sampleWeight struct {
+	index  int
+	weight float64
+}
+
+// boxWeights 计算目标像素覆盖的源像素及覆盖比例（权重和为 1）
+func boxWeights(srcLen, dstLen int) [][]sampleWeight {
+	scale := float64(srcLen) / float64(dstLen)
+	weights := make([][]sampleWeight, dstLen)
+	for i := range weights {
+		start, end := float64(i)*scale, float64(i+1)*scale
+		for j := int(start); j < srcLen && float64(j) < end; j++ {
+			overlap := math.Min(end, float64(j+1)) - math.Max(start, float64(j))
+			if overlap > 0 {
+				weights[i] = append(weights[i], sampleWeight{index: j, weight: overlap / scale})
+			}
+		}
+	}
+	return weights
+}
+
+// Orient 按 EXIF Orientation（1-8）校正方向，使去除元数据后的图片仍正向显示
+func Orient(img image.Image, orientation int) image.Image {
+	if orientation <= 1 || orientation > 8 {
+		return img
+	}
+
+	src := toRGBA(img)
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+	transpose := orientation >= 5
+
+	dw, dh := w, h
+	if transpose {
+		dw, dh = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // 水平翻转
+				dx, dy = w-1-x, y
+			case 3: // 旋转 180°
+				dx, dy = w-1-x, h-1-y
+			case 4: // 垂直翻转
+				dx, dy = x, h-1-y
+			case 5: // 转置
+				dx, dy = y, x
+			case 6: // 顺时针 90°
+				dx, dy = h-1-y, x
+			case 7: // 反转置
+				dx, dy = h-1-y, w-1-x
+			case 8: // 逆时针 90°
+				dx, dy = y, w-1-x
+			}
+			copy(dst.Pix[dst.PixOffset(dx, dy):dst.PixOffset(dx, dy)+4], src.Pix[src.PixOffset(b.Min.X+x, b.Min.Y+y):])
+		}
+	}
+	return dst
+}
+
+// toRGBA 转换为 RGBA，已是 RGBA 时直接返回
+func toRGBA(img image.Image) *image.RGBA {
+	if rgba, ok := img.(*image.RGBA); ok {
+		return rgba
+	}
+	b := img.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), img, b.Min, draw.Src)
+	return rgba
+}
+
+// flatten 将透明区域铺白底，JPEG 不支持透明通道
+func flatten(img image.Image) image.Image {
+	if _, ok := img.(*image.YCbCr); ok {
+		return img
+	}
+	b := img.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(dst, dst.Bounds(), img, b.Min, draw.Over)
+	return dst
+}
@@ -0,0 +1,170 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"math"
+	"math/bits"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gradient 生成带结构的测试图片
+func gradient(w, h int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			v := uint8((x * 255) / w)
+			if (x/(w/4)+y/(h/4))%2 == 0 {
+				v = 255 - v
+			}
+			img.Set(x, y, color.RGBA{R: v, G: uint8(y * 255 / h), B: 128, A: 255})
+		}
+	}
+	return img
+}
+
+// smooth 生成接近照片的平滑图片
+func smooth(w, h int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			fx, fy := float64(x)/float64(w), float64(y)/float64(h)
+			v := 128 + 80*math.Sin(fx*7+fy*3) + 40*math.Cos(fy*9-fx*2)
+			img.Set(x, y, color.RGBA{R: uint8(math.Max(0, math.Min(255, v))), G: uint8(fy * 255), B: uint8(fx * 255), A: 255})
+		}
+	}
+	return img
+}
+
+// exifJPEG 构造带 EXIF（方向、拍摄时间、GPS）的 JPEG
+func exifJPEG(t *testing.T, img image.Image) []byte {
+	le := binary.LittleEndian
+	tiff := new(bytes.Buffer)
+	w := func(v any) { require.NoError(t, binary.Write(tiff, le, v)) }
+	entry := func(tag, typ uint16, count, value uint32) { w(tag); w(typ); w(count); w(value) }
+
+	// 布局：header(8) | IFD0(3 条目) | ExifIFD(1) | GPS IFD(4) | 数据区
+	const ifd0, exifIFD, gpsIFD = 8, 8 + 2 + 3*12 + 4, 8 + 2 + 3*12 + 4 + 2 + 12 + 4
+	const data = gpsIFD + 2 + 4*12 + 4
+	const dateOff, latOff, lngOff = data, data + 20, data + 44
+
+	tiff.WriteString("II")
+	w(uint16(42))
+	w(uint32(ifd0))
+
+	w(uint16(3))
+	entry(tagOrientation, exifTypeShort, 1, 6)
+	entry(tagExifIFD, exifTypeLong, 1, exifIFD)
+	entry(tagGPSIFD, exifTypeLong, 1, gpsIFD)
+	w(uint32(0))
+
+	w(uint16(1))
+	entry(tagDateTimeOriginal, exifTypeASCII, 20, dateOff)
+	w(uint32(0))
+
+	w(uint16(4))
+	entry(tagGPSLatitudeRef, exifTypeASCII, 2, uint32('N'))
+	entry(tagGPSLatitude, exifTypeRational, 3, latOff)
+	entry(tagGPSLongitudeRef, exifTypeASCII, 2, uint32('E'))
+	entry(tagGPSLongitude, exifTypeRational, 3, lngOff)
+	w(uint32(0))
+
+	tiff.WriteString("2026:10:01 08:30:00\x00")
+	for _, v := range []uint32{39, 1, 54, 1, 36, 1, 116, 1, 24, 1, 0, 1} {
+		w(v)
+	}
+
+	var body bytes.Buffer
+	require.NoError(t, jpeg.Encode(&body, img, nil))
+	raw := body.Bytes()
+
+	app1 := append([]byte("Exif\x00\x00"), tiff.Bytes()...)
+	var out bytes.Buffer
+	out.Write(raw[:2])
+	out.Write([]byte{0xFF, jpegMarkerAPP1})
+	require.NoError(t, binary.Write(&out, binary.BigEndian, uint16(len(app1)+2)))
+	out.Write(app1)
+	out.Write(raw[2:])
+	return out.Bytes()
+}
+
+func TestReadEXIF(t *testing.T) {
+	meta, err := ReadEXIF(exifJPEG(t, gradient(40, 20)))
+	require.NoError(t, err)
+
+	assert.Equal(t, 6, meta.Orientation)
+	assert.True(t, meta.HasGPS)
+	assert.InDelta(t, 39.91, meta.Lat, 0.001)
+	assert.InDelta(t, 116.4, meta.Lng, 0.001)
+	require.NotNil(t, meta.TakenAt)
+	assert.Equal(t, time.Date(2026, 10, 1, 8, 30, 0, 0, time.Local), *meta.TakenAt)
+
+	_, err = ReadEXIF([]byte("not a jpeg"))
+	assert.ErrorIs(t, err, ErrNoEXIF)
+}
+
+func TestPipelineStripsMetadata(t *testing.T) {
+	res, err := NewPipeline(Options{ThumbnailSize: 16, Watermark: "CASE-1"}).Process(exifJPEG(t, gradient(40, 20)))
+	require.NoError(t, err)
+
+	// 方向 6 需要旋转，宽高互换
+	assert.Equal(t, 20, res.Width)
+	assert.Equal(t, 40, res.Height)
+	require.NotNil(t, res.Metadata)
+	assert.True(t, res.Metadata.HasGPS)
+
+	for _, out := range [][]byte{res.Public, res.Thumbnail, res.Share} {
+		_, err := ReadEXIF(out)
+		assert.ErrorIs(t, err, ErrNoEXIF)
+	}
+
+	thumb, _, err := Decode(res.Thumbnail)
+	require.NoError(t, err)
+	assert.Equal(t, 8, thumb.Bounds().Dx())
+	assert.Equal(t, 16, thumb.Bounds().Dy())
+}
+
+func TestPHash(t *testing.T) {
+	src := smooth(256, 192)
+	hash := PHash(src)
+
+	// 63 个交流系数以中位数为界，恰有 31 个高于中位数（第 0 位为直流分量）
+	assert.Equal(t, 31, bits.OnesCount64(hash>>1))
+
+	// 缩放并重新压缩后仍应接近
+	data, err := EncodeJPEG(Resize(src, 128, 96), 75)
+	require.NoError(t, err)
+	resized, _, err := Decode(data)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, HammingDistance(hash, PHash(resized)), DefaultSimilarityThreshold)
+
+	// 旋转后的图片及不同图片应明显不同
+	assert.Greater(t, HammingDistance(hash, PHash(Orient(src, 3))), DefaultSimilarityThreshold)
+	assert.Greater(t, HammingDistance(hash, PHash(gradient(256, 192))), DefaultSimilarityThreshold)
+
+	parsed, err := ParseHash(FormatHash(hash))
+	require.NoError(t, err)
+	assert.Equal(t, hash, parsed)
+}
+
+func TestWatermarkChangesPixels(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 200, 100))
+	out := Watermark(src, "CNTUANYUAN")
+
+	changed := false
+	for i := range out.Pix {
+		if out.Pix[i] != src.Pix[i] {
+			changed = true
+			break
+		}
+	}
+	assert.True(t, changed)
+	assert.Equal(t, src.Bounds(), out.Bounds())
+}
@@ -0,0 +1,82 @@
+package imaging
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"math/bits"
+	"sort"
+	"strconv"
+)
+
+const (
+	// DefaultSimilarityThreshold 汉明距离不超过该值视为同一张照片
+	DefaultSimilarityThreshold = 10
+
+	phashSampleSize = 32
+	phashHashSize   = 8
+)
+
+// PHash 计算 64 位 DCT 感知哈希：缩放为 32x32 灰度图后取低频 8x8 系数与中位数比较
+// 对缩放、重新压缩、轻微调色不敏感，可用于发现不同案件中的相同照片
+func PHash(img image.Image) uint64 {
+	small := Resize(img, phashSampleSize, phashSampleSize)
+
+	var gray [phashSampleSize][phashSampleSize]float64
+	for y := 0; y < phashSampleSize; y++ {
+		for x := 0; x < phashSampleSize; x++ {
+			o := small.PixOffset(x, y)
+			r, g, b := float64(small.Pix[o]), float64(small.Pix[o+1]), float64(small.Pix[o+2])
+			gray[y][x] = 0.299*r + 0.587*g + 0.114*b
+		}
+	}
+
+	// 仅计算需要的低频系数
+	var cos [phashHashSize][phashSampleSize]float64
+	for u := 0; u < phashHashSize; u++ {
+		for x := 0; x < phashSampleSize; x++ {
+			cos[u][x] = math.Cos(float64(2*x+1) * float64(u) * math.Pi / (2 * phashSampleSize))
+		}
+	}
+
+	coeffs := make([]float64, 0, phashHashSize*phashHashSize)
+	for v := 0; v < phashHashSize; v++ {
+		for u := 0; u < phashHashSize; u++ {
+			var sum float64
+			for y := 0; y < phashSampleSize; y++ {
+				for x := 0; x < phashSampleSize; x++ {
+					sum += gray[y][x] * cos[u][x] * cos[v][y]
+				}
+			}
+			coeffs = append(coeffs, sum)
+		}
+	}
+
+	// 直流分量只反映整体亮度，不参与中位数计算；其余 63 个系数个数为奇数，取正中间的一个
+	sorted := append([]float64(nil), coeffs[1:]...)
+	sort.Float64s(sorted)
+	median := sorted[len(sorted)/2]
+
+	var hash uint64
+	for i, c := range coeffs {
+		if c > median {
+			hash |= 1 << uint(i)
+		}
+	}
+	return hash
+}
+
+// HammingDistance 两个哈希不同的位数，越小越相似
+func HammingDistance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// FormatHash 格式化为 16 位十六进制字符串
+func FormatHash(hash uint64) string {
+	return fmt.Sprintf("%016x", hash)
+}
+
+// ParseHash 解析十六进制哈希
+func ParseHash(s string) (uint64, error) {
+	return strconv.ParseUint(s, 16, 64)
+}
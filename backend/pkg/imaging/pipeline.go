@@ -0,0 +1,89 @@
+package imaging
+
+// 默认处理参数
+const (
+	DefaultThumbnailSize = 320
+	DefaultShareSize     = 1080
+	DefaultPublicSize    = 2048
+	DefaultJPEGQuality   = 85
+)
+
+// Options 图片处理参数
+type Options struct {
+	ThumbnailSize int    // 缩略图最长边
+	ShareSize     int    // 分享图最长边
+	PublicSize    int    // 公开图最长边
+	Watermark     string // 分享图水印文字，为空不加水印
+	JPEGQuality   int
+}
+
+// Result 图片处理结果
+type Result struct {
+	Width    int // 方向校正后的原图尺寸
+	Height   int
+	Format   string
+	Metadata *Metadata // 不含 EXIF 时为空
+	PHash    uint64
+
+	Public    []byte // 去除全部元数据的公开版本
+	Thumbnail []byte
+	Share     []byte // 带水印的分享版本
+}
+
+// Pipeline 图片处理流水线
+type Pipeline struct {
+	opts Options
+}
+
+// NewPipeline 创建图片处理流水线，未设置的参数使用默认值
+func NewPipeline(opts Options) *Pipeline {
+	if opts.ThumbnailSize <= 0 {
+		opts.ThumbnailSize = DefaultThumbnailSize
+	}
+	if opts.ShareSize <= 0 {
+		opts.ShareSize = DefaultShareSize
+	}
+	if opts.PublicSize <= 0 {
+		opts.PublicSize = DefaultPublicSize
+	}
+	if opts.JPEGQuality <= 0 {
+		opts.JPEGQuality = DefaultJPEGQuality
+	}
+	return &Pipeline{opts: opts}
+}
+
+// Process 解析元数据并生成公开图、缩略图和分享图
+// 所有输出均重新编码为 JPEG，原图中的 EXIF（含 GPS）不会被带出
+func (p *Pipeline) Process(data []byte) (*Result, error) {
+	img, format, err := Decode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &Result{Format: format}
+	if meta, err := ReadEXIF(data); err == nil {
+		res.Metadata = meta
+		img = Orient(img, meta.Orientation)
+	}
+
+	b := img.Bounds()
+	res.Width, res.Height = b.Dx(), b.Dy()
+	res.PHash = PHash(img)
+
+	public := Fit(img, p.opts.PublicSize)
+	if res.Public, err = EncodeJPEG(public, p.opts.JPEGQuality); err != nil {
+		return nil, err
+	}
+	if res.Thumbnail, err = EncodeJPEG(Fit(public, p.opts.ThumbnailSize), p.opts.JPEGQuality); err != nil {
+		return nil, err
+	}
+	share := Fit(public, p.opts.ShareSize)
+	if p.opts.Watermark != "" {
+		share = Watermark(share, p.opts.Watermark)
+	}
+	if res.Share, err = EncodeJPEG(share, p.opts.JPEGQuality); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
@@ -0,0 +1,52 @@
+package imaging
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+var (
+	watermarkTile   = color.NRGBA{R: 255, G: 255, B: 255, A: 56}
+	watermarkLabel  = color.NRGBA{R: 255, G: 255, B: 255, A: 230}
+	watermarkShadow = color.NRGBA{R: 0, G: 0, B: 0, A: 160}
+)
+
+// Watermark 在图片上平铺半透明文字并在右下角加注标识，用于对外分享的版本
+// 平铺水印可防止被简单裁剪去除
+func Watermark(img image.Image, text string) *image.RGBA {
+	b := img.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(dst, dst.Bounds(), img, b.Min, draw.Src)
+	if text == "" {
+		return dst
+	}
+
+	w, h := dst.Bounds().Dx(), dst.Bounds().Dy()
+	scale := max(1, min(w, h)/160)
+
+	// 交错平铺
+	tw, th := TextWidth(text, scale), TextHeight(scale)
+	stepX, stepY := tw+tw/2, th*6
+	for row, y := 0, th; y < h; row, y = row+1, y+stepY {
+		offset := 0
+		if row%2 == 1 {
+			offset = stepX / 2
+		}
+		for x := -offset; x < w; x += stepX {
+			DrawText(dst, x, y, text, scale, watermarkTile)
+		}
+	}
+
+	// 右下角清晰标识
+	label := max(1, scale*2)
+	lw, lh := TextWidth(text, label), TextHeight(label)
+	margin := lh / 2
+	x, y := w-lw-margin, h-lh-margin
+	if x < 0 {
+		x = 0
+	}
+	DrawText(dst, x+label, y+label, text, label, watermarkShadow)
+	DrawText(dst, x, y, text, label, watermarkLabel)
+	return dst
+}
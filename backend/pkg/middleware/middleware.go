@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/Snowitty-Re/CNtunyuan/pkg/errors"
@@ -195,6 +196,7 @@ func SecurityHeadersMiddleware() gin.HandlerFunc {
 
 // RateLimiter 限流器
 type RateLimiter struct {
+	mu       sync.Mutex
 	limiters map[string]*rate.Limiter
 	limit    rate.Limit
 	burst    int
@@ -211,6 +213,9 @@ func NewRateLimiter(rps float64, burst int) *RateLimiter {
 
 // getLimiter 获取或创建限流器
 func (rl *RateLimiter) getLimiter(key string) *rate.Limiter {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
 	if limiter, ok := rl.limiters[key]; ok {
 		return limiter
 	}
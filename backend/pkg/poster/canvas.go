@@ -0,0 +1,139 @@
+package poster
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+
+	"github.com/Snowitty-Re/CNtunyuan/pkg/cjkfont"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/imaging"
+	"github.com/go-pdf/fpdf"
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// canvas 版式绘制目标，坐标原点为左上角，文本坐标为基线起点
+type canvas interface {
+	fillRect(x, y, w, h float64, c color.Color)
+	text(x, y, size float64, s string, c color.Color)
+	measure(s string, size float64) float64
+	image(p *photo, x, y, w, h float64)
+}
+
+// photo 待绘制的照片
+type photo struct {
+	img  image.Image
+	data []byte
+	jpeg bool
+}
+
+// pdfCanvas PDF 画布，单位为 pt
+type pdfCanvas struct {
+	doc    *fpdf.Fpdf
+	images int
+	err    error
+}
+
+func (c *pdfCanvas) fillRect(x, y, w, h float64, col color.Color) {
+	r, g, b := rgb(col)
+	c.doc.SetFillColor(r, g, b)
+	c.doc.Rect(x, y, w, h, "F")
+}
+
+func (c *pdfCanvas) text(x, y, size float64, s string, col color.Color) {
+	r, g, b := rgb(col)
+	c.doc.SetTextColor(r, g, b)
+	c.doc.SetFontSize(size)
+	c.doc.Text(x, y, s)
+}
+
+func (c *pdfCanvas) measure(s string, size float64) float64 {
+	c.doc.SetFontSize(size)
+	return c.doc.GetStringWidth(s)
+}
+
+func (c *pdfCanvas) image(p *photo, x, y, w, h float64) {
+	data := p.data
+	if !p.jpeg {
+		// PDF 只直接嵌入 JPEG，其它格式重新编码
+		encoded, err := imaging.EncodeJPEG(p.img, imaging.DefaultJPEGQuality)
+		if err != nil {
+			c.err = err
+			return
+		}
+		data = encoded
+	}
+	c.images++
+	name := fmt.Sprintf("photo%d", c.images)
+	opts := fpdf.ImageOptions{ImageType: "JPG"}
+	c.doc.RegisterImageOptionsReader(name, opts, bytes.NewReader(data))
+	c.doc.ImageOptions(name, x, y, w, h, false, opts, 0, "")
+}
+
+// rasterCanvas 位图画布，单位为像素
+type rasterCanvas struct {
+	img   *image.RGBA
+	font  *cjkfont.Font
+	faces map[float64]font.Face
+	err   error
+}
+
+func (c *rasterCanvas) face(size float64) font.Face {
+	f, ok := c.faces[size]
+	if !ok {
+		var err error
+		if f, err = c.font.Face(size); err != nil {
+			c.err = err
+			return nil
+		}
+		c.faces[size] = f
+	}
+	return f
+}
+
+func (c *rasterCanvas) fillRect(x, y, w, h float64, col color.Color) {
+	// 四舍五入到整像素，相邻矩形（如二维码模块）边界一致
+	r := image.Rect(int(math.Round(x)), int(math.Round(y)), int(math.Round(x+w)), int(math.Round(y+h)))
+	draw.Draw(c.img, r, image.NewUniform(col), image.Point{}, draw.Over)
+}
+
+func (c *rasterCanvas) text(x, y, size float64, s string, col color.Color) {
+	face := c.face(size)
+	if face == nil {
+		return
+	}
+	d := font.Drawer{
+		Dst:  c.img,
+		Src:  image.NewUniform(col),
+		Face: face,
+		Dot:  fixed.Point26_6{X: fixed.Int26_6(x * 64), Y: fixed.Int26_6(y * 64)},
+	}
+	d.DrawString(s)
+}
+
+func (c *rasterCanvas) measure(s string, size float64) float64 {
+	face := c.face(size)
+	if face == nil {
+		return 0
+	}
+	return float64(font.MeasureString(face, s)) / 64
+}
+
+func (c *rasterCanvas) image(p *photo, x, y, w, h float64) {
+	iw, ih := int(math.Round(w)), int(math.Round(h))
+	if iw <= 0 || ih <= 0 {
+		return
+	}
+	scaled := imaging.Resize(p.img, iw, ih)
+	ox, oy := int(math.Round(x)), int(math.Round(y))
+	draw.Draw(c.img, image.Rect(ox, oy, ox+iw, oy+ih), scaled, image.Point{}, draw.Src)
+}
+
+// rgb 颜色转为 0-255 分量
+func rgb(c color.Color) (int, int, int) {
+	r, g, b, _ := c.RGBA()
+	return int(r >> 8), int(g >> 8), int(b >> 8)
+}
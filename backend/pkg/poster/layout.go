@@ -0,0 +1,204 @@
+package poster
+
+import (
+	"image/color"
+	"strings"
+)
+
+var (
+	colorBackground  = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	colorAccent      = color.RGBA{R: 196, G: 30, B: 36, A: 255}
+	colorText        = color.RGBA{R: 33, G: 33, B: 33, A: 255}
+	colorMuted       = color.RGBA{R: 110, G: 110, B: 110, A: 255}
+	colorPlaceholder = color.RGBA{R: 235, G: 235, B: 235, A: 255}
+	colorOnAccent    = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+)
+
+// qrCaption 二维码说明
+const qrCaption = "扫码查看详情、提供线索"
+
+// render 绘制海报：标题栏、照片与简要信息、详细信息、联系方式与二维码、页脚
+// 所有尺寸按页面宽度等比计算，PDF（pt）与 PNG（像素）共用同一版式
+func render(c canvas, w, h float64, content *Content, p *photo, qr [][]bool) {
+	u := w / 30 // 基准字号
+	m := u * 1.5
+
+	c.fillRect(0, 0, w, h, colorBackground)
+
+	// 标题栏
+	title := content.Title
+	if title == "" {
+		title = DefaultTitle
+	}
+	headerH := u * 4.2
+	c.fillRect(0, 0, w, headerH, colorAccent)
+	titleSize := u * 2.6
+	c.text((w-c.measure(title, titleSize))/2, headerH/2+titleSize*0.36, titleSize, title, colorOnAccent)
+
+	// 照片
+	y := headerH + m
+	photoW := (w - 2*m) * 0.42
+	photoH := photoW * 4 / 3
+	c.fillRect(m, y, photoW, photoH, colorPlaceholder)
+	if p != nil {
+		b := p.img.Bounds()
+		dw, dh := fitInside(float64(b.Dx()), float64(b.Dy()), photoW, photoH)
+		c.image(p, m+(photoW-dw)/2, y+(photoH-dh)/2, dw, dh)
+	} else {
+		hint := "暂无照片"
+		c.text(m+(photoW-c.measure(hint, u))/2, y+photoH/2+u*0.36, u, hint, colorMuted)
+	}
+
+	// 姓名与简要信息
+	x := m + photoW + m*0.8
+	colW := w - m - x
+	nameSize := u * 2.2
+	ty := y + nameSize
+	c.text(x, ty, nameSize, truncate(c, content.Name, nameSize, colW), colorText)
+	ty += u * 0.6
+	for _, f := range content.Summary {
+		ty = drawField(c, x, ty, colW, u, f, y+photoH)
+	}
+
+	// 页脚
+	footerH := u * 2.4
+	c.fillRect(0, h-footerH, w, footerH, colorAccent)
+	if content.Org != "" {
+		footSize := u * 0.9
+		org := truncate(c, content.Org, footSize, w-2*m)
+		c.text((w-c.measure(org, footSize))/2, h-footerH/2+footSize*0.36, footSize, org, colorOnAccent)
+	}
+
+	// 联系方式与二维码
+	qrSize := u * 7.5
+	bottomTop := h - footerH - m*0.6 - qrSize - u*1.2
+	if qr != nil {
+		qx := w - m - qrSize
+		drawQR(c, qr, qx, bottomTop, qrSize)
+		capSize := u * 0.7
+		caption := truncate(c, qrCaption, capSize, qrSize+m)
+		c.text(qx+(qrSize-c.measure(caption, capSize))/2, bottomTop+qrSize+u*0.9, capSize, caption, colorMuted)
+	}
+	contactW := w - 2*m
+	if qr != nil {
+		contactW -= qrSize + m*0.6
+	}
+	cy := bottomTop + u*1.3
+	c.text(m, cy, u*1.3, "如有线索，请立即联系", colorAccent)
+	cy += u * 0.4
+	for _, f := range content.Contacts {
+		cy = drawField(c, m, cy, contactW, u*1.1, f, h-footerH)
+	}
+	if content.CaseNo != "" {
+		c.text(m, h-footerH-m*0.5, u*0.7, "案件编号："+content.CaseNo, colorMuted)
+	}
+
+	// 详细信息占用照片与联系区之间的空间
+	y += photoH + m*0.6
+	c.fillRect(m, bottomTop-m*0.5, w-2*m, u*0.08, colorPlaceholder)
+	for _, f := range content.Details {
+		y = drawField(c, m, y, w-2*m, u, f, bottomTop-m*0.6)
+	}
+}
+
+// drawField 绘制“标签：内容”并自动换行，超出 limit 的内容截断并以省略号结尾，返回下一行起点
+func drawField(c canvas, x, y, width, size float64, f Field, limit float64) float64 {
+	if strings.TrimSpace(f.Value) == "" {
+		return y
+	}
+	lineH := size * 1.55
+	if y+lineH > limit {
+		return y
+	}
+
+	label := f.Label + "："
+	labelW := c.measure(label, size)
+	lines := wrap(c, strings.Join(strings.Fields(f.Value), " "), size, width-labelW)
+
+	for i, line := range lines {
+		y += lineH
+		last := y+lineH > limit
+		if last && i < len(lines)-1 {
+			line = truncate(c, line+"……", size, width-labelW)
+		}
+		if i == 0 {
+			c.text(x, y, size, label, colorAccent)
+		}
+		c.text(x+labelW, y, size, line, colorText)
+		if last {
+			break
+		}
+	}
+	return y
+}
+
+// wrap 按宽度逐字换行
+func wrap(c canvas, s string, size, width float64) []string {
+	var lines []string
+	var cur strings.Builder
+	var curW float64
+	for _, r := range s {
+		rw := c.measure(string(r), size)
+		if curW+rw > width && cur.Len() > 0 {
+			lines = append(lines, cur.String())
+			cur.Reset()
+			curW = 0
+			if r == ' ' {
+				continue
+			}
+		}
+		cur.WriteRune(r)
+		curW += rw
+	}
+	if cur.Len() > 0 {
+		lines = append(lines, cur.String())
+	}
+	return lines
+}
+
+// truncate 超出宽度时截断并以省略号结尾
+func truncate(c canvas, s string, size, width float64) string {
+	if c.measure(s, size) <= width {
+		return s
+	}
+	runes := []rune(strings.TrimSuffix(s, "……"))
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		if t := string(runes) + "…"; c.measure(t, size) <= width {
+			return t
+		}
+	}
+	return ""
+}
+
+// drawQR 绘制二维码模块矩阵，同一行相邻的深色模块合并为一个矩形
+func drawQR(c canvas, qr [][]bool, x, y, size float64) {
+	const quiet = 2
+	module := size / float64(len(qr)+2*quiet)
+	c.fillRect(x, y, size, size, colorBackground)
+	for row, modules := range qr {
+		for col := 0; col < len(modules); {
+			if !modules[col] {
+				col++
+				continue
+			}
+			start := col
+			for col < len(modules) && modules[col] {
+				col++
+			}
+			c.fillRect(x+float64(start+quiet)*module, y+float64(row+quiet)*module, float64(col-start)*module, module, colorText)
+		}
+	}
+}
+
+// fitInside 等比缩放到框内
+func fitInside(w, h, boxW, boxH float64) (float64, float64) {
+	if w <= 0 || h <= 0 {
+		return boxW, boxH
+	}
+	scale := boxW / w
+	if h*scale > boxH {
+		scale = boxH / h
+	}
+	return w * scale, h * scale
+}
@@ -0,0 +1,131 @@
+// Package poster 生成寻人海报：A4 打印版 PDF 与朋友圈分享卡片 PNG，版式一致，内嵌跳转案件页的二维码
+package poster
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/png"
+
+	"github.com/Snowitty-Re/CNtunyuan/pkg/cjkfont"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/imaging"
+	"github.com/go-pdf/fpdf"
+	"github.com/skip2/go-qrcode"
+	"golang.org/x/image/font"
+)
+
+// 分享卡片尺寸（3:4，适合朋友圈）
+const (
+	ShareCardWidth  = 1080
+	ShareCardHeight = 1440
+)
+
+// DefaultTitle 默认标题
+const DefaultTitle = "寻人启事"
+
+// ErrFontUnavailable 未加载中文字体，无法生成海报
+var ErrFontUnavailable = errors.New("poster: font unavailable")
+
+// Field 信息项
+type Field struct {
+	Label string
+	Value string
+}
+
+// Content 海报内容
+type Content struct {
+	Title    string
+	Name     string
+	Photo    []byte  // JPEG/PNG，为空时显示占位
+	Summary  []Field // 照片右侧的简要信息（性别年龄、身高、走失时间）
+	Details  []Field // 照片下方的详细信息（走失地点、体貌特征、衣着），超出版面时截断
+	Contacts []Field // 联系方式
+	Org      string  // 发布组织，显示在页脚
+	CaseNo   string
+	URL      string // 二维码链接（公开案件页）
+}
+
+// Renderer 海报渲染器
+type Renderer struct {
+	font *cjkfont.Font
+}
+
+// NewRenderer 创建海报渲染器；font 为空时 PDF、PNG 均无法生成
+func NewRenderer(font *cjkfont.Font) *Renderer {
+	return &Renderer{font: font}
+}
+
+// PDF 生成 A4 打印版，字体以子集方式嵌入
+func (r *Renderer) PDF(content *Content) ([]byte, error) {
+	if r.font == nil {
+		return nil, ErrFontUnavailable
+	}
+	photo, qr, err := prepare(content)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := fpdf.New("P", "pt", "A4", "")
+	doc.SetAutoPageBreak(false, 0)
+	r.font.Register(doc)
+	doc.SetFont(cjkfont.Family, "", 12)
+	doc.AddPage()
+	w, h := doc.GetPageSize()
+
+	c := &pdfCanvas{doc: doc}
+	render(c, w, h, content, photo, qr)
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	var buf bytes.Buffer
+	if err := doc.Output(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// PNG 生成分享卡片
+func (r *Renderer) PNG(content *Content) ([]byte, error) {
+	if r.font == nil {
+		return nil, ErrFontUnavailable
+	}
+	photo, qr, err := prepare(content)
+	if err != nil {
+		return nil, err
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, ShareCardWidth, ShareCardHeight))
+	c := &rasterCanvas{img: img, font: r.font, faces: make(map[float64]font.Face)}
+	render(c, ShareCardWidth, ShareCardHeight, content, photo, qr)
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// prepare 解码照片并生成二维码模块矩阵（不含留白）；照片无法解码时按无照片处理
+func prepare(content *Content) (*photo, [][]bool, error) {
+	var p *photo
+	if len(content.Photo) > 0 {
+		if img, format, err := imaging.Decode(content.Photo); err == nil {
+			p = &photo{img: img, data: content.Photo, jpeg: format == "jpeg"}
+		}
+	}
+
+	var qr [][]bool
+	if content.URL != "" {
+		code, err := qrcode.New(content.URL, qrcode.Medium)
+		if err != nil {
+			return nil, nil, err
+		}
+		code.DisableBorder = true
+		qr = code.Bitmap()
+	}
+	return p, qr, nil
+}
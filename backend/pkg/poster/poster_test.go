@@ -0,0 +1,101 @@
+package poster
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/Snowitty-Re/CNtunyuan/pkg/cjkfont"
+	"github.com/Snowitty-Re/CNtunyuan/pkg/imaging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+// testRenderer 使用 Go 字体代替中文字体，中文字符绘制为缺字框，不影响版式
+func testRenderer(t *testing.T) *Renderer {
+	f, err := cjkfont.Parse(goregular.TTF)
+	require.NoError(t, err)
+	return NewRenderer(f)
+}
+
+func testContent(t *testing.T) *Content {
+	img := image.NewRGBA(image.Rect(0, 0, 300, 400))
+	for y := 0; y < 400; y++ {
+		for x := 0; x < 300; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	photo, err := imaging.EncodeJPEG(img, 80)
+	require.NoError(t, err)
+
+	return &Content{
+		Name:  "张三",
+		Photo: photo,
+		Summary: []Field{
+			{Label: "性别年龄", Value: "男 8岁"},
+			{Label: "走失时间", Value: "2024-05-01 14:00"},
+		},
+		Details: []Field{
+			{Label: "走失地点", Value: "广东省深圳市南山区"},
+			{Label: "体貌特征", Value: "左眉有痣，说话带潮汕口音，身高约 130cm，短发，偏瘦"},
+		},
+		Contacts: []Field{{Label: "联系人", Value: "李四 400-000-0000"}},
+		Org:      "团圆寻亲志愿者协会",
+		CaseNo:   "MP20240501001",
+		URL:      "https://example.com/public/cases/1",
+	}
+}
+
+func TestRenderer_PDF(t *testing.T) {
+	data, err := testRenderer(t).PDF(testContent(t))
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(data, []byte("%PDF-")))
+	assert.Contains(t, string(data), "/Subtype /Image")
+	assert.Contains(t, string(data), "/FontFile2")
+	assert.True(t, bytes.HasSuffix(bytes.TrimSpace(data), []byte("%%EOF")))
+}
+
+func TestRenderer_PDFWithoutPhoto(t *testing.T) {
+	content := testContent(t)
+	content.Photo = []byte("not an image")
+	data, err := testRenderer(t).PDF(content)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "/Subtype /Image")
+}
+
+func TestRenderer_PNG(t *testing.T) {
+	data, err := testRenderer(t).PNG(testContent(t))
+	require.NoError(t, err)
+
+	img, err := png.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, ShareCardWidth, ShareCardHeight), img.Bounds())
+	assert.Equal(t, color.RGBAModel.Convert(colorAccent), color.RGBAModel.Convert(img.At(10, 10)))
+}
+
+func TestRenderer_WithoutFont(t *testing.T) {
+	r := NewRenderer(nil)
+	_, err := r.PDF(testContent(t))
+	assert.ErrorIs(t, err, ErrFontUnavailable)
+	_, err = r.PNG(testContent(t))
+	assert.ErrorIs(t, err, ErrFontUnavailable)
+}
+
+type measureCanvas struct{}
+
+func (measureCanvas) fillRect(x, y, w, h float64, c color.Color)       {}
+func (measureCanvas) text(x, y, size float64, s string, c color.Color) {}
+func (measureCanvas) image(p *photo, x, y, w, h float64)               {}
+func (measureCanvas) measure(s string, size float64) float64 {
+	return float64(len([]rune(s))) * size
+}
+
+func TestWrapAndTruncate(t *testing.T) {
+	c := measureCanvas{}
+	assert.Equal(t, []string{"一二三", "四五六", "七"}, wrap(c, "一二三四五六七", 10, 30))
+	assert.Equal(t, "一二…", truncate(c, "一二三四", 10, 30))
+	assert.Equal(t, "一二", truncate(c, "一二", 10, 30))
+}
@@ -0,0 +1,274 @@
+// Package search 纯 Go 实现的中文全文检索：倒排索引、中文分词、拼音及首字母匹配、BM25 排序与高亮摘要
+package search
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/Snowitty-Re/CNtunyuan/pkg/hanzi"
+)
+
+// 词项前缀，用于区分同一倒排表中不同类型的词项
+const (
+	wordPrefix     = "w:"   // 词典分词得到的词
+	pinyinPrefix   = "py:"  // 连续汉字的全拼
+	syllablePrefix = "pys:" // 单字拼音
+	initialsPrefix = "pyi:" // 连续汉字的拼音首字母
+)
+
+// maxWordRunes 词典中最长词的字数
+const maxWordRunes = 6
+
+// token 词项及其在字段原文中的位置（按字符计）
+type token struct {
+	term  string
+	start int
+	end   int
+}
+
+// normalize 繁转简、全角转半角、字母转小写，输出与原文逐字对应
+func normalize(s string) []rune {
+	runes := []rune(hanzi.ToSimplified(s))
+	for i, r := range runes {
+		switch {
+		case r == 0x3000:
+			r = ' '
+		case r >= 0xFF01 && r <= 0xFF5E:
+			r -= 0xFEE0
+		}
+		runes[i] = unicode.ToLower(r)
+	}
+	return runes
+}
+
+// isWordRune 字母或数字（不含汉字）
+func isWordRune(r rune) bool {
+	return (unicode.IsLetter(r) || unicode.IsDigit(r)) && !hanzi.IsHan(r)
+}
+
+// analyze 切分字段文本，返回索引词项和字段长度（汉字数 + 词数）
+// 汉字按单字、二元组及词典词建索引，字母数字按整词建索引，withPinyin 时额外生成拼音词项
+func analyze(runes []rune, withPinyin bool) ([]token, int) {
+	var tokens []token
+	length := 0
+	for i := 0; i < len(runes); {
+		switch {
+		case hanzi.IsHan(runes[i]):
+			j := i
+			for j < len(runes) && hanzi.IsHan(runes[j]) {
+				j++
+			}
+			tokens = appendHan(tokens, runes, i, j, withPinyin)
+			length += j - i
+			i = j
+		case isWordRune(runes[i]):
+			j := i
+			for j < len(runes) && isWordRune(runes[j]) {
+				j++
+			}
+			tokens = appendWord(tokens, runes, i, j)
+			length++
+			i = j
+		default:
+			i++
+		}
+	}
+	return tokens, length
+}
+
+// appendHan 连续汉字 [start, end) 的词项
+func appendHan(tokens []token, runes []rune, start, end int, withPinyin bool) []token {
+	for i := start; i < end; i++ {
+		tokens = append(tokens, token{string(runes[i]), i, i + 1})
+		if i+1 < end {
+			tokens = append(tokens, token{string(runes[i : i+2]), i, i + 2})
+		}
+	}
+	for _, w := range segment(runes[start:end]) {
+		tokens = append(tokens, token{wordPrefix + w.term, start + w.start, start + w.end})
+	}
+	if !withPinyin {
+		return tokens
+	}
+
+	var full, initials strings.Builder
+	for i := start; i < end; i++ {
+		syllable, ok := hanzi.RunePinyin(runes[i])
+		if !ok {
+			initial := hanzi.RuneInitial(runes[i])
+			if initial == 0 {
+				// 无法转换的字打断拼音，只保留已生成的单字拼音
+				full.Reset()
+				break
+			}
+			syllable = string(initial)
+		}
+		tokens = append(tokens, token{syllablePrefix + syllable, i, i + 1})
+		full.WriteString(syllable)
+		initials.WriteByte(syllable[0])
+	}
+	if full.Len() > 0 {
+		tokens = append(tokens,
+			token{pinyinPrefix + full.String(), start, end},
+			token{initialsPrefix + initials.String(), start, end},
+		)
+	}
+	return tokens
+}
+
+// appendWord 字母数字词 [start, end) 的词项，字母与数字混排时额外拆分，如 170cm -> 170、cm
+func appendWord(tokens []token, runes []rune, start, end int) []token {
+	tokens = append(tokens, token{string(runes[start:end]), start, end})
+	i := start
+	for i < end {
+		j := i + 1
+		for j < end && unicode.IsDigit(runes[j]) == unicode.IsDigit(runes[i]) {
+			j++
+		}
+		if i == start && j == end {
+			break
+		}
+		tokens = append(tokens, token{string(runes[i:j]), i, j})
+		i = j
+	}
+	return tokens
+}
+
+// segment 正向最大匹配分词，只返回词典中的多字词
+func segment(runes []rune) []token {
+	var words []token
+	for i := 0; i < len(runes); {
+		matched := 0
+		for n := min(maxWordRunes, len(runes)-i); n >= 2; n-- {
+			if _, ok := dictionary()[string(runes[i:i+n])]; ok {
+				matched = n
+				break
+			}
+		}
+		if matched == 0 {
+			i++
+			continue
+		}
+		words = append(words, token{string(runes[i : i+matched]), i, i + matched})
+		i += matched
+	}
+	return words
+}
+
+// Segment 中文分词：词典词整体切出，其余汉字逐字切分，字母数字按整词切分
+func Segment(s string) []string {
+	runes := normalize(s)
+	original := []rune(s)
+	var out []string
+	for i := 0; i < len(runes); {
+		switch {
+		case hanzi.IsHan(runes[i]):
+			j := i
+			for j < len(runes) && hanzi.IsHan(runes[j]) {
+				j++
+			}
+			pos := i
+			for _, w := range segment(runes[i:j]) {
+				for ; pos < i+w.start; pos++ {
+					out = append(out, string(original[pos]))
+				}
+				out = append(out, string(original[i+w.start:i+w.end]))
+				pos = i + w.end
+			}
+			for ; pos < j; pos++ {
+				out = append(out, string(original[pos]))
+			}
+			i = j
+		case isWordRune(runes[i]):
+			j := i
+			for j < len(runes) && isWordRune(runes[j]) {
+				j++
+			}
+			out = append(out, string(runes[i:j]))
+			i = j
+		default:
+			i++
+		}
+	}
+	return out
+}
+
+// alternative 查询子句的一种匹配方式
+type alternative struct {
+	term   string
+	weight float64
+	prefix bool
+}
+
+// clause 查询子句，任一方式命中即满足；文档需满足全部子句
+type clause struct {
+	alternatives []alternative
+}
+
+// parsedQuery 解析后的查询
+type parsedQuery struct {
+	clauses []clause
+	bonus   []string // 不要求命中，命中时加分（查询中的词典词）
+}
+
+// parseQuery 解析查询：汉字按二元组逐一要求命中（单字查询按单字），
+// 字母数字词可匹配原文、姓名拼音、拼音首字母及其前缀
+func parseQuery(q string) parsedQuery {
+	runes := normalize(q)
+	var pq parsedQuery
+	seen := make(map[string]bool)
+	add := func(c clause) {
+		key := c.alternatives[0].term
+		if !seen[key] {
+			seen[key] = true
+			pq.clauses = append(pq.clauses, c)
+		}
+	}
+
+	for i := 0; i < len(runes); {
+		switch {
+		case hanzi.IsHan(runes[i]):
+			j := i
+			for j < len(runes) && hanzi.IsHan(runes[j]) {
+				j++
+			}
+			if j-i == 1 {
+				add(clause{[]alternative{{term: string(runes[i]), weight: 1}}})
+			}
+			for k := i; k+1 < j; k++ {
+				add(clause{[]alternative{{term: string(runes[k : k+2]), weight: 1}}})
+			}
+			for _, w := range segment(runes[i:j]) {
+				pq.bonus = append(pq.bonus, wordPrefix+w.term)
+			}
+			i = j
+		case isWordRune(runes[i]):
+			j := i
+			for j < len(runes) && isWordRune(runes[j]) {
+				j++
+			}
+			add(wordClause(string(runes[i:j])))
+			i = j
+		default:
+			i++
+		}
+	}
+	return pq
+}
+
+// wordClause 字母数字查询词的匹配方式
+func wordClause(w string) clause {
+	c := clause{[]alternative{
+		{term: w, weight: 1},
+		{term: pinyinPrefix + w, weight: 1},
+		{term: syllablePrefix + w, weight: 1},
+		{term: initialsPrefix + w, weight: 0.9},
+	}}
+	if len(w) >= 2 {
+		c.alternatives = append(c.alternatives,
+			alternative{term: pinyinPrefix + w, weight: 0.8, prefix: true},
+			alternative{term: w, weight: 0.6, prefix: true},
+		)
+	}
+	return c
+}
@@ -0,0 +1,55 @@
+package search
+
+import (
+	"strings"
+	"sync"
+)
+
+var (
+	dictOnce  sync.Once
+	dictWords map[string]struct{}
+)
+
+// dictionary 内置词典，收录寻人案件描述中的常用词（衣着、颜色、体貌、地点、人物关系等）
+func dictionary() map[string]struct{} {
+	dictOnce.Do(func() {
+		dictWords = make(map[string]struct{}, 512)
+		for _, w := range strings.Fields(builtinDict) {
+			dictWords[w] = struct{}{}
+		}
+	})
+	return dictWords
+}
+
+// builtinDict 内置词表，空白分隔
+const builtinDict = `
+红色 黑色 白色 灰色 蓝色 深蓝色 浅蓝色 天蓝色 藏青色 绿色 军绿色 墨绿色 黄色 土黄色 紫色 粉色 粉红色
+棕色 咖啡色 卡其色 米色 米白色 橙色 银色 金色 花色 格子 条纹 碎花 迷彩
+
+外套 上衣 衬衫 衬衣 毛衣 卫衣 背心 马甲 夹克 风衣 大衣 棉衣 棉袄 羽绒服 西装 西服 中山装 唐装 运动服 校服
+睡衣 连衣裙 裙子 短裙 长裙 裤子 长裤 短裤 牛仔裤 运动裤 休闲裤 西裤 棉裤 秋裤 打底裤
+鞋子 皮鞋 布鞋 运动鞋 球鞋 拖鞋 凉鞋 棉鞋 靴子 雨靴 解放鞋 袜子
+帽子 鸭舌帽 毛线帽 围巾 手套 口罩 眼镜 墨镜 老花镜 手表 手环 项链 耳环 戒指 手镯 拐杖 轮椅
+书包 背包 挎包 手提包 行李箱 编织袋 塑料袋 雨伞 手机 钱包 身份证 户口本
+
+身高 体重 偏瘦 偏胖 瘦弱 微胖 肥胖 中等身材 身材 体型 驼背 弯腰
+短发 长发 卷发 寸头 光头 秃顶 白发 花白 黑发 染发 辫子 马尾 刘海 胡子 胡须 络腮胡
+脸型 圆脸 瓜子脸 方脸 长脸 皮肤 肤色 黝黑 白皙
+眉毛 眼睛 双眼皮 单眼皮 大眼睛 耳朵 鼻子 嘴巴 嘴唇 牙齿 缺牙 假牙 下巴 额头 脸部 脖子 肩膀
+手臂 胳膊 手指 左手 右手 左腿 右腿 左脚 右脚 左脸 右脸 后背 胸口 腹部
+胎记 疤痕 伤疤 刀疤 烫伤 纹身 黑痣 痣 雀斑 酒窝 六指 兔唇 斜视 近视
+口音 方言 普通话 说话 口吃 结巴 不会说话 聋哑 听力 失聪 视力 失明
+智力 智障 痴呆 老年痴呆 阿尔茨海默 阿尔茨海默病 精神病 精神障碍 抑郁症 自闭症 癫痫 糖尿病 高血压 残疾 瘫痪 跛脚 腿脚不便 行动不便
+记忆力 记不清 神志不清 意识不清 反应迟钝 走路 步态
+
+老人 老年人 老太太 老大爷 儿童 小孩 孩子 婴儿 幼儿 少年 青年 中年 男孩 女孩 男子 女子 男性 女性
+爷爷 奶奶 外公 外婆 姥姥 姥爷 父亲 母亲 爸爸 妈妈 儿子 女儿 孙子 孙女 外孙 外孙女 丈夫 妻子 哥哥 姐姐 弟弟 妹妹
+亲属 家属 家人 邻居 同事 同学 朋友
+
+火车站 高铁站 汽车站 客运站 地铁站 公交站 公交车 出租车 地铁 火车 高铁 长途汽车 机场 码头 服务区 高速公路
+医院 诊所 卫生院 养老院 敬老院 福利院 救助站 派出所 学校 幼儿园 小学 中学 初中 高中 大学
+商场 超市 菜市场 市场 农贸市场 集市 公园 广场 景区 寺庙 网吧 宾馆 酒店 饭店 餐馆 工地 工厂 厂区 桥洞 河边 江边 湖边 山上 树林 田地 农田
+小区 社区 街道 路口 十字路口 红绿灯 天桥 地下通道 人行道 胡同 巷子 村口 村委会 乡镇 县城 城区 郊区 市区 附近 周边 对面 门口
+
+走失 失踪 失联 离家 出走 离家出走 迷路 走丢 拐卖 被拐 寻找 寻亲 找到 回家 下落不明 最后一次 出现 监控 视频 录像 目击 线索
+`
@@ -0,0 +1,109 @@
+package search
+
+import (
+	"html"
+	"sort"
+	"strings"
+)
+
+// defaultSnippetLength 默认摘要长度（字符数）
+const defaultSnippetLength = 60
+
+// highlight 生成文档各字段的高亮摘要（调用方持有读锁）
+func (ix *Index) highlight(id string, terms []string, opts Options) map[string]string {
+	entry := ix.docs[id]
+	if entry == nil {
+		return nil
+	}
+
+	spans := make([][]span, len(ix.fields))
+	for _, term := range terms {
+		p := ix.postings[term][id]
+		if p == nil {
+			continue
+		}
+		for _, s := range p.spans {
+			spans[s.field] = append(spans[s.field], s)
+		}
+	}
+
+	pre, post := opts.PreTag, opts.PostTag
+	if pre == "" && post == "" {
+		pre, post = "<em>", "</em>"
+	}
+	length := opts.SnippetLength
+	if length <= 0 {
+		length = defaultSnippetLength
+	}
+
+	out := make(map[string]string)
+	for fi, list := range spans {
+		if len(list) == 0 {
+			continue
+		}
+		out[ix.fields[fi].Name] = snippet(entry.texts[fi], mergeSpans(list), length, pre, post)
+	}
+	return out
+}
+
+// mergeSpans 排序并合并重叠或相邻的位置
+func mergeSpans(list []span) []span {
+	sort.Slice(list, func(i, j int) bool { return list[i].start < list[j].start })
+	merged := []span{list[0]}
+	for _, s := range list[1:] {
+		last := &merged[len(merged)-1]
+		if s.start <= last.end {
+			last.end = max(last.end, s.end)
+			continue
+		}
+		merged = append(merged, s)
+	}
+	return merged
+}
+
+// snippet 截取包含最多命中位置的窗口并加高亮标记
+func snippet(text []rune, spans []span, length int, pre, post string) string {
+	start, end := 0, len(text)
+	if len(text) > length {
+		// 以每个命中位置前留少量上文作为窗口起点，选取覆盖命中最多的窗口
+		lead := length / 6
+		bestCount := -1
+		for _, anchor := range spans {
+			ws := max(int(anchor.start)-lead, 0)
+			ws = min(ws, len(text)-length)
+			count := 0
+			for _, s := range spans {
+				if int(s.start) >= ws && int(s.end) <= ws+length {
+					count++
+				}
+			}
+			if count > bestCount {
+				bestCount = count
+				start = ws
+			}
+		}
+		end = start + length
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("…")
+	}
+	pos := start
+	for _, s := range spans {
+		ss, se := max(int(s.start), start), min(int(s.end), end)
+		if ss >= se {
+			continue
+		}
+		b.WriteString(html.EscapeString(string(text[pos:ss])))
+		b.WriteString(pre)
+		b.WriteString(html.EscapeString(string(text[ss:se])))
+		b.WriteString(post)
+		pos = se
+	}
+	b.WriteString(html.EscapeString(string(text[pos:end])))
+	if end < len(text) {
+		b.WriteString("…")
+	}
+	return b.String()
+}
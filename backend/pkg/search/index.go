@@ -0,0 +1,385 @@
+package search
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// BM25 参数
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// bonusWeight 查询中的词典词在文档中作为完整词出现时的加权
+const bonusWeight = 0.5
+
+// maxPrefixExpansions 单个前缀最多展开的词项数
+const maxPrefixExpansions = 64
+
+// Field 索引字段
+type Field struct {
+	Name   string
+	Boost  float64 // 字段权重，<= 0 时按 1 计算
+	Pinyin bool    // 是否生成拼音及首字母词项（适用于姓名等短字段）
+}
+
+// Document 索引文档
+type Document struct {
+	ID     string
+	Fields map[string]string
+	Time   time.Time // 相关度相同时按时间倒序
+}
+
+// Options 检索选项
+type Options struct {
+	Offset        int
+	Limit         int    // <= 0 时不限制
+	PreTag        string // 高亮开始标记，默认 <em>
+	PostTag       string // 高亮结束标记，默认 </em>
+	SnippetLength int    // 摘要长度（字符数），默认 60
+}
+
+// Hit 检索命中
+type Hit struct {
+	ID         string
+	Score      float64
+	Highlights map[string]string // 字段名 -> 带高亮标记的摘要（已做 HTML 转义）
+}
+
+// Result 检索结果
+type Result struct {
+	Total int
+	Hits  []Hit
+}
+
+// span 词项在字段原文中的位置
+type span struct {
+	field      int
+	start, end int32
+}
+
+// posting 词项在单个文档中的出现情况
+type posting struct {
+	tf    []int32 // 每个字段的词频
+	spans []span
+}
+
+// docEntry 已索引文档
+type docEntry struct {
+	doc     Document
+	texts   [][]rune // 各字段原文，用于生成摘要
+	lengths []int
+	terms   []string
+}
+
+// Index 内存倒排索引，可并发读写
+type Index struct {
+	mu       sync.RWMutex
+	fields   []Field
+	fieldIdx map[string]int
+	docs     map[string]*docEntry
+	postings map[string]map[string]*posting
+	totalLen []int64
+
+	// 字母开头词项的有序列表，用于前缀匹配，写入后延迟重建
+	sorted      []string
+	sortedDirty bool
+}
+
+// New 创建索引
+func New(fields ...Field) *Index {
+	ix := &Index{
+		fields:   fields,
+		fieldIdx: make(map[string]int, len(fields)),
+		docs:     make(map[string]*docEntry),
+		postings: make(map[string]map[string]*posting),
+		totalLen: make([]int64, len(fields)),
+	}
+	for i, f := range fields {
+		ix.fieldIdx[f.Name] = i
+	}
+	return ix
+}
+
+// Len 文档数
+func (ix *Index) Len() int {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	return len(ix.docs)
+}
+
+// Terms 词项数
+func (ix *Index) Terms() int {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	return len(ix.postings)
+}
+
+// Put 新增或替换文档
+func (ix *Index) Put(doc Document) {
+	entry := &docEntry{
+		doc:     doc,
+		texts:   make([][]rune, len(ix.fields)),
+		lengths: make([]int, len(ix.fields)),
+	}
+	termSet := make(map[string]*posting)
+	for fi, f := range ix.fields {
+		text := doc.Fields[f.Name]
+		if text == "" {
+			continue
+		}
+		entry.texts[fi] = []rune(text)
+		tokens, length := analyze(normalize(text), f.Pinyin)
+		entry.lengths[fi] = length
+		for _, t := range tokens {
+			p := termSet[t.term]
+			if p == nil {
+				p = &posting{tf: make([]int32, len(ix.fields))}
+				termSet[t.term] = p
+			}
+			p.tf[fi]++
+			p.spans = append(p.spans, span{fi, int32(t.start), int32(t.end)})
+		}
+	}
+	entry.terms = make([]string, 0, len(termSet))
+	for term := range termSet {
+		entry.terms = append(entry.terms, term)
+	}
+
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	ix.remove(doc.ID)
+	for term, p := range termSet {
+		list := ix.postings[term]
+		if list == nil {
+			list = make(map[string]*posting)
+			ix.postings[term] = list
+			if isPrefixable(term) {
+				ix.sortedDirty = true
+			}
+		}
+		list[doc.ID] = p
+	}
+	for fi, l := range entry.lengths {
+		ix.totalLen[fi] += int64(l)
+	}
+	ix.docs[doc.ID] = entry
+}
+
+// Delete 删除文档
+func (ix *Index) Delete(id string) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	ix.remove(id)
+}
+
+// remove 删除文档（调用方持有写锁）
+func (ix *Index) remove(id string) {
+	entry, ok := ix.docs[id]
+	if !ok {
+		return
+	}
+	for _, term := range entry.terms {
+		list := ix.postings[term]
+		delete(list, id)
+		if len(list) == 0 {
+			delete(ix.postings, term)
+			if isPrefixable(term) {
+				ix.sortedDirty = true
+			}
+		}
+	}
+	for fi, l := range entry.lengths {
+		ix.totalLen[fi] -= int64(l)
+	}
+	delete(ix.docs, id)
+}
+
+// Documents 全部文档（用于持久化快照）
+func (ix *Index) Documents() []Document {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	docs := make([]Document, 0, len(ix.docs))
+	for _, entry := range ix.docs {
+		docs = append(docs, entry.doc)
+	}
+	sort.Slice(docs, func(i, j int) bool { return docs[i].ID < docs[j].ID })
+	return docs
+}
+
+// Search 检索，文档需命中查询中的全部子句，按 BM25 相关度排序
+func (ix *Index) Search(query string, opts Options) Result {
+	pq := parseQuery(query)
+	if len(pq.clauses) == 0 {
+		return Result{}
+	}
+
+	ix.mu.RLock()
+	if ix.sortedDirty {
+		ix.mu.RUnlock()
+		ix.mu.Lock()
+		ix.rebuildSorted()
+		ix.mu.Unlock()
+		ix.mu.RLock()
+	}
+	defer ix.mu.RUnlock()
+
+	// 展开前缀并计算每个子句在各文档上的最高得分
+	expanded := make([][]alternative, len(pq.clauses))
+	scores := make(map[string]float64)
+	for ci, c := range pq.clauses {
+		expanded[ci] = ix.expand(c)
+		best := make(map[string]float64)
+		for _, alt := range expanded[ci] {
+			for id := range ix.postings[alt.term] {
+				if ci > 0 {
+					if _, ok := scores[id]; !ok {
+						continue
+					}
+				}
+				if s := ix.score(alt.term, id) * alt.weight; s > best[id] {
+					best[id] = s
+				}
+			}
+		}
+		if ci == 0 {
+			scores = best
+			continue
+		}
+		for id, s := range scores {
+			if b, ok := best[id]; ok {
+				scores[id] = s + b
+			} else {
+				delete(scores, id)
+			}
+		}
+		if len(scores) == 0 {
+			return Result{}
+		}
+	}
+	for _, term := range pq.bonus {
+		for id := range ix.postings[term] {
+			if s, ok := scores[id]; ok {
+				scores[id] = s + ix.score(term, id)*bonusWeight
+			}
+		}
+	}
+
+	hits := make([]Hit, 0, len(scores))
+	for id, s := range scores {
+		hits = append(hits, Hit{ID: id, Score: s})
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		ti, tj := ix.docs[hits[i].ID].doc.Time, ix.docs[hits[j].ID].doc.Time
+		if !ti.Equal(tj) {
+			return ti.After(tj)
+		}
+		return hits[i].ID < hits[j].ID
+	})
+
+	result := Result{Total: len(hits)}
+	start := min(max(opts.Offset, 0), len(hits))
+	end := len(hits)
+	if opts.Limit > 0 {
+		end = min(start+opts.Limit, len(hits))
+	}
+	result.Hits = hits[start:end]
+
+	terms := make([]string, 0, len(pq.bonus)+len(expanded))
+	for _, alts := range expanded {
+		for _, alt := range alts {
+			terms = append(terms, alt.term)
+		}
+	}
+	terms = append(terms, pq.bonus...)
+	for i := range result.Hits {
+		result.Hits[i].Highlights = ix.highlight(result.Hits[i].ID, terms, opts)
+	}
+	return result
+}
+
+// expand 展开子句中的前缀匹配
+func (ix *Index) expand(c clause) []alternative {
+	var out []alternative
+	for _, alt := range c.alternatives {
+		if !alt.prefix {
+			out = append(out, alt)
+			continue
+		}
+		namespaced := strings.Contains(alt.term, ":")
+		n := 0
+		for i := sort.SearchStrings(ix.sorted, alt.term); i < len(ix.sorted) && n < maxPrefixExpansions; i++ {
+			term := ix.sorted[i]
+			if !strings.HasPrefix(term, alt.term) {
+				break
+			}
+			if term == alt.term || (!namespaced && strings.Contains(term, ":")) {
+				continue
+			}
+			out = append(out, alternative{term: term, weight: alt.weight})
+			n++
+		}
+	}
+	return out
+}
+
+// score 词项在文档上的 BM25 得分（各字段按权重累加）
+func (ix *Index) score(term, id string) float64 {
+	list := ix.postings[term]
+	p := list[id]
+	if p == nil {
+		return 0
+	}
+	n := float64(len(ix.docs))
+	df := float64(len(list))
+	idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+
+	entry := ix.docs[id]
+	var total float64
+	for fi, tf := range p.tf {
+		if tf == 0 {
+			continue
+		}
+		avg := float64(ix.totalLen[fi]) / n
+		if avg <= 0 {
+			avg = 1
+		}
+		norm := 1 - bm25B + bm25B*float64(entry.lengths[fi])/avg
+		boost := ix.fields[fi].Boost
+		if boost <= 0 {
+			boost = 1
+		}
+		total += boost * float64(tf) * (bm25K1 + 1) / (float64(tf) + bm25K1*norm)
+	}
+	return idf * total
+}
+
+// rebuildSorted 重建前缀匹配用的有序词项列表（调用方持有写锁）
+func (ix *Index) rebuildSorted() {
+	if !ix.sortedDirty {
+		return
+	}
+	ix.sorted = ix.sorted[:0]
+	for term := range ix.postings {
+		if isPrefixable(term) {
+			ix.sorted = append(ix.sorted, term)
+		}
+	}
+	sort.Strings(ix.sorted)
+	ix.sortedDirty = false
+}
+
+// isPrefixable 可前缀匹配的词项：字母数字词及全拼
+func isPrefixable(term string) bool {
+	if strings.HasPrefix(term, pinyinPrefix) {
+		return true
+	}
+	return term != "" && term[0] < 0x80 && !strings.Contains(term, ":")
+}
@@ -0,0 +1,128 @@
+package search
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestIndex() *Index {
+	ix := New(
+		Field{Name: "name", Boost: 3, Pinyin: true},
+		Field{Name: "description"},
+		Field{Name: "clothes"},
+	)
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ix.Put(Document{ID: "1", Time: base, Fields: map[string]string{
+		"name":        "张伟",
+		"description": "老人患有阿尔茨海默病，说话带河南口音",
+		"clothes":     "深蓝色羽绒服，黑色棉裤",
+	}})
+	ix.Put(Document{ID: "2", Time: base.Add(time.Hour), Fields: map[string]string{
+		"name":    "李秀英",
+		"clothes": "红色外套，穿Nike运动鞋",
+	}})
+	ix.Put(Document{ID: "3", Time: base.Add(2 * time.Hour), Fields: map[string]string{
+		"name":        "王芳",
+		"description": "身上衣服有破洞，左脸有胎记",
+	}})
+	return ix
+}
+
+func ids(r Result) []string {
+	out := make([]string, len(r.Hits))
+	for i, h := range r.Hits {
+		out[i] = h.ID
+	}
+	return out
+}
+
+func TestSegment(t *testing.T) {
+	assert.Equal(t, []string{"深蓝色", "羽绒服", "黑色", "棉裤"}, Segment("深蓝色羽绒服，黑色棉裤"))
+	assert.Equal(t, []string{"身高", "170cm", "左脸", "有", "胎记"}, Segment("身高170CM，左脸有胎记"))
+	// 繁体按简体查词典，输出保留原文
+	assert.Equal(t, []string{"阿爾茨海默病"}, Segment("阿爾茨海默病"))
+}
+
+func TestSearchChinese(t *testing.T) {
+	ix := newTestIndex()
+
+	// 部分匹配
+	assert.Equal(t, []string{"2"}, ids(ix.Search("外套", Options{})))
+	assert.Equal(t, []string{"1"}, ids(ix.Search("羽绒", Options{})))
+	// 多个词需全部命中
+	assert.Equal(t, []string{"1"}, ids(ix.Search("河南 羽绒服", Options{})))
+	assert.Empty(t, ids(ix.Search("河南 外套", Options{})))
+	// 繁体查询
+	assert.Equal(t, []string{"1"}, ids(ix.Search("深藍色", Options{})))
+	// 单字查询
+	assert.ElementsMatch(t, []string{"1", "3"}, ids(ix.Search("有", Options{})))
+}
+
+func TestSearchPinyin(t *testing.T) {
+	ix := newTestIndex()
+
+	assert.Equal(t, []string{"1"}, ids(ix.Search("zhangwei", Options{})))
+	assert.Equal(t, []string{"1"}, ids(ix.Search("zw", Options{})))
+	assert.Equal(t, []string{"1"}, ids(ix.Search("zhangw", Options{})))
+	assert.Equal(t, []string{"2"}, ids(ix.Search("li xiu", Options{})))
+	assert.Equal(t, []string{"2"}, ids(ix.Search("NIK", Options{})))
+}
+
+func TestSearchRankingAndPaging(t *testing.T) {
+	ix := newTestIndex()
+	ix.Put(Document{ID: "4", Fields: map[string]string{"name": "张三", "description": "张伟的邻居"}})
+
+	r := ix.Search("张伟", Options{})
+	require.Equal(t, 2, r.Total)
+	// 姓名字段权重更高
+	assert.Equal(t, "1", r.Hits[0].ID)
+	assert.Greater(t, r.Hits[0].Score, r.Hits[1].Score)
+
+	r = ix.Search("张伟", Options{Offset: 1, Limit: 1})
+	assert.Equal(t, 2, r.Total)
+	assert.Equal(t, []string{"4"}, ids(r))
+
+	r = ix.Search("张伟", Options{Offset: 5, Limit: 1})
+	assert.Equal(t, 2, r.Total)
+	assert.Empty(t, r.Hits)
+}
+
+func TestSearchHighlight(t *testing.T) {
+	ix := newTestIndex()
+
+	r := ix.Search("胎记", Options{})
+	require.Len(t, r.Hits, 1)
+	assert.Equal(t, "身上衣服有破洞，左脸有<em>胎记</em>", r.Hits[0].Highlights["description"])
+
+	r = ix.Search("zw", Options{PreTag: "[", PostTag: "]"})
+	require.Len(t, r.Hits, 1)
+	assert.Equal(t, "[张伟]", r.Hits[0].Highlights["name"])
+
+	long := "前面是很长的一段无关描述内容用来测试摘要截取功能是否正常工作，" +
+		"走失时身穿灰色夹克，后面同样还有很长的一段无关的描述内容用来凑够长度"
+	ix.Put(Document{ID: "5", Fields: map[string]string{"description": long + "<b>"}})
+	r = ix.Search("灰色夹克", Options{SnippetLength: 20})
+	require.Len(t, r.Hits, 1)
+	h := r.Hits[0].Highlights["description"]
+	assert.Contains(t, h, "<em>灰色夹克</em>")
+	assert.True(t, len([]rune(h)) < 40)
+	assert.Equal(t, "…", string([]rune(h)[0]))
+}
+
+func TestPutReplaceAndDelete(t *testing.T) {
+	ix := newTestIndex()
+
+	ix.Put(Document{ID: "2", Fields: map[string]string{"name": "李秀英", "clothes": "灰色夹克"}})
+	assert.Empty(t, ids(ix.Search("外套", Options{})))
+	assert.Equal(t, []string{"2"}, ids(ix.Search("夹克", Options{})))
+	assert.Equal(t, 3, ix.Len())
+
+	ix.Delete("2")
+	assert.Empty(t, ids(ix.Search("夹克", Options{})))
+	assert.Empty(t, ids(ix.Search("lxy", Options{})))
+	assert.Equal(t, 2, ix.Len())
+	assert.Len(t, ix.Documents(), 2)
+}
@@ -0,0 +1,19 @@
+package sensitive
+
+import "strings"
+
+// Builtin 内置词典的匹配器，可再通过 Add、LoadFile 追加本地词表
+func Builtin() *Matcher {
+	return New(strings.Fields(builtinDict)...)
+}
+
+// builtinDict 内置敏感词，空白分隔。只收录辱骂、引流广告和诈骗中的常见说法，
+// 部署时按需通过本地词表补充
+const builtinDict = `
+傻逼 煞笔 傻叉 脑残 智障 贱人 贱货 婊子 他妈的 操你妈 草泥马 死全家 狗东西 王八蛋 滚犊子
+
+加微信 加个微信 加我微信 加vx 加v信 加qq 加扣扣 私聊我 扫码进群 进群领 免费领取 点击链接 兼职日结 刷单 返利
+代开发票 办证 贷款秒批 网贷 套现 博彩 六合彩 时时彩 百家乐 裸聊 约炮 色情 成人视频
+
+先交定金 先付定金 手续费到账 转账到 汇款到 付费寻人 有偿提供线索 包找到 内部渠道 公安内部 冒充警察
+`
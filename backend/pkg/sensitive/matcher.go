@@ -0,0 +1,209 @@
+// Package sensitive 提供基于本地词典的敏感词匹配（DFA），匹配时忽略大小写、全半角、繁简差异，
+// 以及夹在词中用于规避过滤的空白和符号（如“加 微 信”“加*微*信”）
+package sensitive
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Snowitty-Re/CNtunyuan/pkg/hanzi"
+)
+
+// maxSkip 词中最多允许夹杂的干扰字符数，避免跨越整句误判
+const maxSkip = 3
+
+type node struct {
+	children map[rune]*node
+	word     string // 不为空表示到此为一个完整敏感词
+}
+
+// Match 一次命中
+type Match struct {
+	Word  string // 词典中的敏感词
+	Start int    // 原文中的起始字节偏移
+	End   int    // 原文中的结束字节偏移（不含）
+}
+
+// Matcher 敏感词匹配器，可并发使用
+type Matcher struct {
+	mu   sync.RWMutex
+	root *node
+	size int
+}
+
+// New 创建匹配器
+func New(words ...string) *Matcher {
+	m := &Matcher{root: &node{}}
+	m.Add(words...)
+	return m
+}
+
+// Add 添加敏感词
+func (m *Matcher) Add(words ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, w := range words {
+		w = strings.TrimSpace(w)
+		cur := m.root
+		n := 0
+		for _, r := range w {
+			r = normalize(r)
+			if isNoise(r) {
+				continue
+			}
+			if cur.children == nil {
+				cur.children = make(map[rune]*node)
+			}
+			next, ok := cur.children[r]
+			if !ok {
+				next = &node{}
+				cur.children[r] = next
+			}
+			cur = next
+			n++
+		}
+		if n > 0 && cur.word == "" {
+			cur.word = w
+			m.size++
+		}
+	}
+}
+
+// Load 从词表读取敏感词：每行一个，忽略空行和 # 开头的注释
+func (m *Matcher) Load(r io.Reader) error {
+	var words []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		words = append(words, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	m.Add(words...)
+	return nil
+}
+
+// LoadFile 从词表文件读取敏感词
+func (m *Matcher) LoadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return m.Load(f)
+}
+
+// Len 敏感词数量
+func (m *Matcher) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.size
+}
+
+// FindAll 查找所有命中，同一位置取最长词，命中之间不重叠
+func (m *Matcher) FindAll(text string) []Match {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var matches []Match
+	for start := 0; start < len(text); {
+		r, size := utf8.DecodeRuneInString(text[start:])
+		if isNoise(normalize(r)) {
+			start += size
+			continue
+		}
+		if end, word := m.longest(text, start); word != "" {
+			matches = append(matches, Match{Word: word, Start: start, End: end})
+			start = end
+			continue
+		}
+		start += size
+	}
+	return matches
+}
+
+// longest 从 start 开始的最长命中，返回结束偏移和敏感词
+func (m *Matcher) longest(text string, start int) (int, string) {
+	cur := m.root
+	end, word := 0, ""
+	skipped := 0
+	for i := start; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		i += size
+		r = normalize(r)
+		if isNoise(r) {
+			skipped++
+			if skipped > maxSkip {
+				break
+			}
+			continue
+		}
+		next, ok := cur.children[r]
+		if !ok {
+			break
+		}
+		cur = next
+		skipped = 0
+		if cur.word != "" {
+			end, word = i, cur.word
+		}
+	}
+	return end, word
+}
+
+// Contains 是否包含敏感词
+func (m *Matcher) Contains(text string) bool {
+	return len(m.FindAll(text)) > 0
+}
+
+// Replace 将命中的敏感词（含夹杂的干扰字符）逐字替换为 mask，返回替换后的文本和命中的词（去重）
+func (m *Matcher) Replace(text string, mask rune) (string, []string) {
+	matches := m.FindAll(text)
+	if len(matches) == 0 {
+		return text, nil
+	}
+
+	var b strings.Builder
+	b.Grow(len(text))
+	seen := make(map[string]struct{}, len(matches))
+	var words []string
+	last := 0
+	for _, match := range matches {
+		b.WriteString(text[last:match.Start])
+		for range text[match.Start:match.End] {
+			b.WriteRune(mask)
+		}
+		last = match.End
+		if _, ok := seen[match.Word]; !ok {
+			seen[match.Word] = struct{}{}
+			words = append(words, match.Word)
+		}
+	}
+	b.WriteString(text[last:])
+	return b.String(), words
+}
+
+// normalize 统一大小写、全半角和繁简
+func normalize(r rune) rune {
+	if r == '　' {
+		return ' '
+	}
+	if r >= '！' && r <= '～' {
+		r -= 0xFEE0
+	}
+	return hanzi.SimplifyRune(unicode.ToLower(r))
+}
+
+// isNoise 是否为干扰字符：空白、标点和符号
+func isNoise(r rune) bool {
+	return unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r)
+}
@@ -0,0 +1,55 @@
+package sensitive
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindAll(t *testing.T) {
+	m := New("加微信", "加微信群", "刷单")
+
+	matches := m.FindAll("想了解的加微信群，不刷单")
+	if assert.Len(t, matches, 2) {
+		// 同一位置取最长词
+		assert.Equal(t, "加微信群", matches[0].Word)
+		assert.Equal(t, "刷单", matches[1].Word)
+	}
+	assert.Empty(t, m.FindAll("微信加了"))
+}
+
+func TestNormalize(t *testing.T) {
+	m := New("加vx", "傻逼")
+
+	// 大小写、全角、繁体和夹杂的符号
+	assert.True(t, m.Contains("加VX"))
+	assert.True(t, m.Contains("加ｖｘ"))
+	assert.True(t, m.Contains("傻 * 逼"))
+	assert.True(t, m.Contains("加　v-x"))
+	// 干扰字符过多不视为一个词
+	assert.False(t, m.Contains("傻了吧，这么逼真"))
+	assert.False(t, m.Contains("傻 。。。。 逼"))
+}
+
+func TestReplace(t *testing.T) {
+	m := New("刷单", "博彩")
+
+	text, words := m.Replace("刷 单返利，刷单、博彩", '*')
+	assert.Equal(t, "***返利，**、**", text)
+	assert.Equal(t, []string{"刷单", "博彩"}, words)
+
+	text, words = m.Replace("乡音未改", '*')
+	assert.Equal(t, "乡音未改", text)
+	assert.Nil(t, words)
+}
+
+func TestLoad(t *testing.T) {
+	m := New()
+	assert.NoError(t, m.Load(strings.NewReader("# 注释\n\n代办\n 刷单 \n刷单\n")))
+	assert.Equal(t, 2, m.Len())
+	assert.True(t, m.Contains("代办户口"))
+
+	assert.Greater(t, Builtin().Len(), 50)
+	assert.True(t, Builtin().Contains("先交定金再帮你找"))
+}
@@ -0,0 +1,45 @@
+package spreadsheet
+
+import (
+	"bytes"
+	"encoding/csv"
+	"unicode/utf8"
+)
+
+// utf8BOM Excel 另存为“CSV UTF-8”时写入的字节序标记
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// readCSV 读取 CSV，自动识别逗号、制表符、分号分隔
+func readCSV(data []byte) ([][]string, error) {
+	data = bytes.TrimPrefix(data, utf8BOM)
+	if !utf8.Valid(data) {
+		return nil, ErrInvalidEncoding
+	}
+
+	r := csv.NewReader(bytes.NewReader(data))
+	r.Comma = detectDelimiter(data)
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+
+	rows, err := r.ReadAll()
+	if err != nil {
+		return nil, ErrInvalidFile
+	}
+	return rows, nil
+}
+
+// detectDelimiter 以首行中出现次数最多的分隔符为准
+func detectDelimiter(data []byte) rune {
+	line := data
+	if i := bytes.IndexByte(data, '\n'); i >= 0 {
+		line = data[:i]
+	}
+
+	best, bestCount := ',', bytes.Count(line, []byte{','})
+	for _, d := range []rune{'\t', ';'} {
+		if n := bytes.Count(line, []byte(string(d))); n > bestCount {
+			best, bestCount = d, n
+		}
+	}
+	return best
+}
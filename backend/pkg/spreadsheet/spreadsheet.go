@@ -0,0 +1,118 @@
+// Package spreadsheet 读取表格文件（CSV、Excel xlsx）的第一个工作表，统一返回字符串二维表
+package spreadsheet
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("spreadsheet: unsupported format, use .csv or .xlsx")
+	ErrInvalidEncoding   = errors.New("spreadsheet: csv must be utf-8 encoded")
+	ErrInvalidFile       = errors.New("spreadsheet: invalid file")
+	ErrEmpty             = errors.New("spreadsheet: no rows")
+)
+
+// 文件格式
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+// Sheet 工作表，Rows 已去除末尾的空行，每行长度可能不同
+type Sheet struct {
+	Format string
+	Rows   [][]string
+}
+
+// Read 根据文件名和内容读取表格；.xls 等旧格式需先另存为 xlsx 或 csv
+func Read(name string, data []byte) (*Sheet, error) {
+	format := DetectFormat(name, data)
+
+	var rows [][]string
+	var err error
+	switch format {
+	case FormatCSV:
+		rows, err = readCSV(data)
+	case FormatXLSX:
+		rows, err = readXLSX(data)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows = trimRows(rows)
+	if len(rows) == 0 {
+		return nil, ErrEmpty
+	}
+	return &Sheet{Format: format, Rows: rows}, nil
+}
+
+// DetectFormat 根据扩展名和文件头判断格式
+func DetectFormat(name string, data []byte) string {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".xlsx":
+		return FormatXLSX
+	case ".csv", ".txt", ".tsv":
+		return FormatCSV
+	}
+	if bytes.HasPrefix(data, []byte("PK\x03\x04")) {
+		return FormatXLSX
+	}
+	return ""
+}
+
+// Header 表头（第一行）
+func (s *Sheet) Header() []string {
+	return s.Rows[0]
+}
+
+// Records 数据行（不含表头）
+func (s *Sheet) Records() [][]string {
+	return s.Rows[1:]
+}
+
+// Cell 取单元格，越界返回空串
+func Cell(row []string, i int) string {
+	if i < 0 || i >= len(row) {
+		return ""
+	}
+	return row[i]
+}
+
+// ExcelTime 将 Excel 日期序列号（1900 日期系统）转换为时间，按 loc 时区解释
+func ExcelTime(serial float64, loc *time.Location) time.Time {
+	// 1899-12-30 为序列号 0，已包含 Excel 将 1900 年视为闰年的偏差
+	base := time.Date(1899, 12, 30, 0, 0, 0, 0, loc)
+	days := int(serial)
+	secs := int((serial-float64(days))*86400 + 0.5)
+	return base.AddDate(0, 0, days).Add(time.Duration(secs) * time.Second)
+}
+
+// trimRows 去除单元格首尾空白以及末尾的空行
+func trimRows(rows [][]string) [][]string {
+	for _, row := range rows {
+		for i := range row {
+			row[i] = strings.TrimSpace(row[i])
+		}
+	}
+	for len(rows) > 0 && IsBlank(rows[len(rows)-1]) {
+		rows = rows[:len(rows)-1]
+	}
+	return rows
+}
+
+// IsBlank 是否为空行
+func IsBlank(row []string) bool {
+	for _, v := range row {
+		if v != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,87 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func buildXLSX(t *testing.T, parts map[string]string) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range parts {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func TestRead_XLSX(t *testing.T) {
+	data := buildXLSX(t, map[string]string{
+		"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="名单" sheetId="1" r:id="rId3"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId3" Type="worksheet" Target="worksheets/data.xml"/></Relationships>`,
+		"xl/sharedStrings.xml": `<sst><si><t>姓名</t></si><si><t>走失时间</t></si><si><r><t>张</t></r><r><t>三</t></r></si></sst>`,
+		"xl/worksheets/data.xml": `<worksheet><sheetData>
+<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c><c r="C1" t="inlineStr"><is><t>备注</t></is></c></row>
+<row r="2"><c r="A2" t="s"><v>2</v></c><c r="C2"><v>45413.5</v></c></row>
+<row r="4"><c r="B4" t="b"><v>1</v></c></row>
+<row r="5"></row>
+</sheetData></worksheet>`,
+	})
+
+	sheet, err := Read("cases.xlsx", data)
+	require.NoError(t, err)
+	assert.Equal(t, FormatXLSX, sheet.Format)
+	assert.Equal(t, []string{"姓名", "走失时间", "备注"}, sheet.Header())
+	require.Len(t, sheet.Records(), 3)
+	assert.Equal(t, []string{"张三", "", "45413.5"}, sheet.Records()[0])
+	assert.Empty(t, sheet.Records()[1])
+	assert.Equal(t, []string{"", "TRUE"}, sheet.Records()[2])
+}
+
+func TestRead_CSV(t *testing.T) {
+	data := append([]byte{0xEF, 0xBB, 0xBF}, []byte("姓名;性别;备注\n 李四 ;女;\"含;分号\"\n\n")...)
+	sheet, err := Read("feed.csv", data)
+	require.NoError(t, err)
+	assert.Equal(t, FormatCSV, sheet.Format)
+	assert.Equal(t, [][]string{{"姓名", "性别", "备注"}, {"李四", "女", "含;分号"}}, sheet.Rows)
+
+	// GBK 编码的“姓名”
+	_, err = Read("feed.csv", []byte{0xD0, 0xD5, 0xC3, 0xFB, '\n'})
+	assert.ErrorIs(t, err, ErrInvalidEncoding)
+}
+
+func TestRead_Errors(t *testing.T) {
+	_, err := Read("cases.xls", []byte{0xD0, 0xCF, 0x11, 0xE0})
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+
+	_, err = Read("cases.xlsx", []byte("PK\x03\x04broken"))
+	assert.ErrorIs(t, err, ErrInvalidFile)
+
+	_, err = Read("empty.csv", []byte("\n,,\n"))
+	assert.ErrorIs(t, err, ErrEmpty)
+}
+
+func TestExcelTime(t *testing.T) {
+	assert.Equal(t, time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC), ExcelTime(45413.5, time.UTC))
+	assert.Equal(t, time.Date(1900, 3, 1, 0, 0, 0, 0, time.UTC), ExcelTime(61, time.UTC))
+}
+
+func TestColumnIndex(t *testing.T) {
+	for ref, want := range map[string]int{"A1": 0, "Z9": 25, "AA10": 26, "XFD1": 16383} {
+		got, ok := columnIndex(ref)
+		assert.True(t, ok)
+		assert.Equal(t, want, got, ref)
+	}
+	_, ok := columnIndex("12")
+	assert.False(t, ok)
+}